@@ -0,0 +1,75 @@
+// gen-fixtures downloads the sample webhook payloads of the git providers,
+// scrubs them and writes them as the fixtures of pkg/test/payloads.
+//
+//	go run ./hack/gen-fixtures [-provider github]
+//	go run ./hack/gen-fixtures -provider gitea -event push -name push -delivery delivery.json
+//
+// The second form makes a fixture of a live delivery, the request body copied
+// from the recent deliveries of a webhook or a GitHub delivery as returned by
+// gh api repos/OWNER/REPO/hooks/HOOK_ID/deliveries/DELIVERY_ID.
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"net/http"
+	"os"
+	"time"
+
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/test/payloads"
+)
+
+func main() {
+	out := flag.String("out", "pkg/test/payloads/testdata", "directory of the fixtures")
+	provider := flag.String("provider", "", "only generate the fixtures of this provider")
+	delivery := flag.String("delivery", "", "file of a live delivery to make a fixture of")
+	event := flag.String("event", "", "event type header of the live delivery, like \"Merge Request Hook\"")
+	name := flag.String("name", "", "name of the fixture of the live delivery")
+	source := flag.String("source", "live delivery", "description of where the live delivery comes from")
+	flag.Parse()
+
+	if err := run(*out, *provider, *delivery, *event, *name, *source); err != nil {
+		fmt.Fprintf(os.Stderr, "gen-fixtures: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+func run(out, provider, delivery, event, name, source string) error {
+	if delivery != "" {
+		data, err := os.ReadFile(delivery)
+		if err != nil {
+			return err
+		}
+		fixture, err := payloads.FromDelivery(provider, name, event, source, data)
+		if err != nil {
+			return fmt.Errorf("%s: %w", delivery, err)
+		}
+		return write(out, fixture)
+	}
+
+	ctx := context.Background()
+	client := &http.Client{Timeout: 30 * time.Second}
+	for _, s := range payloads.Sources {
+		if provider != "" && s.Provider != provider {
+			continue
+		}
+		fixture, err := payloads.Fetch(ctx, client, s)
+		if err != nil {
+			return err
+		}
+		if err := write(out, fixture); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func write(out string, fixture payloads.Fixture) error {
+	file, err := payloads.Write(out, fixture)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("wrote %s\n", file)
+	return nil
+}
@@ -2,6 +2,7 @@ package acl
 
 import (
 	"fmt"
+	"slices"
 
 	"sigs.k8s.io/yaml"
 )
@@ -25,20 +26,30 @@ type aliasesConfig struct {
 // there. Support OWNERS simple configs (approvers, reviewers) and filters. When filters are used,
 // only match against the ".*" filter.
 func UserInOwnerFile(ownersContent, ownersAliasesContent, sender string) (bool, error) {
+	owners, err := Owners(ownersContent, ownersAliasesContent)
+	if err != nil {
+		return false, err
+	}
+	return slices.Contains(owners, sender), nil
+}
+
+// Owners parses the OWNERS and OWNERS_ALIASES files and returns the sorted
+// approvers and reviewers, with their aliases expanded.
+func Owners(ownersContent, ownersAliasesContent string) ([]string, error) {
 	sc := simpleConfig{}
 	fc := filtersConfig{}
 	ac := aliasesConfig{}
 	err := yaml.Unmarshal([]byte(ownersContent), &sc)
 	if err != nil {
-		return false, fmt.Errorf("cannot parse OWNERS file Approvers and Reviewers: %w", err)
+		return nil, fmt.Errorf("cannot parse OWNERS file Approvers and Reviewers: %w", err)
 	}
 	err = yaml.Unmarshal([]byte(ownersContent), &fc)
 	if err != nil {
-		return false, fmt.Errorf("cannot parse OWNERS file Filters: %w", err)
+		return nil, fmt.Errorf("cannot parse OWNERS file Filters: %w", err)
 	}
 	err = yaml.Unmarshal([]byte(ownersAliasesContent), &ac)
 	if err != nil {
-		return false, fmt.Errorf("cannot parse OWNERS_ALIASES: %w", err)
+		return nil, fmt.Errorf("cannot parse OWNERS_ALIASES: %w", err)
 	}
 
 	var approvers, reviewers []string
@@ -52,12 +63,8 @@ func UserInOwnerFile(ownersContent, ownersAliasesContent, sender string) (bool,
 		}
 	}
 	owners := expandAliases(append(approvers, reviewers...), ac.Aliases)
-	for _, owner := range owners {
-		if owner == sender {
-			return true, nil
-		}
-	}
-	return false, nil
+	slices.Sort(owners)
+	return owners, nil
 }
 
 // Expand aliases into the list of owners removing the duplicates.
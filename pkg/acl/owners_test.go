@@ -206,3 +206,47 @@ func TestExpandAliases(t *testing.T) {
 		})
 	}
 }
+
+func TestOwners(t *testing.T) {
+	tests := []struct {
+		name                 string
+		ownersContent        string
+		ownersAliasesContent string
+		want                 []string
+		wantErr              bool
+	}{
+		{
+			name:          "approvers and reviewers sorted",
+			ownersContent: "---\n approvers:\n  - zed\n  - alice\n reviewers:\n  - bob\n",
+			want:          []string{"alice", "bob", "zed"},
+		},
+		{
+			name:                 "aliases expanded",
+			ownersContent:        "---\n approvers:\n  - maintainers\n",
+			ownersAliasesContent: "---\n aliases:\n  maintainers:\n  - bob\n  - alice",
+			want:                 []string{"alice", "bob"},
+		},
+		{
+			name:          "no owners",
+			ownersContent: "---\n",
+			want:          []string{},
+		},
+		{
+			name:          "bad owners file",
+			ownersContent: "bad",
+			wantErr:       true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Owners(tt.ownersContent, tt.ownersAliasesContent)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Owners() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !tt.wantErr && !slices.Equal(got, tt.want) {
+				t.Errorf("Owners() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -2,10 +2,55 @@ package acl
 
 import (
 	"regexp"
+	"strings"
+	"sync"
 )
 
 const OKToTestCommentRegexp = `(^|\n)\/ok-to-test(\r\n|\r|\n|$)`
 
+const okToTestCommand = "/ok-to-test"
+
+var (
+	okToTestMutex sync.RWMutex
+	// okToTestRegexp matches a comment with a /ok-to-test command or one of
+	// its aliases.
+	okToTestRegexp = OKToTestCommentRegexp
+	// okToTestLineRegex matches a line with only a /ok-to-test command or
+	// one of its aliases.
+	okToTestLineRegex = regexp.MustCompile(`(?m)^/ok-to-test\s*$`)
+)
+
+// SetOKToTestAliases sets the commands accepted in addition to /ok-to-test,
+// as configured in the global ConfigMap.
+func SetOKToTestAliases(aliases []string) {
+	commands := []string{regexp.QuoteMeta(okToTestCommand)}
+	for _, alias := range aliases {
+		commands = append(commands, regexp.QuoteMeta(alias))
+	}
+	alternation := strings.Join(commands, "|")
+
+	okToTestMutex.Lock()
+	defer okToTestMutex.Unlock()
+	okToTestRegexp = `(^|\n)(` + alternation + `)(\r\n|\r|\n|$)`
+	okToTestLineRegex = regexp.MustCompile(`(?m)^(` + alternation + `)\s*$`)
+}
+
+// OKToTestRegexp returns the regexp matching the comments with a /ok-to-test
+// command or one of its aliases.
+func OKToTestRegexp() string {
+	okToTestMutex.RLock()
+	defer okToTestMutex.RUnlock()
+	return okToTestRegexp
+}
+
+// IsOKToTestComment returns whether a line of the comment is only a
+// /ok-to-test command or one of its aliases.
+func IsOKToTestComment(comment string) bool {
+	okToTestMutex.RLock()
+	defer okToTestMutex.RUnlock()
+	return okToTestLineRegex.MatchString(comment)
+}
+
 // MatchRegexp Match a regexp to a string.
 func MatchRegexp(reg, comment string) bool {
 	re := regexp.MustCompile(reg)
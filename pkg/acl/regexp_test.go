@@ -46,6 +46,54 @@ func TestRegexp(t *testing.T) {
 	}
 }
 
+func TestOKToTestAliases(t *testing.T) {
+	SetOKToTestAliases([]string{"/lgtm-test", "/prueba-ok", "/テスト"})
+	defer SetOKToTestAliases(nil)
+
+	tests := []struct {
+		name    string
+		comment string
+		matched bool
+	}{
+		{
+			name:    "default command",
+			comment: "/ok-to-test",
+			matched: true,
+		},
+		{
+			name:    "alias",
+			comment: "hello\n/lgtm-test\n",
+			matched: true,
+		},
+		{
+			name:    "localized alias",
+			comment: "/テスト",
+			matched: true,
+		},
+		{
+			name:    "alias with windows line ending",
+			comment: "/prueba-ok\r\nthanks",
+			matched: true,
+		},
+		{
+			name:    "alias not on its own line",
+			comment: "please /lgtm-test",
+			matched: false,
+		},
+		{
+			name:    "alias prefix",
+			comment: "/lgtm-testing",
+			matched: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, IsOKToTestComment(tt.comment), tt.matched)
+			assert.Equal(t, MatchRegexp(OKToTestRegexp(), tt.comment), tt.matched)
+		})
+	}
+}
+
 func TestMatchRegexp(t *testing.T) {
 	type args struct {
 		reg     string
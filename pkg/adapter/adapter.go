@@ -3,17 +3,21 @@ package adapter
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
-	"io"
 	"net/http"
 	"os"
 	"strings"
+	"sync/atomic"
 	"time"
 
 	cloudevents "github.com/cloudevents/sdk-go/v2"
+	"github.com/jonboulle/clockwork"
 	"github.com/openshift-pipelines/pipelines-as-code/pkg/apis/pipelinesascode/v1alpha1"
 	"github.com/openshift-pipelines/pipelines-as-code/pkg/kubeinteraction"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/metrics"
 	"github.com/openshift-pipelines/pipelines-as-code/pkg/params"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/params/clients"
 	"github.com/openshift-pipelines/pipelines-as-code/pkg/params/info"
 	"github.com/openshift-pipelines/pipelines-as-code/pkg/params/version"
 	"github.com/openshift-pipelines/pipelines-as-code/pkg/provider"
@@ -49,10 +53,15 @@ func NewEnvConfig() adapter.EnvConfigAccessor {
 }
 
 type listener struct {
-	run    *params.Run
-	kint   kubeinteraction.Interface
-	logger *zap.SugaredLogger
-	event  *info.Event
+	run        *params.Run
+	kint       kubeinteraction.Interface
+	logger     *zap.SugaredLogger
+	event      *info.Event
+	deliveries *deliveryCache
+	// deliveryLog records the last webhook deliveries for the dashboard.
+	deliveryLog *deliveryLog
+	drainer     *drainer
+	events      *eventPool
 }
 
 type Response struct {
@@ -65,9 +74,13 @@ var _ adapter.Adapter = (*listener)(nil)
 func New(run *params.Run, k *kubeinteraction.Interaction) adapter.AdapterConstructor {
 	return func(ctx context.Context, _ adapter.EnvConfigAccessor, _ cloudevents.Client) adapter.Adapter {
 		return &listener{
-			logger: logging.FromContext(ctx),
-			run:    run,
-			kint:   k,
+			logger:      logging.FromContext(ctx),
+			run:         run,
+			kint:        k,
+			deliveries:  newDeliveryCache(clockwork.NewRealClock()),
+			deliveryLog: newDeliveryLog(clockwork.NewRealClock(), maxDeliveryRecords),
+			drainer:     newDrainer(ctx),
+			events:      newEventPool(logging.FromContext(ctx)),
 		}
 	}
 }
@@ -82,6 +95,19 @@ func (l *listener) Start(ctx context.Context) error {
 	// Start pac config syncer
 	go params.StartConfigSync(ctx, l.run)
 
+	// the repositories are looked up with the List API until the cache of
+	// the index has been filled
+	index, err := clients.NewRepositoryIndex(l.run.Clients.PipelineAsCode)
+	if err != nil {
+		return err
+	}
+	l.run.Clients.RepositoryIndex = index
+	go func() {
+		if err := index.Start(ctx); err != nil {
+			l.logger.Errorf("cannot start the repository index: %v", err)
+		}
+	}()
+
 	l.logger.Infof("Starting Pipelines as Code version: %s", strings.TrimSpace(version.Version))
 	mux := http.NewServeMux()
 
@@ -91,7 +117,10 @@ func (l *listener) Start(ctx context.Context) error {
 		_, _ = fmt.Fprint(w, "ok")
 	})
 
-	mux.HandleFunc("/", l.handleEvent(ctx))
+	mux.HandleFunc(historyAPIPattern, l.handleHistory(ctx))
+	mux.HandleFunc(dashboardPattern, l.handleDashboard(ctx))
+	webhookHandler := l.recordDeliveries(l.handleEvent(ctx))
+	mux.HandleFunc("/", l.fromCloudEvents(webhookHandler))
 
 	srv := &http.Server{
 		Addr: ":" + adapterPort,
@@ -102,17 +131,37 @@ func (l *listener) Start(ctx context.Context) error {
 		IdleTimeout:       30 * time.Second,
 	}
 
-	enabled, tlsCertFile, tlsKeyFile := l.isTLSEnabled()
-	if enabled {
-		if err := srv.ListenAndServeTLS(tlsCertFile, tlsKeyFile); err != nil {
-			return err
+	go func() {
+		// the pending events are stored as webhooks, whatever the event
+		// source
+		if err := l.replayPendingEvents(ctx, webhookHandler); err != nil {
+			l.logger.Errorf("cannot replay the events pending from the last shutdown: %v", err)
 		}
-	} else {
-		if err := srv.ListenAndServe(); err != nil {
-			return err
+	}()
+
+	// the events can also be pulled from a queue when the webhooks cannot
+	// reach the cluster
+	go l.pollSQS(ctx, newSQSClient, webhookHandler)
+	// or synthesized from the heads found on the git providers of the
+	// repositories with polling set
+	go l.pollRepositories(ctx)
+
+	serveErr := make(chan error, 1)
+	go func() {
+		enabled, tlsCertFile, tlsKeyFile := l.isTLSEnabled()
+		if enabled {
+			serveErr <- srv.ListenAndServeTLS(tlsCertFile, tlsKeyFile)
+		} else {
+			serveErr <- srv.ListenAndServe()
 		}
+	}()
+
+	select {
+	case err := <-serveErr:
+		return err
+	case <-ctx.Done():
+		return l.shutdown(srv)
 	}
-	return nil
 }
 
 func (l listener) handleEvent(ctx context.Context) http.HandlerFunc {
@@ -122,28 +171,50 @@ func (l listener) handleEvent(ctx context.Context) http.HandlerFunc {
 			return
 		}
 
+		pacInfo := l.run.Info.GetPacOpts()
+
+		if reason, skipped := skippedEventType(&pacInfo, request.Header); skipped {
+			l.logger.Debugf("skipping event: %s", reason)
+			l.writeResponse(response, http.StatusOK, "skipped event")
+			return
+		}
+
 		// event body
-		payload, err := io.ReadAll(request.Body)
+		payload, err := readPayload(response, request, int64(pacInfo.MaxPayloadSize))
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			l.logger.Errorf("event payload is bigger than the max-payload-size setting of %d bytes, rejecting it", maxBytesErr.Limit)
+			if recorder, err := metrics.NewRecorder(); err == nil {
+				if err := recorder.CountPayloadTooLarge(); err != nil {
+					l.logger.Errorf("cannot record the payload too large metric: %v", err)
+				}
+			}
+			l.writeResponse(response, http.StatusRequestEntityTooLarge, "payload too large")
+			return
+		}
 		if err != nil {
-			l.logger.Errorf("failed to read body : %v", err)
-			response.WriteHeader(http.StatusInternalServerError)
+			l.logger.Errorf("Invalid event body format format: %s", err)
+			response.WriteHeader(http.StatusBadRequest)
 			return
 		}
 
-		var event map[string]any
-		if string(payload) != "" {
-			if err := json.Unmarshal(payload, &event); err != nil {
-				l.logger.Errorf("Invalid event body format format: %s", err)
-				response.WriteHeader(http.StatusBadRequest)
-				return
-			}
+		eventID, accepted := l.drainer.add(pendingEvent{Header: request.Header.Clone(), Payload: payload})
+		if !accepted {
+			response.Header().Set("Retry-After", retryAfter)
+			l.writeResponse(response, http.StatusServiceUnavailable, "shutting down")
+			return
 		}
+		processing := false
+		defer func() {
+			if !processing {
+				l.drainer.done(eventID)
+			}
+		}()
 
 		var gitProvider provider.Interface
 		var logger *zap.SugaredLogger
 
 		l.event = info.NewEvent()
-		pacInfo := l.run.Info.GetPacOpts()
 
 		globalRepo, err := l.run.Clients.PipelineAsCode.PipelinesascodeV1alpha1().Repositories(l.run.Info.Kube.Namespace).Get(
 			ctx, l.run.Info.Controller.GlobalRepository, metav1.GetOptions{},
@@ -188,31 +259,99 @@ func (l listener) handleEvent(ctx context.Context) http.HandlerFunc {
 		}
 		gitProvider.SetPacInfo(&pacInfo)
 
+		var delivery string
+		if !isIncoming {
+			var duplicate bool
+			if delivery, duplicate = l.duplicateDelivery(request, pacInfo.DeduplicateEventsTTL, logger); duplicate {
+				l.writeResponse(response, http.StatusOK, "skipped duplicate delivery")
+				return
+			}
+		}
+
+		// the payload is only validated with the webhook secret of the
+		// Repository while the event is processed
+		var validated atomic.Bool
 		s := sinker{
-			run:        l.run,
-			vcx:        gitProvider,
-			kint:       l.kint,
-			event:      l.event,
-			logger:     logger,
-			payload:    payload,
-			pacInfo:    &pacInfo,
-			globalRepo: globalRepo,
+			run:              l.run,
+			vcx:              gitProvider,
+			kint:             l.kint,
+			event:            l.event,
+			logger:           logger,
+			payload:          payload,
+			pacInfo:          &pacInfo,
+			globalRepo:       globalRepo,
+			payloadValidated: func() { validated.Store(true) },
 		}
 
 		// clone the request to use it further
 		localRequest := request.Clone(request.Context())
 
-		go func() {
-			err := s.processEvent(ctx, localRequest)
+		// the event is processed by the workers of the provider with the
+		// context of the drainer, for it to complete when the controller is
+		// shutting down
+		providerName := gitProvider.GetConfig().Name
+		if providerName == "" {
+			// the GitHub provider only has a name once its client is set
+			providerName = "github"
+		}
+		// accepted before being submitted, for the job to be able to forget it
+		if delivery != "" {
+			l.deliveries.accept(delivery)
+		}
+		processing = l.events.submit(providerName, eventWorkers(&pacInfo, providerName), pacInfo.EventQueueSize, func() {
+			defer l.drainer.done(eventID)
+			if !l.drainer.start(eventID) {
+				// stored to be replayed on the next start
+				return
+			}
+			err := s.processEvent(l.drainer.ctx, localRequest)
 			if err != nil {
 				logger.Errorf("an error occurred: %v", err)
 			}
-		}()
+			if delivery != "" && !validated.Load() {
+				// anyone can send a request with the ID of a delivery,
+				// only the ones signed with the webhook secret are kept
+				l.deliveries.forget(delivery)
+			}
+		})
+		if !processing {
+			if delivery != "" {
+				// processed when it is delivered again
+				l.deliveries.forget(delivery)
+			}
+			logger.Warnf("the queue of the %s events is full, refusing the event", providerName)
+			response.Header().Set("Retry-After", retryAfter)
+			l.writeResponse(response, http.StatusServiceUnavailable, "too many events being processed")
+			return
+		}
 
 		l.writeResponse(response, http.StatusAccepted, "accepted")
 	}
 }
 
+// duplicateDelivery returns the ID of the delivery of the webhook to accept
+// once it is processed, and whether it has already been accepted in the last
+// deduplicate-events-ttl. The ID is empty when the deliveries are not
+// deduplicated.
+func (l listener) duplicateDelivery(request *http.Request, ttlSetting string, logger *zap.SugaredLogger) (string, bool) {
+	if l.deliveries == nil || ttlSetting == "" {
+		return "", false
+	}
+	ttl, err := time.ParseDuration(ttlSetting)
+	if err != nil || ttl <= 0 {
+		return "", false
+	}
+	id := deliveryID(request.Header)
+	if id == "" {
+		return "", false
+	}
+	if l.deliveries.seen(id, ttl) {
+		logger.Infof("skipping delivery %s which has already been received in the last %s", id, ttl)
+		return id, true
+	}
+	return id, false
+}
+
 func (l listener) processRes(processEvent bool, provider provider.Interface, logger *zap.SugaredLogger, skipReason string, err error) (provider.Interface, *zap.SugaredLogger, error) {
 	if processEvent {
 		provider.SetLogger(logger)
@@ -234,8 +373,7 @@ func (l listener) detectProvider(req *http.Request, reqBody string) (provider.In
 	log := *l.logger
 
 	// payload validation
-	var event map[string]any
-	if err := json.Unmarshal([]byte(reqBody), &event); err != nil {
+	if err := checkJSONObject(strings.NewReader(reqBody)); err != nil {
 		return nil, &log, fmt.Errorf("invalid event body format: %w", err)
 	}
 
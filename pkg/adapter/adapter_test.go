@@ -3,18 +3,24 @@ package adapter
 import (
 	"bytes"
 	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
 	"net/http"
 	"net/http/httptest"
 	"testing"
 
 	"github.com/google/go-github/v74/github"
+	"github.com/jonboulle/clockwork"
 	"github.com/openshift-pipelines/pipelines-as-code/pkg/apis/pipelinesascode/v1alpha1"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/consoleui"
 	"github.com/openshift-pipelines/pipelines-as-code/pkg/params"
 	"github.com/openshift-pipelines/pipelines-as-code/pkg/params/clients"
 	"github.com/openshift-pipelines/pipelines-as-code/pkg/params/info"
 	"github.com/openshift-pipelines/pipelines-as-code/pkg/params/settings"
 	testclient "github.com/openshift-pipelines/pipelines-as-code/pkg/test/clients"
+	kitesthelper "github.com/openshift-pipelines/pipelines-as-code/pkg/test/kubernetestint"
 	"github.com/openshift-pipelines/pipelines-as-code/pkg/test/logger"
 	testnewrepo "github.com/openshift-pipelines/pipelines-as-code/pkg/test/repository"
 	tektontest "github.com/openshift-pipelines/pipelines-as-code/pkg/test/tekton"
@@ -110,7 +116,9 @@ func TestHandleEvent(t *testing.T) {
 				},
 			},
 		},
-		logger: logger,
+		logger:  logger,
+		drainer: newDrainer(ctx),
+		events:  newEventPool(logger),
 	}
 	l.run.Clients.InitClients()
 	l.run.Info.InitInfo()
@@ -261,3 +269,154 @@ func TestWhichProvider(t *testing.T) {
 		})
 	}
 }
+
+// newFullQueueListener returns a listener deduplicating the deliveries, with
+// a full queue of github events having no worker to empty it.
+func newFullQueueListener(ctx context.Context, t *testing.T) (*listener, chan func()) {
+	t.Helper()
+	stdata, _ := testclient.SeedTestData(t, ctx, testclient.Data{})
+	logger, _ := logger.GetLogger()
+	l := &listener{
+		run: &params.Run{
+			Clients: clients.Clients{
+				PipelineAsCode: stdata.PipelineAsCode,
+				Log:            logger,
+				Kube:           stdata.Kube,
+			},
+			Info: info.Info{
+				Pac: &info.PacOpts{
+					Settings: settings.Settings{
+						DeduplicateEventsTTL: "1h",
+						EventQueueSize:       1,
+						SQSQueueURL:          "https://sqs.eu-west-1.amazonaws.com/123456789012/pac-events",
+						SQSMessageFormat:     settings.SQSMessageFormatWebhook,
+					},
+				},
+				Controller: &info.ControllerInfo{GlobalRepository: info.DefaultGlobalRepoName},
+				Kube:       &info.KubeOpts{Namespace: "pipelines-as-code"},
+			},
+		},
+		logger:     logger,
+		drainer:    newDrainer(ctx),
+		events:     newEventPool(logger),
+		deliveries: newDeliveryCache(clockwork.NewRealClock()),
+	}
+	queue := make(chan func(), 1)
+	queue <- func() {}
+	l.events.queues["github"] = queue
+	return l, queue
+}
+
+func TestHandleEventRetryRefusedDelivery(t *testing.T) {
+	ctx, _ := rtesting.SetupFakeContext(t)
+	l, queue := newFullQueueListener(ctx, t)
+
+	event, err := json.Marshal(github.PushEvent{Pusher: &github.CommitAuthor{Name: github.Ptr("user")}})
+	assert.NilError(t, err)
+	ts := httptest.NewServer(l.handleEvent(ctx))
+	defer ts.Close()
+	deliver := func() int {
+		req, err := http.NewRequestWithContext(context.Background(), http.MethodPost, ts.URL, bytes.NewReader(event))
+		assert.NilError(t, err)
+		req.Header.Set("X-Github-Event", "push")
+		req.Header.Set("X-Github-Delivery", "abcd")
+		resp, err := http.DefaultClient.Do(req)
+		assert.NilError(t, err)
+		defer resp.Body.Close()
+		return resp.StatusCode
+	}
+
+	assert.Equal(t, deliver(), http.StatusServiceUnavailable)
+	<-queue
+	// the retry of the refused delivery is processed
+	assert.Equal(t, deliver(), http.StatusAccepted)
+	assert.Equal(t, deliver(), http.StatusOK)
+}
+
+func TestHandleEventForgedDelivery(t *testing.T) {
+	ctx, _ := rtesting.SetupFakeContext(t)
+	apiServer := httptest.NewServer(http.NotFoundHandler())
+	defer apiServer.Close()
+	stdata, _ := testclient.SeedTestData(t, ctx, testclient.Data{
+		Repositories: []*v1alpha1.Repository{
+			{
+				ObjectMeta: metav1.ObjectMeta{Name: "repo", Namespace: "ns"},
+				Spec: v1alpha1.RepositorySpec{
+					URL: "https://github.com/owner/repo",
+					GitProvider: &v1alpha1.GitProvider{
+						URL:           apiServer.URL,
+						Secret:        &v1alpha1.Secret{Name: "repo-secret"},
+						WebhookSecret: &v1alpha1.Secret{Name: "repo-secret"},
+					},
+				},
+			},
+		},
+	})
+	logger, _ := logger.GetLogger()
+	l := &listener{
+		run: &params.Run{
+			Clients: clients.Clients{
+				PipelineAsCode: stdata.PipelineAsCode,
+				Log:            logger,
+				Kube:           stdata.Kube,
+			},
+			Info: info.Info{
+				Pac: &info.PacOpts{
+					Settings: settings.Settings{DeduplicateEventsTTL: "1h"},
+				},
+				Controller: &info.ControllerInfo{GlobalRepository: info.DefaultGlobalRepoName},
+				Kube:       &info.KubeOpts{Namespace: "pipelines-as-code"},
+			},
+		},
+		kint: &kitesthelper.KinterfaceTest{
+			GetSecretKeyResult: map[string]string{
+				"repo-secret/provider.token": "token",
+				"repo-secret/webhook.secret": "secret",
+			},
+		},
+		logger:     logger,
+		drainer:    newDrainer(ctx),
+		events:     newEventPool(logger),
+		deliveries: newDeliveryCache(clockwork.NewRealClock()),
+	}
+	l.run.Clients.SetConsoleUI(consoleui.FallBackConsole{})
+	// the events are processed by the test instead of workers
+	queue := make(chan func(), 1)
+	l.events.queues["github"] = queue
+
+	event, err := json.Marshal(github.PushEvent{
+		Ref:        github.Ptr("refs/heads/main"),
+		Pusher:     &github.CommitAuthor{Name: github.Ptr("user")},
+		HeadCommit: &github.HeadCommit{ID: github.Ptr("sha")},
+		Repo: &github.PushEventRepository{
+			HTMLURL:       github.Ptr("https://github.com/owner/repo"),
+			Name:          github.Ptr("repo"),
+			Owner:         &github.User{Login: github.Ptr("owner")},
+			DefaultBranch: github.Ptr("main"),
+		},
+		Sender: &github.User{Login: github.Ptr("user")},
+	})
+	assert.NilError(t, err)
+	ts := httptest.NewServer(l.handleEvent(ctx))
+	defer ts.Close()
+	deliver := func(webhookSecret string) int {
+		req, err := http.NewRequestWithContext(context.Background(), http.MethodPost, ts.URL, bytes.NewReader(event))
+		assert.NilError(t, err)
+		mac := hmac.New(sha256.New, []byte(webhookSecret))
+		mac.Write(event)
+		req.Header.Set("X-Github-Event", "push")
+		req.Header.Set("X-Github-Delivery", "abcd")
+		req.Header.Set(github.SHA256SignatureHeader, "sha256="+hex.EncodeToString(mac.Sum(nil)))
+		resp, err := http.DefaultClient.Do(req)
+		assert.NilError(t, err)
+		defer resp.Body.Close()
+		return resp.StatusCode
+	}
+
+	assert.Equal(t, deliver("forged"), http.StatusAccepted)
+	(<-queue)()
+	// the genuine delivery is not skipped by the forged one
+	assert.Equal(t, deliver("secret"), http.StatusAccepted)
+	(<-queue)()
+	assert.Equal(t, deliver("secret"), http.StatusOK)
+}
@@ -0,0 +1,86 @@
+package adapter
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+
+	cehttp "github.com/cloudevents/sdk-go/v2/protocol/http"
+	"github.com/cloudevents/sdk-go/v2/types"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/params/settings"
+)
+
+// cloudEventHeadersExtension is the extension of the CloudEvents with the
+// headers of the webhook of the git provider, as a JSON object, the brokers
+// only forward the attributes of the events.
+const cloudEventHeadersExtension = "webhookheaders"
+
+// discardBodyWriter only writes the status of the responses, the brokers
+// expect the response to a CloudEvent to be empty or a CloudEvent.
+type discardBodyWriter struct {
+	http.ResponseWriter
+}
+
+func (w discardBodyWriter) Write(b []byte) (int, error) {
+	return len(b), nil
+}
+
+// fromCloudEvents turns the CloudEvents wrapping the webhooks of the git
+// providers back into webhooks when the event-source setting is cloudevents,
+// i.e: delivered by a Knative broker from a central event gateway.
+func (l listener) fromCloudEvents(next http.HandlerFunc) http.HandlerFunc {
+	return func(response http.ResponseWriter, request *http.Request) {
+		pacInfo := l.run.Info.GetPacOpts()
+		if request.Method != http.MethodPost || pacInfo.EventSource != settings.EventSourceCloudEvents {
+			next(response, request)
+			return
+		}
+		if pacInfo.MaxPayloadSize > 0 {
+			request.Body = http.MaxBytesReader(response, request.Body, int64(pacInfo.MaxPayloadSize))
+		}
+		webhook, err := webhookFromCloudEvent(request)
+		if err != nil {
+			l.logger.Errorf("invalid cloudevent: %v", err)
+			response.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		next(discardBodyWriter{response}, webhook)
+	}
+}
+
+// webhookFromCloudEvent returns the webhook request of the git provider
+// wrapped in a CloudEvent, its data is the payload and its webhookheaders
+// extension the headers.
+func webhookFromCloudEvent(request *http.Request) (*http.Request, error) {
+	event, err := cehttp.NewEventFromHTTPRequest(request)
+	if err != nil {
+		return nil, fmt.Errorf("cannot read the cloudevent: %w", err)
+	}
+	value, ok := event.Extensions()[cloudEventHeadersExtension]
+	if !ok {
+		return nil, fmt.Errorf("cloudevent %s of type %s has no %s extension with the headers of the webhook", event.ID(), event.Type(), cloudEventHeadersExtension)
+	}
+	rawHeaders, err := types.ToString(value)
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s extension of cloudevent %s: %w", cloudEventHeadersExtension, event.ID(), err)
+	}
+	headers := map[string]string{}
+	if err := json.Unmarshal([]byte(rawHeaders), &headers); err != nil {
+		return nil, fmt.Errorf("invalid %s extension of cloudevent %s, it must be a json object: %w", cloudEventHeadersExtension, event.ID(), err)
+	}
+
+	webhook := request.Clone(request.Context())
+	webhook.Header = http.Header{}
+	for name, value := range headers {
+		webhook.Header.Set(name, value)
+	}
+	if webhook.Header.Get("Content-Type") == "" && event.DataContentType() != "" {
+		webhook.Header.Set("Content-Type", event.DataContentType())
+	}
+	data := event.Data()
+	webhook.Body = io.NopCloser(bytes.NewReader(data))
+	webhook.ContentLength = int64(len(data))
+	return webhook, nil
+}
@@ -0,0 +1,143 @@
+package adapter
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/params"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/params/info"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/params/settings"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/test/logger"
+	"gotest.tools/v3/assert"
+)
+
+func TestFromCloudEvents(t *testing.T) {
+	payload := `{"action": "opened", "number": 1}`
+	tests := []struct {
+		name        string
+		eventSource string
+		headers     map[string]string
+		body        string
+		wantStatus  int
+		wantHeaders map[string]string
+		wantPayload string
+		wantBody    string
+		wantLog     string
+	}{
+		{
+			name:        "binary cloudevent",
+			eventSource: settings.EventSourceCloudEvents,
+			headers: map[string]string{
+				"ce-specversion":    "1.0",
+				"ce-id":             "1",
+				"ce-source":         "https://gateway.example.com",
+				"ce-type":           "com.github.pull_request",
+				"ce-webhookheaders": `{"X-GitHub-Event": "pull_request", "X-Hub-Signature-256": "sha256=abc"}`,
+				"Content-Type":      "application/json",
+			},
+			body:       payload,
+			wantStatus: http.StatusAccepted,
+			wantHeaders: map[string]string{
+				"X-GitHub-Event":      "pull_request",
+				"X-Hub-Signature-256": "sha256=abc",
+				"Content-Type":        "application/json",
+			},
+			wantPayload: payload,
+		},
+		{
+			name:        "structured cloudevent",
+			eventSource: settings.EventSourceCloudEvents,
+			headers: map[string]string{
+				"Content-Type": "application/cloudevents+json",
+			},
+			body: `{"specversion": "1.0", "id": "1", "source": "https://gateway.example.com", "type": "com.gitlab.merge_request",
+"datacontenttype": "application/json", "webhookheaders": "{\"X-Gitlab-Event\": \"Merge Request Hook\"}",
+"data_base64": "eyJhY3Rpb24iOiAib3BlbmVkIiwgIm51bWJlciI6IDF9"}`,
+			wantStatus: http.StatusAccepted,
+			wantHeaders: map[string]string{
+				"X-Gitlab-Event": "Merge Request Hook",
+				"Content-Type":   "application/json",
+			},
+			wantPayload: payload,
+		},
+		{
+			name:        "no webhook headers",
+			eventSource: settings.EventSourceCloudEvents,
+			headers: map[string]string{
+				"ce-specversion": "1.0",
+				"ce-id":          "1",
+				"ce-source":      "https://gateway.example.com",
+				"ce-type":        "com.github.pull_request",
+			},
+			body:       payload,
+			wantStatus: http.StatusBadRequest,
+			wantLog:    "cloudevent 1 of type com.github.pull_request has no webhookheaders extension with the headers of the webhook",
+		},
+		{
+			name:        "not a cloudevent",
+			eventSource: settings.EventSourceCloudEvents,
+			headers: map[string]string{
+				"X-GitHub-Event": "pull_request",
+			},
+			body:       payload,
+			wantStatus: http.StatusBadRequest,
+			wantLog:    "invalid cloudevent: cannot read the cloudevent",
+		},
+		{
+			name:        "webhook",
+			eventSource: settings.EventSourceWebhook,
+			headers: map[string]string{
+				"X-GitHub-Event": "pull_request",
+			},
+			body:       payload,
+			wantStatus: http.StatusAccepted,
+			wantHeaders: map[string]string{
+				"X-GitHub-Event": "pull_request",
+			},
+			wantPayload: payload,
+			wantBody:    `{"status":202,"message":"accepted"}` + "\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			log, logCatcher := logger.GetLogger()
+			l := listener{
+				run: &params.Run{
+					Info: info.Info{Pac: &info.PacOpts{Settings: settings.Settings{EventSource: tt.eventSource, MaxPayloadSize: 1024}}},
+				},
+				logger: log,
+			}
+			var gotHeaders http.Header
+			gotPayload := ""
+			handler := l.fromCloudEvents(func(response http.ResponseWriter, request *http.Request) {
+				gotHeaders = request.Header
+				body, err := io.ReadAll(request.Body)
+				assert.NilError(t, err)
+				gotPayload = string(body)
+				l.writeResponse(response, http.StatusAccepted, "accepted")
+			})
+
+			req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(tt.body))
+			for name, value := range tt.headers {
+				req.Header.Set(name, value)
+			}
+			rec := httptest.NewRecorder()
+			handler(rec, req)
+
+			assert.Equal(t, rec.Code, tt.wantStatus)
+			assert.Equal(t, rec.Body.String(), tt.wantBody)
+			if tt.wantLog != "" {
+				assert.Assert(t, logCatcher.FilterMessageSnippet(tt.wantLog).Len() > 0, logCatcher.All())
+				return
+			}
+			assert.Equal(t, gotPayload, tt.wantPayload)
+			assert.Equal(t, len(gotHeaders), len(tt.wantHeaders))
+			for name, value := range tt.wantHeaders {
+				assert.Equal(t, gotHeaders.Get(name), value)
+			}
+		})
+	}
+}
@@ -0,0 +1,280 @@
+package adapter
+
+import (
+	"context"
+	"fmt"
+	"html/template"
+	"net/http"
+	"sort"
+	"time"
+
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/apis/pipelinesascode/keys"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/kubeinteraction"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const dashboardPattern = "GET /dashboard"
+
+// maxDashboardFailures is the number of recent failed runs shown on the
+// dashboard.
+const maxDashboardFailures = 20
+
+type dashboardRepository struct {
+	Namespace  string
+	Name       string
+	URL        string
+	LastStatus string
+	LastRun    *time.Time
+}
+
+type dashboardRun struct {
+	Namespace   string
+	Repository  string
+	PipelineRun string
+	Status      string
+	EventType   string
+	SHA         string
+	LogURL      string
+	Time        *time.Time
+}
+
+type dashboardDeliveries struct {
+	Total   int
+	Failed  int
+	Records []deliveryRecord
+}
+
+// dashboard is what a user is allowed to see on the dashboard.
+type dashboard struct {
+	User         string
+	Repositories []dashboardRepository
+	Running      []dashboardRun
+	Failures     []dashboardRun
+	// Deliveries is only shown to the users allowed to list the Repositories
+	// of all the namespaces.
+	Deliveries *dashboardDeliveries
+}
+
+var dashboardTemplate = template.Must(template.New("dashboard").Parse(`<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<meta http-equiv="refresh" content="30">
+<title>Pipelines-as-Code</title>
+<style>
+body { font-family: sans-serif; margin: 2em; }
+table { border-collapse: collapse; margin-bottom: 2em; }
+th, td { border: 1px solid #ccc; padding: 0.3em 0.6em; text-align: left; }
+th { background: #eee; }
+.failed { color: #c00; }
+</style>
+</head>
+<body>
+<h1>Pipelines-as-Code</h1>
+<p>Logged in as {{ .User }}</p>
+
+<h2>Repositories</h2>
+<table>
+<tr><th>Namespace</th><th>Name</th><th>URL</th><th>Last run</th><th>Status</th></tr>
+{{- range .Repositories }}
+<tr><td>{{ .Namespace }}</td><td>{{ .Name }}</td><td><a href="{{ .URL }}">{{ .URL }}</a></td><td>{{ with .LastRun }}{{ .Format "2006-01-02 15:04:05" }}{{ end }}</td><td>{{ .LastStatus }}</td></tr>
+{{- else }}
+<tr><td colspan="5">No Repositories</td></tr>
+{{- end }}
+</table>
+
+<h2>Queued and running</h2>
+<table>
+<tr><th>Repository</th><th>PipelineRun</th><th>State</th><th>Event</th><th>SHA</th><th>Created</th></tr>
+{{- range .Running }}
+<tr><td>{{ .Namespace }}/{{ .Repository }}</td><td>{{ if .LogURL }}<a href="{{ .LogURL }}">{{ .PipelineRun }}</a>{{ else }}{{ .PipelineRun }}{{ end }}</td><td>{{ .Status }}</td><td>{{ .EventType }}</td><td>{{ .SHA }}</td><td>{{ with .Time }}{{ .Format "2006-01-02 15:04:05" }}{{ end }}</td></tr>
+{{- else }}
+<tr><td colspan="6">No PipelineRuns queued or running</td></tr>
+{{- end }}
+</table>
+
+<h2>Recent failures</h2>
+<table>
+<tr><th>Repository</th><th>PipelineRun</th><th>Reason</th><th>Event</th><th>SHA</th><th>Completed</th></tr>
+{{- range .Failures }}
+<tr class="failed"><td>{{ .Namespace }}/{{ .Repository }}</td><td>{{ if .LogURL }}<a href="{{ .LogURL }}">{{ .PipelineRun }}</a>{{ else }}{{ .PipelineRun }}{{ end }}</td><td>{{ .Status }}</td><td>{{ .EventType }}</td><td>{{ .SHA }}</td><td>{{ with .Time }}{{ .Format "2006-01-02 15:04:05" }}{{ end }}</td></tr>
+{{- else }}
+<tr><td colspan="6">No recent failures</td></tr>
+{{- end }}
+</table>
+
+{{- with .Deliveries }}
+
+<h2>Webhook deliveries</h2>
+<p>{{ .Failed }} failed out of the last {{ .Total }} deliveries</p>
+<table>
+<tr><th>Received</th><th>Event</th><th>Delivery</th><th>Status</th><th>Message</th><th>Duration</th></tr>
+{{- range .Records }}
+<tr{{ if .Failed }} class="failed"{{ end }}><td>{{ .Time.Format "2006-01-02 15:04:05" }}</td><td>{{ .Event }}</td><td>{{ .ID }}</td><td>{{ .Status }}</td><td>{{ .Message }}</td><td>{{ .Duration }}</td></tr>
+{{- end }}
+</table>
+{{- end }}
+</body>
+</html>
+`))
+
+// buildDashboard returns the Repositories, the queued and running
+// PipelineRuns and the recent failures of the namespaces where user is
+// allowed to list the Repositories.
+func (l listener) buildDashboard(ctx context.Context, user *authenticationv1.UserInfo) (*dashboard, error) {
+	allowed := map[string]bool{}
+	isVisible := func(namespace string) (bool, error) {
+		if visible, ok := allowed[namespace]; ok {
+			return visible, nil
+		}
+		visible, err := l.isAllowed(ctx, user, "list", namespace, "")
+		if err != nil {
+			return false, err
+		}
+		allowed[namespace] = visible
+		return visible, nil
+	}
+
+	data := &dashboard{
+		User:         user.Username,
+		Repositories: []dashboardRepository{},
+		Running:      []dashboardRun{},
+		Failures:     []dashboardRun{},
+	}
+	repos, err := l.run.Clients.PipelineAsCode.PipelinesascodeV1alpha1().Repositories("").List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("cannot list repositories: %w", err)
+	}
+	for _, repo := range repos.Items {
+		visible, err := isVisible(repo.GetNamespace())
+		if err != nil {
+			return nil, err
+		}
+		if !visible {
+			continue
+		}
+		dr := dashboardRepository{Namespace: repo.GetNamespace(), Name: repo.GetName(), URL: repo.Spec.URL}
+		if len(repo.Status) > 0 {
+			last := newHistoryRun(repo.Status[len(repo.Status)-1])
+			dr.LastStatus = last.Status
+			dr.LastRun = last.StartTime
+		}
+		data.Repositories = append(data.Repositories, dr)
+
+		for _, rs := range repo.Status {
+			if len(rs.Conditions) == 0 || rs.Conditions[0].Status != corev1.ConditionFalse {
+				continue
+			}
+			run := newHistoryRun(rs)
+			data.Failures = append(data.Failures, dashboardRun{
+				Namespace:   repo.GetNamespace(),
+				Repository:  repo.GetName(),
+				PipelineRun: run.PipelineRunName,
+				Status:      run.Status,
+				EventType:   run.EventType,
+				SHA:         run.SHA,
+				LogURL:      run.LogURL,
+				Time:        run.CompletionTime,
+			})
+		}
+	}
+	sort.Slice(data.Repositories, func(i, j int) bool {
+		if data.Repositories[i].Namespace != data.Repositories[j].Namespace {
+			return data.Repositories[i].Namespace < data.Repositories[j].Namespace
+		}
+		return data.Repositories[i].Name < data.Repositories[j].Name
+	})
+	sort.SliceStable(data.Failures, func(i, j int) bool {
+		if data.Failures[i].Time == nil || data.Failures[j].Time == nil {
+			return data.Failures[j].Time == nil && data.Failures[i].Time != nil
+		}
+		return data.Failures[i].Time.After(*data.Failures[j].Time)
+	})
+	if len(data.Failures) > maxDashboardFailures {
+		data.Failures = data.Failures[:maxDashboardFailures]
+	}
+
+	prs, err := l.run.Clients.Tekton.TektonV1().PipelineRuns("").List(ctx, metav1.ListOptions{
+		LabelSelector: fmt.Sprintf("%s in (%s,%s)", keys.State, kubeinteraction.StateQueued, kubeinteraction.StateStarted),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("cannot list pipelineruns: %w", err)
+	}
+	for _, pr := range prs.Items {
+		visible, err := isVisible(pr.GetNamespace())
+		if err != nil {
+			return nil, err
+		}
+		if !visible {
+			continue
+		}
+		created := pr.GetCreationTimestamp().Time
+		data.Running = append(data.Running, dashboardRun{
+			Namespace:   pr.GetNamespace(),
+			Repository:  pr.GetLabels()[keys.Repository],
+			PipelineRun: pr.GetName(),
+			Status:      pr.GetLabels()[keys.State],
+			EventType:   pr.GetAnnotations()[keys.EventType],
+			SHA:         pr.GetAnnotations()[keys.SHA],
+			LogURL:      pr.GetAnnotations()[keys.LogURL],
+			Time:        &created,
+		})
+	}
+	sort.SliceStable(data.Running, func(i, j int) bool {
+		return data.Running[i].Time.Before(*data.Running[j].Time)
+	})
+
+	if l.deliveryLog != nil {
+		visible, err := isVisible("")
+		if err != nil {
+			return nil, err
+		}
+		if visible {
+			deliveries := &dashboardDeliveries{Records: l.deliveryLog.list()}
+			deliveries.Total = len(deliveries.Records)
+			for _, record := range deliveries.Records {
+				if record.Failed() {
+					deliveries.Failed++
+				}
+			}
+			data.Deliveries = deliveries
+		}
+	}
+	return data, nil
+}
+
+// handleDashboard serves a web page with the Repositories, the queued and
+// running PipelineRuns, the recent failures and the last webhook deliveries,
+// when the dashboard is enabled. The browsers are asked for a basic
+// authentication where the password is a Kubernetes token.
+func (l listener) handleDashboard(ctx context.Context) http.HandlerFunc {
+	return func(response http.ResponseWriter, request *http.Request) {
+		if !l.run.Info.GetPacOpts().EnableDashboard {
+			l.writeResponse(response, http.StatusNotFound, "dashboard is not enabled")
+			return
+		}
+		user, code, err := l.authenticate(ctx, request)
+		if err != nil {
+			l.logger.Infof("dashboard refused: %v", err)
+			if code == http.StatusUnauthorized {
+				response.Header().Set("WWW-Authenticate", `Basic realm="Pipelines-as-Code"`)
+			}
+			l.writeResponse(response, code, err.Error())
+			return
+		}
+
+		data, err := l.buildDashboard(ctx, user)
+		if err != nil {
+			l.logger.Errorf("cannot build the dashboard: %v", err)
+			l.writeResponse(response, http.StatusInternalServerError, "cannot build the dashboard")
+			return
+		}
+
+		response.Header().Set("Content-Type", "text/html; charset=utf-8")
+		if err := dashboardTemplate.Execute(response, data); err != nil {
+			l.logger.Errorf("failed to write dashboard response: %v", err)
+		}
+	}
+}
@@ -0,0 +1,167 @@
+package adapter
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jonboulle/clockwork"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/apis/pipelinesascode/keys"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/apis/pipelinesascode/v1alpha1"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/kubeinteraction"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/params"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/params/clients"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/params/info"
+	testclient "github.com/openshift-pipelines/pipelines-as-code/pkg/test/clients"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/test/logger"
+	tektonv1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+	"gotest.tools/v3/assert"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	ktesting "k8s.io/client-go/testing"
+	knativeapis "knative.dev/pkg/apis"
+	knativeduckv1 "knative.dev/pkg/apis/duck/v1"
+	rtesting "knative.dev/pkg/reconciler/testing"
+)
+
+func TestHandleDashboard(t *testing.T) {
+	completionTime := time.Date(2025, time.January, 2, 10, 0, 0, 0, time.UTC)
+	repos := []*v1alpha1.Repository{
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "repo", Namespace: "ns"},
+			Spec:       v1alpha1.RepositorySpec{URL: "https://github.com/owner/repo"},
+			Status: []v1alpha1.RepositoryRunStatus{{
+				Status: knativeduckv1.Status{
+					Conditions: []knativeapis.Condition{{Type: knativeapis.ConditionSucceeded, Status: corev1.ConditionFalse, Reason: "Failed"}},
+				},
+				PipelineRunName: "pr-failed",
+				CompletionTime:  &metav1.Time{Time: completionTime},
+			}},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "secret-repo", Namespace: "other"},
+			Spec:       v1alpha1.RepositorySpec{URL: "https://github.com/owner/secret-repo"},
+		},
+	}
+	prs := []*tektonv1.PipelineRun{
+		{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "pr-running",
+				Namespace: "ns",
+				Labels:    map[string]string{keys.Repository: "repo", keys.State: kubeinteraction.StateStarted},
+			},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "pr-completed",
+				Namespace: "ns",
+				Labels:    map[string]string{keys.Repository: "repo", keys.State: kubeinteraction.StateCompleted},
+			},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "pr-secret",
+				Namespace: "other",
+				Labels:    map[string]string{keys.Repository: "secret-repo", keys.State: kubeinteraction.StateQueued},
+			},
+		},
+	}
+
+	tests := []struct {
+		name            string
+		disabled        bool
+		basicAuth       bool
+		token           string
+		clusterWide     bool
+		wantCode        int
+		wantContains    []string
+		wantNotContains []string
+	}{
+		{
+			name:     "dashboard disabled",
+			disabled: true,
+			token:    "token",
+			wantCode: http.StatusNotFound,
+		},
+		{
+			name:     "no token",
+			wantCode: http.StatusUnauthorized,
+		},
+		{
+			name:         "namespace user",
+			token:        "token",
+			wantCode:     http.StatusOK,
+			wantContains: []string{"https://github.com/owner/repo", "pr-running", "pr-failed", "Failed"},
+			wantNotContains: []string{
+				"secret-repo", "pr-secret", "pr-completed", "Webhook deliveries",
+			},
+		},
+		{
+			name:         "cluster user with basic authentication",
+			basicAuth:    true,
+			token:        "token",
+			clusterWide:  true,
+			wantCode:     http.StatusOK,
+			wantContains: []string{"secret-repo", "pr-secret", "Webhook deliveries", "delivery-id"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, _ := rtesting.SetupFakeContext(t)
+			stdata, _ := testclient.SeedTestData(t, ctx, testclient.Data{Repositories: repos, PipelineRuns: prs})
+			stdata.Kube.PrependReactor("create", "tokenreviews", func(action ktesting.Action) (bool, runtime.Object, error) {
+				review, _ := action.(ktesting.CreateAction).GetObject().(*authenticationv1.TokenReview)
+				review.Status.Authenticated = review.Spec.Token == "token"
+				review.Status.User.Username = "user"
+				return true, review, nil
+			})
+			stdata.Kube.PrependReactor("create", "subjectaccessreviews", func(action ktesting.Action) (bool, runtime.Object, error) {
+				review, _ := action.(ktesting.CreateAction).GetObject().(*authorizationv1.SubjectAccessReview)
+				assert.Equal(t, review.Spec.ResourceAttributes.Verb, "list")
+				review.Status.Allowed = tt.clusterWide || review.Spec.ResourceAttributes.Namespace == "ns"
+				return true, review, nil
+			})
+
+			log, _ := logger.GetLogger()
+			run := params.New()
+			run.Clients = clients.Clients{
+				PipelineAsCode: stdata.PipelineAsCode,
+				Tekton:         stdata.Pipeline,
+				Kube:           stdata.Kube,
+			}
+			run.Info.Pac = info.NewPacOpts()
+			run.Info.Pac.EnableDashboard = !tt.disabled
+			l := listener{run: run, logger: log, deliveryLog: newDeliveryLog(clockwork.NewFakeClock(), maxDeliveryRecords)}
+			l.deliveryLog.add(deliveryRecord{ID: "X-GitHub-Delivery/delivery-id", Event: "push", Status: http.StatusAccepted})
+
+			mux := http.NewServeMux()
+			mux.HandleFunc(dashboardPattern, l.handleDashboard(ctx))
+			req := httptest.NewRequest(http.MethodGet, "/dashboard", nil)
+			if tt.token != "" {
+				if tt.basicAuth {
+					req.SetBasicAuth("user", tt.token)
+				} else {
+					req.Header.Set("Authorization", "Bearer "+tt.token)
+				}
+			}
+			rec := httptest.NewRecorder()
+			mux.ServeHTTP(rec, req)
+
+			assert.Equal(t, rec.Code, tt.wantCode)
+			if tt.wantCode == http.StatusUnauthorized {
+				assert.Assert(t, rec.Header().Get("WWW-Authenticate") != "")
+			}
+			for _, want := range tt.wantContains {
+				assert.Assert(t, strings.Contains(rec.Body.String(), want), "%s not in the dashboard", want)
+			}
+			for _, notWant := range tt.wantNotContains {
+				assert.Assert(t, !strings.Contains(rec.Body.String(), notWant), "%s in the dashboard", notWant)
+			}
+		})
+	}
+}
@@ -0,0 +1,72 @@
+package adapter
+
+import (
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/jonboulle/clockwork"
+)
+
+// deliveryIDHeaders are the headers the Git providers set with the ID of the
+// delivery of a webhook, which is kept when the webhook is redelivered.
+var deliveryIDHeaders = []string{
+	"X-GitHub-Delivery",
+	"X-Gitea-Delivery",
+	"Idempotency-Key",
+	"X-Gitlab-Event-UUID",
+	"X-Request-UUID",
+	"X-Request-Id",
+}
+
+// deliveryID returns the ID of the delivery of the event, prefixed by the
+// header it comes from, or an empty string when the provider doesn't send one.
+func deliveryID(header http.Header) string {
+	for _, name := range deliveryIDHeaders {
+		if id := header.Get(name); id != "" {
+			return name + "/" + id
+		}
+	}
+	return ""
+}
+
+// deliveryCache remembers the deliveries accepted during their TTL.
+type deliveryCache struct {
+	mu       sync.Mutex
+	clock    clockwork.Clock
+	accepted map[string]time.Time
+}
+
+func newDeliveryCache(clock clockwork.Clock) *deliveryCache {
+	return &deliveryCache{clock: clock, accepted: map[string]time.Time{}}
+}
+
+// seen returns whether the delivery has been accepted in the last ttl.
+func (c *deliveryCache) seen(id string, ttl time.Duration) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	now := c.clock.Now()
+	for key, accepted := range c.accepted {
+		if now.Sub(accepted) >= ttl {
+			delete(c.accepted, key)
+		}
+	}
+	_, ok := c.accepted[id]
+	return ok
+}
+
+// accept records the delivery before it is submitted for processing, it is
+// forgotten when it is refused or fails the validation of its payload.
+func (c *deliveryCache) accept(id string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.accepted[id] = c.clock.Now()
+}
+
+// forget removes the delivery, for a request failing the validation of its
+// payload not to have the genuine delivery with the same ID skipped.
+func (c *deliveryCache) forget(id string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(c.accepted, id)
+}
@@ -0,0 +1,73 @@
+package adapter
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/jonboulle/clockwork"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/test/logger"
+	"gotest.tools/v3/assert"
+)
+
+func TestDeliveryID(t *testing.T) {
+	tests := []struct {
+		name   string
+		header http.Header
+		want   string
+	}{
+		{
+			name:   "github",
+			header: http.Header{"X-Github-Delivery": {"abcd"}, "X-Github-Event": {"push"}},
+			want:   "X-GitHub-Delivery/abcd",
+		},
+		{
+			name:   "gitlab prefers the idempotency key over the request id",
+			header: http.Header{"Idempotency-Key": {"abcd"}, "X-Request-Id": {"efgh"}},
+			want:   "Idempotency-Key/abcd",
+		},
+		{
+			name:   "no delivery id",
+			header: http.Header{"X-Github-Event": {"push"}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, deliveryID(tt.header), tt.want)
+		})
+	}
+}
+
+func TestDuplicateDelivery(t *testing.T) {
+	log, logCatcher := logger.GetLogger()
+	clock := clockwork.NewFakeClock()
+	l := listener{logger: log, deliveries: newDeliveryCache(clock)}
+	request := func(id string) *http.Request {
+		return &http.Request{Header: http.Header{"X-Github-Delivery": {id}}}
+	}
+	duplicate := func(id, ttl string) bool {
+		_, duplicate := l.duplicateDelivery(request(id), ttl, log)
+		return duplicate
+	}
+
+	id, dup := l.duplicateDelivery(request("first"), "1h", log)
+	assert.Equal(t, id, "X-GitHub-Delivery/first")
+	assert.Assert(t, !dup)
+	// the delivery has been refused and is processed when retried
+	assert.Assert(t, !duplicate("first", "1h"))
+	l.deliveries.accept(id)
+	assert.Assert(t, duplicate("first", "1h"))
+	assert.Assert(t, logCatcher.FilterMessageSnippet("skipping delivery X-GitHub-Delivery/first which has already been received in the last 1h0m0s").Len() > 0, logCatcher.All())
+	assert.Assert(t, !duplicate("second", "1h"))
+
+	// deduplication disabled
+	id, dup = l.duplicateDelivery(request("first"), "", log)
+	assert.Equal(t, id, "")
+	assert.Assert(t, !dup)
+	assert.Assert(t, !duplicate("first", "0s"))
+
+	// the delivery is forgotten once the ttl has expired
+	clock.Advance(time.Hour)
+	assert.Assert(t, !duplicate("first", "1h"))
+	assert.Equal(t, len(l.deliveries.accepted), 0)
+}
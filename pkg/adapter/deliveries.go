@@ -0,0 +1,132 @@
+package adapter
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/jonboulle/clockwork"
+)
+
+// maxDeliveryRecords is the number of webhook deliveries remembered for the
+// dashboard.
+const maxDeliveryRecords = 50
+
+// eventTypeHeaders are the headers the Git providers set with the type of
+// the event of a webhook.
+var eventTypeHeaders = []string{
+	"X-GitHub-Event",
+	"X-Gitea-Event",
+	"X-Gitlab-Event",
+	"X-Event-Key",
+}
+
+// deliveryRecord is a webhook delivery received by the controller and the
+// response it got.
+type deliveryRecord struct {
+	Time     time.Time
+	ID       string
+	Event    string
+	Status   int
+	Message  string
+	Duration time.Duration
+}
+
+// Failed returns whether the delivery has been answered with an error.
+func (r deliveryRecord) Failed() bool {
+	return r.Status >= http.StatusBadRequest
+}
+
+// deliveryLog remembers the last webhook deliveries.
+type deliveryLog struct {
+	mu      sync.Mutex
+	clock   clockwork.Clock
+	size    int
+	records []deliveryRecord
+}
+
+func newDeliveryLog(clock clockwork.Clock, size int) *deliveryLog {
+	return &deliveryLog{clock: clock, size: size}
+}
+
+func (d *deliveryLog) add(record deliveryRecord) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.records = append(d.records, record)
+	if len(d.records) > d.size {
+		d.records = d.records[len(d.records)-d.size:]
+	}
+}
+
+// list returns the deliveries remembered, the most recent first.
+func (d *deliveryLog) list() []deliveryRecord {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	ret := make([]deliveryRecord, 0, len(d.records))
+	for i := len(d.records) - 1; i >= 0; i-- {
+		ret = append(ret, d.records[i])
+	}
+	return ret
+}
+
+// recordingWriter captures the status code and the beginning of the body of a
+// response.
+type recordingWriter struct {
+	http.ResponseWriter
+	status int
+	body   bytes.Buffer
+}
+
+func (w *recordingWriter) WriteHeader(status int) {
+	if w.status == 0 {
+		w.status = status
+	}
+	w.ResponseWriter.WriteHeader(status)
+}
+
+func (w *recordingWriter) Write(data []byte) (int, error) {
+	if w.status == 0 {
+		w.status = http.StatusOK
+	}
+	if w.body.Len() < 1024 {
+		w.body.Write(data[:min(len(data), 1024-w.body.Len())])
+	}
+	return w.ResponseWriter.Write(data)
+}
+
+// recordDeliveries records the webhook deliveries handled by next in the
+// delivery log of the listener.
+func (l listener) recordDeliveries(next http.HandlerFunc) http.HandlerFunc {
+	return func(response http.ResponseWriter, request *http.Request) {
+		if l.deliveryLog == nil || request.Method != http.MethodPost {
+			next(response, request)
+			return
+		}
+		start := l.deliveryLog.clock.Now()
+		writer := &recordingWriter{ResponseWriter: response}
+		next(writer, request)
+		if writer.status == 0 {
+			writer.status = http.StatusOK
+		}
+
+		record := deliveryRecord{
+			Time:     start,
+			ID:       deliveryID(request.Header),
+			Status:   writer.status,
+			Duration: l.deliveryLog.clock.Since(start),
+		}
+		for _, name := range eventTypeHeaders {
+			if event := request.Header.Get(name); event != "" {
+				record.Event = event
+				break
+			}
+		}
+		body := Response{}
+		if err := json.Unmarshal(writer.body.Bytes(), &body); err == nil {
+			record.Message = body.Message
+		}
+		l.deliveryLog.add(record)
+	}
+}
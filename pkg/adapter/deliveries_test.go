@@ -0,0 +1,55 @@
+package adapter
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jonboulle/clockwork"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/test/logger"
+	"gotest.tools/v3/assert"
+)
+
+func TestDeliveryLog(t *testing.T) {
+	deliveries := newDeliveryLog(clockwork.NewFakeClock(), 2)
+	deliveries.add(deliveryRecord{ID: "1"})
+	deliveries.add(deliveryRecord{ID: "2"})
+	deliveries.add(deliveryRecord{ID: "3"})
+	assert.DeepEqual(t, deliveries.list(), []deliveryRecord{{ID: "3"}, {ID: "2"}})
+}
+
+func TestRecordDeliveries(t *testing.T) {
+	log, _ := logger.GetLogger()
+	clock := clockwork.NewFakeClock()
+	l := listener{logger: log, deliveryLog: newDeliveryLog(clock, maxDeliveryRecords)}
+	handler := l.recordDeliveries(func(response http.ResponseWriter, request *http.Request) {
+		if request.Method != http.MethodPost {
+			l.writeResponse(response, http.StatusOK, "ok")
+			return
+		}
+		clock.Advance(time.Second)
+		l.writeResponse(response, http.StatusUnauthorized, "invalid signature")
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	handler(httptest.NewRecorder(), req)
+	assert.Equal(t, len(l.deliveryLog.list()), 0)
+
+	req = httptest.NewRequest(http.MethodPost, "/", strings.NewReader("{}"))
+	req.Header.Set("X-GitHub-Event", "pull_request")
+	req.Header.Set("X-GitHub-Delivery", "delivery-id")
+	rec := httptest.NewRecorder()
+	handler(rec, req)
+	assert.Equal(t, rec.Code, http.StatusUnauthorized)
+
+	records := l.deliveryLog.list()
+	assert.Equal(t, len(records), 1)
+	assert.Equal(t, records[0].ID, "X-GitHub-Delivery/delivery-id")
+	assert.Equal(t, records[0].Event, "pull_request")
+	assert.Equal(t, records[0].Status, http.StatusUnauthorized)
+	assert.Equal(t, records[0].Message, "invalid signature")
+	assert.Equal(t, records[0].Duration, time.Second)
+	assert.Assert(t, records[0].Failed())
+}
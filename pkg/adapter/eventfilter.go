@@ -0,0 +1,51 @@
+package adapter
+
+import (
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/params/info"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/params/settings"
+)
+
+// webhookEventType returns the git provider and the type of the event from
+// the headers of the webhook, Gitea also sends the GitHub header.
+func webhookEventType(header http.Header) (string, string) {
+	for _, h := range []struct{ provider, header string }{
+		{"gitea", "X-Gitea-Event-Type"},
+		{"github", "X-GitHub-Event"},
+		{"gitlab", "X-Gitlab-Event"},
+		{"bitbucket", "X-Event-Key"},
+	} {
+		if eventType := header.Get(h.header); eventType != "" {
+			return h.provider, eventType
+		}
+	}
+	return "", ""
+}
+
+// skippedEventType returns why the event has to be skipped according to the
+// event types allow and deny lists, without parsing its payload.
+func skippedEventType(pacInfo *info.PacOpts, header http.Header) (string, bool) {
+	provider, eventType := webhookEventType(header)
+	if provider == "" {
+		return "", false
+	}
+	matches := func(eventTypes []string) bool {
+		return slices.ContainsFunc(eventTypes, func(e string) bool { return strings.EqualFold(e, eventType) })
+	}
+	// the lists have been validated when they were loaded
+	if allowed, err := settings.ParseEventTypes(pacInfo.EventTypesAllowList); err == nil {
+		if len(allowed[provider]) > 0 && !matches(allowed[provider]) {
+			return fmt.Sprintf("%s event type %s is not in the event-types-allow-list setting", provider, eventType), true
+		}
+	}
+	if denied, err := settings.ParseEventTypes(pacInfo.EventTypesDenyList); err == nil {
+		if matches(denied[provider]) {
+			return fmt.Sprintf("%s event type %s is in the event-types-deny-list setting", provider, eventType), true
+		}
+	}
+	return "", false
+}
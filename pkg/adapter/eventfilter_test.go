@@ -0,0 +1,69 @@
+package adapter
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/params/info"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/params/settings"
+	"gotest.tools/v3/assert"
+)
+
+func TestSkippedEventType(t *testing.T) {
+	tests := []struct {
+		name       string
+		header     http.Header
+		allowList  string
+		denyList   string
+		wantReason string
+	}{
+		{
+			name:       "denied github event",
+			header:     http.Header{"X-Github-Event": []string{"star"}},
+			denyList:   "github:fork,github:star,github:watch",
+			wantReason: "github event type star is in the event-types-deny-list setting",
+		},
+		{
+			name:     "github event not denied",
+			header:   http.Header{"X-Github-Event": []string{"pull_request"}},
+			denyList: "github:fork,github:star,github:watch",
+		},
+		{
+			name:     "gitea event with the github header",
+			header:   http.Header{"X-Gitea-Event-Type": []string{"push"}, "X-Github-Event": []string{"star"}},
+			denyList: "github:star",
+		},
+		{
+			name:       "gitlab event not allowed",
+			header:     http.Header{"X-Gitlab-Event": []string{"Wiki Page Hook"}},
+			allowList:  "gitlab:Push Hook,gitlab:Merge Request Hook",
+			wantReason: "gitlab event type Wiki Page Hook is not in the event-types-allow-list setting",
+		},
+		{
+			name:      "gitlab event allowed",
+			header:    http.Header{"X-Gitlab-Event": []string{"merge request hook"}},
+			allowList: "gitlab:Push Hook,gitlab:Merge Request Hook",
+		},
+		{
+			name:      "allow list of another provider",
+			header:    http.Header{"X-Event-Key": []string{"repo:push"}},
+			allowList: "gitlab:Push Hook",
+		},
+		{
+			name:     "no event type",
+			header:   http.Header{},
+			denyList: "github:star",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pacInfo := &info.PacOpts{Settings: settings.Settings{
+				EventTypesAllowList: tt.allowList,
+				EventTypesDenyList:  tt.denyList,
+			}}
+			reason, skipped := skippedEventType(pacInfo, tt.header)
+			assert.Equal(t, skipped, tt.wantReason != "")
+			assert.Equal(t, reason, tt.wantReason)
+		})
+	}
+}
@@ -0,0 +1,186 @@
+package adapter
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/apis/pipelinesascode/v1alpha1"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/cli/status"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const historyAPIPattern = "GET /api/v1/namespaces/{namespace}/repositories/{name}/runs"
+
+// HistoryRun is a run of a Repository as returned by the run history API.
+type HistoryRun struct {
+	PipelineRunName         string     `json:"pipelineRunName"`
+	OriginalPipelineRunName string     `json:"originalPipelineRunName,omitempty"`
+	Status                  string     `json:"status"`
+	StartTime               *time.Time `json:"startTime,omitempty"`
+	CompletionTime          *time.Time `json:"completionTime,omitempty"`
+	SHA                     string     `json:"sha,omitempty"`
+	SHAURL                  string     `json:"shaURL,omitempty"`
+	Title                   string     `json:"title,omitempty"`
+	EventType               string     `json:"eventType,omitempty"`
+	TargetBranch            string     `json:"targetBranch,omitempty"`
+	LogURL                  string     `json:"logURL,omitempty"`
+}
+
+// History is the run history of a Repository as returned by the run history
+// API, the most recent run first.
+type History struct {
+	Namespace  string       `json:"namespace"`
+	Repository string       `json:"repository"`
+	URL        string       `json:"url"`
+	Runs       []HistoryRun `json:"runs"`
+}
+
+func deref(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
+
+func newHistoryRun(rs v1alpha1.RepositoryRunStatus) HistoryRun {
+	run := HistoryRun{
+		PipelineRunName:         rs.PipelineRunName,
+		OriginalPipelineRunName: rs.OriginalPipelineRunName,
+		Status:                  "Unknown",
+		SHA:                     deref(rs.SHA),
+		SHAURL:                  deref(rs.SHAURL),
+		Title:                   deref(rs.Title),
+		EventType:               deref(rs.EventType),
+		TargetBranch:            deref(rs.TargetBranch),
+		LogURL:                  deref(rs.LogURL),
+	}
+	if len(rs.Conditions) > 0 {
+		run.Status = rs.Conditions[0].Reason
+	}
+	if rs.StartTime != nil {
+		run.StartTime = &rs.StartTime.Time
+	}
+	if rs.CompletionTime != nil {
+		run.CompletionTime = &rs.CompletionTime.Time
+	}
+	return run
+}
+
+// authenticate reviews the Kubernetes token of the request, passed as a bearer
+// token or as the password of a basic authentication for the browsers, and
+// returns its user.
+func (l listener) authenticate(ctx context.Context, request *http.Request) (*authenticationv1.UserInfo, int, error) {
+	token, found := strings.CutPrefix(request.Header.Get("Authorization"), "Bearer ")
+	if !found {
+		_, token, _ = request.BasicAuth()
+	}
+	if token == "" {
+		return nil, http.StatusUnauthorized, fmt.Errorf("missing bearer token")
+	}
+
+	review, err := l.run.Clients.Kube.AuthenticationV1().TokenReviews().Create(ctx, &authenticationv1.TokenReview{
+		Spec: authenticationv1.TokenReviewSpec{Token: token},
+	}, metav1.CreateOptions{})
+	if err != nil {
+		return nil, http.StatusInternalServerError, fmt.Errorf("cannot review token: %w", err)
+	}
+	if !review.Status.Authenticated {
+		return nil, http.StatusUnauthorized, fmt.Errorf("invalid token")
+	}
+	return &review.Status.User, http.StatusOK, nil
+}
+
+// isAllowed checks user is allowed to verb the repositories of namespace, of
+// all the namespaces when namespace is empty, or the repository name when it
+// is not empty.
+func (l listener) isAllowed(ctx context.Context, user *authenticationv1.UserInfo, verb, namespace, name string) (bool, error) {
+	extra := map[string]authorizationv1.ExtraValue{}
+	for k, v := range user.Extra {
+		extra[k] = authorizationv1.ExtraValue(v)
+	}
+	access, err := l.run.Clients.Kube.AuthorizationV1().SubjectAccessReviews().Create(ctx, &authorizationv1.SubjectAccessReview{
+		Spec: authorizationv1.SubjectAccessReviewSpec{
+			ResourceAttributes: &authorizationv1.ResourceAttributes{
+				Namespace: namespace,
+				Verb:      verb,
+				Group:     v1alpha1.SchemeGroupVersion.Group,
+				Resource:  "repositories",
+				Name:      name,
+			},
+			User:   user.Username,
+			Groups: user.Groups,
+			UID:    user.UID,
+			Extra:  extra,
+		},
+	}, metav1.CreateOptions{})
+	if err != nil {
+		return false, fmt.Errorf("cannot review access: %w", err)
+	}
+	return access.Status.Allowed, nil
+}
+
+// canGetRepository checks the bearer token of the request belongs to a user
+// allowed to get the repository.
+func (l listener) canGetRepository(ctx context.Context, request *http.Request, namespace, name string) (int, error) {
+	user, code, err := l.authenticate(ctx, request)
+	if err != nil {
+		return code, err
+	}
+	allowed, err := l.isAllowed(ctx, user, "get", namespace, name)
+	if err != nil {
+		return http.StatusInternalServerError, err
+	}
+	if !allowed {
+		return http.StatusForbidden, fmt.Errorf("user %s cannot get repository %s/%s", user.Username, namespace, name)
+	}
+	return http.StatusOK, nil
+}
+
+// handleHistory returns the run history of a Repository, for the users
+// allowed to get it, when the run history API is enabled.
+func (l listener) handleHistory(ctx context.Context) http.HandlerFunc {
+	return func(response http.ResponseWriter, request *http.Request) {
+		if !l.run.Info.GetPacOpts().EnableRunHistoryAPI {
+			l.writeResponse(response, http.StatusNotFound, "run history API is not enabled")
+			return
+		}
+		namespace, name := request.PathValue("namespace"), request.PathValue("name")
+		if code, err := l.canGetRepository(ctx, request, namespace, name); err != nil {
+			l.logger.Infof("run history of %s/%s refused: %v", namespace, name, err)
+			l.writeResponse(response, code, err.Error())
+			return
+		}
+
+		repo, err := l.run.Clients.PipelineAsCode.PipelinesascodeV1alpha1().Repositories(namespace).Get(ctx, name, metav1.GetOptions{})
+		if errors.IsNotFound(err) {
+			l.writeResponse(response, http.StatusNotFound, fmt.Sprintf("repository %s/%s not found", namespace, name))
+			return
+		} else if err != nil {
+			l.logger.Errorf("cannot get repository %s/%s: %v", namespace, name, err)
+			l.writeResponse(response, http.StatusInternalServerError, "cannot get repository")
+			return
+		}
+
+		history := History{
+			Namespace:  repo.GetNamespace(),
+			Repository: repo.GetName(),
+			URL:        repo.Spec.URL,
+			Runs:       []HistoryRun{},
+		}
+		for _, rs := range status.MixLivePRandRepoStatus(ctx, l.run, *repo) {
+			history.Runs = append(history.Runs, newHistoryRun(rs))
+		}
+
+		response.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(response).Encode(history); err != nil {
+			l.logger.Errorf("failed to write run history response: %v", err)
+		}
+	}
+}
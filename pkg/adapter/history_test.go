@@ -0,0 +1,155 @@
+package adapter
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/google/go-github/v74/github"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/apis/pipelinesascode/v1alpha1"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/consoleui"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/params"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/params/clients"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/params/info"
+	testclient "github.com/openshift-pipelines/pipelines-as-code/pkg/test/clients"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/test/logger"
+	"gotest.tools/v3/assert"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	ktesting "k8s.io/client-go/testing"
+	knativeapis "knative.dev/pkg/apis"
+	knativeduckv1 "knative.dev/pkg/apis/duck/v1"
+	rtesting "knative.dev/pkg/reconciler/testing"
+)
+
+func TestHandleHistory(t *testing.T) {
+	startTime := time.Date(2025, time.January, 2, 10, 0, 0, 0, time.UTC)
+	repo := &v1alpha1.Repository{
+		ObjectMeta: metav1.ObjectMeta{Name: "repo", Namespace: "ns"},
+		Spec:       v1alpha1.RepositorySpec{URL: "https://github.com/owner/repo"},
+		Status: []v1alpha1.RepositoryRunStatus{{
+			Status: knativeduckv1.Status{
+				Conditions: []knativeapis.Condition{{Type: knativeapis.ConditionSucceeded, Reason: "Succeeded"}},
+			},
+			PipelineRunName: "pr-abcde",
+			StartTime:       &metav1.Time{Time: startTime},
+			SHA:             github.Ptr("sha"),
+			EventType:       github.Ptr("pull_request"),
+			LogURL:          github.Ptr("https://console/pr-abcde"),
+		}},
+	}
+
+	tests := []struct {
+		name          string
+		disabled      bool
+		path          string
+		token         string
+		authenticated bool
+		allowed       bool
+		wantCode      int
+		wantRuns      []HistoryRun
+	}{
+		{
+			name:     "api disabled",
+			disabled: true,
+			path:     "/api/v1/namespaces/ns/repositories/repo/runs",
+			wantCode: http.StatusNotFound,
+		},
+		{
+			name:     "no token",
+			path:     "/api/v1/namespaces/ns/repositories/repo/runs",
+			wantCode: http.StatusUnauthorized,
+		},
+		{
+			name:     "invalid token",
+			path:     "/api/v1/namespaces/ns/repositories/repo/runs",
+			token:    "token",
+			wantCode: http.StatusUnauthorized,
+		},
+		{
+			name:          "not allowed",
+			path:          "/api/v1/namespaces/ns/repositories/repo/runs",
+			token:         "token",
+			authenticated: true,
+			wantCode:      http.StatusForbidden,
+		},
+		{
+			name:          "repository not found",
+			path:          "/api/v1/namespaces/ns/repositories/missing/runs",
+			token:         "token",
+			authenticated: true,
+			allowed:       true,
+			wantCode:      http.StatusNotFound,
+		},
+		{
+			name:          "run history",
+			path:          "/api/v1/namespaces/ns/repositories/repo/runs",
+			token:         "token",
+			authenticated: true,
+			allowed:       true,
+			wantCode:      http.StatusOK,
+			wantRuns: []HistoryRun{{
+				PipelineRunName: "pr-abcde",
+				Status:          "Succeeded",
+				StartTime:       &startTime,
+				SHA:             "sha",
+				EventType:       "pull_request",
+				LogURL:          "https://console/pr-abcde",
+			}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, _ := rtesting.SetupFakeContext(t)
+			stdata, _ := testclient.SeedTestData(t, ctx, testclient.Data{Repositories: []*v1alpha1.Repository{repo}})
+			stdata.Kube.PrependReactor("create", "tokenreviews", func(action ktesting.Action) (bool, runtime.Object, error) {
+				review, _ := action.(ktesting.CreateAction).GetObject().(*authenticationv1.TokenReview)
+				review.Status.Authenticated = tt.authenticated
+				review.Status.User.Username = "user"
+				return true, review, nil
+			})
+			stdata.Kube.PrependReactor("create", "subjectaccessreviews", func(action ktesting.Action) (bool, runtime.Object, error) {
+				review, _ := action.(ktesting.CreateAction).GetObject().(*authorizationv1.SubjectAccessReview)
+				assert.Equal(t, review.Spec.User, "user")
+				assert.Equal(t, review.Spec.ResourceAttributes.Resource, "repositories")
+				review.Status.Allowed = tt.allowed
+				return true, review, nil
+			})
+
+			log, _ := logger.GetLogger()
+			run := params.New()
+			run.Clients = clients.Clients{
+				PipelineAsCode: stdata.PipelineAsCode,
+				Tekton:         stdata.Pipeline,
+				Kube:           stdata.Kube,
+			}
+			run.Clients.SetConsoleUI(consoleui.FallBackConsole{})
+			run.Info.Pac = info.NewPacOpts()
+			run.Info.Pac.EnableRunHistoryAPI = !tt.disabled
+			l := listener{run: run, logger: log}
+
+			mux := http.NewServeMux()
+			mux.HandleFunc(historyAPIPattern, l.handleHistory(ctx))
+			req := httptest.NewRequest(http.MethodGet, tt.path, nil)
+			if tt.token != "" {
+				req.Header.Set("Authorization", "Bearer "+tt.token)
+			}
+			rec := httptest.NewRecorder()
+			mux.ServeHTTP(rec, req)
+
+			assert.Equal(t, rec.Code, tt.wantCode)
+			if tt.wantCode != http.StatusOK {
+				return
+			}
+			history := History{}
+			assert.NilError(t, json.Unmarshal(rec.Body.Bytes(), &history))
+			assert.Equal(t, history.Repository, "repo")
+			assert.Equal(t, history.URL, "https://github.com/owner/repo")
+			assert.DeepEqual(t, history.Runs, tt.wantRuns)
+		})
+	}
+}
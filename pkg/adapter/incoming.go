@@ -147,6 +147,7 @@ func (l *listener) detectIncoming(ctx context.Context, req *http.Request, payloa
 		l.event.Provider.URL = enterpriseURL
 		l.event.Provider.Token = token
 		l.event.InstallationID = installationID
+		l.event.GithubAppSlug = gh.AppSlug
 		// Github app is not installed for provided repository url
 		if l.event.InstallationID == 0 {
 			return false, nil, fmt.Errorf("GithubApp is not installed for the provided repository url %s ", repo.Spec.URL)
@@ -183,25 +184,32 @@ func (l *listener) processIncoming(targetRepo *v1alpha1.Repository) (provider.In
 	l.event.Organization = org
 	l.event.Repository = repo
 
-	var provider provider.Interface
-	if targetRepo.Spec.GitProvider == nil || targetRepo.Spec.GitProvider.Type == "" {
-		provider = github.New()
-	} else {
-		switch targetRepo.Spec.GitProvider.Type {
-		case "github":
-			provider = github.New()
-		case "gitlab":
-			provider = &gitlab.Provider{}
-		case "gitea":
-			provider = &gitea.Provider{}
-		case "bitbucket-cloud":
-			provider = &bitbucketcloud.Provider{}
-		case "bitbucket-datacenter":
-			provider = &bitbucketdatacenter.Provider{}
-		default:
-			return l.processRes(false, nil, l.logger.With("namespace", targetRepo.Namespace), "", fmt.Errorf("no supported Git provider has been detected"))
-		}
+	provider := repositoryProvider(targetRepo)
+	if provider == nil {
+		return l.processRes(false, nil, l.logger.With("namespace", targetRepo.Namespace), "", fmt.Errorf("no supported Git provider has been detected"))
 	}
 
 	return l.processRes(true, provider, l.logger.With("provider", "incoming", "namespace", targetRepo.Namespace), "", nil)
 }
+
+// repositoryProvider returns the provider of the git_provider type of a
+// repository, GitHub when it has none, or nil when the type is unknown.
+func repositoryProvider(repo *v1alpha1.Repository) provider.Interface {
+	if repo.Spec.GitProvider == nil || repo.Spec.GitProvider.Type == "" {
+		return github.New()
+	}
+	switch repo.Spec.GitProvider.Type {
+	case "github":
+		return github.New()
+	case "gitlab":
+		return &gitlab.Provider{}
+	case "gitea":
+		return &gitea.Provider{}
+	case "bitbucket-cloud":
+		return &bitbucketcloud.Provider{}
+	case "bitbucket-datacenter":
+		return &bitbucketdatacenter.Provider{}
+	default:
+		return nil
+	}
+}
@@ -0,0 +1,72 @@
+package adapter
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+)
+
+// checkJSONObject makes sure r is a single JSON object, it walks through its
+// tokens instead of decoding it so a big payload doesn't get decoded in
+// memory as a whole.
+func checkJSONObject(r io.Reader) error {
+	dec := json.NewDecoder(r)
+	tok, err := dec.Token()
+	if err != nil {
+		return err
+	}
+	if delim, ok := tok.(json.Delim); !ok || delim != '{' {
+		return fmt.Errorf("payload is not a JSON object")
+	}
+	for depth := 1; depth > 0; {
+		if tok, err = dec.Token(); err != nil {
+			if errors.Is(err, io.EOF) {
+				return io.ErrUnexpectedEOF
+			}
+			return err
+		}
+		if delim, ok := tok.(json.Delim); ok {
+			switch delim {
+			case '{', '[':
+				depth++
+			case '}', ']':
+				depth--
+			}
+		}
+	}
+	if _, err := dec.Token(); !errors.Is(err, io.EOF) {
+		if err != nil {
+			return err
+		}
+		return fmt.Errorf("invalid data after the JSON object")
+	}
+	return nil
+}
+
+// readPayload reads the body of the request, checking it is a JSON object
+// while it is being read. The read fails with a *http.MaxBytesError when the
+// body is bigger than maxSize, an empty body is not an error.
+func readPayload(response http.ResponseWriter, request *http.Request, maxSize int64) ([]byte, error) {
+	if maxSize > 0 && request.ContentLength > maxSize {
+		return nil, &http.MaxBytesError{Limit: maxSize}
+	}
+	body := io.Reader(request.Body)
+	if maxSize > 0 {
+		body = http.MaxBytesReader(response, request.Body, maxSize)
+	}
+	payload := &bytes.Buffer{}
+	if request.ContentLength > 0 {
+		payload.Grow(int(request.ContentLength))
+	}
+	err := checkJSONObject(io.TeeReader(body, payload))
+	if errors.Is(err, io.EOF) && payload.Len() == 0 {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return payload.Bytes(), nil
+}
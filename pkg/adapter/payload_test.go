@@ -0,0 +1,144 @@
+package adapter
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/metrics"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/params"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/params/info"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/params/settings"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/test/logger"
+	"gotest.tools/v3/assert"
+	"knative.dev/pkg/metrics/metricstest"
+
+	_ "knative.dev/pkg/metrics/testing"
+)
+
+func TestCheckJSONObject(t *testing.T) {
+	tests := []struct {
+		name    string
+		payload string
+		wantErr string
+	}{
+		{
+			name:    "object",
+			payload: `{"commits": [{"id": "1", "files": ["a", "b"]}, {"id": "2"}], "nested": {"a": {"b": null}}}`,
+		},
+		{
+			name:    "array",
+			payload: `[{"id": "1"}]`,
+			wantErr: "payload is not a JSON object",
+		},
+		{
+			name:    "truncated",
+			payload: `{"commits": [{"id": "1"}`,
+			wantErr: "unexpected EOF",
+		},
+		{
+			name:    "trailing data",
+			payload: `{"id": "1"} {"id": "2"}`,
+			wantErr: "invalid data after the JSON object",
+		},
+		{
+			name:    "not json",
+			payload: `some random string`,
+			wantErr: "invalid character",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := checkJSONObject(strings.NewReader(tt.payload))
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			assert.NilError(t, err)
+		})
+	}
+}
+
+func TestReadPayload(t *testing.T) {
+	bigPayload := `{"commits": [` + strings.Repeat(`{"id": "1"},`, 1000) + `{"id": "2"}]}`
+	tests := []struct {
+		name          string
+		payload       string
+		maxSize       int64
+		unknownLength bool
+		wantTooLarge  bool
+	}{
+		{
+			name:    "empty",
+			maxSize: 10,
+		},
+		{
+			name:    "no limit",
+			payload: bigPayload,
+		},
+		{
+			name:    "under the limit",
+			payload: bigPayload,
+			maxSize: int64(len(bigPayload)),
+		},
+		{
+			name:         "over the limit",
+			payload:      bigPayload,
+			maxSize:      100,
+			wantTooLarge: true,
+		},
+		{
+			name:          "over the limit without content length",
+			payload:       bigPayload,
+			maxSize:       100,
+			unknownLength: true,
+			wantTooLarge:  true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, "/", bytes.NewBufferString(tt.payload))
+			if tt.unknownLength {
+				req.ContentLength = -1
+			}
+			got, err := readPayload(httptest.NewRecorder(), req, tt.maxSize)
+			if tt.wantTooLarge {
+				var maxBytesErr *http.MaxBytesError
+				assert.Assert(t, errors.As(err, &maxBytesErr), err)
+				return
+			}
+			assert.NilError(t, err)
+			assert.Equal(t, string(got), tt.payload)
+		})
+	}
+}
+
+func TestHandleEventPayloadTooLarge(t *testing.T) {
+	defer func() {
+		metricstest.Unregister("pipelines_as_code_payload_too_large_count")
+		metrics.ResetRecorder()
+	}()
+	log, logCatcher := logger.GetLogger()
+	l := listener{
+		run: &params.Run{
+			Info: info.Info{Pac: &info.PacOpts{Settings: settings.Settings{MaxPayloadSize: 10}}},
+		},
+		logger: log,
+	}
+	ts := httptest.NewServer(l.handleEvent(context.Background()))
+	defer ts.Close()
+
+	req, err := http.NewRequestWithContext(context.Background(), http.MethodPost, ts.URL, bytes.NewBufferString(`{"commits": ["a", "b", "c"]}`))
+	assert.NilError(t, err)
+	resp, err := http.DefaultClient.Do(req)
+	assert.NilError(t, err)
+	defer resp.Body.Close()
+
+	assert.Equal(t, resp.StatusCode, http.StatusRequestEntityTooLarge)
+	assert.Assert(t, logCatcher.FilterMessageSnippet("event payload is bigger than the max-payload-size setting of 10 bytes").Len() > 0, logCatcher.All())
+	metricstest.CheckCountData(t, "pipelines_as_code_payload_too_large_count", map[string]string{}, 1)
+}
@@ -0,0 +1,214 @@
+package adapter
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/apis/pipelinesascode/keys"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/apis/pipelinesascode/v1alpha1"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/events"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/formatting"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/params/info"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/params/triggertype"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/pipelineascode"
+	"go.uber.org/zap"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+const (
+	// pollTick is how often the Repositories are checked for a poll to do.
+	pollTick = time.Minute
+	// minPollInterval is the shortest polling interval of a Repository.
+	minPollInterval = time.Minute
+)
+
+// pollState is the state of the polling of a Repository, kept in its
+// poll-state annotation for all the replicas of the controller to share it.
+type pollState struct {
+	PolledAt time.Time `json:"polled_at"`
+	// Heads are the SHAs found on the last poll by branch and pull request.
+	Heads map[string]string `json:"heads"`
+}
+
+// pollRepositories synthesizes the events of the Repositories with polling
+// set from the heads found on the Git provider, for the clusters its webhooks
+// cannot reach, until ctx is done.
+func (l *listener) pollRepositories(ctx context.Context) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(pollTick):
+		}
+		l.pollRepositoriesOnce(ctx, time.Now())
+	}
+}
+
+func (l *listener) pollRepositoriesOnce(ctx context.Context, now time.Time) {
+	repos, err := l.run.Clients.PipelineAsCode.PipelinesascodeV1alpha1().Repositories("").List(ctx, metav1.ListOptions{})
+	if err != nil {
+		l.logger.Errorf("cannot list the repositories to poll: %v", err)
+		return
+	}
+	for i := range repos.Items {
+		repo := &repos.Items[i]
+		if repo.Spec.Settings == nil || repo.Spec.Settings.Polling == nil {
+			continue
+		}
+		if err := l.pollRepository(ctx, repo, now); err != nil {
+			emitter := events.NewEventEmitter(l.run.Clients.Kube, l.logger)
+			emitter.EmitMessage(repo, zap.ErrorLevel, "RepositoryPollFailed",
+				fmt.Sprintf("cannot poll repository %s/%s: %v", repo.GetNamespace(), repo.GetName(), err))
+		}
+	}
+}
+
+// pollRepository triggers the events of the heads which have changed since
+// the last poll of the repository, once its polling interval has elapsed.
+// The first poll only records the heads.
+func (l *listener) pollRepository(ctx context.Context, repo *v1alpha1.Repository, now time.Time) error {
+	polling := repo.Spec.Settings.Polling
+	interval, err := time.ParseDuration(polling.Interval)
+	if err != nil || interval < minPollInterval {
+		return fmt.Errorf("invalid polling interval %q, it needs to be a duration of at least %s", polling.Interval, minPollInterval)
+	}
+	state := getPollState(repo)
+	if now.Sub(state.PolledAt) < interval {
+		return nil
+	}
+
+	logger := l.logger.With("provider", "poll", "namespace", repo.GetNamespace(), "repository", repo.GetName())
+	vcx := repositoryProvider(repo)
+	if vcx == nil {
+		return fmt.Errorf("no supported Git provider has been detected")
+	}
+	pacInfo := l.run.Info.GetPacOpts()
+	vcx.SetPacInfo(&pacInfo)
+	vcx.SetLogger(logger)
+
+	event := info.NewEvent()
+	event.URL = repo.Spec.URL
+	if event.Organization, event.Repository, err = formatting.GetRepoOwnerSplitted(repo.Spec.URL); err != nil {
+		return err
+	}
+	globalRepo := l.getGlobalRepository(ctx)
+	// the secret is looked up on a copy, the git_provider url gets defaulted
+	secretRepo := repo.DeepCopy()
+	secretNS := repo.GetNamespace()
+	if repo.Spec.GitProvider != nil {
+		gitProvider := *repo.Spec.GitProvider
+		secretRepo.Spec.GitProvider = &gitProvider
+		if gitProvider.Secret == nil && globalRepo.Spec.GitProvider != nil && globalRepo.Spec.GitProvider.Secret != nil {
+			secretRepo.Spec.GitProvider.Secret = globalRepo.Spec.GitProvider.Secret
+			secretNS = globalRepo.GetNamespace()
+		}
+	}
+	scm := pipelineascode.SecretFromRepository{
+		K8int:       l.kint,
+		Config:      vcx.GetConfig(),
+		Event:       event,
+		Repo:        secretRepo,
+		WebhookType: pacInfo.WebhookType,
+		Logger:      logger,
+		Namespace:   secretNS,
+	}
+	if err := scm.Get(ctx); err != nil {
+		return fmt.Errorf("cannot get secret from repository: %w", err)
+	}
+	if err := vcx.SetClient(ctx, l.run, event, secretRepo, events.NewEventEmitter(l.run.Clients.Kube, logger)); err != nil {
+		return err
+	}
+	polled, err := vcx.GetPollEvents(ctx, event, polling.Branches, polling.PullRequests)
+	if err != nil {
+		return err
+	}
+
+	heads, changed := changedPollEvents(state.Heads, polled)
+	// the state is recorded before the events are triggered, a conflict means
+	// another replica of the controller is polling the repository
+	if err := l.patchPollState(ctx, repo, pollState{PolledAt: now, Heads: heads}); err != nil {
+		if errors.IsConflict(err) {
+			logger.Debugf("repository %s/%s has been polled by another controller", repo.GetNamespace(), repo.GetName())
+			return nil
+		}
+		return fmt.Errorf("cannot record the poll state: %w", err)
+	}
+	for _, polledEvent := range changed {
+		polledEvent.Polled = true
+		logger.Infof("polling has found the new commit %s of %s on repository %s/%s", polledEvent.SHA, pollKey(polledEvent), repo.GetNamespace(), repo.GetName())
+		runProvider := repositoryProvider(repo)
+		runProvider.SetPacInfo(&pacInfo)
+		p := pipelineascode.NewPacs(polledEvent, runProvider, l.run, &pacInfo, l.kint, logger, globalRepo)
+		if err := p.Run(ctx); err != nil {
+			logger.Errorf("an error occurred: %v", err)
+		}
+	}
+	return nil
+}
+
+func (l *listener) getGlobalRepository(ctx context.Context) *v1alpha1.Repository {
+	globalRepo, err := l.run.Clients.PipelineAsCode.PipelinesascodeV1alpha1().Repositories(l.run.Info.Kube.Namespace).Get(
+		ctx, l.run.Info.Controller.GlobalRepository, metav1.GetOptions{},
+	)
+	if err != nil || globalRepo == nil {
+		return &v1alpha1.Repository{}
+	}
+	return globalRepo
+}
+
+// getPollState returns the state of the poll-state annotation of a
+// repository, an empty state when it is missing or invalid.
+func getPollState(repo *v1alpha1.Repository) pollState {
+	state := pollState{}
+	if value, ok := repo.GetAnnotations()[keys.PollState]; ok {
+		_ = json.Unmarshal([]byte(value), &state)
+	}
+	return state
+}
+
+// patchPollState records the state in the poll-state annotation of a
+// repository, it fails with a conflict when the repository has changed since
+// it has been read.
+func (l *listener) patchPollState(ctx context.Context, repo *v1alpha1.Repository, state pollState) error {
+	value, err := json.Marshal(state)
+	if err != nil {
+		return err
+	}
+	patch, _ := json.Marshal(map[string]any{
+		"metadata": map[string]any{
+			"resourceVersion": repo.GetResourceVersion(),
+			"annotations":     map[string]string{keys.PollState: string(value)},
+		},
+	})
+	_, err = l.run.Clients.PipelineAsCode.PipelinesascodeV1alpha1().Repositories(repo.GetNamespace()).Patch(ctx, repo.GetName(), types.MergePatchType, patch, metav1.PatchOptions{})
+	return err
+}
+
+// pollKey identifies the branch or the pull request of a polled event.
+func pollKey(event *info.Event) string {
+	if event.TriggerTarget == triggertype.PullRequest {
+		return "pull/" + strconv.Itoa(event.PullRequestNumber)
+	}
+	return event.BaseBranch
+}
+
+// changedPollEvents returns the heads of the polled events and the events
+// whose head is not the previous one. Nothing has changed on the first poll,
+// when there are no previous heads.
+func changedPollEvents(previous map[string]string, polled []*info.Event) (map[string]string, []*info.Event) {
+	heads := map[string]string{}
+	changed := []*info.Event{}
+	for _, event := range polled {
+		key := pollKey(event)
+		heads[key] = event.SHA
+		if previous != nil && previous[key] != event.SHA {
+			changed = append(changed, event)
+		}
+	}
+	return heads, changed
+}
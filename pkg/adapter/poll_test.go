@@ -0,0 +1,111 @@
+package adapter
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/apis/pipelinesascode/keys"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/apis/pipelinesascode/v1alpha1"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/params/info"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/params/triggertype"
+	"gotest.tools/v3/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestChangedPollEvents(t *testing.T) {
+	pushEvent := &info.Event{TriggerTarget: triggertype.Push, BaseBranch: "refs/heads/main", SHA: "sha2"}
+	prEvent := &info.Event{TriggerTarget: triggertype.PullRequest, PullRequestNumber: 7, SHA: "sha3"}
+	tests := []struct {
+		name        string
+		previous    map[string]string
+		wantChanged []*info.Event
+	}{
+		{
+			name:        "first poll",
+			wantChanged: []*info.Event{},
+		},
+		{
+			name:        "nothing changed",
+			previous:    map[string]string{"refs/heads/main": "sha2", "pull/7": "sha3"},
+			wantChanged: []*info.Event{},
+		},
+		{
+			name:        "new commit",
+			previous:    map[string]string{"refs/heads/main": "sha1", "pull/7": "sha3"},
+			wantChanged: []*info.Event{pushEvent},
+		},
+		{
+			name:        "new pull request",
+			previous:    map[string]string{"refs/heads/main": "sha2"},
+			wantChanged: []*info.Event{prEvent},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			heads, changed := changedPollEvents(tt.previous, []*info.Event{pushEvent, prEvent})
+			assert.DeepEqual(t, heads, map[string]string{"refs/heads/main": "sha2", "pull/7": "sha3"})
+			assert.DeepEqual(t, changed, tt.wantChanged)
+		})
+	}
+}
+
+func TestPollRepositoryNotDue(t *testing.T) {
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name     string
+		interval string
+		state    string
+		wantErr  string
+	}{
+		{
+			name:     "interval not elapsed",
+			interval: "5m",
+			state:    `{"polled_at": "2024-01-01T11:58:00Z", "heads": {}}`,
+		},
+		{
+			name:     "interval too short",
+			interval: "10s",
+			wantErr:  `invalid polling interval "10s", it needs to be a duration of at least 1m0s`,
+		},
+		{
+			name:     "invalid interval",
+			interval: "often",
+			wantErr:  `invalid polling interval "often", it needs to be a duration of at least 1m0s`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &v1alpha1.Repository{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        "repo",
+					Namespace:   "ns",
+					Annotations: map[string]string{keys.PollState: tt.state},
+				},
+				Spec: v1alpha1.RepositorySpec{
+					URL:      "https://github.com/owner/repo",
+					Settings: &v1alpha1.Settings{Polling: &v1alpha1.Polling{Interval: tt.interval}},
+				},
+			}
+			l := &listener{}
+			err := l.pollRepository(context.Background(), repo, now)
+			if tt.wantErr != "" {
+				assert.Error(t, err, tt.wantErr)
+				return
+			}
+			assert.NilError(t, err)
+		})
+	}
+}
+
+func TestGetPollState(t *testing.T) {
+	repo := &v1alpha1.Repository{ObjectMeta: metav1.ObjectMeta{Annotations: map[string]string{
+		keys.PollState: `{"polled_at": "2024-01-01T11:58:00Z", "heads": {"refs/heads/main": "sha1"}}`,
+	}}}
+	state := getPollState(repo)
+	assert.Equal(t, state.PolledAt, time.Date(2024, 1, 1, 11, 58, 0, 0, time.UTC))
+	assert.DeepEqual(t, state.Heads, map[string]string{"refs/heads/main": "sha1"})
+
+	repo.Annotations[keys.PollState] = "invalid"
+	assert.DeepEqual(t, getPollState(repo), pollState{})
+}
@@ -0,0 +1,236 @@
+package adapter
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/apis/pipelinesascode"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// defaultDrainTimeout is how long the events being processed are given to
+	// complete on shutdown, under the 30s termination grace period of the pod.
+	defaultDrainTimeout = 25 * time.Second
+	// retryAfter is the Retry-After header of the webhooks refused while
+	// shutting down or when too many events are waiting, in seconds.
+	retryAfter = "30"
+	// pendingEventLabel labels the secrets of the events which could not be
+	// processed before shutting down, they are replayed on the next start.
+	pendingEventLabel = pipelinesascode.GroupName + "/pending-event"
+	// maxPendingEventSize is the biggest event which can be stored in a
+	// secret, their size is limited to 1MiB.
+	maxPendingEventSize = 1000 * 1024
+)
+
+// pendingEvent is a webhook accepted by the controller.
+type pendingEvent struct {
+	Header  http.Header `json:"header"`
+	Payload []byte      `json:"payload"`
+}
+
+// drainer tracks the events being processed, for the controller to stop
+// accepting webhooks and let them complete before exiting.
+type drainer struct {
+	mu       sync.Mutex
+	wg       sync.WaitGroup
+	draining bool
+	// drained is set once the events which have not started are handed
+	// over to be stored, they must not be started anymore.
+	drained bool
+	nextID  int
+	events  map[int]pendingEvent
+	// started are the events whose processing has begun, they may have
+	// created PipelineRuns or commented and are never replayed.
+	started map[int]bool
+	// ctx is the context of the processing of the events, it outlives the
+	// context of the controller until the drain timeout.
+	ctx    context.Context
+	cancel context.CancelFunc
+}
+
+func newDrainer(ctx context.Context) *drainer {
+	processCtx, cancel := context.WithCancel(context.WithoutCancel(ctx))
+	return &drainer{events: map[int]pendingEvent{}, started: map[int]bool{}, ctx: processCtx, cancel: cancel}
+}
+
+// add tracks a new event, it returns false when the controller is shutting
+// down and the event has to be refused.
+func (d *drainer) add(event pendingEvent) (int, bool) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if d.draining {
+		return 0, false
+	}
+	d.nextID++
+	d.events[d.nextID] = event
+	d.wg.Add(1)
+	return d.nextID, true
+}
+
+// start marks the processing of the event as begun, it returns false when
+// the event has been handed over to be replayed on the next start and must
+// not be processed.
+func (d *drainer) start(id int) bool {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if _, ok := d.events[id]; !ok || d.drained {
+		return false
+	}
+	d.started[id] = true
+	return true
+}
+
+// done marks the event as processed.
+func (d *drainer) done(id int) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if _, ok := d.events[id]; !ok {
+		return
+	}
+	delete(d.events, id)
+	delete(d.started, id)
+	d.wg.Done()
+}
+
+// drain refuses the new events and waits for the ones being processed until
+// the timeout. It returns the events which have not started in time, to be
+// replayed, and the number of the ones interrupted while being processed,
+// which are not as they may already have had side effects.
+func (d *drainer) drain(timeout time.Duration) ([]pendingEvent, int) {
+	d.mu.Lock()
+	d.draining = true
+	d.mu.Unlock()
+
+	finished := make(chan struct{})
+	go func() {
+		d.wg.Wait()
+		close(finished)
+	}()
+	select {
+	case <-finished:
+	case <-time.After(timeout):
+	}
+	d.cancel()
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.drained = true
+	pending := make([]pendingEvent, 0, len(d.events))
+	for id, event := range d.events {
+		if !d.started[id] {
+			pending = append(pending, event)
+		}
+	}
+	return pending, len(d.started)
+}
+
+// drainTimeout returns the drain timeout, it can be changed with the
+// PAC_DRAIN_TIMEOUT environment variable along the termination grace period
+// of the pod.
+func (l *listener) drainTimeout() time.Duration {
+	value := os.Getenv("PAC_DRAIN_TIMEOUT")
+	if value == "" {
+		return defaultDrainTimeout
+	}
+	timeout, err := time.ParseDuration(value)
+	if err != nil {
+		l.logger.Warnf("invalid PAC_DRAIN_TIMEOUT %s, using the default of %s: %v", value, defaultDrainTimeout, err)
+		return defaultDrainTimeout
+	}
+	return timeout
+}
+
+// shutdown lets the events being processed complete, stores the ones which
+// have not in time to replay them on the next start, and stops the server.
+func (l *listener) shutdown(srv *http.Server) error {
+	timeout := l.drainTimeout()
+	l.logger.Infof("shutting down, waiting up to %s for the events being processed", timeout)
+	pending, interrupted := l.drainer.drain(timeout)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if interrupted > 0 {
+		l.logger.Warnf("%d events have been interrupted while being processed in %s, they are not processed again", interrupted, timeout)
+	}
+	if len(pending) > 0 {
+		l.logger.Warnf("%d events have not started to be processed in %s, storing them to process them on the next start", len(pending), timeout)
+		l.storePendingEvents(ctx, pending)
+	}
+	return srv.Shutdown(ctx)
+}
+
+// storePendingEvents stores the events in secrets of the namespace of the
+// controller, the payloads can have private information.
+func (l *listener) storePendingEvents(ctx context.Context, events []pendingEvent) {
+	for _, event := range events {
+		data, err := json.Marshal(event)
+		if err != nil {
+			l.logger.Errorf("cannot store pending event: %v", err)
+			continue
+		}
+		if len(data) > maxPendingEventSize {
+			l.logger.Errorf("cannot store pending event %s, it is bigger than the maximum size of a secret", deliveryID(event.Header))
+			continue
+		}
+		secret := &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				GenerateName: "pac-pending-event-",
+				Labels:       map[string]string{pendingEventLabel: "true"},
+			},
+			Data: map[string][]byte{"event": data},
+		}
+		if _, err := l.run.Clients.Kube.CoreV1().Secrets(l.run.Info.Kube.Namespace).Create(ctx, secret, metav1.CreateOptions{}); err != nil {
+			l.logger.Errorf("cannot store pending event %s: %v", deliveryID(event.Header), err)
+		}
+	}
+}
+
+// replayPendingEvents processes the events stored by the previous controller
+// when it was shut down, the secrets are removed once they are replayed.
+func (l *listener) replayPendingEvents(ctx context.Context, handler http.Handler) error {
+	secrets := l.run.Clients.Kube.CoreV1().Secrets(l.run.Info.Kube.Namespace)
+	list, err := secrets.List(ctx, metav1.ListOptions{LabelSelector: pendingEventLabel + "=true"})
+	if err != nil {
+		return fmt.Errorf("cannot list the pending events: %w", err)
+	}
+	for _, secret := range list.Items {
+		// remove the secret first, an event making the controller crash
+		// must not be replayed forever
+		if err := secrets.Delete(ctx, secret.GetName(), metav1.DeleteOptions{}); err != nil {
+			l.logger.Errorf("cannot remove pending event %s, not replaying it: %v", secret.GetName(), err)
+			continue
+		}
+		var event pendingEvent
+		if err := json.Unmarshal(secret.Data["event"], &event); err != nil {
+			l.logger.Errorf("cannot read pending event %s: %v", secret.GetName(), err)
+			continue
+		}
+		request, err := http.NewRequestWithContext(ctx, http.MethodPost, "/", bytes.NewReader(event.Payload))
+		if err != nil {
+			return err
+		}
+		request.Header = event.Header
+		l.logger.Infof("replaying event %s which has not been processed before the last shutdown", deliveryID(event.Header))
+		handler.ServeHTTP(&discardResponseWriter{header: http.Header{}}, request)
+	}
+	return nil
+}
+
+// discardResponseWriter is the response writer of the replayed and pulled
+// events, it only keeps the status.
+type discardResponseWriter struct {
+	header http.Header
+	status int
+}
+
+func (w *discardResponseWriter) Header() http.Header         { return w.header }
+func (w *discardResponseWriter) Write(b []byte) (int, error) { return len(b), nil }
+func (w *discardResponseWriter) WriteHeader(status int)      { w.status = status }
@@ -0,0 +1,117 @@
+package adapter
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/params"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/params/clients"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/params/info"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/params/settings"
+	testclient "github.com/openshift-pipelines/pipelines-as-code/pkg/test/clients"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/test/logger"
+	"gotest.tools/v3/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	rtesting "knative.dev/pkg/reconciler/testing"
+)
+
+func TestDrainer(t *testing.T) {
+	d := newDrainer(context.Background())
+	first, ok := d.add(pendingEvent{Payload: []byte("first")})
+	assert.Assert(t, ok)
+	second, ok := d.add(pendingEvent{Payload: []byte("second")})
+	assert.Assert(t, ok)
+	queued, ok := d.add(pendingEvent{Payload: []byte("queued")})
+	assert.Assert(t, ok)
+	assert.Assert(t, d.start(first))
+	d.done(first)
+	// done twice is ignored
+	d.done(first)
+	assert.Assert(t, !d.start(first))
+	assert.Assert(t, d.start(second))
+
+	go func() {
+		time.Sleep(10 * time.Millisecond)
+		// events are refused while draining
+		_, ok := d.add(pendingEvent{Payload: []byte("third")})
+		assert.Assert(t, !ok)
+	}()
+	// the started event is interrupted but not replayed, it may already
+	// have had side effects
+	pending, interrupted := d.drain(50 * time.Millisecond)
+	assert.DeepEqual(t, pending, []pendingEvent{{Payload: []byte("queued")}})
+	assert.Equal(t, interrupted, 1)
+	assert.ErrorIs(t, d.ctx.Err(), context.Canceled)
+	// the stored event is not processed anymore
+	assert.Assert(t, !d.start(queued))
+
+	d.done(second)
+	d.done(queued)
+	pending, interrupted = d.drain(time.Second)
+	assert.Equal(t, len(pending), 0)
+	assert.Equal(t, interrupted, 0)
+}
+
+func TestHandleEventShuttingDown(t *testing.T) {
+	log, _ := logger.GetLogger()
+	l := listener{
+		run: &params.Run{
+			Info: info.Info{Pac: &info.PacOpts{Settings: settings.Settings{MaxPayloadSize: 1024}}},
+		},
+		logger:  log,
+		drainer: newDrainer(context.Background()),
+	}
+	pending, _ := l.drainer.drain(time.Second)
+	assert.Equal(t, len(pending), 0)
+
+	ts := httptest.NewServer(l.handleEvent(context.Background()))
+	defer ts.Close()
+	req, err := http.NewRequestWithContext(context.Background(), http.MethodPost, ts.URL, bytes.NewBufferString(`{}`))
+	assert.NilError(t, err)
+	resp, err := http.DefaultClient.Do(req)
+	assert.NilError(t, err)
+	defer resp.Body.Close()
+
+	assert.Equal(t, resp.StatusCode, http.StatusServiceUnavailable)
+	assert.Equal(t, resp.Header.Get("Retry-After"), retryAfter)
+}
+
+func TestStoreAndReplayPendingEvents(t *testing.T) {
+	ctx, _ := rtesting.SetupFakeContext(t)
+	stdata, _ := testclient.SeedTestData(t, ctx, testclient.Data{})
+	log, logCatcher := logger.GetLogger()
+	l := &listener{
+		run: &params.Run{
+			Clients: clients.Clients{Kube: stdata.Kube},
+			Info:    info.Info{Kube: &info.KubeOpts{Namespace: "pipelines-as-code"}},
+		},
+		logger: log,
+	}
+
+	l.storePendingEvents(ctx, []pendingEvent{
+		{Header: http.Header{"X-Github-Delivery": {"first"}}, Payload: []byte(`{"action": "opened"}`)},
+		{Header: http.Header{"X-Github-Delivery": {"too-big"}}, Payload: bytes.Repeat([]byte("a"), maxPendingEventSize)},
+	})
+	assert.Assert(t, logCatcher.FilterMessageSnippet("cannot store pending event X-GitHub-Delivery/too-big").Len() > 0, logCatcher.All())
+
+	secrets, err := stdata.Kube.CoreV1().Secrets("pipelines-as-code").List(ctx, metav1.ListOptions{LabelSelector: pendingEventLabel + "=true"})
+	assert.NilError(t, err)
+	assert.Equal(t, len(secrets.Items), 1)
+
+	replayed := []string{}
+	handler := http.HandlerFunc(func(_ http.ResponseWriter, request *http.Request) {
+		body, _ := io.ReadAll(request.Body)
+		replayed = append(replayed, request.Header.Get("X-Github-Delivery")+" "+string(body))
+	})
+	assert.NilError(t, l.replayPendingEvents(ctx, handler))
+	assert.DeepEqual(t, replayed, []string{`first {"action": "opened"}`})
+
+	secrets, err = stdata.Kube.CoreV1().Secrets("pipelines-as-code").List(ctx, metav1.ListOptions{LabelSelector: pendingEventLabel + "=true"})
+	assert.NilError(t, err)
+	assert.Equal(t, len(secrets.Items), 0)
+}
@@ -23,6 +23,9 @@ type sinker struct {
 	payload    []byte
 	pacInfo    *info.PacOpts
 	globalRepo *v1alpha1.Repository
+	// payloadValidated is called once the payload has been validated with
+	// the webhook secret
+	payloadValidated func()
 }
 
 func (s *sinker) processEventPayload(ctx context.Context, request *http.Request) error {
@@ -72,5 +75,6 @@ func (s *sinker) processEvent(ctx context.Context, request *http.Request) error
 	}
 
 	p := pipelineascode.NewPacs(s.event, s.vcx, s.run, s.pacInfo, s.kint, s.logger, s.globalRepo)
+	p.SetPayloadValidated(s.payloadValidated)
 	return p.Run(ctx)
 }
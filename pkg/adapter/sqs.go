@@ -0,0 +1,100 @@
+package adapter
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/sqs"
+)
+
+// sqsRetryInterval is how long the poller waits when no queue is configured
+// or the queue cannot be read.
+const sqsRetryInterval = 30 * time.Second
+
+// sqsClientFunc returns the client of the queue of the sqs settings.
+type sqsClientFunc func(queueURL, region string) (sqsClient, error)
+
+type sqsClient interface {
+	Receive(ctx context.Context) ([]sqs.Message, error)
+	Delete(ctx context.Context, message sqs.Message) error
+}
+
+func newSQSClient(queueURL, region string) (sqsClient, error) {
+	creds, err := sqs.CredentialsFromEnv()
+	if err != nil {
+		return nil, err
+	}
+	return sqs.NewClient(queueURL, region, creds, http.DefaultClient)
+}
+
+// pollSQS pulls the webhooks of the git providers from the sqs-queue-url
+// queue, for the clusters which cannot receive them, until ctx is done.
+func (l *listener) pollSQS(ctx context.Context, newClient sqsClientFunc, handler http.Handler) {
+	for {
+		if !l.pollSQSOnce(ctx, newClient, handler) {
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(sqsRetryInterval):
+			}
+		}
+	}
+}
+
+// pollSQSOnce processes the next messages of the queue, it returns false when
+// the queue is not configured or cannot be read.
+func (l *listener) pollSQSOnce(ctx context.Context, newClient sqsClientFunc, handler http.Handler) bool {
+	pacInfo := l.run.Info.GetPacOpts()
+	if pacInfo.SQSQueueURL == "" {
+		return false
+	}
+	client, err := newClient(pacInfo.SQSQueueURL, pacInfo.SQSRegion)
+	if err != nil {
+		l.logger.Errorf("cannot read the events of sqs queue %s: %v", pacInfo.SQSQueueURL, err)
+		return false
+	}
+	messages, err := client.Receive(ctx)
+	if err != nil {
+		if ctx.Err() == nil {
+			l.logger.Errorf("cannot read the events of sqs queue %s: %v", pacInfo.SQSQueueURL, err)
+		}
+		return false
+	}
+	for _, message := range messages {
+		if !l.handleSQSMessage(ctx, pacInfo.SQSMessageFormat, message, handler) {
+			// the message is received again once its visibility timeout
+			// has expired
+			continue
+		}
+		if err := client.Delete(ctx, message); err != nil {
+			l.logger.Errorf("cannot remove message %s from sqs queue %s: %v", message.MessageID, pacInfo.SQSQueueURL, err)
+		}
+	}
+	return true
+}
+
+// handleSQSMessage processes the webhook of a message like the ones sent to
+// the controller, it returns false when it has to be retried.
+func (l *listener) handleSQSMessage(ctx context.Context, format string, message sqs.Message, handler http.Handler) bool {
+	header, payload, err := sqs.Decode(format, message)
+	if err != nil {
+		// it would fail the same way on the next tries
+		l.logger.Errorf("skipping sqs message: %v", err)
+		return true
+	}
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, "/", bytes.NewReader(payload))
+	if err != nil {
+		l.logger.Errorf("skipping sqs message %s: %v", message.MessageID, err)
+		return true
+	}
+	request.Header = header
+	writer := &discardResponseWriter{header: http.Header{}}
+	handler.ServeHTTP(writer, request)
+	if writer.status >= http.StatusInternalServerError {
+		l.logger.Warnf("sqs message %s could not be processed with status %d, it will be retried", message.MessageID, writer.status)
+		return false
+	}
+	return true
+}
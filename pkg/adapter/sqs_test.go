@@ -0,0 +1,138 @@
+package adapter
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/params"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/params/info"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/params/settings"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/sqs"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/test/logger"
+	"gotest.tools/v3/assert"
+	rtesting "knative.dev/pkg/reconciler/testing"
+)
+
+type fakeSQSClient struct {
+	messages   []sqs.Message
+	receiveErr error
+	deleted    []string
+}
+
+func (c *fakeSQSClient) Receive(context.Context) ([]sqs.Message, error) {
+	return c.messages, c.receiveErr
+}
+
+func (c *fakeSQSClient) Delete(_ context.Context, message sqs.Message) error {
+	c.deleted = append(c.deleted, message.MessageID)
+	return nil
+}
+
+func TestPollSQSOnce(t *testing.T) {
+	webhookMessage := func(id, event string) sqs.Message {
+		return sqs.Message{
+			MessageID: id,
+			Body:      fmt.Sprintf(`{"headers": {"X-GitHub-Event": %q}, "payload": "{}"}`, event),
+		}
+	}
+	tests := []struct {
+		name        string
+		queueURL    string
+		client      *fakeSQSClient
+		status      int
+		want        bool
+		wantEvents  []string
+		wantDeleted []string
+		wantLog     string
+	}{
+		{
+			name:   "no queue",
+			client: &fakeSQSClient{},
+		},
+		{
+			name:     "cannot receive",
+			queueURL: "https://sqs.eu-west-1.amazonaws.com/123456789012/pac-events",
+			client:   &fakeSQSClient{receiveErr: fmt.Errorf("access denied")},
+			wantLog:  "cannot read the events of sqs queue https://sqs.eu-west-1.amazonaws.com/123456789012/pac-events: access denied",
+		},
+		{
+			name:     "processed messages",
+			queueURL: "https://sqs.eu-west-1.amazonaws.com/123456789012/pac-events",
+			client: &fakeSQSClient{messages: []sqs.Message{
+				webhookMessage("1", "pull_request"),
+				{MessageID: "2", Body: "invalid"},
+				webhookMessage("3", "push"),
+			}},
+			status:      http.StatusAccepted,
+			want:        true,
+			wantEvents:  []string{"pull_request", "push"},
+			wantDeleted: []string{"1", "2", "3"},
+			wantLog:     "skipping sqs message: cannot decode the webhook message 2",
+		},
+		{
+			name:     "retried messages",
+			queueURL: "https://sqs.eu-west-1.amazonaws.com/123456789012/pac-events",
+			client: &fakeSQSClient{messages: []sqs.Message{
+				webhookMessage("1", "pull_request"),
+			}},
+			status:     http.StatusServiceUnavailable,
+			want:       true,
+			wantEvents: []string{"pull_request"},
+			wantLog:    "sqs message 1 could not be processed with status 503, it will be retried",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			log, logCatcher := logger.GetLogger()
+			l := &listener{
+				run: &params.Run{
+					Info: info.Info{Pac: &info.PacOpts{Settings: settings.Settings{
+						SQSQueueURL:      tt.queueURL,
+						SQSMessageFormat: sqs.FormatWebhook,
+					}}},
+				},
+				logger: log,
+			}
+			var events []string
+			handler := http.HandlerFunc(func(response http.ResponseWriter, request *http.Request) {
+				body, err := io.ReadAll(request.Body)
+				assert.NilError(t, err)
+				assert.Equal(t, string(body), "{}")
+				events = append(events, request.Header.Get("X-GitHub-Event"))
+				l.writeResponse(response, tt.status, "")
+			})
+			newClient := func(string, string) (sqsClient, error) { return tt.client, nil }
+
+			got := l.pollSQSOnce(context.Background(), newClient, handler)
+			assert.Equal(t, got, tt.want)
+			assert.DeepEqual(t, events, tt.wantEvents)
+			assert.DeepEqual(t, tt.client.deleted, tt.wantDeleted)
+			if tt.wantLog != "" {
+				assert.Assert(t, logCatcher.FilterMessageSnippet(tt.wantLog).Len() > 0, logCatcher.All())
+			}
+		})
+	}
+}
+
+func TestPollSQSOnceRetryRefusedMessage(t *testing.T) {
+	ctx, _ := rtesting.SetupFakeContext(t)
+	l, queue := newFullQueueListener(ctx, t)
+	client := &fakeSQSClient{messages: []sqs.Message{{
+		MessageID: "1",
+		Body:      `{"headers": {"X-GitHub-Event": "push", "X-GitHub-Delivery": "abcd"}, "payload": "{\"pusher\": {\"name\": \"user\"}}"}`,
+	}}}
+	newClient := func(string, string) (sqsClient, error) { return client, nil }
+
+	// the message is kept in the queue while the events cannot be processed
+	assert.Assert(t, l.pollSQSOnce(ctx, newClient, l.handleEvent(ctx)))
+	assert.Equal(t, len(client.deleted), 0)
+
+	<-queue
+	// and processed when it is received again
+	assert.Assert(t, l.pollSQSOnce(ctx, newClient, l.handleEvent(ctx)))
+	assert.DeepEqual(t, client.deleted, []string{"1"})
+	assert.Equal(t, len(queue), 1)
+}
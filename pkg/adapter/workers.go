@@ -0,0 +1,78 @@
+package adapter
+
+import (
+	"sync"
+
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/metrics"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/params/info"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/params/settings"
+	"go.uber.org/zap"
+)
+
+// eventPool processes the events with a bounded number of workers for each
+// git provider, for the webhooks to be answered right away without starting
+// an unbounded number of goroutines when many events are received at once.
+type eventPool struct {
+	mu     sync.Mutex
+	queues map[string]chan func()
+	logger *zap.SugaredLogger
+}
+
+func newEventPool(logger *zap.SugaredLogger) *eventPool {
+	return &eventPool{queues: map[string]chan func(){}, logger: logger}
+}
+
+// eventWorkers returns the number of workers of the git provider, from the
+// event-workers-per-provider setting or else the event-workers one.
+func eventWorkers(pacInfo *info.PacOpts, provider string) int {
+	workers := pacInfo.EventWorkers
+	// the setting has been validated when it was loaded
+	if perProvider, err := settings.ParseEventWorkersPerProvider(pacInfo.EventWorkersPerProvider); err == nil {
+		if n, ok := perProvider[provider]; ok {
+			workers = n
+		}
+	}
+	return max(workers, 1)
+}
+
+// submit queues the job of an event of the git provider, its workers and its
+// queue are started with the first event. It returns false when the queue is
+// full and the event has to be refused.
+func (p *eventPool) submit(provider string, workers, queueSize int, job func()) bool {
+	p.mu.Lock()
+	queue, ok := p.queues[provider]
+	if !ok {
+		queue = make(chan func(), max(queueSize, 1))
+		p.queues[provider] = queue
+		p.logger.Infof("starting %d workers processing the events of %s", max(workers, 1), provider)
+		for range max(workers, 1) {
+			go p.work(provider, queue)
+		}
+	}
+	p.mu.Unlock()
+
+	select {
+	case queue <- job:
+		p.recordDepth(provider, len(queue))
+		return true
+	default:
+		return false
+	}
+}
+
+func (p *eventPool) work(provider string, queue chan func()) {
+	for job := range queue {
+		p.recordDepth(provider, len(queue))
+		job()
+	}
+}
+
+func (p *eventPool) recordDepth(provider string, depth int) {
+	recorder, err := metrics.NewRecorder()
+	if err != nil {
+		return
+	}
+	if err := recorder.EventQueueDepth(provider, depth); err != nil {
+		p.logger.Debugf("cannot record the event queue depth metric: %v", err)
+	}
+}
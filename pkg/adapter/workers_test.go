@@ -0,0 +1,82 @@
+package adapter
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/params/info"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/params/settings"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/test/logger"
+	"gotest.tools/v3/assert"
+)
+
+func TestEventWorkers(t *testing.T) {
+	tests := []struct {
+		name        string
+		workers     int
+		perProvider string
+		provider    string
+		want        int
+	}{
+		{
+			name:     "default workers",
+			workers:  20,
+			provider: "github",
+			want:     20,
+		},
+		{
+			name:        "workers of the provider",
+			workers:     20,
+			perProvider: "github=30, gitlab=5",
+			provider:    "gitlab",
+			want:        5,
+		},
+		{
+			name:        "provider not in the workers per provider",
+			workers:     20,
+			perProvider: "github=30",
+			provider:    "gitea",
+			want:        20,
+		},
+		{
+			name:     "at least one worker",
+			provider: "github",
+			want:     1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pacInfo := &info.PacOpts{Settings: settings.Settings{
+				EventWorkers:            tt.workers,
+				EventWorkersPerProvider: tt.perProvider,
+			}}
+			assert.Equal(t, eventWorkers(pacInfo, tt.provider), tt.want)
+		})
+	}
+}
+
+func TestEventPool(t *testing.T) {
+	log, _ := logger.GetLogger()
+	p := newEventPool(log)
+
+	// block the only worker of the provider
+	started := make(chan struct{})
+	release := make(chan struct{})
+	assert.Assert(t, p.submit("github", 1, 1, func() {
+		close(started)
+		<-release
+	}))
+	<-started
+
+	var wg sync.WaitGroup
+	wg.Add(2)
+	assert.Assert(t, p.submit("github", 1, 1, wg.Done))
+	// the queue of the provider is full
+	assert.Assert(t, !p.submit("github", 1, 1, wg.Done))
+	// the other providers have their own workers
+	assert.Assert(t, p.submit("gitlab", 1, 1, wg.Done))
+
+	close(release)
+	wg.Wait()
+	assert.Equal(t, len(p.queues["github"]), 0)
+}
@@ -0,0 +1,88 @@
+package incoming
+
+import (
+	"fmt"
+	"slices"
+
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	InputTypeString  = "string"
+	InputTypeBoolean = "boolean"
+	InputTypeChoice  = "choice"
+)
+
+// ManualInput is an input declared in the manual-inputs annotation of a
+// PipelineRun that can be triggered manually.
+type ManualInput struct {
+	Name        string   `json:"name"`
+	Description string   `json:"description,omitempty"`
+	Type        string   `json:"type,omitempty"`
+	Options     []string `json:"options,omitempty"`
+	Default     string   `json:"default,omitempty"`
+	Required    bool     `json:"required,omitempty"`
+}
+
+// ParseManualInputs parses the inputs declared in the manual-inputs annotation.
+func ParseManualInputs(annotation string) ([]ManualInput, error) {
+	inputs := []ManualInput{}
+	if err := yaml.Unmarshal([]byte(annotation), &inputs); err != nil {
+		return nil, fmt.Errorf("cannot parse manual inputs: %w", err)
+	}
+	for i, input := range inputs {
+		if input.Name == "" {
+			return nil, fmt.Errorf("manual input %d has no name", i)
+		}
+		switch input.Type {
+		case "":
+			inputs[i].Type = InputTypeString
+		case InputTypeString, InputTypeBoolean:
+		case InputTypeChoice:
+			if len(input.Options) == 0 {
+				return nil, fmt.Errorf("manual input %s of type choice has no options", input.Name)
+			}
+		default:
+			return nil, fmt.Errorf("manual input %s has an unsupported type %s, supported types are: %s, %s, %s",
+				input.Name, input.Type, InputTypeString, InputTypeBoolean, InputTypeChoice)
+		}
+	}
+	return inputs, nil
+}
+
+// ValidateManualInputs validates the params against the declared inputs and
+// returns them with the default values of the inputs that were not provided.
+func ValidateManualInputs(inputs []ManualInput, params Params) (Params, error) {
+	ret := Params{}
+	for k, v := range params {
+		ret[k] = v
+	}
+	for _, input := range inputs {
+		value, ok := params[input.Name]
+		if !ok {
+			if input.Default != "" {
+				ret[input.Name] = input.Default
+				continue
+			}
+			if input.Required {
+				return nil, fmt.Errorf("input %s is required", input.Name)
+			}
+			continue
+		}
+		svalue, ok := value.(string)
+		if !ok {
+			return nil, fmt.Errorf("input %s must be a string, got %v", input.Name, value)
+		}
+		switch input.Type {
+		case InputTypeBoolean:
+			if svalue != "true" && svalue != "false" {
+				return nil, fmt.Errorf("input %s must be a boolean, got %s", input.Name, svalue)
+			}
+		case InputTypeChoice:
+			if !slices.Contains(input.Options, svalue) {
+				return nil, fmt.Errorf("input %s must be one of %v, got %s", input.Name, input.Options, svalue)
+			}
+		}
+	}
+	return ret, nil
+}
@@ -0,0 +1,88 @@
+package incoming
+
+import (
+	"testing"
+
+	"gotest.tools/v3/assert"
+)
+
+func TestParseManualInputs(t *testing.T) {
+	inputs, err := ParseManualInputs(`
+- name: environment
+  type: choice
+  options: [staging, production]
+  default: staging
+- name: dry_run
+  type: boolean
+- name: version
+  required: true
+`)
+	assert.NilError(t, err)
+	assert.Equal(t, len(inputs), 3)
+	assert.Equal(t, inputs[2].Type, InputTypeString)
+
+	_, err = ParseManualInputs(`[{"name": "foo", "type": "number"}]`)
+	assert.ErrorContains(t, err, "unsupported type number")
+
+	_, err = ParseManualInputs(`[{"name": "foo", "type": "choice"}]`)
+	assert.ErrorContains(t, err, "has no options")
+
+	_, err = ParseManualInputs(`[{"type": "string"}]`)
+	assert.ErrorContains(t, err, "has no name")
+}
+
+func TestValidateManualInputs(t *testing.T) {
+	inputs := []ManualInput{
+		{Name: "environment", Type: InputTypeChoice, Options: []string{"staging", "production"}, Default: "staging"},
+		{Name: "dry_run", Type: InputTypeBoolean},
+		{Name: "version", Type: InputTypeString, Required: true},
+	}
+	tests := []struct {
+		name    string
+		params  Params
+		want    Params
+		wantErr string
+	}{
+		{
+			name:   "defaults are set",
+			params: Params{"version": "1.0"},
+			want:   Params{"version": "1.0", "environment": "staging"},
+		},
+		{
+			name:   "all inputs",
+			params: Params{"version": "1.0", "environment": "production", "dry_run": "true"},
+			want:   Params{"version": "1.0", "environment": "production", "dry_run": "true"},
+		},
+		{
+			name:    "missing required",
+			params:  Params{},
+			wantErr: "input version is required",
+		},
+		{
+			name:    "invalid choice",
+			params:  Params{"version": "1.0", "environment": "dev"},
+			wantErr: "input environment must be one of [staging production], got dev",
+		},
+		{
+			name:    "invalid boolean",
+			params:  Params{"version": "1.0", "dry_run": "yes"},
+			wantErr: "input dry_run must be a boolean, got yes",
+		},
+		{
+			name:    "not a string",
+			params:  Params{"version": 1},
+			wantErr: "input version must be a string",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ValidateManualInputs(inputs, tt.params)
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			assert.NilError(t, err)
+			assert.DeepEqual(t, got, tt.want)
+		})
+	}
+}
@@ -53,6 +53,7 @@ const (
 	OnTargetBranch         = pipelinesascode.GroupName + "/on-target-branch"
 	OnPathChange           = pipelinesascode.GroupName + "/on-path-change"
 	OnLabel                = pipelinesascode.GroupName + "/on-label"
+	OnReviewApproved       = pipelinesascode.GroupName + "/on-review-approved"
 	OnPathChangeIgnore     = pipelinesascode.GroupName + "/on-path-change-ignore"
 	OnCelExpression        = pipelinesascode.GroupName + "/on-cel-expression"
 	TargetNamespace        = pipelinesascode.GroupName + "/target-namespace"
@@ -61,11 +62,45 @@ const (
 	LogURL                 = pipelinesascode.GroupName + "/log-url"
 	ExecutionOrder         = pipelinesascode.GroupName + "/execution-order"
 	SCMReportingPLRStarted = pipelinesascode.GroupName + "/scm-reporting-plr-started"
+	QueuePendingTimeout    = pipelinesascode.GroupName + "/queue-pending-timeout"
+	ManualInputs           = pipelinesascode.GroupName + "/manual-inputs"
+	Matrix                 = pipelinesascode.GroupName + "/matrix"
+	MatrixParent           = pipelinesascode.GroupName + "/matrix-parent"
+	MatrixCell             = pipelinesascode.GroupName + "/matrix-cell"
+	PerCommit              = pipelinesascode.GroupName + "/per-commit"
+	PerCommitParent        = pipelinesascode.GroupName + "/per-commit-parent"
+	Canary                 = pipelinesascode.GroupName + "/canary"
+	CanaryRole             = pipelinesascode.GroupName + "/canary-role"
+	DependsOn              = pipelinesascode.GroupName + "/depends-on"
+	ExportEncrypted        = pipelinesascode.GroupName + "/export-encrypted"
+	AutoConfigureWebhook   = pipelinesascode.GroupName + "/auto-configure-webhook"
+	WebhookID              = pipelinesascode.GroupName + "/webhook-id"
+	WebhookListener        = pipelinesascode.GroupName + "/webhook-listener"
+	StatusContext          = pipelinesascode.GroupName + "/status-context"
+	PipelineRunNamespace   = pipelinesascode.GroupName + "/pipelinerun-namespace"
+	RepositoryNamespace    = pipelinesascode.GroupName + "/repository-namespace"
+	EphemeralNamespace     = pipelinesascode.GroupName + "/ephemeral-namespace"
+	EphemeralNamespaceTTL  = pipelinesascode.GroupName + "/ephemeral-namespace-ttl"
+	ApprovedBy             = pipelinesascode.GroupName + "/approved-by"
+	CoverageResult         = pipelinesascode.GroupName + "/coverage-result"
+	CoverageThreshold      = pipelinesascode.GroupName + "/coverage-threshold"
+	JUnitResult            = pipelinesascode.GroupName + "/junit-result"
+	Timeout                = pipelinesascode.GroupName + "/timeout"
+	OrgRepository          = pipelinesascode.GroupName + "/org-repository"
+	FailureReason          = pipelinesascode.GroupName + "/failure-reason"
+	PollState              = pipelinesascode.GroupName + "/poll-state"
+	TicketID               = pipelinesascode.GroupName + "/ticket-id"
+	GitClone               = pipelinesascode.GroupName + "/git-clone"
+	GitCloneDepth          = pipelinesascode.GroupName + "/git-clone-depth"
+	GitCloneSubmodules     = pipelinesascode.GroupName + "/git-clone-submodules"
+	GitCloneLFS            = pipelinesascode.GroupName + "/git-clone-lfs"
+	SparseCheckout         = pipelinesascode.GroupName + "/sparse-checkout"
 	// PublicGithubAPIURL default is "https://api.github.com" but it can be overridden by X-GitHub-Enterprise-Host header.
 	PublicGithubAPIURL   = "https://api.github.com"
 	GithubApplicationID  = "github-application-id"
 	GithubPrivateKey     = "github-private-key"
 	ResultsRecordSummary = "results.tekton.dev/recordSummaryAnnotations"
+	ResultsLog           = "results.tekton.dev/log"
 )
 
 var ParamsRe = regexp.MustCompile(`{{([^}]{2,})}}`)
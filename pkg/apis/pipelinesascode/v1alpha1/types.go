@@ -1,6 +1,8 @@
 package v1alpha1
 
 import (
+	"slices"
+
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	duckv1 "knative.dev/pkg/apis/duck/v1"
 )
@@ -17,12 +19,170 @@ import (
 // +kubebuilder:printcolumn:name="Reason",type=string,JSONPath=`.pipelinerun_status[-1].conditions[?(@.type=="Succeeded")].reason`
 // +kubebuilder:printcolumn:name="StartTime",type=date,JSONPath=`.pipelinerun_status[-1].startTime`
 // +kubebuilder:printcolumn:name="CompletionTime",type=date,JSONPath=`.pipelinerun_status[-1].completionTime`
+// +kubebuilder:printcolumn:name="Provider",type=string,JSONPath=`.status.provider`
+// +kubebuilder:printcolumn:name="LastEvent",type=string,JSONPath=`.status.lastEvent`
+// +kubebuilder:printcolumn:name="LastStatus",type=string,JSONPath=`.status.lastStatus`
+// +kubebuilder:printcolumn:name="Queue",type=integer,JSONPath=`.status.queued`
 type Repository struct {
 	metav1.TypeMeta   `json:",inline"`
 	metav1.ObjectMeta `json:"metadata,omitempty"`
 
 	Spec   RepositorySpec        `json:"spec"`
 	Status []RepositoryRunStatus `json:"pipelinerun_status,omitempty"`
+
+	// RepositoryStatus is the status subresource of the Repository, it
+	// summarizes the last run and is updated by the watcher.
+	// +optional
+	RepositoryStatus *RepositoryStatus `json:"status,omitempty"`
+}
+
+// RepositoryStatus summarizes the last PipelineRun of a Repository.
+type RepositoryStatus struct {
+	// ObservedGeneration is the generation of the Repository the watcher
+	// last acted on.
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// Provider is the Git provider of the last event, ie: github, gitlab.
+	// +optional
+	Provider string `json:"provider,omitempty"`
+
+	// LastEvent is the type of the last event, ie: pull_request, push.
+	// +optional
+	LastEvent string `json:"lastEvent,omitempty"`
+
+	// LastStatus is the reason of the last PipelineRun, ie: Succeeded, Failed.
+	// +optional
+	LastStatus string `json:"lastStatus,omitempty"`
+
+	// Queued is the number of PipelineRuns waiting in the concurrency queue.
+	// +optional
+	Queued int `json:"queued,omitempty"`
+
+	// GitLabProjectPath is the path of the GitLab project the GitLabProjectID
+	// has been resolved from.
+	// +optional
+	GitLabProjectPath string `json:"gitlabProjectPath,omitempty"`
+
+	// GitLabProjectID is the numeric ID of the GitLab project of the
+	// Repository, resolved from its path with the API.
+	// +optional
+	GitLabProjectID int `json:"gitlabProjectID,omitempty"`
+
+	// Coverage is the last code coverage reported by the PipelineRuns of the
+	// push events, per branch, used as the base of the pull requests.
+	// +optional
+	// +listType=map
+	// +listMapKey=branch
+	Coverage []BranchCoverage `json:"coverage,omitempty"`
+
+	// Conditions are the latest observations of the Repository, ie: whether
+	// the token of its git provider is valid.
+	// +optional
+	// +listType=map
+	// +listMapKey=type
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+
+	// LastFailure is the last failure of the events of the Repository.
+	// +optional
+	LastFailure *RepositoryFailure `json:"lastFailure,omitempty"`
+
+	// Usage is the usage of the completed PipelineRuns per day, kept for
+	// the last 90 days.
+	// +optional
+	// +listType=map
+	// +listMapKey=date
+	Usage []DailyUsage `json:"usage,omitempty"`
+}
+
+// FailureReason is the category of a failure of a Repository, the same
+// reasons are used in its status, its events, the metrics and the CLI.
+// +kubebuilder:validation:Enum=ACLDenied;YAMLInvalid;ResolverError;ProviderAPIError;QuotaExceeded;Timeout;CheckoutFailed
+type FailureReason string
+
+const (
+	// FailureReasonACLDenied is when the sender is not allowed to run the CI.
+	FailureReasonACLDenied FailureReason = "ACLDenied"
+	// FailureReasonYAMLInvalid is when the PipelineRuns of the .tekton
+	// directory are not valid.
+	FailureReasonYAMLInvalid FailureReason = "YAMLInvalid"
+	// FailureReasonResolverError is when the remote tasks or pipelines of the
+	// PipelineRuns cannot be resolved.
+	FailureReasonResolverError FailureReason = "ResolverError"
+	// FailureReasonProviderAPIError is when a call to the API of the git
+	// provider fails, ie: to set a status or create a comment.
+	FailureReasonProviderAPIError FailureReason = "ProviderAPIError"
+	// FailureReasonQuotaExceeded is when a PipelineRun cannot be created
+	// because of a resource quota of its namespace.
+	FailureReasonQuotaExceeded FailureReason = "QuotaExceeded"
+	// FailureReasonTimeout is when a PipelineRun timed out, running or
+	// waiting in the queue.
+	FailureReasonTimeout FailureReason = "Timeout"
+	// FailureReasonCheckoutFailed is when a PipelineRun failed to check out
+	// the source of the event.
+	FailureReasonCheckoutFailed FailureReason = "CheckoutFailed"
+)
+
+// FailureReasons are all the failure reasons.
+var FailureReasons = []FailureReason{
+	FailureReasonACLDenied,
+	FailureReasonYAMLInvalid,
+	FailureReasonResolverError,
+	FailureReasonProviderAPIError,
+	FailureReasonQuotaExceeded,
+	FailureReasonTimeout,
+	FailureReasonCheckoutFailed,
+}
+
+// RepositoryFailure is a failure of a Repository.
+type RepositoryFailure struct {
+	// Reason is the category of the failure.
+	Reason FailureReason `json:"reason"`
+
+	// Message is the message of the failure.
+	// +optional
+	Message string `json:"message,omitempty"`
+
+	// Time is when the failure happened.
+	Time metav1.Time `json:"time"`
+}
+
+// BranchCoverage is the code coverage of a branch.
+type BranchCoverage struct {
+	// Branch is the name of the branch, without the refs/heads/ prefix.
+	Branch string `json:"branch"`
+
+	// Percentage is the coverage in percent, ie: 83.4.
+	Percentage string `json:"percentage"`
+
+	// SHA is the commit the coverage has been computed on.
+	// +optional
+	SHA string `json:"sha,omitempty"`
+}
+
+// DailyUsage is the usage of the PipelineRuns completed on a day.
+type DailyUsage struct {
+	// Date is the day the PipelineRuns have completed on in UTC, ie:
+	// 2026-10-16.
+	Date string `json:"date"`
+
+	// Runs is the number of PipelineRuns.
+	Runs int `json:"runs"`
+
+	// DurationSeconds is the total duration of the PipelineRuns.
+	DurationSeconds int64 `json:"durationSeconds"`
+
+	// CPUMillicoreSeconds is the CPU requested by the TaskRuns multiplied by
+	// their duration, only recorded with the usage-resource-accounting
+	// setting.
+	// +optional
+	CPUMillicoreSeconds int64 `json:"cpuMillicoreSeconds,omitempty"`
+
+	// MemoryMebibyteSeconds is the memory requested by the TaskRuns
+	// multiplied by their duration, only recorded with the
+	// usage-resource-accounting setting.
+	// +optional
+	MemoryMebibyteSeconds int64 `json:"memoryMebibyteSeconds,omitempty"`
 }
 
 type RepositoryRunStatus struct {
@@ -32,6 +192,11 @@ type RepositoryRunStatus struct {
 	// +optional
 	PipelineRunName string `json:"pipelineRunName,omitempty"`
 
+	// OriginalPipelineRunName is the name of the PipelineRun as defined in the
+	// .tekton directory, it is used to compare runs of the same pipeline.
+	// +optional
+	OriginalPipelineRunName string `json:"originalPipelineRunName,omitempty"`
+
 	// StartTime is the time the PipelineRun is actually started.
 	// +optional
 	StartTime *metav1.Time `json:"startTime,omitempty"`
@@ -112,6 +277,30 @@ type RepositorySpec struct {
 	// authorization policies, provider-specific configuration, and provenance settings.
 	// +optional
 	Settings *Settings `json:"settings,omitempty"`
+
+	// Organization makes an organization level Repository match the repositories
+	// under its URL which have no Repository, one is created for them on their
+	// first event. It is not inherited by the Repositories of the organization.
+	// +optional
+	Organization *Organization `json:"organization,omitempty"`
+}
+
+// Organization defines where the Repositories of the repositories of an
+// organization are created.
+type Organization struct {
+	// NamespaceTemplate is the namespace of the created Repositories, with the
+	// {{repo_owner}} and {{repo_name}} placeholders, ie: {{repo_name}}-ci. The
+	// Repositories are created in the namespace of the organization Repository
+	// when it is empty.
+	// +optional
+	NamespaceTemplate string `json:"namespace_template,omitempty"`
+
+	// NamespacePolicy defines what happens when the namespace of a created
+	// Repository doesn't exist: with "existing", the default, the event is
+	// skipped, with "create" the namespace is created.
+	// +optional
+	// +kubebuilder:validation:Enum=existing;create
+	NamespacePolicy string `json:"namespace_policy,omitempty"`
 }
 
 func (r *RepositorySpec) Merge(newRepo RepositorySpec) {
@@ -135,6 +324,75 @@ func (r *RepositorySpec) Merge(newRepo RepositorySpec) {
 	}
 }
 
+// Inherit fills the spec with the values of the organization level
+// Repository parent. Unlike Merge, the settings and git provider of the parent
+// are used when the spec has none and the params of the parent are added when
+// the spec doesn't define a param of the same name, the spec always wins.
+func (r *RepositorySpec) Inherit(parent RepositorySpec) {
+	if parent.ConcurrencyLimit != nil && r.ConcurrencyLimit == nil {
+		r.ConcurrencyLimit = parent.ConcurrencyLimit
+	}
+	if parent.Settings != nil {
+		if r.Settings == nil {
+			settings := *parent.Settings
+			r.Settings = &settings
+		} else {
+			r.Settings.Merge(parent.Settings)
+		}
+	}
+	if parent.GitProvider != nil {
+		if r.GitProvider == nil {
+			gitProvider := *parent.GitProvider
+			r.GitProvider = &gitProvider
+		} else {
+			r.GitProvider.Merge(parent.GitProvider)
+		}
+	}
+	if parent.Incomings != nil && r.Incomings == nil {
+		r.Incomings = parent.Incomings
+	}
+	if parent.Params != nil {
+		params := []Params{}
+		if r.Params != nil {
+			params = append(params, *r.Params...)
+		}
+		defined := len(params)
+		for _, param := range *parent.Params {
+			if !slices.ContainsFunc(params[:defined], func(p Params) bool { return p.Name == param.Name }) {
+				params = append(params, param)
+			}
+		}
+		r.Params = &params
+	}
+}
+
+// GetApprovalPolicy returns the approval policy of the repository, defaulting
+// to requiring an approval only from the senders who are not members.
+func (r *RepositorySpec) GetApprovalPolicy() string {
+	if r.Settings == nil || r.Settings.ApprovalPolicy == "" {
+		return ApprovalPolicyNonMembers
+	}
+	return r.Settings.ApprovalPolicy
+}
+
+// GetTektonChangesPolicy returns the policy of the pull requests changing the
+// .tekton directory, defaulting to allowing them.
+func (r *RepositorySpec) GetTektonChangesPolicy() string {
+	if r.Settings == nil || r.Settings.TektonChangesPolicy == "" {
+		return TektonChangesPolicyAllow
+	}
+	return r.Settings.TektonChangesPolicy
+}
+
+// GetSecretScanning returns the secret scanning severity of the repository,
+// defaulting to off.
+func (r *RepositorySpec) GetSecretScanning() string {
+	if r.Settings == nil || r.Settings.SecretScanning == "" {
+		return SecretScanningOff
+	}
+	return r.Settings.SecretScanning
+}
+
 type Settings struct {
 	// GithubAppTokenScopeRepos lists repositories that can access the GitHub App token when using the
 	// GitHub App authentication method. This allows specific repositories to use tokens generated for
@@ -155,11 +413,316 @@ type Settings struct {
 	// +optional
 	Policy *Policy `json:"policy,omitempty"`
 
+	// ApprovalPolicy defines who needs an /ok-to-test before running CI on a
+	// pull request.
+	// Options:
+	// - 'non-members': Only the senders not allowed by the policy, the OWNERS
+	// file or their membership need an approval (default)
+	// - 'always': Every pull request needs an /ok-to-test from an allowed user,
+	// including the ones sent by members
+	// - 'never': No pull request needs an approval
+	// +optional
+	// +kubebuilder:validation:Enum=always;non-members;never
+	ApprovalPolicy string `json:"approval_policy,omitempty"`
+
+	// TektonChangesPolicy defines what happens when a pull request changes
+	// the .tekton directory and its sender is not an owner of the repository,
+	// listed in the OWNERS file or the ok_to_test policy.
+	// Options:
+	// - 'allow': The PipelineRuns of the pull request run as usual (default)
+	// - 'require_approval': The pull request needs an /ok-to-test from an
+	// allowed user, even when its sender is allowed to run CI
+	// - 'target_branch': The PipelineRuns of the target branch run instead of
+	// the ones of the pull request
+	// +optional
+	// +kubebuilder:validation:Enum=allow;require_approval;target_branch
+	TektonChangesPolicy string `json:"tekton_changes_policy,omitempty"`
+
+	// SecretScanning defines what happens when a credential is found in the
+	// PipelineRuns of the .tekton directory before creating them.
+	// Options:
+	// - 'off': The PipelineRuns are not scanned (default)
+	// - 'warn': The PipelineRuns are created and the credentials found are
+	// reported on the pull request
+	// - 'block': The PipelineRuns are not created
+	// +optional
+	// +kubebuilder:validation:Enum=off;warn;block
+	SecretScanning string `json:"secret_scanning,omitempty"`
+
+	// PendingApprovalComment configures the comment posted on the pull
+	// requests waiting for an /ok-to-test.
+	// +optional
+	PendingApprovalComment *PendingApprovalComment `json:"pending_approval_comment,omitempty"`
+
 	// Gitlab contains GitLab-specific settings for repositories hosted on GitLab.
 	// +optional
 	Gitlab *GitlabSettings `json:"gitlab,omitempty"`
 
 	Github *GithubSettings `json:"github,omitempty"`
+
+	// Gitea contains Gitea-specific settings for repositories hosted on Gitea.
+	// +optional
+	Gitea *GiteaSettings `json:"gitea,omitempty"`
+
+	// ReportSkippedPipelineRuns reports a neutral status with the reason why a
+	// PipelineRun from the .tekton directory didn't match the event, instead of
+	// silently ignoring it.
+	// +optional
+	ReportSkippedPipelineRuns bool `json:"report_skipped_pipelineruns,omitempty"`
+
+	// DefaultPipelinesConfigMap is the name of a ConfigMap holding the PipelineRuns
+	// to run for the repositories without a .tekton directory. It is only honored
+	// on the global Repository and read from its namespace.
+	// +optional
+	DefaultPipelinesConfigMap string `json:"default_pipelines_configmap,omitempty"`
+
+	// ExecutionCluster is a remote cluster where the PipelineRuns of the
+	// repository are created instead of the cluster of the controller.
+	// +optional
+	ExecutionCluster *ExecutionCluster `json:"execution_cluster,omitempty"`
+
+	// AllowedPipelineRunNamespaces lists the namespaces a PipelineRun of the
+	// repository can be created in with the pipelinerun-namespace annotation,
+	// instead of the namespace of the Repository. It is not inherited from the
+	// global Repository.
+	// +optional
+	AllowedPipelineRunNamespaces []string `json:"allowed_pipelinerun_namespaces,omitempty"`
+
+	// EphemeralNamespace runs each PipelineRun of the repository in a
+	// namespace created for it and deleted once it has completed.
+	// +optional
+	EphemeralNamespace *EphemeralNamespace `json:"ephemeral_namespace,omitempty"`
+
+	// PushDebounce is a duration like "30s" during which successive pushes to
+	// the same branch are collapsed into a single run for the latest SHA.
+	// +optional
+	PushDebounce string `json:"push_debounce,omitempty"`
+
+	// CIVariables copies CI variables of the Git provider into a secret of
+	// the namespace of the PipelineRuns each time they are triggered.
+	// +optional
+	CIVariables *CIVariables `json:"ci_variables,omitempty"`
+
+	// DryRun processes the events of the repository, matching, resolving and
+	// checking the permissions, and reports the PipelineRuns that would run on
+	// the Git provider without creating them.
+	// +optional
+	DryRun bool `json:"dry_run,omitempty"`
+
+	// Polling queries the Git provider for new commits and pull requests of
+	// the repository at an interval, for the clusters the webhooks of the
+	// provider cannot reach. It is not inherited from the global Repository.
+	// +optional
+	Polling *Polling `json:"polling,omitempty"`
+
+	// IssueTracker links the PipelineRuns to the ticket of an issue tracker
+	// found in the branch name or the commit message of the event.
+	// +optional
+	IssueTracker *IssueTracker `json:"issue_tracker,omitempty"`
+
+	// TriggerWindows restricts the push and pull request events
+	// automatically triggering the PipelineRuns to some windows of time.
+	// +optional
+	TriggerWindows *TriggerWindows `json:"trigger_windows,omitempty"`
+}
+
+const (
+	// ApprovalPolicyAlways requires an /ok-to-test on every pull request.
+	ApprovalPolicyAlways = "always"
+	// ApprovalPolicyNonMembers requires an /ok-to-test on the pull requests of
+	// the senders who are not allowed to run CI.
+	ApprovalPolicyNonMembers = "non-members"
+	// ApprovalPolicyNever never requires an /ok-to-test.
+	ApprovalPolicyNever = "never"
+)
+
+const (
+	// TektonChangesPolicyAllow runs the PipelineRuns of the pull requests
+	// changing the .tekton directory as usual.
+	TektonChangesPolicyAllow = "allow"
+	// TektonChangesPolicyRequireApproval requires an /ok-to-test on the pull
+	// requests of the non owners changing the .tekton directory.
+	TektonChangesPolicyRequireApproval = "require_approval"
+	// TektonChangesPolicyTargetBranch runs the PipelineRuns of the target
+	// branch on the pull requests of the non owners changing the .tekton
+	// directory.
+	TektonChangesPolicyTargetBranch = "target_branch"
+)
+
+const (
+	// SecretScanningOff does not scan the PipelineRuns for credentials.
+	SecretScanningOff = "off"
+	// SecretScanningWarn reports the credentials found in the PipelineRuns
+	// and creates them.
+	SecretScanningWarn = "warn"
+	// SecretScanningBlock refuses to create the PipelineRuns where
+	// credentials have been found.
+	SecretScanningBlock = "block"
+)
+
+const (
+	// TriggerWindowsOutsideSkip doesn't create the PipelineRuns of the events
+	// received outside of the trigger windows.
+	TriggerWindowsOutsideSkip = "skip"
+	// TriggerWindowsOutsideQueue creates the PipelineRuns of the events
+	// received outside of the trigger windows pending until the next window.
+	TriggerWindowsOutsideQueue = "queue"
+)
+
+// PendingApprovalComment configures the comment explaining how to approve
+// running CI on a pull request.
+type PendingApprovalComment struct {
+	// Disabled doesn't post the comment.
+	// +optional
+	Disabled bool `json:"disabled,omitempty"`
+
+	// Template is a Go template of the comment replacing the default one, it
+	// can use {{ .Sender }}, {{ .Approvers }} and {{ .Command }}.
+	// +optional
+	Template string `json:"template,omitempty"`
+}
+
+// Polling configures the events synthesized from the heads of the branches
+// and of the open pull requests found on the Git provider.
+type Polling struct {
+	// Interval is a duration like "5m" between two queries of the Git
+	// provider, it can't be less than one minute.
+	Interval string `json:"interval"`
+
+	// Branches lists the branches whose new commits trigger a push event, it
+	// defaults to the default branch of the repository.
+	// +optional
+	Branches []string `json:"branches,omitempty"`
+
+	// PullRequests triggers a pull_request event for the new commits of the
+	// open pull requests.
+	// +optional
+	PullRequests bool `json:"pull_requests,omitempty"`
+}
+
+// IssueTracker configures how the ticket of an event is found and linked.
+type IssueTracker struct {
+	// TicketRegex is a regular expression matching the ticket ID in the source
+	// branch, the commit message or the pull request title, the ID is its
+	// first group when it has one. It defaults to Jira keys like PROJ-123.
+	// +optional
+	TicketRegex string `json:"ticket_regex,omitempty"`
+
+	// URL of a ticket where {{ ticket_id }} is replaced by its ID, i.e:
+	// https://example.atlassian.net/browse/{{ ticket_id }}. The link is added
+	// to the status of the PipelineRuns.
+	// +optional
+	URL string `json:"url,omitempty"`
+
+	// Jira comments on the Jira issue of the ticket once the PipelineRuns have
+	// completed, with the jira post-run hook.
+	// +optional
+	Jira *JiraIntegration `json:"jira,omitempty"`
+}
+
+// JiraIntegration is the Jira instance the comments are posted to.
+type JiraIntegration struct {
+	// URL of the Jira instance, i.e: https://example.atlassian.net.
+	URL string `json:"url"`
+
+	// User is the email of the account of a Jira Cloud API token, the token is
+	// used as a personal access token of Jira Data Center when it is not set.
+	// +optional
+	User string `json:"user,omitempty"`
+
+	// Secret holds the token in the namespace of the Repository, its key
+	// defaults to "token".
+	Secret *Secret `json:"secret"`
+}
+
+// TriggerWindows are the windows of time during which the push and pull
+// request events automatically trigger the PipelineRuns.
+type TriggerWindows struct {
+	// Timezone is the IANA name of the timezone of the windows, i.e:
+	// Europe/Paris. It defaults to UTC.
+	// +optional
+	Timezone string `json:"timezone,omitempty"`
+
+	// Windows lists the windows of time, an event is processed when it is
+	// received during one of them.
+	Windows []TriggerWindow `json:"windows"`
+
+	// Outside defines what happens to the events received outside of the
+	// windows.
+	// Options:
+	// - 'skip': The PipelineRuns are not created, a /retest comment runs them
+	// (default)
+	// - 'queue': The PipelineRuns are created pending and start when the next
+	// window opens
+	// +optional
+	// +kubebuilder:validation:Enum=skip;queue
+	Outside string `json:"outside,omitempty"`
+}
+
+// TriggerWindow is a window of time repeated on some days of the week.
+type TriggerWindow struct {
+	// Days is a day of the week field of a cron expression, i.e: "mon-fri" or
+	// "1-5,6". It defaults to every day.
+	// +optional
+	Days string `json:"days,omitempty"`
+
+	// Start is the time the window opens, i.e: "09:00".
+	Start string `json:"start"`
+
+	// End is the time the window closes, i.e: "17:30". The window ends the
+	// next day when it is not after Start.
+	End string `json:"end"`
+}
+
+// CIVariables maps the CI variables of the Git provider, GitLab CI/CD
+// variables or GitHub and Gitea Actions variables, to the keys of a secret.
+type CIVariables struct {
+	// SecretName is the name of the secret created or updated in the
+	// namespace of the PipelineRun.
+	SecretName string `json:"secret_name"`
+
+	// Variables lists the CI variables copied into the secret.
+	Variables []CIVariable `json:"variables"`
+}
+
+// CIVariable maps a CI variable of the Git provider to a key of the secret.
+type CIVariable struct {
+	// Name of the CI variable on the Git provider.
+	Name string `json:"name"`
+
+	// Key of the secret the variable is copied to, it defaults to the name
+	// of the variable.
+	// +optional
+	Key string `json:"key,omitempty"`
+}
+
+// EphemeralNamespace configures the namespaces created for each PipelineRun
+// of a repository.
+type EphemeralNamespace struct {
+	// Secrets lists the secrets of the Repository namespace copied to the
+	// namespace of each PipelineRun.
+	// +optional
+	Secrets []string `json:"secrets,omitempty"`
+
+	// TTL is how long the namespace is kept once its PipelineRun has
+	// completed, as a duration like "30m". It defaults to one hour.
+	// +optional
+	TTL string `json:"ttl,omitempty"`
+
+	// ForkPullRequestsOnly only creates a namespace for the PipelineRuns of
+	// pull requests coming from a fork, the other ones run in the Repository
+	// namespace.
+	// +optional
+	ForkPullRequestsOnly bool `json:"fork_pull_requests_only,omitempty"`
+}
+
+// ExecutionCluster is a remote cluster running the PipelineRuns of a
+// repository, they are created in the namespace of the same name as the
+// Repository namespace.
+type ExecutionCluster struct {
+	// Secret is the secret in the namespace of the Repository holding the
+	// kubeconfig of the execution cluster, the key defaults to "kubeconfig".
+	Secret *Secret `json:"secret"`
 }
 
 type GitlabSettings struct {
@@ -180,6 +743,21 @@ type GithubSettings struct {
 	CommentStrategy string `json:"comment_strategy,omitempty"`
 }
 
+type GiteaSettings struct {
+	// CancelOnForcePush cancels the running PipelineRuns of the pull request head
+	// SHA overwritten by a force push.
+	// +optional
+	CancelOnForcePush bool `json:"cancel_on_force_push,omitempty"`
+
+	// CommentStrategy defines how Gitea comments are handled for pipeline results.
+	// Options:
+	// - 'disable_all': Disables all comments on pull requests
+	// - 'checks_summary': Reports the statuses in a single comment of the pull request, updated on every status
+	// +optional
+	// +kubebuilder:validation:Enum="";disable_all;checks_summary
+	CommentStrategy string `json:"comment_strategy,omitempty"`
+}
+
 func (s *Settings) Merge(newSettings *Settings) {
 	if newSettings.PipelineRunProvenance != "" && s.PipelineRunProvenance == "" {
 		s.PipelineRunProvenance = newSettings.PipelineRunProvenance
@@ -187,9 +765,42 @@ func (s *Settings) Merge(newSettings *Settings) {
 	if newSettings.Policy != nil && s.Policy == nil {
 		s.Policy = newSettings.Policy
 	}
+	if newSettings.ApprovalPolicy != "" && s.ApprovalPolicy == "" {
+		s.ApprovalPolicy = newSettings.ApprovalPolicy
+	}
+	if newSettings.TektonChangesPolicy != "" && s.TektonChangesPolicy == "" {
+		s.TektonChangesPolicy = newSettings.TektonChangesPolicy
+	}
+	if newSettings.SecretScanning != "" && s.SecretScanning == "" {
+		s.SecretScanning = newSettings.SecretScanning
+	}
+	if newSettings.PendingApprovalComment != nil && s.PendingApprovalComment == nil {
+		s.PendingApprovalComment = newSettings.PendingApprovalComment
+	}
 	if newSettings.GithubAppTokenScopeRepos != nil && s.GithubAppTokenScopeRepos == nil {
 		s.GithubAppTokenScopeRepos = newSettings.GithubAppTokenScopeRepos
 	}
+	if newSettings.ReportSkippedPipelineRuns && !s.ReportSkippedPipelineRuns {
+		s.ReportSkippedPipelineRuns = newSettings.ReportSkippedPipelineRuns
+	}
+	if newSettings.ExecutionCluster != nil && s.ExecutionCluster == nil {
+		s.ExecutionCluster = newSettings.ExecutionCluster
+	}
+	if newSettings.EphemeralNamespace != nil && s.EphemeralNamespace == nil {
+		s.EphemeralNamespace = newSettings.EphemeralNamespace
+	}
+	if newSettings.PushDebounce != "" && s.PushDebounce == "" {
+		s.PushDebounce = newSettings.PushDebounce
+	}
+	if newSettings.DryRun && !s.DryRun {
+		s.DryRun = newSettings.DryRun
+	}
+	if newSettings.IssueTracker != nil && s.IssueTracker == nil {
+		s.IssueTracker = newSettings.IssueTracker
+	}
+	if newSettings.TriggerWindows != nil && s.TriggerWindows == nil {
+		s.TriggerWindows = newSettings.TriggerWindows
+	}
 }
 
 type Policy struct {
@@ -283,6 +894,27 @@ type GitProvider struct {
 	// +optional
 	// +kubebuilder:validation:Enum=github;gitlab;bitbucket-datacenter;bitbucket-cloud;gitea
 	Type string `json:"type,omitempty"`
+
+	// SSH fetches the .tekton directory with git archive over SSH with a deploy
+	// key instead of the API of the Git provider, for the instances
+	// restricting the API access. The token of the Secret is still used to
+	// report the statuses.
+	// +optional
+	SSH *SSHFetch `json:"ssh,omitempty"`
+}
+
+// SSHFetch defines how the .tekton directory is fetched over SSH.
+type SSHFetch struct {
+	// Secret contains the private key of a deploy key of the repository, in
+	// its ssh-privatekey key by default, and the known hosts of the Git
+	// server in its known_hosts key.
+	Secret *Secret `json:"secret"`
+
+	// URL is the SSH URL of the repository, ie:
+	// ssh://git@gitlab.example.com:2222/group/repo.git, it defaults to
+	// git@<host>:<path>.git from the URL of the Repository.
+	// +optional
+	URL string `json:"url,omitempty"`
 }
 
 func (g *GitProvider) Merge(newGitProvider *GitProvider) {
@@ -305,6 +937,9 @@ func (g *GitProvider) Merge(newGitProvider *GitProvider) {
 	if newGitProvider.WebhookSecret != nil && g.WebhookSecret == nil {
 		g.WebhookSecret = newGitProvider.WebhookSecret
 	}
+	if newGitProvider.SSH != nil && g.SSH == nil {
+		g.SSH = newGitProvider.SSH
+	}
 }
 
 type Secret struct {
@@ -24,9 +24,14 @@ func TestMergeSpecs(t *testing.T) {
 		WebhookSecret: &Secret{
 			Name: "webhook",
 		},
+		SSH: &SSHFetch{
+			Secret: &Secret{Name: "ssh"},
+		},
 		Type: "type1",
 	}
 	params := &[]Params{{Name: "name", Value: "value"}}
+	issueTracker := &IssueTracker{URL: "https://jira.example.com/browse/{{ ticket_id }}"}
+	triggerWindows := &TriggerWindows{Windows: []TriggerWindow{{Days: "mon-fri", Start: "09:00", End: "17:00"}}}
 	tests := []struct {
 		name     string
 		local    *RepositorySpec
@@ -58,6 +63,11 @@ func TestMergeSpecs(t *testing.T) {
 					Policy: &Policy{
 						OkToTest: []string{"ok1", "ok2"},
 					},
+					ApprovalPolicy:      ApprovalPolicyAlways,
+					TektonChangesPolicy: TektonChangesPolicyTargetBranch,
+					SecretScanning:      SecretScanningBlock,
+					IssueTracker:        issueTracker,
+					TriggerWindows:      triggerWindows,
 				}, // Initialize as needed
 				GitProvider:      gp, // Initialize as needed
 				Incomings:        incomings,
@@ -71,6 +81,11 @@ func TestMergeSpecs(t *testing.T) {
 					Policy: &Policy{
 						OkToTest: []string{"ok1", "ok2"},
 					},
+					ApprovalPolicy:      ApprovalPolicyAlways,
+					TektonChangesPolicy: TektonChangesPolicyTargetBranch,
+					SecretScanning:      SecretScanningBlock,
+					IssueTracker:        issueTracker,
+					TriggerWindows:      triggerWindows,
 				},
 				Incomings:        incomings,
 				GitProvider:      gp,
@@ -142,3 +157,59 @@ func TestMergeSpecs(t *testing.T) {
 		})
 	}
 }
+
+func TestInheritSpecs(t *testing.T) {
+	one := 1
+	two := 2
+	tests := []struct {
+		name     string
+		local    *RepositorySpec
+		parent   RepositorySpec
+		expected *RepositorySpec
+	}{
+		{
+			name:  "use parent spec when unset",
+			local: &RepositorySpec{},
+			parent: RepositorySpec{
+				ConcurrencyLimit: &two,
+				Settings:         &Settings{PipelineRunProvenance: "default_branch"},
+				GitProvider:      &GitProvider{URL: "url", Type: "gitlab"},
+				Params:           &[]Params{{Name: "name", Value: "value"}},
+			},
+			expected: &RepositorySpec{
+				ConcurrencyLimit: &two,
+				Settings:         &Settings{PipelineRunProvenance: "default_branch"},
+				GitProvider:      &GitProvider{URL: "url", Type: "gitlab"},
+				Params:           &[]Params{{Name: "name", Value: "value"}},
+			},
+		},
+		{
+			name: "repository wins over parent",
+			local: &RepositorySpec{
+				ConcurrencyLimit: &one,
+				Settings:         &Settings{PipelineRunProvenance: "source"},
+				GitProvider:      &GitProvider{Type: "github"},
+				Params:           &[]Params{{Name: "name", Value: "local"}},
+			},
+			parent: RepositorySpec{
+				ConcurrencyLimit: &two,
+				Settings:         &Settings{PipelineRunProvenance: "default_branch"},
+				GitProvider:      &GitProvider{URL: "url", Type: "gitlab"},
+				Params:           &[]Params{{Name: "name", Value: "parent"}, {Name: "other", Value: "parent"}},
+			},
+			expected: &RepositorySpec{
+				ConcurrencyLimit: &one,
+				Settings:         &Settings{PipelineRunProvenance: "source"},
+				GitProvider:      &GitProvider{Type: "github"},
+				Params:           &[]Params{{Name: "name", Value: "local"}, {Name: "other", Value: "parent"}},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.local.Inherit(tt.parent)
+			assert.DeepEqual(t, tt.expected, tt.local)
+		})
+	}
+}
@@ -1,8 +1,11 @@
 package cel
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
+	"strconv"
+	"strings"
 
 	"github.com/google/cel-go/cel"
 	"github.com/google/cel-go/common/decls"
@@ -10,7 +13,8 @@ import (
 	"github.com/google/cel-go/common/types/ref"
 )
 
-func evaluate(expr string, env *cel.Env, data map[string]any) (ref.Val, error) {
+// compile parses and checks the expression and returns its program.
+func compile(expr string, env *cel.Env, opts ...cel.ProgramOption) (cel.Program, error) {
 	parsed, issues := env.Parse(expr)
 	if issues != nil && issues.Err() != nil {
 		return nil, fmt.Errorf("failed to parse expression %#v: %w", expr, issues.Err())
@@ -21,12 +25,21 @@ func evaluate(expr string, env *cel.Env, data map[string]any) (ref.Val, error) {
 		return nil, fmt.Errorf("expression %#v check failed: %w", expr, issues.Err())
 	}
 
-	prg, err := env.Program(checked, cel.EvalOptions(cel.OptOptimize))
+	prg, err := env.Program(checked, opts...)
 	if err != nil {
 		return nil, fmt.Errorf("expression %#v failed to create a Program: %w", expr, err)
 	}
+	return prg, nil
+}
+
+func evaluate(expr string, env *cel.Env, data map[string]any) (ref.Val, error) {
+	limits := DefaultLimits()
+	prg, err := compile(expr, env, append(limits.programOptions(), cel.EvalOptions(cel.OptOptimize))...)
+	if err != nil {
+		return nil, err
+	}
 
-	out, _, err := prg.Eval(data)
+	out, err := limits.Eval(context.Background(), prg, data)
 	if err != nil {
 		return nil, fmt.Errorf("expression %#v failed to evaluate: %w", expr, err)
 	}
@@ -34,6 +47,29 @@ func evaluate(expr string, env *cel.Env, data map[string]any) (ref.Val, error) {
 	return out, nil
 }
 
+// PullRequest returns the pr variable of the CEL expressions with the
+// number, the title and the labels of the pull request.
+func PullRequest(number int, title string, labels []string) map[string]any {
+	if labels == nil {
+		labels = []string{}
+	}
+	return map[string]any{
+		"number": number,
+		"title":  title,
+		"labels": labels,
+	}
+}
+
+// pullRequestFromParams returns the pr variable from the standard params.
+func pullRequestFromParams(pacParams map[string]string) map[string]any {
+	number, _ := strconv.Atoi(pacParams["pull_request_number"])
+	labels := []string{}
+	if pacParams["pull_request_labels"] != "" {
+		labels = strings.Split(pacParams["pull_request_labels"], "\\n")
+	}
+	return PullRequest(number, pacParams["event_title"], labels)
+}
+
 // Value evaluates a CEL expression with the given body, headers and
 // / pacParams, it will output a Cel value or an error if selectedjm.
 func Value(query string, body any, headers, pacParams map[string]string, changedFiles map[string]any) (ref.Val, error) {
@@ -49,12 +85,14 @@ func Value(query string, body any, headers, pacParams map[string]string, changed
 	}
 
 	mapStrDyn := types.NewMapType(types.StringType, types.DynType)
-	celDec, _ := cel.NewEnv(
+	celDec, _ := cel.NewEnv(append(DefaultLimits().envOptions(),
+		Helpers(),
 		cel.VariableDecls(
 			decls.NewVariable("body", mapStrDyn),
 			decls.NewVariable("headers", mapStrDyn),
 			decls.NewVariable("pac", mapStrDyn),
 			decls.NewVariable("files", mapStrDyn),
+			decls.NewVariable("pr", mapStrDyn),
 			// Direct variables as per documentation
 			decls.NewVariable("event", types.StringType),
 			decls.NewVariable("event_type", types.StringType),
@@ -74,12 +112,13 @@ func Value(query string, body any, headers, pacParams map[string]string, changed
 			decls.NewVariable("pull_request_labels", types.StringType),
 			decls.NewVariable("pull_request_number", types.StringType),
 			decls.NewVariable("git_auth_secret", types.StringType),
-		))
+		))...)
 	val, err := evaluate(query, celDec, map[string]any{
 		"body":    jsonMap,
 		"pac":     pacParams,
 		"headers": headers,
 		"files":   changedFiles,
+		"pr":      pullRequestFromParams(pacParams),
 		// Direct variables - all from pacParams
 		"event":               pacParams["event"],
 		"event_type":          pacParams["event_type"],
@@ -0,0 +1,212 @@
+package cel
+
+import (
+	"fmt"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/gobwas/glob"
+	"github.com/google/cel-go/cel"
+	"github.com/google/cel-go/common/types"
+	"github.com/google/cel-go/common/types/ref"
+	"github.com/google/cel-go/common/types/traits"
+	"github.com/hashicorp/go-version"
+)
+
+// SemverType is the CEL type of the versions returned by semver().
+var SemverType = cel.OpaqueType("semver")
+
+// Helpers returns the helper functions of the CEL expressions of
+// Pipelines-as-Code:
+//
+//   - files.all_match(glob) and files.any_match(glob) match the changed files,
+//     or a list of them like files.added, against a glob.
+//   - headers.match(name, regexp) matches a header, whatever its case, against
+//     a regexp.
+//   - semver(tag).satisfies(constraint) checks a version, with an optional
+//     refs/tags/ and v prefix, against a constraint like ">= 1.2, < 2".
+//   - body.get(path, default) returns the value at the dotted path of the
+//     payload, ie: "pull_request.head.ref" or "commits.0.id", or default when
+//     the path doesn't exist.
+func Helpers() cel.EnvOption {
+	return cel.Lib(helpers{})
+}
+
+type helpers struct{}
+
+func (helpers) ProgramOptions() []cel.ProgramOption {
+	return []cel.ProgramOption{}
+}
+
+func (helpers) CompileOptions() []cel.EnvOption {
+	mapStrDyn := cel.MapType(cel.StringType, cel.DynType)
+	return []cel.EnvOption{
+		cel.Function("all_match",
+			cel.MemberOverload("dyn_all_match_string", []*cel.Type{cel.DynType, cel.StringType}, cel.BoolType,
+				cel.BinaryBinding(func(files, pattern ref.Val) ref.Val { return globMatch(files, pattern, true) }))),
+		cel.Function("any_match",
+			cel.MemberOverload("dyn_any_match_string", []*cel.Type{cel.DynType, cel.StringType}, cel.BoolType,
+				cel.BinaryBinding(func(files, pattern ref.Val) ref.Val { return globMatch(files, pattern, false) }))),
+		cel.Function("match",
+			cel.MemberOverload("map_match_string_string", []*cel.Type{mapStrDyn, cel.StringType, cel.StringType}, cel.BoolType,
+				cel.FunctionBinding(headerMatch))),
+		cel.Function("semver",
+			cel.Overload("semver_string", []*cel.Type{cel.StringType}, SemverType,
+				cel.UnaryBinding(newSemver))),
+		cel.Function("satisfies",
+			cel.MemberOverload("semver_satisfies_string", []*cel.Type{SemverType, cel.StringType}, cel.BoolType,
+				cel.BinaryBinding(semverSatisfies))),
+		cel.Function("get",
+			cel.MemberOverload("map_get_string_dyn", []*cel.Type{mapStrDyn, cel.StringType, cel.DynType}, cel.DynType,
+				cel.FunctionBinding(bodyGet))),
+	}
+}
+
+// globMatch returns whether all or any of the files match the glob, the files
+// are the "all" key of the files variable or a list of files. No file never
+// matches.
+func globMatch(files, pattern ref.Val, all bool) ref.Val {
+	if mapper, ok := files.(traits.Mapper); ok {
+		files = mapper.Get(types.String("all"))
+	}
+	native, err := files.ConvertToNative(reflect.TypeOf([]string{}))
+	if err != nil {
+		return types.NewErr("cannot match the files %v: %v", files, err)
+	}
+	paths, _ := native.([]string)
+	g, err := glob.Compile(string(pattern.(types.String)))
+	if err != nil {
+		return types.NewErr("invalid glob %q: %v", pattern, err)
+	}
+	if len(paths) == 0 {
+		return types.False
+	}
+	for _, path := range paths {
+		if g.Match(path) != all {
+			return types.Bool(!all)
+		}
+	}
+	return types.Bool(all)
+}
+
+// headerMatch returns whether the header of the name matches the regexp.
+func headerMatch(args ...ref.Val) ref.Val {
+	headers, ok := args[0].(traits.Mapper)
+	if !ok {
+		return types.NewErr("no such overload")
+	}
+	name := strings.ToLower(string(args[1].(types.String)))
+	re, err := regexp.Compile(string(args[2].(types.String)))
+	if err != nil {
+		return types.NewErr("invalid regexp %q: %v", args[2], err)
+	}
+	it := headers.Iterator()
+	for it.HasNext() == types.True {
+		key := it.Next()
+		if strings.ToLower(fmt.Sprint(key.Value())) != name {
+			continue
+		}
+		return types.Bool(re.MatchString(fmt.Sprint(headers.Get(key).Value())))
+	}
+	return types.False
+}
+
+// semverVal is a version in a CEL expression, version is nil when the string
+// is not a version and then it doesn't satisfy any constraint.
+type semverVal struct {
+	version *version.Version
+}
+
+func newSemver(val ref.Val) ref.Val {
+	s := strings.TrimPrefix(string(val.(types.String)), "refs/tags/")
+	v, err := version.NewSemver(s)
+	if err != nil {
+		return semverVal{}
+	}
+	return semverVal{version: v}
+}
+
+func semverSatisfies(val, constraint ref.Val) ref.Val {
+	constraints, err := version.NewConstraint(string(constraint.(types.String)))
+	if err != nil {
+		return types.NewErr("invalid version constraint %q: %v", constraint, err)
+	}
+	v, ok := val.(semverVal)
+	if !ok {
+		return types.NewErr("no such overload")
+	}
+	if v.version == nil {
+		return types.False
+	}
+	return types.Bool(constraints.Check(v.version))
+}
+
+func (v semverVal) ConvertToNative(typeDesc reflect.Type) (any, error) {
+	if typeDesc.Kind() == reflect.String {
+		return v.String(), nil
+	}
+	return nil, fmt.Errorf("type conversion error from semver to %v", typeDesc)
+}
+
+func (v semverVal) ConvertToType(typeVal ref.Type) ref.Val {
+	switch typeVal {
+	case types.StringType:
+		return types.String(v.String())
+	case SemverType:
+		return v
+	}
+	return types.NewErr("type conversion error from semver to %v", typeVal)
+}
+
+func (v semverVal) Equal(other ref.Val) ref.Val {
+	o, ok := other.(semverVal)
+	if !ok || v.version == nil || o.version == nil {
+		return types.Bool(ok && v.version == nil && o.version == nil)
+	}
+	return types.Bool(v.version.Equal(o.version))
+}
+
+func (v semverVal) Type() ref.Type {
+	return SemverType
+}
+
+func (v semverVal) Value() any {
+	return v.version
+}
+
+func (v semverVal) String() string {
+	if v.version == nil {
+		return ""
+	}
+	return v.version.String()
+}
+
+// bodyGet returns the value at the dotted path of the map, or the default
+// value when it doesn't exist. The numbers are the indexes of the lists.
+func bodyGet(args ...ref.Val) ref.Val {
+	current := args[0]
+	for _, key := range strings.Split(string(args[1].(types.String)), ".") {
+		switch container := current.(type) {
+		case traits.Mapper:
+			value, found := container.Find(types.String(key))
+			if !found {
+				return args[2]
+			}
+			current = value
+		case traits.Lister:
+			index, err := strconv.Atoi(key)
+			if err != nil || index < 0 || int64(index) >= int64(container.Size().(types.Int)) {
+				return args[2]
+			}
+			current = container.Get(types.Int(index))
+		default:
+			return args[2]
+		}
+	}
+	if types.IsUnknownOrError(current) || current == types.NullValue {
+		return args[2]
+	}
+	return current
+}
@@ -0,0 +1,69 @@
+package cel
+
+import (
+	"testing"
+
+	"github.com/google/cel-go/common/types"
+	"gotest.tools/v3/assert"
+)
+
+func TestHelpers(t *testing.T) {
+	body := map[string]any{
+		"pull_request": map[string]any{"head": map[string]any{"ref": "feature"}},
+		"commits":      []any{map[string]any{"id": "abc"}},
+		"nothing":      nil,
+	}
+	headers := map[string]string{"X-GitHub-Event": "pull_request"}
+	pacParams := map[string]string{
+		"pull_request_number": "42",
+		"pull_request_labels": `bug\nok-to-merge`,
+		"event_title":         "Fix the docs",
+	}
+	changedFiles := map[string]any{
+		"all":     []string{"docs/index.md", "docs/img/logo.png"},
+		"added":   []string{"docs/img/logo.png"},
+		"deleted": []string{},
+	}
+	tests := []struct {
+		name    string
+		expr    string
+		want    any
+		wantErr string
+	}{
+		{name: "all files match", expr: `files.all_match("docs/**")`, want: true},
+		{name: "not all files match", expr: `files.all_match("docs/*.md")`, want: false},
+		{name: "any file matches", expr: `files.any_match("**/*.png")`, want: true},
+		{name: "list of files", expr: `files.added.all_match("**/*.png")`, want: true},
+		{name: "no files never match", expr: `files.deleted.any_match("**")`, want: false},
+		{name: "invalid glob", expr: `files.all_match("[")`, wantErr: "invalid glob"},
+		{name: "pr labels", expr: `pr.labels.exists(l, l == "ok-to-merge")`, want: true},
+		{name: "pr number and title", expr: `pr.number == 42 && pr.title.startsWith("Fix")`, want: true},
+		{name: "header match whatever its case", expr: `headers.match("x-github-event", "^pull_")`, want: true},
+		{name: "header not matching", expr: `headers.match("X-GitHub-Event", "^push$")`, want: false},
+		{name: "missing header", expr: `headers.match("X-Gitlab-Event", ".*")`, want: false},
+		{name: "semver satisfies", expr: `semver("refs/tags/v1.4.2").satisfies(">= 1.2, < 2")`, want: true},
+		{name: "semver not satisfying", expr: `semver("2.0.0").satisfies("~> 1.4")`, want: false},
+		{name: "not a version", expr: `semver("latest").satisfies(">= 0")`, want: false},
+		{name: "invalid constraint", expr: `semver("1.0.0").satisfies("newest")`, wantErr: "invalid version constraint"},
+		{name: "body path", expr: `body.get("pull_request.head.ref", "") == "feature"`, want: true},
+		{name: "body list index", expr: `body.get("commits.0.id", "")`, want: "abc"},
+		{name: "body missing path", expr: `body.get("pull_request.base.ref", "main")`, want: "main"},
+		{name: "body null value", expr: `body.get("nothing", "default")`, want: "default"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			val, err := Value(tt.expr, body, headers, pacParams, changedFiles)
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			assert.NilError(t, err)
+			switch want := tt.want.(type) {
+			case bool:
+				assert.Equal(t, val, types.Bool(want))
+			case string:
+				assert.Equal(t, val, types.String(want))
+			}
+		})
+	}
+}
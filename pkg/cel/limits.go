@@ -0,0 +1,107 @@
+package cel
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/google/cel-go/cel"
+	"github.com/google/cel-go/common/types/ref"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/params/settings"
+)
+
+const (
+	// maxExpressionSize is the maximum number of characters of an
+	// expression.
+	maxExpressionSize = 32768
+	// maxRecursionDepth is the maximum depth of the nested calls and
+	// operators of an expression.
+	maxRecursionDepth = 100
+	// interruptCheckFrequency is the number of iterations of a comprehension
+	// evaluated before checking the timeout.
+	interruptCheckFrequency = 100
+)
+
+// Limits bound the cost and the duration of the evaluation of the CEL
+// expressions of the users, so a pathological expression cannot use a CPU of
+// the controller or block the processing of the events.
+type Limits struct {
+	// CostLimit is the maximum cost of the evaluation, it fails above it.
+	CostLimit uint64
+	// Timeout is the maximum duration of the evaluation.
+	Timeout time.Duration
+	// DisabledMacros are the macros the expressions cannot use, ie: map.
+	DisabledMacros []string
+}
+
+// DefaultLimits returns the limits of the default settings.
+func DefaultLimits() Limits {
+	return LimitsFromSettings(settings.DefaultSettings())
+}
+
+// LimitsFromSettings returns the limits of the cel-* settings, the invalid
+// values have been rejected when the settings were loaded.
+func LimitsFromSettings(s settings.Settings) Limits {
+	timeout, _ := time.ParseDuration(s.CELEvaluationTimeout)
+	macros, _ := settings.ParseCELDisabledMacros(s.CELDisabledMacros)
+	return Limits{
+		CostLimit:      uint64(max(s.CELCostLimit, 0)),
+		Timeout:        timeout,
+		DisabledMacros: macros,
+	}
+}
+
+// envOptions returns the options of the environment of the expressions,
+// without the disabled macros.
+func (l Limits) envOptions() []cel.EnvOption {
+	opts := []cel.EnvOption{
+		cel.ParserExpressionSizeLimit(maxExpressionSize),
+		cel.ParserRecursionLimit(maxRecursionDepth),
+	}
+	if len(l.DisabledMacros) == 0 {
+		return opts
+	}
+	macros := slices.DeleteFunc(slices.Clone(cel.StandardMacros), func(macro cel.Macro) bool {
+		name := macro.Function()
+		if name == "existsOne" {
+			name = "exists_one"
+		}
+		return slices.Contains(l.DisabledMacros, name)
+	})
+	return append(opts, cel.ClearMacros(), cel.Macros(macros...))
+}
+
+// programOptions returns the options of the programs of the expressions.
+func (l Limits) programOptions() []cel.ProgramOption {
+	opts := []cel.ProgramOption{cel.InterruptCheckFrequency(interruptCheckFrequency)}
+	if l.CostLimit > 0 {
+		opts = append(opts, cel.CostLimit(l.CostLimit))
+	}
+	return opts
+}
+
+// Eval evaluates a program of Compile within the timeout.
+func (l Limits) Eval(ctx context.Context, prg cel.Program, data map[string]any) (ref.Val, error) {
+	if l.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, l.Timeout)
+		defer cancel()
+	}
+	out, _, err := prg.ContextEval(ctx, data)
+	if err != nil && errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		return nil, fmt.Errorf("evaluation timed out after %s", l.Timeout)
+	}
+	return out, err
+}
+
+// Compile parses and checks the expression in the environment created with
+// the options and the limits, and returns its program.
+func (l Limits) Compile(expr string, opts ...cel.EnvOption) (cel.Program, error) {
+	env, err := cel.NewEnv(append(opts, l.envOptions()...)...)
+	if err != nil {
+		return nil, err
+	}
+	return compile(expr, env, l.programOptions()...)
+}
@@ -0,0 +1,84 @@
+package cel
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/cel-go/cel"
+	"github.com/google/cel-go/common/decls"
+	"github.com/google/cel-go/common/types"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/params/settings"
+	"gotest.tools/v3/assert"
+)
+
+func TestLimits(t *testing.T) {
+	items := make([]int, 1000)
+	for i := range items {
+		items[i] = i
+	}
+	nested := `items.all(x, items.all(y, x != y || x == y))`
+	tests := []struct {
+		name        string
+		limits      Limits
+		expr        string
+		wantErr     string
+		wantCompile string
+	}{
+		{
+			name:   "within the limits",
+			limits: DefaultLimits(),
+			expr:   `items.exists(x, x == 999)`,
+		},
+		{
+			name:    "cost limit exceeded",
+			limits:  Limits{CostLimit: 1000},
+			expr:    nested,
+			wantErr: "cost limit exceeded",
+		},
+		{
+			name:    "timeout",
+			limits:  Limits{Timeout: time.Nanosecond},
+			expr:    nested,
+			wantErr: "evaluation timed out after 1ns",
+		},
+		{
+			name:        "disabled macro",
+			limits:      Limits{DisabledMacros: []string{"map", "exists_one"}},
+			expr:        `items.map(x, x * 2).size() == 1000`,
+			wantCompile: "undeclared reference to 'map'",
+		},
+		{
+			name:   "other macros are kept",
+			limits: Limits{DisabledMacros: []string{"map"}},
+			expr:   `items.filter(x, x < 10).size() == 10`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			prg, err := tt.limits.Compile(tt.expr, cel.VariableDecls(decls.NewVariable("items", types.NewListType(types.IntType))))
+			if tt.wantCompile != "" {
+				assert.ErrorContains(t, err, tt.wantCompile)
+				return
+			}
+			assert.NilError(t, err)
+			out, err := tt.limits.Eval(context.Background(), prg, map[string]any{"items": items})
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			assert.NilError(t, err)
+			assert.Equal(t, out, types.True)
+		})
+	}
+}
+
+func TestLimitsFromSettings(t *testing.T) {
+	s := settings.DefaultSettings()
+	assert.DeepEqual(t, LimitsFromSettings(s), Limits{CostLimit: 1000000, Timeout: time.Second, DisabledMacros: []string{}})
+
+	s.CELCostLimit = 10
+	s.CELEvaluationTimeout = "100ms"
+	s.CELDisabledMacros = "map, filter"
+	assert.DeepEqual(t, LimitsFromSettings(s), Limits{CostLimit: 10, Timeout: 100 * time.Millisecond, DisabledMacros: []string{"map", "filter"}})
+}
@@ -2,6 +2,10 @@ package info
 
 import (
 	"context"
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
 
 	"github.com/openshift-pipelines/pipelines-as-code/pkg/params"
 	"github.com/openshift-pipelines/pipelines-as-code/pkg/params/info"
@@ -13,7 +17,46 @@ const infoConfigMap = "pipelines-as-code-info"
 type Options struct {
 	TargetNamespace string
 	ControllerURL   string
-	Provider        string
+	// ControllerURLs are the URLs of the other listeners of the controller,
+	// i.e: an in-cluster one for Gitea and a public one for GitHub, by name.
+	ControllerURLs map[string]string
+	Provider       string
+}
+
+// ParseControllerURLs returns the URLs of the listeners of a comma separated
+// list like "internal=http://pipelines-as-code-controller.pipelines-as-code:8080".
+func ParseControllerURLs(value string) (map[string]string, error) {
+	urls := map[string]string{}
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item == "" {
+			continue
+		}
+		name, listenerURL, found := strings.Cut(item, "=")
+		name, listenerURL = strings.TrimSpace(name), strings.TrimSpace(listenerURL)
+		u, err := url.Parse(listenerURL)
+		if !found || name == "" || err != nil || u.Scheme == "" || u.Host == "" {
+			return nil, fmt.Errorf("invalid controller url %q, it must be a listener name and an url like public=https://pac.example.com", item)
+		}
+		urls[name] = listenerURL
+	}
+	return urls, nil
+}
+
+// GetControllerURL returns the URL of the listener name, the controller-url
+// one when name is empty.
+func (o *Options) GetControllerURL(name string) (string, error) {
+	if name == "" {
+		return o.ControllerURL, nil
+	}
+	if listenerURL, ok := o.ControllerURLs[name]; ok {
+		return listenerURL, nil
+	}
+	names := make([]string, 0, len(o.ControllerURLs))
+	for n := range o.ControllerURLs {
+		names = append(names, n)
+	}
+	sort.Strings(names)
+	return "", fmt.Errorf("listener %q is not in the controller-urls of the %s configmap, the listeners are: %s", name, infoConfigMap, strings.Join(names, ", "))
 }
 
 func IsGithubAppInstalled(ctx context.Context, run *params.Run, targetNamespace string) bool {
@@ -29,10 +72,16 @@ func GetPACInfo(ctx context.Context, run *params.Run, targetNamespace string) (*
 		return nil, err
 	}
 
-	return &Options{
+	opts := &Options{
 		ControllerURL: cm.Data["controller-url"],
 		Provider:      cm.Data["provider"],
-	}, nil
+	}
+	if value := cm.Data["controller-urls"]; value != "" {
+		if opts.ControllerURLs, err = ParseControllerURLs(value); err != nil {
+			return nil, err
+		}
+	}
+	return opts, nil
 }
 
 func UpdateInfoConfigMap(ctx context.Context, run *params.Run, opts *Options) error {
@@ -8,6 +8,7 @@ import (
 	"github.com/openshift-pipelines/pipelines-as-code/pkg/params/clients"
 	"github.com/openshift-pipelines/pipelines-as-code/pkg/params/info"
 	testclient "github.com/openshift-pipelines/pipelines-as-code/pkg/test/clients"
+	"gotest.tools/v3/assert"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	rtesting "knative.dev/pkg/reconciler/testing"
@@ -136,6 +137,65 @@ func TestGetPACInfo(t *testing.T) {
 	}
 }
 
+func TestParseControllerURLs(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   string
+		want    map[string]string
+		wantErr string
+	}{
+		{
+			name:  "listeners",
+			value: "internal=http://pipelines-as-code-controller.pipelines-as-code:8080, public=https://pac.example.com",
+			want: map[string]string{
+				"internal": "http://pipelines-as-code-controller.pipelines-as-code:8080",
+				"public":   "https://pac.example.com",
+			},
+		},
+		{
+			name:  "empty",
+			value: "",
+			want:  map[string]string{},
+		},
+		{
+			name:    "no name",
+			value:   "https://pac.example.com",
+			wantErr: `invalid controller url "https://pac.example.com", it must be a listener name and an url like public=https://pac.example.com`,
+		},
+		{
+			name:    "no url",
+			value:   "public=pac",
+			wantErr: `invalid controller url "public=pac", it must be a listener name and an url like public=https://pac.example.com`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseControllerURLs(tt.value)
+			if tt.wantErr != "" {
+				assert.Error(t, err, tt.wantErr)
+				return
+			}
+			assert.NilError(t, err)
+			assert.DeepEqual(t, got, tt.want)
+		})
+	}
+}
+
+func TestGetControllerURL(t *testing.T) {
+	opts := &Options{
+		ControllerURL:  "https://pac.example.com",
+		ControllerURLs: map[string]string{"internal": "http://pipelines-as-code-controller.pipelines-as-code:8080"},
+	}
+	got, err := opts.GetControllerURL("")
+	assert.NilError(t, err)
+	assert.Equal(t, got, "https://pac.example.com")
+	got, err = opts.GetControllerURL("internal")
+	assert.NilError(t, err)
+	assert.Equal(t, got, "http://pipelines-as-code-controller.pipelines-as-code:8080")
+	_, err = opts.GetControllerURL("public")
+	assert.Error(t, err, `listener "public" is not in the controller-urls of the pipelines-as-code-info configmap, the listeners are: internal`)
+}
+
 func TestUpdateInfoConfigMap(t *testing.T) {
 	namespace := &corev1.Namespace{
 		ObjectMeta: metav1.ObjectMeta{
@@ -0,0 +1,162 @@
+package webhook
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/go-github/v74/github"
+)
+
+// maxDeliveryPages is the maximum number of pages of deliveries read to find
+// the ones of a repository in the deliveries of a GitHub App.
+const maxDeliveryPages = 10
+
+// Delivery is a delivery of a webhook by a Git provider.
+type Delivery struct {
+	ID          int64
+	GUID        string
+	DeliveredAt time.Time
+	Event       string
+	Action      string
+	Status      string
+	StatusCode  int
+	Redelivery  bool
+	Duration    time.Duration
+}
+
+// Deliveries lists the last deliveries of the webhook of a repository and
+// redelivers them.
+type Deliveries interface {
+	List(ctx context.Context, limit int) ([]Delivery, error)
+	Redeliver(ctx context.Context, id int64) error
+}
+
+func newDelivery(hd *github.HookDelivery) Delivery {
+	delivery := Delivery{
+		ID:          hd.GetID(),
+		GUID:        hd.GetGUID(),
+		DeliveredAt: hd.GetDeliveredAt().Time,
+		Event:       hd.GetEvent(),
+		Action:      hd.GetAction(),
+		Status:      hd.GetStatus(),
+		StatusCode:  hd.GetStatusCode(),
+		Redelivery:  hd.GetRedelivery(),
+	}
+	if hd.Duration != nil {
+		delivery.Duration = time.Duration(*hd.Duration * float64(time.Second))
+	}
+	return delivery
+}
+
+// isAccepted returns whether err is the 202 Accepted answered by GitHub to a
+// redelivery, which is scheduled and not an error.
+func isAccepted(err error) bool {
+	var accepted *github.AcceptedError
+	return errors.As(err, &accepted)
+}
+
+// GitHubHookDeliveries are the deliveries of a webhook of a GitHub repository.
+type GitHubHookDeliveries struct {
+	Client *github.Client
+	Owner  string
+	Repo   string
+	HookID int64
+}
+
+// NewGitHubHookDeliveries returns the deliveries of the webhook hookID of a
+// GitHub repository, or of its only webhook when hookID is 0.
+func NewGitHubHookDeliveries(ctx context.Context, client *github.Client, owner, repo string, hookID int64) (*GitHubHookDeliveries, error) {
+	if hookID == 0 {
+		hooks, _, err := client.Repositories.ListHooks(ctx, owner, repo, &github.ListOptions{})
+		if err != nil {
+			return nil, fmt.Errorf("cannot list the webhooks of %s/%s: %w", owner, repo, err)
+		}
+		if len(hooks) != 1 {
+			return nil, fmt.Errorf("found %d webhooks on %s/%s, the webhook ID needs to be specified", len(hooks), owner, repo)
+		}
+		hookID = hooks[0].GetID()
+	}
+	return &GitHubHookDeliveries{Client: client, Owner: owner, Repo: repo, HookID: hookID}, nil
+}
+
+func (d *GitHubHookDeliveries) List(ctx context.Context, limit int) ([]Delivery, error) {
+	hds, _, err := d.Client.Repositories.ListHookDeliveries(ctx, d.Owner, d.Repo, d.HookID, &github.ListCursorOptions{PerPage: limit})
+	if err != nil {
+		return nil, fmt.Errorf("cannot list the deliveries of the webhook %d of %s/%s: %w", d.HookID, d.Owner, d.Repo, err)
+	}
+	ret := make([]Delivery, 0, len(hds))
+	for _, hd := range hds {
+		ret = append(ret, newDelivery(hd))
+	}
+	return ret, nil
+}
+
+func (d *GitHubHookDeliveries) Redeliver(ctx context.Context, id int64) error {
+	if _, _, err := d.Client.Repositories.RedeliverHookDelivery(ctx, d.Owner, d.Repo, d.HookID, id); err != nil && !isAccepted(err) {
+		return fmt.Errorf("cannot redeliver the delivery %d of the webhook %d of %s/%s: %w", id, d.HookID, d.Owner, d.Repo, err)
+	}
+	return nil
+}
+
+// GitHubAppDeliveries are the deliveries of a GitHub App for a repository,
+// Client is authenticated as the App.
+type GitHubAppDeliveries struct {
+	Client       *github.Client
+	RepositoryID int64
+}
+
+// NewGitHubAppDeliveries returns the deliveries of a GitHub App for a
+// repository where it is installed, client is authenticated with the JWT of
+// the App.
+func NewGitHubAppDeliveries(ctx context.Context, client *github.Client, owner, repo string) (*GitHubAppDeliveries, error) {
+	installation, _, err := client.Apps.FindRepositoryInstallation(ctx, owner, repo)
+	if err != nil {
+		return nil, fmt.Errorf("cannot find the installation of the GitHub App on %s/%s: %w", owner, repo, err)
+	}
+	// the deliveries only have the ID of the repository, which is returned
+	// with a token restricted to the repository.
+	token, _, err := client.Apps.CreateInstallationToken(ctx, installation.GetID(), &github.InstallationTokenOptions{
+		Repositories: []string{repo},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("cannot create an installation token for %s/%s: %w", owner, repo, err)
+	}
+	if len(token.Repositories) != 1 {
+		return nil, fmt.Errorf("cannot find the ID of the repository %s/%s", owner, repo)
+	}
+	return &GitHubAppDeliveries{Client: client, RepositoryID: token.Repositories[0].GetID()}, nil
+}
+
+func (d *GitHubAppDeliveries) List(ctx context.Context, limit int) ([]Delivery, error) {
+	ret := []Delivery{}
+	opts := &github.ListCursorOptions{PerPage: 100}
+	for range maxDeliveryPages {
+		hds, resp, err := d.Client.Apps.ListHookDeliveries(ctx, opts)
+		if err != nil {
+			return nil, fmt.Errorf("cannot list the deliveries of the GitHub App: %w", err)
+		}
+		for _, hd := range hds {
+			if hd.GetRepositoryID() != d.RepositoryID {
+				continue
+			}
+			ret = append(ret, newDelivery(hd))
+			if len(ret) == limit {
+				return ret, nil
+			}
+		}
+		if resp.Cursor == "" {
+			break
+		}
+		opts.Cursor = resp.Cursor
+	}
+	return ret, nil
+}
+
+func (d *GitHubAppDeliveries) Redeliver(ctx context.Context, id int64) error {
+	if _, _, err := d.Client.Apps.RedeliverHookDelivery(ctx, id); err != nil && !isAccepted(err) {
+		return fmt.Errorf("cannot redeliver the delivery %d of the GitHub App: %w", id, err)
+	}
+	return nil
+}
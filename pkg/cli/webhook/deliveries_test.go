@@ -0,0 +1,113 @@
+package webhook
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	ghtesthelper "github.com/openshift-pipelines/pipelines-as-code/pkg/test/github"
+	"gotest.tools/v3/assert"
+	rtesting "knative.dev/pkg/reconciler/testing"
+)
+
+func TestGitHubHookDeliveries(t *testing.T) {
+	tests := []struct {
+		name       string
+		hookID     int64
+		hooks      string
+		wantHookID int64
+		wantErr    string
+	}{
+		{
+			name:       "hook id given",
+			hookID:     42,
+			wantHookID: 42,
+		},
+		{
+			name:       "only webhook of the repository",
+			hooks:      `[{"id": 43}]`,
+			wantHookID: 43,
+		},
+		{
+			name:    "many webhooks on the repository",
+			hooks:   `[{"id": 43}, {"id": 44}]`,
+			wantErr: "found 2 webhooks on owner/repo, the webhook ID needs to be specified",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, _ := rtesting.SetupFakeContext(t)
+			client, mux, _, teardown := ghtesthelper.SetupGH()
+			defer teardown()
+			mux.HandleFunc("/repos/owner/repo/hooks", func(w http.ResponseWriter, _ *http.Request) {
+				fmt.Fprint(w, tt.hooks)
+			})
+			redelivered := false
+			mux.HandleFunc(fmt.Sprintf("/repos/owner/repo/hooks/%d/deliveries", tt.wantHookID), func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, r.URL.Query().Get("per_page"), "10")
+				fmt.Fprint(w, `[{"id": 1, "guid": "guid", "delivered_at": "2025-01-02T10:00:00Z", "event": "pull_request",
+					"action": "opened", "status": "Invalid HTTP Response: 503", "status_code": 503, "duration": 0.5}]`)
+			})
+			mux.HandleFunc(fmt.Sprintf("/repos/owner/repo/hooks/%d/deliveries/1/attempts", tt.wantHookID), func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, r.Method, http.MethodPost)
+				redelivered = true
+				w.WriteHeader(http.StatusAccepted)
+			})
+
+			d, err := NewGitHubHookDeliveries(ctx, client, "owner", "repo", tt.hookID)
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			assert.NilError(t, err)
+			assert.Equal(t, d.HookID, tt.wantHookID)
+
+			list, err := d.List(ctx, 10)
+			assert.NilError(t, err)
+			assert.DeepEqual(t, list, []Delivery{{
+				ID:          1,
+				GUID:        "guid",
+				DeliveredAt: time.Date(2025, time.January, 2, 10, 0, 0, 0, time.UTC),
+				Event:       "pull_request",
+				Action:      "opened",
+				Status:      "Invalid HTTP Response: 503",
+				StatusCode:  503,
+				Duration:    500 * time.Millisecond,
+			}})
+
+			assert.NilError(t, d.Redeliver(ctx, 1))
+			assert.Assert(t, redelivered)
+		})
+	}
+}
+
+func TestGitHubAppDeliveries(t *testing.T) {
+	ctx, _ := rtesting.SetupFakeContext(t)
+	client, mux, _, teardown := ghtesthelper.SetupGH()
+	defer teardown()
+	mux.HandleFunc("/repos/owner/repo/installation", func(w http.ResponseWriter, _ *http.Request) {
+		fmt.Fprint(w, `{"id": 7}`)
+	})
+	mux.HandleFunc("/app/installations/7/access_tokens", func(w http.ResponseWriter, _ *http.Request) {
+		fmt.Fprint(w, `{"token": "token", "repositories": [{"id": 100, "name": "repo"}]}`)
+	})
+	mux.HandleFunc("/app/hook/deliveries", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("cursor") == "" {
+			w.Header().Set("Link", `<https://api.github.com/app/hook/deliveries?cursor=next>; rel="next"`)
+			fmt.Fprint(w, `[{"id": 1, "repository_id": 100}, {"id": 2, "repository_id": 200}]`)
+			return
+		}
+		fmt.Fprint(w, `[{"id": 3, "repository_id": 100}, {"id": 4, "repository_id": 100}]`)
+	})
+
+	d, err := NewGitHubAppDeliveries(ctx, client, "owner", "repo")
+	assert.NilError(t, err)
+	assert.Equal(t, d.RepositoryID, int64(100))
+
+	list, err := d.List(ctx, 2)
+	assert.NilError(t, err)
+	assert.Equal(t, len(list), 2)
+	assert.Equal(t, list[0].ID, int64(1))
+	assert.Equal(t, list[1].ID, int64(3))
+}
@@ -0,0 +1,178 @@
+package webhook
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+	"text/template"
+
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/apis/pipelinesascode/v1alpha1"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/formatting"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/params/triggertype"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/pipelineascode"
+)
+
+var (
+	// githubWebhookEvents are the events of the GitHub webhooks.
+	githubWebhookEvents = []string{"issue_comment", triggertype.PullRequest.String(), "push"}
+	// giteaWebhookEvents are the events of the Gitea webhooks.
+	giteaWebhookEvents = []string{"push", "pull_request", "issue_comment"}
+	// gitlabWebhookEvents are the events of the GitLab webhooks, as the
+	// names of the boolean settings of the project hooks.
+	gitlabWebhookEvents = []string{"merge_requests_events", "note_events", "push_events", "tag_push_events"}
+)
+
+// ExportFormats are the formats of the webhook exports.
+var ExportFormats = []string{"terraform", "json"}
+
+var terraformNameRe = regexp.MustCompile(`[^a-z0-9_]+`)
+
+// SecretRef is the reference to the Kubernetes secret holding the webhook
+// secret of a Repository.
+type SecretRef struct {
+	Namespace string `json:"namespace"`
+	Name      string `json:"name"`
+	Key       string `json:"key"`
+}
+
+// Export is the configuration of the webhook of a Repository on the git
+// provider side.
+type Export struct {
+	Provider    string   `json:"provider"`
+	Repository  string   `json:"repository"`
+	URL         string   `json:"url"`
+	ContentType string   `json:"content_type"`
+	Events      []string `json:"events"`
+	InsecureSSL bool     `json:"insecure_ssl"`
+	// Secret is where the webhook secret is, its value is never exported.
+	Secret SecretRef `json:"secret"`
+}
+
+// NewExport returns the webhook configuration of repo for the provider, the
+// controllerURL is the URL the git provider sends the events to.
+func NewExport(repo *v1alpha1.Repository, providerName, controllerURL string) (*Export, error) {
+	var events []string
+	switch providerName {
+	case "github":
+		events = githubWebhookEvents
+	case "gitlab":
+		events = gitlabWebhookEvents
+	case "gitea":
+		events = giteaWebhookEvents
+	default:
+		return nil, fmt.Errorf("exporting the webhook is not supported on %s", providerName)
+	}
+	if controllerURL == "" {
+		return nil, fmt.Errorf("the controller URL is needed to export the webhook")
+	}
+	owner, name, err := formatting.GetRepoOwnerSplitted(repo.Spec.URL)
+	if err != nil {
+		return nil, err
+	}
+
+	// the secret created by tkn pac webhook add when the Repository has none
+	secret := SecretRef{Namespace: repo.GetNamespace(), Name: repo.GetName(), Key: pipelineascode.DefaultGitProviderWebhookSecretKey}
+	if repo.Spec.GitProvider != nil && repo.Spec.GitProvider.WebhookSecret != nil {
+		secret.Name = repo.Spec.GitProvider.WebhookSecret.Name
+		if repo.Spec.GitProvider.WebhookSecret.Key != "" {
+			secret.Key = repo.Spec.GitProvider.WebhookSecret.Key
+		}
+	}
+	return &Export{
+		Provider:    providerName,
+		Repository:  owner + "/" + strings.TrimSuffix(name, ".git"),
+		URL:         controllerURL,
+		ContentType: "json",
+		Events:      events,
+		Secret:      secret,
+	}, nil
+}
+
+// JSON returns the webhook configuration as indented JSON.
+func (e *Export) JSON() (string, error) {
+	out, err := json.MarshalIndent(e, "", "  ")
+	if err != nil {
+		return "", err
+	}
+	return string(out) + "\n", nil
+}
+
+var terraformTemplate = template.Must(template.New("terraform").Funcs(template.FuncMap{
+	"quote": func(s string) string {
+		out, _ := json.Marshal(s)
+		return string(out)
+	},
+	"jsonpathKey": func(s string) string {
+		return strings.ReplaceAll(s, ".", `\.`)
+	},
+	"list": func(s []string) string {
+		out, _ := json.Marshal(s)
+		return strings.ReplaceAll(string(out), ",", ", ")
+	},
+}).Parse(`# The webhook secret is in the key {{ .Secret.Key }} of the secret
+# {{ .Secret.Namespace }}/{{ .Secret.Name }}, ie:
+# kubectl get secret -n {{ .Secret.Namespace }} {{ .Secret.Name }} -o jsonpath='{.data.{{ jsonpathKey .Secret.Key }}}' | base64 -d
+variable "{{ .Name }}_webhook_secret" {
+  type      = string
+  sensitive = true
+}
+{{ if eq .Provider "github" }}
+resource "github_repository_webhook" "{{ .Name }}" {
+  repository = {{ quote .RepositoryName }}
+  active     = true
+  events     = {{ list .Events }}
+
+  configuration {
+    url          = {{ quote .URL }}
+    content_type = {{ quote .ContentType }}
+    insecure_ssl = {{ .InsecureSSL }}
+    secret       = var.{{ .Name }}_webhook_secret
+  }
+}
+{{- else if eq .Provider "gitlab" }}
+resource "gitlab_project_hook" "{{ .Name }}" {
+  project                 = {{ quote .Repository }}
+  url                     = {{ quote .URL }}
+  token                   = var.{{ .Name }}_webhook_secret
+  enable_ssl_verification = {{ not .InsecureSSL }}
+{{- range .Events }}
+  {{ printf "%-23s" . }} = true
+{{- end }}
+}
+{{- else if eq .Provider "gitea" }}
+resource "gitea_repository_webhook" "{{ .Name }}" {
+  username     = {{ quote .Owner }}
+  name         = {{ quote .RepositoryName }}
+  type         = "gitea"
+  url          = {{ quote .URL }}
+  content_type = {{ quote .ContentType }}
+  events       = {{ list .Events }}
+  active       = true
+  secret       = var.{{ .Name }}_webhook_secret
+}
+{{- end }}
+`))
+
+// Terraform returns the webhook configuration as the Terraform resources of
+// the providers of the git providers, with the webhook secret as a variable.
+func (e *Export) Terraform() (string, error) {
+	owner, repoName := e.Repository, e.Repository
+	if i := strings.LastIndex(e.Repository, "/"); i >= 0 {
+		owner, repoName = e.Repository[:i], e.Repository[i+1:]
+	}
+	var out bytes.Buffer
+	err := terraformTemplate.Execute(&out, struct {
+		*Export
+		Name           string
+		Owner          string
+		RepositoryName string
+	}{
+		Export:         e,
+		Name:           strings.Trim(terraformNameRe.ReplaceAllString(strings.ToLower(e.Repository), "_"), "_"),
+		Owner:          owner,
+		RepositoryName: repoName,
+	})
+	return out.String(), err
+}
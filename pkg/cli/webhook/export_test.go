@@ -0,0 +1,74 @@
+package webhook
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/apis/pipelinesascode/v1alpha1"
+	"gotest.tools/v3/assert"
+	"gotest.tools/v3/golden"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestExport(t *testing.T) {
+	tests := []struct {
+		name        string
+		provider    string
+		url         string
+		gitProvider *v1alpha1.GitProvider
+		wantErr     string
+	}{
+		{
+			name:     "github",
+			provider: "github",
+			url:      "https://github.com/owner/repo",
+		},
+		{
+			name:     "gitlab subgroup",
+			provider: "gitlab",
+			url:      "https://gitlab.com/group/subgroup/repo",
+			gitProvider: &v1alpha1.GitProvider{
+				WebhookSecret: &v1alpha1.Secret{Name: "gitlab-webhook", Key: "secret"},
+			},
+		},
+		{
+			name:     "gitea",
+			provider: "gitea",
+			url:      "https://gitea.example.com/owner/repo.git",
+		},
+		{
+			name:     "bitbucket cloud",
+			provider: "bitbucket-cloud",
+			url:      "https://bitbucket.org/owner/repo",
+			wantErr:  "exporting the webhook is not supported on bitbucket-cloud",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &v1alpha1.Repository{
+				ObjectMeta: metav1.ObjectMeta{Name: "repo", Namespace: "ns"},
+				Spec:       v1alpha1.RepositorySpec{URL: tt.url, GitProvider: tt.gitProvider},
+			}
+			e, err := NewExport(repo, tt.provider, "https://pac.example.com")
+			if tt.wantErr != "" {
+				assert.Error(t, err, tt.wantErr)
+				return
+			}
+			assert.NilError(t, err)
+
+			terraform, err := e.Terraform()
+			assert.NilError(t, err)
+			golden.Assert(t, terraform, strings.ReplaceAll(fmt.Sprintf("%s.tf.golden", t.Name()), "/", "-"))
+			out, err := e.JSON()
+			assert.NilError(t, err)
+			golden.Assert(t, out, strings.ReplaceAll(fmt.Sprintf("%s.json.golden", t.Name()), "/", "-"))
+		})
+	}
+}
+
+func TestExportNoControllerURL(t *testing.T) {
+	repo := &v1alpha1.Repository{Spec: v1alpha1.RepositorySpec{URL: "https://github.com/owner/repo"}}
+	_, err := NewExport(repo, "github", "")
+	assert.Error(t, err, "the controller URL is needed to export the webhook")
+}
@@ -0,0 +1,152 @@
+package webhook
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"code.gitea.io/sdk/gitea"
+	"github.com/AlecAivazis/survey/v2"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/cli"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/cli/prompt"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/formatting"
+	giteaprovider "github.com/openshift-pipelines/pipelines-as-code/pkg/provider/gitea"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/random"
+)
+
+type giteaConfig struct {
+	Client              *gitea.Client
+	IOStream            *cli.IOStreams
+	TLS                 giteaprovider.TLSOptions
+	controllerURL       string
+	repoOwner           string
+	repoName            string
+	webhookSecret       string
+	personalAccessToken string
+	APIURL              string
+}
+
+func (gt *giteaConfig) Run(_ context.Context, opts *Options) (*response, error) {
+	err := gt.askGiteaWebhookConfig(opts.RepositoryURL, opts.ControllerURL, opts.ProviderAPIURL, opts.PersonalAccessToken)
+	if err != nil {
+		return nil, err
+	}
+
+	return &response{
+		ControllerURL:       gt.controllerURL,
+		PersonalAccessToken: gt.personalAccessToken,
+		WebhookSecret:       gt.webhookSecret,
+		APIURL:              gt.APIURL,
+	}, gt.create()
+}
+
+func (gt *giteaConfig) askGiteaWebhookConfig(repoURL, controllerURL, apiURL, personalAccessToken string) error {
+	if repoURL == "" {
+		msg := "Enter the Gitea repository URL to configure: "
+		if err := prompt.SurveyAskOne(&survey.Input{Message: msg}, &repoURL,
+			survey.WithValidator(survey.Required)); err != nil {
+			return err
+		}
+	} else {
+		fmt.Fprintf(gt.IOStream.Out, "✓ Setting up Gitea Webhook for Repository %s\n", repoURL)
+	}
+
+	var err error
+	if gt.repoOwner, gt.repoName, err = formatting.GetRepoOwnerSplitted(repoURL); err != nil {
+		return err
+	}
+
+	gt.controllerURL = controllerURL
+
+	if gt.controllerURL != "" {
+		var answer bool
+		fmt.Fprintf(gt.IOStream.Out, "👀 Controller URL detected: %s\n", gt.controllerURL)
+		err := prompt.SurveyAskOne(&survey.Confirm{
+			Message: "Do you want me to use it?",
+			Default: true,
+		}, &answer)
+		if err != nil {
+			return err
+		}
+		if !answer {
+			gt.controllerURL = ""
+		}
+	}
+
+	if gt.controllerURL == "" {
+		if err := prompt.SurveyAskOne(&survey.Input{
+			Message: "Enter your controller's public route URL: ",
+		}, &gt.controllerURL, survey.WithValidator(survey.Required)); err != nil {
+			return err
+		}
+	}
+
+	data := random.AlphaString(12)
+	msg := fmt.Sprintf("Enter a secret for webhook payload validation (default: %s): ", data)
+	var webhookSecret string
+	if err := prompt.SurveyAskOne(&survey.Input{Message: msg, Default: data}, &webhookSecret); err != nil {
+		return err
+	}
+
+	gt.webhookSecret = webhookSecret
+
+	if personalAccessToken == "" {
+		fmt.Fprintln(gt.IOStream.Out, "ℹ ️You need to create a Gitea access token with the 'write:repository' scope")
+		if err := prompt.SurveyAskOne(&survey.Password{
+			Message: "Enter your Gitea access token: ",
+		}, &gt.personalAccessToken, survey.WithValidator(survey.Required)); err != nil {
+			return err
+		}
+	} else {
+		gt.personalAccessToken = personalAccessToken
+	}
+
+	if apiURL == "" {
+		if err := prompt.SurveyAskOne(&survey.Input{
+			Message: "Enter your Gitea API URL: ",
+		}, &gt.APIURL, survey.WithValidator(survey.Required)); err != nil {
+			return err
+		}
+	} else {
+		gt.APIURL = apiURL
+	}
+
+	return nil
+}
+
+func (gt *giteaConfig) create() error {
+	giteaClient, err := gt.newClient()
+	if err != nil {
+		return err
+	}
+
+	hookOpts := gitea.CreateHookOption{
+		Type: gitea.HookTypeGitea,
+		Config: map[string]string{
+			"url":          gt.controllerURL,
+			"content_type": "json",
+			"secret":       gt.webhookSecret,
+		},
+		Events: giteaWebhookEvents,
+		Active: true,
+	}
+
+	_, resp, err := giteaClient.CreateRepoHook(gt.repoOwner, gt.repoName, hookOpts)
+	if err != nil {
+		return err
+	}
+
+	if resp.StatusCode != http.StatusCreated {
+		return fmt.Errorf("failed to create webhook, status code: %v", resp.StatusCode)
+	}
+
+	fmt.Fprintln(gt.IOStream.Out, "✓ Webhook successfully created on your repository")
+	return nil
+}
+
+func (gt *giteaConfig) newClient() (*gitea.Client, error) {
+	if gt.Client != nil {
+		return gt.Client, nil
+	}
+	return giteaprovider.NewClient(gt.APIURL, gt.TLS, gitea.SetToken(gt.personalAccessToken))
+}
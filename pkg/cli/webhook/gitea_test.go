@@ -0,0 +1,128 @@
+package webhook
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/cli"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/cli/prompt"
+	thelp "github.com/openshift-pipelines/pipelines-as-code/pkg/provider/gitea/test"
+	"gotest.tools/v3/assert"
+	rtesting "knative.dev/pkg/reconciler/testing"
+)
+
+func TestAskGiteaWebhookConfig(t *testing.T) {
+	//nolint
+	io, _, _, _ := cli.IOTest()
+	tests := []struct {
+		name                string
+		wantErrStr          string
+		askStubs            func(*prompt.AskStubber)
+		providerURL         string
+		controllerURL       string
+		repoURL             string
+		personalaccesstoken string
+		wantOwner           string
+		wantRepo            string
+	}{
+		{
+			name: "ask all details no defaults",
+			askStubs: func(as *prompt.AskStubber) {
+				as.StubOne("https://gitea.example.com/pac/test")
+				as.StubOne("https://test")
+				as.StubOne("webhook-secret")
+				as.StubOne("token")
+				as.StubOne("https://gitea.example.com")
+			},
+			wantOwner: "pac",
+			wantRepo:  "test",
+		},
+		{
+			name: "with defaults and given personalaccesstoken",
+			askStubs: func(as *prompt.AskStubber) {
+				as.StubOne(true)
+				as.StubOne("webhook-secret")
+			},
+			repoURL:             "https://gitea.example.com/pac/demo",
+			controllerURL:       "https://test",
+			providerURL:         "https://gitea.example.com",
+			personalaccesstoken: "token",
+			wantOwner:           "pac",
+			wantRepo:            "demo",
+		},
+		{
+			name:       "invalid repository url",
+			repoURL:    "https://gitea.example.com/pac",
+			wantErrStr: "invalid repo url at least a organization/project and a repo needs to be specified: https://gitea.example.com/pac",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			as, teardown := prompt.InitAskStubber()
+			defer teardown()
+			if tt.askStubs != nil {
+				tt.askStubs(as)
+			}
+			gt := giteaConfig{IOStream: io}
+			err := gt.askGiteaWebhookConfig(tt.repoURL, tt.controllerURL, tt.providerURL, tt.personalaccesstoken)
+			if tt.wantErrStr != "" {
+				assert.Equal(t, err.Error(), tt.wantErrStr)
+				return
+			}
+			assert.NilError(t, err)
+			assert.Equal(t, gt.repoOwner, tt.wantOwner)
+			assert.Equal(t, gt.repoName, tt.wantRepo)
+		})
+	}
+}
+
+func TestGiteaCreate(t *testing.T) {
+	_, _ = rtesting.SetupFakeContext(t)
+	fakeclient, mux, teardown := thelp.Setup(t)
+	defer teardown()
+	//nolint
+	io, _, _, _ := cli.IOTest()
+
+	// webhook created
+	mux.HandleFunc("/repos/pac/created/hooks", func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusCreated)
+		_, _ = fmt.Fprint(w, `{"id": 1}`)
+	})
+
+	// webhook failed
+	mux.HandleFunc("/repos/pac/forbidden/hooks", func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+		_, _ = fmt.Fprint(w, `{"message": "forbidden"}`)
+	})
+
+	tests := []struct {
+		name     string
+		repoName string
+		wantErr  bool
+	}{
+		{
+			name:     "webhook created",
+			repoName: "created",
+		},
+		{
+			name:     "webhook failed",
+			repoName: "forbidden",
+			wantErr:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gt := giteaConfig{
+				IOStream:  io,
+				Client:    fakeclient,
+				repoOwner: "pac",
+				repoName:  tt.repoName,
+			}
+			err := gt.create()
+			assert.Equal(t, err != nil, tt.wantErr)
+		})
+	}
+}
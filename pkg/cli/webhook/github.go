@@ -13,7 +13,6 @@ import (
 	"github.com/openshift-pipelines/pipelines-as-code/pkg/cli"
 	"github.com/openshift-pipelines/pipelines-as-code/pkg/cli/prompt"
 	"github.com/openshift-pipelines/pipelines-as-code/pkg/formatting"
-	"github.com/openshift-pipelines/pipelines-as-code/pkg/params/triggertype"
 	"github.com/openshift-pipelines/pipelines-as-code/pkg/random"
 	"golang.org/x/oauth2"
 )
@@ -132,11 +131,7 @@ func (gh *gitHubConfig) create(ctx context.Context) error {
 	hook := &github.Hook{
 		Name:   github.Ptr("web"),
 		Active: github.Ptr(true),
-		Events: []string{
-			"issue_comment",
-			triggertype.PullRequest.String(),
-			"push",
-		},
+		Events: githubWebhookEvents,
 		Config: &github.HookConfig{
 			URL:         github.Ptr(gh.controllerURL),
 			ContentType: github.Ptr("json"),
@@ -11,6 +11,8 @@ import (
 	"github.com/openshift-pipelines/pipelines-as-code/pkg/cli/prompt"
 	"github.com/openshift-pipelines/pipelines-as-code/pkg/cmd/tknpac/bootstrap"
 	"github.com/openshift-pipelines/pipelines-as-code/pkg/params"
+	giteaprovider "github.com/openshift-pipelines/pipelines-as-code/pkg/provider/gitea"
+	"github.com/spf13/pflag"
 )
 
 type Interface interface {
@@ -18,18 +20,24 @@ type Interface interface {
 }
 
 type Options struct {
-	Run                      *params.Run
-	IOStreams                *cli.IOStreams
-	PACNamespace             string
-	RepositoryURL            string
-	RepositoryName           string
-	RepositoryNamespace      string
-	ProviderAPIURL           string
-	ControllerURL            string
+	Run                 *params.Run
+	IOStreams           *cli.IOStreams
+	PACNamespace        string
+	RepositoryURL       string
+	RepositoryName      string
+	RepositoryNamespace string
+	ProviderAPIURL      string
+	ControllerURL       string
+	// Listener is the name of the listener of the controller-urls of the
+	// pipelines-as-code-info configmap the webhook targets.
+	Listener                 string
 	PersonalAccessToken      string
 	RepositoryCreateORUpdate bool
 	SecretName               string
 	ProviderSecretKey        string
+	// TLS configures the verification of the certificate of a Gitea
+	// instance using a self-signed certificate.
+	TLS giteaprovider.TLSOptions
 }
 
 type response struct {
@@ -57,7 +65,11 @@ func (w *Options) Install(ctx context.Context, providerType string) error {
 	}
 
 	// check if info configmap has url then use that otherwise try to detect
-	if pacInfo.ControllerURL != "" && w.ControllerURL == "" {
+	if w.Listener != "" {
+		if w.ControllerURL, err = pacInfo.GetControllerURL(w.Listener); err != nil {
+			return err
+		}
+	} else if pacInfo.ControllerURL != "" && w.ControllerURL == "" {
 		w.ControllerURL = pacInfo.ControllerURL
 	} else {
 		w.ControllerURL, _ = bootstrap.DetectOpenShiftRoute(ctx, w.Run, w.PACNamespace)
@@ -79,6 +91,8 @@ func (w *Options) Install(ctx context.Context, providerType string) error {
 		webhookProvider = &gitLabConfig{IOStream: w.IOStreams}
 	case "bitbucket-cloud":
 		webhookProvider = &bitbucketCloudConfig{IOStream: w.IOStreams}
+	case "gitea":
+		webhookProvider = &giteaConfig{IOStream: w.IOStreams, TLS: w.TLS}
 	default:
 		return fmt.Errorf("invalid webhook provider")
 	}
@@ -102,6 +116,15 @@ func (w *Options) Install(ctx context.Context, providerType string) error {
 	return w.updateRepositoryCR(ctx, response)
 }
 
+// AddTLSFlags adds the flags configuring the verification of the certificate
+// of a Gitea instance.
+func AddTLSFlags(flags *pflag.FlagSet, opts *giteaprovider.TLSOptions) {
+	flags.BoolVar(&opts.InsecureSkipVerify, "insecure-skip-tls-verify", false,
+		"Skip the verification of the TLS certificate of the Gitea instance")
+	flags.StringVar(&opts.CAFile, "ca-file", "",
+		"A PEM file with the certificate authority of the Gitea instance")
+}
+
 func GetProviderName(url string) (string, error) {
 	var (
 		err          error
@@ -114,12 +137,14 @@ func GetProviderName(url string) (string, error) {
 		providerName = "gitlab"
 	case strings.Contains(url, "bitbucket-cloud"):
 		providerName = "bitbucket-cloud"
+	case strings.Contains(url, "gitea"):
+		providerName = "gitea"
 	default:
 		msg := "Please select the type of the git platform to setup webhook:"
 		if err = prompt.SurveyAskOne(
 			&survey.Select{
 				Message: msg,
-				Options: []string{"github", "gitlab", "bitbucket-cloud"},
+				Options: []string{"github", "gitlab", "bitbucket-cloud", "gitea"},
 				Default: 0,
 			}, &providerName); err != nil {
 			return "", err
@@ -8,6 +8,7 @@ import (
 	"maps"
 	"os"
 	"path/filepath"
+	"strconv"
 	"strings"
 
 	_ "embed"
@@ -449,6 +450,7 @@ func pacParamsFromEvent(event *info.Event) map[string]string {
 		"event_title":         eventTitle,
 		"trigger_comment":     triggerComment,
 		"pull_request_labels": pullRequestLabels,
+		"force_push":          strconv.FormatBool(event.ForcePush),
 	}
 }
 
@@ -771,6 +771,7 @@ func TestPacParamsFromEvent(t *testing.T) {
 				"event_title":         "Add new feature",
 				"trigger_comment":     "test comment\\nwith newlines",
 				"pull_request_labels": "bug\nenhancement",
+				"force_push":          "false",
 			},
 		},
 		{
@@ -806,6 +807,7 @@ func TestPacParamsFromEvent(t *testing.T) {
 				"event_title":         "Release v1.0.0",
 				"trigger_comment":     "",
 				"pull_request_labels": "",
+				"force_push":          "false",
 			},
 		},
 	}
@@ -851,6 +853,7 @@ func TestPacParamsFromEventEdgeCases(t *testing.T) {
 				"event_title":         "",
 				"trigger_comment":     "",
 				"pull_request_labels": "",
+				"force_push":          "false",
 			},
 		},
 		{
@@ -876,6 +879,7 @@ func TestPacParamsFromEventEdgeCases(t *testing.T) {
 				"event_title":         "",
 				"trigger_comment":     "line1\\nline2\\nline3", // newlines escaped
 				"pull_request_labels": "",
+				"force_push":          "false",
 			},
 		},
 		{
@@ -900,6 +904,7 @@ func TestPacParamsFromEventEdgeCases(t *testing.T) {
 				"event_title":         "",
 				"trigger_comment":     "",
 				"pull_request_labels": "",
+				"force_push":          "false",
 			},
 		},
 	}
@@ -20,6 +20,7 @@ import (
 	"github.com/openshift-pipelines/pipelines-as-code/pkg/git"
 	"github.com/openshift-pipelines/pipelines-as-code/pkg/params"
 	"github.com/openshift-pipelines/pipelines-as-code/pkg/params/info"
+	giteaprovider "github.com/openshift-pipelines/pipelines-as-code/pkg/provider/gitea"
 	"github.com/spf13/cobra"
 	v1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -36,6 +37,7 @@ type RepoOptions struct {
 	GitInfo      *git.Info
 	pacNamespace string
 	Provider     string
+	tlsOpts      giteaprovider.TLSOptions
 
 	IoStreams *cli.IOStreams
 	cliOpts   *cli.PacCliOpts
@@ -69,6 +71,7 @@ func repositoryCommand(run *params.Run, ioStreams *cli.IOStreams) *cobra.Command
 			if err := getRepoURL(createOpts); err != nil {
 				return err
 			}
+			createOpts.Event.URL = formatting.CanonicalRepoURL(createOpts.Event.URL)
 
 			repoName, repoNamespace, err := createOpts.Create(ctx)
 			if err != nil {
@@ -103,6 +106,7 @@ func repositoryCommand(run *params.Run, ioStreams *cli.IOStreams) *cobra.Command
 				RepositoryName:           repoName,
 				RepositoryNamespace:      repoNamespace,
 				RepositoryCreateORUpdate: true,
+				TLS:                      createOpts.tlsOpts,
 			}
 
 			if err := config.Install(ctx, createOpts.Provider); err != nil {
@@ -122,6 +126,7 @@ func repositoryCommand(run *params.Run, ioStreams *cli.IOStreams) *cobra.Command
 		"The target namespace where the runs will be created")
 	cmd.PersistentFlags().StringVarP(&createOpts.pacNamespace, "pac-namespace",
 		"", "", "The namespace where pac is installed")
+	webhook.AddTLSFlags(cmd.PersistentFlags(), &createOpts.tlsOpts)
 	return cmd
 }
 
@@ -241,11 +246,10 @@ func getRepoURL(opts *RepoOptions) error {
 }
 
 func cleanupGitURL(rawURL string) (string, error) {
-	parsedURL, err := url.Parse(rawURL)
-	if err != nil {
+	if _, err := url.Parse(rawURL); err != nil {
 		return "", err
 	}
-	return fmt.Sprintf("%s://%s%s", parsedURL.Scheme, parsedURL.Host, parsedURL.Path), nil
+	return formatting.CanonicalRepoURL(rawURL), nil
 }
 
 func createRepoCRD(ctx context.Context, opts *RepoOptions) (string, string, error) {
@@ -16,7 +16,9 @@ import (
 	"github.com/openshift-pipelines/pipelines-as-code/pkg/cli"
 	"github.com/openshift-pipelines/pipelines-as-code/pkg/cli/prompt"
 	"github.com/openshift-pipelines/pipelines-as-code/pkg/cli/status"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/cli/webhook"
 	"github.com/openshift-pipelines/pipelines-as-code/pkg/cmd/tknpac/completion"
+	tknpacwebhook "github.com/openshift-pipelines/pipelines-as-code/pkg/cmd/tknpac/webhook"
 	"github.com/openshift-pipelines/pipelines-as-code/pkg/consoleui"
 	"github.com/openshift-pipelines/pipelines-as-code/pkg/formatting"
 	"github.com/openshift-pipelines/pipelines-as-code/pkg/kubeinteraction"
@@ -33,6 +35,7 @@ var (
 	targetPRFlag      = "target-pipelinerun"
 	useRealTimeFlag   = "use-realtime"
 	showEventflag     = "show-events"
+	diagnoseFlag      = "diagnose"
 	creationTimestamp = "{.metadata.creationTimestamp}"
 	maxEventLimit     = 50
 )
@@ -62,11 +65,18 @@ type describeOpts struct {
 	cli.PacCliOpts
 	TargetPipelineRun string
 	ShowEvents        bool
+	Diagnose          bool
+	// newDeliveries returns the webhook deliveries of the Repository for the
+	// diagnosis.
+	newDeliveries newDeliveriesFunc
 }
 
 func newDescribeOptions(_ *cobra.Command) *describeOpts {
 	return &describeOpts{
 		PacCliOpts: *cli.NewCliOptions(),
+		newDeliveries: func(ctx context.Context, run *params.Run, repo *v1alpha1.Repository) (webhook.Deliveries, error) {
+			return tknpacwebhook.NewRepositoryDeliveries(ctx, run, repo, "", 0)
+		},
 	}
 }
 
@@ -102,6 +112,11 @@ func Root(run *params.Run, ioStreams *cli.IOStreams) *cobra.Command {
 				return err
 			}
 
+			opts.Diagnose, err = cmd.Flags().GetBool(diagnoseFlag)
+			if err != nil {
+				return err
+			}
+
 			opts.TargetPipelineRun, err = cmd.Flags().GetString(targetPRFlag)
 			if err != nil {
 				return err
@@ -145,6 +160,8 @@ func Root(run *params.Run, ioStreams *cli.IOStreams) *cobra.Command {
 
 	cmd.Flags().BoolP(
 		showEventflag, "", false, "show kubernetes events associated with this repository, useful if you have an error that cannot be reported on the git provider interface")
+	cmd.Flags().BoolP(
+		diagnoseFlag, "", false, "explain why the last event of the repository has produced a PipelineRun or not, from its last webhook delivery, its events and its PipelineRuns")
 	cmd.PersistentFlags().BoolVarP(&useRealTime, useRealTimeFlag, "", false,
 		"display the time as RFC3339 instead of a relative time")
 	return cmd
@@ -235,6 +252,9 @@ func describe(ctx context.Context, cs *params.Run, clock clockwork.Clock, opts *
 		"formatTime":      formatting.Age,
 		"sanitizeBranch":  formatting.SanitizeBranch,
 		"shortSHA":        formatting.ShortSHA,
+		"failureReason": func(ev corev1.Event) string {
+			return ev.GetLabels()[keys.FailureReason]
+		},
 	}
 
 	statuses := status.MixLivePRandRepoStatus(ctx, cs, *repository)
@@ -267,6 +287,16 @@ func describe(ctx context.Context, cs *params.Run, clock clockwork.Clock, opts *
 	if err := t.Execute(w, data); err != nil {
 		return err
 	}
+	if err := w.Flush(); err != nil {
+		return err
+	}
 
-	return w.Flush()
+	if opts.Diagnose {
+		d, err := diagnoseRepository(ctx, cs, clock, opts, repository)
+		if err != nil {
+			return err
+		}
+		d.print(ioStreams.Out, colorScheme)
+	}
+	return nil
 }
@@ -39,6 +39,7 @@ func TestDescribe(t *testing.T) {
 		opts             *describeOpts
 		pruns            []*tektonv1.PipelineRun
 		events           []*corev1.Event
+		lastFailure      *v1alpha1.RepositoryFailure
 	}
 	tests := []struct {
 		name    string
@@ -304,6 +305,43 @@ func TestDescribe(t *testing.T) {
 			},
 			wantErr: false,
 		},
+		{
+			name: "repository last failure",
+			args: args{
+				repoName:         "test-run",
+				currentNamespace: "namespace",
+				opts: &describeOpts{
+					PacCliOpts: cli.PacCliOpts{
+						Namespace: "namespace",
+					},
+					ShowEvents: true,
+				},
+				lastFailure: &v1alpha1.RepositoryFailure{
+					Reason:  v1alpha1.FailureReasonACLDenied,
+					Message: "User nobody is not allowed to trigger CI on this repo.",
+					Time:    metav1.Time{Time: cw.Now().Add(-5 * time.Minute)},
+				},
+				events: []*corev1.Event{
+					{
+						ObjectMeta: metav1.ObjectMeta{
+							CreationTimestamp: metav1.Time{Time: cw.Now().Add(-5 * time.Minute)},
+							Namespace:         "namespace",
+							Name:              "test-run-abcd",
+							Labels: map[string]string{
+								keys.FailureReason: string(v1alpha1.FailureReasonACLDenied),
+							},
+						},
+						Message: "User nobody is not allowed to trigger CI on this repo.",
+						Reason:  "RepositoryPermissionDenied",
+						Type:    corev1.EventTypeNormal,
+						InvolvedObject: corev1.ObjectReference{
+							Name: "test-run", Kind: "Repository", Namespace: "namespace",
+						},
+					},
+				},
+			},
+			wantErr: false,
+		},
 		{
 			name: "repository multiple events",
 			args: args{
@@ -465,6 +503,9 @@ func TestDescribe(t *testing.T) {
 					Status: tt.args.statuses,
 				},
 			}
+			if tt.args.lastFailure != nil {
+				repositories[0].RepositoryStatus = &v1alpha1.RepositoryStatus{LastFailure: tt.args.lastFailure}
+			}
 
 			tdata := testclient.Data{
 				Events: tt.args.events,
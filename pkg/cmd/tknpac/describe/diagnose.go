@@ -0,0 +1,240 @@
+package describe
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/jonboulle/clockwork"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/apis/pipelinesascode/keys"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/apis/pipelinesascode/v1alpha1"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/cli"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/cli/webhook"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/formatting"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/kubeinteraction"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/params"
+	tektonv1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// diagnoseWindow is how long after the last webhook delivery the events and
+// the PipelineRuns of the Repository are considered to be produced by it.
+const diagnoseWindow = 5 * time.Minute
+
+// noMatchReasons are the reasons of the events emitted when no PipelineRun
+// matches the event.
+var noMatchReasons = []string{
+	"RepositoryNoMatch",
+	"RepositoryCannotLocatePipelineRun",
+	"RepositoryCannotLocatePipelineRunForIncomingEvent",
+}
+
+// newDeliveriesFunc returns the webhook deliveries of a Repository.
+type newDeliveriesFunc func(ctx context.Context, run *params.Run, repo *v1alpha1.Repository) (webhook.Deliveries, error)
+
+type diagnosisResult int
+
+const (
+	diagnosisOK diagnosisResult = iota
+	diagnosisFailed
+	diagnosisUnknown
+	diagnosisNotReached
+)
+
+// diagnosisStep is a step of the processing of an event by the controller.
+type diagnosisStep struct {
+	name   string
+	result diagnosisResult
+	detail string
+}
+
+// diagnosis explains why the last event of a Repository has produced a run
+// or not.
+type diagnosis struct {
+	steps []diagnosisStep
+}
+
+func (d *diagnosis) add(name string, result diagnosisResult, format string, args ...any) {
+	d.steps = append(d.steps, diagnosisStep{name: name, result: result, detail: fmt.Sprintf(format, args...)})
+}
+
+// notReached adds the steps the event didn't reach.
+func (d *diagnosis) notReached(names ...string) {
+	for _, name := range names {
+		d.add(name, diagnosisNotReached, "not reached")
+	}
+}
+
+// eventTime returns the last time the event has been seen, the identical
+// events are counted in one event.
+func eventTime(ev corev1.Event) time.Time {
+	if !ev.LastTimestamp.IsZero() {
+		return ev.LastTimestamp.Time
+	}
+	return ev.CreationTimestamp.Time
+}
+
+// lastEventWindow returns the time range of the events produced by the last
+// event: after the last delivery or before the last event of the Repository.
+func lastEventWindow(delivery *webhook.Delivery, events []corev1.Event, prs []tektonv1.PipelineRun) (time.Time, time.Time) {
+	if delivery != nil {
+		return delivery.DeliveredAt, delivery.DeliveredAt.Add(diagnoseWindow)
+	}
+	last := time.Time{}
+	for _, ev := range events {
+		if t := eventTime(ev); t.After(last) {
+			last = t
+		}
+	}
+	for _, pr := range prs {
+		if t := pr.GetCreationTimestamp().Time; t.After(last) {
+			last = t
+		}
+	}
+	return last.Add(-diagnoseWindow), last
+}
+
+// diagnose correlates the last webhook delivery of the Repository with its
+// events and its PipelineRuns to explain what happened to the event.
+func diagnose(repo *v1alpha1.Repository, delivery *webhook.Delivery, deliveryErr error, events []corev1.Event, prs []tektonv1.PipelineRun, clock clockwork.Clock) *diagnosis {
+	d := &diagnosis{}
+	switch {
+	case deliveryErr != nil:
+		d.add("Webhook delivery", diagnosisUnknown, "cannot get the webhook deliveries: %v", deliveryErr)
+	case delivery == nil:
+		d.add("Webhook delivery", diagnosisUnknown, "no webhook delivery found")
+	default:
+		name := delivery.Event
+		if delivery.Action != "" {
+			name += "." + delivery.Action
+		}
+		age := formatting.Age(&metav1.Time{Time: delivery.DeliveredAt}, clock)
+		if delivery.StatusCode >= 400 || delivery.StatusCode == 0 {
+			d.add("Webhook delivery", diagnosisFailed, "%s delivered %s, the controller answered %d %s, the event has not been processed",
+				name, age, delivery.StatusCode, delivery.Status)
+			d.notReached("Repository matched", "Sender allowed", "PipelineRun matched", "Errors")
+			return d
+		}
+		d.add("Webhook delivery", diagnosisOK, "%s delivered %s, the controller answered %d %s", name, age, delivery.StatusCode, delivery.Status)
+	}
+
+	from, to := lastEventWindow(delivery, events, prs)
+	inWindow := func(t time.Time) bool { return !t.Before(from) && !t.After(to) }
+	lastEvents := []corev1.Event{}
+	for _, ev := range events {
+		if inWindow(eventTime(ev)) {
+			lastEvents = append(lastEvents, ev)
+		}
+	}
+	lastPRs := []string{}
+	for _, pr := range prs {
+		if inWindow(pr.GetCreationTimestamp().Time) {
+			lastPRs = append(lastPRs, pr.GetName())
+		}
+	}
+
+	if len(lastEvents) == 0 && len(lastPRs) == 0 {
+		d.add("Repository matched", diagnosisFailed,
+			"nothing has been recorded for the Repository, check that the URL of the repository of the event is %s and the logs of the controller",
+			repo.Spec.URL)
+		d.notReached("Sender allowed", "PipelineRun matched", "Errors")
+		return d
+	}
+	d.add("Repository matched", diagnosisOK, "the event has been matched to the Repository %s/%s", repo.GetNamespace(), repo.GetName())
+
+	failures := []corev1.Event{}
+	for _, ev := range lastEvents {
+		if ev.GetLabels()[keys.FailureReason] == string(v1alpha1.FailureReasonACLDenied) || ev.Reason == "RepositoryPermissionDenied" {
+			d.add("Sender allowed", diagnosisFailed, "%s", ev.Message)
+			d.notReached("PipelineRun matched", "Errors")
+			return d
+		}
+		if ev.GetLabels()[keys.FailureReason] != "" {
+			failures = append(failures, ev)
+		}
+	}
+	d.add("Sender allowed", diagnosisOK, "the sender is allowed to run the CI")
+
+	switch {
+	case len(lastPRs) > 0:
+		d.add("PipelineRun matched", diagnosisOK, "created %s", strings.Join(lastPRs, ", "))
+	default:
+		idx := slices.IndexFunc(lastEvents, func(ev corev1.Event) bool { return slices.Contains(noMatchReasons, ev.Reason) })
+		switch {
+		case idx >= 0:
+			d.add("PipelineRun matched", diagnosisFailed, "%s", lastEvents[idx].Message)
+		case len(failures) > 0:
+			d.add("PipelineRun matched", diagnosisFailed, "no PipelineRun has been created because of the errors")
+		default:
+			d.add("PipelineRun matched", diagnosisUnknown, "no PipelineRun has been created, check the logs of the controller")
+		}
+	}
+
+	if len(failures) == 0 {
+		d.add("Errors", diagnosisOK, "no error reported")
+		return d
+	}
+	messages := []string{}
+	for _, ev := range failures {
+		messages = append(messages, fmt.Sprintf("%s (%s): %s", ev.Reason, ev.GetLabels()[keys.FailureReason], ev.Message))
+	}
+	d.add("Errors", diagnosisFailed, "%s", strings.Join(messages, "\n  "))
+	return d
+}
+
+func (d *diagnosis) print(out io.Writer, cs *cli.ColorScheme) {
+	fmt.Fprintf(out, "\n%s\n\n", cs.Underline("Diagnosis of the last event:"))
+	for _, step := range d.steps {
+		icon := cs.InfoIcon()
+		detail := step.detail
+		switch step.result {
+		case diagnosisOK:
+			icon = cs.SuccessIcon()
+		case diagnosisFailed:
+			icon = cs.FailureIcon()
+		case diagnosisNotReached:
+			icon = "-"
+			detail = cs.Dimmed(detail)
+		case diagnosisUnknown:
+		}
+		fmt.Fprintf(out, "%s %s: %s\n", icon, cs.Bold(step.name), detail)
+	}
+}
+
+// diagnoseRepository diagnoses the last event of the Repository from its
+// last webhook delivery, its events and its PipelineRuns.
+func diagnoseRepository(ctx context.Context, run *params.Run, clock clockwork.Clock, opts *describeOpts, repo *v1alpha1.Repository) (*diagnosis, error) {
+	kinteract, err := kubeinteraction.NewKubernetesInteraction(run)
+	if err != nil {
+		return nil, err
+	}
+	events := []corev1.Event{}
+	if list, err := kinteract.GetEvents(ctx, repo.GetNamespace(), "Repository", repo.GetName()); err == nil {
+		events = list.Items
+	}
+	prs, err := run.Clients.Tekton.TektonV1().PipelineRuns(repo.GetNamespace()).List(ctx, metav1.ListOptions{
+		LabelSelector: keys.Repository + "=" + repo.GetName(),
+	})
+	if err != nil {
+		return nil, err
+	}
+	delivery, deliveryErr := lastDelivery(ctx, run, repo, opts.newDeliveries)
+	return diagnose(repo, delivery, deliveryErr, events, prs.Items, clock), nil
+}
+
+// lastDelivery returns the last webhook delivery of the Repository.
+func lastDelivery(ctx context.Context, run *params.Run, repo *v1alpha1.Repository, newDeliveries newDeliveriesFunc) (*webhook.Delivery, error) {
+	deliveries, err := newDeliveries(ctx, run, repo)
+	if err != nil {
+		return nil, err
+	}
+	list, err := deliveries.List(ctx, 1)
+	if err != nil || len(list) == 0 {
+		return nil, err
+	}
+	return &list[0], nil
+}
@@ -0,0 +1,205 @@
+package describe
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jonboulle/clockwork"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/apis/pipelinesascode/keys"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/apis/pipelinesascode/v1alpha1"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/cli"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/cli/webhook"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/consoleui"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/params"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/params/clients"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/params/info"
+	tcli "github.com/openshift-pipelines/pipelines-as-code/pkg/test/cli"
+	testclient "github.com/openshift-pipelines/pipelines-as-code/pkg/test/clients"
+	tektonv1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+	"gotest.tools/v3/assert"
+	"gotest.tools/v3/golden"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	rtesting "knative.dev/pkg/reconciler/testing"
+)
+
+type fakeDeliveries struct {
+	deliveries []webhook.Delivery
+	err        error
+}
+
+func (f *fakeDeliveries) List(_ context.Context, limit int) ([]webhook.Delivery, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	return f.deliveries[:min(limit, len(f.deliveries))], nil
+}
+
+func (f *fakeDeliveries) Redeliver(_ context.Context, _ int64) error {
+	return nil
+}
+
+func makeEvent(at time.Time, reason, failure, message string) corev1.Event {
+	ev := corev1.Event{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:              fmt.Sprintf("test-run.%x", at.UnixNano()),
+			Namespace:         "ns",
+			CreationTimestamp: metav1.Time{Time: at},
+		},
+		LastTimestamp: metav1.Time{Time: at},
+		Reason:        reason,
+		Message:       message,
+		Type:          corev1.EventTypeWarning,
+		InvolvedObject: corev1.ObjectReference{
+			Name: "test-run", Kind: "Repository", Namespace: "ns",
+		},
+	}
+	if failure != "" {
+		ev.Labels = map[string]string{keys.FailureReason: failure}
+	}
+	return ev
+}
+
+func TestDiagnose(t *testing.T) {
+	now := time.Date(1999, time.February, 3, 4, 5, 6, 7, time.UTC)
+	cw := clockwork.NewFakeClockAt(now)
+	delivered := now.Add(-2 * time.Minute)
+	repo := &v1alpha1.Repository{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-run", Namespace: "ns"},
+		Spec:       v1alpha1.RepositorySpec{URL: "https://github.com/owner/repo"},
+	}
+	pr := func(name string, at time.Time) tektonv1.PipelineRun {
+		return tektonv1.PipelineRun{ObjectMeta: metav1.ObjectMeta{Name: name, CreationTimestamp: metav1.Time{Time: at}}}
+	}
+	tests := []struct {
+		name        string
+		delivery    *webhook.Delivery
+		deliveryErr error
+		events      []corev1.Event
+		prs         []tektonv1.PipelineRun
+		want        []diagnosisResult
+		wantDetail  string
+	}{
+		{
+			name:       "pipelinerun created",
+			delivery:   &webhook.Delivery{Event: "pull_request", Action: "opened", DeliveredAt: delivered, StatusCode: 202, Status: "Accepted"},
+			prs:        []tektonv1.PipelineRun{pr("old", now.Add(-time.Hour)), pr("pr-abcd", delivered.Add(time.Second))},
+			want:       []diagnosisResult{diagnosisOK, diagnosisOK, diagnosisOK, diagnosisOK, diagnosisOK},
+			wantDetail: "created pr-abcd",
+		},
+		{
+			name:       "delivery failed",
+			delivery:   &webhook.Delivery{Event: "push", DeliveredAt: delivered, StatusCode: 503, Status: "Service Unavailable"},
+			want:       []diagnosisResult{diagnosisFailed, diagnosisNotReached, diagnosisNotReached, diagnosisNotReached, diagnosisNotReached},
+			wantDetail: "the controller answered 503 Service Unavailable",
+		},
+		{
+			name:       "repository not matched",
+			delivery:   &webhook.Delivery{Event: "push", DeliveredAt: delivered, StatusCode: 200, Status: "OK"},
+			events:     []corev1.Event{makeEvent(now.Add(-time.Hour), "RepositoryNoMatch", "", "older event")},
+			want:       []diagnosisResult{diagnosisOK, diagnosisFailed, diagnosisNotReached, diagnosisNotReached, diagnosisNotReached},
+			wantDetail: "https://github.com/owner/repo",
+		},
+		{
+			name:     "sender not allowed",
+			delivery: &webhook.Delivery{Event: "pull_request", DeliveredAt: delivered, StatusCode: 200, Status: "OK"},
+			events: []corev1.Event{
+				makeEvent(delivered.Add(time.Second), "RepositoryPermissionDenied", string(v1alpha1.FailureReasonACLDenied), "User nobody is not allowed to trigger CI"),
+			},
+			want:       []diagnosisResult{diagnosisOK, diagnosisOK, diagnosisFailed, diagnosisNotReached, diagnosisNotReached},
+			wantDetail: "User nobody is not allowed to trigger CI",
+		},
+		{
+			name:     "no pipelinerun matched",
+			delivery: &webhook.Delivery{Event: "push", DeliveredAt: delivered, StatusCode: 200, Status: "OK"},
+			events: []corev1.Event{
+				makeEvent(delivered.Add(time.Second), "RepositoryNoMatch", "", "cannot match any pipelinerun"),
+			},
+			want:       []diagnosisResult{diagnosisOK, diagnosisOK, diagnosisOK, diagnosisFailed, diagnosisOK},
+			wantDetail: "cannot match any pipelinerun",
+		},
+		{
+			name:     "invalid pipelinerun",
+			delivery: &webhook.Delivery{Event: "push", DeliveredAt: delivered, StatusCode: 200, Status: "OK"},
+			events: []corev1.Event{
+				makeEvent(delivered.Add(time.Second), "PipelineRunValidationErrors", string(v1alpha1.FailureReasonYAMLInvalid), "pipelinerun pr has invalid yaml"),
+			},
+			want:       []diagnosisResult{diagnosisOK, diagnosisOK, diagnosisOK, diagnosisFailed, diagnosisFailed},
+			wantDetail: "PipelineRunValidationErrors (YAMLInvalid): pipelinerun pr has invalid yaml",
+		},
+		{
+			name:        "no delivery uses the last events",
+			deliveryErr: fmt.Errorf("no webhook"),
+			events: []corev1.Event{
+				makeEvent(now.Add(-time.Hour), "PipelineRunValidationErrors", string(v1alpha1.FailureReasonYAMLInvalid), "older event"),
+				makeEvent(delivered, "RepositoryNoMatch", "", "cannot match any pipelinerun"),
+			},
+			want:       []diagnosisResult{diagnosisUnknown, diagnosisOK, diagnosisOK, diagnosisFailed, diagnosisOK},
+			wantDetail: "cannot get the webhook deliveries: no webhook",
+		},
+		{
+			name:       "nothing happened",
+			delivery:   &webhook.Delivery{Event: "push", DeliveredAt: delivered, StatusCode: 200, Status: "OK"},
+			events:     []corev1.Event{makeEvent(delivered.Add(time.Second), "RepositoryCreateStatus", "", "status created")},
+			want:       []diagnosisResult{diagnosisOK, diagnosisOK, diagnosisOK, diagnosisUnknown, diagnosisOK},
+			wantDetail: "check the logs of the controller",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := diagnose(repo, tt.delivery, tt.deliveryErr, tt.events, tt.prs, cw)
+			got := []diagnosisResult{}
+			details := []string{}
+			for _, step := range d.steps {
+				got = append(got, step.result)
+				details = append(details, step.detail)
+			}
+			assert.DeepEqual(t, got, tt.want)
+			assert.Assert(t, strings.Contains(strings.Join(details, "\n"), tt.wantDetail), "%v does not contain %s", details, tt.wantDetail)
+		})
+	}
+}
+
+func TestDescribeDiagnose(t *testing.T) {
+	now := time.Date(1999, time.February, 3, 4, 5, 6, 7, time.UTC)
+	cw := clockwork.NewFakeClockAt(now)
+	ns := "ns"
+	ev := makeEvent(now.Add(-time.Minute), "RepositoryNoMatch", "", "cannot match any pipelinerun on the push event")
+	tdata := testclient.Data{
+		Namespaces: []*corev1.Namespace{{ObjectMeta: metav1.ObjectMeta{Name: ns}}},
+		Events:     []*corev1.Event{&ev},
+		Repositories: []*v1alpha1.Repository{
+			{
+				ObjectMeta: metav1.ObjectMeta{Name: "test-run", Namespace: ns},
+				Spec:       v1alpha1.RepositorySpec{URL: "https://anurl.com"},
+			},
+		},
+	}
+	ctx, _ := rtesting.SetupFakeContext(t)
+	stdata, _ := testclient.SeedTestData(t, ctx, tdata)
+	cs := &params.Run{
+		Clients: clients.Clients{
+			PipelineAsCode: stdata.PipelineAsCode,
+			Tekton:         stdata.Pipeline,
+			Kube:           stdata.Kube,
+		},
+		Info: info.Info{Kube: &info.KubeOpts{Namespace: ns}},
+	}
+	cs.Clients.SetConsoleUI(consoleui.FallBackConsole{})
+	opts := &describeOpts{
+		PacCliOpts: cli.PacCliOpts{Namespace: ns},
+		Diagnose:   true,
+		newDeliveries: func(_ context.Context, _ *params.Run, _ *v1alpha1.Repository) (webhook.Deliveries, error) {
+			return &fakeDeliveries{deliveries: []webhook.Delivery{
+				{Event: "push", DeliveredAt: now.Add(-2 * time.Minute), StatusCode: 200, Status: "OK"},
+			}}, nil
+		},
+	}
+
+	io, out := tcli.NewIOStream()
+	assert.NilError(t, describe(ctx, cs, cw, opts, io, "test-run"))
+	golden.Assert(t, out.String(), fmt.Sprintf("%s.golden", t.Name()))
+}
@@ -1,9 +1,13 @@
 package list
 
 import (
+	"bytes"
 	"context"
 	_ "embed"
 	"fmt"
+	"io"
+	"sort"
+	"strings"
 	"text/tabwriter"
 	"text/template"
 
@@ -16,7 +20,9 @@ import (
 	"github.com/openshift-pipelines/pipelines-as-code/pkg/formatting"
 	"github.com/openshift-pipelines/pipelines-as-code/pkg/params"
 	"github.com/spf13/cobra"
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/watch"
 )
 
 //go:embed template/list.tmpl
@@ -27,11 +33,28 @@ var (
 	namespaceFlag     = "namespace"
 	useRealTimeFlag   = "use-realtime"
 	noHeadersFlag     = "no-headers"
+	sortByFlag        = "sort-by"
+	failedFlag        = "failed"
+	watchFlag         = "watch"
+
+	sortByLastRun = "last-run"
 )
 
+type listOptions struct {
+	selectors  string
+	sortBy     string
+	failedOnly bool
+	watch      bool
+}
+
+type repoStatusInfo struct {
+	Status               *v1alpha1.RepositoryRunStatus
+	Name, Namespace, URL string
+}
+
 func Root(run *params.Run, ioStreams *cli.IOStreams) *cobra.Command {
 	var noheaders, useRealTime, allNamespaces bool
-	var selectors string
+	lopts := listOptions{}
 
 	cmd := &cobra.Command{
 		Use:          "list",
@@ -64,13 +87,16 @@ func Root(run *params.Run, ioStreams *cli.IOStreams) *cobra.Command {
 			if err != nil {
 				return err
 			}
+			if lopts.sortBy != "" && lopts.sortBy != sortByLastRun {
+				return fmt.Errorf("invalid --%s value %q, only %q is supported", sortByFlag, lopts.sortBy, sortByLastRun)
+			}
 			ctx := context.Background()
 			err = run.Clients.NewClients(ctx, &run.Info)
 			if err != nil {
 				return err
 			}
 			cw := clockwork.NewRealClock()
-			return list(ctx, run, opts, ioStreams, cw, selectors)
+			return list(ctx, run, opts, ioStreams, cw, lopts)
 		},
 	}
 
@@ -92,11 +118,20 @@ func Root(run *params.Run, ioStreams *cli.IOStreams) *cobra.Command {
 	cmd.Flags().BoolVar(
 		&noheaders, noHeadersFlag, false, "don't print headers.")
 
-	cmd.Flags().StringVarP(&selectors, "selectors", "l",
+	cmd.Flags().StringVarP(&lopts.selectors, "selectors", "l",
 		"", "Selector (label query) to filter on, "+
 			"supports '=', "+
 			"'==',"+
 			" and '!='.(e.g. -l key1=value1,key2=value2)")
+
+	cmd.Flags().StringVar(&lopts.sortBy, sortByFlag, "",
+		fmt.Sprintf("sort the repositories, %q shows the most recently run first", sortByLastRun))
+
+	cmd.Flags().BoolVar(&lopts.failedOnly, failedFlag, false,
+		"only list the repositories whose last run has failed")
+
+	cmd.Flags().BoolVarP(&lopts.watch, watchFlag, "w", false,
+		"after listing the repositories, watch for their changes")
 	return cmd
 }
 
@@ -129,7 +164,64 @@ func formatStatus(status *v1alpha1.RepositoryRunStatus, cs *cli.ColorScheme, c c
 	return fmt.Sprintf("%s\t%s", s, cs.HyperLink(cs.ColorStatus(reason), *status.LogURL))
 }
 
-func list(ctx context.Context, cs *params.Run, opts *cli.PacCliOpts, ioStreams *cli.IOStreams, clock clockwork.Clock, selectors string) error {
+// isFailed returns true when the run has failed.
+func isFailed(status *v1alpha1.RepositoryRunStatus) bool {
+	return status != nil && len(status.Conditions) > 0 && status.Conditions[0].Status == corev1.ConditionFalse
+}
+
+func getRepoStatus(ctx context.Context, cs *params.Run, repo v1alpha1.Repository) repoStatusInfo {
+	rs := repoStatusInfo{
+		Name:      repo.GetName(),
+		URL:       repo.Spec.URL,
+		Namespace: repo.GetNamespace(),
+	}
+	statuses := status.MixLivePRandRepoStatus(ctx, cs, repo)
+	if len(statuses) > 0 {
+		rs.Status = &statuses[0]
+	}
+	return rs
+}
+
+// sortByLastRunTime sorts the most recently run repositories first, the ones
+// which never ran are kept last.
+func sortByLastRunTime(repoStatuses []repoStatusInfo) {
+	sort.SliceStable(repoStatuses, func(i, j int) bool {
+		si, sj := repoStatuses[i].Status, repoStatuses[j].Status
+		if si == nil || si.StartTime == nil {
+			return false
+		}
+		if sj == nil || sj.StartTime == nil {
+			return true
+		}
+		return sj.StartTime.Before(si.StartTime)
+	})
+}
+
+func printStatuses(out io.Writer, colorScheme *cli.ColorScheme, clock clockwork.Clock, opts *cli.PacCliOpts, repoStatuses []repoStatusInfo) error {
+	w := ansiterm.NewTabWriter(out, 0, 5, 3, ' ', tabwriter.TabIndent)
+	data := struct {
+		Statuses    []repoStatusInfo
+		ColorScheme *cli.ColorScheme
+		Clock       clockwork.Clock
+		Opts        *cli.PacCliOpts
+	}{
+		Statuses:    repoStatuses,
+		ColorScheme: colorScheme,
+		Clock:       clock,
+		Opts:        opts,
+	}
+	funcMap := template.FuncMap{
+		"formatStatus": formatStatus,
+	}
+
+	t := template.Must(template.New("LS Template").Funcs(funcMap).Parse(lsTmpl))
+	if err := t.Execute(w, data); err != nil {
+		return err
+	}
+	return w.Flush()
+}
+
+func list(ctx context.Context, cs *params.Run, opts *cli.PacCliOpts, ioStreams *cli.IOStreams, clock clockwork.Clock, lopts listOptions) error {
 	if opts.Namespace != "" {
 		cs.Info.Kube.Namespace = opts.Namespace
 	}
@@ -137,7 +229,7 @@ func list(ctx context.Context, cs *params.Run, opts *cli.PacCliOpts, ioStreams *
 		cs.Info.Kube.Namespace = ""
 	}
 
-	lopt := metav1.ListOptions{LabelSelector: selectors}
+	lopt := metav1.ListOptions{LabelSelector: lopts.selectors}
 
 	repositories, err := cs.Clients.PipelineAsCode.PipelinesascodeV1alpha1().Repositories(cs.Info.Kube.Namespace).List(
 		ctx, lopt)
@@ -145,48 +237,67 @@ func list(ctx context.Context, cs *params.Run, opts *cli.PacCliOpts, ioStreams *
 		return err
 	}
 
-	type repoStatusInfo struct {
-		Status               *v1alpha1.RepositoryRunStatus
-		Name, Namespace, URL string
-	}
 	repoStatuses := []repoStatusInfo{}
 	for _, repo := range repositories.Items {
-		rs := repoStatusInfo{
-			Name:      repo.GetName(),
-			URL:       repo.Spec.URL,
-			Namespace: repo.GetNamespace(),
-		}
-		statuses := status.MixLivePRandRepoStatus(ctx, cs, repo)
-		if len(statuses) > 0 {
-			rs.Status = &statuses[0]
+		rs := getRepoStatus(ctx, cs, repo)
+		if lopts.failedOnly && !isFailed(rs.Status) {
+			continue
 		}
 		repoStatuses = append(repoStatuses, rs)
 	}
 
-	if len(repoStatuses) == 0 {
+	if len(repoStatuses) == 0 && !lopts.watch {
 		return fmt.Errorf("no repo found")
 	}
+	if lopts.sortBy == sortByLastRun {
+		sortByLastRunTime(repoStatuses)
+	}
 
-	w := ansiterm.NewTabWriter(ioStreams.Out, 0, 5, 3, ' ', tabwriter.TabIndent)
-	colorScheme := ioStreams.ColorScheme()
-	data := struct {
-		Statuses    []repoStatusInfo
-		ColorScheme *cli.ColorScheme
-		Clock       clockwork.Clock
-		Opts        *cli.PacCliOpts
-	}{
-		Statuses:    repoStatuses,
-		ColorScheme: colorScheme,
-		Clock:       clock,
-		Opts:        opts,
+	if err := printStatuses(ioStreams.Out, ioStreams.ColorScheme(), clock, opts, repoStatuses); err != nil {
+		return err
 	}
-	funcMap := template.FuncMap{
-		"formatStatus": formatStatus,
+	if !lopts.watch {
+		return nil
 	}
 
-	t := template.Must(template.New("LS Template").Funcs(funcMap).Parse(lsTmpl))
-	if err := t.Execute(w, data); err != nil {
+	lopt.ResourceVersion = repositories.GetResourceVersion()
+	watcher, err := cs.Clients.PipelineAsCode.PipelinesascodeV1alpha1().Repositories(cs.Info.Kube.Namespace).Watch(ctx, lopt)
+	if err != nil {
 		return err
 	}
-	return w.Flush()
+	defer watcher.Stop()
+	return watchRepositories(ctx, cs, opts, ioStreams, clock, lopts, watcher)
+}
+
+// watchRepositories prints a new line for every repository added or updated
+// until the watch is closed, like kubectl get --watch does.
+func watchRepositories(ctx context.Context, cs *params.Run, opts *cli.PacCliOpts, ioStreams *cli.IOStreams, clock clockwork.Clock, lopts listOptions, watcher watch.Interface) error {
+	wopts := *opts
+	wopts.NoHeaders = true
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case event, ok := <-watcher.ResultChan():
+			if !ok {
+				return nil
+			}
+			if event.Type == watch.Error {
+				return fmt.Errorf("error while watching the repositories: %v", event.Object)
+			}
+			repo, ok := event.Object.(*v1alpha1.Repository)
+			if !ok || (event.Type != watch.Added && event.Type != watch.Modified) {
+				continue
+			}
+			rs := getRepoStatus(ctx, cs, *repo)
+			if lopts.failedOnly && !isFailed(rs.Status) {
+				continue
+			}
+			var buf bytes.Buffer
+			if err := printStatuses(&buf, ioStreams.ColorScheme(), clock, &wopts, []repoStatusInfo{rs}); err != nil {
+				return err
+			}
+			fmt.Fprint(ioStreams.Out, strings.TrimPrefix(buf.String(), "\n"))
+		}
+	}
 }
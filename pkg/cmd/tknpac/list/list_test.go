@@ -24,6 +24,7 @@ import (
 	"gotest.tools/v3/golden"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/watch"
 	knativeapis "knative.dev/pkg/apis"
 	knativeduckv1 "knative.dev/pkg/apis/duck/v1"
 	rtesting "knative.dev/pkg/reconciler/testing"
@@ -110,13 +111,29 @@ func TestList(t *testing.T) {
 		},
 	}
 
+	repoFailed := repoNamespace2.DeepCopy()
+	repoFailed.Name = "repo3"
+	repoFailed.Namespace = namespace1.GetName()
+	repoFailed.Status[0].Conditions = []knativeapis.Condition{{Status: corev1.ConditionFalse, Reason: "Failed"}}
+	repoFailed.Status[0].StartTime = &metav1.Time{Time: cw.Now().Add(-5 * time.Minute)}
+	repoFailed.Status[0].CompletionTime = &metav1.Time{Time: cw.Now().Add(-4 * time.Minute)}
+	repoNoRun := &pacv1alpha1.Repository{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "repo0",
+			Namespace: namespace1.GetName(),
+		},
+		Spec: pacv1alpha1.RepositorySpec{
+			URL: "https://anurl.com/owner/norun",
+		},
+	}
+
 	type args struct {
 		namespaces       []*corev1.Namespace
 		repositories     []*pacv1alpha1.Repository
 		pipelineruns     []*tektonv1.PipelineRun
 		currentNamespace string
 		opts             *cli.PacCliOpts
-		selectors        string
+		lopts            listOptions
 	}
 	tests := []struct {
 		name    string
@@ -170,6 +187,37 @@ func TestList(t *testing.T) {
 				repositories:     []*pacv1alpha1.Repository{repoNamespace1, repoNamespace2},
 			},
 		},
+		{
+			name: "Test sort by last run",
+			args: args{
+				opts:             &cli.PacCliOpts{},
+				currentNamespace: namespace1.GetName(),
+				namespaces:       []*corev1.Namespace{namespace1},
+				repositories:     []*pacv1alpha1.Repository{repoNoRun, repoNamespace1, repoFailed},
+				lopts:            listOptions{sortBy: sortByLastRun},
+			},
+		},
+		{
+			name: "Test failed only",
+			args: args{
+				opts:             &cli.PacCliOpts{},
+				currentNamespace: namespace1.GetName(),
+				namespaces:       []*corev1.Namespace{namespace1},
+				repositories:     []*pacv1alpha1.Repository{repoNoRun, repoNamespace1, repoFailed},
+				lopts:            listOptions{failedOnly: true},
+			},
+		},
+		{
+			name: "Test failed only without failures",
+			args: args{
+				opts:             &cli.PacCliOpts{},
+				currentNamespace: namespace1.GetName(),
+				namespaces:       []*corev1.Namespace{namespace1},
+				repositories:     []*pacv1alpha1.Repository{repoNamespace1},
+				lopts:            listOptions{failedOnly: true},
+			},
+			wantErr: "no repo found",
+		},
 		{
 			name: "Test list repositories only live PR",
 			args: args{
@@ -207,7 +255,7 @@ func TestList(t *testing.T) {
 			cs.Clients.SetConsoleUI(consoleui.FallBackConsole{})
 			io, out := newIOStream()
 			if err := list(ctx, cs, tt.args.opts, io,
-				cw, tt.args.selectors); err != nil && tt.wantErr != "" {
+				cw, tt.args.lopts); err != nil && tt.wantErr != "" {
 				assert.Equal(t, err.Error(), tt.wantErr)
 			} else {
 				golden.Assert(t, out.String(), strings.ReplaceAll(fmt.Sprintf("%s.golden", t.Name()), "/", "-"))
@@ -215,3 +263,71 @@ func TestList(t *testing.T) {
 		})
 	}
 }
+
+func TestWatchRepositories(t *testing.T) {
+	t1 := time.Date(1999, time.February, 3, 4, 5, 6, 7, time.UTC)
+	cw := clockwork.NewFakeClockAt(t1)
+	makeRepo := func(name string, condition corev1.ConditionStatus, reason string) *pacv1alpha1.Repository {
+		return &pacv1alpha1.Repository{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "ns"},
+			Spec:       pacv1alpha1.RepositorySpec{URL: "https://anurl.com/owner/" + name},
+			Status: []pacv1alpha1.RepositoryRunStatus{{
+				Status: knativeduckv1.Status{
+					Conditions: []knativeapis.Condition{{Status: condition, Reason: reason}},
+				},
+				PipelineRunName: "pipelinerun",
+				StartTime:       &metav1.Time{Time: cw.Now().Add(-16 * time.Minute)},
+				CompletionTime:  &metav1.Time{Time: cw.Now().Add(-15 * time.Minute)},
+				SHA:             github.Ptr("SHA"),
+				SHAURL:          github.Ptr("https://somewhereandnowhere"),
+				LogURL:          github.Ptr("https://help.me.obiwan.kenobi"),
+			}},
+		}
+	}
+	succeeded := makeRepo("succeeded", corev1.ConditionTrue, "Succeeded")
+	failed := makeRepo("failed", corev1.ConditionFalse, "Failed")
+
+	tests := []struct {
+		name  string
+		lopts listOptions
+		want  []string
+	}{
+		{
+			name: "all repositories",
+			want: []string{"• succeeded", "• failed"},
+		},
+		{
+			name:  "failed only",
+			lopts: listOptions{failedOnly: true},
+			want:  []string{"• failed"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, _ := rtesting.SetupFakeContext(t)
+			stdata, _ := testclient.SeedTestData(t, ctx, testclient.Data{})
+			cs := &params.Run{
+				Clients: clients.Clients{
+					PipelineAsCode: stdata.PipelineAsCode,
+					Tekton:         stdata.Pipeline,
+				},
+				Info: info.Info{Kube: &info.KubeOpts{Namespace: "ns"}},
+			}
+			cs.Clients.SetConsoleUI(consoleui.FallBackConsole{})
+			watcher := watch.NewFakeWithChanSize(3, false)
+			watcher.Add(succeeded)
+			watcher.Modify(failed)
+			watcher.Delete(succeeded)
+			watcher.Stop()
+
+			io, out := newIOStream()
+			assert.NilError(t, watchRepositories(ctx, cs, &cli.PacCliOpts{}, io, cw, tt.lopts, watcher))
+			lines := []string{}
+			for _, line := range strings.Split(strings.TrimSpace(out.String()), "\n") {
+				fields := strings.Fields(line)
+				lines = append(lines, strings.Join(fields[:2], " "))
+			}
+			assert.DeepEqual(t, lines, tt.want)
+		})
+	}
+}
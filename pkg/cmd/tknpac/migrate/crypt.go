@@ -0,0 +1,89 @@
+package migrate
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/pbkdf2"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"fmt"
+	"os"
+	"strings"
+)
+
+const (
+	keyIterations = 600000
+	keyLength     = 32
+	saltLength    = 16
+)
+
+func readPassphrase(file string) (string, error) {
+	b, err := os.ReadFile(file)
+	if err != nil {
+		return "", fmt.Errorf("cannot read passphrase file: %w", err)
+	}
+	passphrase := strings.TrimSpace(string(b))
+	if passphrase == "" {
+		return "", fmt.Errorf("passphrase file %s is empty", file)
+	}
+	return passphrase, nil
+}
+
+func newGCM(passphrase string, salt []byte) (cipher.AEAD, error) {
+	key, err := pbkdf2.Key(sha256.New, passphrase, salt, keyIterations, keyLength)
+	if err != nil {
+		return nil, err
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+// encryptData encrypts every value of data with AES-GCM and a key derived
+// from the passphrase, it returns the encrypted data and the base64 salt.
+func encryptData(passphrase string, data map[string][]byte) (map[string][]byte, string, error) {
+	salt := make([]byte, saltLength)
+	if _, err := rand.Read(salt); err != nil {
+		return nil, "", err
+	}
+	gcm, err := newGCM(passphrase, salt)
+	if err != nil {
+		return nil, "", err
+	}
+	encrypted := map[string][]byte{}
+	for k, v := range data {
+		nonce := make([]byte, gcm.NonceSize())
+		if _, err := rand.Read(nonce); err != nil {
+			return nil, "", err
+		}
+		encrypted[k] = gcm.Seal(nonce, nonce, v, []byte(k))
+	}
+	return encrypted, base64.StdEncoding.EncodeToString(salt), nil
+}
+
+// decryptData reverses encryptData.
+func decryptData(passphrase, salt string, data map[string][]byte) (map[string][]byte, error) {
+	rawSalt, err := base64.StdEncoding.DecodeString(salt)
+	if err != nil {
+		return nil, fmt.Errorf("invalid salt: %w", err)
+	}
+	gcm, err := newGCM(passphrase, rawSalt)
+	if err != nil {
+		return nil, err
+	}
+	decrypted := map[string][]byte{}
+	for k, v := range data {
+		if len(v) < gcm.NonceSize() {
+			return nil, fmt.Errorf("cannot decrypt key %s: invalid data", k)
+		}
+		plain, err := gcm.Open(nil, v[:gcm.NonceSize()], v[gcm.NonceSize():], []byte(k))
+		if err != nil {
+			return nil, fmt.Errorf("cannot decrypt key %s, is the passphrase right?", k)
+		}
+		decrypted[k] = plain
+	}
+	return decrypted, nil
+}
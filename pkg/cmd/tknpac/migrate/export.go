@@ -0,0 +1,188 @@
+package migrate
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/apis/pipelinesascode/keys"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/apis/pipelinesascode/v1alpha1"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/cli"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/cmd/tknpac/completion"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/params"
+	"github.com/spf13/cobra"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/yaml"
+)
+
+const exportLongHelp = `
+Export a Pipelines as Code Repository and the secrets it references
+
+The Repository and the secrets of its git_provider and incoming webhooks are
+written as YAML, to be imported on another cluster with tkn pac import.
+
+The secrets are exported in clear unless the --passphrase-file flag is used, in
+which case their values are encrypted with a key derived from the passphrase.
+
+eg:
+	tkn pac export my-repo -n my-namespace --passphrase-file passphrase.txt -o my-repo.yaml`
+
+const (
+	namespaceFlag      = "namespace"
+	passphraseFileFlag = "passphrase-file"
+)
+
+type exportOptions struct {
+	namespace      string
+	passphraseFile string
+	output         string
+}
+
+func ExportCommand(run *params.Run, ioStreams *cli.IOStreams) *cobra.Command {
+	eopts := &exportOptions{}
+	cmd := &cobra.Command{
+		Use:   "export repository",
+		Short: "Export a Repository and its secrets to migrate it to another cluster",
+		Long:  exportLongHelp,
+		Args:  cobra.ExactArgs(1),
+		ValidArgsFunction: func(_ *cobra.Command, args []string, _ string) ([]string, cobra.ShellCompDirective) {
+			return completion.BaseCompletion("repositories", args)
+		},
+		Annotations: map[string]string{
+			"commandType": "main",
+		},
+		RunE: func(_ *cobra.Command, args []string) error {
+			ctx := context.Background()
+			if err := run.Clients.NewClients(ctx, &run.Info); err != nil {
+				return err
+			}
+			if eopts.namespace == "" {
+				eopts.namespace = run.Info.Kube.Namespace
+			}
+			out := ioStreams.Out
+			if eopts.output != "" {
+				f, err := os.Create(eopts.output)
+				if err != nil {
+					return err
+				}
+				defer f.Close()
+				out = f
+			}
+			return export(ctx, run, eopts, args[0], out)
+		},
+	}
+	cmd.Flags().StringVarP(&eopts.namespace, namespaceFlag, "n", "", "If present, the namespace scope for this CLI request")
+	_ = cmd.RegisterFlagCompletionFunc(namespaceFlag,
+		func(_ *cobra.Command, args []string, _ string) ([]string, cobra.ShellCompDirective) {
+			return completion.BaseCompletion(namespaceFlag, args)
+		},
+	)
+	cmd.Flags().StringVar(&eopts.passphraseFile, passphraseFileFlag, "", "A file with the passphrase to encrypt the secrets with")
+	cmd.Flags().StringVarP(&eopts.output, "output", "o", "", "The file to write the export to (default to stdout)")
+	return cmd
+}
+
+// secretNames returns the names of the secrets referenced by the repository.
+func secretNames(repo *v1alpha1.Repository) []string {
+	names := []string{}
+	add := func(secret *v1alpha1.Secret) {
+		if secret == nil || secret.Name == "" {
+			return
+		}
+		for _, name := range names {
+			if name == secret.Name {
+				return
+			}
+		}
+		names = append(names, secret.Name)
+	}
+	if repo.Spec.GitProvider != nil {
+		add(repo.Spec.GitProvider.Secret)
+		add(repo.Spec.GitProvider.WebhookSecret)
+	}
+	if repo.Spec.Incomings != nil {
+		for i := range *repo.Spec.Incomings {
+			add(&(*repo.Spec.Incomings)[i].Secret)
+		}
+	}
+	return names
+}
+
+// cleanMeta only keeps the metadata worth migrating.
+func cleanMeta(meta metav1.ObjectMeta) metav1.ObjectMeta {
+	annotations := map[string]string{}
+	for k, v := range meta.GetAnnotations() {
+		if k != corev1.LastAppliedConfigAnnotation {
+			annotations[k] = v
+		}
+	}
+	if len(annotations) == 0 {
+		annotations = nil
+	}
+	return metav1.ObjectMeta{
+		Name:        meta.GetName(),
+		Namespace:   meta.GetNamespace(),
+		Labels:      meta.GetLabels(),
+		Annotations: annotations,
+	}
+}
+
+func export(ctx context.Context, run *params.Run, eopts *exportOptions, name string, out io.Writer) error {
+	passphrase := ""
+	if eopts.passphraseFile != "" {
+		var err error
+		if passphrase, err = readPassphrase(eopts.passphraseFile); err != nil {
+			return err
+		}
+	}
+
+	repo, err := run.Clients.PipelineAsCode.PipelinesascodeV1alpha1().Repositories(eopts.namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return fmt.Errorf("cannot get repository %s/%s: %w", eopts.namespace, name, err)
+	}
+	objects := []any{&v1alpha1.Repository{
+		TypeMeta:   metav1.TypeMeta{APIVersion: v1alpha1.SchemeGroupVersion.String(), Kind: "Repository"},
+		ObjectMeta: cleanMeta(repo.ObjectMeta),
+		Spec:       repo.Spec,
+	}}
+
+	for _, secretName := range secretNames(repo) {
+		secret, err := run.Clients.Kube.CoreV1().Secrets(eopts.namespace).Get(ctx, secretName, metav1.GetOptions{})
+		if err != nil {
+			return fmt.Errorf("cannot get secret %s/%s of repository %s: %w", eopts.namespace, secretName, name, err)
+		}
+		exported := &corev1.Secret{
+			TypeMeta:   metav1.TypeMeta{APIVersion: "v1", Kind: "Secret"},
+			ObjectMeta: cleanMeta(secret.ObjectMeta),
+			Type:       secret.Type,
+			Data:       secret.Data,
+		}
+		if passphrase != "" {
+			data, salt, err := encryptData(passphrase, secret.Data)
+			if err != nil {
+				return fmt.Errorf("cannot encrypt secret %s: %w", secretName, err)
+			}
+			exported.Data = data
+			if exported.Annotations == nil {
+				exported.Annotations = map[string]string{}
+			}
+			// the salt of the key is needed to decrypt the secret on import
+			exported.Annotations[keys.ExportEncrypted] = salt
+		}
+		objects = append(objects, exported)
+	}
+
+	for i, obj := range objects {
+		b, err := yaml.Marshal(obj)
+		if err != nil {
+			return err
+		}
+		if i > 0 {
+			fmt.Fprintln(out, "---")
+		}
+		fmt.Fprint(out, string(b))
+	}
+	return nil
+}
@@ -0,0 +1,225 @@
+package migrate
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/apis/pipelinesascode/keys"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/apis/pipelinesascode/v1alpha1"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/cli"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/cli/webhook"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/cmd/tknpac/completion"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/params"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/pipelineascode"
+	"github.com/spf13/cobra"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/yaml"
+)
+
+const importLongHelp = `
+Import a Pipelines as Code Repository exported with tkn pac export
+
+The Repository and its secrets are created, or updated when they already
+exist, in the namespace given with the --namespace flag or in the namespace
+they were exported from.
+
+With the --webhook flag the webhook of the Git provider is updated to point
+to the Pipelines as Code controller of this cluster, as tkn pac webhook add
+does.
+
+eg:
+	tkn pac import -f my-repo.yaml -n my-new-namespace --passphrase-file passphrase.txt --webhook`
+
+type importOptions struct {
+	namespace      string
+	passphraseFile string
+	filename       string
+	pacNamespace   string
+	webhook        bool
+}
+
+func ImportCommand(run *params.Run, ioStreams *cli.IOStreams) *cobra.Command {
+	iopts := &importOptions{}
+	cmd := &cobra.Command{
+		Use:   "import",
+		Short: "Import a Repository and its secrets exported from another cluster",
+		Long:  importLongHelp,
+		Args:  cobra.NoArgs,
+		Annotations: map[string]string{
+			"commandType": "main",
+		},
+		RunE: func(_ *cobra.Command, _ []string) error {
+			ctx := context.Background()
+			if err := run.Clients.NewClients(ctx, &run.Info); err != nil {
+				return err
+			}
+			b, err := os.ReadFile(iopts.filename)
+			if err != nil {
+				return err
+			}
+			repo, err := importRepository(ctx, run, iopts, ioStreams, b)
+			if err != nil {
+				return err
+			}
+			if iopts.webhook {
+				return installWebhook(ctx, run, ioStreams, repo, iopts.pacNamespace)
+			}
+			return nil
+		},
+	}
+	cmd.Flags().StringVarP(&iopts.filename, "filename", "f", "", "The file of the export to import")
+	_ = cmd.MarkFlagRequired("filename")
+	cmd.Flags().StringVarP(&iopts.namespace, namespaceFlag, "n", "", "The namespace to import the Repository to (default to the namespace it was exported from)")
+	_ = cmd.RegisterFlagCompletionFunc(namespaceFlag,
+		func(_ *cobra.Command, args []string, _ string) ([]string, cobra.ShellCompDirective) {
+			return completion.BaseCompletion(namespaceFlag, args)
+		},
+	)
+	cmd.Flags().StringVar(&iopts.passphraseFile, passphraseFileFlag, "", "A file with the passphrase the secrets have been encrypted with")
+	cmd.Flags().BoolVar(&iopts.webhook, "webhook", false, "Update the webhook of the Git provider to the controller of this cluster")
+	cmd.Flags().StringVar(&iopts.pacNamespace, "pac-namespace", "", "The namespace where pac is installed")
+	return cmd
+}
+
+// parseExport returns the repository and the secrets of an export.
+func parseExport(b []byte) (*v1alpha1.Repository, []*corev1.Secret, error) {
+	var repo *v1alpha1.Repository
+	secrets := []*corev1.Secret{}
+	for _, doc := range strings.Split(string(b), "\n---\n") {
+		if strings.TrimSpace(doc) == "" {
+			continue
+		}
+		meta := metav1.TypeMeta{}
+		if err := yaml.Unmarshal([]byte(doc), &meta); err != nil {
+			return nil, nil, fmt.Errorf("cannot parse export: %w", err)
+		}
+		switch meta.Kind {
+		case "Repository":
+			if repo != nil {
+				return nil, nil, fmt.Errorf("export has more than one repository")
+			}
+			repo = &v1alpha1.Repository{}
+			if err := yaml.Unmarshal([]byte(doc), repo); err != nil {
+				return nil, nil, fmt.Errorf("cannot parse repository: %w", err)
+			}
+		case "Secret":
+			secret := &corev1.Secret{}
+			if err := yaml.Unmarshal([]byte(doc), secret); err != nil {
+				return nil, nil, fmt.Errorf("cannot parse secret: %w", err)
+			}
+			secrets = append(secrets, secret)
+		default:
+			return nil, nil, fmt.Errorf("unexpected kind %q in export", meta.Kind)
+		}
+	}
+	if repo == nil {
+		return nil, nil, fmt.Errorf("no repository in export")
+	}
+	return repo, secrets, nil
+}
+
+func importRepository(ctx context.Context, run *params.Run, iopts *importOptions, ioStreams *cli.IOStreams, b []byte) (*v1alpha1.Repository, error) {
+	repo, secrets, err := parseExport(b)
+	if err != nil {
+		return nil, err
+	}
+	namespace := iopts.namespace
+	if namespace == "" {
+		namespace = repo.GetNamespace()
+	}
+	if namespace == "" {
+		namespace = run.Info.Kube.Namespace
+	}
+	passphrase := ""
+	if iopts.passphraseFile != "" {
+		if passphrase, err = readPassphrase(iopts.passphraseFile); err != nil {
+			return nil, err
+		}
+	}
+
+	cs := ioStreams.ColorScheme()
+	for _, secret := range secrets {
+		secret.Namespace = namespace
+		if salt, ok := secret.GetAnnotations()[keys.ExportEncrypted]; ok {
+			if passphrase == "" {
+				return nil, fmt.Errorf("secret %s is encrypted, the --%s flag is required", secret.GetName(), passphraseFileFlag)
+			}
+			if secret.Data, err = decryptData(passphrase, salt, secret.Data); err != nil {
+				return nil, fmt.Errorf("secret %s: %w", secret.GetName(), err)
+			}
+			delete(secret.Annotations, keys.ExportEncrypted)
+		}
+		if err := applySecret(ctx, run, secret); err != nil {
+			return nil, err
+		}
+		fmt.Fprintf(ioStreams.Out, "%s Secret %s has been imported in namespace %s\n", cs.SuccessIcon(), secret.GetName(), namespace)
+	}
+
+	repo.Namespace = namespace
+	current, err := run.Clients.PipelineAsCode.PipelinesascodeV1alpha1().Repositories(namespace).Get(ctx, repo.GetName(), metav1.GetOptions{})
+	switch {
+	case errors.IsNotFound(err):
+		repo, err = run.Clients.PipelineAsCode.PipelinesascodeV1alpha1().Repositories(namespace).Create(ctx, repo, metav1.CreateOptions{})
+	case err == nil:
+		current.Labels, current.Annotations, current.Spec = repo.Labels, repo.Annotations, repo.Spec
+		repo, err = run.Clients.PipelineAsCode.PipelinesascodeV1alpha1().Repositories(namespace).Update(ctx, current, metav1.UpdateOptions{})
+	}
+	if err != nil {
+		return nil, fmt.Errorf("cannot import repository %s: %w", repo.GetName(), err)
+	}
+	fmt.Fprintf(ioStreams.Out, "%s Repository %s has been imported in namespace %s\n", cs.SuccessIcon(), repo.GetName(), namespace)
+	return repo, nil
+}
+
+func applySecret(ctx context.Context, run *params.Run, secret *corev1.Secret) error {
+	current, err := run.Clients.Kube.CoreV1().Secrets(secret.GetNamespace()).Get(ctx, secret.GetName(), metav1.GetOptions{})
+	switch {
+	case errors.IsNotFound(err):
+		_, err = run.Clients.Kube.CoreV1().Secrets(secret.GetNamespace()).Create(ctx, secret, metav1.CreateOptions{})
+	case err == nil:
+		current.Labels, current.Annotations, current.Data = secret.Labels, secret.Annotations, secret.Data
+		_, err = run.Clients.Kube.CoreV1().Secrets(secret.GetNamespace()).Update(ctx, current, metav1.UpdateOptions{})
+	}
+	if err != nil {
+		return fmt.Errorf("cannot import secret %s: %w", secret.GetName(), err)
+	}
+	return nil
+}
+
+// installWebhook points the webhook of the Git provider of repo to the
+// controller of the cluster.
+func installWebhook(ctx context.Context, run *params.Run, ioStreams *cli.IOStreams, repo *v1alpha1.Repository, pacNamespace string) error {
+	if repo.Spec.GitProvider == nil || repo.Spec.GitProvider.Secret == nil {
+		fmt.Fprintf(ioStreams.Out, "%s Cannot update the webhook as the repository has no git_provider secret\n", ioStreams.ColorScheme().WarningIcon())
+		return nil
+	}
+	providerName, err := webhook.GetProviderName(repo.Spec.URL)
+	if err != nil {
+		return err
+	}
+	secret, err := run.Clients.Kube.CoreV1().Secrets(repo.GetNamespace()).Get(ctx, repo.Spec.GitProvider.Secret.Name, metav1.GetOptions{})
+	if err != nil {
+		return err
+	}
+	secretKey := repo.Spec.GitProvider.Secret.Key
+	if secretKey == "" {
+		secretKey = pipelineascode.DefaultGitProviderSecretKey
+	}
+	config := &webhook.Options{
+		Run:                 run,
+		RepositoryName:      repo.GetName(),
+		RepositoryNamespace: repo.GetNamespace(),
+		PACNamespace:        pacNamespace,
+		RepositoryURL:       repo.Spec.URL,
+		ProviderAPIURL:      repo.Spec.GitProvider.URL,
+		IOStreams:           ioStreams,
+		PersonalAccessToken: string(secret.Data[secretKey]),
+		SecretName:          repo.Spec.GitProvider.Secret.Name,
+		ProviderSecretKey:   secretKey,
+	}
+	return config.Install(ctx, providerName)
+}
@@ -0,0 +1,173 @@
+package migrate
+
+import (
+	"bytes"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/apis/pipelinesascode/keys"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/apis/pipelinesascode/v1alpha1"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/cli"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/params"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/params/clients"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/params/info"
+	testclient "github.com/openshift-pipelines/pipelines-as-code/pkg/test/clients"
+	"gotest.tools/v3/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	rtesting "knative.dev/pkg/reconciler/testing"
+)
+
+func TestExportImport(t *testing.T) {
+	repo := &v1alpha1.Repository{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "repo",
+			Namespace:   "old",
+			Labels:      map[string]string{"team": "a"},
+			Annotations: map[string]string{corev1.LastAppliedConfigAnnotation: "{}"},
+		},
+		Spec: v1alpha1.RepositorySpec{
+			URL: "https://gitlab.com/owner/repo",
+			GitProvider: &v1alpha1.GitProvider{
+				URL:           "https://gitlab.com",
+				Secret:        &v1alpha1.Secret{Name: "gitlab", Key: "token"},
+				WebhookSecret: &v1alpha1.Secret{Name: "gitlab", Key: "webhook"},
+			},
+			Incomings: &[]v1alpha1.Incoming{{Type: "webhook-url", Secret: v1alpha1.Secret{Name: "incoming"}, Targets: []string{"main"}}},
+		},
+		Status: []v1alpha1.RepositoryRunStatus{{PipelineRunName: "run"}},
+	}
+	secrets := []*corev1.Secret{
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "gitlab", Namespace: "old"},
+			Data:       map[string][]byte{"token": []byte("glpat"), "webhook": []byte("shhh")},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "incoming", Namespace: "old"},
+			Data:       map[string][]byte{"secret": []byte("incoming")},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "unrelated", Namespace: "old"},
+			Data:       map[string][]byte{"secret": []byte("unrelated")},
+		},
+	}
+	dir := t.TempDir()
+	passphraseFile := filepath.Join(dir, "passphrase")
+	assert.NilError(t, os.WriteFile(passphraseFile, []byte("passphrase\n"), 0o600))
+	wrongPassphraseFile := filepath.Join(dir, "wrong")
+	assert.NilError(t, os.WriteFile(wrongPassphraseFile, []byte("wrong"), 0o600))
+
+	tests := []struct {
+		name                 string
+		exportPassphraseFile string
+		importPassphraseFile string
+		namespace            string
+		wantNamespace        string
+		wantErr              string
+	}{
+		{
+			name:          "clear export",
+			wantNamespace: "old",
+		},
+		{
+			name:                 "encrypted export to a new namespace",
+			exportPassphraseFile: passphraseFile,
+			importPassphraseFile: passphraseFile,
+			namespace:            "new",
+			wantNamespace:        "new",
+		},
+		{
+			name:                 "encrypted export without passphrase",
+			exportPassphraseFile: passphraseFile,
+			namespace:            "new",
+			wantErr:              "secret gitlab is encrypted, the --passphrase-file flag is required",
+		},
+		{
+			name:                 "encrypted export with wrong passphrase",
+			exportPassphraseFile: passphraseFile,
+			importPassphraseFile: wrongPassphraseFile,
+			namespace:            "new",
+			wantErr:              "secret gitlab: cannot decrypt key",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, _ := rtesting.SetupFakeContext(t)
+			stdata, _ := testclient.SeedTestData(t, ctx, testclient.Data{
+				Repositories: []*v1alpha1.Repository{repo},
+				Secret:       secrets,
+			})
+			run := &params.Run{
+				Clients: clients.Clients{
+					PipelineAsCode: stdata.PipelineAsCode,
+					Kube:           stdata.Kube,
+				},
+				Info: info.Info{Kube: &info.KubeOpts{Namespace: "default"}},
+			}
+
+			exported := &bytes.Buffer{}
+			err := export(ctx, run, &exportOptions{namespace: "old", passphraseFile: tt.exportPassphraseFile}, "repo", exported)
+			assert.NilError(t, err)
+			assert.Assert(t, !bytes.Contains(exported.Bytes(), []byte("unrelated")))
+			assert.Assert(t, !bytes.Contains(exported.Bytes(), []byte(corev1.LastAppliedConfigAnnotation)))
+			assert.Assert(t, !bytes.Contains(exported.Bytes(), []byte("pipelinerun_status")))
+			if tt.exportPassphraseFile != "" {
+				assert.Assert(t, bytes.Contains(exported.Bytes(), []byte(keys.ExportEncrypted)))
+			}
+
+			ioStreams := &cli.IOStreams{In: io.NopCloser(&bytes.Buffer{}), Out: &bytes.Buffer{}, ErrOut: &bytes.Buffer{}}
+			iopts := &importOptions{namespace: tt.namespace, passphraseFile: tt.importPassphraseFile}
+			_, err = importRepository(ctx, run, iopts, ioStreams, exported.Bytes())
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			assert.NilError(t, err)
+
+			got, err := stdata.PipelineAsCode.PipelinesascodeV1alpha1().Repositories(tt.wantNamespace).Get(ctx, "repo", metav1.GetOptions{})
+			assert.NilError(t, err)
+			assert.DeepEqual(t, got.Spec, repo.Spec)
+			assert.DeepEqual(t, got.GetLabels(), repo.GetLabels())
+			for _, secret := range secrets[:2] {
+				got, err := stdata.Kube.CoreV1().Secrets(tt.wantNamespace).Get(ctx, secret.GetName(), metav1.GetOptions{})
+				assert.NilError(t, err)
+				assert.DeepEqual(t, got.Data, secret.Data)
+				_, encrypted := got.GetAnnotations()[keys.ExportEncrypted]
+				assert.Assert(t, !encrypted)
+			}
+		})
+	}
+}
+
+func TestParseExport(t *testing.T) {
+	tests := []struct {
+		name    string
+		export  string
+		wantErr string
+	}{
+		{
+			name:    "no repository",
+			export:  "apiVersion: v1\nkind: Secret\nmetadata:\n  name: secret\n",
+			wantErr: "no repository in export",
+		},
+		{
+			name:    "unexpected kind",
+			export:  "apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: cm\n",
+			wantErr: `unexpected kind "ConfigMap" in export`,
+		},
+		{
+			name: "two repositories",
+			export: "apiVersion: pipelinesascode.tekton.dev/v1alpha1\nkind: Repository\nmetadata:\n  name: one\n" +
+				"---\napiVersion: pipelinesascode.tekton.dev/v1alpha1\nkind: Repository\nmetadata:\n  name: two\n",
+			wantErr: "export has more than one repository",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, _, err := parseExport([]byte(tt.export))
+			assert.Error(t, err, tt.wantErr)
+		})
+	}
+}
@@ -0,0 +1,187 @@
+package purge
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/jonboulle/clockwork"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/apis/pipelinesascode"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/cli"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/cmd/tknpac/completion"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/params"
+	"github.com/spf13/cobra"
+	tektonv1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const longhelp = `
+
+purge - delete the stale resources left by Pipelines-as-Code
+
+tkn pac purge deletes, when they are older than the --older-than duration:
+
+	* the completed PipelineRuns created by Pipelines-as-Code
+	* the git-auth secrets of the PipelineRuns which are no longer running
+	* the ConfigMaps managed by Pipelines-as-Code whose PipelineRun has been deleted
+
+Use --dry-run to only print what would be deleted.
+
+eg:
+	tkn pac purge --all-namespaces --older-than 72h --dry-run`
+
+const (
+	namespaceFlag     = "namespace"
+	allNamespacesFlag = "all-namespaces"
+	olderThanFlag     = "older-than"
+	dryRunFlag        = "dry-run"
+
+	managedBySelector    = "app.kubernetes.io/managed-by=" + pipelinesascode.GroupName
+	gitAuthSecretPrefix  = "pac-gitauth-"
+	defaultOlderThan     = 24 * time.Hour
+	pipelineRunOwnerKind = "PipelineRun"
+)
+
+type purgeOptions struct {
+	cs            *params.Run
+	ioStreams     *cli.IOStreams
+	clock         clockwork.Clock
+	namespace     string
+	allNamespaces bool
+	olderThan     time.Duration
+	dryRun        bool
+}
+
+func Command(run *params.Run, ioStreams *cli.IOStreams) *cobra.Command {
+	popts := &purgeOptions{
+		cs:        run,
+		ioStreams: ioStreams,
+		clock:     clockwork.NewRealClock(),
+	}
+	cmd := &cobra.Command{
+		Use:   "purge",
+		Long:  longhelp,
+		Short: "Delete the stale PipelineRuns, git-auth secrets and ConfigMaps of Pipelines-as-Code",
+		Args:  cobra.NoArgs,
+		Annotations: map[string]string{
+			"commandType": "main",
+		},
+		RunE: func(_ *cobra.Command, _ []string) error {
+			ctx := context.Background()
+			if err := run.Clients.NewClients(ctx, &run.Info); err != nil {
+				return err
+			}
+			if popts.namespace == "" && !popts.allNamespaces {
+				popts.namespace = run.Info.Kube.Namespace
+			}
+			return purge(ctx, popts)
+		},
+	}
+
+	cmd.Flags().StringVarP(&popts.namespace, namespaceFlag, "n", "", "If present, the namespace scope for this CLI request")
+	_ = cmd.RegisterFlagCompletionFunc(namespaceFlag,
+		func(_ *cobra.Command, args []string, _ string) ([]string, cobra.ShellCompDirective) {
+			return completion.BaseCompletion(namespaceFlag, args)
+		},
+	)
+	cmd.Flags().BoolVarP(&popts.allNamespaces, allNamespacesFlag, "A", false, "purge the resources across all namespaces")
+	cmd.Flags().DurationVar(&popts.olderThan, olderThanFlag, defaultOlderThan, "only delete the resources older than this duration")
+	cmd.Flags().BoolVar(&popts.dryRun, dryRunFlag, false, "only print the resources which would be deleted")
+	return cmd
+}
+
+func (p *purgeOptions) report(kind, namespace, name string) {
+	suffix := ""
+	if p.dryRun {
+		suffix = " (dry run)"
+	}
+	fmt.Fprintf(p.ioStreams.Out, "%s %s/%s deleted%s\n", kind, namespace, name, suffix)
+}
+
+// expired returns true when the time is older than the --older-than duration.
+func (p *purgeOptions) expired(t time.Time) bool {
+	return p.clock.Since(t) > p.olderThan
+}
+
+// pipelineRunOwner returns the name of the PipelineRun owning the object or
+// an empty string when it is not owned by a PipelineRun.
+func pipelineRunOwner(obj metav1.Object) string {
+	for _, ref := range obj.GetOwnerReferences() {
+		if ref.Kind == pipelineRunOwnerKind {
+			return ref.Name
+		}
+	}
+	return ""
+}
+
+func purge(ctx context.Context, p *purgeOptions) error {
+	if p.olderThan < 0 {
+		return fmt.Errorf("--%s must be a positive duration", olderThanFlag)
+	}
+	namespace := p.namespace
+	if p.allNamespaces {
+		namespace = metav1.NamespaceAll
+	}
+
+	prs, err := p.cs.Clients.Tekton.TektonV1().PipelineRuns(namespace).List(ctx, metav1.ListOptions{LabelSelector: managedBySelector})
+	if err != nil {
+		return fmt.Errorf("cannot list the PipelineRuns: %w", err)
+	}
+	// the PipelineRuns still present once purged, keyed by namespace/name
+	remaining := map[string]*tektonv1.PipelineRun{}
+	for i := range prs.Items {
+		pr := &prs.Items[i]
+		if !pr.IsDone() || pr.Status.CompletionTime == nil || !p.expired(pr.Status.CompletionTime.Time) {
+			remaining[pr.GetNamespace()+"/"+pr.GetName()] = pr
+			continue
+		}
+		if !p.dryRun {
+			if err := p.cs.Clients.Tekton.TektonV1().PipelineRuns(pr.GetNamespace()).Delete(ctx, pr.GetName(), metav1.DeleteOptions{}); err != nil {
+				return fmt.Errorf("cannot delete the PipelineRun %s/%s: %w", pr.GetNamespace(), pr.GetName(), err)
+			}
+		}
+		p.report("PipelineRun", pr.GetNamespace(), pr.GetName())
+	}
+
+	secrets, err := p.cs.Clients.Kube.CoreV1().Secrets(namespace).List(ctx, metav1.ListOptions{LabelSelector: managedBySelector})
+	if err != nil {
+		return fmt.Errorf("cannot list the secrets: %w", err)
+	}
+	for _, secret := range secrets.Items {
+		if !strings.HasPrefix(secret.GetName(), gitAuthSecretPrefix) || !p.expired(secret.GetCreationTimestamp().Time) {
+			continue
+		}
+		// the token may still be used by its PipelineRun
+		if owner, ok := remaining[secret.GetNamespace()+"/"+pipelineRunOwner(&secret)]; ok && !owner.IsDone() {
+			continue
+		}
+		if !p.dryRun {
+			if err := p.cs.Clients.Kube.CoreV1().Secrets(secret.GetNamespace()).Delete(ctx, secret.GetName(), metav1.DeleteOptions{}); err != nil {
+				return fmt.Errorf("cannot delete the secret %s/%s: %w", secret.GetNamespace(), secret.GetName(), err)
+			}
+		}
+		p.report("Secret", secret.GetNamespace(), secret.GetName())
+	}
+
+	configMaps, err := p.cs.Clients.Kube.CoreV1().ConfigMaps(namespace).List(ctx, metav1.ListOptions{LabelSelector: managedBySelector})
+	if err != nil {
+		return fmt.Errorf("cannot list the ConfigMaps: %w", err)
+	}
+	for _, cm := range configMaps.Items {
+		owner := pipelineRunOwner(&cm)
+		if owner == "" || !p.expired(cm.GetCreationTimestamp().Time) {
+			continue
+		}
+		if _, ok := remaining[cm.GetNamespace()+"/"+owner]; ok {
+			continue
+		}
+		if !p.dryRun {
+			if err := p.cs.Clients.Kube.CoreV1().ConfigMaps(cm.GetNamespace()).Delete(ctx, cm.GetName(), metav1.DeleteOptions{}); err != nil {
+				return fmt.Errorf("cannot delete the ConfigMap %s/%s: %w", cm.GetNamespace(), cm.GetName(), err)
+			}
+		}
+		p.report("ConfigMap", cm.GetNamespace(), cm.GetName())
+	}
+	return nil
+}
@@ -0,0 +1,150 @@
+package purge
+
+import (
+	"bytes"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jonboulle/clockwork"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/apis/pipelinesascode"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/cli"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/params"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/params/clients"
+	testclient "github.com/openshift-pipelines/pipelines-as-code/pkg/test/clients"
+	tektontest "github.com/openshift-pipelines/pipelines-as-code/pkg/test/tekton"
+	tektonv1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+	"gotest.tools/v3/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	rtesting "knative.dev/pkg/reconciler/testing"
+)
+
+func TestPurge(t *testing.T) {
+	ns, otherNS := "ns", "other"
+	clock := clockwork.NewFakeClock()
+	labels := map[string]string{"app.kubernetes.io/managed-by": pipelinesascode.GroupName}
+	ownedBy := func(prName string) []metav1.OwnerReference {
+		return []metav1.OwnerReference{{Kind: "PipelineRun", Name: prName}}
+	}
+	created := func(ago time.Duration) metav1.Time {
+		return metav1.Time{Time: clock.Now().Add(-ago)}
+	}
+
+	running := &tektonv1.PipelineRun{ObjectMeta: metav1.ObjectMeta{Name: "running", Namespace: ns, Labels: labels}}
+	pipelineRuns := []*tektonv1.PipelineRun{
+		tektontest.MakePRCompletion(clock, "old", ns, "", nil, labels, 120),
+		tektontest.MakePRCompletion(clock, "recent", ns, "", nil, labels, 10),
+		tektontest.MakePRCompletion(clock, "old-other", otherNS, "", nil, labels, 120),
+		running,
+	}
+	secrets := []*corev1.Secret{
+		{ObjectMeta: metav1.ObjectMeta{Name: "pac-gitauth-old", Namespace: ns, Labels: labels, OwnerReferences: ownedBy("old"), CreationTimestamp: created(2 * time.Hour)}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "pac-gitauth-running", Namespace: ns, Labels: labels, OwnerReferences: ownedBy("running"), CreationTimestamp: created(2 * time.Hour)}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "pac-gitauth-recent", Namespace: ns, Labels: labels, CreationTimestamp: created(10 * time.Minute)}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "ci-variables", Namespace: ns, Labels: labels, CreationTimestamp: created(2 * time.Hour)}},
+	}
+	configMaps := []*corev1.ConfigMap{
+		{ObjectMeta: metav1.ObjectMeta{Name: "orphaned", Namespace: ns, Labels: labels, OwnerReferences: ownedBy("gone"), CreationTimestamp: created(2 * time.Hour)}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "owned", Namespace: ns, Labels: labels, OwnerReferences: ownedBy("recent"), CreationTimestamp: created(2 * time.Hour)}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "not-owned", Namespace: ns, Labels: labels, CreationTimestamp: created(2 * time.Hour)}},
+	}
+
+	tests := []struct {
+		name             string
+		allNamespaces    bool
+		dryRun           bool
+		olderThan        time.Duration
+		wantOutput       []string
+		wantPipelineRuns []string
+		wantErr          string
+	}{
+		{
+			name:      "purge namespace",
+			olderThan: time.Hour,
+			wantOutput: []string{
+				"ConfigMap ns/orphaned deleted",
+				"PipelineRun ns/old deleted",
+				"Secret ns/pac-gitauth-old deleted",
+			},
+			wantPipelineRuns: []string{"old-other", "recent", "running"},
+		},
+		{
+			name:          "purge all namespaces",
+			allNamespaces: true,
+			olderThan:     time.Hour,
+			wantOutput: []string{
+				"ConfigMap ns/orphaned deleted",
+				"PipelineRun ns/old deleted",
+				"PipelineRun other/old-other deleted",
+				"Secret ns/pac-gitauth-old deleted",
+			},
+			wantPipelineRuns: []string{"recent", "running"},
+		},
+		{
+			name:      "dry run",
+			dryRun:    true,
+			olderThan: time.Hour,
+			wantOutput: []string{
+				"ConfigMap ns/orphaned deleted (dry run)",
+				"PipelineRun ns/old deleted (dry run)",
+				"Secret ns/pac-gitauth-old deleted (dry run)",
+			},
+			wantPipelineRuns: []string{"old", "old-other", "recent", "running"},
+		},
+		{
+			name:      "negative duration",
+			olderThan: -time.Hour,
+			wantErr:   "--older-than must be a positive duration",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, _ := rtesting.SetupFakeContext(t)
+			stdata, _ := testclient.SeedTestData(t, ctx, testclient.Data{
+				Namespaces: []*corev1.Namespace{
+					{ObjectMeta: metav1.ObjectMeta{Name: ns}},
+					{ObjectMeta: metav1.ObjectMeta{Name: otherNS}},
+				},
+				PipelineRuns: pipelineRuns,
+				Secret:       secrets,
+				ConfigMap:    configMaps,
+			})
+			out := &bytes.Buffer{}
+			popts := &purgeOptions{
+				cs: &params.Run{
+					Clients: clients.Clients{
+						Kube:   stdata.Kube,
+						Tekton: stdata.Pipeline,
+					},
+				},
+				ioStreams:     &cli.IOStreams{Out: out},
+				clock:         clock,
+				namespace:     ns,
+				allNamespaces: tt.allNamespaces,
+				olderThan:     tt.olderThan,
+				dryRun:        tt.dryRun,
+			}
+			err := purge(ctx, popts)
+			if tt.wantErr != "" {
+				assert.Error(t, err, tt.wantErr)
+				return
+			}
+			assert.NilError(t, err)
+
+			got := strings.Split(strings.TrimSpace(out.String()), "\n")
+			sort.Strings(got)
+			assert.DeepEqual(t, got, tt.wantOutput)
+
+			prs, err := stdata.Pipeline.TektonV1().PipelineRuns(metav1.NamespaceAll).List(ctx, metav1.ListOptions{})
+			assert.NilError(t, err)
+			gotPipelineRuns := []string{}
+			for _, pr := range prs.Items {
+				gotPipelineRuns = append(gotPipelineRuns, pr.GetName())
+			}
+			sort.Strings(gotPipelineRuns)
+			assert.DeepEqual(t, gotPipelineRuns, tt.wantPipelineRuns)
+		})
+	}
+}
@@ -0,0 +1,93 @@
+package resolve
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/cli"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/lockfile"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/params"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/params/info"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/params/settings"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/provider/github"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/resolve"
+	"github.com/spf13/cobra"
+)
+
+var lockLonghelp = fmt.Sprintf(`
+
+lock - pin the remote tasks and pipelines of the PipelineRuns to their digest.
+
+Resolve the remote tasks and pipelines referenced in the annotations of the
+PipelineRuns of the .tekton directory and write their digest to the
+.tekton/%s lockfile. When the lockfile is committed to the repository,
+Pipelines-as-Code refuses to run a PipelineRun referencing a remote task or
+pipeline which is not pinned in it or whose content has changed.
+
+Run it again and commit the lockfile every time a remote task or pipeline is
+added or updated:
+
+%s pac lock
+
+Tasks and pipelines inside the repository are not pinned, they are versioned
+with the PipelineRuns.`, lockfile.Filename, settings.TknBinaryName)
+
+func LockCommand(run *params.Run, streams *cli.IOStreams) *cobra.Command {
+	var lockFilenames []string
+	var lockOutput string
+	cmd := &cobra.Command{
+		Use:   "lock",
+		Long:  lockLonghelp,
+		Short: "Pin the remote tasks and pipelines of the PipelineRuns in a lockfile",
+		RunE: func(_ *cobra.Command, _ []string) error {
+			ctx := context.Background()
+
+			if err := setupRun(ctx, run); err != nil {
+				return err
+			}
+
+			lock, err := lockRemoteResources(ctx, run, lockFilenames)
+			if err != nil {
+				return err
+			}
+			data, err := lock.Marshal()
+			if err != nil {
+				return err
+			}
+			if err := os.WriteFile(lockOutput, data, 0o600); err != nil {
+				return err
+			}
+			fmt.Fprintf(streams.Out, "%d remote tasks and pipelines have been pinned in %s\n", len(lock.Resources), lockOutput)
+			return nil
+		},
+		Annotations: map[string]string{
+			"commandType": "main",
+		},
+	}
+	cmd.Flags().StringSliceVarP(&lockFilenames, "filename", "f", []string{".tekton"},
+		"Filename or directory of the PipelineRuns to pin the remote tasks and pipelines of")
+
+	cmd.Flags().StringVarP(&lockOutput, "output", "o", filepath.Join(".tekton", lockfile.Filename),
+		"Path of the lockfile")
+	return cmd
+}
+
+// lockRemoteResources resolves the PipelineRuns of filenames and returns the
+// lock of the remote tasks and pipelines they reference.
+func lockRemoteResources(ctx context.Context, cs *params.Run, filenames []string) (*lockfile.Lock, error) {
+	types, err := resolve.ReadTektonTypes(ctx, cs.Clients.Log, expandYamlsAsSingleTemplate(filenames))
+	if err != nil {
+		return nil, err
+	}
+	lock := lockfile.New()
+	if _, err := resolve.Resolve(ctx, cs, cs.Clients.Log, github.New(), types, info.NewEvent(), &resolve.Opts{
+		RemoteTasks: true,
+		Lock:        lock,
+		UpdateLock:  true,
+	}); err != nil {
+		return nil, err
+	}
+	return lock, nil
+}
@@ -77,37 +77,14 @@ func Command(run *params.Run, streams *cli.IOStreams) *cobra.Command {
 		RunE: func(_ *cobra.Command, _ []string) error {
 			ctx := context.Background()
 
-			errc := run.Clients.NewClients(ctx, &run.Info)
-
-			// only report error here on CLI
-			zaplog, err := zap.NewProduction(
-				zap.IncreaseLevel(zap.FatalLevel),
-			)
-			if err != nil {
+			if err := setupRun(ctx, run); err != nil {
 				return err
 			}
-			run.Clients.Log = zaplog.Sugar()
-
-			if errc != nil {
-				// this check allows resolve to be run without
-				// a kubeconfig so users can verify the tkn version
-				noConfigErr := strings.Contains(errc.Error(), "Couldn't get kubeConfiguration namespace")
-				if !noConfigErr {
-					return errc
-				}
-			} else {
-				// it's OK  if pac is not installed, ignore the error
-				_ = run.UpdatePacConfig(ctx)
-			}
 
 			if len(filenames) == 0 {
 				return fmt.Errorf("you need to at least specify a file with -f")
 			}
 
-			if err := settings.SyncConfig(run.Clients.Log, &run.Info.Pac.Settings, map[string]string{}, settings.DefaultValidators()); err != nil {
-				return err
-			}
-
 			mapped := splitArgsInMap(parameters)
 
 			// ignore error
@@ -172,6 +149,35 @@ func Command(run *params.Run, streams *cli.IOStreams) *cobra.Command {
 	return cmd
 }
 
+// setupRun sets up the clients and the settings of run to resolve the remote
+// tasks the same way they are resolved on CI.
+func setupRun(ctx context.Context, run *params.Run) error {
+	errc := run.Clients.NewClients(ctx, &run.Info)
+
+	// only report error here on CLI
+	zaplog, err := zap.NewProduction(
+		zap.IncreaseLevel(zap.FatalLevel),
+	)
+	if err != nil {
+		return err
+	}
+	run.Clients.Log = zaplog.Sugar()
+
+	if errc != nil {
+		// this check allows resolve to be run without
+		// a kubeconfig so users can verify the tkn version
+		noConfigErr := strings.Contains(errc.Error(), "Couldn't get kubeConfiguration namespace")
+		if !noConfigErr {
+			return errc
+		}
+	} else {
+		// it's OK  if pac is not installed, ignore the error
+		_ = run.UpdatePacConfig(ctx)
+	}
+
+	return settings.SyncConfig(run.Clients.Log, &run.Info.Pac.Settings, map[string]string{}, settings.DefaultValidators())
+}
+
 func splitArgsInMap(args []string) map[string]string {
 	m := make(map[string]string)
 	for _, e := range args {
@@ -215,6 +221,9 @@ func resolveFilenames(ctx context.Context, cs *params.Run, filenames []string, p
 	if err != nil {
 		return "", err
 	}
+	for _, referenceErr := range ropt.ReferenceErrors {
+		cs.Clients.Log.Warn(referenceErr.Err.Error())
+	}
 
 	// cleanedup regexp do as much as we can but really it's a lost game to try this
 	cleanRe := regexp.MustCompile(`\n(\t|\s)*(status|taskRunTemplate|creationTimestamp|spec|taskRunTemplate|metadata|computeResources):\s*(null|{})\n`)
@@ -3,10 +3,13 @@ package resolve
 import (
 	"bytes"
 	"fmt"
+	"net/http"
+	"net/http/httptest"
 	"strings"
 	"testing"
 
 	"github.com/openshift-pipelines/pipelines-as-code/pkg/cli"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/lockfile"
 	"github.com/openshift-pipelines/pipelines-as-code/pkg/params"
 	"github.com/openshift-pipelines/pipelines-as-code/pkg/params/clients"
 	"github.com/openshift-pipelines/pipelines-as-code/pkg/params/info"
@@ -119,3 +122,44 @@ func TestResolveFilenames(t *testing.T) {
 		})
 	}
 }
+
+func TestLockRemoteResources(t *testing.T) {
+	task := `apiVersion: tekton.dev/v1
+kind: Task
+metadata:
+  name: remote
+spec:
+  steps:
+    - name: hello
+      image: alpine
+      script: "echo hello"`
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		fmt.Fprint(w, task)
+	}))
+	defer server.Close()
+
+	pipelinerun := fmt.Sprintf(`apiVersion: tekton.dev/v1
+kind: PipelineRun
+metadata:
+  name: test
+  annotations:
+    pipelinesascode.tekton.dev/task: "[%s/task.yaml]"
+spec:
+  pipelineSpec:
+    tasks:
+      - name: remote
+        taskRef:
+          name: remote`, server.URL)
+	dir := assertfs.NewDir(t, "test-lock", assertfs.WithFile("pr.yaml", pipelinerun))
+	defer dir.Remove()
+
+	observer, _ := zapobserver.New(zap.InfoLevel)
+	cs := &params.Run{
+		Clients: clients.Clients{Log: zap.New(observer).Sugar(), HTTP: http.Client{}},
+		Info:    info.Info{Pac: &info.PacOpts{Settings: settings.Settings{}}},
+	}
+	ctx, _ := rtesting.SetupFakeContext(t)
+	lock, err := lockRemoteResources(ctx, cs, []string{dir.Path()})
+	assert.NilError(t, err)
+	assert.DeepEqual(t, lock.Resources, map[string]string{server.URL + "/task.yaml": lockfile.Digest(task)})
+}
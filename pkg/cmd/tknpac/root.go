@@ -12,7 +12,12 @@ import (
 	"github.com/openshift-pipelines/pipelines-as-code/pkg/cmd/tknpac/info"
 	"github.com/openshift-pipelines/pipelines-as-code/pkg/cmd/tknpac/list"
 	"github.com/openshift-pipelines/pipelines-as-code/pkg/cmd/tknpac/logs"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/cmd/tknpac/migrate"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/cmd/tknpac/purge"
 	"github.com/openshift-pipelines/pipelines-as-code/pkg/cmd/tknpac/resolve"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/cmd/tknpac/top"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/cmd/tknpac/trigger"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/cmd/tknpac/usage"
 	"github.com/openshift-pipelines/pipelines-as-code/pkg/cmd/tknpac/version"
 	"github.com/openshift-pipelines/pipelines-as-code/pkg/cmd/tknpac/webhook"
 	"github.com/openshift-pipelines/pipelines-as-code/pkg/params"
@@ -41,10 +46,17 @@ func Root(clients *params.Run) *cobra.Command {
 	cmd.AddCommand(describe.Root(clients, ioStreams))
 	cmd.AddCommand(logs.Command(clients, ioStreams))
 	cmd.AddCommand(resolve.Command(clients, ioStreams))
+	cmd.AddCommand(resolve.LockCommand(clients, ioStreams))
+	cmd.AddCommand(trigger.Command(clients, ioStreams))
 	cmd.AddCommand(completion.Command())
 	cmd.AddCommand(bootstrap.Command(clients, ioStreams))
 	cmd.AddCommand(generate.Command(clients, ioStreams))
 	cmd.AddCommand(cel.Command(ioStreams))
 	cmd.AddCommand(webhook.Root(clients, ioStreams))
+	cmd.AddCommand(migrate.ExportCommand(clients, ioStreams))
+	cmd.AddCommand(migrate.ImportCommand(clients, ioStreams))
+	cmd.AddCommand(purge.Command(clients, ioStreams))
+	cmd.AddCommand(usage.Command(clients, ioStreams))
+	cmd.AddCommand(top.Command(clients, ioStreams))
 	return cmd
 }
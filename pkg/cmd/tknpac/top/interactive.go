@@ -0,0 +1,178 @@
+package top
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"os/signal"
+	"strings"
+	"time"
+
+	tektonv1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+	"golang.org/x/term"
+)
+
+const (
+	enterAltScreen = "\x1b[?1049h\x1b[?25l"
+	leaveAltScreen = "\x1b[?25h\x1b[?1049l"
+	clearScreen    = "\x1b[H\x1b[2J"
+)
+
+type key int
+
+const (
+	keyNone key = iota
+	keyUp
+	keyDown
+	keyLogs
+	keyCancel
+	keyYes
+	keyQuit
+)
+
+// parseKey returns the key pressed from the bytes read on the terminal in
+// raw mode.
+func parseKey(b []byte) key {
+	switch string(b) {
+	case "k", "\x1b[A":
+		return keyUp
+	case "j", "\x1b[B":
+		return keyDown
+	case "l", "\r", "\n":
+		return keyLogs
+	case "c":
+		return keyCancel
+	case "y", "Y":
+		return keyYes
+	case "q", "\x03":
+		return keyQuit
+	}
+	return keyNone
+}
+
+func readKeys(in io.Reader, keys chan<- key) {
+	defer close(keys)
+	buf := make([]byte, 8)
+	for {
+		n, err := in.Read(buf)
+		if err != nil {
+			return
+		}
+		if k := parseKey(buf[:n]); k != keyNone {
+			keys <- k
+		}
+	}
+}
+
+// interactive refreshes the view of the PipelineRuns until the user quits.
+func interactive(ctx context.Context, t *topOptions) error {
+	fd := int(os.Stdin.Fd())
+	oldState, err := term.MakeRaw(fd)
+	if err != nil {
+		return fmt.Errorf("cannot set the terminal in raw mode: %w", err)
+	}
+	out := t.ioStreams.Out
+	fmt.Fprint(out, enterAltScreen)
+	defer func() {
+		fmt.Fprint(out, leaveAltScreen)
+		_ = term.Restore(fd, oldState)
+	}()
+
+	keys := make(chan key)
+	go readKeys(t.ioStreams.In, keys)
+	ticker := time.NewTicker(t.refresh)
+	defer ticker.Stop()
+
+	var runs []tektonv1.PipelineRun
+	var confirm *tektonv1.PipelineRun
+	selected, message := 0, ""
+	refresh := true
+	for {
+		if refresh {
+			if latest, err := listRuns(ctx, t); err != nil {
+				message = t.ioStreams.ColorScheme().Red(err.Error())
+			} else {
+				runs = latest
+			}
+		}
+		selected = min(max(selected, 0), max(len(runs)-1, 0))
+		// the terminal in raw mode does not return the carriage on a new line
+		view := strings.ReplaceAll(screen(t, runs, selected, message), "\n", "\r\n")
+		fmt.Fprint(out, clearScreen+view)
+
+		refresh = false
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+			refresh = true
+			continue
+		case k, ok := <-keys:
+			if !ok {
+				return nil
+			}
+			if confirm != nil {
+				if k == keyYes {
+					message = fmt.Sprintf("PipelineRun %s/%s cancelled", confirm.GetNamespace(), confirm.GetName())
+					if err := cancel(ctx, t, confirm); err != nil {
+						message = t.ioStreams.ColorScheme().Red(err.Error())
+					}
+					refresh = true
+				} else {
+					message = ""
+				}
+				confirm = nil
+				continue
+			}
+			message = ""
+			switch k {
+			case keyQuit:
+				return nil
+			case keyUp:
+				selected--
+			case keyDown:
+				selected++
+			case keyCancel:
+				if len(runs) > 0 {
+					confirm = &runs[selected]
+					message = fmt.Sprintf("Cancel the PipelineRun %s/%s? (y/N)", confirm.GetNamespace(), confirm.GetName())
+				}
+			case keyLogs:
+				if len(runs) > 0 {
+					if err := followLogs(t, &runs[selected], fd, oldState); err != nil {
+						message = t.ioStreams.ColorScheme().Red(err.Error())
+					}
+					refresh = true
+				}
+			}
+		}
+	}
+}
+
+// followLogs restores the terminal to follow the logs of a PipelineRun with
+// tkn, until it completes or the user interrupts it, and then goes back to
+// the view.
+func followLogs(t *topOptions, pr *tektonv1.PipelineRun, fd int, oldState *term.State) error {
+	if t.tknPath == "" {
+		return fmt.Errorf("cannot find the tkn binary in $PATH to follow the logs, use --%s", tknPathFlag)
+	}
+	out := t.ioStreams.Out
+	fmt.Fprint(out, leaveAltScreen)
+	_ = term.Restore(fd, oldState)
+	defer func() {
+		_, _ = term.MakeRaw(fd)
+		fmt.Fprint(out, enterAltScreen)
+	}()
+
+	// ctrl-c only interrupts tkn and brings back the view
+	signal.Ignore(os.Interrupt)
+	defer signal.Reset(os.Interrupt)
+	fmt.Fprintf(out, "Following the logs of %s/%s, ctrl-c to go back\n", pr.GetNamespace(), pr.GetName())
+	//nolint: gosec
+	cmd := exec.Command(t.tknPath, "pr", "logs", "-f", "-n", pr.GetNamespace(), pr.GetName())
+	cmd.Stdout, cmd.Stderr = out, t.ioStreams.ErrOut
+	_ = cmd.Run()
+	return nil
+}
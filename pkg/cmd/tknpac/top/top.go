@@ -0,0 +1,233 @@
+package top
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"text/tabwriter"
+	"time"
+
+	"github.com/hako/durafmt"
+	"github.com/jonboulle/clockwork"
+	"github.com/juju/ansiterm"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/apis/pipelinesascode"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/apis/pipelinesascode/keys"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/cli"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/cmd/tknpac/completion"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/kubeinteraction"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/params"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/params/settings"
+	"github.com/spf13/cobra"
+	tektonv1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+const longhelp = `
+
+top - show the running PipelineRuns of Pipelines-as-Code
+
+tkn pac top shows the PipelineRuns created by Pipelines-as-Code which have not
+completed yet, across all namespaces or in the namespace given with
+--namespace, with their Repository, event, duration and state. The view is
+refreshed every --refresh interval.
+
+Keybindings:
+
+	up/k, down/j  select a PipelineRun
+	enter/l       follow the logs of the selected PipelineRun with tkn
+	c             cancel the selected PipelineRun, after a confirmation
+	q, ctrl-c     quit
+
+The PipelineRuns are printed once when the output is not a terminal.
+
+eg:
+	tkn pac top -n my-namespace --refresh 5s`
+
+const (
+	namespaceFlag = "namespace"
+	refreshFlag   = "refresh"
+	tknPathFlag   = "tkn-path"
+
+	managedBySelector = "app.kubernetes.io/managed-by=" + pipelinesascode.GroupName
+	defaultRefresh    = 2 * time.Second
+)
+
+type topOptions struct {
+	cs        *params.Run
+	ioStreams *cli.IOStreams
+	clock     clockwork.Clock
+	namespace string
+	refresh   time.Duration
+	tknPath   string
+}
+
+func Command(run *params.Run, ioStreams *cli.IOStreams) *cobra.Command {
+	topts := &topOptions{
+		cs:        run,
+		ioStreams: ioStreams,
+		clock:     clockwork.NewRealClock(),
+	}
+	cmd := &cobra.Command{
+		Use:   "top",
+		Long:  longhelp,
+		Short: "Show a live view of the running PipelineRuns of Pipelines-as-Code",
+		Args:  cobra.NoArgs,
+		Annotations: map[string]string{
+			"commandType": "main",
+		},
+		RunE: func(_ *cobra.Command, _ []string) error {
+			ctx := context.Background()
+			if err := run.Clients.NewClients(ctx, &run.Info); err != nil {
+				return err
+			}
+			if topts.refresh <= 0 {
+				return fmt.Errorf("--%s must be a positive duration", refreshFlag)
+			}
+			if !ioStreams.IsStdoutTTY() {
+				runs, err := listRuns(ctx, topts)
+				if err != nil {
+					return err
+				}
+				return render(ioStreams.Out, topts, runs, -1)
+			}
+			if topts.tknPath == "" {
+				if fname, err := exec.LookPath(settings.TknBinaryName); err == nil {
+					topts.tknPath, _ = filepath.Abs(fname)
+				}
+			}
+			return interactive(ctx, topts)
+		},
+	}
+
+	cmd.Flags().StringVarP(&topts.namespace, namespaceFlag, "n", "", "only show the PipelineRuns of this namespace, all namespaces by default")
+	_ = cmd.RegisterFlagCompletionFunc(namespaceFlag,
+		func(_ *cobra.Command, args []string, _ string) ([]string, cobra.ShellCompDirective) {
+			return completion.BaseCompletion(namespaceFlag, args)
+		},
+	)
+	cmd.Flags().DurationVar(&topts.refresh, refreshFlag, defaultRefresh, "how often the PipelineRuns are refreshed")
+	cmd.Flags().StringVar(&topts.tknPath, tknPathFlag, "", fmt.Sprintf("Path to the %s binary used to follow the logs (default to search for it in you $PATH)", settings.TknBinaryName))
+	return cmd
+}
+
+// listRuns returns the PipelineRuns of Pipelines-as-Code which have not
+// completed, the oldest first.
+func listRuns(ctx context.Context, t *topOptions) ([]tektonv1.PipelineRun, error) {
+	prs, err := t.cs.Clients.Tekton.TektonV1().PipelineRuns(t.namespace).List(ctx, metav1.ListOptions{LabelSelector: managedBySelector})
+	if err != nil {
+		return nil, fmt.Errorf("cannot list the PipelineRuns: %w", err)
+	}
+	runs := []tektonv1.PipelineRun{}
+	for _, pr := range prs.Items {
+		if pr.IsDone() {
+			continue
+		}
+		runs = append(runs, pr)
+	}
+	sort.SliceStable(runs, func(i, j int) bool {
+		return runs[i].CreationTimestamp.Before(&runs[j].CreationTimestamp)
+	})
+	return runs, nil
+}
+
+// state returns the Pipelines-as-Code state of a PipelineRun, whether it is
+// running or why it has not started yet.
+func state(pr *tektonv1.PipelineRun) string {
+	st := pr.GetAnnotations()[keys.State]
+	if st == "" {
+		st = kubeinteraction.StateStarted
+	}
+	if st == kubeinteraction.StateStarted && pr.Spec.Status == tektonv1.PipelineRunSpecStatusPending {
+		return "pending"
+	}
+	if st == kubeinteraction.StateStarted {
+		return "running"
+	}
+	return st
+}
+
+// event returns the event type of a PipelineRun with its pull request number.
+func event(pr *tektonv1.PipelineRun) string {
+	ev := pr.GetLabels()[keys.EventType]
+	if number := pr.GetLabels()[keys.PullRequest]; number != "" {
+		ev = fmt.Sprintf("%s #%s", ev, number)
+	}
+	return ev
+}
+
+// duration returns how long a PipelineRun has been running, or waiting when
+// it has not started yet.
+func duration(pr *tektonv1.PipelineRun, clock clockwork.Clock) string {
+	since := pr.GetCreationTimestamp().Time
+	if pr.Status.StartTime != nil {
+		since = pr.Status.StartTime.Time
+	}
+	return durafmt.ParseShort(clock.Since(since)).String()
+}
+
+// render prints the PipelineRuns, highlighting the selected one when
+// selected is not negative.
+func render(out io.Writer, t *topOptions, runs []tektonv1.PipelineRun, selected int) error {
+	cs := t.ioStreams.ColorScheme()
+	if len(runs) == 0 {
+		fmt.Fprintln(out, cs.Dimmed("No running PipelineRuns"))
+		return nil
+	}
+	w := ansiterm.NewTabWriter(out, 0, 5, 3, ' ', tabwriter.TabIndent)
+	fmt.Fprintf(w, "  %s\t%s\t%s\t%s\t%s\t%s\n", cs.Underline("NAMESPACE"), cs.Underline("NAME"), cs.Underline("REPOSITORY"),
+		cs.Underline("EVENT"), cs.Underline("DURATION"), cs.Underline("STATE"))
+	for i := range runs {
+		pr := &runs[i]
+		marker, bold := "  ", func(s string) string { return s }
+		if i == selected {
+			marker, bold = cs.Bold("> "), cs.Bold
+		}
+		fmt.Fprintf(w, "%s%s\t%s\t%s\t%s\t%s\t%s\n", marker, bold(pr.GetNamespace()), bold(pr.GetName()),
+			bold(pr.GetAnnotations()[keys.Repository]), bold(event(pr)), bold(duration(pr, t.clock)), bold(state(pr)))
+	}
+	return w.Flush()
+}
+
+// cancel cancels a PipelineRun, the watcher then reports its status on the
+// git provider.
+func cancel(ctx context.Context, t *topOptions, pr *tektonv1.PipelineRun) error {
+	patch, err := json.Marshal(map[string]any{
+		"spec": map[string]any{
+			"status": tektonv1.PipelineRunSpecStatusCancelled,
+		},
+	})
+	if err != nil {
+		return err
+	}
+	if _, err := t.cs.Clients.Tekton.TektonV1().PipelineRuns(pr.GetNamespace()).Patch(ctx, pr.GetName(),
+		types.MergePatchType, patch, metav1.PatchOptions{}); err != nil {
+		return fmt.Errorf("cannot cancel the PipelineRun %s/%s: %w", pr.GetNamespace(), pr.GetName(), err)
+	}
+	return nil
+}
+
+// screen renders the whole view: the header, the PipelineRuns and the status
+// line with the last message.
+func screen(t *topOptions, runs []tektonv1.PipelineRun, selected int, message string) string {
+	cs := t.ioStreams.ColorScheme()
+	var buf bytes.Buffer
+	scope := "all namespaces"
+	if t.namespace != "" {
+		scope = "namespace " + t.namespace
+	}
+	fmt.Fprintf(&buf, "%s - %d running PipelineRuns in %s, refreshed every %s\n",
+		cs.Bold("tkn pac top"), len(runs), scope, t.refresh)
+	fmt.Fprintln(&buf, cs.Dimmed("up/k down/j: select   enter/l: logs   c: cancel   q: quit"))
+	fmt.Fprintln(&buf)
+	_ = render(&buf, t, runs, selected)
+	if message != "" {
+		fmt.Fprintf(&buf, "\n%s\n", message)
+	}
+	return buf.String()
+}
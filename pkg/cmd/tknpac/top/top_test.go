@@ -0,0 +1,168 @@
+package top
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jonboulle/clockwork"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/apis/pipelinesascode"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/apis/pipelinesascode/keys"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/cli"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/kubeinteraction"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/params"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/params/clients"
+	testclient "github.com/openshift-pipelines/pipelines-as-code/pkg/test/clients"
+	tektontest "github.com/openshift-pipelines/pipelines-as-code/pkg/test/tekton"
+	tektonv1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+	"gotest.tools/v3/assert"
+	"gotest.tools/v3/golden"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	rtesting "knative.dev/pkg/reconciler/testing"
+)
+
+func TestTop(t *testing.T) {
+	ns, otherNS := "ns", "other"
+	clock := clockwork.NewFakeClock()
+	labels := func(event, pullRequest string) map[string]string {
+		l := map[string]string{
+			"app.kubernetes.io/managed-by": pipelinesascode.GroupName,
+			keys.EventType:                 event,
+		}
+		if pullRequest != "" {
+			l[keys.PullRequest] = pullRequest
+		}
+		return l
+	}
+	makePR := func(name, namespace, repo, state string, ago time.Duration, started bool, lbls map[string]string) *tektonv1.PipelineRun {
+		pr := &tektonv1.PipelineRun{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:              name,
+				Namespace:         namespace,
+				Labels:            lbls,
+				Annotations:       map[string]string{keys.Repository: repo, keys.State: state},
+				CreationTimestamp: metav1.Time{Time: clock.Now().Add(-ago)},
+			},
+		}
+		if started {
+			pr.Status.StartTime = &metav1.Time{Time: clock.Now().Add(-ago + time.Minute)}
+		} else {
+			pr.Spec.Status = tektonv1.PipelineRunSpecStatusPending
+		}
+		return pr
+	}
+
+	pipelineRuns := []*tektonv1.PipelineRun{
+		makePR("build-abcde", ns, "frontend", kubeinteraction.StateStarted, 10*time.Minute, true, labels("pull_request", "42")),
+		makePR("build-fghij", ns, "frontend", kubeinteraction.StateQueued, 2*time.Minute, false, labels("pull_request", "43")),
+		makePR("deploy-klmno", otherNS, "api", kubeinteraction.StateStarted, 30*time.Minute, true, labels("push", "")),
+		tektontest.MakePRCompletion(clock, "done", ns, "", nil, labels("push", ""), 10),
+		{ObjectMeta: metav1.ObjectMeta{Name: "not-pac", Namespace: ns}},
+	}
+
+	tests := []struct {
+		name      string
+		namespace string
+		selected  int
+		wantRuns  []string
+	}{
+		{
+			name:     "all namespaces",
+			selected: -1,
+			wantRuns: []string{"deploy-klmno", "build-abcde", "build-fghij"},
+		},
+		{
+			name:      "namespace",
+			namespace: ns,
+			selected:  1,
+			wantRuns:  []string{"build-abcde", "build-fghij"},
+		},
+		{
+			name:      "no running pipelineruns",
+			namespace: "empty",
+			selected:  -1,
+			wantRuns:  []string{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, _ := rtesting.SetupFakeContext(t)
+			stdata, _ := testclient.SeedTestData(t, ctx, testclient.Data{
+				Namespaces: []*corev1.Namespace{
+					{ObjectMeta: metav1.ObjectMeta{Name: ns}},
+					{ObjectMeta: metav1.ObjectMeta{Name: otherNS}},
+				},
+				PipelineRuns: pipelineRuns,
+			})
+			out := &bytes.Buffer{}
+			topts := &topOptions{
+				cs: &params.Run{
+					Clients: clients.Clients{Tekton: stdata.Pipeline},
+				},
+				ioStreams: &cli.IOStreams{Out: out},
+				clock:     clock,
+				namespace: tt.namespace,
+				refresh:   defaultRefresh,
+			}
+			runs, err := listRuns(ctx, topts)
+			assert.NilError(t, err)
+			names := []string{}
+			for _, pr := range runs {
+				names = append(names, pr.GetName())
+			}
+			assert.DeepEqual(t, names, tt.wantRuns)
+
+			assert.NilError(t, render(out, topts, runs, tt.selected))
+			golden.Assert(t, out.String(), strings.ReplaceAll(fmt.Sprintf("%s.golden", t.Name()), "/", "-"))
+		})
+	}
+}
+
+func TestCancel(t *testing.T) {
+	ctx, _ := rtesting.SetupFakeContext(t)
+	pr := &tektonv1.PipelineRun{ObjectMeta: metav1.ObjectMeta{Name: "build", Namespace: "ns"}}
+	stdata, _ := testclient.SeedTestData(t, ctx, testclient.Data{
+		Namespaces:   []*corev1.Namespace{{ObjectMeta: metav1.ObjectMeta{Name: "ns"}}},
+		PipelineRuns: []*tektonv1.PipelineRun{pr},
+	})
+	topts := &topOptions{
+		cs: &params.Run{
+			Clients: clients.Clients{Tekton: stdata.Pipeline},
+		},
+	}
+	assert.NilError(t, cancel(ctx, topts, pr))
+	got, err := stdata.Pipeline.TektonV1().PipelineRuns("ns").Get(ctx, "build", metav1.GetOptions{})
+	assert.NilError(t, err)
+	assert.Equal(t, string(got.Spec.Status), tektonv1.PipelineRunSpecStatusCancelled)
+
+	assert.ErrorContains(t, cancel(ctx, topts, &tektonv1.PipelineRun{ObjectMeta: metav1.ObjectMeta{Name: "gone", Namespace: "ns"}}),
+		"cannot cancel the PipelineRun ns/gone")
+}
+
+func TestParseKey(t *testing.T) {
+	tests := []struct {
+		input string
+		want  key
+	}{
+		{input: "k", want: keyUp},
+		{input: "\x1b[A", want: keyUp},
+		{input: "j", want: keyDown},
+		{input: "\x1b[B", want: keyDown},
+		{input: "\r", want: keyLogs},
+		{input: "l", want: keyLogs},
+		{input: "c", want: keyCancel},
+		{input: "y", want: keyYes},
+		{input: "q", want: keyQuit},
+		{input: "\x03", want: keyQuit},
+		{input: "x", want: keyNone},
+		{input: "\x1b[C", want: keyNone},
+	}
+	for _, tt := range tests {
+		t.Run(fmt.Sprintf("%q", tt.input), func(t *testing.T) {
+			assert.Equal(t, parseKey([]byte(tt.input)), tt.want)
+		})
+	}
+}
@@ -0,0 +1,190 @@
+package trigger
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/cli"
+	pacinfo "github.com/openshift-pipelines/pipelines-as-code/pkg/cli/info"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/cmd/tknpac/completion"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/matcher"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/params"
+	"github.com/spf13/cobra"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const longhelp = `
+
+trigger - manually start a PipelineRun of a Repository
+
+tkn pac trigger will start the PipelineRun from the .tekton directory of the
+branch of a Repository via its incoming webhook, the Repository needs an
+incoming webhook rule targeting that branch.
+
+The inputs declared in the pipelinesascode.tekton.dev/manual-inputs annotation
+of the PipelineRun can be passed with the --input flag and are validated by
+the Pipelines-as-Code controller.
+
+eg:
+	tkn pac trigger release --repository my-repo --branch main --input environment=production`
+
+const (
+	namespaceFlag     = "namespace"
+	repositoryFlag    = "repository"
+	branchFlag        = "branch"
+	inputFlag         = "input"
+	controllerURLFlag = "controller-url"
+)
+
+type triggerOption struct {
+	cs            *params.Run
+	ioStreams     *cli.IOStreams
+	client        *http.Client
+	namespace     string
+	repoName      string
+	branch        string
+	pipelineRun   string
+	controllerURL string
+	inputs        []string
+}
+
+func Command(run *params.Run, ioStreams *cli.IOStreams) *cobra.Command {
+	topts := &triggerOption{
+		cs:        run,
+		ioStreams: ioStreams,
+		client:    http.DefaultClient,
+	}
+	cmd := &cobra.Command{
+		Use:   "trigger pipelinerun",
+		Long:  longhelp,
+		Short: "Manually start a PipelineRun of a Repository",
+		Args:  cobra.ExactArgs(1),
+		Annotations: map[string]string{
+			"commandType": "main",
+		},
+		RunE: func(_ *cobra.Command, args []string) error {
+			topts.pipelineRun = args[0]
+			ctx := context.Background()
+			if err := run.Clients.NewClients(ctx, &run.Info); err != nil {
+				return err
+			}
+			if topts.namespace == "" {
+				topts.namespace = run.Info.Kube.Namespace
+			}
+			return trigger(ctx, topts)
+		},
+	}
+
+	cmd.Flags().StringVarP(&topts.namespace, namespaceFlag, "n", "", "If present, the namespace scope for this CLI request")
+	_ = cmd.RegisterFlagCompletionFunc(namespaceFlag,
+		func(_ *cobra.Command, args []string, _ string) ([]string, cobra.ShellCompDirective) {
+			return completion.BaseCompletion(namespaceFlag, args)
+		},
+	)
+	cmd.Flags().StringVarP(&topts.repoName, repositoryFlag, "r", "", "The name of the Repository")
+	_ = cmd.RegisterFlagCompletionFunc(repositoryFlag,
+		func(_ *cobra.Command, args []string, _ string) ([]string, cobra.ShellCompDirective) {
+			return completion.BaseCompletion("repositories", args)
+		},
+	)
+	cmd.Flags().StringVarP(&topts.branch, branchFlag, "b", "", "The branch to take the PipelineRun from")
+	cmd.Flags().StringArrayVarP(&topts.inputs, inputFlag, "i", []string{}, "An input of the PipelineRun as key=value, can be repeated")
+	cmd.Flags().StringVar(&topts.controllerURL, controllerURLFlag, "", "The public URL of the Pipelines-as-Code controller (default to detect it from the installation)")
+	_ = cmd.MarkFlagRequired(repositoryFlag)
+	_ = cmd.MarkFlagRequired(branchFlag)
+	return cmd
+}
+
+func parseInputs(inputs []string) (map[string]string, error) {
+	ret := map[string]string{}
+	for _, input := range inputs {
+		key, value, ok := strings.Cut(input, "=")
+		if !ok || key == "" {
+			return nil, fmt.Errorf("input %s is not in the key=value format", input)
+		}
+		ret[key] = value
+	}
+	return ret, nil
+}
+
+func getControllerURL(ctx context.Context, run *params.Run) (string, error) {
+	ns, _, err := params.GetInstallLocation(ctx, run)
+	if err != nil {
+		return "", err
+	}
+	info, err := pacinfo.GetPACInfo(ctx, run, ns)
+	if err != nil {
+		return "", err
+	}
+	if info.ControllerURL == "" {
+		return "", fmt.Errorf("cannot detect the controller URL of the installation, pass it with the --%s flag", controllerURLFlag)
+	}
+	return info.ControllerURL, nil
+}
+
+func trigger(ctx context.Context, topts *triggerOption) error {
+	inputs, err := parseInputs(topts.inputs)
+	if err != nil {
+		return err
+	}
+
+	repo, err := topts.cs.Clients.PipelineAsCode.PipelinesascodeV1alpha1().Repositories(topts.namespace).Get(ctx, topts.repoName, metav1.GetOptions{})
+	if err != nil {
+		return err
+	}
+	if repo.Spec.Incomings == nil {
+		return fmt.Errorf("repository %s has no incoming webhook rules to trigger a PipelineRun", repo.GetName())
+	}
+	hook := matcher.IncomingWebhookRule(topts.branch, *repo.Spec.Incomings)
+	if hook == nil {
+		return fmt.Errorf("branch %s has not matched any incoming webhook rules of the repository %s", topts.branch, repo.GetName())
+	}
+	secret, err := topts.cs.Clients.Kube.CoreV1().Secrets(repo.GetNamespace()).Get(ctx, hook.Secret.Name, metav1.GetOptions{})
+	if err != nil {
+		return fmt.Errorf("cannot get the incoming webhook secret %s: %w", hook.Secret.Name, err)
+	}
+	secretValue := string(secret.Data[hook.Secret.Key])
+	if secretValue == "" {
+		return fmt.Errorf("incoming webhook secret %s is empty or key %s does not exist", hook.Secret.Name, hook.Secret.Key)
+	}
+
+	controllerURL := topts.controllerURL
+	if controllerURL == "" {
+		if controllerURL, err = getControllerURL(ctx, topts.cs); err != nil {
+			return err
+		}
+	}
+
+	body, err := json.Marshal(map[string]any{
+		"repository":  repo.GetName(),
+		"branch":      topts.branch,
+		"pipelinerun": topts.pipelineRun,
+		"secret":      secretValue,
+		"params":      inputs,
+	})
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, strings.TrimSuffix(controllerURL, "/")+"/incoming", bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := topts.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("cannot trigger pipelinerun %s: %w", topts.pipelineRun, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= http.StatusBadRequest {
+		message, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("cannot trigger pipelinerun %s: %s %s", topts.pipelineRun, resp.Status, strings.TrimSpace(string(message)))
+	}
+
+	fmt.Fprintf(topts.ioStreams.Out, "PipelineRun %s has been triggered on the branch %s of the repository %s\n", topts.pipelineRun, topts.branch, repo.GetName())
+	return nil
+}
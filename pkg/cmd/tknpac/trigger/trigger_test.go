@@ -0,0 +1,147 @@
+package trigger
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/apis/pipelinesascode/v1alpha1"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/cli"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/params"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/params/clients"
+	testclient "github.com/openshift-pipelines/pipelines-as-code/pkg/test/clients"
+	"gotest.tools/v3/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	rtesting "knative.dev/pkg/reconciler/testing"
+)
+
+func TestTrigger(t *testing.T) {
+	ns := "ns"
+	repo := &v1alpha1.Repository{
+		ObjectMeta: metav1.ObjectMeta{Name: "repo", Namespace: ns},
+		Spec: v1alpha1.RepositorySpec{
+			URL: "https://github.com/owner/repo",
+			Incomings: &[]v1alpha1.Incoming{
+				{
+					Type:    "webhook-url",
+					Targets: []string{"main"},
+					Params:  []string{"environment"},
+					Secret:  v1alpha1.Secret{Name: "incoming-secret", Key: "secret"},
+				},
+			},
+		},
+	}
+	repoNoIncoming := &v1alpha1.Repository{
+		ObjectMeta: metav1.ObjectMeta{Name: "repo-no-incoming", Namespace: ns},
+		Spec:       v1alpha1.RepositorySpec{URL: "https://github.com/owner/other"},
+	}
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "incoming-secret", Namespace: ns},
+		Data:       map[string][]byte{"secret": []byte("shhh")},
+	}
+
+	tests := []struct {
+		name       string
+		repoName   string
+		branch     string
+		inputs     []string
+		statusCode int
+		wantErr    string
+		wantBody   map[string]any
+	}{
+		{
+			name:       "trigger with inputs",
+			repoName:   "repo",
+			branch:     "main",
+			inputs:     []string{"environment=production"},
+			statusCode: http.StatusAccepted,
+			wantBody: map[string]any{
+				"repository":  "repo",
+				"branch":      "main",
+				"pipelinerun": "release",
+				"secret":      "shhh",
+				"params":      map[string]any{"environment": "production"},
+			},
+		},
+		{
+			name:     "bad input format",
+			repoName: "repo",
+			branch:   "main",
+			inputs:   []string{"environment"},
+			wantErr:  "input environment is not in the key=value format",
+		},
+		{
+			name:     "no incoming rules",
+			repoName: "repo-no-incoming",
+			branch:   "main",
+			wantErr:  "repository repo-no-incoming has no incoming webhook rules to trigger a PipelineRun",
+		},
+		{
+			name:     "branch not matching",
+			repoName: "repo",
+			branch:   "devel",
+			wantErr:  "branch devel has not matched any incoming webhook rules of the repository repo",
+		},
+		{
+			name:       "controller refusing",
+			repoName:   "repo",
+			branch:     "main",
+			inputs:     []string{"environment=nowhere"},
+			statusCode: http.StatusBadRequest,
+			wantErr:    "cannot trigger pipelinerun release: 400 Bad Request invalid inputs",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, _ := rtesting.SetupFakeContext(t)
+			stdata, _ := testclient.SeedTestData(t, ctx, testclient.Data{
+				Repositories: []*v1alpha1.Repository{repo, repoNoIncoming},
+				Secret:       []*corev1.Secret{secret},
+			})
+
+			var gotBody map[string]any
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, r.URL.Path, "/incoming")
+				assert.Equal(t, r.Header.Get("Content-Type"), "application/json")
+				body, err := io.ReadAll(r.Body)
+				assert.NilError(t, err)
+				assert.NilError(t, json.Unmarshal(body, &gotBody))
+				w.WriteHeader(tt.statusCode)
+				if tt.statusCode >= http.StatusBadRequest {
+					_, _ = w.Write([]byte("invalid inputs"))
+				}
+			}))
+			defer server.Close()
+
+			out := &bytes.Buffer{}
+			topts := &triggerOption{
+				cs: &params.Run{
+					Clients: clients.Clients{
+						PipelineAsCode: stdata.PipelineAsCode,
+						Kube:           stdata.Kube,
+					},
+				},
+				ioStreams:     &cli.IOStreams{Out: out},
+				client:        server.Client(),
+				namespace:     ns,
+				repoName:      tt.repoName,
+				branch:        tt.branch,
+				pipelineRun:   "release",
+				controllerURL: server.URL + "/",
+				inputs:        tt.inputs,
+			}
+			err := trigger(ctx, topts)
+			if tt.wantErr != "" {
+				assert.Error(t, err, tt.wantErr)
+				return
+			}
+			assert.NilError(t, err)
+			assert.DeepEqual(t, gotBody, tt.wantBody)
+			assert.Equal(t, out.String(), "PipelineRun release has been triggered on the branch main of the repository repo\n")
+		})
+	}
+}
@@ -0,0 +1,195 @@
+package usage
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/jonboulle/clockwork"
+	"github.com/juju/ansiterm"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/apis/pipelinesascode/v1alpha1"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/cli"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/cmd/tknpac/completion"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/params"
+	"github.com/spf13/cobra"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const longhelp = `
+
+usage - show the usage of the Repositories
+
+tkn pac usage sums the daily usage recorded by the watcher in the status of the
+Repositories: the number of PipelineRuns, their duration and, when the
+usage-resource-accounting setting is enabled, the CPU and memory requested by
+their TaskRuns.
+
+The --since and --until flags take a date like 2026-10-01, inclusive, or a
+number of days ago like 7d. The usage of the last 30 days is shown by default
+and the watcher keeps 90 days of usage.
+
+eg:
+	tkn pac usage --all-namespaces --since 2026-09-01 --until 2026-09-30
+	tkn pac usage my-repo --since 7d`
+
+const (
+	namespaceFlag     = "namespace"
+	allNamespacesFlag = "all-namespaces"
+	sinceFlag         = "since"
+	untilFlag         = "until"
+
+	defaultSince = "30d"
+)
+
+type usageOptions struct {
+	cs            *params.Run
+	ioStreams     *cli.IOStreams
+	clock         clockwork.Clock
+	namespace     string
+	allNamespaces bool
+	repository    string
+	since         string
+	until         string
+}
+
+// repositoryUsage is the usage of a Repository over the time range.
+type repositoryUsage struct {
+	namespace, name string
+	usage           v1alpha1.DailyUsage
+}
+
+func Command(run *params.Run, ioStreams *cli.IOStreams) *cobra.Command {
+	uopts := &usageOptions{
+		cs:        run,
+		ioStreams: ioStreams,
+		clock:     clockwork.NewRealClock(),
+	}
+	cmd := &cobra.Command{
+		Use:   "usage [repository]",
+		Long:  longhelp,
+		Short: "Show the PipelineRuns count, duration and requested resources of the Repositories",
+		Args:  cobra.MaximumNArgs(1),
+		Annotations: map[string]string{
+			"commandType": "main",
+		},
+		RunE: func(_ *cobra.Command, args []string) error {
+			ctx := context.Background()
+			if err := run.Clients.NewClients(ctx, &run.Info); err != nil {
+				return err
+			}
+			if uopts.namespace == "" && !uopts.allNamespaces {
+				uopts.namespace = run.Info.Kube.Namespace
+			}
+			if len(args) > 0 {
+				uopts.repository = args[0]
+			}
+			return usage(ctx, uopts)
+		},
+	}
+
+	cmd.Flags().StringVarP(&uopts.namespace, namespaceFlag, "n", "", "If present, the namespace scope for this CLI request")
+	_ = cmd.RegisterFlagCompletionFunc(namespaceFlag,
+		func(_ *cobra.Command, args []string, _ string) ([]string, cobra.ShellCompDirective) {
+			return completion.BaseCompletion(namespaceFlag, args)
+		},
+	)
+	cmd.Flags().BoolVarP(&uopts.allNamespaces, allNamespacesFlag, "A", false, "show the usage of the repositories across all namespaces")
+	cmd.Flags().StringVar(&uopts.since, sinceFlag, defaultSince, "first day of the usage, a date like 2026-10-01 or a number of days ago like 7d")
+	cmd.Flags().StringVar(&uopts.until, untilFlag, "", "last day of the usage, a date like 2026-10-31 or a number of days ago like 1d, today by default")
+	return cmd
+}
+
+// parseDay returns the date of a day given as a date or a number of days ago.
+func (u *usageOptions) parseDay(flag, value string) (string, error) {
+	if days, ok := strings.CutSuffix(value, "d"); ok {
+		n, err := strconv.Atoi(days)
+		if err != nil || n < 0 {
+			return "", fmt.Errorf("invalid --%s value %q, it needs to be a date like 2026-10-01 or a number of days like 7d", flag, value)
+		}
+		return u.clock.Now().UTC().AddDate(0, 0, -n).Format(time.DateOnly), nil
+	}
+	if _, err := time.Parse(time.DateOnly, value); err != nil {
+		return "", fmt.Errorf("invalid --%s value %q, it needs to be a date like 2026-10-01 or a number of days like 7d", flag, value)
+	}
+	return value, nil
+}
+
+func formatDuration(seconds int64) string {
+	return (time.Duration(seconds) * time.Second).String()
+}
+
+func usage(ctx context.Context, u *usageOptions) error {
+	since, err := u.parseDay(sinceFlag, u.since)
+	if err != nil {
+		return err
+	}
+	until := u.clock.Now().UTC().Format(time.DateOnly)
+	if u.until != "" {
+		if until, err = u.parseDay(untilFlag, u.until); err != nil {
+			return err
+		}
+	}
+	if until < since {
+		return fmt.Errorf("--%s %s is after --%s %s", sinceFlag, since, untilFlag, until)
+	}
+
+	namespace := u.namespace
+	if u.allNamespaces {
+		namespace = metav1.NamespaceAll
+	}
+	repositories, err := u.cs.Clients.PipelineAsCode.PipelinesascodeV1alpha1().Repositories(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return fmt.Errorf("cannot list the repositories: %w", err)
+	}
+
+	usages := []repositoryUsage{}
+	total := v1alpha1.DailyUsage{}
+	for _, repo := range repositories.Items {
+		if u.repository != "" && repo.GetName() != u.repository {
+			continue
+		}
+		ru := repositoryUsage{namespace: repo.GetNamespace(), name: repo.GetName()}
+		if repo.RepositoryStatus != nil {
+			for _, day := range repo.RepositoryStatus.Usage {
+				if day.Date < since || day.Date > until {
+					continue
+				}
+				ru.usage.Runs += day.Runs
+				ru.usage.DurationSeconds += day.DurationSeconds
+				ru.usage.CPUMillicoreSeconds += day.CPUMillicoreSeconds
+				ru.usage.MemoryMebibyteSeconds += day.MemoryMebibyteSeconds
+			}
+		}
+		total.Runs += ru.usage.Runs
+		total.DurationSeconds += ru.usage.DurationSeconds
+		total.CPUMillicoreSeconds += ru.usage.CPUMillicoreSeconds
+		total.MemoryMebibyteSeconds += ru.usage.MemoryMebibyteSeconds
+		usages = append(usages, ru)
+	}
+	if len(usages) == 0 {
+		if u.repository != "" {
+			return fmt.Errorf("repository %s not found", u.repository)
+		}
+		return fmt.Errorf("no repo found")
+	}
+
+	cs := u.ioStreams.ColorScheme()
+	w := ansiterm.NewTabWriter(u.ioStreams.Out, 0, 5, 3, ' ', tabwriter.TabIndent)
+	fmt.Fprintf(w, "Usage from %s to %s\n\n", since, until)
+	fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\n", cs.Underline("NAMESPACE"), cs.Underline("NAME"), cs.Underline("RUNS"),
+		cs.Underline("DURATION"), cs.Underline("CPU CORE-HOURS"), cs.Underline("MEMORY GIB-HOURS"))
+	row := func(namespace, name string, usage v1alpha1.DailyUsage) {
+		fmt.Fprintf(w, "%s\t%s\t%d\t%s\t%.2f\t%.2f\n", namespace, name, usage.Runs, formatDuration(usage.DurationSeconds),
+			float64(usage.CPUMillicoreSeconds)/1000/3600, float64(usage.MemoryMebibyteSeconds)/1024/3600)
+	}
+	for _, ru := range usages {
+		row(ru.namespace, ru.name, ru.usage)
+	}
+	if len(usages) > 1 {
+		row(cs.Bold("TOTAL"), "", total)
+	}
+	return w.Flush()
+}
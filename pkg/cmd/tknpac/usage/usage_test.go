@@ -0,0 +1,122 @@
+package usage
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jonboulle/clockwork"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/apis/pipelinesascode/v1alpha1"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/cli"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/params"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/params/clients"
+	testclient "github.com/openshift-pipelines/pipelines-as-code/pkg/test/clients"
+	"gotest.tools/v3/assert"
+	"gotest.tools/v3/golden"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	rtesting "knative.dev/pkg/reconciler/testing"
+)
+
+func TestUsage(t *testing.T) {
+	ns, otherNS := "ns", "other"
+	clock := clockwork.NewFakeClockAt(time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC))
+	repositories := []*v1alpha1.Repository{
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "frontend", Namespace: ns},
+			Spec:       v1alpha1.RepositorySpec{URL: "https://forge/owner/frontend"},
+			RepositoryStatus: &v1alpha1.RepositoryStatus{Usage: []v1alpha1.DailyUsage{
+				{Date: "2026-09-01", Runs: 10, DurationSeconds: 6000},
+				{Date: "2026-10-10", Runs: 4, DurationSeconds: 1200, CPUMillicoreSeconds: 3600000, MemoryMebibyteSeconds: 1843200},
+				{Date: "2026-10-16", Runs: 2, DurationSeconds: 600, CPUMillicoreSeconds: 1800000, MemoryMebibyteSeconds: 921600},
+			}},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "backend", Namespace: ns},
+			Spec:       v1alpha1.RepositorySpec{URL: "https://forge/owner/backend"},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "api", Namespace: otherNS},
+			Spec:       v1alpha1.RepositorySpec{URL: "https://forge/owner/api"},
+			RepositoryStatus: &v1alpha1.RepositoryStatus{Usage: []v1alpha1.DailyUsage{
+				{Date: "2026-10-12", Runs: 1, DurationSeconds: 90},
+			}},
+		},
+	}
+
+	tests := []struct {
+		name          string
+		allNamespaces bool
+		repository    string
+		since         string
+		until         string
+		wantErr       string
+	}{
+		{
+			name:  "namespace",
+			since: "30d",
+		},
+		{
+			name:          "all namespaces",
+			allNamespaces: true,
+			since:         "2026-09-01",
+			until:         "2026-10-12",
+		},
+		{
+			name:       "repository",
+			repository: "frontend",
+			since:      "2d",
+		},
+		{
+			name:       "unknown repository",
+			repository: "unknown",
+			since:      "30d",
+			wantErr:    "repository unknown not found",
+		},
+		{
+			name:    "invalid since",
+			since:   "last week",
+			wantErr: `invalid --since value "last week", it needs to be a date like 2026-10-01 or a number of days like 7d`,
+		},
+		{
+			name:    "since after until",
+			since:   "2026-10-10",
+			until:   "2026-10-01",
+			wantErr: "--since 2026-10-10 is after --until 2026-10-01",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, _ := rtesting.SetupFakeContext(t)
+			stdata, _ := testclient.SeedTestData(t, ctx, testclient.Data{
+				Namespaces: []*corev1.Namespace{
+					{ObjectMeta: metav1.ObjectMeta{Name: ns}},
+					{ObjectMeta: metav1.ObjectMeta{Name: otherNS}},
+				},
+				Repositories: repositories,
+			})
+			out := &bytes.Buffer{}
+			uopts := &usageOptions{
+				cs: &params.Run{
+					Clients: clients.Clients{PipelineAsCode: stdata.PipelineAsCode},
+				},
+				ioStreams:     &cli.IOStreams{Out: out},
+				clock:         clock,
+				namespace:     ns,
+				allNamespaces: tt.allNamespaces,
+				repository:    tt.repository,
+				since:         tt.since,
+				until:         tt.until,
+			}
+			err := usage(ctx, uopts)
+			if tt.wantErr != "" {
+				assert.Error(t, err, tt.wantErr)
+				return
+			}
+			assert.NilError(t, err)
+			golden.Assert(t, out.String(), strings.ReplaceAll(fmt.Sprintf("%s.golden", t.Name()), "/", "-"))
+		})
+	}
+}
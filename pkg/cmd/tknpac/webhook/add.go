@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/apis/pipelinesascode/keys"
 	"github.com/openshift-pipelines/pipelines-as-code/pkg/apis/pipelinesascode/v1alpha1"
 	"github.com/openshift-pipelines/pipelines-as-code/pkg/cli"
 	"github.com/openshift-pipelines/pipelines-as-code/pkg/cli/prompt"
@@ -12,6 +13,7 @@ import (
 	"github.com/openshift-pipelines/pipelines-as-code/pkg/params"
 	"github.com/openshift-pipelines/pipelines-as-code/pkg/params/settings"
 	"github.com/openshift-pipelines/pipelines-as-code/pkg/pipelineascode"
+	giteaprovider "github.com/openshift-pipelines/pipelines-as-code/pkg/provider/gitea"
 	"github.com/spf13/cobra"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
@@ -19,7 +21,8 @@ import (
 var namespaceFlag = "namespace"
 
 func webhookAdd(run *params.Run, ioStreams *cli.IOStreams) *cobra.Command {
-	var pacNamespace string
+	var pacNamespace, listener string
+	var tlsOpts giteaprovider.TLSOptions
 	cmd := &cobra.Command{
 		Use:     "add",
 		Aliases: []string{""},
@@ -43,7 +46,7 @@ func webhookAdd(run *params.Run, ioStreams *cli.IOStreams) *cobra.Command {
 				return err
 			}
 
-			return add(ctx, opts, run, ioStreams, repoName, pacNamespace)
+			return add(ctx, opts, run, ioStreams, repoName, pacNamespace, listener, tlsOpts)
 		},
 		Annotations: map[string]string{
 			"commandType": "main",
@@ -54,6 +57,9 @@ func webhookAdd(run *params.Run, ioStreams *cli.IOStreams) *cobra.Command {
 
 	cmd.Flags().StringP(
 		namespaceFlag, "n", "", "If present, the namespace scope for this CLI request")
+	cmd.Flags().StringVar(&listener, "listener", "",
+		"The name of the listener of the controller-urls of the pipelines-as-code-info configmap the webhook targets, default to the webhook-listener annotation of the repository")
+	webhook.AddTLSFlags(cmd.Flags(), &tlsOpts)
 
 	_ = cmd.RegisterFlagCompletionFunc(namespaceFlag,
 		func(_ *cobra.Command, args []string, _ string) ([]string, cobra.ShellCompDirective) {
@@ -63,7 +69,7 @@ func webhookAdd(run *params.Run, ioStreams *cli.IOStreams) *cobra.Command {
 	return cmd
 }
 
-func add(ctx context.Context, opts *cli.PacCliOpts, run *params.Run, ioStreams *cli.IOStreams, repoName, pacNamespace string) error {
+func add(ctx context.Context, opts *cli.PacCliOpts, run *params.Run, ioStreams *cli.IOStreams, repoName, pacNamespace, listener string, tlsOpts giteaprovider.TLSOptions) error {
 	var (
 		err          error
 		repo         *v1alpha1.Repository
@@ -90,6 +96,10 @@ func add(ctx context.Context, opts *cli.PacCliOpts, run *params.Run, ioStreams *
 		return err
 	}
 
+	if listener == "" {
+		listener = repo.GetAnnotations()[keys.WebhookListener]
+	}
+
 	if repo.Spec.GitProvider == nil {
 		config := &webhook.Options{
 			Run:                      run,
@@ -97,8 +107,10 @@ func add(ctx context.Context, opts *cli.PacCliOpts, run *params.Run, ioStreams *
 			RepositoryNamespace:      repo.Namespace,
 			PACNamespace:             pacNamespace,
 			RepositoryURL:            repo.Spec.URL,
+			Listener:                 listener,
 			IOStreams:                ioStreams,
 			RepositoryCreateORUpdate: true,
+			TLS:                      tlsOpts,
 		}
 		return config.Install(ctx, providerName)
 	}
@@ -133,11 +145,13 @@ func add(ctx context.Context, opts *cli.PacCliOpts, run *params.Run, ioStreams *
 		PACNamespace:             pacNamespace,
 		RepositoryURL:            repo.Spec.URL,
 		ProviderAPIURL:           repo.Spec.GitProvider.URL,
+		Listener:                 listener,
 		IOStreams:                ioStreams,
 		PersonalAccessToken:      string(tokenData),
 		RepositoryCreateORUpdate: false,
 		SecretName:               secretName,
 		ProviderSecretKey:        gitProviderSecretKey,
+		TLS:                      tlsOpts,
 	}
 
 	return config.Install(ctx, providerName)
@@ -13,6 +13,7 @@ import (
 	"github.com/openshift-pipelines/pipelines-as-code/pkg/params"
 	"github.com/openshift-pipelines/pipelines-as-code/pkg/params/clients"
 	"github.com/openshift-pipelines/pipelines-as-code/pkg/params/info"
+	giteaprovider "github.com/openshift-pipelines/pipelines-as-code/pkg/provider/gitea"
 	testclient "github.com/openshift-pipelines/pipelines-as-code/pkg/test/clients"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -102,8 +103,9 @@ func TestWebhookAdd(t *testing.T) {
 			},
 		},
 		Data: map[string]string{
-			"version":        "devel",
-			"controller-url": "https://hook.pipelinesascode.com/WKR2cP3ug5K6A92T",
+			"version":         "devel",
+			"controller-url":  "https://hook.pipelinesascode.com/WKR2cP3ug5K6A92T",
+			"controller-urls": "internal=http://pipelines-as-code-controller.pipelines-as-code:8080",
 		},
 	}
 
@@ -115,6 +117,7 @@ func TestWebhookAdd(t *testing.T) {
 		secrets      []*corev1.Secret
 		configMaps   []*corev1.ConfigMap
 		repoName     string
+		listener     string
 		opts         *cli.PacCliOpts
 		wantErr      bool
 		wantMsg      string
@@ -138,6 +141,41 @@ func TestWebhookAdd(t *testing.T) {
 		configMaps:   []*corev1.ConfigMap{configMap},
 		wantErr:      true, // returning error while creating webhook because it requires actual personal access token in order to connect github
 		wantMsg:      "✓ Setting up GitHub Webhook for Repository https://anurl.com/owner/repo\n👀 I have detected a controller url: https://hook.pipelinesascode.com/WKR2cP3ug5K6A92T\nℹ ️You now need to create a GitHub personal access token, please checkout the docs at https://is.gd/KJ1dDH for the required scopes\n",
+	}, {
+		name: "Use webhook add command with another listener of the controller",
+		askStubs: func(as *prompt.AskStubber) {
+			as.StubOne("github")
+			as.StubOne("true")
+			as.StubOne("yes")
+			as.StubOne("c8978ebcd5407637a6da1c8d178654267fd66a3b")
+			as.StubOne(keys.PublicGithubAPIURL)
+		},
+		namespaces:   []*corev1.Namespace{namespace1},
+		repositories: []*v1alpha1.Repository{repo1},
+		repoName:     "repo1",
+		listener:     "internal",
+		opts: &cli.PacCliOpts{
+			Namespace: namespace1.GetName(),
+		},
+		pacNamespace: namespace2.GetName(),
+		configMaps:   []*corev1.ConfigMap{configMap},
+		wantErr:      true,
+		wantMsg:      "✓ Setting up GitHub Webhook for Repository https://anurl.com/owner/repo\n👀 I have detected a controller url: http://pipelines-as-code-controller.pipelines-as-code:8080\nℹ ️You now need to create a GitHub personal access token, please checkout the docs at https://is.gd/KJ1dDH for the required scopes\n",
+	}, {
+		name: "unknown listener of the controller",
+		askStubs: func(as *prompt.AskStubber) {
+			as.StubOne("github")
+		},
+		namespaces:   []*corev1.Namespace{namespace1},
+		repositories: []*v1alpha1.Repository{repo1},
+		repoName:     "repo1",
+		listener:     "public",
+		opts: &cli.PacCliOpts{
+			Namespace: namespace1.GetName(),
+		},
+		pacNamespace: namespace2.GetName(),
+		configMaps:   []*corev1.ConfigMap{configMap},
+		wantErr:      true,
 	}, {
 		name:         "failed to configure webhook when git_provider secret is empty",
 		namespaces:   []*corev1.Namespace{namespace2},
@@ -231,7 +269,7 @@ func TestWebhookAdd(t *testing.T) {
 			}
 			io, out := newIOStream()
 			if err := add(ctx, tt.opts, cs, io,
-				tt.repoName, tt.pacNamespace); (err != nil) != tt.wantErr {
+				tt.repoName, tt.pacNamespace, tt.listener, giteaprovider.TLSOptions{}); (err != nil) != tt.wantErr {
 				t.Errorf("add() error = %v, wantErr %v", err, tt.wantErr)
 			} else {
 				if res := cmp.Diff(out.String(), tt.wantMsg); res != "" {
@@ -0,0 +1,218 @@
+package webhook
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"text/tabwriter"
+
+	"github.com/juju/ansiterm"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/apis/pipelinesascode/keys"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/apis/pipelinesascode/v1alpha1"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/cli"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/cli/prompt"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/cli/webhook"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/cmd/tknpac/completion"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/formatting"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/params"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/pipelineascode"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/provider/github"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/provider/github/app"
+	"github.com/spf13/cobra"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+type deliveriesOptions struct {
+	cli.PacCliOpts
+	apiURL    string
+	hookID    int64
+	limit     int
+	redeliver int64
+}
+
+func webhookDeliveries(run *params.Run, ioStreams *cli.IOStreams) *cobra.Command {
+	opts := &deliveriesOptions{}
+	cmd := &cobra.Command{
+		Use:   "deliveries",
+		Short: "List and redeliver the webhook deliveries of a repository",
+		Long: `List the last webhook deliveries of a repository and their response codes as
+recorded by the Git provider, or redeliver one of them with --redeliver.
+
+The deliveries of the webhook of the repository are listed when the Repository
+has a git_provider secret, otherwise the deliveries of the GitHub App of
+the Pipelines-as-Code installation are listed.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			var (
+				err      error
+				repoName string
+			)
+			opts.Namespace, err = cmd.Flags().GetString(namespaceFlag)
+			if err != nil {
+				return err
+			}
+
+			if len(args) > 0 {
+				repoName = args[0]
+			}
+
+			ctx := cmd.Context()
+			if err := run.Clients.NewClients(ctx, &run.Info); err != nil {
+				return err
+			}
+			return deliveries(ctx, opts, run, ioStreams, repoName)
+		},
+		Annotations: map[string]string{
+			"commandType": "main",
+		},
+		ValidArgsFunction: func(_ *cobra.Command, args []string, _ string) ([]string, cobra.ShellCompDirective) {
+			return completion.BaseCompletion("repositories", args)
+		},
+	}
+
+	cmd.Flags().StringP(
+		namespaceFlag, "n", "", "If present, the namespace scope for this CLI request")
+	cmd.Flags().StringVarP(&opts.apiURL, "github-api-url", "", "", "GitHub API URL, for GitHub Enterprise")
+	cmd.Flags().Int64VarP(&opts.hookID, "hook-id", "", 0, "ID of the webhook when the repository has more than one")
+	cmd.Flags().IntVarP(&opts.limit, "limit", "", 20, "Maximum number of deliveries to list")
+	cmd.Flags().Int64VarP(&opts.redeliver, "redeliver", "", 0, "ID of a delivery to redeliver")
+
+	_ = cmd.RegisterFlagCompletionFunc(namespaceFlag,
+		func(_ *cobra.Command, args []string, _ string) ([]string, cobra.ShellCompDirective) {
+			return completion.BaseCompletion(namespaceFlag, args)
+		},
+	)
+	return cmd
+}
+
+func deliveries(ctx context.Context, opts *deliveriesOptions, run *params.Run, ioStreams *cli.IOStreams, repoName string) error {
+	var (
+		err  error
+		repo *v1alpha1.Repository
+	)
+	if opts.Namespace != "" {
+		run.Info.Kube.Namespace = opts.Namespace
+	}
+	if repoName != "" {
+		repo, err = run.Clients.PipelineAsCode.PipelinesascodeV1alpha1().Repositories(run.Info.Kube.Namespace).Get(ctx,
+			repoName, metav1.GetOptions{})
+		if err != nil {
+			return err
+		}
+	} else {
+		repo, err = prompt.SelectRepo(ctx, run, run.Info.Kube.Namespace)
+		if err != nil {
+			return err
+		}
+	}
+
+	d, err := newDeliveries(ctx, opts, run, repo)
+	if err != nil {
+		return err
+	}
+	return showDeliveries(ctx, opts, ioStreams, d)
+}
+
+// newDeliveries returns the deliveries of the webhook of the repository when
+// it has a git_provider secret, or of the GitHub App otherwise.
+func newDeliveries(ctx context.Context, opts *deliveriesOptions, run *params.Run, repo *v1alpha1.Repository) (webhook.Deliveries, error) {
+	return NewRepositoryDeliveries(ctx, run, repo, opts.apiURL, opts.hookID)
+}
+
+// NewRepositoryDeliveries returns the deliveries of the webhook hookID of the
+// repository when it has a git_provider secret, or of the GitHub App
+// otherwise. The webhook ID is read from the annotation of the Repository
+// when hookID is 0 and apiURL defaults to the URL of its git provider.
+func NewRepositoryDeliveries(ctx context.Context, run *params.Run, repo *v1alpha1.Repository, apiURL string, hookID int64) (webhook.Deliveries, error) {
+	owner, repoName, err := formatting.GetRepoOwnerSplitted(repo.Spec.URL)
+	if err != nil {
+		return nil, err
+	}
+
+	if repo.Spec.GitProvider == nil || repo.Spec.GitProvider.Secret == nil {
+		targetNs, _, err := params.GetInstallLocation(ctx, run)
+		if err != nil {
+			return nil, err
+		}
+		jwtToken, err := app.NewInstallation(nil, run, nil, nil, targetNs).GenerateJWT(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("cannot authenticate as the GitHub App: %w", err)
+		}
+		client, _, _ := github.MakeClient(ctx, apiURL, jwtToken)
+		return webhook.NewGitHubAppDeliveries(ctx, client, owner, repoName)
+	}
+
+	providerName := repo.Spec.GitProvider.Type
+	if providerName == "" {
+		if providerName, err = webhook.GetProviderName(repo.Spec.URL); err != nil {
+			return nil, err
+		}
+	}
+	if providerName != "github" {
+		return nil, fmt.Errorf("webhook deliveries are not supported on %s", providerName)
+	}
+
+	secretKey := repo.Spec.GitProvider.Secret.Key
+	if secretKey == "" {
+		secretKey = pipelineascode.DefaultGitProviderSecretKey
+	}
+	secret, err := run.Clients.Kube.CoreV1().Secrets(repo.Namespace).Get(ctx, repo.Spec.GitProvider.Secret.Name, metav1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+	token, ok := secret.Data[secretKey]
+	if !ok {
+		return nil, fmt.Errorf("cannot find key %s in the secret %s", secretKey, secret.GetName())
+	}
+
+	if id := repo.GetAnnotations()[keys.WebhookID]; hookID == 0 && id != "" {
+		if hookID, err = strconv.ParseInt(id, 10, 64); err != nil {
+			return nil, fmt.Errorf("invalid webhook ID %s in the annotation %s: %w", id, keys.WebhookID, err)
+		}
+	}
+	if apiURL == "" {
+		apiURL = repo.Spec.GitProvider.URL
+	}
+	client, _, _ := github.MakeClient(ctx, apiURL, string(token))
+	return webhook.NewGitHubHookDeliveries(ctx, client, owner, repoName, hookID)
+}
+
+func showDeliveries(ctx context.Context, opts *deliveriesOptions, ioStreams *cli.IOStreams, d webhook.Deliveries) error {
+	cs := ioStreams.ColorScheme()
+	if opts.redeliver != 0 {
+		if err := d.Redeliver(ctx, opts.redeliver); err != nil {
+			return err
+		}
+		fmt.Fprintf(ioStreams.Out, "%s Delivery %d has been redelivered\n", cs.SuccessIcon(), opts.redeliver)
+		return nil
+	}
+
+	list, err := d.List(ctx, opts.limit)
+	if err != nil {
+		return err
+	}
+	if len(list) == 0 {
+		fmt.Fprintln(ioStreams.Out, "No deliveries found")
+		return nil
+	}
+
+	w := ansiterm.NewTabWriter(ioStreams.Out, 0, 5, 3, ' ', tabwriter.TabIndent)
+	fmt.Fprintln(w, "ID\tDELIVERED\tEVENT\tSTATUS\tDURATION\tREDELIVERY")
+	for _, delivery := range list {
+		event := delivery.Event
+		if delivery.Action != "" {
+			event += "." + delivery.Action
+		}
+		status := fmt.Sprintf("%d %s", delivery.StatusCode, delivery.Status)
+		if delivery.StatusCode >= 400 || delivery.StatusCode == 0 {
+			status = cs.Red(status)
+		}
+		fmt.Fprintf(w, "%d\t%s\t%s\t%s\t%s\t%t\n",
+			delivery.ID,
+			delivery.DeliveredAt.Format("2006-01-02 15:04:05"),
+			event,
+			status,
+			delivery.Duration,
+			delivery.Redelivery)
+	}
+	return w.Flush()
+}
@@ -0,0 +1,84 @@
+package webhook
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/apis/pipelinesascode/v1alpha1"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/cli"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/cli/webhook"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/params"
+	"gotest.tools/v3/assert"
+	"gotest.tools/v3/golden"
+	rtesting "knative.dev/pkg/reconciler/testing"
+)
+
+type fakeDeliveries struct {
+	deliveries  []webhook.Delivery
+	redelivered int64
+}
+
+func (f *fakeDeliveries) List(_ context.Context, limit int) ([]webhook.Delivery, error) {
+	return f.deliveries[:min(limit, len(f.deliveries))], nil
+}
+
+func (f *fakeDeliveries) Redeliver(_ context.Context, id int64) error {
+	f.redelivered = id
+	return nil
+}
+
+func TestShowDeliveries(t *testing.T) {
+	delivered := time.Date(2025, time.January, 2, 10, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name            string
+		opts            deliveriesOptions
+		deliveries      []webhook.Delivery
+		wantRedelivered int64
+	}{
+		{
+			name: "list",
+			opts: deliveriesOptions{limit: 20},
+			deliveries: []webhook.Delivery{
+				{ID: 2, DeliveredAt: delivered, Event: "pull_request", Action: "opened", Status: "Invalid HTTP Response: 503", StatusCode: 503, Duration: 500 * time.Millisecond},
+				{ID: 1, DeliveredAt: delivered.Add(-time.Minute), Event: "push", Status: "OK", StatusCode: 202, Duration: 120 * time.Millisecond, Redelivery: true},
+			},
+		},
+		{
+			name: "no deliveries",
+			opts: deliveriesOptions{limit: 20},
+		},
+		{
+			name:            "redeliver",
+			opts:            deliveriesOptions{redeliver: 2},
+			wantRedelivered: 2,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, _ := rtesting.SetupFakeContext(t)
+			io, _, out, _ := cli.IOTest()
+			d := &fakeDeliveries{deliveries: tt.deliveries}
+			assert.NilError(t, showDeliveries(ctx, &tt.opts, io, d))
+			assert.Equal(t, d.redelivered, tt.wantRedelivered)
+			golden.Assert(t, out.String(), strings.ReplaceAll(fmt.Sprintf("%s.golden", t.Name()), "/", "-"))
+		})
+	}
+}
+
+func TestNewDeliveriesUnsupportedProvider(t *testing.T) {
+	ctx, _ := rtesting.SetupFakeContext(t)
+	repo := &v1alpha1.Repository{
+		Spec: v1alpha1.RepositorySpec{
+			URL: "https://gitea.example.com/owner/repo",
+			GitProvider: &v1alpha1.GitProvider{
+				Type:   "gitea",
+				Secret: &v1alpha1.Secret{Name: "secret"},
+			},
+		},
+	}
+	_, err := newDeliveries(ctx, &deliveriesOptions{}, params.New(), repo)
+	assert.Error(t, err, "webhook deliveries are not supported on gitea")
+}
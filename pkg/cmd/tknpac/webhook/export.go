@@ -0,0 +1,156 @@
+package webhook
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/apis/pipelinesascode/keys"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/apis/pipelinesascode/v1alpha1"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/cli"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/cli/info"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/cli/prompt"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/cli/webhook"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/cmd/tknpac/bootstrap"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/cmd/tknpac/completion"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/params"
+	"github.com/spf13/cobra"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+type exportOptions struct {
+	cli.PacCliOpts
+	format        string
+	provider      string
+	controllerURL string
+	pacNamespace  string
+}
+
+func webhookExport(run *params.Run, ioStreams *cli.IOStreams) *cobra.Command {
+	opts := &exportOptions{}
+	cmd := &cobra.Command{
+		Use:   "export",
+		Short: "Export the webhook configuration of a repository for the git provider",
+		Long: `Export the webhook configuration needed on the git provider side for a
+repository as Terraform resources or JSON, to manage the webhooks declaratively
+instead of creating them with "webhook add".
+
+The webhook secret is never exported, the Terraform resources use a variable
+for it and the JSON output has the reference of its Kubernetes secret.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			var (
+				err      error
+				repoName string
+			)
+			opts.Namespace, err = cmd.Flags().GetString(namespaceFlag)
+			if err != nil {
+				return err
+			}
+			if !slices.Contains(webhook.ExportFormats, opts.format) {
+				return fmt.Errorf("invalid format %s, must be one of: %s", opts.format, strings.Join(webhook.ExportFormats, ", "))
+			}
+
+			if len(args) > 0 {
+				repoName = args[0]
+			}
+
+			ctx := cmd.Context()
+			if err := run.Clients.NewClients(ctx, &run.Info); err != nil {
+				return err
+			}
+			return export(ctx, opts, run, ioStreams, repoName)
+		},
+		Annotations: map[string]string{
+			"commandType": "main",
+		},
+		ValidArgsFunction: func(_ *cobra.Command, args []string, _ string) ([]string, cobra.ShellCompDirective) {
+			return completion.BaseCompletion("repositories", args)
+		},
+	}
+
+	cmd.Flags().StringP(
+		namespaceFlag, "n", "", "If present, the namespace scope for this CLI request")
+	cmd.Flags().StringVarP(&opts.format, "format", "", "terraform",
+		fmt.Sprintf("Output format, one of: %s", strings.Join(webhook.ExportFormats, ", ")))
+	cmd.Flags().StringVarP(&opts.provider, "provider", "", "",
+		"Git provider of the repository, one of: github, gitlab, gitea, detected from the Repository URL if not set")
+	cmd.Flags().StringVarP(&opts.controllerURL, "controller-url", "", "",
+		"URL of the Pipelines-as-Code controller, detected from the installation if not set")
+	cmd.Flags().StringVarP(&opts.pacNamespace, "pac-namespace", "", "",
+		"The namespace where pac is installed")
+
+	_ = cmd.RegisterFlagCompletionFunc(namespaceFlag,
+		func(_ *cobra.Command, args []string, _ string) ([]string, cobra.ShellCompDirective) {
+			return completion.BaseCompletion(namespaceFlag, args)
+		},
+	)
+	return cmd
+}
+
+func export(ctx context.Context, opts *exportOptions, run *params.Run, ioStreams *cli.IOStreams, repoName string) error {
+	var (
+		err  error
+		repo *v1alpha1.Repository
+	)
+	if opts.Namespace != "" {
+		run.Info.Kube.Namespace = opts.Namespace
+	}
+	if repoName != "" {
+		repo, err = run.Clients.PipelineAsCode.PipelinesascodeV1alpha1().Repositories(run.Info.Kube.Namespace).Get(ctx,
+			repoName, metav1.GetOptions{})
+		if err != nil {
+			return err
+		}
+	} else {
+		repo, err = prompt.SelectRepo(ctx, run, run.Info.Kube.Namespace)
+		if err != nil {
+			return err
+		}
+	}
+
+	providerName := opts.provider
+	if providerName == "" && repo.Spec.GitProvider != nil {
+		providerName = repo.Spec.GitProvider.Type
+	}
+	if providerName == "" {
+		if providerName, err = webhook.GetProviderName(repo.Spec.URL); err != nil {
+			return err
+		}
+	}
+
+	controllerURL := opts.controllerURL
+	if controllerURL == "" {
+		installed, installationNS, err := bootstrap.DetectPacInstallation(ctx, opts.pacNamespace, run)
+		if !installed {
+			return fmt.Errorf("pipelines as code not installed, set the controller URL with --controller-url")
+		}
+		if err != nil {
+			return err
+		}
+		pacInfo, err := info.GetPACInfo(ctx, run, installationNS)
+		if err != nil {
+			return err
+		}
+		if controllerURL, err = pacInfo.GetControllerURL(repo.GetAnnotations()[keys.WebhookListener]); err != nil {
+			return err
+		}
+	}
+
+	e, err := webhook.NewExport(repo, providerName, controllerURL)
+	if err != nil {
+		return err
+	}
+	var out string
+	switch opts.format {
+	case "json":
+		out, err = e.JSON()
+	default:
+		out, err = e.Terraform()
+	}
+	if err != nil {
+		return err
+	}
+	fmt.Fprint(ioStreams.Out, out)
+	return nil
+}
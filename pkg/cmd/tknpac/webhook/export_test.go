@@ -0,0 +1,75 @@
+package webhook
+
+import (
+	"testing"
+
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/apis/pipelinesascode/v1alpha1"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/params"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/params/clients"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/params/info"
+	testclient "github.com/openshift-pipelines/pipelines-as-code/pkg/test/clients"
+	"gotest.tools/v3/assert"
+	"gotest.tools/v3/assert/cmp"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	rtesting "knative.dev/pkg/reconciler/testing"
+)
+
+func TestWebhookExport(t *testing.T) {
+	repo := &v1alpha1.Repository{
+		ObjectMeta: metav1.ObjectMeta{Name: "repo", Namespace: "ns"},
+		Spec: v1alpha1.RepositorySpec{
+			URL:         "https://code.example.com/owner/repo",
+			GitProvider: &v1alpha1.GitProvider{Type: "gitlab"},
+		},
+	}
+	configMap := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: "pipelines-as-code-info", Namespace: "pac"},
+		Data:       map[string]string{"controller-url": "https://hook.example.com"},
+	}
+	tests := []struct {
+		name         string
+		opts         *exportOptions
+		wantContains []string
+		wantErr      string
+	}{
+		{
+			name:         "terraform with the controller url of the installation",
+			opts:         &exportOptions{format: "terraform", pacNamespace: "pac"},
+			wantContains: []string{`resource "gitlab_project_hook" "owner_repo"`, `url                     = "https://hook.example.com"`},
+		},
+		{
+			name:         "json with the provider and the controller url flags",
+			opts:         &exportOptions{format: "json", provider: "gitea", controllerURL: "https://other.example.com"},
+			wantContains: []string{`"provider": "gitea"`, `"url": "https://other.example.com"`},
+		},
+		{
+			name:    "unsupported provider",
+			opts:    &exportOptions{format: "json", provider: "bitbucket-cloud", controllerURL: "https://other.example.com"},
+			wantErr: "exporting the webhook is not supported on bitbucket-cloud",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, _ := rtesting.SetupFakeContext(t)
+			stdata, _ := testclient.SeedTestData(t, ctx, testclient.Data{
+				Repositories: []*v1alpha1.Repository{repo},
+				ConfigMap:    []*corev1.ConfigMap{configMap},
+			})
+			run := &params.Run{
+				Clients: clients.Clients{PipelineAsCode: stdata.PipelineAsCode, Kube: stdata.Kube},
+				Info:    info.Info{Kube: &info.KubeOpts{Namespace: "ns"}},
+			}
+			io, out := newIOStream()
+			err := export(ctx, tt.opts, run, io, "repo")
+			if tt.wantErr != "" {
+				assert.Error(t, err, tt.wantErr)
+				return
+			}
+			assert.NilError(t, err)
+			for _, want := range tt.wantContains {
+				assert.Assert(t, cmp.Contains(out.String(), want))
+			}
+		})
+	}
+}
@@ -20,5 +20,7 @@ func Root(clients *params.Run, ioStreams *cli.IOStreams) *cobra.Command {
 
 	cmd.AddCommand(webhookAdd(clients, ioStreams))
 	cmd.AddCommand(webhookUpdateToken(clients, ioStreams))
+	cmd.AddCommand(webhookDeliveries(clients, ioStreams))
+	cmd.AddCommand(webhookExport(clients, ioStreams))
 	return cmd
 }
@@ -129,7 +129,7 @@ func TestProcessTemplates(t *testing.T) {
 	}{
 		{
 			name:     "params/basic",
-			expected: map[string]string{"params": "batman"},
+			expected: map[string]string{"params": "batman", "force_push": "false"},
 			repository: &v1alpha1.Repository{
 				Spec: v1alpha1.RepositorySpec{
 					Params: &[]v1alpha1.Params{
@@ -151,6 +151,9 @@ func TestProcessTemplates(t *testing.T) {
 				"repo_url":              "",
 				"revision":              "",
 				"sender":                "",
+				"sender_email":          "",
+				"sender_full_name":      "",
+				"sender_avatar_url":     "",
 				"source_branch":         "",
 				"source_url":            "",
 				"git_tag":               "",
@@ -158,6 +161,11 @@ func TestProcessTemplates(t *testing.T) {
 				"target_namespace":      "",
 				"trigger_comment":       "",
 				"pull_request_labels":   "",
+				"force_push":            "false",
+				"github_app_slug":       "",
+				"ticket_id":             "",
+				"review_state":          "",
+				"reviewer":              "",
 			},
 			repository: &v1alpha1.Repository{
 				Spec: v1alpha1.RepositorySpec{},
@@ -166,7 +174,7 @@ func TestProcessTemplates(t *testing.T) {
 		},
 		{
 			name:     "params/added_from_incoming_webhook_override",
-			expected: map[string]string{"the_best_superhero_is": "you"},
+			expected: map[string]string{"the_best_superhero_is": "you", "force_push": "false"},
 			repository: &v1alpha1.Repository{
 				Spec: v1alpha1.RepositorySpec{
 					Params: &[]v1alpha1.Params{
@@ -181,7 +189,7 @@ func TestProcessTemplates(t *testing.T) {
 		},
 		{
 			name:     "params/from secret",
-			expected: map[string]string{"params": "gone", "target_namespace": ns},
+			expected: map[string]string{"params": "gone", "target_namespace": ns, "force_push": "false"},
 			secretData: map[string]string{
 				"name": "gone",
 			},
@@ -223,7 +231,7 @@ func TestProcessTemplates(t *testing.T) {
 		},
 		{
 			name:     "params/use last params when two values of the same name",
-			expected: map[string]string{"params": "robin"},
+			expected: map[string]string{"params": "robin", "force_push": "false"},
 			repository: &v1alpha1.Repository{
 				Spec: v1alpha1.RepositorySpec{
 					Params: &[]v1alpha1.Params{
@@ -241,7 +249,7 @@ func TestProcessTemplates(t *testing.T) {
 		},
 		{
 			name:     "params/fallback to stdparams",
-			expected: map[string]string{"event_type": "pull_request"},
+			expected: map[string]string{"event_type": "pull_request", "force_push": "false"},
 			event:    &info.Event{EventType: "pull_request"},
 			repository: &v1alpha1.Repository{
 				Spec: v1alpha1.RepositorySpec{
@@ -255,6 +263,7 @@ func TestProcessTemplates(t *testing.T) {
 				"event_type":      "push",
 				"hello":           `"yolo"`,
 				"trigger_comment": triggerCommentArgs,
+				"force_push":      "false",
 			},
 			event: &info.Event{EventType: "pull_request", TriggerComment: triggerCommentArgs},
 			repository: &v1alpha1.Repository{
@@ -271,6 +280,7 @@ func TestProcessTemplates(t *testing.T) {
 				"event_type":      "push",
 				"hello":           `"yolo"`,
 				"trigger_comment": triggerCommentArgs,
+				"force_push":      "false",
 			},
 			event: &info.Event{EventType: "pull_request", TriggerComment: triggerCommentArgs},
 			repository: &v1alpha1.Repository{
@@ -309,7 +319,7 @@ func TestProcessTemplates(t *testing.T) {
 		},
 		{
 			name:               "params/pick value when value and secret set",
-			expected:           map[string]string{"params": "batman"},
+			expected:           map[string]string{"params": "batman", "force_push": "false"},
 			expectedLogSnippet: "repo repo, param name params has a value and secretref, picking value",
 			repository: &v1alpha1.Repository{
 				ObjectMeta: metav1.ObjectMeta{
@@ -331,7 +341,7 @@ func TestProcessTemplates(t *testing.T) {
 		},
 		{
 			name:     "params/filter",
-			expected: map[string]string{"event_type": "pull_request", "params": "batman"},
+			expected: map[string]string{"event_type": "pull_request", "params": "batman", "force_push": "false"},
 			event:    &info.Event{EventType: "pull_request"},
 			repository: &v1alpha1.Repository{
 				Spec: v1alpha1.RepositorySpec{
@@ -347,7 +357,7 @@ func TestProcessTemplates(t *testing.T) {
 		},
 		{
 			name:     "params/filter on body",
-			expected: map[string]string{"params": "batman", "event_type": "pull_request"},
+			expected: map[string]string{"params": "batman", "event_type": "pull_request", "force_push": "false"},
 			event:    &info.Event{EventType: "pull_request", Event: github.PullRequestEvent{Number: github.Ptr(42)}},
 			repository: &v1alpha1.Repository{
 				Spec: v1alpha1.RepositorySpec{
@@ -443,7 +453,7 @@ func TestProcessTemplates(t *testing.T) {
 		},
 		{
 			name:               "params/two filters same name, match first",
-			expected:           map[string]string{"params": "batman1", "event_type": "pull_request"},
+			expected:           map[string]string{"params": "batman1", "event_type": "pull_request", "force_push": "false"},
 			event:              &info.Event{EventType: "pull_request"},
 			expectedLogSnippet: "skipping params name params, filter has already been matched previously",
 			repository: &v1alpha1.Repository{
@@ -466,7 +476,8 @@ func TestProcessTemplates(t *testing.T) {
 		{
 			name: "params/changed files",
 			expected: map[string]string{
-				"all": "all matched",
+				"all":        "all matched",
+				"force_push": "false",
 			},
 			repository: &v1alpha1.Repository{
 				Spec: v1alpha1.RepositorySpec{
@@ -0,0 +1,190 @@
+package customparams
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os/exec"
+	"strings"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// maxPluginResponseSize is the maximum size of the reply of a dynamic
+// variables provider.
+const maxPluginResponseSize = 1 << 20
+
+// pluginRequest is sent to the dynamic variables provider, as the body of a
+// POST request to a URL or on the standard input of an executable.
+type pluginRequest struct {
+	Repository string            `json:"repository"`
+	Namespace  string            `json:"namespace"`
+	Params     map[string]string `json:"params"`
+	Body       json.RawMessage   `json:"body,omitempty"`
+}
+
+type pluginCacheEntry struct {
+	variables map[string]string
+	expires   time.Time
+}
+
+// pluginCache caches the variables of the dynamic variables provider by
+// request, the same event is usually processed for several PipelineRuns.
+type pluginCache struct {
+	mu      sync.Mutex
+	entries map[string]pluginCacheEntry
+}
+
+var variablesCache = &pluginCache{entries: map[string]pluginCacheEntry{}}
+
+func (c *pluginCache) get(key string, now time.Time) (map[string]string, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	entry, ok := c.entries[key]
+	if !ok || now.After(entry.expires) {
+		return nil, false
+	}
+	return entry.variables, true
+}
+
+func (c *pluginCache) set(key string, variables map[string]string, expires time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for k, entry := range c.entries {
+		if time.Now().After(entry.expires) {
+			delete(c.entries, k)
+		}
+	}
+	c.entries[key] = pluginCacheEntry{variables: variables, expires: expires}
+}
+
+// getPluginParams returns the variables computed by the dynamic variables
+// provider of the Pipelines-as-Code configuration from the standard params
+// and the payload of the event, nil when there is no provider.
+func (p *CustomParams) getPluginParams(ctx context.Context, stdParams map[string]string) (map[string]string, error) {
+	if p.run == nil || p.run.Info.Pac == nil {
+		return nil, nil
+	}
+	pacOpts := p.run.Info.GetPacOpts()
+	pluginProvider := pacOpts.DynamicVariablesProvider
+	if pluginProvider == "" {
+		return nil, nil
+	}
+
+	req := pluginRequest{
+		Repository: p.repo.GetName(),
+		Namespace:  p.repo.GetNamespace(),
+		Params:     stdParams,
+	}
+	if p.event.Request != nil && json.Valid(p.event.Request.Payload) {
+		req.Body = p.event.Request.Payload
+	}
+	data, err := json.Marshal(req)
+	if err != nil {
+		return nil, err
+	}
+	sum := sha256.Sum256(append([]byte(pluginProvider+"\n"), data...))
+	key := hex.EncodeToString(sum[:])
+	if variables, ok := variablesCache.get(key, time.Now()); ok {
+		return variables, nil
+	}
+
+	timeout, err := time.ParseDuration(pacOpts.DynamicVariablesProviderTimeout)
+	if err != nil || timeout <= 0 {
+		timeout = 5 * time.Second
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	var reply []byte
+	if strings.HasPrefix(pluginProvider, "http://") || strings.HasPrefix(pluginProvider, "https://") {
+		reply, err = p.callPluginURL(ctx, pluginProvider, data)
+	} else {
+		reply, err = callPluginExecutable(ctx, pluginProvider, data)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("dynamic variables provider %s has failed: %w", pluginProvider, err)
+	}
+	variables := map[string]string{}
+	if err := json.Unmarshal(reply, &variables); err != nil {
+		return nil, fmt.Errorf("dynamic variables provider %s has not replied with a JSON object of strings: %w", pluginProvider, err)
+	}
+
+	if ttl, err := time.ParseDuration(pacOpts.DynamicVariablesProviderCacheTTL); err == nil && ttl > 0 {
+		variablesCache.set(key, variables, time.Now().Add(ttl))
+	}
+	return variables, nil
+}
+
+func (p *CustomParams) callPluginURL(ctx context.Context, url string, data []byte) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := p.run.Clients.HTTP.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return nil, fmt.Errorf("status code %d", resp.StatusCode)
+	}
+	return io.ReadAll(io.LimitReader(resp.Body, maxPluginResponseSize))
+}
+
+func callPluginExecutable(ctx context.Context, path string, data []byte) ([]byte, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	var stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, path)
+	cmd.Stdin = bytes.NewReader(data)
+	cmd.Stderr = &stderr
+	// the output of the processes started by the executable is not waited
+	// for once it is killed
+	cmd.WaitDelay = time.Second
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return nil, err
+	}
+	if err := cmd.Start(); err != nil {
+		return nil, err
+	}
+	reply, readErr := io.ReadAll(io.LimitReader(stdout, maxPluginResponseSize+1))
+	if len(reply) > maxPluginResponseSize {
+		// the executable is killed instead of reading the rest of its reply
+		cancel()
+		_ = cmd.Wait()
+		return nil, fmt.Errorf("reply is larger than %d bytes", maxPluginResponseSize)
+	}
+	if err := cmd.Wait(); err != nil {
+		return nil, fmt.Errorf("%w: %s", err, strings.TrimSpace(stderr.String()))
+	}
+	if readErr != nil {
+		return nil, readErr
+	}
+	return reply, nil
+}
+
+// addPluginParams adds the variables of the dynamic variables provider to the
+// standard params, without overriding them. The run carries on without the
+// variables when the provider fails.
+func (p *CustomParams) addPluginParams(ctx context.Context, stdParams map[string]string) {
+	variables, err := p.getPluginParams(ctx, stdParams)
+	if err != nil {
+		p.eventEmitter.EmitMessage(p.repo, zap.WarnLevel, "DynamicVariablesProviderError", err.Error())
+		return
+	}
+	for k, v := range variables {
+		if _, ok := stdParams[k]; !ok {
+			stdParams[k] = v
+		}
+	}
+}
@@ -0,0 +1,157 @@
+package customparams
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/apis/pipelinesascode/v1alpha1"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/events"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/params"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/params/clients"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/params/info"
+	testclient "github.com/openshift-pipelines/pipelines-as-code/pkg/test/clients"
+	"go.uber.org/zap"
+	zapobserver "go.uber.org/zap/zaptest/observer"
+	"gotest.tools/v3/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	rtesting "knative.dev/pkg/reconciler/testing"
+)
+
+func TestAddPluginParams(t *testing.T) {
+	calls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		req := pluginRequest{}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		switch r.URL.Path {
+		case "/fail":
+			w.WriteHeader(http.StatusInternalServerError)
+		case "/invalid":
+			fmt.Fprint(w, `["not", "an", "object"]`)
+		default:
+			fmt.Fprintf(w, `{"environment": "staging", "revision": "overridden", "caller": "%s/%s@%s", "action": %q}`,
+				req.Namespace, req.Repository, req.Params["revision"], string(req.Body))
+		}
+	}))
+	defer server.Close()
+
+	script := filepath.Join(t.TempDir(), "provider.sh")
+	assert.NilError(t, os.WriteFile(script, []byte("#!/bin/sh\ncat >/dev/null\necho '{\"environment\": \"production\"}'\n"), 0o700))
+	endless := filepath.Join(t.TempDir(), "endless.sh")
+	assert.NilError(t, os.WriteFile(endless, []byte("#!/bin/sh\ncat >/dev/null\nyes\n"), 0o700))
+
+	tests := []struct {
+		name      string
+		provider  string
+		cacheTTL  string
+		runs      int
+		want      map[string]string
+		wantCalls int
+		wantLog   string
+	}{
+		{
+			name: "no provider",
+			want: map[string]string{"revision": "123"},
+		},
+		{
+			name:     "url",
+			provider: server.URL + "/vars",
+			want: map[string]string{
+				"revision":    "123",
+				"environment": "staging",
+				"caller":      "ns/repo@123",
+				"action":      `{"action":"opened"}`,
+			},
+			wantCalls: 1,
+		},
+		{
+			name:     "cached",
+			provider: server.URL + "/cached",
+			cacheTTL: "5m",
+			runs:     3,
+			want: map[string]string{
+				"revision":    "123",
+				"environment": "staging",
+				"caller":      "ns/repo@123",
+				"action":      `{"action":"opened"}`,
+			},
+			wantCalls: 1,
+		},
+		{
+			name:      "not cached",
+			provider:  server.URL + "/notcached",
+			cacheTTL:  "0s",
+			runs:      2,
+			want:      map[string]string{"revision": "123", "environment": "staging", "caller": "ns/repo@123", "action": `{"action":"opened"}`},
+			wantCalls: 2,
+		},
+		{
+			name:      "url failing",
+			provider:  server.URL + "/fail",
+			want:      map[string]string{"revision": "123"},
+			wantCalls: 1,
+			wantLog:   fmt.Sprintf("dynamic variables provider %s/fail has failed: status code 500", server.URL),
+		},
+		{
+			name:      "invalid reply",
+			provider:  server.URL + "/invalid",
+			want:      map[string]string{"revision": "123"},
+			wantCalls: 1,
+			wantLog:   fmt.Sprintf("dynamic variables provider %s/invalid has not replied with a JSON object of strings", server.URL),
+		},
+		{
+			name:     "executable",
+			provider: script,
+			want:     map[string]string{"revision": "123", "environment": "production"},
+		},
+		{
+			name:     "executable reply too large",
+			provider: endless,
+			want:     map[string]string{"revision": "123"},
+			wantLog:  fmt.Sprintf("dynamic variables provider %s has failed: reply is larger than %d bytes", endless, maxPluginResponseSize),
+		},
+		{
+			name:     "executable not found",
+			provider: "/nonexistent/provider",
+			want:     map[string]string{"revision": "123"},
+			wantLog:  "dynamic variables provider /nonexistent/provider has failed",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			calls = 0
+			ctx, _ := rtesting.SetupFakeContext(t)
+			observer, logs := zapobserver.New(zap.InfoLevel)
+			logger := zap.New(observer).Sugar()
+			stdata, _ := testclient.SeedTestData(t, ctx, testclient.Data{})
+
+			run := &params.Run{Clients: clients.Clients{HTTP: *server.Client()}, Info: info.NewInfo()}
+			run.Info.Pac.DynamicVariablesProvider = tt.provider
+			run.Info.Pac.DynamicVariablesProviderCacheTTL = tt.cacheTTL
+			repo := &v1alpha1.Repository{ObjectMeta: metav1.ObjectMeta{Name: "repo", Namespace: "ns"}}
+			event := &info.Event{Request: &info.Request{Payload: []byte(`{"action":"opened"}`)}}
+			p := NewCustomParams(event, repo, run, nil, events.NewEventEmitter(stdata.Kube, logger), nil)
+
+			runs := max(tt.runs, 1)
+			for range runs {
+				stdParams := map[string]string{"revision": "123"}
+				p.addPluginParams(ctx, stdParams)
+				assert.DeepEqual(t, stdParams, tt.want)
+			}
+			if tt.wantLog != "" {
+				assert.Equal(t, logs.FilterMessageSnippet(tt.wantLog).Len(), 1, logs.All())
+			}
+			if tt.provider != script && tt.provider != endless {
+				assert.Equal(t, calls, tt.wantCalls)
+			}
+		})
+	}
+}
@@ -0,0 +1,72 @@
+package customparams
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"sync"
+	"time"
+
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/provider"
+	"go.uber.org/zap"
+)
+
+// senderProfileTTL is how long the profile of a sender is kept, to not query
+// the provider on every event of the same user.
+const senderProfileTTL = time.Hour
+
+type senderProfileEntry struct {
+	profile provider.UserProfile
+	expires time.Time
+}
+
+type senderProfileCache struct {
+	mu      sync.Mutex
+	entries map[string]senderProfileEntry
+}
+
+var senderProfiles = &senderProfileCache{entries: map[string]senderProfileEntry{}}
+
+func (c *senderProfileCache) get(key string, now time.Time) (provider.UserProfile, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	entry, ok := c.entries[key]
+	if !ok || now.After(entry.expires) {
+		return provider.UserProfile{}, false
+	}
+	return entry.profile, true
+}
+
+func (c *senderProfileCache) set(key string, profile provider.UserProfile, now time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for k, entry := range c.entries {
+		if now.After(entry.expires) {
+			delete(c.entries, k)
+		}
+	}
+	c.entries[key] = senderProfileEntry{profile: profile, expires: now.Add(senderProfileTTL)}
+}
+
+// getSenderProfile returns the profile of the sender of the event from the
+// provider user API, an empty one when it cannot be fetched.
+func (p *CustomParams) getSenderProfile(ctx context.Context) provider.UserProfile {
+	if p.vcx == nil || p.event.Sender == "" {
+		return provider.UserProfile{}
+	}
+	host := ""
+	if u, err := url.Parse(p.event.URL); err == nil {
+		host = u.Host
+	}
+	key := fmt.Sprintf("%s\n%s\n%s", p.vcx.GetConfig().Name, host, p.event.Sender)
+	if profile, ok := senderProfiles.get(key, time.Now()); ok {
+		return profile
+	}
+	profile, err := p.vcx.GetUserProfile(ctx, p.event)
+	if err != nil {
+		p.eventEmitter.EmitMessage(p.repo, zap.WarnLevel, "ParamsError", fmt.Sprintf("error getting the profile of the sender: %s", err.Error()))
+		return provider.UserProfile{}
+	}
+	senderProfiles.set(key, *profile, time.Now())
+	return *profile
+}
@@ -0,0 +1,76 @@
+package customparams
+
+import (
+	"testing"
+
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/apis/pipelinesascode/v1alpha1"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/events"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/params/info"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/provider"
+	testclient "github.com/openshift-pipelines/pipelines-as-code/pkg/test/clients"
+	testprovider "github.com/openshift-pipelines/pipelines-as-code/pkg/test/provider"
+	"go.uber.org/zap"
+	zapobserver "go.uber.org/zap/zaptest/observer"
+	"gotest.tools/v3/assert"
+	rtesting "knative.dev/pkg/reconciler/testing"
+)
+
+func TestGetSenderProfile(t *testing.T) {
+	profile := &provider.UserProfile{
+		Email:     "jane@example.com",
+		FullName:  "Jane Doe",
+		AvatarURL: "https://example.com/jane.png",
+	}
+	tests := []struct {
+		name       string
+		sender     string
+		vcx        *testprovider.TestProviderImp
+		cached     *provider.UserProfile
+		want       provider.UserProfile
+		wantLogMsg string
+	}{
+		{
+			name:   "profile from the provider",
+			sender: "jane",
+			vcx:    &testprovider.TestProviderImp{UserProfile: profile},
+			want:   *profile,
+		},
+		{
+			name:   "profile from the cache",
+			sender: "cached",
+			vcx:    &testprovider.TestProviderImp{UserProfileErroring: true},
+			cached: profile,
+			want:   *profile,
+		},
+		{
+			name:       "provider error",
+			sender:     "unknown",
+			vcx:        &testprovider.TestProviderImp{UserProfileErroring: true},
+			wantLogMsg: "error getting the profile of the sender: cannot get the user unknown",
+		},
+		{
+			name: "no sender",
+			vcx:  &testprovider.TestProviderImp{UserProfile: profile},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			observer, tlog := zapobserver.New(zap.InfoLevel)
+			logger := zap.New(observer).Sugar()
+			ctx, _ := rtesting.SetupFakeContext(t)
+			stdata, _ := testclient.SeedTestData(t, ctx, testclient.Data{})
+			event := &info.Event{Sender: tt.sender, URL: "https://forge.example.com/owner/repo"}
+			p := NewCustomParams(event, &v1alpha1.Repository{}, nil, nil, events.NewEventEmitter(stdata.Kube, logger), tt.vcx)
+			if tt.cached != nil {
+				p.vcx = &testprovider.TestProviderImp{UserProfile: tt.cached}
+				p.getSenderProfile(ctx)
+				p.vcx = tt.vcx
+			}
+
+			assert.Equal(t, p.getSenderProfile(ctx), tt.want)
+			if tt.wantLogMsg != "" {
+				assert.Equal(t, tlog.FilterMessage(tt.wantLogMsg).Len(), 1)
+			}
+		})
+	}
+}
@@ -3,10 +3,12 @@ package customparams
 import (
 	"context"
 	"fmt"
+	"strconv"
 	"strings"
 
 	"github.com/openshift-pipelines/pipelines-as-code/pkg/changedfiles"
 	"github.com/openshift-pipelines/pipelines-as-code/pkg/formatting"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/issuetracker"
 	"github.com/openshift-pipelines/pipelines-as-code/pkg/opscomments"
 	"go.uber.org/zap"
 )
@@ -33,9 +35,15 @@ func (p *CustomParams) makeStandardParamsFromEvent(ctx context.Context) (map[str
 		repoURL = p.event.CloneURL
 	}
 	changedFiles := p.getChangedFiles(ctx)
+	senderProfile := p.getSenderProfile(ctx)
 	triggerCommentAsSingleLine := strings.ReplaceAll(strings.ReplaceAll(p.event.TriggerComment, "\r\n", "\\n"), "\n", "\\n")
 	pullRequestLabels := strings.Join(p.event.PullRequestLabel, "\\n")
 
+	ticketID, err := issuetracker.TicketID(p.repo, p.event)
+	if err != nil {
+		p.eventEmitter.EmitMessage(p.repo, zap.WarnLevel, "ParamsError", fmt.Sprintf("error getting the ticket id: %s", err.Error()))
+	}
+
 	gitTag := ""
 	if strings.HasPrefix(p.event.BaseBranch, "refs/tags/") {
 		gitTag = strings.TrimPrefix(p.event.BaseBranch, "refs/tags/")
@@ -51,10 +59,18 @@ func (p *CustomParams) makeStandardParamsFromEvent(ctx context.Context) (map[str
 			"git_tag":             gitTag,
 			"source_url":          p.event.HeadURL,
 			"sender":              strings.ToLower(p.event.Sender),
+			"sender_email":        senderProfile.Email,
+			"sender_full_name":    senderProfile.FullName,
+			"sender_avatar_url":   senderProfile.AvatarURL,
 			"target_namespace":    p.repo.GetNamespace(),
 			"event_type":          opscomments.EventTypeBackwardCompat(p.eventEmitter, p.repo, p.event.EventType),
 			"trigger_comment":     triggerCommentAsSingleLine,
 			"pull_request_labels": pullRequestLabels,
+			"force_push":          strconv.FormatBool(p.event.ForcePush),
+			"github_app_slug":     p.event.GithubAppSlug,
+			"ticket_id":           ticketID,
+			"review_state":        p.event.ReviewState,
+			"reviewer":            strings.ToLower(p.event.Reviewer),
 		}, map[string]any{
 			"all":      changedFiles.All,
 			"added":    changedFiles.Added,
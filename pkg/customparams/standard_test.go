@@ -27,8 +27,10 @@ func TestMakeStandardParamsFromEvent(t *testing.T) {
 				Repository:       "Repo",
 				BaseBranch:       "main",
 				HeadBranch:       "foo",
-				EventType:        "pull_request",
+				EventType:        "pull_request_review",
 				Sender:           "SENDER",
+				ReviewState:      "approved",
+				Reviewer:         "Reviewer",
 				URL:              "https://paris.com",
 				HeadURL:          "https://india.com",
 				TriggerComment:   "\n/test me\nHelp me obiwan kenobi\r\n\r\n\r\nTo test or not to test, is the question?\n\n\n",
@@ -41,19 +43,27 @@ func TestMakeStandardParamsFromEvent(t *testing.T) {
 				},
 			},
 			want: map[string]string{
-				"event_type":          "pull_request",
+				"event_type":          "pull_request_review",
 				"repo_name":           "repo",
 				"repo_owner":          "org",
 				"repo_url":            "https://paris.com",
 				"source_url":          "https://india.com",
 				"revision":            "1234567890",
 				"sender":              "sender",
+				"sender_email":        "",
+				"sender_full_name":    "",
+				"sender_avatar_url":   "",
 				"source_branch":       "foo",
 				"git_tag":             "",
 				"target_branch":       "main",
 				"target_namespace":    "myns",
 				"trigger_comment":     `\n/test me\nHelp me obiwan kenobi\n\n\nTo test or not to test, is the question?\n\n\n`,
 				"pull_request_labels": "bugs\\nenhancements",
+				"force_push":          "false",
+				"github_app_slug":     "",
+				"ticket_id":           "",
+				"review_state":        "approved",
+				"reviewer":            "reviewer",
 			},
 			wantVCX: &testprovider.TestProviderImp{
 				WantAllChangedFiles: []string{"added.go", "deleted.go", "modified.go", "renamed.go"},
@@ -64,7 +74,7 @@ func TestMakeStandardParamsFromEvent(t *testing.T) {
 			},
 		},
 		{
-			name: "event with different clone URL",
+			name: "event with different clone URL and force push",
 			event: &info.Event{
 				SHA:              "1234567890",
 				Organization:     "Org",
@@ -78,12 +88,18 @@ func TestMakeStandardParamsFromEvent(t *testing.T) {
 				TriggerComment:   "/test me\nHelp me obiwan kenobi",
 				PullRequestLabel: []string{"bugs", "enhancements"},
 				CloneURL:         "https://blahblah",
+				ForcePush:        true,
+				GithubAppSlug:    "pipelines-as-code",
+				SHATitle:         "PROJ-42: fix the build",
 			},
 			repo: &v1alpha1.Repository{
 				ObjectMeta: metav1.ObjectMeta{
 					Name:      "myname",
 					Namespace: "myns",
 				},
+				Spec: v1alpha1.RepositorySpec{
+					Settings: &v1alpha1.Settings{IssueTracker: &v1alpha1.IssueTracker{}},
+				},
 			},
 			want: map[string]string{
 				"event_type":          "pull_request",
@@ -93,12 +109,20 @@ func TestMakeStandardParamsFromEvent(t *testing.T) {
 				"source_url":          "https://india.com",
 				"revision":            "1234567890",
 				"sender":              "sender",
+				"sender_email":        "",
+				"sender_full_name":    "",
+				"sender_avatar_url":   "",
 				"source_branch":       "foo",
 				"git_tag":             "",
 				"target_branch":       "main",
 				"target_namespace":    "myns",
 				"trigger_comment":     "/test me\\nHelp me obiwan kenobi",
 				"pull_request_labels": "bugs\\nenhancements",
+				"force_push":          "true",
+				"github_app_slug":     "pipelines-as-code",
+				"ticket_id":           "PROJ-42",
+				"review_state":        "",
+				"reviewer":            "",
 			},
 			wantVCX: &testprovider.TestProviderImp{
 				WantAllChangedFiles: []string{"added.go", "deleted.go", "modified.go", "renamed.go"},
@@ -137,12 +161,20 @@ func TestMakeStandardParamsFromEvent(t *testing.T) {
 				"source_url":          "https://india.com",
 				"revision":            "1234567890",
 				"sender":              "sender",
+				"sender_email":        "",
+				"sender_full_name":    "",
+				"sender_avatar_url":   "",
 				"source_branch":       "refs/tags/v1.0",
 				"git_tag":             "v1.0",
 				"target_branch":       "refs/tags/v1.0",
 				"target_namespace":    "myns",
 				"trigger_comment":     "/test me\\nHelp me obiwan kenobi",
 				"pull_request_labels": "",
+				"force_push":          "false",
+				"github_app_slug":     "",
+				"ticket_id":           "",
+				"review_state":        "",
+				"reviewer":            "",
 			},
 			wantVCX: &testprovider.TestProviderImp{
 				WantAllChangedFiles: []string{"added.go", "deleted.go", "modified.go", "renamed.go"},
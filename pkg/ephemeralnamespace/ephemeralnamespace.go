@@ -0,0 +1,143 @@
+package ephemeralnamespace
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/apis/pipelinesascode"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/apis/pipelinesascode/keys"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/apis/pipelinesascode/v1alpha1"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/formatting"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/params/info"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/params/triggertype"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/random"
+	"go.uber.org/zap"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+const (
+	// DefaultTTL is how long a namespace is kept once its PipelineRun has
+	// completed when the Repository doesn't set it.
+	DefaultTTL = time.Hour
+
+	// the random suffix makes the name unique, the prefix is cut to keep the
+	// name within the 63 characters of a namespace name
+	suffixLen    = 6
+	maxPrefixLen = 63 - suffixLen - 1
+
+	managedByLabel = "app.kubernetes.io/managed-by"
+)
+
+// Selector selects the ephemeral namespaces created by Pipelines-as-Code.
+var Selector = fmt.Sprintf("%s=true,%s=%s", keys.EphemeralNamespace, managedByLabel, pipelinesascode.GroupName)
+
+// Enabled tells whether the PipelineRuns of repo for event run in an
+// ephemeral namespace.
+func Enabled(repo *v1alpha1.Repository, event *info.Event) bool {
+	if repo.Spec.Settings == nil || repo.Spec.Settings.EphemeralNamespace == nil {
+		return false
+	}
+	if repo.Spec.Settings.EphemeralNamespace.ForkPullRequestsOnly {
+		return event.TriggerTarget == triggertype.PullRequest && event.HeadURL != event.BaseURL
+	}
+	return true
+}
+
+// TTL returns how long the ephemeral namespaces of repo are kept once their
+// PipelineRun has completed.
+func TTL(repo *v1alpha1.Repository) (time.Duration, error) {
+	if repo.Spec.Settings == nil || repo.Spec.Settings.EphemeralNamespace == nil || repo.Spec.Settings.EphemeralNamespace.TTL == "" {
+		return DefaultTTL, nil
+	}
+	ttl, err := time.ParseDuration(repo.Spec.Settings.EphemeralNamespace.TTL)
+	if err != nil || ttl < 0 {
+		return 0, fmt.Errorf("invalid ephemeral_namespace ttl %q of repository %s", repo.Spec.Settings.EphemeralNamespace.TTL, repo.GetName())
+	}
+	return ttl, nil
+}
+
+// Create creates a namespace for a PipelineRun of repo and copies the secrets
+// of its ephemeral_namespace settings into it, the namespace is deleted when
+// any of this fails.
+func Create(ctx context.Context, logger *zap.SugaredLogger, kube kubernetes.Interface, repo *v1alpha1.Repository) (string, error) {
+	ttl, err := TTL(repo)
+	if err != nil {
+		return "", err
+	}
+
+	prefix := repo.GetNamespace()
+	if len(prefix) > maxPrefixLen {
+		prefix = strings.TrimSuffix(prefix[:maxPrefixLen], "-")
+	}
+	ns := &corev1.Namespace{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: fmt.Sprintf("%s-%s", prefix, strings.ToLower(random.AlphaString(suffixLen))),
+			Labels: map[string]string{
+				managedByLabel:          pipelinesascode.GroupName,
+				keys.EphemeralNamespace: "true",
+				keys.Repository:         formatting.CleanValueKubernetes(repo.GetName()),
+			},
+			Annotations: map[string]string{
+				keys.RepositoryNamespace:   repo.GetNamespace(),
+				keys.EphemeralNamespaceTTL: ttl.String(),
+			},
+		},
+	}
+	ns, err = kube.CoreV1().Namespaces().Create(ctx, ns, metav1.CreateOptions{})
+	if err != nil {
+		return "", fmt.Errorf("cannot create the ephemeral namespace: %w", err)
+	}
+
+	for _, name := range repo.Spec.Settings.EphemeralNamespace.Secrets {
+		if err := copySecret(ctx, kube, repo.GetNamespace(), ns.GetName(), name); err != nil {
+			if derr := Delete(ctx, kube, ns.GetName()); derr != nil {
+				logger.Errorf("cannot delete the ephemeral namespace %s: %v", ns.GetName(), derr)
+			}
+			return "", err
+		}
+	}
+	return ns.GetName(), nil
+}
+
+func copySecret(ctx context.Context, kube kubernetes.Interface, from, to, name string) error {
+	secret, err := kube.CoreV1().Secrets(from).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return fmt.Errorf("cannot get secret %s to copy to the ephemeral namespace: %w", name, err)
+	}
+	copied := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        secret.GetName(),
+			Namespace:   to,
+			Labels:      secret.GetLabels(),
+			Annotations: secret.GetAnnotations(),
+		},
+		Type: secret.Type,
+		Data: secret.Data,
+	}
+	if _, err := kube.CoreV1().Secrets(to).Create(ctx, copied, metav1.CreateOptions{}); err != nil {
+		return fmt.Errorf("cannot copy secret %s to the ephemeral namespace: %w", name, err)
+	}
+	return nil
+}
+
+// Repository returns the namespace and the name of the Repository an
+// ephemeral namespace has been created for, from its annotation and label.
+func Repository(ns *corev1.Namespace) (string, string, error) {
+	if ns.GetLabels()[managedByLabel] != pipelinesascode.GroupName {
+		return "", "", fmt.Errorf("namespace %s is not managed by %s", ns.GetName(), pipelinesascode.GroupName)
+	}
+	repoNamespace, repoName := ns.GetAnnotations()[keys.RepositoryNamespace], ns.GetLabels()[keys.Repository]
+	if repoNamespace == "" || repoName == "" {
+		return "", "", fmt.Errorf("namespace %s has no %s annotation or %s label", ns.GetName(), keys.RepositoryNamespace, keys.Repository)
+	}
+	return repoNamespace, repoName, nil
+}
+
+// Delete deletes an ephemeral namespace.
+func Delete(ctx context.Context, kube kubernetes.Interface, namespace string) error {
+	return kube.CoreV1().Namespaces().Delete(ctx, namespace, metav1.DeleteOptions{})
+}
@@ -0,0 +1,149 @@
+package ephemeralnamespace
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/apis/pipelinesascode/keys"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/apis/pipelinesascode/v1alpha1"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/params/info"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/params/triggertype"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/test/logger"
+	"gotest.tools/v3/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+	rtesting "knative.dev/pkg/reconciler/testing"
+)
+
+func TestEnabled(t *testing.T) {
+	pullRequest := &info.Event{TriggerTarget: triggertype.PullRequest, BaseURL: "https://forge/org/repo", HeadURL: "https://forge/org/repo"}
+	fork := &info.Event{TriggerTarget: triggertype.PullRequest, BaseURL: "https://forge/org/repo", HeadURL: "https://forge/fork/repo"}
+	tests := []struct {
+		name     string
+		settings *v1alpha1.Settings
+		event    *info.Event
+		want     bool
+	}{
+		{
+			name:  "no settings",
+			event: pullRequest,
+			want:  false,
+		},
+		{
+			name:     "enabled",
+			settings: &v1alpha1.Settings{EphemeralNamespace: &v1alpha1.EphemeralNamespace{}},
+			event:    pullRequest,
+			want:     true,
+		},
+		{
+			name:     "fork pull requests only on a fork",
+			settings: &v1alpha1.Settings{EphemeralNamespace: &v1alpha1.EphemeralNamespace{ForkPullRequestsOnly: true}},
+			event:    fork,
+			want:     true,
+		},
+		{
+			name:     "fork pull requests only on a branch",
+			settings: &v1alpha1.Settings{EphemeralNamespace: &v1alpha1.EphemeralNamespace{ForkPullRequestsOnly: true}},
+			event:    pullRequest,
+			want:     false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &v1alpha1.Repository{Spec: v1alpha1.RepositorySpec{Settings: tt.settings}}
+			assert.Equal(t, Enabled(repo, tt.event), tt.want)
+		})
+	}
+}
+
+func TestTTL(t *testing.T) {
+	tests := []struct {
+		name    string
+		ttl     string
+		want    time.Duration
+		wantErr string
+	}{
+		{name: "default", want: DefaultTTL},
+		{name: "set", ttl: "30m", want: 30 * time.Minute},
+		{name: "invalid", ttl: "soon", wantErr: `invalid ephemeral_namespace ttl "soon" of repository repo`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &v1alpha1.Repository{
+				ObjectMeta: metav1.ObjectMeta{Name: "repo"},
+				Spec: v1alpha1.RepositorySpec{Settings: &v1alpha1.Settings{
+					EphemeralNamespace: &v1alpha1.EphemeralNamespace{TTL: tt.ttl},
+				}},
+			}
+			ttl, err := TTL(repo)
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			assert.NilError(t, err)
+			assert.Equal(t, ttl, tt.want)
+		})
+	}
+}
+
+func TestCreate(t *testing.T) {
+	tests := []struct {
+		name    string
+		secrets []string
+		wantErr string
+	}{
+		{
+			name:    "copy secrets",
+			secrets: []string{"registry"},
+		},
+		{
+			name:    "missing secret",
+			secrets: []string{"missing"},
+			wantErr: "cannot get secret missing to copy to the ephemeral namespace",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, _ := rtesting.SetupFakeContext(t)
+			kube := fake.NewSimpleClientset(&corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Name: "registry", Namespace: "ns"},
+				Type:       corev1.SecretTypeDockerConfigJson,
+				Data:       map[string][]byte{corev1.DockerConfigJsonKey: []byte("{}")},
+			})
+			repo := &v1alpha1.Repository{
+				ObjectMeta: metav1.ObjectMeta{Name: "repo", Namespace: "ns"},
+				Spec: v1alpha1.RepositorySpec{Settings: &v1alpha1.Settings{
+					EphemeralNamespace: &v1alpha1.EphemeralNamespace{Secrets: tt.secrets, TTL: "10m"},
+				}},
+			}
+
+			logger, _ := logger.GetLogger()
+			namespace, err := Create(ctx, logger, kube, repo)
+			namespaces, listErr := kube.CoreV1().Namespaces().List(ctx, metav1.ListOptions{})
+			assert.NilError(t, listErr)
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, err, tt.wantErr)
+				assert.Equal(t, len(namespaces.Items), 0)
+				return
+			}
+			assert.NilError(t, err)
+			assert.Assert(t, strings.HasPrefix(namespace, "ns-"))
+			assert.Equal(t, len(namespaces.Items), 1)
+			ns := namespaces.Items[0]
+			assert.Equal(t, ns.GetLabels()[keys.EphemeralNamespace], "true")
+			assert.Equal(t, ns.GetAnnotations()[keys.RepositoryNamespace], "ns")
+			assert.Equal(t, ns.GetAnnotations()[keys.EphemeralNamespaceTTL], "10m0s")
+			repoNamespace, repoName, err := Repository(&ns)
+			assert.NilError(t, err)
+			assert.Equal(t, repoNamespace, "ns")
+			assert.Equal(t, repoName, "repo")
+
+			secret, err := kube.CoreV1().Secrets(namespace).Get(ctx, "registry", metav1.GetOptions{})
+			assert.NilError(t, err)
+			assert.Equal(t, secret.Type, corev1.SecretTypeDockerConfigJson)
+			assert.Equal(t, string(secret.Data[corev1.DockerConfigJsonKey]), "{}")
+		})
+	}
+}
@@ -4,6 +4,8 @@ type PacYamlValidations struct {
 	Name   string
 	Err    error
 	Schema string
+	// Position locates the error in the .tekton directory when it is known.
+	Position *Position
 }
 
 const GenericBadYAMLValidation = "Generic bad YAML Validation"
@@ -0,0 +1,89 @@
+package errors
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// snippetContext is the number of lines shown before the line of the error
+// in a snippet.
+const snippetContext = 2
+
+var yamlLineRe = regexp.MustCompile(`line (\d+):`)
+
+// Position locates an error in a file of the .tekton directory, Line and
+// Column start at 1.
+type Position struct {
+	File    string
+	Line    int
+	Column  int
+	Snippet string
+}
+
+func (p *Position) String() string {
+	if p.Column == 0 {
+		return fmt.Sprintf("%s:%d", p.File, p.Line)
+	}
+	return fmt.Sprintf("%s:%d:%d", p.File, p.Line, p.Column)
+}
+
+// YamlError is the error of a yaml file of the .tekton directory which cannot
+// be parsed.
+type YamlError struct {
+	Position *Position
+	Err      error
+}
+
+func (e *YamlError) Error() string {
+	return fmt.Sprintf("error unmarshalling yaml file %s: %s", e.Position.File, e.Err.Error())
+}
+
+func (e *YamlError) Unwrap() error {
+	return e.Err
+}
+
+// NewYamlError returns the parsing error err of the content of file with the
+// position of the line reported by the yaml parser.
+func NewYamlError(file, content string, err error) *YamlError {
+	position := &Position{File: file}
+	if line := YamlErrorLine(err); line > 0 {
+		position = NewPosition(file, content, 1, line)
+	}
+	return &YamlError{Position: position, Err: err}
+}
+
+// YamlErrorLine returns the line reported in an error of the yaml parser or 0
+// when it doesn't report any.
+func YamlErrorLine(err error) int {
+	matches := yamlLineRe.FindStringSubmatch(err.Error())
+	if len(matches) != 2 {
+		return 0
+	}
+	line, _ := strconv.Atoi(matches[1])
+	return line
+}
+
+// NewPosition returns the position of line in the content of file, with a
+// snippet of the lines leading to it. content may be an extract of file
+// starting at firstLine. The yaml parser only reports lines, the column is the
+// first non blank character of the line and is marked with a caret in the
+// snippet.
+func NewPosition(file, content string, firstLine, line int) *Position {
+	lines := strings.Split(content, "\n")
+	index := line - firstLine
+	if index < 0 || index >= len(lines) {
+		return &Position{File: file, Line: line}
+	}
+	text := lines[index]
+	column := len(text) - len(strings.TrimLeft(text, " \t")) + 1
+
+	width := len(strconv.Itoa(line))
+	var snippet strings.Builder
+	for i := max(index-snippetContext, 0); i <= index; i++ {
+		fmt.Fprintf(&snippet, "%*d | %s\n", width, firstLine+i, lines[i])
+	}
+	fmt.Fprintf(&snippet, "%*s | %s^", width, "", strings.Repeat(" ", column-1))
+	return &Position{File: file, Line: line, Column: column, Snippet: snippet.String()}
+}
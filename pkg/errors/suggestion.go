@@ -0,0 +1,15 @@
+package errors
+
+// Suggestion is a correction proposed for a likely mistake in a PipelineRun of
+// the .tekton directory, ie: a misspelled annotation or event name.
+type Suggestion struct {
+	// Name is the name of the PipelineRun.
+	Name    string
+	Message string
+	// Position locates the mistake when it is known.
+	Position *Position
+	// Text is the line at Position and Fix the same line corrected, they are
+	// empty when the mistake couldn't be located.
+	Text string
+	Fix  string
+}
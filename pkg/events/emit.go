@@ -2,38 +2,114 @@ package events
 
 import (
 	"context"
+	"fmt"
+	"time"
 
 	"github.com/openshift-pipelines/pipelines-as-code/pkg/apis/pipelinesascode"
 	"github.com/openshift-pipelines/pipelines-as-code/pkg/apis/pipelinesascode/keys"
 	"github.com/openshift-pipelines/pipelines-as-code/pkg/apis/pipelinesascode/v1alpha1"
 	"github.com/openshift-pipelines/pipelines-as-code/pkg/formatting"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/generated/clientset/versioned"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/metrics"
 	"go.uber.org/zap"
 	"go.uber.org/zap/zapcore"
 	v1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/client-go/tools/record/util"
+	"k8s.io/utils/clock"
 )
 
+const (
+	// spamBurst is the number of events of a Repository emitted before
+	// dropping them.
+	spamBurst = 100
+	// spamQPS is the rate the events of a Repository are allowed again once
+	// spamBurst is reached, one every ten seconds.
+	spamQPS = 1. / 10.
+)
+
+// correlator is shared by the emitters, they are created for each event and
+// the similar events of a Repository have to be counted across them.
+var correlator = newCorrelator(clock.RealClock{})
+
+// newCorrelator returns the correlator counting the identical events in one
+// event, combining more than ten events of the same reason in ten minutes and
+// dropping the events of a Repository above spamBurst.
+func newCorrelator(clock clock.PassiveClock) *record.EventCorrelator {
+	return record.NewEventCorrelatorWithOptions(record.CorrelatorOptions{
+		BurstSize: spamBurst,
+		QPS:       spamQPS,
+		Clock:     clock,
+	})
+}
+
 func NewEventEmitter(client kubernetes.Interface, logger *zap.SugaredLogger) *EventEmitter {
 	return &EventEmitter{
-		client: client,
-		logger: logger,
+		client:     client,
+		logger:     logger,
+		correlator: correlator,
 	}
 }
 
 type EventEmitter struct {
-	client kubernetes.Interface
-	logger *zap.SugaredLogger
+	client     kubernetes.Interface
+	logger     *zap.SugaredLogger
+	correlator *record.EventCorrelator
+	enabled    func() bool
+	pacClient  versioned.Interface
 }
 
 func (e *EventEmitter) SetLogger(logger *zap.SugaredLogger) {
 	e.logger = logger
 }
 
+// SetEnabled sets the function telling if the Kubernetes events of the
+// Repositories are emitted, it is called for each event to follow the changes
+// of the settings. The messages are still logged when they are disabled.
+func (e *EventEmitter) SetEnabled(enabled func() bool) {
+	e.enabled = enabled
+}
+
+// SetRepositoryClient sets the client EmitFailure records the last failure of
+// the Repositories with, their status is not updated without it.
+func (e *EventEmitter) SetRepositoryClient(pacClient versioned.Interface) {
+	e.pacClient = pacClient
+}
+
 func (e *EventEmitter) EmitMessage(repo *v1alpha1.Repository, loggerLevel zapcore.Level, reason, message string) {
-	if repo != nil {
+	e.emit(repo, loggerLevel, "", reason, message)
+}
+
+// EmitFailure emits the message of a failure of the Repository like
+// EmitMessage, with the failure reason as a label of the event. The failure
+// is counted in the metrics and recorded as the last failure of the status of
+// the Repository.
+func (e *EventEmitter) EmitFailure(repo *v1alpha1.Repository, loggerLevel zapcore.Level, failure v1alpha1.FailureReason, reason, message string) {
+	e.emit(repo, loggerLevel, failure, reason, message)
+	if repo == nil {
+		return
+	}
+	if recorder, err := metrics.NewRecorder(); err == nil {
+		_ = recorder.CountFailure(repo.GetNamespace(), repo.GetName(), string(failure))
+	}
+	if e.pacClient == nil {
+		return
+	}
+	if err := e.setLastFailure(repo, failure, message); err != nil && e.logger != nil {
+		e.logger.Infof("Cannot record the last failure of the repository %s/%s: %s", repo.GetNamespace(), repo.GetName(), err.Error())
+	}
+}
+
+func (e *EventEmitter) emit(repo *v1alpha1.Repository, loggerLevel zapcore.Level, failure v1alpha1.FailureReason, reason, message string) {
+	if repo != nil && (e.enabled == nil || e.enabled()) {
 		event := makeEvent(repo, loggerLevel, reason, message)
-		if _, err := e.client.CoreV1().Events(event.Namespace).Create(context.Background(), event, metav1.CreateOptions{}); err != nil {
+		if failure != "" {
+			event.Labels[keys.FailureReason] = string(failure)
+		}
+		if err := e.record(event); err != nil {
 			if e.logger != nil {
 				e.logger.Infof("Cannot create event: %s", err.Error())
 			}
@@ -55,11 +131,62 @@ func (e *EventEmitter) EmitMessage(repo *v1alpha1.Repository, loggerLevel zapcor
 	}
 }
 
+// record creates the event, or updates the count of the identical event
+// already created, unless there are too many events for its Repository.
+func (e *EventEmitter) record(event *v1.Event) error {
+	result, err := e.correlator.EventCorrelate(event)
+	if err != nil {
+		return err
+	}
+	if result.Skip {
+		return nil
+	}
+	event = result.Event
+	var recorded *v1.Event
+	if event.Count > 1 {
+		recorded, err = e.client.CoreV1().Events(event.Namespace).Patch(context.Background(), event.Name,
+			types.StrategicMergePatchType, result.Patch, metav1.PatchOptions{})
+	}
+	// the event to update may have expired already
+	if event.Count <= 1 || util.IsKeyNotFoundError(err) {
+		event.ResourceVersion = ""
+		recorded, err = e.client.CoreV1().Events(event.Namespace).Create(context.Background(), event, metav1.CreateOptions{})
+	}
+	if err != nil {
+		return err
+	}
+	e.correlator.UpdateState(recorded)
+	return nil
+}
+
+// setLastFailure records the failure in the status of the Repository.
+func (e *EventEmitter) setLastFailure(repo *v1alpha1.Repository, failure v1alpha1.FailureReason, message string) error {
+	ctx := context.Background()
+	repositories := e.pacClient.PipelinesascodeV1alpha1().Repositories(repo.GetNamespace())
+	lastrepo, err := repositories.Get(ctx, repo.GetName(), metav1.GetOptions{})
+	if err != nil {
+		return err
+	}
+	if lastrepo.RepositoryStatus == nil {
+		lastrepo.RepositoryStatus = &v1alpha1.RepositoryStatus{}
+	}
+	lastrepo.RepositoryStatus.LastFailure = &v1alpha1.RepositoryFailure{
+		Reason:  failure,
+		Message: message,
+		Time:    metav1.Now(),
+	}
+	_, err = repositories.UpdateStatus(ctx, lastrepo, metav1.UpdateOptions{})
+	return err
+}
+
 func makeEvent(repo *v1alpha1.Repository, loggerLevel zapcore.Level, reason, message string) *v1.Event {
+	now := time.Now()
 	event := &v1.Event{
 		ObjectMeta: metav1.ObjectMeta{
-			GenerateName: repo.Name + "-",
-			Namespace:    repo.Namespace,
+			// named like the events of the client-go recorders, the
+			// correlator updates the event of the name when it is repeated
+			Name:      fmt.Sprintf("%v.%x", repo.Name, now.UnixNano()),
+			Namespace: repo.Namespace,
 			Labels: map[string]string{
 				keys.Repository: formatting.CleanValueKubernetes(repo.Name),
 			},
@@ -81,6 +208,9 @@ func makeEvent(repo *v1alpha1.Repository, loggerLevel zapcore.Level, reason, mes
 		Source: v1.EventSource{
 			Component: "Pipelines As Code",
 		},
+		FirstTimestamp: metav1.NewTime(now),
+		LastTimestamp:  metav1.NewTime(now),
+		Count:          1,
 	}
 	if loggerLevel == zap.InfoLevel {
 		event.Type = v1.EventTypeNormal
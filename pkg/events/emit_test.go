@@ -2,9 +2,13 @@ package events
 
 import (
 	"context"
+	"fmt"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/openshift-pipelines/pipelines-as-code/pkg/apis/pipelinesascode"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/apis/pipelinesascode/keys"
 	"github.com/openshift-pipelines/pipelines-as-code/pkg/apis/pipelinesascode/v1alpha1"
 	testclient "github.com/openshift-pipelines/pipelines-as-code/pkg/test/clients"
 	"go.uber.org/zap"
@@ -13,6 +17,7 @@ import (
 	"gotest.tools/v3/assert"
 	v1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	clocktesting "k8s.io/utils/clock/testing"
 	rtesting "knative.dev/pkg/reconciler/testing"
 )
 
@@ -116,3 +121,157 @@ func TestEventEmitter_EmitMessage(t *testing.T) {
 		})
 	}
 }
+
+func TestEventEmitterThrottle(t *testing.T) {
+	repo := &v1alpha1.Repository{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-repo",
+			Namespace: "test-ns",
+			UID:       "uid",
+		},
+	}
+	tests := []struct {
+		name        string
+		messages    []string
+		reasons     bool
+		disabled    bool
+		wantEvents  int
+		wantCount   int32
+		wantMessage string
+	}{
+		{
+			name:        "identical events are counted",
+			messages:    []string{"same", "same", "same"},
+			wantEvents:  1,
+			wantCount:   3,
+			wantMessage: "same",
+		},
+		{
+			name:        "different events",
+			messages:    []string{"one", "two"},
+			wantEvents:  2,
+			wantCount:   1,
+			wantMessage: "one",
+		},
+		{
+			name: "similar events are combined",
+			messages: func() []string {
+				messages := []string{}
+				for i := range 12 {
+					messages = append(messages, fmt.Sprintf("message %d", i))
+				}
+				return messages
+			}(),
+			// the first nine events and the combined event counting the others
+			wantEvents:  10,
+			wantCount:   1,
+			wantMessage: "message 0",
+		},
+		{
+			name: "events above the burst are dropped",
+			messages: func() []string {
+				messages := []string{}
+				for i := range spamBurst + 10 {
+					messages = append(messages, strings.Repeat("x", i+1))
+				}
+				return messages
+			}(),
+			// a reason for each message so they are not combined
+			reasons:     true,
+			wantEvents:  spamBurst,
+			wantCount:   1,
+			wantMessage: "x",
+		},
+		{
+			name:       "events disabled",
+			messages:   []string{"one", "two"},
+			disabled:   true,
+			wantEvents: 0,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, _ := rtesting.SetupFakeContext(t)
+			stdata, _ := testclient.SeedTestData(t, ctx, testclient.Data{})
+			observer, _ := zapobserver.New(zap.InfoLevel)
+			emitter := NewEventEmitter(stdata.Kube, zap.New(observer).Sugar())
+			emitter.correlator = newCorrelator(clocktesting.NewFakeClock(time.Now()))
+			emitter.SetEnabled(func() bool { return !tt.disabled })
+
+			for i, message := range tt.messages {
+				reason := "reason"
+				if tt.reasons {
+					reason = fmt.Sprintf("reason%d", i)
+				}
+				emitter.EmitMessage(repo, zap.InfoLevel, reason, message)
+			}
+
+			events, err := stdata.Kube.CoreV1().Events(repo.Namespace).List(ctx, metav1.ListOptions{})
+			assert.NilError(t, err)
+			assert.Equal(t, len(events.Items), tt.wantEvents)
+			if tt.wantEvents == 0 {
+				return
+			}
+			found := false
+			for _, event := range events.Items {
+				if event.Message == tt.wantMessage {
+					found = true
+					assert.Equal(t, event.Count, tt.wantCount)
+				}
+			}
+			assert.Assert(t, found, "no event with the message %s", tt.wantMessage)
+		})
+	}
+}
+
+func TestEventEmitterEmitFailure(t *testing.T) {
+	repo := &v1alpha1.Repository{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-repo",
+			Namespace: "test-ns",
+		},
+	}
+	tests := []struct {
+		name            string
+		withRepoClient  bool
+		wantLastFailure bool
+	}{
+		{
+			name:            "failure recorded in the status",
+			withRepoClient:  true,
+			wantLastFailure: true,
+		},
+		{
+			name: "no repository client",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, _ := rtesting.SetupFakeContext(t)
+			stdata, _ := testclient.SeedTestData(t, ctx, testclient.Data{Repositories: []*v1alpha1.Repository{repo}})
+			observer, _ := zapobserver.New(zap.InfoLevel)
+			emitter := NewEventEmitter(stdata.Kube, zap.New(observer).Sugar())
+			emitter.correlator = newCorrelator(clocktesting.NewFakeClock(time.Now()))
+			if tt.withRepoClient {
+				emitter.SetRepositoryClient(stdata.PipelineAsCode)
+			}
+
+			emitter.EmitFailure(repo, zap.ErrorLevel, v1alpha1.FailureReasonYAMLInvalid, "PipelineRunValidationErrors", "bad yaml")
+
+			events, err := stdata.Kube.CoreV1().Events(repo.Namespace).List(ctx, metav1.ListOptions{})
+			assert.NilError(t, err)
+			assert.Equal(t, len(events.Items), 1)
+			assert.Equal(t, events.Items[0].Reason, "PipelineRunValidationErrors")
+			assert.Equal(t, events.Items[0].Labels[keys.FailureReason], string(v1alpha1.FailureReasonYAMLInvalid))
+
+			got, err := stdata.PipelineAsCode.PipelinesascodeV1alpha1().Repositories(repo.Namespace).Get(ctx, repo.Name, metav1.GetOptions{})
+			assert.NilError(t, err)
+			if !tt.wantLastFailure {
+				assert.Assert(t, got.RepositoryStatus == nil)
+				return
+			}
+			assert.Equal(t, got.RepositoryStatus.LastFailure.Reason, v1alpha1.FailureReasonYAMLInvalid)
+			assert.Equal(t, got.RepositoryStatus.LastFailure.Message, "bad yaml")
+		})
+	}
+}
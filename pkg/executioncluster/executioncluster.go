@@ -0,0 +1,68 @@
+package executioncluster
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/apis/pipelinesascode/v1alpha1"
+	"github.com/tektoncd/pipeline/pkg/client/clientset/versioned"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/clientcmd"
+)
+
+const DefaultSecretKey = "kubeconfig"
+
+// Clients are the clients of an execution cluster.
+type Clients struct {
+	Kube   kubernetes.Interface
+	Tekton versioned.Interface
+}
+
+// NewClientsFunc creates the clients of a cluster from its kubeconfig.
+type NewClientsFunc func(kubeconfig []byte) (*Clients, error)
+
+// NewClientsFromKubeconfig is the default NewClientsFunc.
+func NewClientsFromKubeconfig(kubeconfig []byte) (*Clients, error) {
+	config, err := clientcmd.RESTConfigFromKubeConfig(kubeconfig)
+	if err != nil {
+		return nil, fmt.Errorf("invalid kubeconfig: %w", err)
+	}
+	kube, err := kubernetes.NewForConfig(config)
+	if err != nil {
+		return nil, err
+	}
+	tekton, err := versioned.NewForConfig(config)
+	if err != nil {
+		return nil, err
+	}
+	return &Clients{Kube: kube, Tekton: tekton}, nil
+}
+
+// Get returns the clients of the execution cluster of repo, or nil when its
+// PipelineRuns run on the cluster of the controller.
+func Get(ctx context.Context, kube kubernetes.Interface, repo *v1alpha1.Repository, newClients NewClientsFunc) (*Clients, error) {
+	if repo.Spec.Settings == nil || repo.Spec.Settings.ExecutionCluster == nil {
+		return nil, nil
+	}
+	secretRef := repo.Spec.Settings.ExecutionCluster.Secret
+	if secretRef == nil || secretRef.Name == "" {
+		return nil, fmt.Errorf("execution cluster of repository %s has no kubeconfig secret", repo.GetName())
+	}
+	key := secretRef.Key
+	if key == "" {
+		key = DefaultSecretKey
+	}
+	secret, err := kube.CoreV1().Secrets(repo.GetNamespace()).Get(ctx, secretRef.Name, metav1.GetOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("cannot get the execution cluster secret %s: %w", secretRef.Name, err)
+	}
+	kubeconfig, ok := secret.Data[key]
+	if !ok {
+		return nil, fmt.Errorf("execution cluster secret %s has no key %s", secretRef.Name, key)
+	}
+	if newClients == nil {
+		newClients = NewClientsFromKubeconfig
+	}
+	return newClients(kubeconfig)
+}
@@ -0,0 +1,81 @@
+package executioncluster
+
+import (
+	"testing"
+
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/apis/pipelinesascode/v1alpha1"
+	"gotest.tools/v3/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+	rtesting "knative.dev/pkg/reconciler/testing"
+)
+
+func TestGet(t *testing.T) {
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "remote", Namespace: "ns"},
+		Data:       map[string][]byte{DefaultSecretKey: []byte("default"), "other": []byte("other")},
+	}
+	tests := []struct {
+		name             string
+		executionCluster *v1alpha1.ExecutionCluster
+		wantKubeconfig   string
+		wantErr          string
+	}{
+		{
+			name: "no execution cluster",
+		},
+		{
+			name:             "default key",
+			executionCluster: &v1alpha1.ExecutionCluster{Secret: &v1alpha1.Secret{Name: "remote"}},
+			wantKubeconfig:   "default",
+		},
+		{
+			name:             "custom key",
+			executionCluster: &v1alpha1.ExecutionCluster{Secret: &v1alpha1.Secret{Name: "remote", Key: "other"}},
+			wantKubeconfig:   "other",
+		},
+		{
+			name:             "no secret",
+			executionCluster: &v1alpha1.ExecutionCluster{},
+			wantErr:          "execution cluster of repository repo has no kubeconfig secret",
+		},
+		{
+			name:             "missing secret",
+			executionCluster: &v1alpha1.ExecutionCluster{Secret: &v1alpha1.Secret{Name: "missing"}},
+			wantErr:          "cannot get the execution cluster secret missing",
+		},
+		{
+			name:             "missing key",
+			executionCluster: &v1alpha1.ExecutionCluster{Secret: &v1alpha1.Secret{Name: "remote", Key: "missing"}},
+			wantErr:          "execution cluster secret remote has no key missing",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, _ := rtesting.SetupFakeContext(t)
+			repo := &v1alpha1.Repository{
+				ObjectMeta: metav1.ObjectMeta{Name: "repo", Namespace: "ns"},
+				Spec:       v1alpha1.RepositorySpec{Settings: &v1alpha1.Settings{ExecutionCluster: tt.executionCluster}},
+			}
+			gotKubeconfig := ""
+			newClients := func(kubeconfig []byte) (*Clients, error) {
+				gotKubeconfig = string(kubeconfig)
+				return &Clients{}, nil
+			}
+			clients, err := Get(ctx, fake.NewSimpleClientset(secret), repo, newClients)
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			assert.NilError(t, err)
+			assert.Equal(t, clients != nil, tt.wantKubeconfig != "")
+			assert.Equal(t, gotKubeconfig, tt.wantKubeconfig)
+		})
+	}
+}
+
+func TestNewClientsFromKubeconfig(t *testing.T) {
+	_, err := NewClientsFromKubeconfig([]byte("not a kubeconfig"))
+	assert.ErrorContains(t, err, "invalid kubeconfig")
+}
@@ -1,6 +1,8 @@
 package formatting
 
 import (
+	"time"
+
 	"github.com/hako/durafmt"
 	"github.com/jonboulle/clockwork"
 	"github.com/openshift-pipelines/pipelines-as-code/pkg/apis/pipelinesascode/v1alpha1"
@@ -46,3 +48,45 @@ func Timeout(t *metav1.Duration) string {
 	}
 	return durafmt.Parse(t.Duration).String()
 }
+
+// AverageRunDuration returns the average duration of the completed runs in the
+// Repository status, zero when there is none to compute it from.
+func AverageRunDuration(statuses []v1alpha1.RepositoryRunStatus) time.Duration {
+	return PipelineAverageDuration(statuses, "")
+}
+
+// PipelineAverageDuration returns the average duration of the completed runs
+// of the pipeline originalName in the Repository status, all runs are taken
+// into account when originalName is empty.
+func PipelineAverageDuration(statuses []v1alpha1.RepositoryRunStatus, originalName string) time.Duration {
+	var total time.Duration
+	count := 0
+	for _, runStatus := range statuses {
+		if runStatus.StartTime == nil || runStatus.CompletionTime == nil {
+			continue
+		}
+		if originalName != "" && runStatus.OriginalPipelineRunName != originalName {
+			continue
+		}
+		total += runStatus.CompletionTime.Sub(runStatus.StartTime.Time)
+		count++
+	}
+	if count == 0 {
+		return 0
+	}
+	return total / time.Duration(count)
+}
+
+// DurationTrend describes how duration compares to the average duration of
+// the previous runs, i.e: "3m faster than usual".
+func DurationTrend(duration, average time.Duration) string {
+	delta := (duration - average).Round(time.Second)
+	switch {
+	case delta > 0:
+		return durafmt.ParseShort(delta).String() + " slower than usual"
+	case delta < 0:
+		return durafmt.ParseShort(-delta).String() + " faster than usual"
+	default:
+		return "as long as usual"
+	}
+}
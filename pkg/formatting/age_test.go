@@ -130,3 +130,56 @@ func TestPRDuration(t *testing.T) {
 		})
 	}
 }
+
+func TestAverageRunDuration(t *testing.T) {
+	clock := clockwork.NewFakeClock()
+	start := metav1.NewTime(clock.Now())
+	statuses := []v1alpha1.RepositoryRunStatus{
+		{
+			StartTime:      &start,
+			CompletionTime: &metav1.Time{Time: clock.Now().Add(5 * time.Minute)},
+		},
+		{
+			StartTime:      &start,
+			CompletionTime: &metav1.Time{Time: clock.Now().Add(9 * time.Minute)},
+		},
+		{
+			// still running, not accounted
+			StartTime: &start,
+		},
+	}
+	assert.Equal(t, AverageRunDuration(statuses), 7*time.Minute)
+	assert.Equal(t, AverageRunDuration(statuses[2:]), time.Duration(0))
+	assert.Equal(t, AverageRunDuration(nil), time.Duration(0))
+}
+
+func TestPipelineAverageDuration(t *testing.T) {
+	clock := clockwork.NewFakeClock()
+	start := metav1.NewTime(clock.Now())
+	statuses := []v1alpha1.RepositoryRunStatus{
+		{
+			OriginalPipelineRunName: "pull-request",
+			StartTime:               &start,
+			CompletionTime:          &metav1.Time{Time: clock.Now().Add(6 * time.Minute)},
+		},
+		{
+			OriginalPipelineRunName: "push",
+			StartTime:               &start,
+			CompletionTime:          &metav1.Time{Time: clock.Now().Add(30 * time.Minute)},
+		},
+		{
+			OriginalPipelineRunName: "pull-request",
+			StartTime:               &start,
+			CompletionTime:          &metav1.Time{Time: clock.Now().Add(8 * time.Minute)},
+		},
+	}
+	assert.Equal(t, PipelineAverageDuration(statuses, "pull-request"), 7*time.Minute)
+	assert.Equal(t, PipelineAverageDuration(statuses, "push"), 30*time.Minute)
+	assert.Equal(t, PipelineAverageDuration(statuses, "unknown"), time.Duration(0))
+}
+
+func TestDurationTrend(t *testing.T) {
+	assert.Equal(t, DurationTrend(9*time.Minute, 7*time.Minute), "2 minutes slower than usual")
+	assert.Equal(t, DurationTrend(5*time.Minute, 7*time.Minute), "2 minutes faster than usual")
+	assert.Equal(t, DurationTrend(7*time.Minute, 7*time.Minute), "as long as usual")
+}
@@ -0,0 +1,75 @@
+package formatting
+
+import (
+	"encoding/xml"
+	"fmt"
+	"strings"
+)
+
+// maxFailedTests is the number of failed tests listed in the status, the
+// others are only counted.
+const maxFailedTests = 10
+
+// TestSummary is the summary of the JUnit reports of a PipelineRun.
+type TestSummary struct {
+	Passed      int
+	Failed      int
+	Skipped     int
+	FailedTests []string
+	// MoreFailedTests is the number of failed tests not in FailedTests.
+	MoreFailedTests int
+}
+
+type junitTestSuite struct {
+	XMLName xml.Name
+	Name    string           `xml:"name,attr"`
+	Suites  []junitTestSuite `xml:"testsuite"`
+	Cases   []junitTestCase  `xml:"testcase"`
+}
+
+type junitTestCase struct {
+	Name      string    `xml:"name,attr"`
+	Classname string    `xml:"classname,attr"`
+	Failure   *struct{} `xml:"failure"`
+	Error     *struct{} `xml:"error"`
+	Skipped   *struct{} `xml:"skipped"`
+}
+
+// ParseJUnit adds the test cases of a JUnit XML report, with a testsuites or
+// a testsuite root element, to the summary.
+func (s *TestSummary) ParseJUnit(report string) error {
+	var root junitTestSuite
+	if err := xml.Unmarshal([]byte(strings.TrimSpace(report)), &root); err != nil {
+		return fmt.Errorf("cannot parse the JUnit report: %w", err)
+	}
+	if root.XMLName.Local != "testsuites" && root.XMLName.Local != "testsuite" {
+		return fmt.Errorf("cannot parse the JUnit report: unexpected root element %s", root.XMLName.Local)
+	}
+	s.addSuite(root)
+	return nil
+}
+
+func (s *TestSummary) addSuite(suite junitTestSuite) {
+	for _, tc := range suite.Cases {
+		switch {
+		case tc.Failure != nil || tc.Error != nil:
+			s.Failed++
+			if len(s.FailedTests) == maxFailedTests {
+				s.MoreFailedTests++
+				continue
+			}
+			name := tc.Name
+			if tc.Classname != "" {
+				name = tc.Classname + "." + tc.Name
+			}
+			s.FailedTests = append(s.FailedTests, name)
+		case tc.Skipped != nil:
+			s.Skipped++
+		default:
+			s.Passed++
+		}
+	}
+	for _, child := range suite.Suites {
+		s.addSuite(child)
+	}
+}
@@ -0,0 +1,83 @@
+package formatting
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"gotest.tools/v3/assert"
+)
+
+func TestParseJUnit(t *testing.T) {
+	manyFailures := []string{}
+	for i := 0; i < 12; i++ {
+		manyFailures = append(manyFailures, fmt.Sprintf(`<testcase name="test%d"><failure/></testcase>`, i))
+	}
+
+	tests := []struct {
+		name    string
+		reports []string
+		want    TestSummary
+		wantErr string
+	}{
+		{
+			name: "testsuites root",
+			reports: []string{`<?xml version="1.0" encoding="UTF-8"?>
+<testsuites>
+  <testsuite name="pkg/a">
+    <testcase classname="pkg/a" name="TestOK"/>
+    <testcase classname="pkg/a" name="TestFail"><failure message="expected 1">a_test.go:12</failure></testcase>
+    <testcase classname="pkg/a" name="TestSkip"><skipped/></testcase>
+  </testsuite>
+  <testsuite name="pkg/b">
+    <testcase name="TestError"><error>panic</error></testcase>
+  </testsuite>
+</testsuites>`},
+			want: TestSummary{Passed: 1, Failed: 2, Skipped: 1, FailedTests: []string{"pkg/a.TestFail", "TestError"}},
+		},
+		{
+			name: "testsuite root of several reports",
+			reports: []string{
+				`<testsuite name="unit"><testcase name="a"/><testcase name="b"/></testsuite>`,
+				`<testsuite name="e2e"><testsuite name="nested"><testcase name="c"><failure/></testcase></testsuite></testsuite>`,
+			},
+			want: TestSummary{Passed: 2, Failed: 1, FailedTests: []string{"c"}},
+		},
+		{
+			name:    "failed tests truncated",
+			reports: []string{"<testsuite>" + strings.Join(manyFailures, "") + "</testsuite>"},
+			want: TestSummary{
+				Failed:          12,
+				FailedTests:     []string{"test0", "test1", "test2", "test3", "test4", "test5", "test6", "test7", "test8", "test9"},
+				MoreFailedTests: 2,
+			},
+		},
+		{
+			name:    "not xml",
+			reports: []string{"ok 42 tests"},
+			wantErr: "cannot parse the JUnit report: EOF",
+		},
+		{
+			name:    "not a junit report",
+			reports: []string{"<project><name>foo</name></project>"},
+			wantErr: "cannot parse the JUnit report: unexpected root element project",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			summary := TestSummary{}
+			var err error
+			for _, report := range tt.reports {
+				if err = summary.ParseJUnit(report); err != nil {
+					break
+				}
+			}
+			if tt.wantErr != "" {
+				assert.Error(t, err, tt.wantErr)
+				return
+			}
+			assert.NilError(t, err)
+			assert.DeepEqual(t, summary, tt.want)
+		})
+	}
+}
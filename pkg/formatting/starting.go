@@ -34,6 +34,16 @@ type MessageTemplate struct {
 	TknBinaryURL    string
 	TaskStatus      string
 	FailureSnippet  string
+	QueuePosition   int
+	EstimatedStart  string
+	Duration        string
+	AverageDuration string
+	DurationTrend   string
+	Timeout         string
+	CheckoutFailure string
+	TicketID        string
+	TicketURL       string
+	TestSummary     *TestSummary
 }
 
 func (mt MessageTemplate) MakeTemplate(tmpl string) (string, error) {
@@ -1,6 +1,7 @@
 package formatting
 
 import (
+	"strings"
 	"testing"
 )
 
@@ -40,6 +41,73 @@ func TestMessageTemplate_MakeTemplate(t *testing.T) {
 			msg:  "I am {{ .Mt.FailureSnippet }}",
 			want: "I am such a failure",
 		},
+		{
+			name: "Queuing template without position",
+			mt:   mt,
+			msg:  QueuingPipelineRunMarkdown,
+			want: "PipelineRun **[test-pipeline](https://test-console-url.com)** has been queued in namespace **test-namespace**\n",
+		},
+		{
+			name: "Queuing template with position and estimation",
+			mt: MessageTemplate{
+				PipelineRunName: "test-pipeline",
+				Namespace:       "test-namespace",
+				ConsoleURL:      "https://test-console-url.com",
+				QueuePosition:   2,
+				EstimatedStart:  "14 minutes",
+			},
+			msg:  QueuingPipelineRunMarkdown,
+			want: "PipelineRun **[test-pipeline](https://test-console-url.com)** has been queued in namespace **test-namespace**, it is at position **2** in the queue and is estimated to start in about 14 minutes\n",
+		},
+		{
+			name: "PipelineRun status template with duration trend",
+			mt: MessageTemplate{
+				PipelineRunName: "test-pipeline",
+				Namespace:       "test-namespace",
+				NamespaceURL:    "https://test-namespace-url.com",
+				ConsoleURL:      "https://test-console-url.com",
+				Duration:        "9 minutes",
+				AverageDuration: "7 minutes",
+				DurationTrend:   "2 minutes slower than usual",
+			},
+			msg:  PipelineRunStatusMarkDown[:strings.Index(PipelineRunStatusMarkDown, "---")],
+			want: "- **Namespace**: [test-namespace](https://test-namespace-url.com)\n- **PipelineRun**: [test-pipeline](https://test-console-url.com)\n- **Duration**: 9 minutes (usually takes ~7 minutes, 2 minutes slower than usual)\n\n",
+		},
+		{
+			name: "PipelineRun status template with timeout",
+			mt: MessageTemplate{
+				PipelineRunName: "test-pipeline",
+				Namespace:       "test-namespace",
+				NamespaceURL:    "https://test-namespace-url.com",
+				ConsoleURL:      "https://test-console-url.com",
+				Timeout:         "after 1 hour, the default timeout of Tekton Pipelines",
+			},
+			msg:  PipelineRunStatusMarkDown[:strings.Index(PipelineRunStatusMarkDown, "---")],
+			want: "- **Namespace**: [test-namespace](https://test-namespace-url.com)\n- **PipelineRun**: [test-pipeline](https://test-console-url.com)\n- **Timed out**: after 1 hour, the default timeout of Tekton Pipelines\n\n",
+		},
+		{
+			name: "PipelineRun status template with tests",
+			mt: MessageTemplate{
+				TaskStatus: "| ok | unit | 1s |",
+				TestSummary: &TestSummary{
+					Passed:          3,
+					Failed:          2,
+					FailedTests:     []string{"pkg.TestFoo"},
+					MoreFailedTests: 1,
+				},
+			},
+			msg:  PipelineRunStatusMarkDown[strings.Index(PipelineRunStatusMarkDown, "{{ .Mt.TaskStatus }}"):],
+			want: "| ok | unit | 1s |\n---\n\n### Tests:\n\n3 passed, 2 failed, 0 skipped\n\n**Failed tests:**\n\n- `pkg.TestFoo`\n- and 1 more\n",
+		},
+		{
+			name: "PipelineRun status HTML template escapes the test names",
+			mt: MessageTemplate{
+				TaskStatus:  "<table></table>",
+				TestSummary: &TestSummary{Failed: 1, FailedTests: []string{"TestCompare/a<b"}},
+			},
+			msg:  PipelineRunStatusHTML[strings.Index(PipelineRunStatusHTML, "{{ .Mt.TaskStatus }}"):],
+			want: "<table></table>\n<hr>\n<h4>Tests:</h4>\n0 passed, 1 failed, 0 skipped\n<ul>\n<li>TestCompare/a&lt;b</li>\n</ul>\n",
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
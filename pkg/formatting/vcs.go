@@ -2,6 +2,7 @@ package formatting
 
 import (
 	"fmt"
+	"net"
 	"net/url"
 	"path/filepath"
 	"strings"
@@ -33,6 +34,47 @@ func ShortSHA(sha string) string {
 	return sha[0:shortShaLength]
 }
 
+// CanonicalRepoURL returns the HTTPS URL of a repository from its SSH forms,
+// ie: git@github.com:owner/repo.git, and removes the credentials, the default
+// port, the trailing slashes and the .git suffix of its other URLs.
+func CanonicalRepoURL(u string) string {
+	u = strings.TrimSpace(u)
+	if !strings.Contains(u, "://") {
+		if userHost, repoPath, ok := strings.Cut(u, ":"); ok && strings.Contains(userHost, "@") {
+			_, host, _ := strings.Cut(userHost, "@")
+			u = "https://" + host + "/" + strings.TrimPrefix(repoPath, "/")
+		}
+	}
+	trim := func(p string) string {
+		return strings.TrimRight(strings.TrimSuffix(strings.TrimRight(p, "/"), ".git"), "/")
+	}
+
+	parsed, err := url.Parse(u)
+	if err != nil || parsed.Host == "" {
+		return trim(u)
+	}
+	scheme, host, port := strings.ToLower(parsed.Scheme), strings.ToLower(parsed.Hostname()), parsed.Port()
+	switch {
+	case scheme == "ssh" || scheme == "git+ssh" || scheme == "git":
+		// the SSH port has nothing to do with the HTTPS one
+		scheme, port = "https", ""
+	case scheme == "https" && port == "443", scheme == "http" && port == "80":
+		port = ""
+	}
+	if port != "" {
+		host = net.JoinHostPort(host, port)
+	} else if strings.Contains(host, ":") {
+		host = "[" + host + "]"
+	}
+	return scheme + "://" + host + trim(parsed.Path)
+}
+
+// NormalizeRepoURL returns the URL of a repository as it is compared to the
+// URL of the events, its canonical URL in lower case.
+func NormalizeRepoURL(u string) string {
+	return strings.ToLower(CanonicalRepoURL(u))
+}
+
 func GetRepoOwnerFromURL(ghURL string) (string, error) {
 	org, repo, err := GetRepoOwnerSplitted(ghURL)
 	if err != nil {
@@ -0,0 +1,321 @@
+package git
+
+import (
+	"archive/tar"
+	"bufio"
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha1" //nolint:gosec // the hashed known hosts use HMAC-SHA1
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"golang.org/x/crypto/ssh"
+)
+
+// ErrArchiveNoSuchRef is returned when the git server refuses to archive a
+// revision which is not a ref, most servers only allow the commit SHAs with
+// the uploadArchive.allowUnreachable setting.
+var ErrArchiveNoSuchRef = errors.New("the git server refuses to archive a revision which is not a ref")
+
+// ErrNoKnownHosts is returned when there are no known hosts to check the host
+// key of the git server against, the connection is not attempted.
+var ErrNoKnownHosts = errors.New("no known hosts to check the host key of the git server")
+
+// SSHArchiveOpts are the options to fetch a directory of a remote repository
+// with git archive over SSH.
+type SSHArchiveOpts struct {
+	// Remote is the SSH URL of the repository, ie: git@host:path.git or
+	// ssh://git@host:port/path.git.
+	Remote string
+	// Revision is the commit SHA or the branch to archive.
+	Revision string
+	// Path is the directory to archive.
+	Path string
+	// PrivateKey is the private key of the deploy key of the repository.
+	PrivateKey []byte
+	// KnownHosts are the known hosts of the git server, the host key is
+	// checked against them.
+	KnownHosts []byte
+}
+
+// SSHURL returns the SSH URL of a repository from its HTTP(S) URL, ie:
+// git@gitlab.com:group/repo.git for https://gitlab.com/group/repo.
+func SSHURL(repoURL string) (string, error) {
+	u, err := url.Parse(repoURL)
+	if err != nil {
+		return "", err
+	}
+	repoPath := strings.Trim(u.Path, "/")
+	if u.Hostname() == "" || repoPath == "" {
+		return "", fmt.Errorf("cannot get the ssh url of %s", repoURL)
+	}
+	return fmt.Sprintf("git@%s:%s.git", u.Hostname(), strings.TrimSuffix(repoPath, ".git")), nil
+}
+
+// parseSSHRemote returns the user, the address and the path of a SSH remote.
+func parseSSHRemote(remote string) (string, string, string, error) {
+	if strings.HasPrefix(remote, "ssh://") {
+		u, err := url.Parse(remote)
+		if err != nil {
+			return "", "", "", err
+		}
+		port := u.Port()
+		if port == "" {
+			port = "22"
+		}
+		return u.User.Username(), net.JoinHostPort(u.Hostname(), port), u.Path, nil
+	}
+	userHost, repoPath, ok := strings.Cut(remote, ":")
+	if !ok || repoPath == "" {
+		return "", "", "", fmt.Errorf("invalid ssh remote %s", remote)
+	}
+	user, host, ok := strings.Cut(userHost, "@")
+	if !ok {
+		user, host = "", userHost
+	}
+	return user, net.JoinHostPort(host, "22"), repoPath, nil
+}
+
+// knownHostsCallback checks the host key of the server against the known
+// hosts, with their plain or hashed host names.
+func knownHostsCallback(knownHosts []byte) ssh.HostKeyCallback {
+	return func(hostname string, _ net.Addr, key ssh.PublicKey) error {
+		host, port, err := net.SplitHostPort(hostname)
+		if err != nil {
+			host, port = hostname, "22"
+		}
+		if port != "22" {
+			host = fmt.Sprintf("[%s]:%s", host, port)
+		}
+		rest := knownHosts
+		for len(rest) > 0 {
+			var hosts []string
+			var known ssh.PublicKey
+			_, hosts, known, _, rest, err = ssh.ParseKnownHosts(rest)
+			if err != nil {
+				break
+			}
+			if slices.ContainsFunc(hosts, func(pattern string) bool { return knownHostMatches(pattern, host) }) &&
+				bytes.Equal(known.Marshal(), key.Marshal()) {
+				return nil
+			}
+		}
+		return fmt.Errorf("the host key of %s is not in the known hosts", hostname)
+	}
+}
+
+// knownHostMatches returns whether the host pattern of a known hosts line
+// matches host, the hashed patterns are |1|salt|hash.
+func knownHostMatches(pattern, host string) bool {
+	salt, hash, ok := strings.Cut(strings.TrimPrefix(pattern, "|1|"), "|")
+	if !strings.HasPrefix(pattern, "|1|") || !ok {
+		return pattern == host
+	}
+	saltBytes, err := base64.StdEncoding.DecodeString(salt)
+	if err != nil {
+		return false
+	}
+	mac := hmac.New(sha1.New, saltBytes)
+	mac.Write([]byte(host))
+	return base64.StdEncoding.EncodeToString(mac.Sum(nil)) == hash
+}
+
+// SSHArchive returns the files of the directory of a remote repository,
+// keyed by their path, with the git-upload-archive command over SSH. It
+// returns an empty map when the directory doesn't exist at the revision.
+func SSHArchive(ctx context.Context, opts SSHArchiveOpts) (map[string][]byte, error) {
+	user, addr, repoPath, err := parseSSHRemote(opts.Remote)
+	if err != nil {
+		return nil, err
+	}
+	if len(bytes.TrimSpace(opts.KnownHosts)) == 0 {
+		return nil, fmt.Errorf("cannot connect to %s: %w", addr, ErrNoKnownHosts)
+	}
+	signer, err := ssh.ParsePrivateKey(opts.PrivateKey)
+	if err != nil {
+		return nil, fmt.Errorf("cannot parse the ssh private key: %w", err)
+	}
+	config := &ssh.ClientConfig{
+		User:            user,
+		Auth:            []ssh.AuthMethod{ssh.PublicKeys(signer)},
+		HostKeyCallback: knownHostsCallback(opts.KnownHosts),
+		Timeout:         30 * time.Second,
+	}
+	conn, err := (&net.Dialer{Timeout: config.Timeout}).DialContext(ctx, "tcp", addr)
+	if err != nil {
+		return nil, fmt.Errorf("cannot connect to %s: %w", addr, err)
+	}
+	sshConn, chans, reqs, err := ssh.NewClientConn(conn, addr, config)
+	if err != nil {
+		conn.Close()
+		return nil, fmt.Errorf("cannot open a ssh connection to %s: %w", addr, err)
+	}
+	client := ssh.NewClient(sshConn, chans, reqs)
+	defer client.Close()
+	// stop reading the archive when the context is done
+	stop := context.AfterFunc(ctx, func() { client.Close() })
+	defer stop()
+
+	session, err := client.NewSession()
+	if err != nil {
+		return nil, err
+	}
+	defer session.Close()
+	stdin, err := session.StdinPipe()
+	if err != nil {
+		return nil, err
+	}
+	stdout, err := session.StdoutPipe()
+	if err != nil {
+		return nil, err
+	}
+	var stderr bytes.Buffer
+	session.Stderr = &stderr
+	if err := session.Start("git-upload-archive " + shellQuote(repoPath)); err != nil {
+		return nil, fmt.Errorf("cannot run git-upload-archive on %s: %w", addr, err)
+	}
+
+	path := strings.Trim(opts.Path, "/")
+	for _, arg := range []string{"--format=tar", opts.Revision, path} {
+		if err := writePktLine(stdin, "argument "+arg+"\n"); err != nil {
+			return nil, err
+		}
+	}
+	if _, err := io.WriteString(stdin, "0000"); err != nil {
+		return nil, err
+	}
+
+	r := bufio.NewReader(stdout)
+	line, err := readPktLine(r)
+	if err != nil {
+		return nil, fmt.Errorf("cannot read the answer of git-upload-archive: %w, output: %s", err, strings.TrimSpace(stderr.String()))
+	}
+	if ack := strings.TrimSpace(string(line)); ack != "ACK" {
+		return nil, fmt.Errorf("git-upload-archive refused to archive %s: %s", opts.Revision, ack)
+	}
+	if line, err = readPktLine(r); err != nil {
+		return nil, fmt.Errorf("cannot read the answer of git-upload-archive: %w", err)
+	} else if line != nil {
+		return nil, fmt.Errorf("git-upload-archive protocol error, expected a flush after its ACK")
+	}
+
+	archive, err := readSideband(r)
+	if err != nil {
+		var remoteErr *remoteError
+		switch {
+		case errors.As(err, &remoteErr) && strings.Contains(remoteErr.messages, "did not match any files"):
+			return map[string][]byte{}, nil
+		case errors.As(err, &remoteErr) && strings.Contains(remoteErr.messages, "no such ref"):
+			return nil, fmt.Errorf("%w: %s", ErrArchiveNoSuchRef, opts.Revision)
+		}
+		return nil, fmt.Errorf("cannot archive %s of %s at %s: %w", path, opts.Remote, opts.Revision, err)
+	}
+	return untar(bytes.NewReader(archive))
+}
+
+// shellQuote quotes s for the shell of the git server like git does.
+func shellQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
+
+// writePktLine writes a line in the pkt-line format of the git protocol.
+func writePktLine(w io.Writer, line string) error {
+	_, err := fmt.Fprintf(w, "%04x%s", len(line)+4, line)
+	return err
+}
+
+// readPktLine reads a line in the pkt-line format of the git protocol, it
+// returns nil on a flush packet.
+func readPktLine(r io.Reader) ([]byte, error) {
+	header := make([]byte, 4)
+	if _, err := io.ReadFull(r, header); err != nil {
+		return nil, err
+	}
+	length, err := strconv.ParseUint(string(header), 16, 16)
+	if err != nil {
+		return nil, fmt.Errorf("invalid pkt-line length %q", header)
+	}
+	if length == 0 {
+		return nil, nil
+	}
+	if length < 4 {
+		return nil, fmt.Errorf("invalid pkt-line length %d", length)
+	}
+	line := make([]byte, length-4)
+	if _, err := io.ReadFull(r, line); err != nil {
+		return nil, err
+	}
+	return line, nil
+}
+
+// remoteError is the error of the git server, with its messages.
+type remoteError struct {
+	messages string
+}
+
+func (e *remoteError) Error() string {
+	return "remote: " + strings.TrimSpace(e.messages)
+}
+
+// readSideband returns the data of the side-band packets until the flush
+// packet, the messages of the server are in the error when it fails.
+func readSideband(r io.Reader) ([]byte, error) {
+	var data bytes.Buffer
+	var messages strings.Builder
+	for {
+		line, err := readPktLine(r)
+		if err != nil {
+			if messages.Len() > 0 {
+				return nil, &remoteError{messages: messages.String()}
+			}
+			return nil, err
+		}
+		if line == nil {
+			return data.Bytes(), nil
+		}
+		if len(line) == 0 {
+			continue
+		}
+		switch line[0] {
+		case 1:
+			data.Write(line[1:])
+		case 2:
+			messages.Write(line[1:])
+		case 3:
+			messages.Write(line[1:])
+			return nil, &remoteError{messages: messages.String()}
+		}
+	}
+}
+
+// untar returns the regular files of a tar archive keyed by their path.
+func untar(r io.Reader) (map[string][]byte, error) {
+	files := map[string][]byte{}
+	tr := tar.NewReader(r)
+	for {
+		header, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			return files, nil
+		}
+		if err != nil {
+			return nil, fmt.Errorf("cannot read the archive: %w", err)
+		}
+		if header.Typeflag != tar.TypeReg {
+			continue
+		}
+		content, err := io.ReadAll(tr)
+		if err != nil {
+			return nil, fmt.Errorf("cannot read %s from the archive: %w", header.Name, err)
+		}
+		files[header.Name] = content
+	}
+}
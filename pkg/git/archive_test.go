@@ -0,0 +1,208 @@
+package git
+
+import (
+	"context"
+	"errors"
+	"os"
+	"os/exec"
+	"strings"
+	"testing"
+
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/test/gitssh"
+	"gotest.tools/v3/assert"
+	"gotest.tools/v3/env"
+	"gotest.tools/v3/fs"
+)
+
+func TestSSHURL(t *testing.T) {
+	tests := []struct {
+		name    string
+		repoURL string
+		want    string
+		wantErr bool
+	}{
+		{
+			name:    "https url",
+			repoURL: "https://gitlab.com/group/subgroup/repo",
+			want:    "git@gitlab.com:group/subgroup/repo.git",
+		},
+		{
+			name:    "git suffix and port",
+			repoURL: "https://gitlab.example.com:8443/group/repo.git/",
+			want:    "git@gitlab.example.com:group/repo.git",
+		},
+		{
+			name:    "no path",
+			repoURL: "https://gitlab.com",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := SSHURL(tt.repoURL)
+			if tt.wantErr {
+				assert.Assert(t, err != nil)
+				return
+			}
+			assert.NilError(t, err)
+			assert.Equal(t, got, tt.want)
+		})
+	}
+}
+
+func TestSSHArchive(t *testing.T) {
+	if os.Getenv("TEST_SKIP_GIT") != "" {
+		t.Skip("Skipping Git test")
+		return
+	}
+	if gitPath, _ := exec.LookPath("git"); gitPath == "" {
+		t.Skip("could not find the git binary in path, skipping test")
+		return
+	}
+	tmpFile := fs.NewFile(t, "gitconfig-")
+	defer tmpFile.Remove()
+	defer env.PatchAll(t, map[string]string{
+		"HOME": tmpFile.Path(),
+		"PATH": "/usr/local/bin:/usr/bin:/bin:/usr/sbin:/sbin",
+	})()
+
+	repo := fs.NewDir(t, "TestSSHArchive",
+		fs.WithFile("README.md", "readme"),
+		fs.WithDir(".tekton",
+			fs.WithFile("pr.yaml", "kind: PipelineRun"),
+			fs.WithDir("tasks", fs.WithFile("task.yaml", "kind: Task"))))
+	defer repo.Remove()
+	for _, args := range [][]string{
+		{"init", "-b", "main"},
+		{"config", "--local", "user.email", "foo@foo.com"},
+		{"config", "--local", "user.name", "Mister Ze Foo"},
+		{"add", "."},
+		{"commit", "-m", "tekton"},
+	} {
+		_, err := RunGit(repo.Path(), args...)
+		assert.NilError(t, err)
+	}
+	sha, err := RunGit(repo.Path(), "rev-parse", "HEAD")
+	assert.NilError(t, err)
+	server := gitssh.NewServer(t, repo.Path())
+
+	tests := []struct {
+		name       string
+		revision   string
+		path       string
+		knownHosts []byte
+		wantFiles  map[string]string
+		wantErr    error
+		wantErrMsg string
+	}{
+		{
+			name:       "tekton directory of a branch",
+			revision:   "main",
+			path:       ".tekton",
+			knownHosts: server.KnownHosts,
+			wantFiles: map[string]string{
+				".tekton/pr.yaml":         "kind: PipelineRun",
+				".tekton/tasks/task.yaml": "kind: Task",
+			},
+		},
+		{
+			name:       "tekton subdirectory",
+			revision:   "main",
+			path:       ".tekton/tasks",
+			knownHosts: server.KnownHosts,
+			wantFiles: map[string]string{
+				".tekton/tasks/task.yaml": "kind: Task",
+			},
+		},
+		{
+			name:     "no known hosts",
+			revision: "main",
+			path:     ".tekton",
+			wantErr:  ErrNoKnownHosts,
+		},
+		{
+			name:       "unknown host key",
+			revision:   "main",
+			path:       ".tekton",
+			knownHosts: []byte("[127.0.0.1]:22 " + strings.SplitN(string(server.KnownHosts), " ", 2)[1]),
+			wantErrMsg: "is not in the known hosts",
+		},
+		{
+			name:       "no tekton directory",
+			revision:   "main",
+			path:       ".other",
+			knownHosts: server.KnownHosts,
+			wantFiles:  map[string]string{},
+		},
+		{
+			name:       "commit sha is not a ref",
+			revision:   strings.TrimSpace(sha),
+			path:       ".tekton",
+			knownHosts: server.KnownHosts,
+			wantErr:    ErrArchiveNoSuchRef,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			files, err := SSHArchive(context.Background(), SSHArchiveOpts{
+				Remote:     server.Remote,
+				Revision:   tt.revision,
+				Path:       tt.path,
+				PrivateKey: server.PrivateKey,
+				KnownHosts: tt.knownHosts,
+			})
+			if tt.wantErr != nil {
+				assert.Assert(t, errors.Is(err, tt.wantErr), "got %v", err)
+				return
+			}
+			if tt.wantErrMsg != "" {
+				assert.ErrorContains(t, err, tt.wantErrMsg)
+				return
+			}
+			assert.NilError(t, err)
+			got := map[string]string{}
+			for name, content := range files {
+				got[name] = string(content)
+			}
+			assert.DeepEqual(t, got, tt.wantFiles)
+		})
+	}
+}
+
+func TestKnownHostMatches(t *testing.T) {
+	tests := []struct {
+		name    string
+		pattern string
+		host    string
+		want    bool
+	}{
+		{
+			name:    "plain host",
+			pattern: "gitlab.com",
+			host:    "gitlab.com",
+			want:    true,
+		},
+		{
+			name:    "plain host with port",
+			pattern: "[gitlab.com]:2222",
+			host:    "gitlab.com",
+		},
+		{
+			// the HMAC-SHA1 of gitlab.com with the salt
+			name:    "hashed host",
+			pattern: "|1|sdqgVfQvFYhT7aAm3nByzfW2KFk=|fRSgiXwCxPfH9lqIec7hxWUXBQw=",
+			host:    "gitlab.com",
+			want:    true,
+		},
+		{
+			name:    "hashed other host",
+			pattern: "|1|sdqgVfQvFYhT7aAm3nByzfW2KFk=|fRSgiXwCxPfH9lqIec7hxWUXBQw=",
+			host:    "github.com",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, knownHostMatches(tt.pattern, tt.host), tt.want)
+		})
+	}
+}
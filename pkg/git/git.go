@@ -7,6 +7,8 @@ import (
 	"os"
 	"os/exec"
 	"strings"
+
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/formatting"
 )
 
 type Info struct {
@@ -71,19 +73,10 @@ func GetGitInfo(dir string) *Info {
 			gitURL = brootdir
 		}
 	}
-	gitURL = strings.TrimSpace(gitURL)
-	gitURL = strings.TrimSuffix(gitURL, ".git")
-
-	// convert github and probably others ssh access format into https
-	// i think it only fails with bitbucket data center
-	if strings.HasPrefix(gitURL, "git@") {
-		sp := strings.Split(gitURL, ":")
-		prefix := strings.ReplaceAll(sp[0], "git@", "https://")
-		gitURL = fmt.Sprintf("%s/%s", prefix, strings.Join(sp[1:], ":"))
-	}
-
+	// the ssh remotes are converted to their https url, i think it only
+	// fails with bitbucket data center
 	return &Info{
-		URL:          gitURL,
+		URL:          formatting.CanonicalRepoURL(gitURL),
 		TopLevelPath: strings.TrimSpace(brootdir),
 		SHA:          strings.TrimSpace(sha),
 		Branch:       strings.TrimSpace(headbranch),
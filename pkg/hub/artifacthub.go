@@ -35,15 +35,16 @@ type artifactHubClient struct {
 	params *params.Run
 	url    string
 	name   string
+	auth   *catalogAuth
 }
 
 // newArtifactHubClient returns a new Artifact Hub client.
-func newArtifactHubClient(params *params.Run, url, name string) Client {
+func newArtifactHubClient(params *params.Run, url, name string, auth *catalogAuth) Client {
 	url = strings.TrimSuffix(url, "/") // Trim any trailing slash
 	if !strings.HasSuffix(url, "/api/v1") {
 		url = fmt.Sprintf("%s/api/v1", url)
 	}
-	return &artifactHubClient{params: params, url: url, name: name}
+	return &artifactHubClient{params: params, url: url, name: name, auth: auth}
 }
 
 // GetResource gets a resource from the Artifact Hub.
@@ -89,7 +90,7 @@ func (a *artifactHubClient) getLatestVersion(ctx context.Context, catalogName, r
 	pkgType, catalogName := getArtifactHubTypeByKind(catalogName, kind)
 	url := fmt.Sprintf("%s/packages/%s/%s/%s", a.url, pkgType, catalogName, resource)
 	resp := new(artifactHubPkgResponse)
-	data, err := a.params.Clients.GetURL(ctx, url)
+	data, err := getURL(ctx, a.params, a.auth, url)
 	if err != nil {
 		return "", fmt.Errorf("could not fetch %s %s from hub, url: %s: %w", kind, resource, url, err)
 	}
@@ -115,7 +116,7 @@ func (a *artifactHubClient) getSpecificVersion(ctx context.Context, catalogName,
 
 	url := fmt.Sprintf("%s/packages/%s/%s/%s/%s", a.url, pkgType, catalogName, resourceName, version)
 	resp := new(artifactHubPkgResponse)
-	data, err := a.params.Clients.GetURL(ctx, url)
+	data, err := getURL(ctx, a.params, a.auth, url)
 	if err != nil {
 		return "", fmt.Errorf("could not fetch %s %s from hub, url: %s: %w", kind, resource, url, err)
 	}
@@ -45,7 +45,7 @@ func TestNewArtifactHubClient(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			client := newArtifactHubClient(&params.Run{}, tt.url, "test")
+			client := newArtifactHubClient(&params.Run{}, tt.url, "test", nil)
 			ahClient, ok := client.(*artifactHubClient)
 			assert.Assert(t, ok)
 			assert.Equal(t, tt.wantedURL, ahClient.url)
@@ -269,7 +269,7 @@ func TestArtifactHubClient_GetResource(t *testing.T) {
 					HTTP: *httpTestClient,
 				},
 			}
-			client := newArtifactHubClient(cs, testHubURL, testCatalogHubName)
+			client := newArtifactHubClient(cs, testHubURL, testCatalogHubName, nil)
 			got, err := client.GetResource(ctx, tc.catalogName, tc.resource, tc.kind)
 
 			if tc.wantErr {
@@ -570,7 +570,7 @@ func TestNewArtifactHubClient_URLHandling(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			client := newArtifactHubClient(&params.Run{}, tt.url, "test")
+			client := newArtifactHubClient(&params.Run{}, tt.url, "test", nil)
 			ahClient, ok := client.(*artifactHubClient)
 			assert.Assert(t, ok)
 			assert.Equal(t, tt.wantedURL, ahClient.url)
@@ -0,0 +1,98 @@
+package hub
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/params"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/params/clients"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/params/info"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/params/settings"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// catalogTokenKey is the key of the catalog secret with the token.
+	catalogTokenKey = "token"
+	// catalogCAKey is the key of the catalog secret with the CA certificate
+	// of the catalog.
+	catalogCAKey = "ca.crt"
+)
+
+// catalogAuth is how the requests to a catalog are authenticated.
+type catalogAuth struct {
+	client *http.Client
+	header string
+	value  string
+}
+
+// newCatalogAuth returns the authentication of catalog from its secret in
+// the Pipelines-as-Code namespace, nil when the catalog has no secret.
+func newCatalogAuth(ctx context.Context, cs *params.Run, catalog settings.HubCatalog) (*catalogAuth, error) {
+	if catalog.Secret == "" {
+		return nil, nil
+	}
+	if cs.Clients.Kube == nil {
+		return nil, fmt.Errorf("cannot get secret %s of catalog %s: no kubernetes client", catalog.Secret, catalog.Name)
+	}
+	secret, err := cs.Clients.Kube.CoreV1().Secrets(info.GetNS(ctx)).Get(ctx, catalog.Secret, metav1.GetOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("cannot get secret %s of catalog %s: %w", catalog.Secret, catalog.Name, err)
+	}
+
+	auth := &catalogAuth{client: &cs.Clients.HTTP}
+	if token := string(secret.Data[catalogTokenKey]); token != "" {
+		auth.header, auth.value = "Authorization", "Bearer "+token
+		if catalog.AuthHeader != "" {
+			auth.header, auth.value = catalog.AuthHeader, token
+		}
+	}
+	if ca := secret.Data[catalogCAKey]; len(ca) > 0 {
+		pool, err := x509.SystemCertPool()
+		if err != nil {
+			pool = x509.NewCertPool()
+		}
+		if !pool.AppendCertsFromPEM(ca) {
+			return nil, fmt.Errorf("cannot parse the %s of secret %s of catalog %s", catalogCAKey, catalog.Secret, catalog.Name)
+		}
+		transport, ok := http.DefaultTransport.(*http.Transport)
+		if !ok {
+			return nil, fmt.Errorf("cannot set the CA certificate of catalog %s", catalog.Name)
+		}
+		transport = transport.Clone()
+		transport.TLSClientConfig = &tls.Config{RootCAs: pool, MinVersion: tls.VersionTLS12}
+		auth.client = &http.Client{Transport: transport, Timeout: cs.Clients.HTTP.Timeout}
+	}
+	return auth, nil
+}
+
+// getURL fetches url, with the authentication of the catalog when it has
+// one.
+func getURL(ctx context.Context, cs *params.Run, auth *catalogAuth, url string) ([]byte, error) {
+	if auth == nil {
+		return cs.Clients.GetURL(ctx, url)
+	}
+	nctx, cancel := context.WithTimeout(ctx, clients.RequestMaxWaitTime)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(nctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	if auth.header != "" {
+		req.Header.Set(auth.header, auth.value)
+	}
+	res, err := auth.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+	if res.StatusCode < 200 || res.StatusCode >= 300 {
+		return nil, fmt.Errorf("Non-OK HTTP status: %d", res.StatusCode)
+	}
+	return io.ReadAll(res.Body)
+}
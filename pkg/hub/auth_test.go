@@ -0,0 +1,132 @@
+package hub
+
+import (
+	"encoding/pem"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	hubType "github.com/openshift-pipelines/pipelines-as-code/pkg/hub/vars"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/params"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/params/clients"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/params/info"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/params/settings"
+	"gotest.tools/v3/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+	rtesting "knative.dev/pkg/reconciler/testing"
+)
+
+func TestGetResourceAuthAndFailover(t *testing.T) {
+	ns := "pipelines-as-code"
+	mirror := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-Api-Key") != "mirror-token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		fmt.Fprintf(w, sampleArtifactHubManifest, "from mirror")
+	}))
+	defer mirror.Close()
+	primary := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer primary-token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		if r.URL.Path == "/api/v1/packages/tekton-task/tekton-catalog-tasks/down" {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		fmt.Fprintf(w, sampleArtifactHubManifest, "from primary")
+	}))
+	defer primary.Close()
+
+	ca := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: mirror.Certificate().Raw})
+	kube := fake.NewSimpleClientset(
+		&corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "primary-auth", Namespace: ns},
+			Data:       map[string][]byte{catalogTokenKey: []byte("primary-token")},
+		},
+		&corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "mirror-auth", Namespace: ns},
+			Data:       map[string][]byte{catalogTokenKey: []byte("mirror-token"), catalogCAKey: ca},
+		},
+	)
+
+	tests := []struct {
+		name     string
+		resource string
+		failover []string
+		want     string
+		wantErr  string
+	}{
+		{
+			name:     "from catalog with token",
+			resource: "git-clone",
+			failover: []string{"mirror"},
+			want:     "from primary",
+		},
+		{
+			name:     "from failover catalog with custom header and ca",
+			resource: "down",
+			failover: []string{"mirror"},
+			want:     "from mirror",
+		},
+		{
+			name:     "no failover catalog",
+			resource: "down",
+			wantErr:  "Non-OK HTTP status: 503",
+		},
+		{
+			name:     "unknown failover catalog",
+			resource: "down",
+			failover: []string{"unknown"},
+			wantErr:  "failover catalog unknown: could not get details for catalog name: unknown",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, _ := rtesting.SetupFakeContext(t)
+			ctx = info.StoreNS(ctx, ns)
+			catalogs := &sync.Map{}
+			catalogs.Store("default", settings.HubCatalog{
+				Index:    "default",
+				URL:      primary.URL,
+				Name:     "default",
+				Type:     hubType.ArtifactHubType,
+				Secret:   "primary-auth",
+				Failover: tt.failover,
+			})
+			catalogs.Store("mirror", settings.HubCatalog{
+				Index:      "1",
+				URL:        mirror.URL,
+				Name:       "default",
+				Type:       hubType.ArtifactHubType,
+				Secret:     "mirror-auth",
+				AuthHeader: "X-Api-Key",
+			})
+			cs := &params.Run{
+				Clients: clients.Clients{HTTP: http.Client{}, Kube: kube},
+				Info: info.Info{Pac: &info.PacOpts{
+					Settings: settings.Settings{HubCatalogs: catalogs},
+				}},
+			}
+			got, err := GetResource(ctx, cs, "default", tt.resource, "task")
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			assert.NilError(t, err)
+			assert.Equal(t, got, tt.want)
+		})
+	}
+}
+
+func TestNewCatalogAuthMissingSecret(t *testing.T) {
+	ctx, _ := rtesting.SetupFakeContext(t)
+	cs := &params.Run{Clients: clients.Clients{Kube: fake.NewSimpleClientset()}}
+	_, err := newCatalogAuth(ctx, cs, settings.HubCatalog{Name: "mirror", Secret: "missing"})
+	assert.ErrorContains(t, err, "cannot get secret missing of catalog mirror")
+}
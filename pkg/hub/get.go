@@ -16,12 +16,43 @@ package hub
 
 import (
 	"context"
+	"errors"
+	"fmt"
 
 	"github.com/openshift-pipelines/pipelines-as-code/pkg/params"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/params/settings"
 )
 
-// GetResource returns a resource from the hub.
+// GetResource returns a resource from the hub, the failover catalogs of the
+// catalog are tried in order when it cannot be fetched from it.
 func GetResource(ctx context.Context, cs *params.Run, catalogName, resource, kind string) (string, error) {
+	data, err := getCatalogResource(ctx, cs, catalogName, resource, kind)
+	if err == nil {
+		return data, nil
+	}
+	value, ok := cs.Info.Pac.HubCatalogs.Load(catalogName)
+	if !ok {
+		return "", err
+	}
+	catalog, ok := value.(settings.HubCatalog)
+	if !ok {
+		return "", err
+	}
+	errs := []error{err}
+	for _, failover := range catalog.Failover {
+		if failover == catalogName {
+			continue
+		}
+		data, ferr := getCatalogResource(ctx, cs, failover, resource, kind)
+		if ferr == nil {
+			return data, nil
+		}
+		errs = append(errs, fmt.Errorf("failover catalog %s: %w", failover, ferr))
+	}
+	return "", errors.Join(errs...)
+}
+
+func getCatalogResource(ctx context.Context, cs *params.Run, catalogName, resource, kind string) (string, error) {
 	client, err := NewClient(ctx, cs, catalogName)
 	if err != nil {
 		return "", err
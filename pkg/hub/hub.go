@@ -29,7 +29,7 @@ type Client interface {
 }
 
 // NewClient returns a new hub client.
-func NewClient(_ context.Context, cs *params.Run, catalogName string) (Client, error) {
+func NewClient(ctx context.Context, cs *params.Run, catalogName string) (Client, error) {
 	value, ok := cs.Info.Pac.HubCatalogs.Load(catalogName)
 	if !ok {
 		return nil, fmt.Errorf("could not get details for catalog name: %s", catalogName)
@@ -39,11 +39,16 @@ func NewClient(_ context.Context, cs *params.Run, catalogName string) (Client, e
 		return nil, fmt.Errorf("could not get details for catalog name: %s", catalogName)
 	}
 
+	auth, err := newCatalogAuth(ctx, cs, catalogValue)
+	if err != nil {
+		return nil, err
+	}
+
 	switch catalogValue.Type {
 	case hubtypes.TektonHubType:
-		return newTektonHubClient(cs, catalogValue.URL, catalogValue.Name), nil
+		return newTektonHubClient(cs, catalogValue.URL, catalogValue.Name, auth), nil
 	default:
 		// defaulting to Artifact Hub
-		return newArtifactHubClient(cs, catalogValue.URL, catalogValue.Name), nil
+		return newArtifactHubClient(cs, catalogValue.URL, catalogValue.Name, auth), nil
 	}
 }
@@ -28,11 +28,12 @@ type tektonHubClient struct {
 	params *params.Run
 	url    string
 	name   string
+	auth   *catalogAuth
 }
 
 // newTektonHubClient returns a new Tekton Hub client.
-func newTektonHubClient(params *params.Run, url, name string) Client {
-	return &tektonHubClient{params: params, url: url, name: name}
+func newTektonHubClient(params *params.Run, url, name string, auth *catalogAuth) Client {
+	return &tektonHubClient{params: params, url: url, name: name, auth: auth}
 }
 
 type resourceVersionDataResponseBody struct {
@@ -89,7 +90,7 @@ func (t *tektonHubClient) GetResource(ctx context.Context, _, resource, kind str
 		return "", fmt.Errorf("could not fetch remote %s %s, hub API returned: %w", kind, resource, err)
 	}
 
-	data, err := t.params.Clients.GetURL(ctx, rawURL)
+	data, err := getURL(ctx, t.params, t.auth, rawURL)
 	if err != nil {
 		return "", fmt.Errorf("could not fetch remote %s %s, hub API returned: %w", kind, resource, err)
 	}
@@ -102,7 +103,7 @@ func (t *tektonHubClient) getSpecificVersion(ctx context.Context, catalogName, r
 	resourceName := split[0]
 	url := fmt.Sprintf("%s/resource/%s/%s/%s/%s", t.url, catalogName, kind, resourceName, version)
 	hr := hubResourceVersion{}
-	data, err := t.params.Clients.GetURL(ctx, url)
+	data, err := getURL(ctx, t.params, t.auth, url)
 	if err != nil {
 		return "", fmt.Errorf("could not fetch specific %s version from the hub %s:%s: %w", kind, resource, version, err)
 	}
@@ -116,7 +117,7 @@ func (t *tektonHubClient) getSpecificVersion(ctx context.Context, catalogName, r
 func (t *tektonHubClient) getLatestVersion(ctx context.Context, catalogName, resource, kind string) (string, error) {
 	url := fmt.Sprintf("%s/resource/%s/%s/%s", t.url, catalogName, kind, resource)
 	hr := new(hubResource)
-	data, err := t.params.Clients.GetURL(ctx, url)
+	data, err := getURL(ctx, t.params, t.auth, url)
 	if err != nil {
 		return "", err
 	}
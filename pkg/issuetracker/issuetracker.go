@@ -0,0 +1,65 @@
+package issuetracker
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/apis/pipelinesascode/v1alpha1"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/params/info"
+)
+
+const (
+	// DefaultTicketRegex matches the keys of the Jira issues like PROJ-123.
+	DefaultTicketRegex = `\b[A-Z][A-Z0-9_]+-[0-9]+\b`
+	// TicketIDPlaceholder is replaced by the ticket ID in the URL of the
+	// tickets.
+	TicketIDPlaceholder = "{{ ticket_id }}"
+)
+
+// Get returns the issue tracker of the settings of a repository, nil when it
+// has none.
+func Get(repo *v1alpha1.Repository) *v1alpha1.IssueTracker {
+	if repo == nil || repo.Spec.Settings == nil {
+		return nil
+	}
+	return repo.Spec.Settings.IssueTracker
+}
+
+// TicketID returns the first ticket ID found in the source branch, the commit
+// message or the pull request title of the event, an empty string when the
+// repository has no issue tracker or no ticket has been found.
+func TicketID(repo *v1alpha1.Repository, event *info.Event) (string, error) {
+	tracker := Get(repo)
+	if tracker == nil || event == nil {
+		return "", nil
+	}
+	expr := tracker.TicketRegex
+	if expr == "" {
+		expr = DefaultTicketRegex
+	}
+	re, err := regexp.Compile(expr)
+	if err != nil {
+		return "", fmt.Errorf("invalid ticket_regex %q of repository %s: %w", expr, repo.GetName(), err)
+	}
+	for _, text := range []string{strings.TrimPrefix(event.HeadBranch, "refs/heads/"), event.SHATitle, event.PullRequestTitle} {
+		match := re.FindStringSubmatch(text)
+		switch {
+		case len(match) > 1 && match[1] != "":
+			return match[1], nil
+		case len(match) == 1:
+			return match[0], nil
+		}
+	}
+	return "", nil
+}
+
+// TicketURL returns the URL of a ticket, an empty string when the issue
+// tracker of the repository has no URL.
+func TicketURL(repo *v1alpha1.Repository, ticketID string) string {
+	tracker := Get(repo)
+	if tracker == nil || tracker.URL == "" || ticketID == "" {
+		return ""
+	}
+	return strings.ReplaceAll(tracker.URL, TicketIDPlaceholder, ticketID)
+}
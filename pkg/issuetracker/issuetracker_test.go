@@ -0,0 +1,84 @@
+package issuetracker
+
+import (
+	"testing"
+
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/apis/pipelinesascode/v1alpha1"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/params/info"
+	"gotest.tools/v3/assert"
+)
+
+func TestTicketID(t *testing.T) {
+	tests := []struct {
+		name    string
+		tracker *v1alpha1.IssueTracker
+		event   *info.Event
+		want    string
+		wantErr string
+	}{
+		{
+			name:  "no issue tracker",
+			event: &info.Event{HeadBranch: "PROJ-1-fix"},
+		},
+		{
+			name:    "source branch",
+			tracker: &v1alpha1.IssueTracker{},
+			event:   &info.Event{HeadBranch: "refs/heads/feature/PROJ-12-login", SHATitle: "OTHER-3 fix"},
+			want:    "PROJ-12",
+		},
+		{
+			name:    "commit message",
+			tracker: &v1alpha1.IssueTracker{},
+			event:   &info.Event{HeadBranch: "main", SHATitle: "fix the login (PROJ-7)"},
+			want:    "PROJ-7",
+		},
+		{
+			name:    "pull request title",
+			tracker: &v1alpha1.IssueTracker{},
+			event:   &info.Event{HeadBranch: "login", SHATitle: "fix", PullRequestTitle: "[PROJ-8] Login"},
+			want:    "PROJ-8",
+		},
+		{
+			name:    "regex group",
+			tracker: &v1alpha1.IssueTracker{TicketRegex: `#([0-9]+)`},
+			event:   &info.Event{HeadBranch: "login", SHATitle: "fix #42"},
+			want:    "42",
+		},
+		{
+			name:    "no ticket",
+			tracker: &v1alpha1.IssueTracker{},
+			event:   &info.Event{HeadBranch: "login", SHATitle: "fix the login"},
+		},
+		{
+			name:    "invalid regex",
+			tracker: &v1alpha1.IssueTracker{TicketRegex: `(`},
+			event:   &info.Event{HeadBranch: "login"},
+			wantErr: "invalid ticket_regex \"(\" of repository repo: error parsing regexp: missing closing ): `(`",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &v1alpha1.Repository{}
+			repo.Name = "repo"
+			if tt.tracker != nil {
+				repo.Spec.Settings = &v1alpha1.Settings{IssueTracker: tt.tracker}
+			}
+			got, err := TicketID(repo, tt.event)
+			if tt.wantErr != "" {
+				assert.Error(t, err, tt.wantErr)
+				return
+			}
+			assert.NilError(t, err)
+			assert.Equal(t, got, tt.want)
+		})
+	}
+}
+
+func TestTicketURL(t *testing.T) {
+	repo := &v1alpha1.Repository{Spec: v1alpha1.RepositorySpec{Settings: &v1alpha1.Settings{
+		IssueTracker: &v1alpha1.IssueTracker{URL: "https://example.atlassian.net/browse/{{ ticket_id }}"},
+	}}}
+	assert.Equal(t, TicketURL(repo, "PROJ-12"), "https://example.atlassian.net/browse/PROJ-12")
+	assert.Equal(t, TicketURL(repo, ""), "")
+	assert.Equal(t, TicketURL(&v1alpha1.Repository{}, "PROJ-12"), "")
+}
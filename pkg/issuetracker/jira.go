@@ -0,0 +1,50 @@
+package issuetracker
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/apis/pipelinesascode/v1alpha1"
+)
+
+// DefaultJiraTokenKey is the key of the token in the secret of the Jira
+// integration when it has none.
+const DefaultJiraTokenKey = "token"
+
+// CommentJira adds a comment to the Jira issue of a ticket with the REST API,
+// the token is a Jira Cloud API token when the integration has a user or a
+// Jira Data Center personal access token otherwise.
+func CommentJira(ctx context.Context, client *http.Client, jira *v1alpha1.JiraIntegration, token, ticketID, comment string) error {
+	body, err := json.Marshal(map[string]string{"body": comment})
+	if err != nil {
+		return err
+	}
+	commentURL := fmt.Sprintf("%s/rest/api/2/issue/%s/comment", strings.TrimSuffix(jira.URL, "/"), url.PathEscape(ticketID))
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, commentURL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "application/json")
+	if jira.User != "" {
+		req.SetBasicAuth(jira.User, token)
+	} else {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("cannot comment on jira issue %s: %w", ticketID, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusCreated && resp.StatusCode != http.StatusOK {
+		message, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return fmt.Errorf("cannot comment on jira issue %s, jira has answered with status %d: %s", ticketID, resp.StatusCode, strings.TrimSpace(string(message)))
+	}
+	return nil
+}
@@ -0,0 +1,66 @@
+package issuetracker
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/apis/pipelinesascode/v1alpha1"
+	"gotest.tools/v3/assert"
+)
+
+func TestCommentJira(t *testing.T) {
+	tests := []struct {
+		name     string
+		user     string
+		status   int
+		wantAuth string
+		wantErr  string
+	}{
+		{
+			name:     "cloud api token",
+			user:     "bot@example.com",
+			status:   http.StatusCreated,
+			wantAuth: "Basic Ym90QGV4YW1wbGUuY29tOnMzY3IzdA==",
+		},
+		{
+			name:     "personal access token",
+			status:   http.StatusCreated,
+			wantAuth: "Bearer s3cr3t",
+		},
+		{
+			name:     "unknown issue",
+			status:   http.StatusNotFound,
+			wantAuth: "Bearer s3cr3t",
+			wantErr:  `cannot comment on jira issue PROJ-12, jira has answered with status 404: {"errorMessages": ["Issue does not exist"]}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, r.Method, http.MethodPost)
+				assert.Equal(t, r.URL.Path, "/rest/api/2/issue/PROJ-12/comment")
+				assert.Equal(t, r.Header.Get("Authorization"), tt.wantAuth)
+				body := map[string]string{}
+				assert.NilError(t, json.NewDecoder(r.Body).Decode(&body))
+				assert.Equal(t, body["body"], "PipelineRun build has succeeded")
+				w.WriteHeader(tt.status)
+				if tt.status != http.StatusCreated {
+					fmt.Fprint(w, `{"errorMessages": ["Issue does not exist"]}`)
+				}
+			}))
+			defer server.Close()
+
+			jira := &v1alpha1.JiraIntegration{URL: server.URL + "/", User: tt.user}
+			err := CommentJira(context.Background(), server.Client(), jira, "s3cr3t", "PROJ-12", "PipelineRun build has succeeded")
+			if tt.wantErr != "" {
+				assert.Error(t, err, tt.wantErr)
+				return
+			}
+			assert.NilError(t, err)
+		})
+	}
+}
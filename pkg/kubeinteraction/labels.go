@@ -8,6 +8,8 @@ import (
 	"github.com/openshift-pipelines/pipelines-as-code/pkg/apis/pipelinesascode/keys"
 	apipac "github.com/openshift-pipelines/pipelines-as-code/pkg/apis/pipelinesascode/v1alpha1"
 	"github.com/openshift-pipelines/pipelines-as-code/pkg/formatting"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/issuetracker"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/opscomments"
 	"github.com/openshift-pipelines/pipelines-as-code/pkg/params"
 	"github.com/openshift-pipelines/pipelines-as-code/pkg/params/info"
 	"github.com/openshift-pipelines/pipelines-as-code/pkg/params/version"
@@ -17,6 +19,8 @@ import (
 const (
 	StateStarted   = "started"
 	StateQueued    = "queued"
+	StateWaiting   = "waiting"
+	StateScheduled = "scheduled"
 	StateCompleted = "completed"
 	StateFailed    = "failed"
 )
@@ -59,6 +63,15 @@ func AddLabelsAndAnnotations(event *info.Event, pipelineRun *tektonv1.PipelineRu
 			paramsinfo.Controller.Name, paramsinfo.Controller.Configmap, paramsinfo.Controller.Secret, paramsinfo.Controller.GlobalRepository),
 	}
 
+	if approver := approvedBy(event, repo); approver != "" {
+		annotations[keys.ApprovedBy] = approver
+	}
+
+	// an invalid ticket_regex is reported with the ticket_id param
+	if ticketID, _ := issuetracker.TicketID(repo, event); ticketID != "" {
+		annotations[keys.TicketID] = ticketID
+	}
+
 	if event.PullRequestNumber != 0 {
 		labels[keys.PullRequest] = strconv.Itoa(event.PullRequestNumber)
 		annotations[keys.PullRequest] = strconv.Itoa(event.PullRequestNumber)
@@ -101,3 +114,16 @@ func AddLabelsAndAnnotations(event *info.Event, pipelineRun *tektonv1.PipelineRu
 
 	return nil
 }
+
+// approvedBy returns the user who approved running CI on the pull request: the
+// sender of the /ok-to-test comment or, when the repository always requires an
+// approval, the sender of the GitOps comment which triggered the run.
+func approvedBy(event *info.Event, repo *apipac.Repository) string {
+	if event.EventType == opscomments.OkToTestCommentEventType.String() {
+		return event.Sender
+	}
+	if repo.Spec.GetApprovalPolicy() == apipac.ApprovalPolicyAlways && opscomments.IsAnyOpsEventType(event.EventType) {
+		return event.Sender
+	}
+	return ""
+}
@@ -6,6 +6,7 @@ import (
 
 	"github.com/openshift-pipelines/pipelines-as-code/pkg/apis/pipelinesascode/keys"
 	apipac "github.com/openshift-pipelines/pipelines-as-code/pkg/apis/pipelinesascode/v1alpha1"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/opscomments"
 	"github.com/openshift-pipelines/pipelines-as-code/pkg/params"
 	"github.com/openshift-pipelines/pipelines-as-code/pkg/params/info"
 	tektonv1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
@@ -84,3 +85,57 @@ func TestAddLabelsAndAnnotations(t *testing.T) {
 		})
 	}
 }
+
+func TestAddLabelsAndAnnotationsApprovedBy(t *testing.T) {
+	tests := []struct {
+		name           string
+		eventType      string
+		approvalPolicy string
+		want           string
+	}{
+		{
+			name:      "ok-to-test comment",
+			eventType: opscomments.OkToTestCommentEventType.String(),
+			want:      "approver",
+		},
+		{
+			name:      "retest comment",
+			eventType: opscomments.RetestAllCommentEventType.String(),
+		},
+		{
+			name:           "retest comment with the always approval policy",
+			eventType:      opscomments.RetestAllCommentEventType.String(),
+			approvalPolicy: apipac.ApprovalPolicyAlways,
+			want:           "approver",
+		},
+		{
+			name:           "pull request with the never approval policy",
+			eventType:      "pull_request",
+			approvalPolicy: apipac.ApprovalPolicyNever,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			event := info.NewEvent()
+			event.Sender = "approver"
+			event.EventType = tt.eventType
+			pipelineRun := &tektonv1.PipelineRun{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels:      map[string]string{},
+					Annotations: map[string]string{},
+				},
+			}
+			repo := &apipac.Repository{
+				ObjectMeta: metav1.ObjectMeta{Name: "repo"},
+				Spec: apipac.RepositorySpec{
+					Settings: &apipac.Settings{ApprovalPolicy: tt.approvalPolicy},
+				},
+			}
+			paramsRun := &params.Run{Info: info.Info{Controller: &info.ControllerInfo{}}}
+			assert.NilError(t, AddLabelsAndAnnotations(event, pipelineRun, repo, &info.ProviderConfig{}, paramsRun))
+			approver, ok := pipelineRun.Annotations[keys.ApprovedBy]
+			assert.Equal(t, ok, tt.want != "")
+			assert.Equal(t, approver, tt.want)
+		})
+	}
+}
@@ -1,15 +1,85 @@
 package kubeinteraction
 
 import (
+	"bytes"
 	"context"
+	"fmt"
 	"io"
+	"net/http"
+	"os"
+	"strings"
 
 	"github.com/google/go-github/v74/github"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/apis/pipelinesascode/keys"
 	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
+// defaultLogLimitBytes is the maximum size of the logs kept when the pac
+// settings are not available, i.e: from the cli.
+const defaultLogLimitBytes = 1024 * 1024
+
+// serviceAccountTokenFile is the token used to authenticate to the Tekton
+// Results API.
+var serviceAccountTokenFile = "/var/run/secrets/kubernetes.io/serviceaccount/token" //nolint:gosec
+
+func (k Interaction) logLimitBytes() int64 {
+	if k.Run.Info.Pac != nil {
+		if limit := k.Run.Info.GetPacOpts().ErrorLogSnippetMaxBytes; limit > 0 {
+			return int64(limit)
+		}
+	}
+	return defaultLogLimitBytes
+}
+
+// readTail reads r until the end and only keeps its last limit bytes, without
+// the line truncated by the limit.
+func readTail(r io.Reader, limit int64) (string, error) {
+	var buf []byte
+	chunk := make([]byte, 32*1024)
+	truncated := false
+	for {
+		n, err := r.Read(chunk)
+		buf = append(buf, chunk[:n]...)
+		if int64(len(buf)) > 2*limit {
+			buf = append([]byte{}, buf[int64(len(buf))-limit:]...)
+			truncated = true
+		}
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return "", err
+		}
+	}
+	if int64(len(buf)) > limit {
+		buf = buf[int64(len(buf))-limit:]
+		truncated = true
+	}
+	if truncated {
+		if i := bytes.IndexByte(buf, '\n'); i >= 0 {
+			buf = buf[i+1:]
+		}
+	}
+	return string(buf), nil
+}
+
+// tailLines returns the last n lines of log, all of them when n is not
+// positive.
+func tailLines(log string, n int64) string {
+	if n <= 0 {
+		return log
+	}
+	lines := strings.Split(strings.TrimSuffix(log, "\n"), "\n")
+	if int64(len(lines)) > n {
+		lines = lines[int64(len(lines))-n:]
+	}
+	return strings.Join(lines, "\n")
+}
+
 // GetPodLogs of a ns on a podname and container, tailLines is the number of
-// line to tail -1 mean unlimited.
+// line to tail -1 mean unlimited. The logs are streamed and only the last
+// error-log-snippet-max-bytes of them are kept.
 func (k Interaction) GetPodLogs(ctx context.Context, ns, podName, containerName string, tailLines int64) (string, error) {
 	kclient := k.Run.Clients.Kube.CoreV1()
 	pdOpts := &corev1.PodLogOptions{
@@ -22,6 +92,51 @@ func (k Interaction) GetPodLogs(ctx context.Context, ns, podName, containerName
 	if err != nil {
 		return "", err
 	}
-	log, err := io.ReadAll(ios)
-	return string(log), err
+	defer ios.Close()
+	return readTail(ios, k.logLimitBytes())
+}
+
+// GetArchivedLogs gets the logs of a TaskRun whose pod is gone from the
+// Tekton Results API, which may store them in a S3 bucket or any other
+// storage it supports. The logs of all the steps of the TaskRun are returned
+// as Tekton Results stores them as a whole. It returns an empty log when the
+// tekton-results-api-url setting is not set.
+func (k Interaction) GetArchivedLogs(ctx context.Context, ns, taskRunName string, tailLineNumber int64) (string, error) {
+	if k.Run.Info.Pac == nil {
+		return "", nil
+	}
+	resultsURL := k.Run.Info.GetPacOpts().TektonResultsAPIURL
+	if resultsURL == "" {
+		return "", nil
+	}
+	tr, err := k.Run.Clients.Tekton.TektonV1().TaskRuns(ns).Get(ctx, taskRunName, metav1.GetOptions{})
+	if err != nil {
+		return "", err
+	}
+	logName, ok := tr.GetAnnotations()[keys.ResultsLog]
+	if !ok {
+		return "", fmt.Errorf("taskrun %s has no log stored in tekton results", taskRunName)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet,
+		fmt.Sprintf("%s/apis/results.tekton.dev/v1alpha2/parents/%s", strings.TrimSuffix(resultsURL, "/"), logName), nil)
+	if err != nil {
+		return "", err
+	}
+	if token, err := os.ReadFile(serviceAccountTokenFile); err == nil {
+		req.Header.Set("Authorization", "Bearer "+strings.TrimSpace(string(token)))
+	}
+	res, err := k.Run.Clients.HTTP.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("cannot get the logs of taskrun %s from tekton results: %w", taskRunName, err)
+	}
+	defer res.Body.Close()
+	if res.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("cannot get the logs of taskrun %s from tekton results: %d %s", taskRunName, res.StatusCode, http.StatusText(res.StatusCode))
+	}
+	log, err := readTail(res.Body, k.logLimitBytes())
+	if err != nil {
+		return "", err
+	}
+	return tailLines(log, tailLineNumber), nil
 }
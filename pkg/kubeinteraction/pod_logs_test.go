@@ -0,0 +1,124 @@
+package kubeinteraction
+
+import (
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/apis/pipelinesascode/keys"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/params"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/params/clients"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/params/info"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/params/settings"
+	testclient "github.com/openshift-pipelines/pipelines-as-code/pkg/test/clients"
+	httptesthelper "github.com/openshift-pipelines/pipelines-as-code/pkg/test/http"
+	tektonv1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+	"gotest.tools/v3/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	rtesting "knative.dev/pkg/reconciler/testing"
+)
+
+func TestReadTail(t *testing.T) {
+	tests := []struct {
+		name  string
+		log   string
+		limit int64
+		want  string
+	}{
+		{
+			name:  "under the limit",
+			log:   "one\ntwo\n",
+			limit: 100,
+			want:  "one\ntwo\n",
+		},
+		{
+			name:  "over the limit drops the truncated line",
+			log:   "one\ntwo\nthree\n",
+			limit: 8,
+			want:  "three\n",
+		},
+		{
+			name:  "much bigger than the limit",
+			log:   strings.Repeat("line\n", 100000) + "error\n",
+			limit: 10,
+			want:  "error\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := readTail(strings.NewReader(tt.log), tt.limit)
+			assert.NilError(t, err)
+			assert.Equal(t, got, tt.want)
+		})
+	}
+}
+
+func TestGetArchivedLogs(t *testing.T) {
+	logName := "ns/results/uid/logs/uid"
+	tokenFile := filepath.Join(t.TempDir(), "token")
+	assert.NilError(t, os.WriteFile(tokenFile, []byte("token\n"), 0o600))
+	serviceAccountTokenFile = tokenFile
+
+	tests := []struct {
+		name        string
+		resultsURL  string
+		annotations map[string]string
+		status      int
+		want        string
+		wantErr     string
+	}{
+		{
+			name: "tekton results not configured",
+		},
+		{
+			name:        "logs from tekton results",
+			resultsURL:  "https://results",
+			annotations: map[string]string{keys.ResultsLog: logName},
+			status:      http.StatusOK,
+			want:        "two\nthree",
+		},
+		{
+			name:       "taskrun without logs",
+			resultsURL: "https://results",
+			wantErr:    "taskrun taskrun has no log stored in tekton results",
+		},
+		{
+			name:        "tekton results error",
+			resultsURL:  "https://results",
+			annotations: map[string]string{keys.ResultsLog: logName},
+			status:      http.StatusNotFound,
+			wantErr:     "cannot get the logs of taskrun taskrun from tekton results: 404",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, _ := rtesting.SetupFakeContext(t)
+			stdata, _ := testclient.SeedTestData(t, ctx, testclient.Data{
+				TaskRuns: []*tektonv1.TaskRun{{
+					ObjectMeta: metav1.ObjectMeta{Name: "taskrun", Namespace: "ns", Annotations: tt.annotations},
+				}},
+			})
+			httpClient := httptesthelper.MakeHTTPTestClient(map[string]map[string]string{
+				fmt.Sprintf("https://results/apis/results.tekton.dev/v1alpha2/parents/%s", logName): {
+					"body": "one\ntwo\nthree\n",
+					"code": fmt.Sprintf("%d", tt.status),
+				},
+			})
+			run := &params.Run{
+				Clients: clients.Clients{Tekton: stdata.Pipeline, HTTP: *httpClient},
+				Info:    info.Info{Pac: &info.PacOpts{Settings: settings.Settings{TektonResultsAPIURL: tt.resultsURL}}},
+			}
+			k := Interaction{Run: run}
+			got, err := k.GetArchivedLogs(ctx, "ns", "taskrun", 2)
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			assert.NilError(t, err)
+			assert.Equal(t, got, tt.want)
+		})
+	}
+}
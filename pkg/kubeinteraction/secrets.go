@@ -67,3 +67,24 @@ func (k Interaction) CreateSecret(ctx context.Context, ns string, secret *corev1
 	_, err := k.Run.Clients.Kube.CoreV1().Secrets(ns).Create(ctx, secret, metav1.CreateOptions{})
 	return err
 }
+
+// CreateOrUpdateSecret creates the secret or replaces the data, labels and
+// annotations of the existing one.
+func (k Interaction) CreateOrUpdateSecret(ctx context.Context, ns string, secret *corev1.Secret) error {
+	_, err := k.Run.Clients.Kube.CoreV1().Secrets(ns).Create(ctx, secret, metav1.CreateOptions{})
+	if !errors.IsAlreadyExists(err) {
+		return err
+	}
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		existing, err := k.Run.Clients.Kube.CoreV1().Secrets(ns).Get(ctx, secret.GetName(), metav1.GetOptions{})
+		if err != nil {
+			return err
+		}
+		existing.Labels = secret.Labels
+		existing.Annotations = secret.Annotations
+		existing.Data = secret.Data
+		existing.StringData = secret.StringData
+		_, err = k.Run.Clients.Kube.CoreV1().Secrets(ns).Update(ctx, existing, metav1.UpdateOptions{})
+		return err
+	})
+}
@@ -108,3 +108,54 @@ func TestUpdateSecretWithOwnerRef(t *testing.T) {
 	assert.Equal(t, updatedSecret.OwnerReferences[0].Kind, "PipelineRun")
 	assert.Equal(t, updatedSecret.OwnerReferences[0].Name, pr.Name)
 }
+
+func TestCreateOrUpdateSecret(t *testing.T) {
+	ns := "there"
+	tests := []struct {
+		name     string
+		existing []*corev1.Secret
+	}{
+		{
+			name: "create",
+		},
+		{
+			name: "update",
+			existing: []*corev1.Secret{
+				{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: ns,
+						Name:      "ci-variables",
+						Labels:    map[string]string{"old": "label"},
+					},
+					StringData: map[string]string{"TOKEN": "old"},
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, _ := rtesting.SetupFakeContext(t)
+			stdata, _ := testclient.SeedTestData(t, ctx, testclient.Data{Secret: tt.existing})
+			kint := Interaction{
+				Run: &params.Run{
+					Clients: clients.Clients{
+						Kube: stdata.Kube,
+					},
+				},
+			}
+			secret := &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:   "ci-variables",
+					Labels: map[string]string{"new": "label"},
+				},
+				StringData: map[string]string{"TOKEN": "new"},
+			}
+			assert.NilError(t, kint.CreateOrUpdateSecret(ctx, ns, secret))
+
+			got, err := stdata.Kube.CoreV1().Secrets(ns).Get(ctx, "ci-variables", metav1.GetOptions{})
+			assert.NilError(t, err)
+			assert.DeepEqual(t, got.StringData, map[string]string{"TOKEN": "new"})
+			assert.DeepEqual(t, got.Labels, map[string]string{"new": "label"})
+		})
+	}
+}
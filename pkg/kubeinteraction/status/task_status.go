@@ -77,7 +77,7 @@ func CollectFailedTasksLogSnippet(ctx context.Context, cs *params.Run, kinteract
 	}
 
 	trStatus := GetStatusFromTaskStatusOrFromAsking(ctx, pr, cs)
-	for _, task := range trStatus {
+	for trName, task := range trStatus {
 		if task.Status == nil {
 			continue
 		}
@@ -102,20 +102,27 @@ func CollectFailedTasksLogSnippet(ctx context.Context, cs *params.Run, kinteract
 		}
 
 		if kinteract != nil {
+			// only the first failed step is the one that made the task fail,
+			// the next ones have been skipped
 			for _, step := range task.Status.Steps {
-				if step.Terminated != nil && step.Terminated.ExitCode != 0 {
-					log, err := kinteract.GetPodLogs(ctx, pr.GetNamespace(), task.Status.PodName, step.Container, numLines)
-					if err != nil {
-						cs.Clients.Log.Errorf("cannot get pod logs: %w", err)
-						continue
-					}
-					trimmed := strings.TrimSpace(log)
-					if strings.HasSuffix(trimmed, " Skipping step because a previous step failed") {
-						continue
-					}
-					// see if a pattern match from errRe
-					ti.LogSnippet = strings.TrimSpace(trimmed)
+				if step.Terminated == nil || step.Terminated.ExitCode == 0 {
+					continue
 				}
+				log, err := kinteract.GetPodLogs(ctx, pr.GetNamespace(), task.Status.PodName, step.Container, numLines)
+				if errors.IsNotFound(err) {
+					// the pod is gone, its logs may have been archived
+					log, err = kinteract.GetArchivedLogs(ctx, pr.GetNamespace(), trName, numLines)
+				}
+				if err != nil {
+					cs.Clients.Log.Errorf("cannot get pod logs: %w", err)
+					break
+				}
+				trimmed := strings.TrimSpace(log)
+				if strings.HasSuffix(trimmed, " Skipping step because a previous step failed") {
+					continue
+				}
+				ti.LogSnippet = trimmed
+				break
 			}
 		}
 		failureReasons[task.PipelineTaskName] = ti
@@ -30,6 +30,7 @@ func TestCollectFailedTasksLogSnippet(t *testing.T) {
 		message, status   string
 		wantFailure       int
 		podOutput         string
+		archivedOutput    string
 	}{
 		{
 			name:        "no failures",
@@ -45,6 +46,13 @@ func TestCollectFailedTasksLogSnippet(t *testing.T) {
 			wantFailure: 1,
 			displayName: "A task",
 		},
+		{
+			name:           "failure pod gone with archived logs",
+			status:         "Failed",
+			message:        "i am gonna to make you fail",
+			archivedOutput: "hahah i am the devil of the archive",
+			wantFailure:    1,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -104,11 +112,20 @@ func TestCollectFailedTasksLogSnippet(t *testing.T) {
 					"task1": tt.podOutput,
 				}
 			}
+			if tt.archivedOutput != "" {
+				intf.PodsGone = true
+				intf.GetArchivedLogsOutput = map[string]string{
+					"task1": tt.archivedOutput,
+				}
+			}
 			got := CollectFailedTasksLogSnippet(ctx, cs, intf, pr, 1)
 			assert.Equal(t, tt.wantFailure, len(got))
 			if tt.podOutput != "" {
 				assert.Equal(t, tt.podOutput, got["task1"].LogSnippet)
 			}
+			if tt.archivedOutput != "" {
+				assert.Equal(t, tt.archivedOutput, got["task1"].LogSnippet)
+			}
 			if tt.displayName != "" {
 				assert.Equal(t, tt.displayName, got["task1"].DisplayName)
 			}
@@ -0,0 +1,88 @@
+package lockfile
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strings"
+
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	// Filename is the name of the lockfile inside the .tekton directory.
+	Filename = "pac.lock"
+
+	digestPrefix = "sha256:"
+
+	header = "# Generated by tkn pac lock, the remote tasks and pipelines are checked\n# against these digests when resolving the PipelineRuns.\n"
+)
+
+// Lock pins the remote tasks and pipelines referenced in the annotations of
+// the PipelineRuns to the digest of their content.
+type Lock struct {
+	// Resources maps the annotation value of a remote task or pipeline to
+	// the digest of its content.
+	Resources map[string]string `json:"resources"`
+}
+
+// New returns an empty lock.
+func New() *Lock {
+	return &Lock{Resources: map[string]string{}}
+}
+
+// Parse parses the content of a lockfile.
+func Parse(data []byte) (*Lock, error) {
+	lock := New()
+	if err := yaml.UnmarshalStrict(data, lock); err != nil {
+		return nil, fmt.Errorf("cannot parse %s: %w", Filename, err)
+	}
+	if lock.Resources == nil {
+		lock.Resources = map[string]string{}
+	}
+	for uri, digest := range lock.Resources {
+		if !strings.HasPrefix(digest, digestPrefix) {
+			return nil, fmt.Errorf("cannot parse %s: digest %q of %s is not a %s digest", Filename, digest, uri, strings.TrimSuffix(digestPrefix, ":"))
+		}
+	}
+	return lock, nil
+}
+
+// Marshal returns the content of the lockfile.
+func (l *Lock) Marshal() ([]byte, error) {
+	data, err := yaml.Marshal(l)
+	if err != nil {
+		return nil, err
+	}
+	return append([]byte(header), data...), nil
+}
+
+// Digest returns the digest of the content of a task or pipeline.
+func Digest(data string) string {
+	sum := sha256.Sum256([]byte(data))
+	return digestPrefix + hex.EncodeToString(sum[:])
+}
+
+// IsRemote tells whether an annotation value references a task or pipeline
+// outside of the repository, files inside the repository are versioned with
+// the PipelineRuns and are not pinned.
+func IsRemote(uri string) bool {
+	return strings.Contains(uri, "://") || !strings.Contains(uri, "/")
+}
+
+// Add pins uri to the digest of data.
+func (l *Lock) Add(uri, data string) {
+	l.Resources[uri] = Digest(data)
+}
+
+// Verify checks that data, fetched from uri, matches its digest in the lock.
+func (l *Lock) Verify(uri, data string) error {
+	digest, ok := l.Resources[uri]
+	if !ok {
+		return fmt.Errorf("%s is not pinned in %s, run tkn pac lock to update it", uri, Filename)
+	}
+	if got := Digest(data); got != digest {
+		return fmt.Errorf("%s has digest %s but %s pins it to %s, it may have been modified upstream", uri, got, Filename, digest)
+	}
+	return nil
+}
@@ -0,0 +1,79 @@
+package lockfile
+
+import (
+	"testing"
+
+	"gotest.tools/v3/assert"
+)
+
+func TestIsRemote(t *testing.T) {
+	tests := []struct {
+		uri  string
+		want bool
+	}{
+		{uri: "git-clone", want: true},
+		{uri: "git-clone:0.9", want: true},
+		{uri: "custom://git-clone", want: true},
+		{uri: "https://example.com/task.yaml", want: true},
+		{uri: ".tekton/tasks/task.yaml", want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.uri, func(t *testing.T) {
+			assert.Equal(t, IsRemote(tt.uri), tt.want)
+		})
+	}
+}
+
+func TestLock(t *testing.T) {
+	lock := New()
+	lock.Add("git-clone", "task")
+	lock.Add("https://example.com/pipeline.yaml", "pipeline")
+
+	data, err := lock.Marshal()
+	assert.NilError(t, err)
+	parsed, err := Parse(data)
+	assert.NilError(t, err)
+	assert.DeepEqual(t, parsed, lock)
+
+	assert.NilError(t, parsed.Verify("git-clone", "task"))
+	assert.ErrorContains(t, parsed.Verify("git-clone", "tampered"), "git-clone has digest "+Digest("tampered")+" but pac.lock pins it to "+Digest("task"))
+	assert.ErrorContains(t, parsed.Verify("buildah", "task"), "buildah is not pinned in pac.lock")
+}
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    string
+		wantErr string
+	}{
+		{
+			name: "empty",
+			data: "",
+		},
+		{
+			name: "valid",
+			data: "resources:\n  git-clone: sha256:abcd\n",
+		},
+		{
+			name:    "unknown field",
+			data:    "tasks: {}\n",
+			wantErr: "unknown field",
+		},
+		{
+			name:    "bad digest",
+			data:    "resources:\n  git-clone: md5:abcd\n",
+			wantErr: `digest "md5:abcd" of git-clone is not a sha256 digest`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			lock, err := Parse([]byte(tt.data))
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			assert.NilError(t, err)
+			assert.Assert(t, lock.Resources != nil)
+		})
+	}
+}
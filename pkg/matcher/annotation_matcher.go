@@ -68,8 +68,10 @@ func branchMatch(prunBranch, baseBranch string) bool {
 	return matchGlob(prunBranch, baseBranch)
 }
 
+// GetAnnotationValues returns the values of an annotation, either a single
+// value or a comma separated list of values inside brackets.
 // TODO: move to another file since it's common to all annotations_* files.
-func getAnnotationValues(annotation string) ([]string, error) {
+func GetAnnotationValues(annotation string) ([]string, error) {
 	re := regexp.MustCompile(reValidateTag)
 	annotation = strings.TrimSpace(annotation)
 	match := re.MatchString(annotation)
@@ -104,9 +106,14 @@ func getTargetBranch(prun *tektonv1.PipelineRun, event *info.Event) (bool, strin
 			return false, "", "", fmt.Errorf("annotation %s is empty", keys.OnEvent)
 		}
 		targetEvents := []string{event.TriggerTarget.String()}
-		if event.EventType == triggertype.Incoming.String() {
+		switch event.EventType {
+		case triggertype.Incoming.String():
 			// if we have a incoming event, we want to match pipelineruns on both incoming and push
 			targetEvents = []string{triggertype.Incoming.String(), triggertype.Push.String()}
+		case triggertype.PullRequestReview.String(), triggertype.PullRequestReviewComment.String():
+			// the review events are pull requests for the PipelineRuns
+			// gated on the review decision
+			targetEvents = append(targetEvents, event.EventType)
 		}
 		matched, err := matchOnAnnotation(key, targetEvents, false)
 		targetEvent = key
@@ -142,6 +149,16 @@ type Match struct {
 	PipelineRun *tektonv1.PipelineRun
 	Repo        *apipac.Repository
 	Config      map[string]string
+	// Event is the event of the PipelineRun when it differs from the event
+	// being processed, ie: for one of the commits of a push.
+	Event *info.Event
+}
+
+// Skipped is a PipelineRun that has not been matched to the event and the
+// reason why.
+type Skipped struct {
+	PipelineRun *tektonv1.PipelineRun
+	Reason      string
 }
 
 // getName returns the name of the PipelineRun, if GenerateName is not set, it
@@ -155,6 +172,29 @@ func getName(prun *tektonv1.PipelineRun) string {
 	return name
 }
 
+func isReviewEvent(event *info.Event) bool {
+	return event.EventType == triggertype.PullRequestReview.String() ||
+		event.EventType == triggertype.PullRequestReviewComment.String()
+}
+
+// matchesReviewEvent tells whether a PipelineRun is matched on a review
+// event: when it asks for the event with its on-event annotation or uses the
+// review in its CEL expression, or when the review may have changed the review
+// decision it is gated on. The comments do not change the review decision.
+func matchesReviewEvent(prun *tektonv1.PipelineRun, event *info.Event) bool {
+	decisionChanged := event.EventType == triggertype.PullRequestReview.String() && event.ReviewState != "commented"
+	annotations := prun.GetObjectMeta().GetAnnotations()
+	if celExpr, ok := annotations[keys.OnCelExpression]; ok {
+		return reReview.MatchString(celExpr) || (decisionChanged && reReviewDecision.MatchString(celExpr))
+	}
+	if onEvent, ok := annotations[keys.OnEvent]; ok {
+		if matched, _ := matchOnAnnotation(onEvent, []string{event.EventType}, false); matched {
+			return true
+		}
+	}
+	return decisionChanged && annotations[keys.OnReviewApproved] == "true"
+}
+
 // checkPipelineRunAnnotation checks if the Pipelinerun has
 // `on-event`/`on-target-branch annotations` with `on-cel-expression`
 // and if present then warns the user that `on-cel-expression` will take precedence.
@@ -166,6 +206,7 @@ func checkPipelineRunAnnotation(prun *tektonv1.PipelineRun, eventEmitter *events
 	}{
 		{"on-event", prun.GetObjectMeta().GetAnnotations()[keys.OnEvent]},
 		{"on-target-branch", prun.GetObjectMeta().GetAnnotations()[keys.OnTargetBranch]},
+		{"on-review-approved", prun.GetObjectMeta().GetAnnotations()[keys.OnReviewApproved]},
 	}
 
 	// Preallocate the annotations slice with the exact capacity needed
@@ -191,7 +232,16 @@ func checkPipelineRunAnnotation(prun *tektonv1.PipelineRun, eventEmitter *events
 }
 
 func MatchPipelinerunByAnnotation(ctx context.Context, logger *zap.SugaredLogger, pruns []*tektonv1.PipelineRun, cs *params.Run, event *info.Event, vcx provider.Interface, eventEmitter *events.EventEmitter, repo *apipac.Repository) ([]Match, error) {
+	matchedPRs, _, err := MatchPipelinerunByAnnotationWithSkipped(ctx, logger, pruns, cs, event, vcx, eventEmitter, repo)
+	return matchedPRs, err
+}
+
+// MatchPipelinerunByAnnotationWithSkipped matches the PipelineRuns to the
+// event like MatchPipelinerunByAnnotation and returns as well the PipelineRuns
+// that have been skipped with the reason they didn't match.
+func MatchPipelinerunByAnnotationWithSkipped(ctx context.Context, logger *zap.SugaredLogger, pruns []*tektonv1.PipelineRun, cs *params.Run, event *info.Event, vcx provider.Interface, eventEmitter *events.EventEmitter, repo *apipac.Repository) ([]Match, []Skipped, error) {
 	matchedPRs := []Match{}
+	skippedPRs := []Skipped{}
 	infomsg := fmt.Sprintf("matching pipelineruns to event: URL=%s, target-branch=%s, source-branch=%s, target-event=%s",
 		event.URL,
 		event.BaseBranch,
@@ -210,6 +260,17 @@ func MatchPipelinerunByAnnotation(ctx context.Context, logger *zap.SugaredLogger
 	}
 	logger.Info(infomsg)
 
+	// the review decision is only fetched once for all the PipelineRuns gated
+	// on it
+	reviewDecision, reviewDecisionErr, reviewDecisionFetched := "", error(nil), false
+	getReviewDecision := func() (string, error) {
+		if !reviewDecisionFetched {
+			reviewDecision, reviewDecisionErr = vcx.GetReviewDecision(ctx, event)
+			reviewDecisionFetched = true
+		}
+		return reviewDecision, reviewDecisionErr
+	}
+
 	celValidationErrors := []*pacerrors.PacYamlValidations{}
 	for _, prun := range pruns {
 		prMatch := Match{
@@ -218,6 +279,9 @@ func MatchPipelinerunByAnnotation(ctx context.Context, logger *zap.SugaredLogger
 		}
 
 		prName := getName(prun)
+		skip := func(format string, args ...any) {
+			skippedPRs = append(skippedPRs, Skipped{PipelineRun: prun, Reason: fmt.Sprintf(format, args...)})
+		}
 		if event.TargetPipelineRun != "" && event.TargetPipelineRun == strings.TrimSuffix(prName, "-") {
 			logger.Infof("matched target pipelinerun with name: %s, target pipelinerun: %s", prName, event.TargetPipelineRun)
 			matchedPRs = append(matchedPRs, prMatch)
@@ -226,6 +290,7 @@ func MatchPipelinerunByAnnotation(ctx context.Context, logger *zap.SugaredLogger
 
 		if prun.GetObjectMeta().GetAnnotations() == nil {
 			logger.Debugf("PipelineRun %s does not have any annotations", prName)
+			skip("the PipelineRun does not have any annotations to match an event")
 			continue
 		}
 
@@ -238,6 +303,7 @@ func MatchPipelinerunByAnnotation(ctx context.Context, logger *zap.SugaredLogger
 			prMatch.Repo, _ = MatchEventURLRepo(ctx, cs, event, targetNS)
 			if prMatch.Repo == nil {
 				logger.Warnf("could not find Repository CRD in branch %s, the pipelineRun %s has a label that explicitly targets it", targetNS, prName)
+				skip("no Repository found in the target namespace %s", targetNS)
 				continue
 			}
 		}
@@ -246,6 +312,7 @@ func MatchPipelinerunByAnnotation(ctx context.Context, logger *zap.SugaredLogger
 			re, err := regexp.Compile(targetComment)
 			if err != nil {
 				logger.Warnf("could not compile regexp %s from pipelineRun %s", targetComment, prName)
+				skip("the %s annotation %q is not a valid regexp", keys.OnComment, targetComment)
 				continue
 			}
 
@@ -274,13 +341,22 @@ func MatchPipelinerunByAnnotation(ctx context.Context, logger *zap.SugaredLogger
 		_, ok := prun.GetObjectMeta().GetAnnotations()[keys.OnLabel]
 		if event.TriggerTarget == triggertype.PullRequest && event.EventType == string(triggertype.PullRequestLabeled) && !ok {
 			logger.Infof("label update event, PipelineRun %s does not have a on-label for any of those labels: %s", prName, strings.Join(event.PullRequestLabel, "|"))
+			skip("the PipelineRun does not have a %s annotation for the labels %s", keys.OnLabel, strings.Join(event.PullRequestLabel, ", "))
+			continue
+		}
+
+		// A review event only matches the PipelineRuns asking for it or gated
+		// on the review decision it may have changed.
+		if isReviewEvent(event) && !matchesReviewEvent(prun, event) {
+			logger.Infof("%s event, PipelineRun %s does not match on reviews", event.EventType, prName)
+			skip("the PipelineRun is not matching on %s events and is not gated on the review decision", event.EventType)
 			continue
 		}
 
 		if celExpr, ok := prun.GetObjectMeta().GetAnnotations()[keys.OnCelExpression]; ok {
 			checkPipelineRunAnnotation(prun, eventEmitter, repo)
 
-			out, err := celEvaluate(ctx, celExpr, event, vcx)
+			out, err := celEvaluate(ctx, celExpr, event, vcx, celLimits(cs), getReviewDecision)
 			if err != nil {
 				logger.Errorf("there was an error evaluating the CEL expression, skipping: %v", err)
 				if checkIfCELEvaluateError(err) {
@@ -289,19 +365,25 @@ func MatchPipelinerunByAnnotation(ctx context.Context, logger *zap.SugaredLogger
 						Err:  fmt.Errorf("CEL expression evaluation error: %s", sanitizeErrorAsMarkdown(err)),
 					})
 				}
+				skip("the %s annotation could not be evaluated: %v", keys.OnCelExpression, err)
 				continue
 			}
 			if out != types.True {
 				logger.Infof("CEL expression for PipelineRun %s is not matching, skipping", prName)
+				skip("the %s annotation %q evaluated to false", keys.OnCelExpression, celExpr)
 				continue
 			}
 			logger.Infof("CEL expression has been evaluated and matched")
 		} else {
 			matched, targetEvent, targetBranch, err := getTargetBranch(prun, event)
 			if err != nil {
-				return matchedPRs, err
+				return matchedPRs, skippedPRs, err
 			}
 			if !matched {
+				skip("the event %q on the target branch %q does not match the %s annotation %q and the %s annotation %q",
+					event.TriggerTarget.String(), event.BaseBranch,
+					keys.OnEvent, prun.GetObjectMeta().GetAnnotations()[keys.OnEvent],
+					keys.OnTargetBranch, prun.GetObjectMeta().GetAnnotations()[keys.OnTargetBranch])
 				continue
 			}
 			prMatch.Config["target-branch"] = targetBranch
@@ -318,9 +400,10 @@ func MatchPipelinerunByAnnotation(ctx context.Context, logger *zap.SugaredLogger
 				// our own path changes. we may split up if needed to refine.
 				matched, err := matchOnAnnotation(key, changedFiles.All, true)
 				if err != nil {
-					return matchedPRs, err
+					return matchedPRs, skippedPRs, err
 				}
 				if !matched {
+					skip("none of the changed files match the %s annotation %q", keys.OnPathChange, key)
 					continue
 				}
 				logger.Infof("matched PipelineRun with name: %s, annotation PathChange: %q", prName, key)
@@ -330,15 +413,31 @@ func MatchPipelinerunByAnnotation(ctx context.Context, logger *zap.SugaredLogger
 			if key, ok := prun.GetObjectMeta().GetAnnotations()[keys.OnLabel]; ok {
 				matched, err := matchOnAnnotation(key, event.PullRequestLabel, false)
 				if err != nil {
-					return matchedPRs, err
+					return matchedPRs, skippedPRs, err
 				}
 				if !matched {
+					skip("none of the labels match the %s annotation %q", keys.OnLabel, key)
 					continue
 				}
 				logger.Infof("matched PipelineRun with name: %s, annotation Label: %q", prName, key)
 				prMatch.Config["label"] = key
 			}
 
+			if prun.GetObjectMeta().GetAnnotations()[keys.OnReviewApproved] == "true" {
+				decision, err := getReviewDecision()
+				if err != nil {
+					logger.Errorf("error getting the review decision: %v", err)
+					skip("the review decision required by the %s annotation could not be fetched: %v", keys.OnReviewApproved, err)
+					continue
+				}
+				if decision != provider.ReviewDecisionApproved {
+					logger.Infof("PipelineRun %s needs the pull request to be approved, the review decision is %q", prName, decision)
+					skip("the pull request has not been approved as required by the %s annotation", keys.OnReviewApproved)
+					continue
+				}
+				prMatch.Config["review-approved"] = "true"
+			}
+
 			if key, ok := prun.GetObjectMeta().GetAnnotations()[keys.OnPathChangeIgnore]; ok {
 				changedFiles, err := vcx.GetFiles(ctx, event)
 				if err != nil {
@@ -350,10 +449,11 @@ func MatchPipelinerunByAnnotation(ctx context.Context, logger *zap.SugaredLogger
 				// our own path changes. we may split up if needed to refine.
 				matched, err := matchOnAnnotation(key, changedFiles.All, true)
 				if err != nil {
-					return matchedPRs, err
+					return matchedPRs, skippedPRs, err
 				}
 				if matched {
 					logger.Infof("Skipping pipelinerun with name: %s, annotation PathChangeIgnore: %q", prName, key)
+					skip("the changed files match the %s annotation %q", keys.OnPathChangeIgnore, key)
 					continue
 				}
 				prMatch.Config["path-change-ignore"] = key
@@ -372,12 +472,12 @@ func MatchPipelinerunByAnnotation(ctx context.Context, logger *zap.SugaredLogger
 		// Filter out templates that already have successful PipelineRuns for /retest and /ok-to-test
 		if event.EventType == opscomments.RetestAllCommentEventType.String() ||
 			event.EventType == opscomments.OkToTestCommentEventType.String() {
-			return filterSuccessfulTemplates(ctx, logger, cs, event, repo, matchedPRs), nil
+			return filterSuccessfulTemplates(ctx, logger, cs, event, repo, matchedPRs), skippedPRs, nil
 		}
-		return matchedPRs, nil
+		return matchedPRs, skippedPRs, nil
 	}
 
-	return nil, fmt.Errorf("%s", buildAvailableMatchingAnnotationErr(event, pruns))
+	return nil, skippedPRs, fmt.Errorf("%s", buildAvailableMatchingAnnotationErr(event, pruns))
 }
 
 // filterSuccessfulTemplates filters out templates that already have successful PipelineRuns
@@ -471,7 +571,7 @@ func buildAvailableMatchingAnnotationErr(event *info.Event, pruns []*tektonv1.Pi
 }
 
 func matchOnAnnotation(annotations string, eventType []string, branchMatching bool) (bool, error) {
-	targets, err := getAnnotationValues(annotations)
+	targets, err := GetAnnotationValues(annotations)
 	if err != nil {
 		return false, err
 	}
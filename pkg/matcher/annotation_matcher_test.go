@@ -19,11 +19,13 @@ import (
 	"github.com/openshift-pipelines/pipelines-as-code/pkg/params"
 	"github.com/openshift-pipelines/pipelines-as-code/pkg/params/clients"
 	"github.com/openshift-pipelines/pipelines-as-code/pkg/params/info"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/params/settings"
 	"github.com/openshift-pipelines/pipelines-as-code/pkg/params/triggertype"
 	"github.com/openshift-pipelines/pipelines-as-code/pkg/provider"
 	ghprovider "github.com/openshift-pipelines/pipelines-as-code/pkg/provider/github"
 	testclient "github.com/openshift-pipelines/pipelines-as-code/pkg/test/clients"
 	ghtesthelper "github.com/openshift-pipelines/pipelines-as-code/pkg/test/github"
+	testprovider "github.com/openshift-pipelines/pipelines-as-code/pkg/test/provider"
 	testnewrepo "github.com/openshift-pipelines/pipelines-as-code/pkg/test/repository"
 	tektonv1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
 	"go.uber.org/zap"
@@ -306,6 +308,57 @@ func TestMatchPipelinerunAnnotationAndRepositories(t *testing.T) {
 				},
 			},
 		},
+		{
+			name:       "cel/match helpers",
+			wantPRName: pipelineTargetNSName,
+			args: annotationTestArgs{
+				fileChanged: []struct {
+					FileName    string
+					Status      string
+					NewFile     bool
+					RenamedFile bool
+					DeletedFile bool
+				}{
+					{
+						FileName: ".tekton/pull_request.yaml",
+						Status:   "added",
+						NewFile:  true,
+					},
+				},
+				pruns: []*tektonv1.PipelineRun{
+					{
+						ObjectMeta: metav1.ObjectMeta{
+							Name: pipelineTargetNSName,
+							Annotations: map[string]string{
+								keys.OnCelExpression: `files.all_match(".tekton/*.yaml") && pr.labels.exists(l, l == "ci") && pr.number == 1000`,
+							},
+						},
+					},
+				},
+				runevent: info.Event{
+					URL:               targetURL,
+					TriggerTarget:     "pull_request",
+					EventType:         "pull_request",
+					BaseBranch:        mainBranch,
+					HeadBranch:        "unittests",
+					PullRequestNumber: 1000,
+					PullRequestLabel:  []string{"ci"},
+					Organization:      "mylittle",
+					Repository:        "pony",
+				},
+				data: testclient.Data{
+					Repositories: []*v1alpha1.Repository{
+						testnewrepo.NewRepo(
+							testnewrepo.RepoTestcreationOpts{
+								Name:             "test-good",
+								URL:              targetURL,
+								InstallNamespace: targetNamespace,
+							},
+						),
+					},
+				},
+			},
+		},
 		{
 			name:       "cel/match path title pr",
 			wantPRName: pipelineTargetNSName,
@@ -1466,6 +1519,15 @@ func TestMatchPipelinerunByAnnotation(t *testing.T) {
 		},
 	}
 
+	pipelineCelMacro := &tektonv1.PipelineRun{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "pipeline-cel-macro",
+			Annotations: map[string]string{
+				keys.OnCelExpression: `event == "pull_request" && ["main"].exists(b, b == target_branch)`,
+			},
+		},
+	}
+
 	pipelinePush := &tektonv1.PipelineRun{
 		ObjectMeta: metav1.ObjectMeta{
 			Name: "pipeline-push",
@@ -1531,6 +1593,7 @@ func TestMatchPipelinerunByAnnotation(t *testing.T) {
 	type args struct {
 		pruns    []*tektonv1.PipelineRun
 		runevent info.Event
+		settings *settings.Settings
 	}
 	tests := []struct {
 		name       string
@@ -1615,6 +1678,38 @@ func TestMatchPipelinerunByAnnotation(t *testing.T) {
 			wantErr:    false,
 			wantPrName: pipelineCel.GetName(),
 		},
+		{
+			name: "match-on-cel-expression-with-macro",
+			args: args{
+				pruns: []*tektonv1.PipelineRun{pipelineCelMacro},
+				runevent: info.Event{
+					TriggerTarget: "pull_request",
+					EventType:     "pull_request",
+					BaseBranch:    "main",
+					Request: &info.Request{
+						Header: http.Header{},
+					},
+				},
+			},
+			wantErr:    false,
+			wantPrName: pipelineCelMacro.GetName(),
+		},
+		{
+			name: "no-match-on-cel-expression-with-disabled-macro",
+			args: args{
+				pruns: []*tektonv1.PipelineRun{pipelineCelMacro},
+				runevent: info.Event{
+					TriggerTarget: "pull_request",
+					EventType:     "pull_request",
+					BaseBranch:    "main",
+					Request: &info.Request{
+						Header: http.Header{},
+					},
+				},
+				settings: &settings.Settings{CELCostLimit: 1000, CELEvaluationTimeout: "1s", CELDisabledMacros: "exists"},
+			},
+			wantErr: true,
+		},
 		{
 			name: "cel-expression-takes-precedence-over-annotations",
 			args: args{
@@ -1981,6 +2076,9 @@ func TestMatchPipelinerunByAnnotation(t *testing.T) {
 				Clients: clients.Clients{},
 				Info:    info.Info{},
 			}
+			if tt.args.settings != nil {
+				cs.Info.Pac = &info.PacOpts{Settings: *tt.args.settings}
+			}
 
 			eventEmitter := events.NewEventEmitter(cs.Clients.Kube, logger)
 			matches, err := MatchPipelinerunByAnnotation(ctx, logger, tt.args.pruns, cs, &tt.args.runevent, &ghprovider.Provider{}, eventEmitter, nil)
@@ -2010,6 +2108,59 @@ func TestMatchPipelinerunByAnnotation(t *testing.T) {
 	}
 }
 
+func TestMatchPipelinerunByAnnotationWithSkipped(t *testing.T) {
+	pipelineGood := &tektonv1.PipelineRun{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "pipeline-good",
+			Annotations: map[string]string{
+				keys.OnEvent:        "[pull_request]",
+				keys.OnTargetBranch: "[main]",
+			},
+		},
+	}
+	pipelineOtherBranch := &tektonv1.PipelineRun{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "pipeline-other-branch",
+			Annotations: map[string]string{
+				keys.OnEvent:        "[pull_request]",
+				keys.OnTargetBranch: "[release]",
+			},
+		},
+	}
+	pipelineCelFalse := &tektonv1.PipelineRun{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "pipeline-cel-false",
+			Annotations: map[string]string{
+				keys.OnCelExpression: `event == "push"`,
+			},
+		},
+	}
+
+	observer, _ := zapobserver.New(zap.InfoLevel)
+	logger := zap.New(observer).Sugar()
+	ctx, _ := rtesting.SetupFakeContext(t)
+	cs := &params.Run{Clients: clients.Clients{}, Info: info.Info{}}
+	eventEmitter := events.NewEventEmitter(cs.Clients.Kube, logger)
+	runevent := &info.Event{TriggerTarget: "pull_request", EventType: "pull_request", BaseBranch: "main", Request: &info.Request{}}
+
+	matches, skipped, err := MatchPipelinerunByAnnotationWithSkipped(ctx, logger,
+		[]*tektonv1.PipelineRun{pipelineGood, pipelineOtherBranch, pipelineCelFalse}, cs, runevent, &ghprovider.Provider{}, eventEmitter, nil)
+	assert.NilError(t, err)
+	assert.Equal(t, len(matches), 1)
+	assert.Equal(t, matches[0].PipelineRun.GetName(), "pipeline-good")
+	assert.Equal(t, len(skipped), 2)
+	assert.Equal(t, skipped[0].PipelineRun.GetName(), "pipeline-other-branch")
+	assert.Assert(t, strings.Contains(skipped[0].Reason, `the target branch "main" does not match`), skipped[0].Reason)
+	assert.Equal(t, skipped[1].PipelineRun.GetName(), "pipeline-cel-false")
+	assert.Assert(t, strings.Contains(skipped[1].Reason, "evaluated to false"), skipped[1].Reason)
+
+	// skipped PipelineRuns are returned even when nothing matched
+	_, skipped, err = MatchPipelinerunByAnnotationWithSkipped(ctx, logger,
+		[]*tektonv1.PipelineRun{pipelineOtherBranch}, cs, runevent, &ghprovider.Provider{}, eventEmitter, nil)
+	assert.Assert(t, err != nil)
+	assert.Equal(t, len(skipped), 1)
+}
+
 func Test_getAnnotationValues(t *testing.T) {
 	type args struct {
 		annotation string
@@ -2084,13 +2235,13 @@ func Test_getAnnotationValues(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			got, err := getAnnotationValues(tt.args.annotation)
+			got, err := GetAnnotationValues(tt.args.annotation)
 			if (err != nil) != tt.wantErr {
-				t.Errorf("getAnnotationValues() error = %v, wantErr %v", err, tt.wantErr)
+				t.Errorf("GetAnnotationValues() error = %v, wantErr %v", err, tt.wantErr)
 				return
 			}
 			if !reflect.DeepEqual(got, tt.want) {
-				t.Errorf("getAnnotationValues() got = %v, want %v", got, tt.want)
+				t.Errorf("GetAnnotationValues() got = %v, want %v", got, tt.want)
 			}
 		})
 	}
@@ -2932,3 +3083,236 @@ func TestFilterSuccessfulTemplates(t *testing.T) {
 		})
 	}
 }
+
+func TestMatchPipelinerunByAnnotationReviewApproved(t *testing.T) {
+	pipelineGood := &tektonv1.PipelineRun{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "pipeline-good",
+			Annotations: map[string]string{
+				keys.OnEvent:        "[pull_request]",
+				keys.OnTargetBranch: "[main]",
+			},
+		},
+	}
+	pipelineApproved := &tektonv1.PipelineRun{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "pipeline-approved",
+			Annotations: map[string]string{
+				keys.OnEvent:          "[pull_request]",
+				keys.OnTargetBranch:   "[main]",
+				keys.OnReviewApproved: "true",
+			},
+		},
+	}
+	pipelineCelApproved := &tektonv1.PipelineRun{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "pipeline-cel-approved",
+			Annotations: map[string]string{
+				keys.OnCelExpression: `event == "pull_request" && review_decision == "approved"`,
+			},
+		},
+	}
+	pipelineCelChangesRequested := &tektonv1.PipelineRun{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "pipeline-cel-changes-requested",
+			Annotations: map[string]string{
+				keys.OnCelExpression: `review_decision == "changes_requested"`,
+			},
+		},
+	}
+	pruns := []*tektonv1.PipelineRun{pipelineGood, pipelineApproved, pipelineCelApproved, pipelineCelChangesRequested}
+
+	tests := []struct {
+		name           string
+		eventType      string
+		reviewDecision string
+		erroring       bool
+		wantMatches    []string
+		wantSkipReason string
+	}{
+		{
+			name:        "pull request not reviewed",
+			eventType:   triggertype.PullRequest.String(),
+			wantMatches: []string{"pipeline-good"},
+			wantSkipReason: "the pull request has not been approved as required by the " +
+				keys.OnReviewApproved + " annotation",
+		},
+		{
+			name:           "pull request approved",
+			eventType:      triggertype.PullRequest.String(),
+			reviewDecision: provider.ReviewDecisionApproved,
+			wantMatches:    []string{"pipeline-good", "pipeline-approved", "pipeline-cel-approved"},
+		},
+		{
+			name:           "review approving the pull request",
+			eventType:      triggertype.PullRequestReview.String(),
+			reviewDecision: provider.ReviewDecisionApproved,
+			wantMatches:    []string{"pipeline-approved", "pipeline-cel-approved"},
+		},
+		{
+			name:           "review requesting changes",
+			eventType:      triggertype.PullRequestReview.String(),
+			reviewDecision: provider.ReviewDecisionChangesRequested,
+			wantMatches:    []string{"pipeline-cel-changes-requested"},
+		},
+		{
+			name:           "review decision not available",
+			eventType:      triggertype.PullRequestReview.String(),
+			erroring:       true,
+			wantMatches:    []string{},
+			wantSkipReason: "the review decision required by the " + keys.OnReviewApproved + " annotation could not be fetched: cannot get the reviews",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			observer, _ := zapobserver.New(zap.InfoLevel)
+			logger := zap.New(observer).Sugar()
+			ctx, _ := rtesting.SetupFakeContext(t)
+			cs := &params.Run{Clients: clients.Clients{}, Info: info.Info{}}
+			eventEmitter := events.NewEventEmitter(cs.Clients.Kube, logger)
+			runevent := &info.Event{
+				TriggerTarget:     triggertype.PullRequest,
+				EventType:         tt.eventType,
+				BaseBranch:        "main",
+				PullRequestNumber: 10,
+				Request:           &info.Request{},
+			}
+			vcx := &testprovider.TestProviderImp{ReviewDecision: tt.reviewDecision, ReviewDecisionErroring: tt.erroring}
+
+			matches, skipped, err := MatchPipelinerunByAnnotationWithSkipped(ctx, logger, pruns, cs, runevent, vcx, eventEmitter, nil)
+			assert.Equal(t, err != nil, len(tt.wantMatches) == 0)
+			got := []string{}
+			for _, match := range matches {
+				got = append(got, match.PipelineRun.GetName())
+			}
+			assert.DeepEqual(t, got, tt.wantMatches)
+			if tt.wantSkipReason != "" {
+				found := false
+				for _, s := range skipped {
+					if s.PipelineRun.GetName() == "pipeline-approved" {
+						found = true
+						assert.Equal(t, s.Reason, tt.wantSkipReason)
+					}
+				}
+				assert.Assert(t, found, "pipeline-approved has not been skipped")
+			}
+		})
+	}
+}
+
+func TestMatchPipelinerunByAnnotationReviewEvents(t *testing.T) {
+	pipelinePR := &tektonv1.PipelineRun{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "pipeline-pr",
+			Annotations: map[string]string{
+				keys.OnEvent:        "[pull_request]",
+				keys.OnTargetBranch: "[main]",
+			},
+		},
+	}
+	pipelineApproved := &tektonv1.PipelineRun{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "pipeline-approved",
+			Annotations: map[string]string{
+				keys.OnEvent:          "[pull_request]",
+				keys.OnTargetBranch:   "[main]",
+				keys.OnReviewApproved: "true",
+			},
+		},
+	}
+	pipelineReview := &tektonv1.PipelineRun{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "pipeline-review",
+			Annotations: map[string]string{
+				keys.OnEvent:        "[pull_request_review]",
+				keys.OnTargetBranch: "[main]",
+			},
+		},
+	}
+	pipelineReviewComment := &tektonv1.PipelineRun{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "pipeline-review-comment",
+			Annotations: map[string]string{
+				keys.OnEvent:        "[pull_request_review_comment]",
+				keys.OnTargetBranch: "[main]",
+			},
+		},
+	}
+	pipelineCelDismissed := &tektonv1.PipelineRun{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "pipeline-cel-dismissed",
+			Annotations: map[string]string{
+				keys.OnCelExpression: `review.state == "dismissed" && review.reviewer == "security-bot"`,
+			},
+		},
+	}
+	pruns := []*tektonv1.PipelineRun{pipelinePR, pipelineApproved, pipelineReview, pipelineReviewComment, pipelineCelDismissed}
+
+	tests := []struct {
+		name        string
+		eventType   string
+		reviewState string
+		reviewer    string
+		wantMatches []string
+	}{
+		{
+			name:        "pull request",
+			eventType:   triggertype.PullRequest.String(),
+			wantMatches: []string{"pipeline-pr", "pipeline-approved"},
+		},
+		{
+			name:        "approving review",
+			eventType:   triggertype.PullRequestReview.String(),
+			reviewState: "approved",
+			reviewer:    "reviewer",
+			wantMatches: []string{"pipeline-approved", "pipeline-review"},
+		},
+		{
+			name:        "commenting review",
+			eventType:   triggertype.PullRequestReview.String(),
+			reviewState: "commented",
+			reviewer:    "reviewer",
+			wantMatches: []string{"pipeline-review"},
+		},
+		{
+			name:        "dismissed review",
+			eventType:   triggertype.PullRequestReview.String(),
+			reviewState: "dismissed",
+			reviewer:    "security-bot",
+			wantMatches: []string{"pipeline-approved", "pipeline-review", "pipeline-cel-dismissed"},
+		},
+		{
+			name:        "review comment",
+			eventType:   triggertype.PullRequestReviewComment.String(),
+			reviewer:    "reviewer",
+			wantMatches: []string{"pipeline-review-comment"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			observer, _ := zapobserver.New(zap.InfoLevel)
+			logger := zap.New(observer).Sugar()
+			ctx, _ := rtesting.SetupFakeContext(t)
+			cs := &params.Run{Clients: clients.Clients{}, Info: info.Info{}}
+			eventEmitter := events.NewEventEmitter(cs.Clients.Kube, logger)
+			runevent := &info.Event{
+				TriggerTarget:     triggertype.PullRequest,
+				EventType:         tt.eventType,
+				BaseBranch:        "main",
+				PullRequestNumber: 10,
+				ReviewState:       tt.reviewState,
+				Reviewer:          tt.reviewer,
+				Request:           &info.Request{},
+			}
+			vcx := &testprovider.TestProviderImp{ReviewDecision: provider.ReviewDecisionApproved}
+
+			matches, _, err := MatchPipelinerunByAnnotationWithSkipped(ctx, logger, pruns, cs, runevent, vcx, eventEmitter, nil)
+			assert.NilError(t, err)
+			got := []string{}
+			for _, match := range matches {
+				got = append(got, match.PipelineRun.GetName())
+			}
+			assert.DeepEqual(t, got, tt.wantMatches)
+		})
+	}
+}
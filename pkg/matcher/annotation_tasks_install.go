@@ -10,6 +10,7 @@ import (
 
 	"github.com/openshift-pipelines/pipelines-as-code/pkg/apis/pipelinesascode"
 	"github.com/openshift-pipelines/pipelines-as-code/pkg/hub"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/lockfile"
 	"github.com/openshift-pipelines/pipelines-as-code/pkg/params"
 	"github.com/openshift-pipelines/pipelines-as-code/pkg/params/info"
 	"github.com/openshift-pipelines/pipelines-as-code/pkg/params/settings"
@@ -30,6 +31,25 @@ type RemoteTasks struct {
 	ProviderInterface provider.Interface
 	Event             *info.Event
 	Logger            *zap.SugaredLogger
+	// Lock pins the remote tasks and pipelines to a digest, they are not
+	// checked when it's nil.
+	Lock *lockfile.Lock
+	// UpdateLock records the digests of the remote tasks and pipelines in
+	// Lock instead of checking them.
+	UpdateLock bool
+}
+
+// checkLock checks the content of a remote task or pipeline against the lock,
+// or records it in the lock when updating it.
+func (rt RemoteTasks) checkLock(uri, data string) error {
+	if rt.Lock == nil || !lockfile.IsRemote(uri) {
+		return nil
+	}
+	if rt.UpdateLock {
+		rt.Lock.Add(uri, data)
+		return nil
+	}
+	return rt.Lock.Verify(uri, data)
 }
 
 // nolint: dupl
@@ -172,7 +192,7 @@ func grabValuesFromAnnotations(annotations map[string]string, annotationReg stri
 		if !rtareg.MatchString(annotationK) {
 			continue
 		}
-		items, err := getAnnotationValues(annotationV)
+		items, err := GetAnnotationValues(annotationV)
 		if err != nil {
 			return ret, err
 		}
@@ -207,6 +227,9 @@ func (rt RemoteTasks) GetTaskFromAnnotationName(ctx context.Context, name string
 	if data == "" {
 		return nil, fmt.Errorf("could not get remote task \"%s\": returning empty", name)
 	}
+	if err := rt.checkLock(name, data); err != nil {
+		return nil, err
+	}
 
 	task, err := rt.convertTotask(ctx, name, data)
 	if err != nil {
@@ -223,6 +246,9 @@ func (rt RemoteTasks) GetPipelineFromAnnotationName(ctx context.Context, name st
 	if data == "" {
 		return nil, fmt.Errorf("could not get remote pipeline \"%s\": returning empty", name)
 	}
+	if err := rt.checkLock(name, data); err != nil {
+		return nil, err
+	}
 
 	pipeline, err := rt.convertToPipeline(ctx, name, data)
 	if err != nil {
@@ -11,6 +11,7 @@ import (
 	"github.com/openshift-pipelines/pipelines-as-code/pkg/apis/pipelinesascode"
 	"github.com/openshift-pipelines/pipelines-as-code/pkg/apis/pipelinesascode/keys"
 	hubtype "github.com/openshift-pipelines/pipelines-as-code/pkg/hub/vars"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/lockfile"
 	"github.com/openshift-pipelines/pipelines-as-code/pkg/params"
 	"github.com/openshift-pipelines/pipelines-as-code/pkg/params/clients"
 	"github.com/openshift-pipelines/pipelines-as-code/pkg/params/info"
@@ -243,6 +244,7 @@ func TestGetTaskFromAnnotationName(t *testing.T) {
 		wantErr                string
 		wantLog                string
 		wantProviderRemoteTask bool
+		lock                   map[string]string
 	}{
 		{
 			name: "test-annotations-error-remote-http-not-k8",
@@ -325,6 +327,54 @@ func TestGetTaskFromAnnotationName(t *testing.T) {
 				SHA: "007",
 			},
 		},
+		{
+			name:        "test-annotations-inside-repo-not-locked",
+			task:        "be/healthy",
+			gotTaskName: "task",
+			filesInsideRepo: map[string]string{
+				"be/healthy": readTDfile(t, "task-good"),
+			},
+			runevent: info.Event{
+				SHA: "007",
+			},
+			lock: map[string]string{},
+		},
+		{
+			name:        "test-annotations-remote-locked",
+			task:        "https://remote.task",
+			gotTaskName: "task",
+			remoteURLS: map[string]map[string]string{
+				"https://remote.task": {
+					"body": readTDfile(t, "task-good"),
+					"code": "200",
+				},
+			},
+			lock: map[string]string{"https://remote.task": lockfile.Digest(readTDfile(t, "task-good"))},
+		},
+		{
+			name: "test-annotations-remote-locked-tampered",
+			task: "https://remote.task",
+			remoteURLS: map[string]map[string]string{
+				"https://remote.task": {
+					"body": readTDfile(t, "task-good"),
+					"code": "200",
+				},
+			},
+			lock:    map[string]string{"https://remote.task": lockfile.Digest("another task")},
+			wantErr: "it may have been modified upstream",
+		},
+		{
+			name: "test-annotations-remote-not-locked",
+			task: "https://remote.task",
+			remoteURLS: map[string]map[string]string{
+				"https://remote.task": {
+					"body": readTDfile(t, "task-good"),
+					"code": "200",
+				},
+			},
+			lock:    map[string]string{},
+			wantErr: "https://remote.task is not pinned in pac.lock",
+		},
 		{
 			name:    "test-annotations-remote-inside-file-not-found",
 			task:    "pas/la",
@@ -453,6 +503,9 @@ func TestGetTaskFromAnnotationName(t *testing.T) {
 				},
 				Event: &tt.runevent,
 			}
+			if tt.lock != nil {
+				rt.Lock = &lockfile.Lock{Resources: tt.lock}
+			}
 
 			got, err := rt.GetTaskFromAnnotationName(ctx, tt.task)
 			if tt.wantLog != "" {
@@ -12,7 +12,9 @@ import (
 	"github.com/google/cel-go/common/decls"
 	"github.com/google/cel-go/common/types"
 	"github.com/google/cel-go/common/types/ref"
+	pacCel "github.com/openshift-pipelines/pipelines-as-code/pkg/cel"
 	"github.com/openshift-pipelines/pipelines-as-code/pkg/changedfiles"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/params"
 	"github.com/openshift-pipelines/pipelines-as-code/pkg/params/info"
 	"github.com/openshift-pipelines/pipelines-as-code/pkg/params/triggertype"
 	"github.com/openshift-pipelines/pipelines-as-code/pkg/provider"
@@ -22,7 +24,16 @@ const (
 	reChangedFilesTags = `files\.`
 )
 
-func celEvaluate(ctx context.Context, expr string, event *info.Event, vcx provider.Interface) (ref.Val, error) {
+var (
+	reReviewDecision = regexp.MustCompile(`\breview_decision\b`)
+	reReview         = regexp.MustCompile(`\breview\.`)
+)
+
+// celEvaluate evaluates the on-cel-expression of a PipelineRun for the event
+// within the limits of the settings.
+// The review decision is only fetched, with getReviewDecision, when the
+// expression uses it.
+func celEvaluate(ctx context.Context, expr string, event *info.Event, vcx provider.Interface, limits pacCel.Limits, getReviewDecision func() (string, error)) (ref.Val, error) {
 	eventTitle := event.PullRequestTitle
 	if event.TriggerTarget == triggertype.Push {
 		eventTitle = event.SHATitle
@@ -70,15 +81,29 @@ func celEvaluate(ctx context.Context, expr string, event *info.Event, vcx provid
 		}
 	}
 
+	reviewDecision := ""
+	if reReviewDecision.MatchString(expr) {
+		reviewDecision, err = getReviewDecision()
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	data := map[string]any{
-		"event":         event.TriggerTarget.String(),
-		"event_title":   eventTitle,
-		"target_branch": event.BaseBranch,
-		"source_branch": event.HeadBranch,
-		"target_url":    event.BaseURL,
-		"source_url":    event.HeadURL,
-		"body":          jsonMap,
-		"headers":       headerMap,
+		"event":           event.TriggerTarget.String(),
+		"event_title":     eventTitle,
+		"target_branch":   event.BaseBranch,
+		"source_branch":   event.HeadBranch,
+		"target_url":      event.BaseURL,
+		"source_url":      event.HeadURL,
+		"body":            jsonMap,
+		"headers":         headerMap,
+		"pr":              pacCel.PullRequest(event.PullRequestNumber, event.PullRequestTitle, event.PullRequestLabel),
+		"review_decision": reviewDecision,
+		"review": map[string]any{
+			"state":    event.ReviewState,
+			"reviewer": event.Reviewer,
+		},
 		"files": map[string]any{
 			"all":      changedFiles.All,
 			"added":    changedFiles.Added,
@@ -87,8 +112,9 @@ func celEvaluate(ctx context.Context, expr string, event *info.Event, vcx provid
 			"renamed":  changedFiles.Renamed,
 		},
 	}
-	env, err := cel.NewEnv(
+	prg, err := limits.Compile(expr,
 		cel.Lib(celPac{vcx, ctx, event}),
+		pacCel.Helpers(),
 		cel.VariableDecls(
 			decls.NewVariable("event", types.StringType),
 			decls.NewVariable("headers", types.NewMapType(types.StringType, types.DynType)),
@@ -99,33 +125,29 @@ func celEvaluate(ctx context.Context, expr string, event *info.Event, vcx provid
 			decls.NewVariable("target_url", types.StringType),
 			decls.NewVariable("source_url", types.StringType),
 			decls.NewVariable("files", types.NewMapType(types.StringType, types.DynType)),
+			decls.NewVariable("pr", types.NewMapType(types.StringType, types.DynType)),
+			decls.NewVariable("review_decision", types.StringType),
+			decls.NewVariable("review", types.NewMapType(types.StringType, types.StringType)),
 		))
 	if err != nil {
 		return nil, err
 	}
 
-	parsed, issues := env.Parse(expr)
-	if issues != nil && issues.Err() != nil {
-		return nil, fmt.Errorf("failed to parse expression %#v: %w", expr, issues.Err())
-	}
-
-	checked, issues := env.Check(parsed)
-	if issues != nil && issues.Err() != nil {
-		return nil, fmt.Errorf("expression %#v check failed: %w", expr, issues.Err())
-	}
-
-	prg, err := env.Program(checked)
-	if err != nil {
-		return nil, fmt.Errorf("expression %#v failed to create a Program: %w", expr, err)
-	}
-
-	out, _, err := prg.Eval(data)
+	out, err := limits.Eval(ctx, prg, data)
 	if err != nil {
 		return nil, fmt.Errorf("expression %#v failed to evaluate: %w", expr, err)
 	}
 	return out, nil
 }
 
+// celLimits returns the limits of the CEL expressions of the settings.
+func celLimits(cs *params.Run) pacCel.Limits {
+	if cs.Info.Pac == nil {
+		return pacCel.DefaultLimits()
+	}
+	return pacCel.LimitsFromSettings(cs.Info.GetPacOpts().Settings)
+}
+
 type celPac struct {
 	vcx   provider.Interface
 	ctx   context.Context
@@ -49,7 +49,7 @@ func reportCELValidationErrors(ctx context.Context, repo *apipac.Repository, val
 	markdownErrMessage := fmt.Sprintf(`%s
 %s`, provider.ValidationErrorTemplate, strings.Join(errorRows, "\n"))
 	if err := vcx.CreateComment(ctx, event, markdownErrMessage, provider.ValidationErrorTemplate); err != nil {
-		eventEmitter.EmitMessage(repo, zap.ErrorLevel, "PipelineRunCommentCreationError",
+		eventEmitter.EmitFailure(repo, zap.ErrorLevel, apipac.FailureReasonProviderAPIError, "PipelineRunCommentCreationError",
 			fmt.Sprintf("failed to create comment: %s", err.Error()))
 	}
 }
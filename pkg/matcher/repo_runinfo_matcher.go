@@ -2,30 +2,106 @@ package matcher
 
 import (
 	"context"
+	"path"
+	"slices"
 	"strings"
 
 	apipac "github.com/openshift-pipelines/pipelines-as-code/pkg/apis/pipelinesascode/v1alpha1"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/formatting"
 	"github.com/openshift-pipelines/pipelines-as-code/pkg/params"
 	"github.com/openshift-pipelines/pipelines-as-code/pkg/params/info"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/params/settings"
 	"github.com/openshift-pipelines/pipelines-as-code/pkg/sort"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 func MatchEventURLRepo(ctx context.Context, cs *params.Run, event *info.Event, ns string) (*apipac.Repository, error) {
+	repositories, err := repositoriesByURL(ctx, cs, event.URL, ns)
+	if err != nil {
+		return nil, err
+	}
+	if len(repositories) == 0 {
+		return matchWildcardRepo(ctx, cs, event, ns)
+	}
+	return resolveRepositoryConflict(ctx, cs, event.URL, repositories), nil
+}
+
+// matchWildcardRepo returns the Repository with the first URL of the
+// repository-url-wildcards setting matching the event, ie:
+// https://github.com/org/* for https://github.com/org/repo. Only the
+// Repositories of the namespace bound to the wildcard URL are matched.
+func matchWildcardRepo(ctx context.Context, cs *params.Run, event *info.Event, ns string) (*apipac.Repository, error) {
+	if cs.Info.Pac == nil {
+		return nil, nil
+	}
+	wildcards, err := settings.ParseRepositoryURLWildcards(cs.Info.GetPacOpts().RepositoryURLWildcards)
+	if err != nil {
+		return nil, err
+	}
+	eventURL := formatting.NormalizeRepoURL(event.URL)
+	for _, wildcard := range wildcards {
+		if ns != "" && wildcard.Namespace != ns {
+			continue
+		}
+		if matched, _ := path.Match(formatting.NormalizeRepoURL(wildcard.URL), eventURL); !matched {
+			continue
+		}
+		repositories, err := repositoriesByURL(ctx, cs, wildcard.URL, wildcard.Namespace)
+		if err != nil {
+			return nil, err
+		}
+		if len(repositories) > 0 {
+			return resolveRepositoryConflict(ctx, cs, wildcard.URL, repositories), nil
+		}
+	}
+	return nil, nil
+}
+
+// repositoriesByURL returns the Repositories of the namespace, or of all the
+// namespaces when it is empty, with the URL, the oldest first. They are read
+// from the cache of the repository index when it has been started.
+func repositoriesByURL(ctx context.Context, cs *params.Run, url, ns string) ([]apipac.Repository, error) {
+	if cs.Clients.RepositoryIndex.Synced() {
+		repositories, err := cs.Clients.RepositoryIndex.ByURL(url)
+		if err != nil {
+			return nil, err
+		}
+		if ns == "" {
+			return repositories, nil
+		}
+		return slices.DeleteFunc(repositories, func(repo apipac.Repository) bool { return repo.GetNamespace() != ns }), nil
+	}
+
 	repositories, err := cs.Clients.PipelineAsCode.PipelinesascodeV1alpha1().Repositories(ns).List(
 		ctx, metav1.ListOptions{})
-	sort.RepositorySortByCreationOldestTime(repositories.Items)
 	if err != nil {
 		return nil, err
 	}
-	for _, repo := range repositories.Items {
-		repo.Spec.URL = strings.TrimSuffix(repo.Spec.URL, "/")
-		if repo.Spec.URL == event.URL {
-			return &repo, nil
+	sort.RepositorySortByCreationOldestTime(repositories.Items)
+	url = formatting.NormalizeRepoURL(url)
+	return slices.DeleteFunc(repositories.Items, func(repo apipac.Repository) bool {
+		return formatting.NormalizeRepoURL(repo.Spec.URL) != url
+	}), nil
+}
+
+// MatchOrgRepository returns the organization level Repository among
+// candidates whose URL is the closest parent of repoURL, ie:
+// https://github.com/org for https://github.com/org/repo. The first one wins
+// when several have the same URL.
+func MatchOrgRepository(repoURL string, candidates []*apipac.Repository) *apipac.Repository {
+	var orgRepo *apipac.Repository
+	orgURL := ""
+	repoURL = formatting.NormalizeRepoURL(repoURL)
+	for _, candidate := range candidates {
+		candidateURL := formatting.NormalizeRepoURL(candidate.Spec.URL)
+		if candidateURL == "" || !strings.HasPrefix(repoURL, candidateURL+"/") {
+			continue
+		}
+		if len(candidateURL) > len(orgURL) {
+			orgRepo, orgURL = candidate, candidateURL
 		}
 	}
-
-	return nil, nil
+	return orgRepo
 }
 
 // GetRepo get a repo by name anywhere on a cluster.
@@ -1,6 +1,7 @@
 package matcher
 
 import (
+	"fmt"
 	"testing"
 	"time"
 
@@ -9,6 +10,7 @@ import (
 	"github.com/openshift-pipelines/pipelines-as-code/pkg/params"
 	"github.com/openshift-pipelines/pipelines-as-code/pkg/params/clients"
 	"github.com/openshift-pipelines/pipelines-as-code/pkg/params/info"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/params/settings"
 	testclient "github.com/openshift-pipelines/pipelines-as-code/pkg/test/clients"
 	testnewrepo "github.com/openshift-pipelines/pipelines-as-code/pkg/test/repository"
 	"go.uber.org/zap"
@@ -76,6 +78,25 @@ func Test_getRepoByCR(t *testing.T) {
 			wantTargetNS: targetNamespace,
 			wantErr:      false,
 		},
+		{
+			name: "test-match-url-git-suffix-and-case",
+			args: args{
+				data: testclient.Data{
+					Repositories: []*v1alpha1.Repository{
+						testnewrepo.NewRepo(
+							testnewrepo.RepoTestcreationOpts{
+								Name:             "test-good",
+								URL:              "https//Nowhere.togo.git",
+								InstallNamespace: targetNamespace,
+							},
+						),
+					},
+				},
+				runevent: info.Event{URL: targetURL, BaseBranch: mainBranch, EventType: "pull_request"},
+			},
+			wantTargetNS: targetNamespace,
+			wantErr:      false,
+		},
 		{
 			name: "test-nomatch-url",
 			args: args{
@@ -169,31 +190,179 @@ func Test_getRepoByCR(t *testing.T) {
 			wantErr:      false,
 		},
 	}
+	for _, tt := range tests {
+		for _, withIndex := range []bool{false, true} {
+			t.Run(fmt.Sprintf("%s/index=%t", tt.name, withIndex), func(t *testing.T) {
+				ctx, _ := rtesting.SetupFakeContext(t)
+				cs, _ := testclient.SeedTestData(t, ctx, tt.args.data)
+				observer, _ := zapobserver.New(zap.InfoLevel)
+				logger := zap.New(observer).Sugar()
+				client := &params.Run{
+					Clients: clients.Clients{PipelineAsCode: cs.PipelineAsCode, Log: logger},
+					Info:    info.Info{},
+				}
+				if withIndex {
+					index, err := clients.NewRepositoryIndex(cs.PipelineAsCode)
+					assert.NilError(t, err)
+					assert.NilError(t, index.Start(ctx))
+					client.Clients.RepositoryIndex = index
+				}
+				got, err := MatchEventURLRepo(ctx, client, &tt.args.runevent, "")
+
+				if err == nil && tt.wantErr {
+					assert.NilError(t, err, "GetRepoByCR() error = %v, wantErr %v", err, tt.wantErr)
+				}
+				if tt.wantTargetNS == "" && got != nil {
+					t.Errorf("GetRepoByCR() got = '%v', want '%v'", got.GetNamespace(), tt.wantTargetNS)
+				}
+				if tt.wantTargetNS != "" && got == nil {
+					t.Errorf("GetRepoByCR() want nil got '%v'", tt.wantTargetNS)
+				}
+
+				if tt.wantTargetNS != "" && tt.wantTargetNS != got.GetNamespace() {
+					t.Errorf("GetRepoByCR() got = '%v', want '%v'", got.GetNamespace(), tt.wantTargetNS)
+				}
+			})
+		}
+	}
+}
+
+func TestMatchOrgRepository(t *testing.T) {
+	makeRepo := func(name, ns, url string) *v1alpha1.Repository {
+		return &v1alpha1.Repository{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: ns},
+			Spec:       v1alpha1.RepositorySpec{URL: url},
+		}
+	}
+	repo := makeRepo("repo", "ns", "https://forge/org/group/repo")
+	tests := []struct {
+		name       string
+		candidates []*v1alpha1.Repository
+		want       string
+	}{
+		{
+			name:       "organization repository",
+			candidates: []*v1alpha1.Repository{repo, makeRepo("org", "ns", "https://forge/org/")},
+			want:       "org",
+		},
+		{
+			name: "closest parent wins",
+			candidates: []*v1alpha1.Repository{
+				makeRepo("org", "ns", "https://forge/org"),
+				makeRepo("group", "ns", "https://forge/org/group"),
+			},
+			want: "group",
+		},
+		{
+			name:       "normalized url",
+			candidates: []*v1alpha1.Repository{makeRepo("org", "ns", "https://Forge/Org.git")},
+			want:       "org",
+		},
+		{
+			name: "first of the same url",
+			candidates: []*v1alpha1.Repository{
+				makeRepo("org", "ns", "https://forge/org"),
+				makeRepo("other", "other", "https://forge/org"),
+			},
+			want: "org",
+		},
+		{
+			name:       "url prefix is not a parent",
+			candidates: []*v1alpha1.Repository{makeRepo("org", "ns", "https://forge/or")},
+		},
+		{
+			name:       "only itself",
+			candidates: []*v1alpha1.Repository{repo},
+		},
+	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			ctx, _ := rtesting.SetupFakeContext(t)
-			cs, _ := testclient.SeedTestData(t, ctx, tt.args.data)
-			observer, _ := zapobserver.New(zap.InfoLevel)
-			logger := zap.New(observer).Sugar()
-			client := &params.Run{
-				Clients: clients.Clients{PipelineAsCode: cs.PipelineAsCode, Log: logger},
-				Info:    info.Info{},
+			got := MatchOrgRepository(repo.Spec.URL, tt.candidates)
+			if tt.want == "" {
+				assert.Assert(t, got == nil)
+				return
 			}
-			got, err := MatchEventURLRepo(ctx, client, &tt.args.runevent, "")
+			assert.Equal(t, got.GetName(), tt.want)
+		})
+	}
+}
 
-			if err == nil && tt.wantErr {
-				assert.NilError(t, err, "GetRepoByCR() error = %v, wantErr %v", err, tt.wantErr)
-			}
-			if tt.wantTargetNS == "" && got != nil {
-				t.Errorf("GetRepoByCR() got = '%v', want '%v'", got.GetNamespace(), tt.wantTargetNS)
-			}
-			if tt.wantTargetNS != "" && got == nil {
-				t.Errorf("GetRepoByCR() want nil got '%v'", tt.wantTargetNS)
+func TestMatchEventURLRepoWildcard(t *testing.T) {
+	repos := []*v1alpha1.Repository{
+		testnewrepo.NewRepo(testnewrepo.RepoTestcreationOpts{
+			Name:             "org",
+			URL:              "https://github.com/org/*",
+			InstallNamespace: "org-ci",
+		}),
+		testnewrepo.NewRepo(testnewrepo.RepoTestcreationOpts{
+			Name:             "hijack",
+			URL:              "https://github.com/other/*",
+			InstallNamespace: "user-ns",
+		}),
+		testnewrepo.NewRepo(testnewrepo.RepoTestcreationOpts{
+			Name:             "special",
+			URL:              "https://github.com/org/special",
+			InstallNamespace: "special-ns",
+		}),
+	}
+	tests := []struct {
+		name      string
+		wildcards string
+		url       string
+		ns        string
+		wantRepo  string
+	}{
+		{
+			name:      "match wildcard url",
+			wildcards: "https://github.com/org/*=org-ci",
+			url:       "https://github.com/Org/repo.git",
+			wantRepo:  "org",
+		},
+		{
+			name:      "repository url before wildcard url",
+			wildcards: "https://github.com/org/*=org-ci",
+			url:       "https://github.com/org/special",
+			wantRepo:  "special",
+		},
+		{
+			name:      "wildcard url not matching subgroups",
+			wildcards: "https://github.com/org/*=org-ci",
+			url:       "https://github.com/org/group/repo",
+		},
+		{
+			name: "wildcard url not in the setting",
+			url:  "https://github.com/other/repo",
+		},
+		{
+			name:      "wildcard url bound to another namespace",
+			wildcards: "https://github.com/other/*=other-ci",
+			url:       "https://github.com/other/repo",
+		},
+		{
+			name:      "wildcard url of another target namespace",
+			wildcards: "https://github.com/org/*=org-ci",
+			url:       "https://github.com/org/repo",
+			ns:        "special-ns",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, _ := rtesting.SetupFakeContext(t)
+			cs, _ := testclient.SeedTestData(t, ctx, testclient.Data{Repositories: repos})
+			run := &params.Run{
+				Clients: clients.Clients{PipelineAsCode: cs.PipelineAsCode},
+				Info: info.Info{Pac: &info.PacOpts{Settings: settings.Settings{
+					RepositoryURLWildcards: tt.wildcards,
+				}}},
 			}
-
-			if tt.wantTargetNS != "" && tt.wantTargetNS != got.GetNamespace() {
-				t.Errorf("GetRepoByCR() got = '%v', want '%v'", got.GetNamespace(), tt.wantTargetNS)
+			got, err := MatchEventURLRepo(ctx, run, &info.Event{URL: tt.url}, tt.ns)
+			assert.NilError(t, err)
+			if tt.wantRepo == "" {
+				assert.Assert(t, got == nil)
+				return
 			}
+			assert.Assert(t, got != nil)
+			assert.Equal(t, got.GetName(), tt.wantRepo)
 		})
 	}
 }
@@ -0,0 +1,101 @@
+package matcher
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	apipac "github.com/openshift-pipelines/pipelines-as-code/pkg/apis/pipelinesascode/v1alpha1"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/events"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/params"
+	"go.uber.org/zap/zapcore"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"knative.dev/pkg/logging"
+)
+
+// RepositoryURLConflictCondition is the condition of the Repository status
+// telling whether another Repository with the same URL is used for its events.
+const RepositoryURLConflictCondition = "URLConflict"
+
+// resolveRepositoryConflict returns the Repository used for the events of the
+// URL among the repositories sorted oldest first: a URL without a wildcard
+// has already won over a wildcard URL, the oldest Repository wins over the
+// others. The other Repositories get the URLConflict condition, and a
+// Kubernetes event the first time they lose.
+func resolveRepositoryConflict(ctx context.Context, cs *params.Run, url string, repositories []apipac.Repository) *apipac.Repository {
+	winner := &repositories[0]
+	if len(repositories) == 1 {
+		if meta.IsStatusConditionTrue(repositoryConditions(winner), RepositoryURLConflictCondition) {
+			setConflictCondition(ctx, cs, winner, metav1.Condition{
+				Type:   RepositoryURLConflictCondition,
+				Status: metav1.ConditionFalse,
+				Reason: "Matched",
+			})
+		}
+		return winner
+	}
+
+	logger := logging.FromContext(ctx)
+	winnerName := winner.GetNamespace() + "/" + winner.GetName()
+	names := []string{}
+	for i := range repositories[1:] {
+		loser := &repositories[i+1]
+		names = append(names, loser.GetNamespace()+"/"+loser.GetName())
+		condition := metav1.Condition{
+			Type:   RepositoryURLConflictCondition,
+			Status: metav1.ConditionTrue,
+			Reason: "OlderRepository",
+			Message: fmt.Sprintf("the older repository %s has the same URL %s and is used for its events, delete one of them",
+				winnerName, url),
+		}
+		if !setConflictCondition(ctx, cs, loser, condition) || cs.Clients.Kube == nil {
+			continue
+		}
+		eventEmitter := events.NewEventEmitter(cs.Clients.Kube, logger)
+		if cs.Info.Pac != nil {
+			eventEmitter.SetEnabled(func() bool { return cs.Info.GetPacOpts().EnableRepositoryEvents })
+		}
+		eventEmitter.EmitMessage(loser, zapcore.WarnLevel, "RepositoryURLConflict", condition.Message)
+	}
+	logger.Warnf("the repositories %s have the same URL %s as the older repository %s which is used",
+		strings.Join(names, ", "), url, winnerName)
+	return winner
+}
+
+// repositoryConditions returns the conditions of the Repository status.
+func repositoryConditions(repo *apipac.Repository) []metav1.Condition {
+	if repo.RepositoryStatus == nil {
+		return nil
+	}
+	return repo.RepositoryStatus.Conditions
+}
+
+// setConflictCondition sets the URLConflict condition of the Repository
+// status and returns whether it has changed. The Repository may come from
+// the cache, so the status is only read again when the cached condition is
+// different.
+func setConflictCondition(ctx context.Context, cs *params.Run, repo *apipac.Repository, condition metav1.Condition) bool {
+	current := meta.FindStatusCondition(repositoryConditions(repo), condition.Type)
+	if current != nil && current.Status == condition.Status && current.Reason == condition.Reason && current.Message == condition.Message {
+		return false
+	}
+	repositories := cs.Clients.PipelineAsCode.PipelinesascodeV1alpha1().Repositories(repo.GetNamespace())
+	lastrepo, err := repositories.Get(ctx, repo.GetName(), metav1.GetOptions{})
+	if err != nil {
+		logging.FromContext(ctx).Warnf("cannot get repository %s/%s: %v", repo.GetNamespace(), repo.GetName(), err)
+		return false
+	}
+	if lastrepo.RepositoryStatus == nil {
+		lastrepo.RepositoryStatus = &apipac.RepositoryStatus{}
+	}
+	condition.ObservedGeneration = lastrepo.GetGeneration()
+	if !meta.SetStatusCondition(&lastrepo.RepositoryStatus.Conditions, condition) {
+		return false
+	}
+	if _, err := repositories.UpdateStatus(ctx, lastrepo, metav1.UpdateOptions{}); err != nil {
+		logging.FromContext(ctx).Warnf("cannot update the status of repository %s/%s: %v", repo.GetNamespace(), repo.GetName(), err)
+		return false
+	}
+	return true
+}
@@ -0,0 +1,108 @@
+package matcher
+
+import (
+	"testing"
+	"time"
+
+	"github.com/jonboulle/clockwork"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/apis/pipelinesascode/v1alpha1"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/params"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/params/clients"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/params/info"
+	testclient "github.com/openshift-pipelines/pipelines-as-code/pkg/test/clients"
+	testnewrepo "github.com/openshift-pipelines/pipelines-as-code/pkg/test/repository"
+	"gotest.tools/v3/assert"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	rtesting "knative.dev/pkg/reconciler/testing"
+)
+
+func TestResolveRepositoryConflict(t *testing.T) {
+	cw := clockwork.NewFakeClock()
+	newRepo := func(name, ns string, age time.Duration, conflict metav1.ConditionStatus) *v1alpha1.Repository {
+		repo := testnewrepo.NewRepo(testnewrepo.RepoTestcreationOpts{
+			Name:             name,
+			URL:              targetURL,
+			InstallNamespace: ns,
+			CreateTime:       metav1.Time{Time: cw.Now().Add(-age)},
+		})
+		if conflict != "" {
+			repo.RepositoryStatus = &v1alpha1.RepositoryStatus{Conditions: []metav1.Condition{{
+				Type:   RepositoryURLConflictCondition,
+				Status: conflict,
+				Reason: "OlderRepository",
+			}}}
+		}
+		return repo
+	}
+	tests := []struct {
+		name         string
+		repositories []*v1alpha1.Repository
+		wantRepo     string
+		// wantConflict is the status of the URLConflict condition per
+		// namespace, empty when the condition is not set
+		wantConflict map[string]metav1.ConditionStatus
+		wantEvents   int
+	}{
+		{
+			name: "oldest repository wins",
+			repositories: []*v1alpha1.Repository{
+				newRepo("new", "new-ns", time.Minute, ""),
+				newRepo("old", "old-ns", 5*time.Minute, ""),
+			},
+			wantRepo:     "old",
+			wantConflict: map[string]metav1.ConditionStatus{"new-ns": metav1.ConditionTrue, "old-ns": ""},
+			wantEvents:   1,
+		},
+		{
+			name: "conflict cleared once alone",
+			repositories: []*v1alpha1.Repository{
+				newRepo("new", "new-ns", time.Minute, metav1.ConditionTrue),
+			},
+			wantRepo:     "new",
+			wantConflict: map[string]metav1.ConditionStatus{"new-ns": metav1.ConditionFalse},
+		},
+		{
+			name: "no conflict",
+			repositories: []*v1alpha1.Repository{
+				newRepo("repo", "ns", time.Minute, ""),
+			},
+			wantRepo:     "repo",
+			wantConflict: map[string]metav1.ConditionStatus{"ns": ""},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, _ := rtesting.SetupFakeContext(t)
+			cs, _ := testclient.SeedTestData(t, ctx, testclient.Data{Repositories: tt.repositories})
+			run := &params.Run{Clients: clients.Clients{PipelineAsCode: cs.PipelineAsCode, Kube: cs.Kube}}
+
+			// the conflict is only reported once for the same events
+			for range 2 {
+				got, err := MatchEventURLRepo(ctx, run, &info.Event{URL: targetURL}, "")
+				assert.NilError(t, err)
+				assert.Equal(t, got.GetName(), tt.wantRepo)
+			}
+
+			for ns, want := range tt.wantConflict {
+				repositories, err := cs.PipelineAsCode.PipelinesascodeV1alpha1().Repositories(ns).List(ctx, metav1.ListOptions{})
+				assert.NilError(t, err)
+				assert.Equal(t, len(repositories.Items), 1)
+				condition := meta.FindStatusCondition(repositoryConditions(&repositories.Items[0]), RepositoryURLConflictCondition)
+				if want == "" {
+					assert.Assert(t, condition == nil)
+					continue
+				}
+				assert.Assert(t, condition != nil)
+				assert.Equal(t, condition.Status, want)
+			}
+
+			events, err := cs.Kube.CoreV1().Events("").List(ctx, metav1.ListOptions{})
+			assert.NilError(t, err)
+			assert.Equal(t, len(events.Items), tt.wantEvents)
+			for _, event := range events.Items {
+				assert.Equal(t, event.Reason, "RepositoryURLConflict")
+			}
+		})
+	}
+}
@@ -36,6 +36,44 @@ var gitProviderAPIRequestCount = stats.Int64(
 	stats.UnitDimensionless,
 )
 
+var payloadTooLargeCount = stats.Int64(
+	"pipelines_as_code_payload_too_large_count",
+	"number of events rejected because their payload was bigger than the max-payload-size setting",
+	stats.UnitDimensionless,
+)
+
+var invalidProviderSecret = stats.Int64(
+	"pipelines_as_code_repository_invalid_provider_secret",
+	"whether the git provider token of a repository has been found invalid by the last validation",
+	stats.UnitDimensionless,
+)
+
+var prTimeoutCount = stats.Int64(
+	"pipelines_as_code_pipelinerun_timeout_count",
+	"number of pipelineruns by pipelines as code which have timed out",
+	stats.UnitDimensionless,
+)
+
+var eventQueueDepth = stats.Int64(
+	"pipelines_as_code_event_queue_depth",
+	"number of events waiting to be processed by the controller",
+	stats.UnitDimensionless,
+)
+
+var failureCount = stats.Int64(
+	"pipelines_as_code_repository_failure_count",
+	"number of failures of the repositories by failure reason",
+	stats.UnitDimensionless,
+)
+
+var prCPUSeconds = stats.Float64("pipelines_as_code_pipelinerun_cpu_seconds_sum",
+	"number of cpu seconds requested by all pipelineruns completed by pipelines as code",
+	stats.UnitDimensionless)
+
+var prMemoryByteSeconds = stats.Float64("pipelines_as_code_pipelinerun_memory_byte_seconds_sum",
+	"number of memory byte seconds requested by all pipelineruns completed by pipelines as code",
+	stats.UnitDimensionless)
+
 // Recorder holds keys for metrics.
 type Recorder struct {
 	initialized     bool
@@ -45,6 +83,7 @@ type Recorder struct {
 	repository      tag.Key
 	status          tag.Key
 	reason          tag.Key
+	timeoutSource   tag.Key
 	ReportingPeriod time.Duration
 }
 
@@ -107,6 +146,13 @@ func NewRecorder() (*Recorder, error) {
 		}
 		R.reason = reason
 
+		timeoutSource, errRegistering := tag.NewKey("timeout-source")
+		if errRegistering != nil {
+			ErrRegistering = errRegistering
+			return
+		}
+		R.timeoutSource = timeoutSource
+
 		var (
 			prCountView = &view.View{
 				Description: prCount.Description(),
@@ -134,10 +180,51 @@ func NewRecorder() (*Recorder, error) {
 				Aggregation: view.Count(),
 				TagKeys:     []tag.Key{R.provider, R.eventType, R.namespace, R.repository},
 			}
+			payloadTooLargeView = &view.View{
+				Description: payloadTooLargeCount.Description(),
+				Measure:     payloadTooLargeCount,
+				Aggregation: view.Count(),
+			}
+			invalidProviderSecretView = &view.View{
+				Description: invalidProviderSecret.Description(),
+				Measure:     invalidProviderSecret,
+				Aggregation: view.LastValue(),
+				TagKeys:     []tag.Key{R.namespace, R.repository},
+			}
+			prTimeoutView = &view.View{
+				Description: prTimeoutCount.Description(),
+				Measure:     prTimeoutCount,
+				Aggregation: view.Count(),
+				TagKeys:     []tag.Key{R.namespace, R.repository, R.timeoutSource},
+			}
+			eventQueueDepthView = &view.View{
+				Description: eventQueueDepth.Description(),
+				Measure:     eventQueueDepth,
+				Aggregation: view.LastValue(),
+				TagKeys:     []tag.Key{R.provider},
+			}
+			failureView = &view.View{
+				Description: failureCount.Description(),
+				Measure:     failureCount,
+				Aggregation: view.Count(),
+				TagKeys:     []tag.Key{R.namespace, R.repository, R.reason},
+			}
+			prCPUSecondsView = &view.View{
+				Description: prCPUSeconds.Description(),
+				Measure:     prCPUSeconds,
+				Aggregation: view.Sum(),
+				TagKeys:     []tag.Key{R.namespace, R.repository},
+			}
+			prMemoryByteSecondsView = &view.View{
+				Description: prMemoryByteSeconds.Description(),
+				Measure:     prMemoryByteSeconds,
+				Aggregation: view.Sum(),
+				TagKeys:     []tag.Key{R.namespace, R.repository},
+			}
 		)
 
-		view.Unregister(prCountView, prDurationView, runningPRView, gitProviderAPIRequestView)
-		errRegistering = view.Register(prCountView, prDurationView, runningPRView, gitProviderAPIRequestView)
+		view.Unregister(prCountView, prDurationView, runningPRView, gitProviderAPIRequestView, payloadTooLargeView, invalidProviderSecretView, prTimeoutView, eventQueueDepthView, failureView, prCPUSecondsView, prMemoryByteSecondsView)
+		errRegistering = view.Register(prCountView, prDurationView, runningPRView, gitProviderAPIRequestView, payloadTooLargeView, invalidProviderSecretView, prTimeoutView, eventQueueDepthView, failureView, prCPUSecondsView, prMemoryByteSecondsView)
 		if errRegistering != nil {
 			ErrRegistering = errRegistering
 			R.initialized = false
@@ -310,6 +397,121 @@ func (r *Recorder) ReportGitProviderAPIUsage(provider, event, namespace, reposit
 	return nil
 }
 
+// CountPayloadTooLarge counts the events rejected because of the size of
+// their payload.
+func (r *Recorder) CountPayloadTooLarge() error {
+	if err := r.assertInitialized(); err != nil {
+		return err
+	}
+	metrics.Record(context.Background(), payloadTooLargeCount.M(1))
+	return nil
+}
+
+// InvalidProviderSecret reports whether the git provider token of a
+// repository is invalid.
+func (r *Recorder) InvalidProviderSecret(namespace, repository string, invalid bool) error {
+	if err := r.assertInitialized(); err != nil {
+		return err
+	}
+
+	ctx, err := tag.New(
+		context.Background(),
+		tag.Insert(r.namespace, namespace),
+		tag.Insert(r.repository, repository),
+	)
+	if err != nil {
+		return err
+	}
+
+	value := int64(0)
+	if invalid {
+		value = 1
+	}
+	metrics.Record(ctx, invalidProviderSecret.M(value))
+	return nil
+}
+
+// CountTimeout counts the pipelineruns which have timed out, by where their
+// timeout comes from.
+func (r *Recorder) CountTimeout(namespace, repository, source string) error {
+	if err := r.assertInitialized(); err != nil {
+		return err
+	}
+
+	ctx, err := tag.New(
+		context.Background(),
+		tag.Insert(r.namespace, namespace),
+		tag.Insert(r.repository, repository),
+		tag.Insert(r.timeoutSource, source),
+	)
+	if err != nil {
+		return err
+	}
+
+	metrics.Record(ctx, prTimeoutCount.M(1))
+	return nil
+}
+
+// EventQueueDepth reports the number of events of a git provider waiting to
+// be processed.
+func (r *Recorder) EventQueueDepth(provider string, depth int) error {
+	if err := r.assertInitialized(); err != nil {
+		return err
+	}
+
+	ctx, err := tag.New(
+		context.Background(),
+		tag.Insert(r.provider, provider),
+	)
+	if err != nil {
+		return err
+	}
+
+	metrics.Record(ctx, eventQueueDepth.M(int64(depth)))
+	return nil
+}
+
+// CountFailure counts the failures of a repository by failure reason.
+func (r *Recorder) CountFailure(namespace, repository, reason string) error {
+	if err := r.assertInitialized(); err != nil {
+		return err
+	}
+
+	ctx, err := tag.New(
+		context.Background(),
+		tag.Insert(r.namespace, namespace),
+		tag.Insert(r.repository, repository),
+		tag.Insert(r.reason, reason),
+	)
+	if err != nil {
+		return err
+	}
+
+	metrics.Record(ctx, failureCount.M(1))
+	return nil
+}
+
+// CountResourceUsage accumulates the cpu and memory requested by a
+// pipelinerun multiplied by its duration.
+func (r *Recorder) CountResourceUsage(namespace, repository string, cpuSeconds, memoryByteSeconds float64) error {
+	if err := r.assertInitialized(); err != nil {
+		return err
+	}
+
+	ctx, err := tag.New(
+		context.Background(),
+		tag.Insert(r.namespace, namespace),
+		tag.Insert(r.repository, repository),
+	)
+	if err != nil {
+		return err
+	}
+
+	metrics.Record(ctx, prCPUSeconds.M(cpuSeconds))
+	metrics.Record(ctx, prMemoryByteSeconds.M(memoryByteSeconds))
+	return nil
+}
+
 func ResetRecorder() {
 	Once = sync.Once{}
 	R = nil
@@ -7,6 +7,7 @@ import (
 
 	"go.uber.org/zap"
 
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/acl"
 	"github.com/openshift-pipelines/pipelines-as-code/pkg/apis/pipelinesascode/v1alpha1"
 	"github.com/openshift-pipelines/pipelines-as-code/pkg/events"
 	"github.com/openshift-pipelines/pipelines-as-code/pkg/params/info"
@@ -18,7 +19,6 @@ var (
 	retestAllRegex    = regexp.MustCompile(`(?m)^/retest\s*$`)
 	testSingleRegex   = regexp.MustCompile(`(?m)^/test[ \t]+\S+`)
 	retestSingleRegex = regexp.MustCompile(`(?m)^/retest[ \t]+\S+`)
-	oktotestRegex     = regexp.MustCompile(`(?m)^/ok-to-test\s*$`)
 	cancelAllRegex    = regexp.MustCompile(`(?m)^(/cancel)\s*$`)
 	cancelSingleRegex = regexp.MustCompile(`(?m)^(/cancel)[ \t]+\S+`)
 )
@@ -57,7 +57,7 @@ func CommentEventType(comment string) EventType {
 		return TestAllCommentEventType
 	case testSingleRegex.MatchString(comment):
 		return TestSingleCommentEventType
-	case oktotestRegex.MatchString(comment):
+	case acl.IsOKToTestComment(comment):
 		return OkToTestCommentEventType
 	case cancelAllRegex.MatchString(comment):
 		return CancelCommentAllEventType
@@ -85,7 +85,7 @@ func SetEventTypeAndTargetPR(event *info.Event, comment string) {
 }
 
 func IsOkToTestComment(comment string) bool {
-	return oktotestRegex.MatchString(comment)
+	return acl.IsOKToTestComment(comment)
 }
 
 // EventTypeBackwardCompat handle the backward compatibility we need to keep until
@@ -36,8 +36,11 @@ type Clients struct {
 	HTTP              http.Client
 	Log               *zap.SugaredLogger
 	Dynamic           dynamic.Interface
-	consoleUIMutex    *sync.Mutex
-	consoleUI         consoleui.Interface
+	// RepositoryIndex finds the Repositories by URL from a cache, it is only
+	// started by the controller.
+	RepositoryIndex *RepositoryIndex
+	consoleUIMutex  *sync.Mutex
+	consoleUI       consoleui.Interface
 }
 
 func (c *Clients) InitClients() {
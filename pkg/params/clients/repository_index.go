@@ -0,0 +1,72 @@
+package clients
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/apis/pipelinesascode/v1alpha1"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/formatting"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/generated/clientset/versioned"
+	informers "github.com/openshift-pipelines/pipelines-as-code/pkg/generated/informers/externalversions/pipelinesascode/v1alpha1"
+	"k8s.io/client-go/tools/cache"
+)
+
+const repositoryURLIndex = "repository-url"
+
+// RepositoryIndex caches the Repositories of the cluster with an informer,
+// indexed by their normalized URL, for the events to find their Repository
+// without listing all of them.
+type RepositoryIndex struct {
+	informer cache.SharedIndexInformer
+}
+
+func NewRepositoryIndex(pac versioned.Interface) (*RepositoryIndex, error) {
+	informer := informers.NewRepositoryInformer(pac, "", 0, cache.Indexers{})
+	if err := informer.AddIndexers(cache.Indexers{repositoryURLIndex: repositoryURLIndexFunc}); err != nil {
+		return nil, err
+	}
+	return &RepositoryIndex{informer: informer}, nil
+}
+
+func repositoryURLIndexFunc(obj any) ([]string, error) {
+	repo, ok := obj.(*v1alpha1.Repository)
+	if !ok || repo.Spec.URL == "" {
+		return nil, nil
+	}
+	return []string{formatting.NormalizeRepoURL(repo.Spec.URL)}, nil
+}
+
+// Start runs the informer until the context is done and waits for its cache
+// to be filled.
+func (i *RepositoryIndex) Start(ctx context.Context) error {
+	go i.informer.Run(ctx.Done())
+	if !cache.WaitForCacheSync(ctx.Done(), i.informer.HasSynced) {
+		return fmt.Errorf("cannot fill the cache of the repositories")
+	}
+	return nil
+}
+
+// Synced returns whether the cache has been filled.
+func (i *RepositoryIndex) Synced() bool {
+	return i != nil && i.informer.HasSynced()
+}
+
+// ByURL returns copies of the Repositories with the URL once normalized, the
+// oldest first.
+func (i *RepositoryIndex) ByURL(url string) ([]v1alpha1.Repository, error) {
+	objs, err := i.informer.GetIndexer().ByIndex(repositoryURLIndex, formatting.NormalizeRepoURL(url))
+	if err != nil {
+		return nil, err
+	}
+	repositories := make([]v1alpha1.Repository, 0, len(objs))
+	for _, obj := range objs {
+		if repo, ok := obj.(*v1alpha1.Repository); ok {
+			repositories = append(repositories, *repo.DeepCopy())
+		}
+	}
+	slices.SortFunc(repositories, func(a, b v1alpha1.Repository) int {
+		return a.CreationTimestamp.Compare(b.CreationTimestamp.Time)
+	})
+	return repositories, nil
+}
@@ -20,6 +20,10 @@ type Event struct {
 	// Full request
 	Request *Request
 
+	// Polled is set on the events synthesized from polling the Git provider,
+	// they have no webhook payload to validate.
+	Polled bool
+
 	// TriggerTarget stable field across providers, ie: on GitLab, Github and
 	// others it would be always be pull_request we can rely on to know if it's
 	// a push or a pull_request
@@ -38,11 +42,17 @@ type Event struct {
 	URL           string // WEB url not the git URL, which would match to the repo.spec
 	SHAURL        string // pretty URL for web browsing for UIs (cli/web)
 	SHATitle      string // commit title for UIs
+	// PushCommits are the commits of a push event, from the oldest to the newest
+	PushCommits []Commit
 
 	PullRequestNumber int      // Pull or Merge Request number
 	PullRequestTitle  string   // Title of the pull Request
 	PullRequestLabel  []string // Labels of the pull Request
 	TriggerComment    string   // The comment triggering the pipelinerun when using on-comment annotation
+	ForcePush         bool     // Whether the head of the pull request has been force pushed
+	BeforeSHA         string   // The head SHA of the pull request overwritten by a force push
+	ReviewState       string   // State of the review of a pull_request_review event, like approved or dismissed
+	Reviewer          string   // Author of the review or the review comment of the pull request
 
 	// TODO: move forge specifics to each driver
 	// Github
@@ -50,6 +60,7 @@ type Event struct {
 	Repository     string
 	InstallationID int64
 	GHEURL         string
+	GithubAppSlug  string // slug of the GitHub App the event has been received with
 
 	// TODO: move out inside the provider
 	// Bitbucket Cloud
@@ -84,6 +95,13 @@ type Request struct {
 	Payload []byte
 }
 
+// Commit is a commit of a push event.
+type Commit struct {
+	SHA   string
+	URL   string
+	Title string
+}
+
 // DeepCopyInto deep copy runinfo in another instance.
 func (r *Event) DeepCopyInto(out *Event) {
 	*out = *r
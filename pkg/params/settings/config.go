@@ -3,13 +3,21 @@ package settings
 import (
 	"fmt"
 	"net/url"
+	"path"
+	"path/filepath"
 	"regexp"
+	"slices"
+	"strconv"
 	"strings"
 	"sync"
+	"time"
 
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/acl"
 	"github.com/openshift-pipelines/pipelines-as-code/pkg/configutil"
 	hubType "github.com/openshift-pipelines/pipelines-as-code/pkg/hub/vars"
 	"go.uber.org/zap"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/yaml"
 )
 
 const (
@@ -18,6 +26,9 @@ const (
 	HubURLKey                          = "hub-url"
 	HubCatalogNameKey                  = "hub-catalog-name"
 	HubCatalogTypeKey                  = "hub-catalog-type"
+	HubSecretKey                       = "hub-secret"
+	HubAuthHeaderKey                   = "hub-auth-header"
+	HubFailoverKey                     = "hub-failover"
 	TektonHubURLDefaultValue           = "https://api.hub.tekton.dev/v1"
 	TektonHubCatalogNameDefaultValue   = "tekton"
 	ArtifactHubCatalogNameDefaultValue = "artifacthub"
@@ -30,12 +41,29 @@ const (
 	CustomConsoleNamespaceURLKey = "custom-console-url-namespace"
 
 	SecretGhAppTokenRepoScopedKey = "secret-github-app-token-scoped" //nolint: gosec
+
+	// EventSourceWebhook receives the webhooks of the git providers directly.
+	EventSourceWebhook = "webhook"
+	// EventSourceCloudEvents receives the webhooks wrapped in CloudEvents,
+	// i.e: delivered by a Knative broker.
+	EventSourceCloudEvents = "cloudevents"
+
+	// SQSMessageFormatWebhook is a message with the webhook of the git provider.
+	SQSMessageFormatWebhook = "webhook"
+	// SQSMessageFormatEventBridge is an EventBridge event delivered to the
+	// queue by a rule of the bus, its detail is the webhook.
+	SQSMessageFormatEventBridge = "eventbridge"
+	// SQSMessageFormatSNS is an SNS notification delivered to the queue by a
+	// subscription of the topic, its message is the webhook.
+	SQSMessageFormatSNS = "sns"
 )
 
 var (
 	TknBinaryName       = `tkn`
 	TknBinaryURL        = `https://tekton.dev/docs/cli/#installation`
 	hubCatalogNameRegex = regexp.MustCompile(`^catalog-(\d+)-`)
+	// postRunHookNameRegex matches the names of the post-run hooks.
+	postRunHookNameRegex = regexp.MustCompile(`^[a-z0-9][a-z0-9-]*$`)
 )
 
 type HubCatalog struct {
@@ -43,6 +71,15 @@ type HubCatalog struct {
 	Name  string
 	URL   string
 	Type  string
+	// Secret is the Secret of the Pipelines-as-Code namespace with the token
+	// and the CA certificate of the catalog.
+	Secret string
+	// AuthHeader is the header the token is sent in, the token is sent as a
+	// bearer token in the Authorization header when it's empty.
+	AuthHeader string
+	// Failover are the catalogs the resources are fetched from, in order,
+	// when the catalog fails to return them.
+	Failover []string
 }
 
 // if there is a change performed on the default value,
@@ -55,10 +92,13 @@ type Settings struct {
 	DefaultMaxKeepRuns                  int    `json:"default-max-keep-runs"`
 	BitbucketCloudCheckSourceIP         bool   `default:"true"                                 json:"bitbucket-cloud-check-source-ip"`
 	BitbucketCloudAdditionalSourceIP    string `json:"bitbucket-cloud-additional-source-ip"`
+	BitbucketDataCenterProjectWebhook   bool   `default:"false"                                json:"bitbucket-datacenter-project-webhook"`
 	TektonDashboardURL                  string `json:"tekton-dashboard-url"`
 	AutoConfigureNewGitHubRepo          bool   `default:"false"                                json:"auto-configure-new-github-repo"`
 	AutoConfigureRepoNamespaceTemplate  string `json:"auto-configure-repo-namespace-template"`
 	AutoConfigureRepoRepositoryTemplate string `json:"auto-configure-repo-repository-template"`
+	AutoConfigureGitHubAppInstallations bool   `default:"false"                                json:"auto-configure-github-app-installations"`
+	AutoConfigureExistingNamespacesOnly bool   `default:"false"                                json:"auto-configure-existing-namespaces-only"`
 
 	SecretAutoCreation               bool   `default:"true"                             json:"secret-auto-create"`
 	SecretGHAppRepoScoped            bool   `default:"true"                             json:"secret-github-app-token-scoped"`
@@ -68,6 +108,8 @@ type Settings struct {
 	ErrorDetection              bool   `default:"true"                                                                          json:"error-detection-from-container-logs"`
 	ErrorDetectionNumberOfLines int    `default:"50"                                                                            json:"error-detection-max-number-of-lines"`
 	ErrorDetectionSimpleRegexp  string `default:"^(?P<filename>[^:]*):(?P<line>[0-9]+):(?P<column>[0-9]+)?([ ]*)?(?P<error>.*)" json:"error-detection-simple-regexp"`
+	ErrorLogSnippetMaxBytes     int    `default:"1048576"                                                                       json:"error-log-snippet-max-bytes"`
+	TektonResultsAPIURL         string `json:"tekton-results-api-url"`
 
 	EnableCancelInProgressOnPullRequests bool `json:"enable-cancel-in-progress-on-pull-requests"`
 	EnableCancelInProgressOnPush         bool `json:"enable-cancel-in-progress-on-push"`
@@ -80,7 +122,51 @@ type Settings struct {
 	CustomConsolePRTaskLog    string `json:"custom-console-url-pr-tasklog"`
 	CustomConsoleNamespaceURL string `json:"custom-console-url-namespace"`
 
-	RememberOKToTest bool `json:"remember-ok-to-test"`
+	RememberOKToTest bool   `json:"remember-ok-to-test"`
+	OKToTestAliases  string `json:"ok-to-test-aliases"`
+
+	QueuePendingTimeout string `json:"queue-pending-timeout"`
+
+	EnableRunHistoryAPI bool `json:"enable-run-history-api"`
+	EnableDashboard     bool `json:"enable-dashboard"`
+
+	MaxPayloadSize       int    `default:"26214400" json:"max-payload-size"`
+	DeduplicateEventsTTL string `default:"1h"       json:"deduplicate-events-ttl"`
+
+	ProviderSecretValidationInterval string `default:"24h" json:"provider-secret-validation-interval"`
+
+	DefaultPodSecurityContext string `json:"default-pod-security-context"`
+
+	GitCloneImage string `default:"gcr.io/tekton-releases/github.com/tektoncd/pipeline/cmd/git-init:v0.40.2" json:"git-clone-image"`
+
+	DynamicVariablesProvider         string `json:"dynamic-variables-provider"`
+	DynamicVariablesProviderTimeout  string `default:"5s" json:"dynamic-variables-provider-timeout"`
+	DynamicVariablesProviderCacheTTL string `default:"5m" json:"dynamic-variables-provider-cache-ttl"`
+
+	PostRunHooks string `json:"post-run-hooks"`
+
+	UsageResourceAccounting bool `json:"usage-resource-accounting"`
+
+	EventWorkers            int    `default:"20"   json:"event-workers"`
+	EventWorkersPerProvider string `json:"event-workers-per-provider"`
+	EventQueueSize          int    `default:"1000" json:"event-queue-size"`
+
+	EventTypesAllowList string `json:"event-types-allow-list"`
+	EventTypesDenyList  string `default:"github:fork,github:star,github:watch" json:"event-types-deny-list"`
+
+	RepositoryURLWildcards string `json:"repository-url-wildcards"`
+
+	CELCostLimit         int    `default:"1000000" json:"cel-cost-limit"`
+	CELEvaluationTimeout string `default:"1s"      json:"cel-evaluation-timeout"`
+	CELDisabledMacros    string `json:"cel-disabled-macros"`
+
+	EnableRepositoryEvents bool `default:"true" json:"enable-repository-events"`
+
+	EventSource string `default:"webhook" json:"event-source"`
+
+	SQSQueueURL      string `json:"sqs-queue-url"`
+	SQSRegion        string `json:"sqs-region"`
+	SQSMessageFormat string `default:"webhook" json:"sqs-message-format"`
 }
 
 func (s *Settings) DeepCopy(out *Settings) {
@@ -104,11 +190,33 @@ func DefaultSettings() Settings {
 
 func DefaultValidators() map[string]func(string) error {
 	return map[string]func(string) error{
-		"ErrorDetectionSimpleRegexp": isValidRegex,
-		"TektonDashboardURL":         isValidURL,
-		"CustomConsoleURL":           isValidURL,
-		"CustomConsolePRTaskLog":     startWithHTTPorHTTPS,
-		"CustomConsolePRDetail":      startWithHTTPorHTTPS,
+		"ErrorDetectionSimpleRegexp":       isValidRegex,
+		"TektonDashboardURL":               isValidURL,
+		"CustomConsoleURL":                 isValidURL,
+		"CustomConsolePRTaskLog":           startWithHTTPorHTTPS,
+		"CustomConsolePRDetail":            startWithHTTPorHTTPS,
+		"QueuePendingTimeout":              isValidDuration,
+		"TektonResultsAPIURL":              isValidURL,
+		"DeduplicateEventsTTL":             isValidDuration,
+		"ProviderSecretValidationInterval": isValidDuration,
+		"DefaultPodSecurityContext":        isValidPodSecurityContext,
+		"DynamicVariablesProvider":         isValidURLOrAbsolutePath,
+		"DynamicVariablesProviderTimeout":  isValidDuration,
+		"DynamicVariablesProviderCacheTTL": isValidDuration,
+		"OKToTestAliases":                  isValidOKToTestAliases,
+		"PostRunHooks":                     isValidPostRunHooks,
+		"EventWorkers":                     isPositiveInteger,
+		"EventWorkersPerProvider":          isValidEventWorkersPerProvider,
+		"EventQueueSize":                   isPositiveInteger,
+		"EventTypesAllowList":              isValidEventTypes,
+		"EventTypesDenyList":               isValidEventTypes,
+		"RepositoryURLWildcards":           isValidRepositoryURLWildcards,
+		"CELCostLimit":                     isPositiveInteger,
+		"CELEvaluationTimeout":             isValidDuration,
+		"CELDisabledMacros":                isValidCELMacros,
+		"EventSource":                      isValidEventSource,
+		"SQSQueueURL":                      isValidURL,
+		"SQSMessageFormat":                 isValidSQSMessageFormat,
 	}
 }
 
@@ -135,6 +243,182 @@ func SyncConfig(logger *zap.SugaredLogger, setting *Settings, config map[string]
 	setting.HubCatalogs.Store("default", catalogDefault)
 	// TODO: detect changes in extra hub catalogs
 
+	acl.SetOKToTestAliases(ParseOKToTestAliases(setting.OKToTestAliases))
+
+	return nil
+}
+
+// ParseOKToTestAliases returns the commands of a comma separated list of
+// aliases of /ok-to-test.
+func ParseOKToTestAliases(value string) []string {
+	aliases := []string{}
+	for _, alias := range strings.Split(value, ",") {
+		if alias = strings.TrimSpace(alias); alias != "" {
+			aliases = append(aliases, alias)
+		}
+	}
+	return aliases
+}
+
+func isValidOKToTestAliases(value string) error {
+	for _, alias := range ParseOKToTestAliases(value) {
+		if !strings.HasPrefix(alias, "/") || len(alias) == 1 {
+			return fmt.Errorf("invalid ok-to-test alias %q, it must start with / followed by the command", alias)
+		}
+		if strings.ContainsAny(alias, "\r\n") {
+			return fmt.Errorf("invalid ok-to-test alias %q, it must be on a single line", alias)
+		}
+	}
+	return nil
+}
+
+// isValidPostRunHooks only checks the syntax of the hook names, the unknown
+// hooks are reported by the watcher when the PipelineRuns complete.
+func isValidPostRunHooks(value string) error {
+	for _, name := range strings.Split(value, ",") {
+		if name = strings.TrimSpace(name); name != "" && !postRunHookNameRegex.MatchString(name) {
+			return fmt.Errorf("invalid post-run hook name %q", name)
+		}
+	}
+	return nil
+}
+
+// ParseEventWorkersPerProvider returns the number of workers of the providers
+// of a comma separated list like "github=30,gitlab=10".
+func ParseEventWorkersPerProvider(value string) (map[string]int, error) {
+	workers := map[string]int{}
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item == "" {
+			continue
+		}
+		name, count, found := strings.Cut(item, "=")
+		n, err := strconv.Atoi(strings.TrimSpace(count))
+		if !found || strings.TrimSpace(name) == "" || err != nil || n <= 0 {
+			return nil, fmt.Errorf("invalid event workers %q, it must be a provider name and a positive number like github=10", item)
+		}
+		workers[strings.TrimSpace(name)] = n
+	}
+	return workers, nil
+}
+
+func isValidEventWorkersPerProvider(value string) error {
+	_, err := ParseEventWorkersPerProvider(value)
+	return err
+}
+
+// EventTypeProviders are the names of the git providers of the event types
+// lists, both Bitbucket Cloud and Data Center send their event type in the
+// same header.
+var EventTypeProviders = []string{"bitbucket", "gitea", "github", "gitlab"}
+
+// ParseEventTypes returns the event types of each git provider of a comma
+// separated list like "github:star,gitlab:Wiki Page Hook".
+func ParseEventTypes(value string) (map[string][]string, error) {
+	eventTypes := map[string][]string{}
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item == "" {
+			continue
+		}
+		provider, eventType, found := strings.Cut(item, ":")
+		provider, eventType = strings.TrimSpace(provider), strings.TrimSpace(eventType)
+		if !found || eventType == "" {
+			return nil, fmt.Errorf("invalid event type %q, it must be a provider name and an event type like github:star", item)
+		}
+		if !slices.Contains(EventTypeProviders, provider) {
+			return nil, fmt.Errorf("invalid event type %q, the provider must be one of %s", item, strings.Join(EventTypeProviders, ", "))
+		}
+		eventTypes[provider] = append(eventTypes[provider], eventType)
+	}
+	return eventTypes, nil
+}
+
+func isValidEventTypes(value string) error {
+	_, err := ParseEventTypes(value)
+	return err
+}
+
+// RepositoryURLWildcard binds a Repository URL with a wildcard, like
+// https://github.com/org/*, to the namespace allowed to have it.
+type RepositoryURLWildcard struct {
+	URL       string
+	Namespace string
+}
+
+// ParseRepositoryURLWildcards parses a comma separated list of wildcard URLs
+// and namespaces like "https://github.com/org/*=org-ci".
+func ParseRepositoryURLWildcards(value string) ([]RepositoryURLWildcard, error) {
+	wildcards := []RepositoryURLWildcard{}
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item == "" {
+			continue
+		}
+		i := strings.LastIndex(item, "=")
+		if i < 0 {
+			return nil, fmt.Errorf("invalid repository url wildcard %q, it must be an url and a namespace like https://github.com/org/*=namespace", item)
+		}
+		wildcard := RepositoryURLWildcard{URL: strings.TrimSpace(item[:i]), Namespace: strings.TrimSpace(item[i+1:])}
+		if !strings.Contains(wildcard.URL, "*") {
+			return nil, fmt.Errorf("invalid repository url wildcard %q, the url has no wildcard", item)
+		}
+		if _, err := path.Match(wildcard.URL, ""); err != nil {
+			return nil, fmt.Errorf("invalid repository url wildcard %q: %w", item, err)
+		}
+		if wildcard.Namespace == "" {
+			return nil, fmt.Errorf("invalid repository url wildcard %q, the namespace is empty", item)
+		}
+		wildcards = append(wildcards, wildcard)
+	}
+	return wildcards, nil
+}
+
+func isValidRepositoryURLWildcards(value string) error {
+	_, err := ParseRepositoryURLWildcards(value)
+	return err
+}
+
+// CELMacros are the macros of the CEL expressions which can be disabled.
+var CELMacros = []string{"all", "exists", "exists_one", "filter", "has", "map"}
+
+// ParseCELDisabledMacros returns the macros of a comma separated list like
+// "map,filter".
+func ParseCELDisabledMacros(value string) ([]string, error) {
+	macros := []string{}
+	for _, macro := range strings.Split(value, ",") {
+		if macro = strings.TrimSpace(macro); macro == "" {
+			continue
+		}
+		if !slices.Contains(CELMacros, macro) {
+			return nil, fmt.Errorf("invalid cel macro %q, it must be one of %s", macro, strings.Join(CELMacros, ", "))
+		}
+		macros = append(macros, macro)
+	}
+	return macros, nil
+}
+
+func isValidCELMacros(value string) error {
+	_, err := ParseCELDisabledMacros(value)
+	return err
+}
+
+func isValidEventSource(value string) error {
+	if value != EventSourceWebhook && value != EventSourceCloudEvents {
+		return fmt.Errorf("invalid event source %q, it must be %s or %s", value, EventSourceWebhook, EventSourceCloudEvents)
+	}
+	return nil
+}
+
+func isValidSQSMessageFormat(value string) error {
+	formats := []string{SQSMessageFormatEventBridge, SQSMessageFormatSNS, SQSMessageFormatWebhook}
+	if !slices.Contains(formats, value) {
+		return fmt.Errorf("invalid sqs message format %q, it must be one of %s", value, strings.Join(formats, ", "))
+	}
+	return nil
+}
+
+func isPositiveInteger(value string) error {
+	if n, err := strconv.Atoi(value); err != nil || n <= 0 {
+		return fmt.Errorf("invalid value %q, it must be a positive number", value)
+	}
 	return nil
 }
 
@@ -145,6 +429,44 @@ func isValidURL(rawURL string) error {
 	return nil
 }
 
+// ParsePodSecurityContext parses a pod security context written in YAML or
+// JSON, unknown fields are refused.
+func ParsePodSecurityContext(value string) (*corev1.PodSecurityContext, error) {
+	securityContext := &corev1.PodSecurityContext{}
+	if err := yaml.UnmarshalStrict([]byte(value), securityContext); err != nil {
+		return nil, err
+	}
+	return securityContext, nil
+}
+
+func isValidPodSecurityContext(value string) error {
+	if _, err := ParsePodSecurityContext(value); err != nil {
+		return fmt.Errorf("invalid pod security context: %w", err)
+	}
+	return nil
+}
+
+func isValidDuration(value string) error {
+	d, err := time.ParseDuration(value)
+	if err != nil {
+		return fmt.Errorf("invalid duration: %w", err)
+	}
+	if d < 0 {
+		return fmt.Errorf("invalid duration: %s cannot be negative", value)
+	}
+	return nil
+}
+
+func isValidURLOrAbsolutePath(value string) error {
+	if filepath.IsAbs(value) {
+		return nil
+	}
+	if err := startWithHTTPorHTTPS(value); err != nil {
+		return fmt.Errorf("invalid value, must be an absolute path or start with http:// or https://")
+	}
+	return isValidURL(value)
+}
+
 func isValidRegex(regex string) error {
 	if _, err := regexp.Compile(regex); err != nil {
 		return fmt.Errorf("invalid regex: %w", err)
@@ -31,6 +31,8 @@ func TestSyncConfig(t *testing.T) {
 				TektonDashboardURL:                   "",
 				AutoConfigureNewGitHubRepo:           false,
 				AutoConfigureRepoNamespaceTemplate:   "",
+				AutoConfigureGitHubAppInstallations:  false,
+				AutoConfigureExistingNamespacesOnly:  false,
 				SecretAutoCreation:                   true,
 				SecretGHAppRepoScoped:                true,
 				SecretGhAppTokenScopedExtraRepos:     "",
@@ -38,6 +40,7 @@ func TestSyncConfig(t *testing.T) {
 				ErrorDetection:                       true,
 				ErrorDetectionNumberOfLines:          50,
 				ErrorDetectionSimpleRegexp:           "^(?P<filename>[^:]*):(?P<line>[0-9]+):(?P<column>[0-9]+)?([ ]*)?(?P<error>.*)",
+				ErrorLogSnippetMaxBytes:              1048576,
 				EnableCancelInProgressOnPullRequests: false,
 				EnableCancelInProgressOnPush:         false,
 				SkipPushEventForPRCommits:            true,
@@ -47,6 +50,20 @@ func TestSyncConfig(t *testing.T) {
 				CustomConsolePRTaskLog:               "",
 				CustomConsoleNamespaceURL:            "",
 				RememberOKToTest:                     false,
+				MaxPayloadSize:                       26214400,
+				DeduplicateEventsTTL:                 "1h",
+				GitCloneImage:                        "gcr.io/tekton-releases/github.com/tektoncd/pipeline/cmd/git-init:v0.40.2",
+				ProviderSecretValidationInterval:     "24h",
+				DynamicVariablesProviderTimeout:      "5s",
+				DynamicVariablesProviderCacheTTL:     "5m",
+				EventWorkers:                         20,
+				EventQueueSize:                       1000,
+				EventTypesDenyList:                   "github:fork,github:star,github:watch",
+				CELCostLimit:                         1000000,
+				CELEvaluationTimeout:                 "1s",
+				EnableRepositoryEvents:               true,
+				EventSource:                          "webhook",
+				SQSMessageFormat:                     "webhook",
 			},
 		},
 		{
@@ -62,6 +79,8 @@ func TestSyncConfig(t *testing.T) {
 				"auto-configure-new-github-repo":          "true",
 				"auto-configure-repo-namespace-template":  "template",
 				"auto-configure-repo-repository-template": "template",
+				"auto-configure-github-app-installations": "true",
+				"auto-configure-existing-namespaces-only": "true",
 				"secret-auto-create":                      "false",
 				"secret-github-app-token-scoped":          "false",
 				"secret-github-app-scope-extra-repos":     "extra-repos",
@@ -69,13 +88,42 @@ func TestSyncConfig(t *testing.T) {
 				"error-detection-from-container-logs":     "false",
 				"error-detection-max-number-of-lines":     "100",
 				"error-detection-simple-regexp":           "^(?P<filename>[^:]*):(?P<line>[0-9]+):(?P<column>[0-9]+)?([ ]*)?(?P<error>.*)",
+				"error-log-snippet-max-bytes":             "4096",
+				"tekton-results-api-url":                  "https://tekton-results",
 				"custom-console-name":                     "custom-console",
 				"custom-console-url":                      "https://custom-console",
 				"custom-console-url-pr-details":           "https://custom-console-pr-details",
 				"custom-console-url-pr-tasklog":           "https://custom-console-pr-tasklog",
 				"custom-console-url-namespace":            "https://custom-console-namespace",
 				"remember-ok-to-test":                     "false",
+				"ok-to-test-aliases":                      "/lgtm-test, /approve-ci",
 				"skip-push-event-for-pr-commits":          "true",
+				"queue-pending-timeout":                   "1h",
+				"enable-run-history-api":                  "true",
+				"enable-dashboard":                        "true",
+				"max-payload-size":                        "1024",
+				"deduplicate-events-ttl":                  "10m",
+				"provider-secret-validation-interval":     "1h",
+				"default-pod-security-context":            "runAsNonRoot: true",
+				"git-clone-image":                         "registry.local/git-init:v1",
+				"dynamic-variables-provider":              "https://variables.example.com",
+				"dynamic-variables-provider-timeout":      "1s",
+				"dynamic-variables-provider-cache-ttl":    "0s",
+				"post-run-hooks":                          "sbom",
+				"usage-resource-accounting":               "true",
+				"event-workers":                           "10",
+				"event-workers-per-provider":              "github=30, gitlab=5",
+				"event-queue-size":                        "100",
+				"event-types-allow-list":                  "gitlab:Push Hook,gitlab:Merge Request Hook",
+				"event-types-deny-list":                   "github:star",
+				"repository-url-wildcards":                "https://github.com/org/*=org-ci",
+				"cel-cost-limit":                          "1000",
+				"cel-evaluation-timeout":                  "100ms",
+				"cel-disabled-macros":                     "map, filter",
+				"enable-repository-events":                "false",
+				"event-source":                            "cloudevents",
+				"sqs-queue-url":                           "https://sqs.eu-west-1.amazonaws.com/123456789012/pac-events",
+				"sqs-message-format":                      "eventbridge",
 			},
 			expectedStruct: Settings{
 				ApplicationName:                     "pac-pac",
@@ -89,6 +137,8 @@ func TestSyncConfig(t *testing.T) {
 				AutoConfigureNewGitHubRepo:          true,
 				AutoConfigureRepoNamespaceTemplate:  "template",
 				AutoConfigureRepoRepositoryTemplate: "template",
+				AutoConfigureGitHubAppInstallations: true,
+				AutoConfigureExistingNamespacesOnly: true,
 				SecretAutoCreation:                  false,
 				SecretGHAppRepoScoped:               false,
 				SecretGhAppTokenScopedExtraRepos:    "extra-repos",
@@ -96,13 +146,41 @@ func TestSyncConfig(t *testing.T) {
 				ErrorDetection:                      false,
 				ErrorDetectionNumberOfLines:         100,
 				ErrorDetectionSimpleRegexp:          "^(?P<filename>[^:]*):(?P<line>[0-9]+):(?P<column>[0-9]+)?([ ]*)?(?P<error>.*)",
+				ErrorLogSnippetMaxBytes:             4096,
+				TektonResultsAPIURL:                 "https://tekton-results",
 				CustomConsoleName:                   "custom-console",
 				CustomConsoleURL:                    "https://custom-console",
 				CustomConsolePRdetail:               "https://custom-console-pr-details",
 				CustomConsolePRTaskLog:              "https://custom-console-pr-tasklog",
 				CustomConsoleNamespaceURL:           "https://custom-console-namespace",
 				RememberOKToTest:                    false,
+				OKToTestAliases:                     "/lgtm-test, /approve-ci",
 				SkipPushEventForPRCommits:           true,
+				QueuePendingTimeout:                 "1h",
+				EnableRunHistoryAPI:                 true,
+				EnableDashboard:                     true,
+				MaxPayloadSize:                      1024,
+				DeduplicateEventsTTL:                "10m",
+				ProviderSecretValidationInterval:    "1h",
+				DefaultPodSecurityContext:           "runAsNonRoot: true",
+				GitCloneImage:                       "registry.local/git-init:v1",
+				DynamicVariablesProvider:            "https://variables.example.com",
+				DynamicVariablesProviderTimeout:     "1s",
+				DynamicVariablesProviderCacheTTL:    "0s",
+				PostRunHooks:                        "sbom",
+				UsageResourceAccounting:             true,
+				EventWorkers:                        10,
+				EventWorkersPerProvider:             "github=30, gitlab=5",
+				EventQueueSize:                      100,
+				EventTypesAllowList:                 "gitlab:Push Hook,gitlab:Merge Request Hook",
+				EventTypesDenyList:                  "github:star",
+				RepositoryURLWildcards:              "https://github.com/org/*=org-ci",
+				CELCostLimit:                        1000,
+				CELEvaluationTimeout:                "100ms",
+				CELDisabledMacros:                   "map, filter",
+				EventSource:                         "cloudevents",
+				SQSQueueURL:                         "https://sqs.eu-west-1.amazonaws.com/123456789012/pac-events",
+				SQSMessageFormat:                    "eventbridge",
 			},
 		},
 		{
@@ -140,6 +218,97 @@ func TestSyncConfig(t *testing.T) {
 			},
 			expectedError: "custom validation failed for field CustomConsolePRTaskLog: invalid value, must start with http:// or https://",
 		},
+		{
+			name: "invalid value for queue pending timeout",
+			configMap: map[string]string{
+				"queue-pending-timeout": "1 hour",
+			},
+			expectedError: "custom validation failed for field QueuePendingTimeout: invalid duration",
+		},
+		{
+			name: "negative value for queue pending timeout",
+			configMap: map[string]string{
+				"queue-pending-timeout": "-1h",
+			},
+			expectedError: "custom validation failed for field QueuePendingTimeout: invalid duration: -1h cannot be negative",
+		},
+		{
+			name: "invalid value for default pod security context",
+			configMap: map[string]string{
+				"default-pod-security-context": "runAsNonRoot: true\nrunAsRoot: false",
+			},
+			expectedError: "custom validation failed for field DefaultPodSecurityContext: invalid pod security context",
+		},
+		{
+			name: "invalid value for ok-to-test aliases",
+			configMap: map[string]string{
+				"ok-to-test-aliases": "/lgtm-test,approve-ci",
+			},
+			expectedError: "custom validation failed for field OKToTestAliases: invalid ok-to-test alias \"approve-ci\", it must start with / followed by the command",
+		},
+		{
+			name: "invalid value for post-run hooks",
+			configMap: map[string]string{
+				"post-run-hooks": "sbom, SBOM Upload",
+			},
+			expectedError: "custom validation failed for field PostRunHooks: invalid post-run hook name \"SBOM Upload\"",
+		},
+		{
+			name: "invalid value for event workers per provider",
+			configMap: map[string]string{
+				"event-workers-per-provider": "github=30,gitlab",
+			},
+			expectedError: "custom validation failed for field EventWorkersPerProvider: invalid event workers \"gitlab\", it must be a provider name and a positive number like github=10",
+		},
+		{
+			name: "invalid provider of event types",
+			configMap: map[string]string{
+				"event-types-deny-list": "github:star,forgejo:push",
+			},
+			expectedError: "custom validation failed for field EventTypesDenyList: invalid event type \"forgejo:push\", the provider must be one of bitbucket, gitea, github, gitlab",
+		},
+		{
+			name: "repository url wildcard without namespace",
+			configMap: map[string]string{
+				"repository-url-wildcards": "https://github.com/org/*=",
+			},
+			expectedError: "custom validation failed for field RepositoryURLWildcards: invalid repository url wildcard \"https://github.com/org/*=\", the namespace is empty",
+		},
+		{
+			name: "invalid event source",
+			configMap: map[string]string{
+				"event-source": "kafka",
+			},
+			expectedError: "custom validation failed for field EventSource: invalid event source \"kafka\", it must be webhook or cloudevents",
+		},
+		{
+			name: "invalid sqs message format",
+			configMap: map[string]string{
+				"sqs-message-format": "kinesis",
+			},
+			expectedError: "custom validation failed for field SQSMessageFormat: invalid sqs message format \"kinesis\", it must be one of eventbridge, sns, webhook",
+		},
+		{
+			name: "invalid cel macro",
+			configMap: map[string]string{
+				"cel-disabled-macros": "map,reduce",
+			},
+			expectedError: "custom validation failed for field CELDisabledMacros: invalid cel macro \"reduce\", it must be one of all, exists, exists_one, filter, has, map",
+		},
+		{
+			name: "invalid value for event queue size",
+			configMap: map[string]string{
+				"event-queue-size": "0",
+			},
+			expectedError: "custom validation failed for field EventQueueSize: invalid value \"0\", it must be a positive number",
+		},
+		{
+			name: "invalid value for dynamic variables provider",
+			configMap: map[string]string{
+				"dynamic-variables-provider": "bin/variables",
+			},
+			expectedError: "custom validation failed for field DynamicVariablesProvider: invalid value, must be an absolute path or start with http:// or https://",
+		},
 	}
 
 	for _, tc := range testCases {
@@ -3,6 +3,8 @@ package settings
 import (
 	"fmt"
 	"net/url"
+	"reflect"
+	"strings"
 	"sync"
 
 	hubtypes "github.com/openshift-pipelines/pipelines-as-code/pkg/hub/vars"
@@ -25,10 +27,13 @@ func getHubCatalogs(logger *zap.SugaredLogger, catalogs *sync.Map, config map[st
 		config[HubCatalogTypeKey] = hubtypes.ArtifactHubType
 	}
 	hc := HubCatalog{
-		Index: "default",
-		Name:  config[HubCatalogNameKey],
-		URL:   config[HubURLKey],
-		Type:  config[HubCatalogTypeKey],
+		Index:      "default",
+		Name:       config[HubCatalogNameKey],
+		URL:        config[HubURLKey],
+		Type:       config[HubCatalogTypeKey],
+		Secret:     config[HubSecretKey],
+		AuthHeader: config[HubAuthHeaderKey],
+		Failover:   splitCatalogs(config[HubFailoverKey]),
 	}
 	catalogs.Store("default", hc)
 
@@ -86,22 +91,37 @@ func getHubCatalogs(logger *zap.SugaredLogger, catalogs *sync.Map, config map[st
 					catalogType = hubtypes.ArtifactHubType // default to artifact hub if not specified
 				}
 
+				catalog := HubCatalog{
+					Index:      index,
+					Name:       catalogName,
+					URL:        catalogURL,
+					Type:       catalogType,
+					Secret:     config[fmt.Sprintf("%s-secret", cPrefix)],
+					AuthHeader: config[fmt.Sprintf("%s-auth-header", cPrefix)],
+					Failover:   splitCatalogs(config[fmt.Sprintf("%s-failover", cPrefix)]),
+				}
 				value, ok := catalogs.Load(catalogID)
 				if ok {
 					catalogValues, ok := value.(HubCatalog)
-					if ok && (catalogValues.Name == catalogName) && (catalogValues.URL == catalogURL) && (catalogValues.Index == index) && (catalogValues.Type == catalogType) {
+					if ok && reflect.DeepEqual(catalogValues, catalog) {
 						continue
 					}
 				}
 				logger.Infof("CONFIG: setting custom hub %s, catalog %s", catalogID, catalogURL)
-				catalogs.Store(catalogID, HubCatalog{
-					Index: index,
-					Name:  catalogName,
-					URL:   catalogURL,
-					Type:  catalogType,
-				})
+				catalogs.Store(catalogID, catalog)
 			}
 		}
 	}
 	return catalogs
 }
+
+// splitCatalogs splits a comma separated list of catalogs.
+func splitCatalogs(value string) []string {
+	var catalogs []string
+	for _, catalog := range strings.Split(value, ",") {
+		if catalog = strings.TrimSpace(catalog); catalog != "" {
+			catalogs = append(catalogs, catalog)
+		}
+	}
+	return catalogs
+}
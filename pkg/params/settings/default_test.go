@@ -163,3 +163,31 @@ func TestGetCatalogHub(t *testing.T) {
 		})
 	}
 }
+
+func TestGetCatalogHubAuth(t *testing.T) {
+	config := map[string]string{
+		"hub-secret":            "hub-auth",
+		"hub-failover":          "mirror, , artifact",
+		"catalog-1-id":          "mirror",
+		"catalog-1-url":         "https://mirror.example.com",
+		"catalog-1-name":        "tekton",
+		"catalog-1-secret":      "mirror-auth",
+		"catalog-1-auth-header": "X-Api-Key",
+		"catalog-1-failover":    "default",
+	}
+	catalogs := getHubCatalogs(zap.NewNop().Sugar(), &sync.Map{}, config)
+
+	value, ok := catalogs.Load("default")
+	assert.Assert(t, ok)
+	catalog, _ := value.(HubCatalog)
+	assert.Equal(t, catalog.Secret, "hub-auth")
+	assert.Equal(t, catalog.AuthHeader, "")
+	assert.DeepEqual(t, catalog.Failover, []string{"mirror", "artifact"})
+
+	value, ok = catalogs.Load("mirror")
+	assert.Assert(t, ok)
+	catalog, _ = value.(HubCatalog)
+	assert.Equal(t, catalog.Secret, "mirror-auth")
+	assert.Equal(t, catalog.AuthHeader, "X-Api-Key")
+	assert.DeepEqual(t, catalog.Failover, []string{"default"})
+}
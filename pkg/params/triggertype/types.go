@@ -8,7 +8,7 @@ type (
 func IsPullRequestType(s string) Trigger {
 	eventType := s
 	switch s {
-	case PullRequest.String(), OkToTest.String(), Retest.String(), Cancel.String(), PullRequestLabeled.String():
+	case PullRequest.String(), OkToTest.String(), Retest.String(), Cancel.String(), PullRequestLabeled.String(), PullRequestReview.String(), PullRequestReviewComment.String():
 		eventType = PullRequest.String()
 	}
 	return Trigger(eventType)
@@ -40,20 +40,26 @@ func StringToType(s string) Trigger {
 		return Comment
 	case PullRequestLabeled.String():
 		return PullRequestLabeled
+	case PullRequestReview.String():
+		return PullRequestReview
+	case PullRequestReviewComment.String():
+		return PullRequestReviewComment
 	}
 	return ""
 }
 
 const (
-	Cancel                Trigger = "cancel"
-	CheckRunRerequested   Trigger = "check-run-rerequested"
-	CheckSuiteRerequested Trigger = "check-suite-rerequested"
-	Comment               Trigger = "comment"
-	Incoming              Trigger = "incoming"
-	PullRequestLabeled    Trigger = "pull_request_labeled"
-	OkToTest              Trigger = "ok-to-test"
-	PullRequestClosed     Trigger = "pull_request_closed"
-	PullRequest           Trigger = "pull_request" // it's should be "pull_request_opened_updated" but let's keep it simple.
-	Push                  Trigger = "push"
-	Retest                Trigger = "retest"
+	Cancel                   Trigger = "cancel"
+	CheckRunRerequested      Trigger = "check-run-rerequested"
+	CheckSuiteRerequested    Trigger = "check-suite-rerequested"
+	Comment                  Trigger = "comment"
+	Incoming                 Trigger = "incoming"
+	PullRequestLabeled       Trigger = "pull_request_labeled"
+	OkToTest                 Trigger = "ok-to-test"
+	PullRequestClosed        Trigger = "pull_request_closed"
+	PullRequestReview        Trigger = "pull_request_review"
+	PullRequestReviewComment Trigger = "pull_request_review_comment"
+	PullRequest              Trigger = "pull_request" // it's should be "pull_request_opened_updated" but let's keep it simple.
+	Push                     Trigger = "push"
+	Retest                   Trigger = "retest"
 )
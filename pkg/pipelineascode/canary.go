@@ -0,0 +1,177 @@
+package pipelineascode
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/apis/pipelinesascode/keys"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/apis/pipelinesascode/v1alpha1"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/formatting"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/matcher"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/params/info"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/params/triggertype"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/resolve"
+	tektonv1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+	"go.uber.org/zap"
+)
+
+const (
+	// canaryRoleCandidate is the role of the PipelineRun of the pull request.
+	canaryRoleCandidate = "candidate"
+	// canaryRoleBaseline is the role of the PipelineRun of the target branch.
+	canaryRoleBaseline = "baseline"
+)
+
+// isCanary returns whether the PipelineRun has the canary annotation.
+func isCanary(pr *tektonv1.PipelineRun) bool {
+	return pr.GetAnnotations()[keys.Canary] == "true"
+}
+
+// tektonDirChanged returns whether one of the files is in the .tekton
+// directory.
+func tektonDirChanged(files []string) bool {
+	for _, file := range files {
+		if strings.HasPrefix(file, tektonDir+"/") {
+			return true
+		}
+	}
+	return false
+}
+
+// newCanaryBaseline returns the PipelineRun of the target branch to run along
+// the candidate, with its own name so both report their own status.
+func newCanaryBaseline(baseline *tektonv1.PipelineRun) *tektonv1.PipelineRun {
+	baseline = baseline.DeepCopy()
+	originalName := baseline.GetAnnotations()[keys.OriginalPRName]
+	if baseline.GetName() != "" {
+		baseline.SetName(fmt.Sprintf("%s-%s", baseline.GetName(), canaryRoleBaseline))
+	}
+	if baseline.GetGenerateName() != "" {
+		baseline.SetGenerateName(fmt.Sprintf("%s-%s-", strings.TrimSuffix(baseline.GetGenerateName(), "-"), canaryRoleBaseline))
+	}
+	baselineName := fmt.Sprintf("%s-%s", strings.TrimSuffix(originalName, "-"), canaryRoleBaseline)
+
+	if baseline.Annotations == nil {
+		baseline.Annotations = map[string]string{}
+	}
+	if baseline.Labels == nil {
+		baseline.Labels = map[string]string{}
+	}
+	delete(baseline.Annotations, keys.Canary)
+	baseline.Annotations[keys.OriginalPRName] = baselineName
+	baseline.Labels[keys.OriginalPRName] = formatting.CleanValueKubernetes(baselineName)
+	baseline.Labels[keys.CanaryRole] = canaryRoleBaseline
+	return baseline
+}
+
+// pairCanaries adds after every matched PipelineRun having the canary
+// annotation its PipelineRun of the target branch from baselines, and labels
+// both of them with their role. A PipelineRun new in the pull request has no
+// baseline and runs alone.
+func pairCanaries(matches []matcher.Match, baselines []*tektonv1.PipelineRun) []matcher.Match {
+	byName := map[string]*tektonv1.PipelineRun{}
+	for _, baseline := range baselines {
+		byName[baseline.GetAnnotations()[keys.OriginalPRName]] = baseline
+	}
+	ret := make([]matcher.Match, 0, len(matches))
+	for _, match := range matches {
+		ret = append(ret, match)
+		if !isCanary(match.PipelineRun) {
+			continue
+		}
+		baseline, ok := byName[match.PipelineRun.GetAnnotations()[keys.OriginalPRName]]
+		if !ok {
+			continue
+		}
+		if match.PipelineRun.Labels == nil {
+			match.PipelineRun.Labels = map[string]string{}
+		}
+		match.PipelineRun.Labels[keys.CanaryRole] = canaryRoleCandidate
+
+		baselineMatch := match
+		baselineMatch.PipelineRun = newCanaryBaseline(baseline)
+		ret = append(ret, baselineMatch)
+	}
+	return ret
+}
+
+// addCanaryBaselines runs the PipelineRun definitions of the target branch
+// along the matched PipelineRuns having the canary annotation, when the pull
+// request changes the .tekton directory, so a change of a pipeline can be
+// compared with the current one before merging it.
+func (p *PacRun) addCanaryBaselines(ctx context.Context, repo *v1alpha1.Repository, matches []matcher.Match) ([]matcher.Match, error) {
+	if p.event.TriggerTarget != triggertype.PullRequest || p.event.BaseBranch == "" {
+		return matches, nil
+	}
+	canaries := []*tektonv1.PipelineRun{}
+	for _, match := range matches {
+		if isCanary(match.PipelineRun) {
+			canaries = append(canaries, match.PipelineRun)
+		}
+	}
+	if len(canaries) == 0 {
+		return matches, nil
+	}
+	if repo.Spec.GitProvider != nil && repo.Spec.GitProvider.SSH != nil {
+		return matches, fmt.Errorf("the %s annotation is not supported when fetching the %s directory over ssh", keys.Canary, tektonDir)
+	}
+	files, err := p.vcx.GetFiles(ctx, p.event)
+	if err != nil {
+		return matches, fmt.Errorf("cannot get the changed files of the pull request: %w", err)
+	}
+	if !tektonDirChanged(files.All) {
+		return matches, nil
+	}
+
+	// the definitions are read from the target branch and the templates are
+	// applied for the pull request, so both run on the same code
+	baseEvent := info.NewEvent()
+	p.event.DeepCopyInto(baseEvent)
+	baseEvent.DefaultBranch = p.event.BaseBranch
+	rawTemplates, err := p.vcx.GetTektonDir(ctx, baseEvent, tektonDir, "default_branch")
+	if err != nil {
+		return matches, fmt.Errorf("cannot get the %s directory of the target branch %s: %w", tektonDir, p.event.BaseBranch, err)
+	}
+	if rawTemplates == "" {
+		return matches, nil
+	}
+	types, err := resolve.ReadTektonTypes(ctx, p.logger, p.makeTemplate(ctx, repo, rawTemplates))
+	if err != nil {
+		return matches, err
+	}
+	baselines, err := resolve.MetadataResolve(types.PipelineRuns)
+	if err != nil {
+		return matches, err
+	}
+	names := map[string]bool{}
+	for _, canary := range canaries {
+		names[canary.GetAnnotations()[keys.OriginalPRName]] = true
+	}
+	types.PipelineRuns = nil
+	for _, baseline := range baselines {
+		if names[baseline.GetAnnotations()[keys.OriginalPRName]] {
+			types.PipelineRuns = append(types.PipelineRuns, baseline)
+		}
+	}
+	if len(types.PipelineRuns) == 0 {
+		return matches, nil
+	}
+	baselines = types.PipelineRuns
+	if p.pacInfo.RemoteTasks {
+		if baselines, err = resolve.Resolve(ctx, p.run, p.logger, p.vcx, types, baseEvent, &resolve.Opts{
+			GenerateName: true,
+			RemoteTasks:  true,
+		}); err != nil {
+			return matches, fmt.Errorf("cannot resolve the pipelineruns of the target branch %s: %w", p.event.BaseBranch, err)
+		}
+	}
+	if err := p.changePipelineRun(ctx, repo, baselines); err != nil {
+		return matches, err
+	}
+
+	ret := pairCanaries(matches, baselines)
+	p.eventEmitter.EmitMessage(repo, zap.InfoLevel, "RepositoryCanaryBaseline",
+		fmt.Sprintf("running %d pipelinerun(s) of the target branch %s along the pull request ones", len(ret)-len(matches), p.event.BaseBranch))
+	return ret, nil
+}
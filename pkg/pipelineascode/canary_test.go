@@ -0,0 +1,187 @@
+package pipelineascode
+
+import (
+	"testing"
+
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/apis/pipelinesascode/keys"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/apis/pipelinesascode/v1alpha1"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/events"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/matcher"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/params"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/params/clients"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/params/info"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/params/triggertype"
+	testclient "github.com/openshift-pipelines/pipelines-as-code/pkg/test/clients"
+	kitesthelper "github.com/openshift-pipelines/pipelines-as-code/pkg/test/kubernetestint"
+	testprovider "github.com/openshift-pipelines/pipelines-as-code/pkg/test/provider"
+	tektonv1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+	"go.uber.org/zap"
+	zapobserver "go.uber.org/zap/zaptest/observer"
+	"gotest.tools/v3/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	rtesting "knative.dev/pkg/reconciler/testing"
+)
+
+func makeCanaryPR(generateName, originalName string, canary bool) *tektonv1.PipelineRun {
+	pr := &tektonv1.PipelineRun{ObjectMeta: metav1.ObjectMeta{
+		GenerateName: generateName,
+		Annotations:  map[string]string{keys.OriginalPRName: originalName},
+		Labels:       map[string]string{keys.OriginalPRName: originalName},
+	}}
+	if canary {
+		pr.Annotations[keys.Canary] = "true"
+	}
+	return pr
+}
+
+func TestPairCanaries(t *testing.T) {
+	tests := []struct {
+		name      string
+		matches   []matcher.Match
+		baselines []*tektonv1.PipelineRun
+		want      []string
+		wantRoles []string
+	}{
+		{
+			name:      "canary with a baseline",
+			matches:   []matcher.Match{{PipelineRun: makeCanaryPR("build-", "build", true)}},
+			baselines: []*tektonv1.PipelineRun{makeCanaryPR("build-", "build", true)},
+			want:      []string{"build", "build-baseline"},
+			wantRoles: []string{canaryRoleCandidate, canaryRoleBaseline},
+		},
+		{
+			name: "not a canary",
+			matches: []matcher.Match{
+				{PipelineRun: makeCanaryPR("lint-", "lint", false)},
+				{PipelineRun: makeCanaryPR("build-", "build", true)},
+			},
+			baselines: []*tektonv1.PipelineRun{makeCanaryPR("build-", "build", true)},
+			want:      []string{"lint", "build", "build-baseline"},
+			wantRoles: []string{"", canaryRoleCandidate, canaryRoleBaseline},
+		},
+		{
+			name:      "new pipelinerun without baseline",
+			matches:   []matcher.Match{{PipelineRun: makeCanaryPR("build-", "build", true)}},
+			baselines: []*tektonv1.PipelineRun{makeCanaryPR("other-", "other", true)},
+			want:      []string{"build"},
+			wantRoles: []string{""},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := pairCanaries(tt.matches, tt.baselines)
+			names := []string{}
+			roles := []string{}
+			for _, match := range got {
+				names = append(names, match.PipelineRun.GetAnnotations()[keys.OriginalPRName])
+				roles = append(roles, match.PipelineRun.GetLabels()[keys.CanaryRole])
+			}
+			assert.DeepEqual(t, names, tt.want)
+			assert.DeepEqual(t, roles, tt.wantRoles)
+		})
+	}
+}
+
+func TestNewCanaryBaseline(t *testing.T) {
+	baseline := newCanaryBaseline(makeCanaryPR("build-", "build", true))
+	assert.Equal(t, baseline.GetGenerateName(), "build-baseline-")
+	assert.Equal(t, baseline.GetAnnotations()[keys.OriginalPRName], "build-baseline")
+	assert.Equal(t, baseline.GetLabels()[keys.OriginalPRName], "build-baseline")
+	assert.Equal(t, baseline.GetLabels()[keys.CanaryRole], canaryRoleBaseline)
+	_, ok := baseline.GetAnnotations()[keys.Canary]
+	assert.Assert(t, !ok)
+
+	named := &tektonv1.PipelineRun{ObjectMeta: metav1.ObjectMeta{Name: "build", Annotations: map[string]string{keys.OriginalPRName: "build"}}}
+	assert.Equal(t, newCanaryBaseline(named).GetName(), "build-baseline")
+}
+
+func TestAddCanaryBaselines(t *testing.T) {
+	baseTemplates := `---
+apiVersion: tekton.dev/v1
+kind: PipelineRun
+metadata:
+  generateName: build-
+  annotations:
+    pipelinesascode.tekton.dev/canary: "true"
+spec:
+  pipelineSpec:
+    tasks:
+      - name: old
+        taskSpec:
+          steps:
+            - name: step
+              image: alpine
+              script: echo {{ revision }}
+`
+	pullRequest := &info.Event{
+		TriggerTarget: triggertype.PullRequest,
+		EventType:     "pull_request",
+		SHA:           "headsha",
+		BaseBranch:    "main",
+		HeadBranch:    "feature",
+	}
+	tests := []struct {
+		name         string
+		event        *info.Event
+		changedFiles []string
+		canary       bool
+		want         []string
+		wantBaseline bool
+	}{
+		{
+			name:         "tekton directory changed",
+			event:        pullRequest,
+			changedFiles: []string{".tekton/build.yaml"},
+			canary:       true,
+			want:         []string{"build-", "build-baseline-"},
+			wantBaseline: true,
+		},
+		{
+			name:         "tekton directory not changed",
+			event:        pullRequest,
+			changedFiles: []string{"main.go"},
+			canary:       true,
+			want:         []string{"build-"},
+		},
+		{
+			name:         "no canary annotation",
+			event:        pullRequest,
+			changedFiles: []string{".tekton/build.yaml"},
+			want:         []string{"build-"},
+		},
+		{
+			name:         "push",
+			event:        &info.Event{TriggerTarget: triggertype.Push, EventType: "push", SHA: "headsha", BaseBranch: "main"},
+			changedFiles: []string{".tekton/build.yaml"},
+			canary:       true,
+			want:         []string{"build-"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, _ := rtesting.SetupFakeContext(t)
+			observer, _ := zapobserver.New(zap.InfoLevel)
+			logger := zap.New(observer).Sugar()
+			stdata, _ := testclient.SeedTestData(t, ctx, testclient.Data{})
+			vcx := &testprovider.TestProviderImp{TektonDirTemplate: baseTemplates, WantAllChangedFiles: tt.changedFiles}
+			p := NewPacs(tt.event, vcx, &params.Run{Clients: clients.Clients{}}, &info.PacOpts{}, &kitesthelper.KinterfaceTest{}, logger, nil)
+			p.eventEmitter = events.NewEventEmitter(stdata.Kube, logger)
+			repo := &v1alpha1.Repository{ObjectMeta: metav1.ObjectMeta{Name: "repo", Namespace: "ns"}}
+
+			matches, err := p.addCanaryBaselines(ctx, repo, []matcher.Match{{PipelineRun: makeCanaryPR("build-", "build-", tt.canary), Repo: repo}})
+			assert.NilError(t, err)
+			names := []string{}
+			for _, match := range matches {
+				names = append(names, match.PipelineRun.GetGenerateName())
+			}
+			assert.DeepEqual(t, names, tt.want)
+			if tt.wantBaseline {
+				baseline := matches[1].PipelineRun
+				assert.Equal(t, baseline.Spec.PipelineSpec.Tasks[0].Name, "old")
+				assert.Equal(t, baseline.Spec.PipelineSpec.Tasks[0].TaskSpec.Steps[0].Script, "echo headsha")
+				assert.Equal(t, matches[0].PipelineRun.GetLabels()[keys.CanaryRole], canaryRoleCandidate)
+				assert.Assert(t, baseline.GetAnnotations()[keys.GitAuthSecret] != "")
+			}
+		})
+	}
+}
@@ -206,6 +206,33 @@ func (p *PacRun) cancelPipelineRunsOpsComment(ctx context.Context, repo *v1alpha
 	return nil
 }
 
+// cancelForcePushedPipelineRuns cancels the PipelineRuns of the pull request
+// head SHA overwritten by a force push, when enabled on a Gitea repository.
+func (p *PacRun) cancelForcePushedPipelineRuns(ctx context.Context, repo *v1alpha1.Repository) error {
+	if !p.event.ForcePush || p.event.BeforeSHA == "" || p.event.PullRequestNumber == 0 {
+		return nil
+	}
+	if repo.Spec.Settings == nil || repo.Spec.Settings.Gitea == nil || !repo.Spec.Settings.Gitea.CancelOnForcePush {
+		return nil
+	}
+
+	labelSelector := getLabelSelector(map[string]string{
+		keys.URLRepository: formatting.CleanValueKubernetes(p.event.Repository),
+		keys.PullRequest:   strconv.Itoa(p.event.PullRequestNumber),
+		keys.SHA:           formatting.CleanValueKubernetes(p.event.BeforeSHA),
+	}, selection.Equals)
+
+	prs, err := p.run.Clients.Tekton.TektonV1().PipelineRuns(repo.Namespace).List(ctx, metav1.ListOptions{
+		LabelSelector: labelSelector,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to list pipelineRuns : %w", err)
+	}
+
+	p.cancelPipelineRuns(ctx, prs, repo, func(tektonv1.PipelineRun) bool { return true })
+	return nil
+}
+
 func (p *PacRun) cancelPipelineRuns(ctx context.Context, prs *tektonv1.PipelineRunList, repo *v1alpha1.Repository, condition matchingCond) {
 	var wg sync.WaitGroup
 	for _, pr := range prs.Items {
@@ -1566,3 +1566,93 @@ func TestGetLabelSelector(t *testing.T) {
 		})
 	}
 }
+
+func TestCancelForcePushedPipelineRuns(t *testing.T) {
+	observer, _ := zapobserver.New(zap.InfoLevel)
+	logger := zap.New(observer).Sugar()
+	forcePushedLabels := map[string]string{
+		keys.URLRepository: formatting.CleanValueKubernetes("foo"),
+		keys.SHA:           formatting.CleanValueKubernetes("beforesha"),
+		keys.PullRequest:   strconv.Itoa(pullReqNumber),
+	}
+	cancelOnForcePush := &v1alpha1.Repository{
+		ObjectMeta: fooRepo.ObjectMeta,
+		Spec: v1alpha1.RepositorySpec{
+			URL: fooRepo.Spec.URL,
+			Settings: &v1alpha1.Settings{
+				Gitea: &v1alpha1.GiteaSettings{CancelOnForcePush: true},
+			},
+		},
+	}
+	forcePushEvent := &info.Event{
+		Repository:        "foo",
+		SHA:               "foosha",
+		PullRequestNumber: pullReqNumber,
+		ForcePush:         true,
+		BeforeSHA:         "beforesha",
+	}
+	pipelineRuns := []*pipelinev1.PipelineRun{
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "pr-before", Namespace: "foo", Labels: forcePushedLabels},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "pr-foo", Namespace: "foo", Labels: fooRepoLabels},
+		},
+	}
+	tests := []struct {
+		name                  string
+		event                 *info.Event
+		repo                  *v1alpha1.Repository
+		cancelledPipelineRuns map[string]bool
+	}{
+		{
+			name:                  "cancel pipelineruns of force pushed sha",
+			event:                 forcePushEvent,
+			repo:                  cancelOnForcePush,
+			cancelledPipelineRuns: map[string]bool{"pr-before": true},
+		},
+		{
+			name:  "setting disabled",
+			event: forcePushEvent,
+			repo:  fooRepo,
+		},
+		{
+			name: "not a force push",
+			event: &info.Event{
+				Repository:        "foo",
+				SHA:               "foosha",
+				PullRequestNumber: pullReqNumber,
+			},
+			repo: cancelOnForcePush,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, _ := rtesting.SetupFakeContext(t)
+			prs := make([]*pipelinev1.PipelineRun, 0, len(pipelineRuns))
+			for _, pr := range pipelineRuns {
+				prs = append(prs, pr.DeepCopy())
+			}
+			stdata, _ := testclient.SeedTestData(t, ctx, testclient.Data{PipelineRuns: prs})
+			cs := &params.Run{
+				Clients: clients.Clients{
+					Log:    logger,
+					Tekton: stdata.Pipeline,
+					Kube:   stdata.Kube,
+				},
+			}
+			pac := NewPacs(tt.event, nil, cs, &info.PacOpts{}, nil, logger, nil)
+			assert.NilError(t, pac.cancelForcePushedPipelineRuns(ctx, tt.repo))
+
+			got, err := cs.Clients.Tekton.TektonV1().PipelineRuns("foo").List(ctx, metav1.ListOptions{})
+			assert.NilError(t, err)
+			for _, pr := range got.Items {
+				if tt.cancelledPipelineRuns[pr.Name] {
+					assert.Equal(t, string(pr.Spec.Status), pipelinev1.PipelineRunSpecStatusCancelledRunFinally)
+					continue
+				}
+				assert.Assert(t, string(pr.Spec.Status) != pipelinev1.PipelineRunSpecStatusCancelledRunFinally)
+			}
+		})
+	}
+}
@@ -0,0 +1,37 @@
+package pipelineascode
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/apis/pipelinesascode/v1alpha1"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/kubeinteraction"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/params/info"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/secrets"
+)
+
+// syncCIVariables copies the CI variables of the Git provider mapped by the
+// ci_variables setting of repo into a secret of namespace, so the
+// PipelineRuns can use them without duplicating them in the cluster.
+func (p *PacRun) syncCIVariables(ctx context.Context, k8int kubeinteraction.Interface, repo *v1alpha1.Repository, event *info.Event, namespace string) error {
+	if repo.Spec.Settings == nil || repo.Spec.Settings.CIVariables == nil {
+		return nil
+	}
+	ciVariables := repo.Spec.Settings.CIVariables
+	if ciVariables.SecretName == "" || len(ciVariables.Variables) == 0 {
+		return fmt.Errorf("the ci_variables setting of repository %s needs a secret_name and variables", repo.GetName())
+	}
+	names := make([]string, 0, len(ciVariables.Variables))
+	for _, variable := range ciVariables.Variables {
+		names = append(names, variable.Name)
+	}
+	values, err := p.vcx.GetCIVariables(ctx, event, names)
+	if err != nil {
+		return fmt.Errorf("cannot get the CI variables of repository %s: %w", repo.GetName(), err)
+	}
+	secret := secrets.MakeCIVariablesSecret(event, ciVariables, values)
+	if err := k8int.CreateOrUpdateSecret(ctx, namespace, secret); err != nil {
+		return fmt.Errorf("cannot create the CI variables secret %s in namespace %s: %w", secret.GetName(), namespace, err)
+	}
+	return nil
+}
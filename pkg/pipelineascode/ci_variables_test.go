@@ -0,0 +1,83 @@
+package pipelineascode
+
+import (
+	"testing"
+
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/apis/pipelinesascode/v1alpha1"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/kubeinteraction"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/params"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/params/clients"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/params/info"
+	testclient "github.com/openshift-pipelines/pipelines-as-code/pkg/test/clients"
+	testprovider "github.com/openshift-pipelines/pipelines-as-code/pkg/test/provider"
+	"gotest.tools/v3/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	rtesting "knative.dev/pkg/reconciler/testing"
+)
+
+func TestSyncCIVariables(t *testing.T) {
+	providerVariables := map[string]string{"REGISTRY": "quay.io", "DEPLOY_TOKEN": "s3cr3t"}
+	tests := []struct {
+		name        string
+		ciVariables *v1alpha1.CIVariables
+		want        map[string]string
+		wantErr     string
+	}{
+		{
+			name: "no setting",
+		},
+		{
+			name: "mapped variables",
+			ciVariables: &v1alpha1.CIVariables{
+				SecretName: "ci-variables",
+				Variables: []v1alpha1.CIVariable{
+					{Name: "REGISTRY"},
+					{Name: "DEPLOY_TOKEN", Key: "token"},
+				},
+			},
+			want: map[string]string{"REGISTRY": "quay.io", "token": "s3cr3t"},
+		},
+		{
+			name: "missing variable",
+			ciVariables: &v1alpha1.CIVariables{
+				SecretName: "ci-variables",
+				Variables:  []v1alpha1.CIVariable{{Name: "MISSING"}},
+			},
+			wantErr: "cannot get the CI variables of repository repo: CI variable MISSING does not exist",
+		},
+		{
+			name:        "no secret name",
+			ciVariables: &v1alpha1.CIVariables{Variables: []v1alpha1.CIVariable{{Name: "REGISTRY"}}},
+			wantErr:     "the ci_variables setting of repository repo needs a secret_name and variables",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, _ := rtesting.SetupFakeContext(t)
+			stdata, _ := testclient.SeedTestData(t, ctx, testclient.Data{})
+			k8int := &kubeinteraction.Interaction{Run: &params.Run{Clients: clients.Clients{Kube: stdata.Kube}}}
+			repo := &v1alpha1.Repository{
+				ObjectMeta: metav1.ObjectMeta{Name: "repo", Namespace: "ns"},
+				Spec:       v1alpha1.RepositorySpec{Settings: &v1alpha1.Settings{CIVariables: tt.ciVariables}},
+			}
+			p := &PacRun{vcx: &testprovider.TestProviderImp{CIVariables: providerVariables}}
+			event := &info.Event{Organization: "owner", Repository: "repo", SHA: "123"}
+
+			err := p.syncCIVariables(ctx, k8int, repo, event, "run-ns")
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			assert.NilError(t, err)
+			secrets, err := stdata.Kube.CoreV1().Secrets("run-ns").List(ctx, metav1.ListOptions{})
+			assert.NilError(t, err)
+			if tt.want == nil {
+				assert.Equal(t, len(secrets.Items), 0)
+				return
+			}
+			assert.Equal(t, len(secrets.Items), 1)
+			assert.Equal(t, secrets.Items[0].GetName(), tt.ciVariables.SecretName)
+			assert.DeepEqual(t, secrets.Items[0].StringData, tt.want)
+		})
+	}
+}
@@ -0,0 +1,73 @@
+package pipelineascode
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/apis/pipelinesascode/v1alpha1"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/opscomments"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/params/info"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/params/triggertype"
+	"go.uber.org/zap"
+)
+
+// maxPushDebounce is the longest debounce window of the push events of a
+// repository.
+const maxPushDebounce = 5 * time.Minute
+
+// pushDebounce returns the debounce window of the push events of repo, zero
+// when there is none.
+func pushDebounce(repo *v1alpha1.Repository) (time.Duration, error) {
+	if repo.Spec.Settings == nil || repo.Spec.Settings.PushDebounce == "" {
+		return 0, nil
+	}
+	window, err := time.ParseDuration(repo.Spec.Settings.PushDebounce)
+	if err != nil || window < 0 || window > maxPushDebounce {
+		return 0, fmt.Errorf("invalid push_debounce %q of repository %s, it needs to be a duration up to %s", repo.Spec.Settings.PushDebounce, repo.GetName(), maxPushDebounce)
+	}
+	return window, nil
+}
+
+// isSupersededPush waits for the debounce window of repo on a push event and
+// tells whether the branch has been pushed again meanwhile. The event of the
+// latest push runs the PipelineRuns, so successive pushes collapse into a
+// single run for the latest SHA.
+func (p *PacRun) isSupersededPush(ctx context.Context, repo *v1alpha1.Repository) bool {
+	if p.event.TriggerTarget != triggertype.Push || p.event.EventType == triggertype.Incoming.String() ||
+		opscomments.IsAnyOpsEventType(p.event.EventType) || strings.HasPrefix(p.event.HeadBranch, "refs/tags/") {
+		return false
+	}
+	window, err := pushDebounce(repo)
+	if err != nil {
+		p.eventEmitter.EmitMessage(repo, zap.WarnLevel, "RepositoryInvalidPushDebounce", err.Error())
+		return false
+	}
+	if window == 0 {
+		return false
+	}
+
+	timer := time.NewTimer(window)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return false
+	case <-timer.C:
+	}
+
+	latest := info.NewEvent()
+	p.event.DeepCopyInto(latest)
+	latest.SHA = ""
+	latest.HeadBranch = strings.TrimPrefix(p.event.HeadBranch, "refs/heads/")
+	if err := p.vcx.GetCommitInfo(ctx, latest); err != nil {
+		p.logger.Warnf("cannot get the latest commit of branch %s after the push debounce window, running for %s: %v", latest.HeadBranch, p.event.SHA, err)
+		return false
+	}
+	if latest.SHA == "" || latest.SHA == p.event.SHA {
+		return false
+	}
+	p.eventEmitter.EmitMessage(repo, zap.InfoLevel, "RepositoryPushDebounced",
+		fmt.Sprintf("skipping push of %s on branch %s, superseded by %s within %s", p.event.SHA, latest.HeadBranch, latest.SHA, window))
+	return true
+}
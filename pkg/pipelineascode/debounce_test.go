@@ -0,0 +1,106 @@
+package pipelineascode
+
+import (
+	"testing"
+
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/apis/pipelinesascode/v1alpha1"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/events"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/params/info"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/params/triggertype"
+	testclient "github.com/openshift-pipelines/pipelines-as-code/pkg/test/clients"
+	testprovider "github.com/openshift-pipelines/pipelines-as-code/pkg/test/provider"
+	"go.uber.org/zap"
+	zapobserver "go.uber.org/zap/zaptest/observer"
+	"gotest.tools/v3/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	rtesting "knative.dev/pkg/reconciler/testing"
+)
+
+func TestIsSupersededPush(t *testing.T) {
+	pushEvent := &info.Event{
+		TriggerTarget: triggertype.Push,
+		EventType:     "push",
+		HeadBranch:    "refs/heads/main",
+		SHA:           "firstsha",
+	}
+	tests := []struct {
+		name          string
+		event         *info.Event
+		pushDebounce  string
+		branchHeadSHA string
+		want          bool
+		wantLog       string
+	}{
+		{
+			name:          "superseded by a newer push",
+			event:         pushEvent,
+			pushDebounce:  "1ms",
+			branchHeadSHA: "secondsha",
+			want:          true,
+			wantLog:       "skipping push of firstsha on branch main, superseded by secondsha within 1ms",
+		},
+		{
+			name:          "latest push",
+			event:         pushEvent,
+			pushDebounce:  "1ms",
+			branchHeadSHA: "firstsha",
+		},
+		{
+			name:          "no debounce",
+			event:         pushEvent,
+			branchHeadSHA: "secondsha",
+		},
+		{
+			name:          "invalid debounce",
+			event:         pushEvent,
+			pushDebounce:  "1h",
+			branchHeadSHA: "secondsha",
+			wantLog:       `invalid push_debounce "1h" of repository repo, it needs to be a duration up to 5m0s`,
+		},
+		{
+			name: "pull request",
+			event: &info.Event{
+				TriggerTarget: triggertype.PullRequest,
+				EventType:     "pull_request",
+				HeadBranch:    "feature",
+				SHA:           "firstsha",
+			},
+			pushDebounce:  "1ms",
+			branchHeadSHA: "secondsha",
+		},
+		{
+			name: "tag",
+			event: &info.Event{
+				TriggerTarget: triggertype.Push,
+				EventType:     "push",
+				HeadBranch:    "refs/tags/v1.0",
+				SHA:           "firstsha",
+			},
+			pushDebounce:  "1ms",
+			branchHeadSHA: "secondsha",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, _ := rtesting.SetupFakeContext(t)
+			observer, logs := zapobserver.New(zap.InfoLevel)
+			logger := zap.New(observer).Sugar()
+			stdata, _ := testclient.SeedTestData(t, ctx, testclient.Data{})
+			repo := &v1alpha1.Repository{
+				ObjectMeta: metav1.ObjectMeta{Name: "repo", Namespace: "ns"},
+				Spec:       v1alpha1.RepositorySpec{Settings: &v1alpha1.Settings{PushDebounce: tt.pushDebounce}},
+			}
+			p := &PacRun{
+				event:        tt.event,
+				vcx:          &testprovider.TestProviderImp{BranchHeadSHA: tt.branchHeadSHA},
+				logger:       logger,
+				eventEmitter: events.NewEventEmitter(stdata.Kube, logger),
+			}
+			assert.Equal(t, p.isSupersededPush(ctx, repo), tt.want)
+			if tt.wantLog != "" {
+				assert.Equal(t, logs.FilterMessage(tt.wantLog).Len(), 1, logs.All())
+			}
+			assert.Equal(t, tt.event.SHA, "firstsha")
+		})
+	}
+}
@@ -0,0 +1,49 @@
+package pipelineascode
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/provider"
+	"go.uber.org/zap"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// getDefaultPipelines returns the PipelineRuns of the default pipelines
+// ConfigMap of the global Repository, used for the repositories without a
+// .tekton directory.
+func (p *PacRun) getDefaultPipelines(ctx context.Context) (string, error) {
+	if p.globalRepo == nil || p.globalRepo.Spec.Settings == nil || p.globalRepo.Spec.Settings.DefaultPipelinesConfigMap == "" {
+		return "", nil
+	}
+	name := p.globalRepo.Spec.Settings.DefaultPipelinesConfigMap
+	cm, err := p.run.Clients.Kube.CoreV1().ConfigMaps(p.globalRepo.GetNamespace()).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return "", fmt.Errorf("cannot get the default pipelines configmap %s/%s: %w", p.globalRepo.GetNamespace(), name, err)
+	}
+
+	files := make([]string, 0, len(cm.Data))
+	for key := range cm.Data {
+		if strings.HasSuffix(key, ".yaml") || strings.HasSuffix(key, ".yml") {
+			files = append(files, key)
+		}
+	}
+	if len(files) == 0 {
+		return "", nil
+	}
+	sort.Strings(files)
+	var allTemplates string
+	for _, file := range files {
+		data := cm.Data[file]
+		if err := provider.ValidateYaml([]byte(data), file); err != nil {
+			return "", err
+		}
+		allTemplates = provider.AppendYamlFile(allTemplates, file, data)
+	}
+
+	p.eventEmitter.EmitMessage(nil, zap.InfoLevel, "RepositoryDefaultPipelines",
+		fmt.Sprintf("no %s directory in this repository, using the default pipelines from configmap %s/%s", tektonDir, p.globalRepo.GetNamespace(), name))
+	return allTemplates, nil
+}
@@ -0,0 +1,106 @@
+package pipelineascode
+
+import (
+	"testing"
+
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/apis/pipelinesascode/v1alpha1"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/events"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/params"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/params/clients"
+	testclient "github.com/openshift-pipelines/pipelines-as-code/pkg/test/clients"
+	"go.uber.org/zap"
+	zapobserver "go.uber.org/zap/zaptest/observer"
+	"gotest.tools/v3/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	rtesting "knative.dev/pkg/reconciler/testing"
+)
+
+func TestGetDefaultPipelines(t *testing.T) {
+	globalNS := "pac"
+	makeGlobalRepo := func(configMap string) *v1alpha1.Repository {
+		return &v1alpha1.Repository{
+			ObjectMeta: metav1.ObjectMeta{Name: "global", Namespace: globalNS},
+			Spec: v1alpha1.RepositorySpec{
+				Settings: &v1alpha1.Settings{DefaultPipelinesConfigMap: configMap},
+			},
+		}
+	}
+	configMap := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: "default-pipelines", Namespace: globalNS},
+		Data: map[string]string{
+			"push.yaml":  "kind: PipelineRun\nmetadata:\n  name: push\n",
+			"pr.yaml":    "kind: PipelineRun\nmetadata:\n  name: pr\n",
+			"README.md":  "not a template",
+			"other.yml":  "---\nkind: PipelineRun\nmetadata:\n  name: other\n",
+			"broken.txt": "{{",
+		},
+	}
+	badConfigMap := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: "bad-pipelines", Namespace: globalNS},
+		Data:       map[string]string{"bad.yaml": "kind: PipelineRun\n  name: : bad\n"},
+	}
+	emptyConfigMap := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: "empty-pipelines", Namespace: globalNS},
+		Data:       map[string]string{"README.md": "nothing here"},
+	}
+
+	tests := []struct {
+		name       string
+		globalRepo *v1alpha1.Repository
+		want       string
+		wantErr    string
+	}{
+		{
+			name: "no global repository",
+		},
+		{
+			name:       "no default pipelines configured",
+			globalRepo: makeGlobalRepo(""),
+		},
+		{
+			name:       "default pipelines",
+			globalRepo: makeGlobalRepo("default-pipelines"),
+			want: "\n# pipelinesascode.tekton.dev/source: other.yml:1\nkind: PipelineRun\nmetadata:\n  name: other\n" +
+				"---\n# pipelinesascode.tekton.dev/source: pr.yaml:0\nkind: PipelineRun\nmetadata:\n  name: pr\n" +
+				"---\n# pipelinesascode.tekton.dev/source: push.yaml:0\nkind: PipelineRun\nmetadata:\n  name: push\n",
+		},
+		{
+			name:       "no templates in configmap",
+			globalRepo: makeGlobalRepo("empty-pipelines"),
+		},
+		{
+			name:       "missing configmap",
+			globalRepo: makeGlobalRepo("missing"),
+			wantErr:    "cannot get the default pipelines configmap pac/missing",
+		},
+		{
+			name:       "invalid yaml",
+			globalRepo: makeGlobalRepo("bad-pipelines"),
+			wantErr:    "error unmarshalling yaml file bad.yaml",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			observer, _ := zapobserver.New(zap.InfoLevel)
+			logger := zap.New(observer).Sugar()
+			ctx, _ := rtesting.SetupFakeContext(t)
+			stdata, _ := testclient.SeedTestData(t, ctx, testclient.Data{
+				ConfigMap: []*corev1.ConfigMap{configMap, badConfigMap, emptyConfigMap},
+			})
+			p := &PacRun{
+				run:          &params.Run{Clients: clients.Clients{Kube: stdata.Kube}},
+				logger:       logger,
+				eventEmitter: events.NewEventEmitter(stdata.Kube, logger),
+				globalRepo:   tt.globalRepo,
+			}
+			got, err := p.getDefaultPipelines(ctx)
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			assert.NilError(t, err)
+			assert.Equal(t, got, tt.want)
+		})
+	}
+}
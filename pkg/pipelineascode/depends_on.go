@@ -0,0 +1,77 @@
+package pipelineascode
+
+import (
+	"fmt"
+
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/apis/pipelinesascode/keys"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/matcher"
+	tektonv1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+)
+
+// dependencyName returns the name other PipelineRuns use to depend on pr, the
+// cells of a matrix are depended on by the name of the matrix.
+func dependencyName(pr *tektonv1.PipelineRun) string {
+	if parent, ok := pr.GetAnnotations()[keys.MatrixParent]; ok {
+		return parent
+	}
+	return pr.GetAnnotations()[keys.OriginalPRName]
+}
+
+// checkDependencies makes sure the PipelineRuns the matched PipelineRuns
+// depend on have been matched by the same event and are not depending on each
+// other in a loop, a PipelineRun waiting for them would never start otherwise.
+func checkDependencies(matches []matcher.Match) error {
+	graph := map[string][]string{}
+	for _, match := range matches {
+		name := dependencyName(match.PipelineRun)
+		if _, ok := graph[name]; !ok {
+			graph[name] = []string{}
+		}
+	}
+	for _, match := range matches {
+		annotation, ok := match.PipelineRun.GetAnnotations()[keys.DependsOn]
+		if !ok {
+			continue
+		}
+		name := dependencyName(match.PipelineRun)
+		deps, err := matcher.GetAnnotationValues(annotation)
+		if err != nil {
+			return fmt.Errorf("pipelinerun %s: %w", name, err)
+		}
+		for _, dep := range deps {
+			if _, ok := graph[dep]; !ok {
+				return fmt.Errorf("pipelinerun %s depends on %s which has not been matched for this event", name, dep)
+			}
+		}
+		graph[name] = append(graph[name], deps...)
+	}
+
+	const (
+		visiting = 1
+		visited  = 2
+	)
+	states := map[string]int{}
+	var visit func(name string) error
+	visit = func(name string) error {
+		switch states[name] {
+		case visiting:
+			return fmt.Errorf("pipelinerun %s has a circular dependency", name)
+		case visited:
+			return nil
+		}
+		states[name] = visiting
+		for _, dep := range graph[name] {
+			if err := visit(dep); err != nil {
+				return err
+			}
+		}
+		states[name] = visited
+		return nil
+	}
+	for _, match := range matches {
+		if err := visit(dependencyName(match.PipelineRun)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
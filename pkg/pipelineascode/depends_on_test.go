@@ -0,0 +1,84 @@
+package pipelineascode
+
+import (
+	"testing"
+
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/apis/pipelinesascode/keys"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/matcher"
+	tektonv1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+	"gotest.tools/v3/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestCheckDependencies(t *testing.T) {
+	makeMatch := func(name, dependsOn string, extra map[string]string) matcher.Match {
+		annotations := map[string]string{keys.OriginalPRName: name}
+		if dependsOn != "" {
+			annotations[keys.DependsOn] = dependsOn
+		}
+		for k, v := range extra {
+			annotations[k] = v
+		}
+		return matcher.Match{PipelineRun: &tektonv1.PipelineRun{
+			ObjectMeta: metav1.ObjectMeta{GenerateName: name + "-", Annotations: annotations},
+		}}
+	}
+
+	tests := []struct {
+		name    string
+		matches []matcher.Match
+		wantErr string
+	}{
+		{
+			name:    "no dependencies",
+			matches: []matcher.Match{makeMatch("lint", "", nil), makeMatch("deploy", "", nil)},
+		},
+		{
+			name:    "depends on a matched pipelinerun",
+			matches: []matcher.Match{makeMatch("lint", "", nil), makeMatch("deploy", "lint", nil)},
+		},
+		{
+			name: "depends on a matrix",
+			matches: []matcher.Match{
+				makeMatch("build-linux", "", map[string]string{keys.MatrixParent: "build"}),
+				makeMatch("build-darwin", "", map[string]string{keys.MatrixParent: "build"}),
+				makeMatch("deploy", "[build, lint]", nil),
+				makeMatch("lint", "", nil),
+			},
+		},
+		{
+			name:    "depends on an unmatched pipelinerun",
+			matches: []matcher.Match{makeMatch("deploy", "lint", nil)},
+			wantErr: "pipelinerun deploy depends on lint which has not been matched for this event",
+		},
+		{
+			name:    "depends on itself",
+			matches: []matcher.Match{makeMatch("deploy", "deploy", nil)},
+			wantErr: "pipelinerun deploy has a circular dependency",
+		},
+		{
+			name: "circular dependency",
+			matches: []matcher.Match{
+				makeMatch("lint", "test", nil),
+				makeMatch("test", "deploy", nil),
+				makeMatch("deploy", "lint", nil),
+			},
+			wantErr: "pipelinerun lint has a circular dependency",
+		},
+		{
+			name:    "bad annotation",
+			matches: []matcher.Match{makeMatch("lint", "", nil), makeMatch("deploy", "[lint", nil)},
+			wantErr: "pipelinerun deploy: annotations in pipeline are in wrong format: [lint",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := checkDependencies(tt.matches)
+			if tt.wantErr != "" {
+				assert.Error(t, err, tt.wantErr)
+				return
+			}
+			assert.NilError(t, err)
+		})
+	}
+}
@@ -0,0 +1,56 @@
+package pipelineascode
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/apis/pipelinesascode/v1alpha1"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/matcher"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/provider"
+	"go.uber.org/zap"
+)
+
+// isDryRun returns whether the repository has the dry_run setting, its events
+// are processed without creating any PipelineRun.
+func isDryRun(repo *v1alpha1.Repository) bool {
+	return repo != nil && repo.Spec.Settings != nil && repo.Spec.Settings.DryRun
+}
+
+// reportDryRun creates a neutral status for every matched PipelineRun saying
+// where it would have run, instead of creating it.
+func (p *PacRun) reportDryRun(ctx context.Context, repo *v1alpha1.Repository, matchedPRs []matcher.Match) {
+	names := []string{}
+	for i, match := range matchedPRs {
+		if match.Repo == nil {
+			match.Repo = repo
+		}
+		name := strings.TrimSuffix(match.PipelineRun.GetGenerateName(), "-")
+		if name == "" {
+			name = match.PipelineRun.GetName()
+		}
+		names = append(names, name)
+
+		namespace, err := pipelineRunNamespace(match)
+		text := fmt.Sprintf("Dry run: PipelineRun %s would run in the namespace %s.", name, namespace)
+		if err != nil {
+			text = fmt.Sprintf("Dry run: PipelineRun %s matched but would fail to start: %s.", name, err.Error())
+		}
+		status := provider.StatusOpts{
+			Status:                   CompletedStatus,
+			Title:                    "Dry run",
+			Text:                     text,
+			Conclusion:               neutralConclusion,
+			DetailsURL:               p.eventOf(match).URL,
+			PipelineRun:              match.PipelineRun,
+			OriginalPipelineRunName:  name,
+			InstanceCountForCheckRun: i,
+		}
+		if err := p.vcx.CreateStatus(ctx, p.eventOf(match), status); err != nil {
+			p.eventEmitter.EmitFailure(repo, zap.WarnLevel, v1alpha1.FailureReasonProviderAPIError, "RepositoryCreateStatus",
+				fmt.Sprintf("cannot report dry run of pipelinerun %s: %s", name, err.Error()))
+		}
+	}
+	p.eventEmitter.EmitMessage(repo, zap.InfoLevel, "RepositoryDryRun",
+		fmt.Sprintf("dry run of the repository, not creating the matched pipelineruns: %s", strings.Join(names, ", ")))
+}
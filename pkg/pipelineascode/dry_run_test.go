@@ -0,0 +1,76 @@
+package pipelineascode
+
+import (
+	"context"
+	"testing"
+
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/apis/pipelinesascode/keys"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/apis/pipelinesascode/v1alpha1"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/events"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/matcher"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/params/info"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/provider"
+	testclient "github.com/openshift-pipelines/pipelines-as-code/pkg/test/clients"
+	testprovider "github.com/openshift-pipelines/pipelines-as-code/pkg/test/provider"
+	tektonv1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+	"go.uber.org/zap"
+	zapobserver "go.uber.org/zap/zaptest/observer"
+	"gotest.tools/v3/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	rtesting "knative.dev/pkg/reconciler/testing"
+)
+
+// statusRecorder records the statuses created on the provider.
+type statusRecorder struct {
+	testprovider.TestProviderImp
+	statuses []provider.StatusOpts
+}
+
+func (s *statusRecorder) CreateStatus(_ context.Context, _ *info.Event, status provider.StatusOpts) error {
+	s.statuses = append(s.statuses, status)
+	return nil
+}
+
+func TestReportDryRun(t *testing.T) {
+	ctx, _ := rtesting.SetupFakeContext(t)
+	observer, logs := zapobserver.New(zap.InfoLevel)
+	logger := zap.New(observer).Sugar()
+	stdata, _ := testclient.SeedTestData(t, ctx, testclient.Data{})
+	repo := &v1alpha1.Repository{
+		ObjectMeta: metav1.ObjectMeta{Name: "repo", Namespace: "ns"},
+		Spec:       v1alpha1.RepositorySpec{Settings: &v1alpha1.Settings{DryRun: true}},
+	}
+	matches := []matcher.Match{
+		{PipelineRun: &tektonv1.PipelineRun{ObjectMeta: metav1.ObjectMeta{GenerateName: "pull-request-"}}},
+		{PipelineRun: &tektonv1.PipelineRun{ObjectMeta: metav1.ObjectMeta{
+			Name:        "other-namespace",
+			Annotations: map[string]string{keys.PipelineRunNamespace: "other"},
+		}}},
+	}
+	vcx := &statusRecorder{}
+	p := &PacRun{
+		event:        &info.Event{URL: "https://forge/owner/repo"},
+		vcx:          vcx,
+		logger:       logger,
+		eventEmitter: events.NewEventEmitter(stdata.Kube, logger),
+	}
+
+	assert.Assert(t, isDryRun(repo))
+	p.reportDryRun(ctx, repo, matches)
+
+	assert.Equal(t, len(vcx.statuses), 2)
+	assert.Equal(t, vcx.statuses[0].Conclusion, neutralConclusion)
+	assert.Equal(t, vcx.statuses[0].OriginalPipelineRunName, "pull-request")
+	assert.Equal(t, vcx.statuses[0].Text, "Dry run: PipelineRun pull-request would run in the namespace ns.")
+	assert.Equal(t, vcx.statuses[1].Text,
+		"Dry run: PipelineRun other-namespace matched but would fail to start: namespace other of the "+keys.PipelineRunNamespace+
+			" annotation is not in the allowed_pipelinerun_namespaces settings of the repository ns/repo.")
+	assert.Equal(t, logs.FilterMessage("dry run of the repository, not creating the matched pipelineruns: pull-request, other-namespace").Len(), 1, logs.All())
+}
+
+func TestIsDryRun(t *testing.T) {
+	assert.Assert(t, !isDryRun(nil))
+	assert.Assert(t, !isDryRun(&v1alpha1.Repository{}))
+	assert.Assert(t, !isDryRun(&v1alpha1.Repository{Spec: v1alpha1.RepositorySpec{Settings: &v1alpha1.Settings{}}}))
+	assert.Assert(t, isDryRun(&v1alpha1.Repository{Spec: v1alpha1.RepositorySpec{Settings: &v1alpha1.Settings{DryRun: true}}}))
+}
@@ -0,0 +1,109 @@
+package pipelineascode
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/apis/pipelinesascode/keys"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/apis/pipelinesascode/v1alpha1"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/consoleui"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/matcher"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/params"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/params/clients"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/params/info"
+	testclient "github.com/openshift-pipelines/pipelines-as-code/pkg/test/clients"
+	kitesthelper "github.com/openshift-pipelines/pipelines-as-code/pkg/test/kubernetestint"
+	testprovider "github.com/openshift-pipelines/pipelines-as-code/pkg/test/provider"
+	pipelinev1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+	"go.uber.org/zap"
+	zapobserver "go.uber.org/zap/zaptest/observer"
+	"gotest.tools/v3/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	rtesting "knative.dev/pkg/reconciler/testing"
+)
+
+func TestStartPREphemeralNamespace(t *testing.T) {
+	tests := []struct {
+		name               string
+		ephemeralNamespace *v1alpha1.EphemeralNamespace
+		wantEphemeral      bool
+		wantErr            string
+	}{
+		{
+			name: "repository namespace",
+		},
+		{
+			name:               "ephemeral namespace",
+			ephemeralNamespace: &v1alpha1.EphemeralNamespace{Secrets: []string{"registry"}},
+			wantEphemeral:      true,
+		},
+		{
+			name:               "missing secret",
+			ephemeralNamespace: &v1alpha1.EphemeralNamespace{Secrets: []string{"missing"}},
+			wantErr:            "cannot get secret missing to copy to the ephemeral namespace",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			observer, _ := zapobserver.New(zap.InfoLevel)
+			logger := zap.New(observer).Sugar()
+			ctx, _ := rtesting.SetupFakeContext(t)
+			stdata, _ := testclient.SeedTestData(t, ctx, testclient.Data{
+				Secret: []*corev1.Secret{{
+					ObjectMeta: metav1.ObjectMeta{Name: "registry", Namespace: "ns"},
+					Data:       map[string][]byte{"token": []byte("token")},
+				}},
+			})
+
+			run := params.New()
+			run.Clients = clients.Clients{
+				Kube:   stdata.Kube,
+				Tekton: stdata.Pipeline,
+				Log:    logger,
+			}
+			run.Clients.SetConsoleUI(consoleui.FallBackConsole{})
+			p := &PacRun{
+				run:     run,
+				event:   info.NewEvent(),
+				vcx:     &testprovider.TestProviderImp{},
+				pacInfo: &info.PacOpts{},
+				k8int:   &kitesthelper.KinterfaceTest{},
+				logger:  logger,
+			}
+			match := matcher.Match{
+				PipelineRun: &pipelinev1.PipelineRun{
+					ObjectMeta: metav1.ObjectMeta{Name: "pr", Namespace: "ns", Labels: map[string]string{}, Annotations: map[string]string{keys.OriginalPRName: "pr"}},
+				},
+				Repo: &v1alpha1.Repository{
+					ObjectMeta: metav1.ObjectMeta{Name: "repo", Namespace: "ns"},
+					Spec: v1alpha1.RepositorySpec{
+						URL:      "https://github.com/owner/repo",
+						Settings: &v1alpha1.Settings{EphemeralNamespace: tt.ephemeralNamespace},
+					},
+				},
+			}
+
+			pr, err := p.startPR(ctx, match)
+			namespaces, listErr := stdata.Kube.CoreV1().Namespaces().List(ctx, metav1.ListOptions{})
+			assert.NilError(t, listErr)
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, err, tt.wantErr)
+				assert.Equal(t, len(namespaces.Items), 0)
+				return
+			}
+			assert.NilError(t, err)
+			if !tt.wantEphemeral {
+				assert.Equal(t, pr.GetNamespace(), "ns")
+				assert.Equal(t, len(namespaces.Items), 0)
+				return
+			}
+			assert.Equal(t, len(namespaces.Items), 1)
+			assert.Equal(t, pr.GetNamespace(), namespaces.Items[0].GetName())
+			assert.Assert(t, strings.HasPrefix(pr.GetNamespace(), "ns-"))
+			assert.Equal(t, pr.GetAnnotations()[keys.RepositoryNamespace], "ns")
+			_, err = stdata.Kube.CoreV1().Secrets(pr.GetNamespace()).Get(ctx, "registry", metav1.GetOptions{})
+			assert.NilError(t, err)
+		})
+	}
+}
@@ -3,14 +3,18 @@ package pipelineascode
 import (
 	"context"
 	"fmt"
+	"path"
 	"regexp"
 	"strings"
 
 	"github.com/openshift-pipelines/pipelines-as-code/pkg/apis/pipelinesascode/v1alpha1"
 	pacerrors "github.com/openshift-pipelines/pipelines-as-code/pkg/errors"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/opscomments"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/params/triggertype"
 	"github.com/openshift-pipelines/pipelines-as-code/pkg/provider"
 	tektonv1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
 	"go.uber.org/zap"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 )
 
 const (
@@ -19,8 +23,45 @@ const (
 
 var regexpIgnoreErrors = regexp.MustCompile(`.*no kind.*is registered for version.*in scheme.*`)
 
+// isQuotaExceeded returns whether the creation of a PipelineRun has been
+// rejected by a resource quota of its namespace.
+func isQuotaExceeded(err error) bool {
+	return apierrors.IsForbidden(err) && strings.Contains(err.Error(), "exceeded quota")
+}
+
+// isAllowed checks if the sender of the event is allowed to run CI, according
+// to the approval policy of the repository for the pull requests. With the
+// always policy only the GitOps comments of the allowed users, like
+// /ok-to-test, can run CI on a pull request, as with the require_approval
+// tekton_changes_policy when a non owner changes the .tekton directory.
+// approval explains why an /ok-to-test is needed when it is.
+func (p *PacRun) isAllowed(ctx context.Context, repo *v1alpha1.Repository) (allowed bool, approval string, err error) {
+	if p.event.TriggerTarget == triggertype.PullRequest && !opscomments.IsAnyOpsEventType(p.event.EventType) &&
+		repo.Spec.GetTektonChangesPolicy() == v1alpha1.TektonChangesPolicyRequireApproval {
+		guarded, err := p.isGuardedTektonChange(ctx, repo)
+		if err != nil {
+			return false, "", err
+		}
+		if guarded {
+			return false, fmt.Sprintf("The changes of the %s directory by %s require an /ok-to-test from an allowed user", tektonDir, p.event.Sender), nil
+		}
+	}
+	if p.event.TriggerTarget == triggertype.PullRequest {
+		switch repo.Spec.GetApprovalPolicy() {
+		case v1alpha1.ApprovalPolicyNever:
+			return true, "", nil
+		case v1alpha1.ApprovalPolicyAlways:
+			if !opscomments.IsAnyOpsEventType(p.event.EventType) {
+				return false, "The approval policy of this repo requires an /ok-to-test from an allowed user", nil
+			}
+		}
+	}
+	allowed, err = p.vcx.IsAllowed(ctx, p.event)
+	return allowed, "", err
+}
+
 func (p *PacRun) checkAccessOrError(ctx context.Context, repo *v1alpha1.Repository, status provider.StatusOpts, viamsg string) (bool, error) {
-	allowed, err := p.vcx.IsAllowed(ctx, p.event)
+	allowed, approval, err := p.isAllowed(ctx, repo)
 	if err != nil {
 		return false, fmt.Errorf("unable to verify event authorization: %w", err)
 	}
@@ -31,12 +72,16 @@ func (p *PacRun) checkAccessOrError(ctx context.Context, repo *v1alpha1.Reposito
 	if p.event.AccountID != "" {
 		msg = fmt.Sprintf("User: %s AccountID: %s is not allowed to trigger CI %s in this repo.", p.event.Sender, p.event.AccountID, viamsg)
 	}
-	p.eventEmitter.EmitMessage(repo, zap.InfoLevel, "RepositoryPermissionDenied", msg)
+	if approval != "" {
+		msg = fmt.Sprintf("%s to trigger CI %s.", approval, viamsg)
+	}
+	p.eventEmitter.EmitFailure(repo, zap.InfoLevel, v1alpha1.FailureReasonACLDenied, "RepositoryPermissionDenied", msg)
 	status.Text = msg
 
 	if err := p.vcx.CreateStatus(ctx, p.event, status); err != nil {
 		return false, fmt.Errorf("failed to run create status, user is not allowed to run the CI:: %w", err)
 	}
+	p.postPendingApprovalComment(ctx, repo)
 	return false, nil
 }
 
@@ -45,23 +90,92 @@ func (p *PacRun) checkAccessOrError(ctx context.Context, repo *v1alpha1.Reposito
 // 2. Emitting error messages to the event system
 // 3. Creating a markdown formatted comment on the repository with all errors.
 func (p *PacRun) reportValidationErrors(ctx context.Context, repo *v1alpha1.Repository, validationErrors []*pacerrors.PacYamlValidations) {
-	errorRows := make([]string, 0, len(validationErrors))
+	reported := make([]*pacerrors.PacYamlValidations, 0, len(validationErrors))
 	for _, err := range validationErrors {
 		// if the error is a TektonConversionError, we don't want to report it since it may be a file that is not a tekton resource
 		// and we don't want to report it as a validation error.
 		if !regexpIgnoreErrors.MatchString(err.Err.Error()) && (strings.HasPrefix(err.Schema, tektonv1.SchemeGroupVersion.Group) || err.Schema == pacerrors.GenericBadYAMLValidation) {
-			errorRows = append(errorRows, fmt.Sprintf("| %s | `%s` |", err.Name, err.Err.Error()))
+			reported = append(reported, err)
 		}
-		p.eventEmitter.EmitMessage(repo, zap.ErrorLevel, "PipelineRunValidationErrors",
-			fmt.Sprintf("cannot read the PipelineRun: %s, error: %s", err.Name, err.Err.Error()))
+		msg := fmt.Sprintf("cannot read the PipelineRun: %s, error: %s", err.Name, err.Err.Error())
+		if err.Position != nil {
+			msg += fmt.Sprintf(" at %s", err.Position)
+		}
+		p.eventEmitter.EmitFailure(repo, zap.ErrorLevel, v1alpha1.FailureReasonYAMLInvalid, "PipelineRunValidationErrors", msg)
 	}
-	if len(errorRows) == 0 {
+	if len(reported) == 0 {
 		return
 	}
-	markdownErrMessage := fmt.Sprintf(`%s
-%s`, provider.ValidationErrorTemplate, strings.Join(errorRows, "\n"))
-	if err := p.vcx.CreateComment(ctx, p.event, markdownErrMessage, provider.ValidationErrorTemplate); err != nil {
-		p.eventEmitter.EmitMessage(repo, zap.ErrorLevel, "PipelineRunCommentCreationError",
+	if err := p.vcx.CreateComment(ctx, p.event, validationErrorsMarkdown(reported), provider.ValidationErrorTemplate); err != nil {
+		p.eventEmitter.EmitFailure(repo, zap.ErrorLevel, v1alpha1.FailureReasonProviderAPIError, "PipelineRunCommentCreationError",
+			fmt.Sprintf("failed to create comment: %s", err.Error()))
+	}
+}
+
+// validationErrorsMarkdown renders the validation errors as a table, followed
+// by the snippets of the files around the errors which have been located.
+func validationErrorsMarkdown(validationErrors []*pacerrors.PacYamlValidations) string {
+	rows := make([]string, 0, len(validationErrors))
+	snippets := []string{}
+	for _, err := range validationErrors {
+		if err.Position == nil {
+			rows = append(rows, fmt.Sprintf("| %s | `%s` |", err.Name, err.Err.Error()))
+			continue
+		}
+		rows = append(rows, fmt.Sprintf("| %s | `%s`: `%s` |", err.Name, err.Position, err.Err.Error()))
+		if err.Position.Snippet != "" {
+			snippets = append(snippets, fmt.Sprintf("**%s**\n\n```\n%s\n```", err.Position, err.Position.Snippet))
+		}
+	}
+	markdown := fmt.Sprintf("%s\n%s", provider.ValidationErrorTemplate, strings.Join(rows, "\n"))
+	if len(snippets) > 0 {
+		markdown += "\n\n" + strings.Join(snippets, "\n\n")
+	}
+	return markdown
+}
+
+// reportSuggestions warns about the likely mistakes found in the annotations
+// of the PipelineRuns and comments their corrections on the pull request. The
+// corrections which have been located are as well suggested on their line, for
+// the providers supporting it, so they can be committed from the pull request.
+func (p *PacRun) reportSuggestions(ctx context.Context, repo *v1alpha1.Repository, suggestions []*pacerrors.Suggestion) {
+	for _, suggestion := range suggestions {
+		msg := fmt.Sprintf("PipelineRun %s: %s", suggestion.Name, suggestion.Message)
+		if suggestion.Position != nil {
+			msg += fmt.Sprintf(" at %s", suggestion.Position)
+		}
+		p.eventEmitter.EmitMessage(repo, zap.WarnLevel, "PipelineRunAnnotationSuggestion", msg)
+	}
+	if err := p.vcx.CreateComment(ctx, p.event, suggestionsMarkdown(suggestions), provider.SuggestionTemplate); err != nil {
+		p.eventEmitter.EmitFailure(repo, zap.ErrorLevel, v1alpha1.FailureReasonProviderAPIError, "PipelineRunCommentCreationError",
 			fmt.Sprintf("failed to create comment: %s", err.Error()))
 	}
+	for _, suggestion := range suggestions {
+		if suggestion.Fix == "" {
+			continue
+		}
+		comment := fmt.Sprintf("%s\n\n```suggestion\n%s\n```", suggestion.Message, suggestion.Fix)
+		file := path.Join(tektonDir, suggestion.Position.File)
+		if err := p.vcx.CreateLineComment(ctx, p.event, file, suggestion.Position.Line, comment); err != nil {
+			p.logger.Debugf("cannot suggest the correction on %s: %v", suggestion.Position, err)
+		}
+	}
+}
+
+// suggestionsMarkdown renders the suggestions as a table, followed by the diff
+// correcting the lines which have been located.
+func suggestionsMarkdown(suggestions []*pacerrors.Suggestion) string {
+	rows := make([]string, 0, len(suggestions))
+	diffs := []string{}
+	for _, suggestion := range suggestions {
+		rows = append(rows, fmt.Sprintf("| %s | %s |", suggestion.Name, strings.ReplaceAll(suggestion.Message, "|", "\\|")))
+		if suggestion.Fix != "" {
+			diffs = append(diffs, fmt.Sprintf("**%s**\n\n```diff\n-%s\n+%s\n```", suggestion.Position, suggestion.Text, suggestion.Fix))
+		}
+	}
+	markdown := fmt.Sprintf("%s\n%s", provider.SuggestionTemplate, strings.Join(rows, "\n"))
+	if len(diffs) > 0 {
+		markdown += "\n\n" + strings.Join(diffs, "\n\n")
+	}
+	return markdown
 }
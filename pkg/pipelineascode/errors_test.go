@@ -3,18 +3,23 @@ package pipelineascode
 import (
 	"context"
 	"errors"
+	"fmt"
 	"testing"
 
 	"github.com/openshift-pipelines/pipelines-as-code/pkg/apis/pipelinesascode/v1alpha1"
 	pacerrors "github.com/openshift-pipelines/pipelines-as-code/pkg/errors"
 	"github.com/openshift-pipelines/pipelines-as-code/pkg/events"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/opscomments"
 	"github.com/openshift-pipelines/pipelines-as-code/pkg/params/info"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/params/triggertype"
 	"github.com/openshift-pipelines/pipelines-as-code/pkg/provider"
 	testclient "github.com/openshift-pipelines/pipelines-as-code/pkg/test/clients"
 	testprovider "github.com/openshift-pipelines/pipelines-as-code/pkg/test/provider"
 	"go.uber.org/zap"
 	zapobserver "go.uber.org/zap/zaptest/observer"
 	"gotest.tools/v3/assert"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	rtesting "knative.dev/pkg/reconciler/testing"
 )
 
@@ -22,12 +27,18 @@ func TestCheckAccessOrErrror(t *testing.T) {
 	tests := []struct {
 		name              string
 		allowIt           bool
+		approvalPolicy    string
+		eventType         string
 		sender            string
 		accountID         string
 		createStatusError bool
 		expectedErr       bool
 		expectedAllowed   bool
 		expectedErrMsg    string
+		expectedMsg       string
+		tektonChanges     string
+		changedFiles      []string
+		okToTest          []string
 	}{
 		{
 			name:            "user is allowed",
@@ -47,6 +58,95 @@ func TestCheckAccessOrErrror(t *testing.T) {
 			accountID:       "user123",
 			expectedAllowed: false,
 		},
+		{
+			name:            "member needs an approval with the always policy",
+			allowIt:         true,
+			approvalPolicy:  v1alpha1.ApprovalPolicyAlways,
+			eventType:       "pull_request",
+			sender:          "johndoe",
+			expectedAllowed: false,
+			expectedMsg:     "The approval policy of this repo requires an /ok-to-test from an allowed user to trigger CI via test.",
+		},
+		{
+			name:            "ok-to-test from a member with the always policy",
+			allowIt:         true,
+			approvalPolicy:  v1alpha1.ApprovalPolicyAlways,
+			eventType:       opscomments.OkToTestCommentEventType.String(),
+			expectedAllowed: true,
+		},
+		{
+			name:            "ok-to-test from a non member with the always policy",
+			allowIt:         false,
+			approvalPolicy:  v1alpha1.ApprovalPolicyAlways,
+			eventType:       opscomments.OkToTestCommentEventType.String(),
+			sender:          "johndoe",
+			expectedAllowed: false,
+			expectedMsg:     "User johndoe is not allowed to trigger CI via test in this repo.",
+		},
+		{
+			name:            "non member allowed with the never policy",
+			allowIt:         false,
+			approvalPolicy:  v1alpha1.ApprovalPolicyNever,
+			eventType:       "pull_request",
+			sender:          "johndoe",
+			expectedAllowed: true,
+		},
+		{
+			name:            "non owner changing the tekton directory needs an approval",
+			allowIt:         true,
+			tektonChanges:   v1alpha1.TektonChangesPolicyRequireApproval,
+			changedFiles:    []string{".tekton/pr.yaml", "main.go"},
+			eventType:       "pull_request",
+			sender:          "johndoe",
+			expectedAllowed: false,
+			expectedMsg:     "The changes of the .tekton directory by johndoe require an /ok-to-test from an allowed user to trigger CI via test.",
+		},
+		{
+			name:            "owner changing the tekton directory",
+			allowIt:         true,
+			tektonChanges:   v1alpha1.TektonChangesPolicyRequireApproval,
+			changedFiles:    []string{".tekton/pr.yaml"},
+			eventType:       "pull_request",
+			sender:          "owner",
+			expectedAllowed: true,
+		},
+		{
+			name:            "ok-to-test user changing the tekton directory",
+			allowIt:         true,
+			tektonChanges:   v1alpha1.TektonChangesPolicyRequireApproval,
+			changedFiles:    []string{".tekton/pr.yaml"},
+			okToTest:        []string{"johndoe"},
+			eventType:       "pull_request",
+			sender:          "johndoe",
+			expectedAllowed: true,
+		},
+		{
+			name:            "non owner not changing the tekton directory",
+			allowIt:         true,
+			tektonChanges:   v1alpha1.TektonChangesPolicyRequireApproval,
+			changedFiles:    []string{"main.go"},
+			eventType:       "pull_request",
+			sender:          "johndoe",
+			expectedAllowed: true,
+		},
+		{
+			name:            "ok-to-test on a pull request changing the tekton directory",
+			allowIt:         true,
+			tektonChanges:   v1alpha1.TektonChangesPolicyRequireApproval,
+			changedFiles:    []string{".tekton/pr.yaml"},
+			eventType:       opscomments.OkToTestCommentEventType.String(),
+			sender:          "johndoe",
+			expectedAllowed: true,
+		},
+		{
+			name:            "non owner changing the tekton directory with the target_branch policy",
+			allowIt:         true,
+			tektonChanges:   v1alpha1.TektonChangesPolicyTargetBranch,
+			changedFiles:    []string{".tekton/pr.yaml"},
+			eventType:       "pull_request",
+			sender:          "johndoe",
+			expectedAllowed: true,
+		},
 		{
 			name:              "create status error",
 			allowIt:           false,
@@ -60,18 +160,22 @@ func TestCheckAccessOrErrror(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			// Setup observer to capture logs
-			observerCore, _ := zapobserver.New(zap.InfoLevel)
+			observerCore, observedLogs := zapobserver.New(zap.InfoLevel)
 			logger := zap.New(observerCore).Sugar()
 
 			// Create test event
 			testEvent := &info.Event{
-				Sender:    tt.sender,
-				AccountID: tt.accountID,
+				Sender:        tt.sender,
+				AccountID:     tt.accountID,
+				EventType:     tt.eventType,
+				TriggerTarget: triggertype.PullRequest,
 			}
 
 			// Create mock provider
 			prov := &testprovider.TestProviderImp{
-				AllowIT: tt.allowIt,
+				AllowIT:             tt.allowIt,
+				WantAllChangedFiles: tt.changedFiles,
+				FilesInsideRepo:     map[string]string{"OWNERS": "approvers:\n  - owner\n"},
 			}
 
 			// Set createStatus error if needed
@@ -94,6 +198,12 @@ func TestCheckAccessOrErrror(t *testing.T) {
 
 			// Call the function
 			repo := &v1alpha1.Repository{}
+			if tt.approvalPolicy != "" || tt.tektonChanges != "" {
+				repo.Spec.Settings = &v1alpha1.Settings{ApprovalPolicy: tt.approvalPolicy, TektonChangesPolicy: tt.tektonChanges}
+			}
+			if tt.okToTest != nil {
+				repo.Spec.Settings.Policy = &v1alpha1.Policy{OkToTest: tt.okToTest}
+			}
 			status := provider.StatusOpts{}
 			allowed, err := p.checkAccessOrError(context.Background(), repo, status, "via test")
 
@@ -109,6 +219,9 @@ func TestCheckAccessOrErrror(t *testing.T) {
 			}
 
 			assert.Equal(t, tt.expectedAllowed, allowed)
+			if tt.expectedMsg != "" {
+				assert.Equal(t, observedLogs.FilterMessage(tt.expectedMsg).Len(), 1)
+			}
 		})
 	}
 }
@@ -215,3 +328,88 @@ func TestReportValidationErrors(t *testing.T) {
 		})
 	}
 }
+
+func TestValidationErrorsMarkdown(t *testing.T) {
+	got := validationErrorsMarkdown([]*pacerrors.PacYamlValidations{
+		{
+			Name: "pr",
+			Err:  errors.New("invalid pipeline spec"),
+		},
+		{
+			Name: ".tekton/pr.yaml",
+			Err:  errors.New("yaml validation error: line 4: mapping values are not allowed in this context"),
+			Position: &pacerrors.Position{
+				File:    ".tekton/pr.yaml",
+				Line:    4,
+				Column:  3,
+				Snippet: "3 |   name foo\n4 |   namespace: bar\n  |   ^",
+			},
+		},
+	})
+	assert.Equal(t, got, provider.ValidationErrorTemplate+`
+| pr | `+"`invalid pipeline spec`"+` |
+| .tekton/pr.yaml | `+"`.tekton/pr.yaml:4:3`: `yaml validation error: line 4: mapping values are not allowed in this context`"+` |
+
+**.tekton/pr.yaml:4:3**
+
+`+"```"+`
+3 |   name foo
+4 |   namespace: bar
+  |   ^
+`+"```")
+}
+
+func TestSuggestionsMarkdown(t *testing.T) {
+	got := suggestionsMarkdown([]*pacerrors.Suggestion{
+		{
+			Name:    "pr",
+			Message: `unknown annotation "pipelinesascode.tekton.dev/on-evnt", did you mean "pipelinesascode.tekton.dev/on-event"?`,
+		},
+		{
+			Name:     "pr-push",
+			Message:  `unknown event "pullrequest" in the pipelinesascode.tekton.dev/on-event annotation, did you mean "pull_request"?`,
+			Position: &pacerrors.Position{File: "pr-push.yaml", Line: 7, Column: 5},
+			Text:     `    pipelinesascode.tekton.dev/on-event: "[pullrequest]"`,
+			Fix:      `    pipelinesascode.tekton.dev/on-event: "[pull_request]"`,
+		},
+	})
+	assert.Equal(t, got, provider.SuggestionTemplate+`
+| pr | unknown annotation "pipelinesascode.tekton.dev/on-evnt", did you mean "pipelinesascode.tekton.dev/on-event"? |
+| pr-push | unknown event "pullrequest" in the pipelinesascode.tekton.dev/on-event annotation, did you mean "pull_request"? |
+
+**pr-push.yaml:7:5**
+
+`+"```diff"+`
+-    pipelinesascode.tekton.dev/on-event: "[pullrequest]"
++    pipelinesascode.tekton.dev/on-event: "[pull_request]"
+`+"```")
+}
+
+func TestIsQuotaExceeded(t *testing.T) {
+	prGR := schema.GroupResource{Group: "tekton.dev", Resource: "pipelineruns"}
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{
+			name: "quota exceeded",
+			err: fmt.Errorf("creating pipelinerun pr- in namespace ns has failed: %w",
+				apierrors.NewForbidden(prGR, "pr-", errors.New("exceeded quota: compute, requested: pods=1, used: pods=10, limited: pods=10"))),
+			want: true,
+		},
+		{
+			name: "forbidden",
+			err:  apierrors.NewForbidden(prGR, "pr-", errors.New("user cannot create pipelineruns")),
+		},
+		{
+			name: "other error",
+			err:  errors.New("exceeded quota"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, isQuotaExceeded(tt.err), tt.want)
+		})
+	}
+}
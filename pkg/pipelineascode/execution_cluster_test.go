@@ -0,0 +1,116 @@
+package pipelineascode
+
+import (
+	"testing"
+
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/apis/pipelinesascode/keys"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/apis/pipelinesascode/v1alpha1"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/consoleui"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/executioncluster"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/matcher"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/params"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/params/clients"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/params/info"
+	testclient "github.com/openshift-pipelines/pipelines-as-code/pkg/test/clients"
+	kitesthelper "github.com/openshift-pipelines/pipelines-as-code/pkg/test/kubernetestint"
+	testprovider "github.com/openshift-pipelines/pipelines-as-code/pkg/test/provider"
+	pipelinev1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+	faketekton "github.com/tektoncd/pipeline/pkg/client/clientset/versioned/fake"
+	"go.uber.org/zap"
+	zapobserver "go.uber.org/zap/zaptest/observer"
+	"gotest.tools/v3/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	rtesting "knative.dev/pkg/reconciler/testing"
+)
+
+func TestStartPRExecutionCluster(t *testing.T) {
+	tests := []struct {
+		name             string
+		executionCluster *v1alpha1.ExecutionCluster
+		concurrencyLimit *int
+		wantRemote       bool
+		wantErr          string
+	}{
+		{
+			name: "local cluster",
+		},
+		{
+			name:             "execution cluster",
+			executionCluster: &v1alpha1.ExecutionCluster{Secret: &v1alpha1.Secret{Name: "remote"}},
+			wantRemote:       true,
+		},
+		{
+			name:             "execution cluster with a concurrency limit",
+			executionCluster: &v1alpha1.ExecutionCluster{Secret: &v1alpha1.Secret{Name: "remote"}},
+			concurrencyLimit: func() *int { i := 1; return &i }(),
+			wantErr:          "concurrency_limit is not supported with an execution cluster",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			observer, _ := zapobserver.New(zap.InfoLevel)
+			logger := zap.New(observer).Sugar()
+			ctx, _ := rtesting.SetupFakeContext(t)
+			stdata, _ := testclient.SeedTestData(t, ctx, testclient.Data{
+				Secret: []*corev1.Secret{{
+					ObjectMeta: metav1.ObjectMeta{Name: "remote", Namespace: "ns"},
+					Data:       map[string][]byte{executioncluster.DefaultSecretKey: []byte("kubeconfig")},
+				}},
+			})
+			remoteTekton := faketekton.NewSimpleClientset()
+
+			run := params.New()
+			run.Clients = clients.Clients{
+				Kube:   stdata.Kube,
+				Tekton: stdata.Pipeline,
+				Log:    logger,
+			}
+			run.Clients.SetConsoleUI(consoleui.FallBackConsole{})
+			p := &PacRun{
+				run:     run,
+				event:   info.NewEvent(),
+				vcx:     &testprovider.TestProviderImp{},
+				pacInfo: &info.PacOpts{},
+				k8int:   &kitesthelper.KinterfaceTest{},
+				logger:  logger,
+				newExecutionClients: func(_ []byte) (*executioncluster.Clients, error) {
+					return &executioncluster.Clients{Tekton: remoteTekton}, nil
+				},
+			}
+			match := matcher.Match{
+				PipelineRun: &pipelinev1.PipelineRun{
+					ObjectMeta: metav1.ObjectMeta{Name: "pr", Namespace: "ns", Labels: map[string]string{}, Annotations: map[string]string{keys.OriginalPRName: "pr"}},
+				},
+				Repo: &v1alpha1.Repository{
+					ObjectMeta: metav1.ObjectMeta{Name: "repo", Namespace: "ns"},
+					Spec: v1alpha1.RepositorySpec{
+						URL:              "https://github.com/owner/repo",
+						ConcurrencyLimit: tt.concurrencyLimit,
+						Settings:         &v1alpha1.Settings{ExecutionCluster: tt.executionCluster},
+					},
+				},
+			}
+
+			_, err := p.startPR(ctx, match)
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			assert.NilError(t, err)
+
+			remote, err := remoteTekton.TektonV1().PipelineRuns("ns").List(ctx, metav1.ListOptions{})
+			assert.NilError(t, err)
+			local, err := stdata.Pipeline.TektonV1().PipelineRuns("ns").List(ctx, metav1.ListOptions{})
+			assert.NilError(t, err)
+			if tt.wantRemote {
+				assert.Equal(t, len(remote.Items), 1)
+				assert.Equal(t, len(local.Items), 0)
+				assert.Equal(t, remote.Items[0].GetAnnotations()[keys.State], "started")
+			} else {
+				assert.Equal(t, len(remote.Items), 0)
+				assert.Equal(t, len(local.Items), 1)
+			}
+		})
+	}
+}
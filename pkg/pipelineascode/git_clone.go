@@ -0,0 +1,173 @@
+package pipelineascode
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/apis/pipelinesascode/keys"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/params/info"
+	tektonv1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+	corev1 "k8s.io/api/core/v1"
+)
+
+const (
+	gitCloneTaskName = "pac-git-clone"
+	// gitCloneAuthWorkspace is the workspace of the pipeline bound to the git
+	// auth secret created for the PipelineRun.
+	gitCloneAuthWorkspace = "pac-git-auth"
+	gitCloneDefaultDepth  = "1"
+
+	gitCloneScript = `#!/usr/bin/env sh
+set -eu
+if [ "$(workspaces.basic-auth.bound)" = "true" ]; then
+  cp "$(workspaces.basic-auth.path)/.git-credentials" "${HOME}/.git-credentials"
+  cp "$(workspaces.basic-auth.path)/.gitconfig" "${HOME}/.gitconfig"
+  chmod 400 "${HOME}/.git-credentials" "${HOME}/.gitconfig"
+fi
+/ko-app/git-init \
+  -url="$(params.url)" \
+  -revision="$(params.revision)" \
+  -path="$(workspaces.output.path)" \
+  -depth="$(params.depth)" \
+  -submodules="$(params.submodules)" \
+  -sparseCheckoutDirectories="$(params.sparse-checkout-directories)"
+if [ "$(params.lfs)" = "true" ]; then
+  cd "$(workspaces.output.path)"
+  git lfs install --local
+  git lfs pull
+fi
+`
+)
+
+// gitCloneOptions are the options of the injected clone task, read from the
+// annotations of the PipelineRun.
+type gitCloneOptions struct {
+	workspace      string
+	depth          string
+	submodules     string
+	lfs            string
+	sparseCheckout string
+}
+
+func parseGitCloneOptions(pr *tektonv1.PipelineRun) (*gitCloneOptions, error) {
+	annotations := pr.GetAnnotations()
+	opts := &gitCloneOptions{
+		workspace:      strings.TrimSpace(annotations[keys.GitClone]),
+		depth:          gitCloneDefaultDepth,
+		submodules:     "true",
+		lfs:            "false",
+		sparseCheckout: sparseCheckoutDirectories(annotations[keys.SparseCheckout]),
+	}
+	if opts.workspace == "" {
+		return nil, fmt.Errorf("the %s annotation on pipelinerun %s should be the name of the workspace to clone the repository in", keys.GitClone, pr.GetGenerateName())
+	}
+	if value, ok := annotations[keys.GitCloneDepth]; ok {
+		if depth, err := strconv.Atoi(strings.TrimSpace(value)); err != nil || depth < 0 {
+			return nil, fmt.Errorf("invalid %s annotation %q on pipelinerun %s, it should be a number of commits or 0 for the full history", keys.GitCloneDepth, value, pr.GetGenerateName())
+		}
+		opts.depth = strings.TrimSpace(value)
+	}
+	for _, option := range []struct {
+		key   string
+		value *string
+	}{{keys.GitCloneSubmodules, &opts.submodules}, {keys.GitCloneLFS, &opts.lfs}} {
+		annotation, ok := annotations[option.key]
+		if !ok {
+			continue
+		}
+		b, err := strconv.ParseBool(strings.TrimSpace(annotation))
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s annotation %q on pipelinerun %s, it should be true or false", option.key, annotation, pr.GetGenerateName())
+		}
+		*option.value = strconv.FormatBool(b)
+	}
+	return opts, nil
+}
+
+// gitCloneOptionsOf returns the options of the clone task to inject in pr,
+// none when it doesn't have the git-clone annotation. It checks the clone
+// task can be injected, before anything is created for the PipelineRun.
+func gitCloneOptionsOf(pr *tektonv1.PipelineRun) (*gitCloneOptions, error) {
+	if _, ok := pr.GetAnnotations()[keys.GitClone]; !ok {
+		return nil, nil
+	}
+	opts, err := parseGitCloneOptions(pr)
+	if err != nil {
+		return nil, err
+	}
+	spec := pr.Spec.PipelineSpec
+	if spec == nil {
+		return nil, fmt.Errorf("the %s annotation needs the pipeline of pipelinerun %s to be embedded or in the .tekton directory", keys.GitClone, pr.GetGenerateName())
+	}
+	if !slices.ContainsFunc(spec.Workspaces, func(ws tektonv1.PipelineWorkspaceDeclaration) bool { return ws.Name == opts.workspace }) {
+		return nil, fmt.Errorf("the workspace %s of the %s annotation is not declared by the pipeline of pipelinerun %s", opts.workspace, keys.GitClone, pr.GetGenerateName())
+	}
+	if slices.ContainsFunc(spec.Tasks, func(task tektonv1.PipelineTask) bool { return task.Name == gitCloneTaskName }) {
+		return nil, fmt.Errorf("the pipeline of pipelinerun %s already has a %s task", pr.GetGenerateName(), gitCloneTaskName)
+	}
+	return opts, nil
+}
+
+// injectGitClone adds a task cloning the repository of the event with opts as
+// the first task of the pipeline of pr, the clone is authenticated with the
+// git auth secret created for the PipelineRun.
+func injectGitClone(pr *tektonv1.PipelineRun, opts *gitCloneOptions, event *info.Event, image, authSecret string) {
+	spec := pr.Spec.PipelineSpec
+	repoURL := event.URL
+	if event.CloneURL != "" {
+		repoURL = event.CloneURL
+	}
+	cloneTask := tektonv1.PipelineTask{
+		Name: gitCloneTaskName,
+		TaskSpec: &tektonv1.EmbeddedTask{TaskSpec: tektonv1.TaskSpec{
+			Params: tektonv1.ParamSpecs{
+				{Name: "url", Type: tektonv1.ParamTypeString},
+				{Name: "revision", Type: tektonv1.ParamTypeString},
+				{Name: "depth", Type: tektonv1.ParamTypeString},
+				{Name: "submodules", Type: tektonv1.ParamTypeString},
+				{Name: "lfs", Type: tektonv1.ParamTypeString},
+				{Name: "sparse-checkout-directories", Type: tektonv1.ParamTypeString},
+			},
+			Workspaces: []tektonv1.WorkspaceDeclaration{
+				{Name: "output"},
+				{Name: "basic-auth", Optional: true, ReadOnly: true},
+			},
+			Steps: []tektonv1.Step{{
+				Name:   "clone",
+				Image:  image,
+				Env:    []corev1.EnvVar{{Name: "HOME", Value: "/home/git"}},
+				Script: gitCloneScript,
+			}},
+		}},
+		Params: tektonv1.Params{
+			{Name: "url", Value: *tektonv1.NewStructuredValues(repoURL)},
+			{Name: "revision", Value: *tektonv1.NewStructuredValues(event.SHA)},
+			{Name: "depth", Value: *tektonv1.NewStructuredValues(opts.depth)},
+			{Name: "submodules", Value: *tektonv1.NewStructuredValues(opts.submodules)},
+			{Name: "lfs", Value: *tektonv1.NewStructuredValues(opts.lfs)},
+			{Name: "sparse-checkout-directories", Value: *tektonv1.NewStructuredValues(opts.sparseCheckout)},
+		},
+		Workspaces: []tektonv1.WorkspacePipelineTaskBinding{
+			{Name: "output", Workspace: opts.workspace},
+			{Name: "basic-auth", Workspace: gitCloneAuthWorkspace},
+		},
+	}
+
+	// every task waits for the clone, the tasks already running after
+	// another one wait for it through that task
+	for i := range spec.Tasks {
+		if len(spec.Tasks[i].RunAfter) == 0 {
+			spec.Tasks[i].RunAfter = []string{gitCloneTaskName}
+		}
+	}
+	spec.Tasks = append([]tektonv1.PipelineTask{cloneTask}, spec.Tasks...)
+	spec.Workspaces = append(spec.Workspaces, tektonv1.PipelineWorkspaceDeclaration{Name: gitCloneAuthWorkspace, Optional: true})
+	if authSecret != "" {
+		pr.Spec.Workspaces = append(pr.Spec.Workspaces, tektonv1.WorkspaceBinding{
+			Name:   gitCloneAuthWorkspace,
+			Secret: &corev1.SecretVolumeSource{SecretName: authSecret},
+		})
+	}
+}
@@ -0,0 +1,206 @@
+package pipelineascode
+
+import (
+	"testing"
+
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/apis/pipelinesascode/keys"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/apis/pipelinesascode/v1alpha1"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/kubeinteraction"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/matcher"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/params"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/params/clients"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/params/info"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/params/settings"
+	testclient "github.com/openshift-pipelines/pipelines-as-code/pkg/test/clients"
+	testprovider "github.com/openshift-pipelines/pipelines-as-code/pkg/test/provider"
+	tektonv1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+	"go.uber.org/zap"
+	zapobserver "go.uber.org/zap/zaptest/observer"
+	"gotest.tools/v3/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	rtesting "knative.dev/pkg/reconciler/testing"
+)
+
+func TestInjectGitClone(t *testing.T) {
+	pipelineSpec := func() *tektonv1.PipelineSpec {
+		return &tektonv1.PipelineSpec{
+			Workspaces: []tektonv1.PipelineWorkspaceDeclaration{{Name: "source"}},
+			Tasks: []tektonv1.PipelineTask{
+				{Name: "lint"},
+				{Name: "build", RunAfter: []string{"lint"}},
+			},
+		}
+	}
+	tests := []struct {
+		name         string
+		annotations  map[string]string
+		pipelineSpec *tektonv1.PipelineSpec
+		cloneURL     string
+		authSecret   string
+		wantParams   map[string]string
+		wantErr      string
+		wantNoClone  bool
+	}{
+		{
+			name:         "no annotation",
+			pipelineSpec: pipelineSpec(),
+			wantNoClone:  true,
+		},
+		{
+			name:         "default options",
+			annotations:  map[string]string{keys.GitClone: "source"},
+			pipelineSpec: pipelineSpec(),
+			authSecret:   "pac-gitauth-abcdef",
+			wantParams: map[string]string{
+				"url": "https://forge/org/repo", "revision": "123456", "depth": "1",
+				"submodules": "true", "lfs": "false", "sparse-checkout-directories": "",
+			},
+		},
+		{
+			name: "options from the annotations",
+			annotations: map[string]string{
+				keys.GitClone:           "source",
+				keys.GitCloneDepth:      "0",
+				keys.GitCloneSubmodules: "false",
+				keys.GitCloneLFS:        "True",
+				keys.SparseCheckout:     "services/foo, libs/bar,",
+			},
+			pipelineSpec: pipelineSpec(),
+			cloneURL:     "https://forge/scm/org/repo.git",
+			wantParams: map[string]string{
+				"url": "https://forge/scm/org/repo.git", "revision": "123456", "depth": "0",
+				"submodules": "false", "lfs": "true", "sparse-checkout-directories": "/services/foo/,/libs/bar/",
+			},
+		},
+		{
+			name:         "no workspace",
+			annotations:  map[string]string{keys.GitClone: " "},
+			pipelineSpec: pipelineSpec(),
+			wantErr:      "the pipelinesascode.tekton.dev/git-clone annotation on pipelinerun pr- should be the name of the workspace to clone the repository in",
+		},
+		{
+			name:         "unknown workspace",
+			annotations:  map[string]string{keys.GitClone: "output"},
+			pipelineSpec: pipelineSpec(),
+			wantErr:      "the workspace output of the pipelinesascode.tekton.dev/git-clone annotation is not declared by the pipeline of pipelinerun pr-",
+		},
+		{
+			name:        "pipeline not embedded",
+			annotations: map[string]string{keys.GitClone: "source"},
+			wantErr:     "the pipelinesascode.tekton.dev/git-clone annotation needs the pipeline of pipelinerun pr- to be embedded or in the .tekton directory",
+		},
+		{
+			name:         "invalid depth",
+			annotations:  map[string]string{keys.GitClone: "source", keys.GitCloneDepth: "-1"},
+			pipelineSpec: pipelineSpec(),
+			wantErr:      `invalid pipelinesascode.tekton.dev/git-clone-depth annotation "-1" on pipelinerun pr-, it should be a number of commits or 0 for the full history`,
+		},
+		{
+			name:         "invalid lfs",
+			annotations:  map[string]string{keys.GitClone: "source", keys.GitCloneLFS: "yes"},
+			pipelineSpec: pipelineSpec(),
+			wantErr:      `invalid pipelinesascode.tekton.dev/git-clone-lfs annotation "yes" on pipelinerun pr-, it should be true or false`,
+		},
+		{
+			name:        "task name already used",
+			annotations: map[string]string{keys.GitClone: "source"},
+			pipelineSpec: &tektonv1.PipelineSpec{
+				Workspaces: []tektonv1.PipelineWorkspaceDeclaration{{Name: "source"}},
+				Tasks:      []tektonv1.PipelineTask{{Name: gitCloneTaskName}},
+			},
+			wantErr: "the pipeline of pipelinerun pr- already has a pac-git-clone task",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pr := &tektonv1.PipelineRun{
+				ObjectMeta: metav1.ObjectMeta{GenerateName: "pr-", Annotations: tt.annotations},
+				Spec:       tektonv1.PipelineRunSpec{PipelineSpec: tt.pipelineSpec},
+			}
+			event := &info.Event{URL: "https://forge/org/repo", CloneURL: tt.cloneURL, SHA: "123456"}
+
+			opts, err := gitCloneOptionsOf(pr)
+			if tt.wantErr != "" {
+				assert.Error(t, err, tt.wantErr)
+				return
+			}
+			assert.NilError(t, err)
+			if tt.wantNoClone {
+				assert.Assert(t, opts == nil)
+				return
+			}
+			injectGitClone(pr, opts, event, "git-init:latest", tt.authSecret)
+			spec := pr.Spec.PipelineSpec
+
+			clone := spec.Tasks[0]
+			assert.Equal(t, clone.Name, gitCloneTaskName)
+			assert.Equal(t, clone.TaskSpec.Steps[0].Image, "git-init:latest")
+			params := map[string]string{}
+			for _, param := range clone.Params {
+				params[param.Name] = param.Value.StringVal
+			}
+			assert.DeepEqual(t, params, tt.wantParams)
+			assert.DeepEqual(t, clone.Workspaces, []tektonv1.WorkspacePipelineTaskBinding{
+				{Name: "output", Workspace: "source"},
+				{Name: "basic-auth", Workspace: gitCloneAuthWorkspace},
+			})
+			assert.DeepEqual(t, spec.Tasks[1].RunAfter, []string{gitCloneTaskName})
+			assert.DeepEqual(t, spec.Tasks[2].RunAfter, []string{"lint"})
+			assert.Equal(t, spec.Workspaces[1].Name, gitCloneAuthWorkspace)
+
+			if tt.authSecret == "" {
+				assert.Equal(t, len(pr.Spec.Workspaces), 0)
+				return
+			}
+			assert.DeepEqual(t, pr.Spec.Workspaces, []tektonv1.WorkspaceBinding{{
+				Name:   gitCloneAuthWorkspace,
+				Secret: &corev1.SecretVolumeSource{SecretName: tt.authSecret},
+			}})
+		})
+	}
+}
+
+func TestStartPRInvalidGitClone(t *testing.T) {
+	observer, _ := zapobserver.New(zap.InfoLevel)
+	logger := zap.New(observer).Sugar()
+	ctx, _ := rtesting.SetupFakeContext(t)
+	stdata, _ := testclient.SeedTestData(t, ctx, testclient.Data{})
+	run := params.New()
+	run.Clients = clients.Clients{
+		Kube:   stdata.Kube,
+		Tekton: stdata.Pipeline,
+		Log:    logger,
+	}
+	event := info.NewEvent()
+	event.URL = "https://forge/org/repo"
+	event.Provider.Token = "token"
+	p := &PacRun{
+		run:     run,
+		event:   event,
+		vcx:     &testprovider.TestProviderImp{},
+		pacInfo: &info.PacOpts{Settings: settings.Settings{SecretAutoCreation: true}},
+		k8int:   &kubeinteraction.Interaction{Run: run},
+		logger:  logger,
+	}
+	match := matcher.Match{
+		PipelineRun: &tektonv1.PipelineRun{
+			ObjectMeta: metav1.ObjectMeta{
+				GenerateName: "pr-",
+				Namespace:    "ns",
+				Annotations:  map[string]string{keys.GitAuthSecret: "pac-gitauth-abcdef", keys.GitClone: "output"},
+			},
+			Spec: tektonv1.PipelineRunSpec{PipelineSpec: &tektonv1.PipelineSpec{
+				Workspaces: []tektonv1.PipelineWorkspaceDeclaration{{Name: "source"}},
+			}},
+		},
+		Repo: &v1alpha1.Repository{ObjectMeta: metav1.ObjectMeta{Name: "repo", Namespace: "ns"}},
+	}
+
+	_, err := p.startPR(ctx, match)
+	assert.Error(t, err, "the workspace output of the pipelinesascode.tekton.dev/git-clone annotation is not declared by the pipeline of pipelinerun pr-")
+	// the annotations are checked before the git auth secret is created
+	secrets, err := stdata.Kube.CoreV1().Secrets("ns").List(ctx, metav1.ListOptions{})
+	assert.NilError(t, err)
+	assert.Equal(t, len(secrets.Items), 0)
+}
@@ -0,0 +1,45 @@
+package pipelineascode
+
+import (
+	"context"
+	"path"
+
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/lockfile"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/matcher"
+	tektonv1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+)
+
+// getLock returns the lockfile of the .tekton directory pinning the remote
+// tasks and pipelines of the PipelineRuns, nil when the repository has none
+// or when the PipelineRuns don't reference any remote task or pipeline.
+func (p *PacRun) getLock(ctx context.Context, prs []*tektonv1.PipelineRun) (*lockfile.Lock, error) {
+	if !hasRemoteAnnotations(prs) {
+		return nil, nil
+	}
+	data, err := p.vcx.GetFileInsideRepo(ctx, p.event, path.Join(tektonDir, lockfile.Filename), "")
+	if err != nil {
+		p.logger.Debugf("no %s found in the repository: %v", lockfile.Filename, err)
+		return nil, nil
+	}
+	return lockfile.Parse([]byte(data))
+}
+
+// hasRemoteAnnotations tells whether a PipelineRun references a remote task
+// or pipeline in its annotations.
+func hasRemoteAnnotations(prs []*tektonv1.PipelineRun) bool {
+	for _, pr := range prs {
+		uris, err := matcher.GrabTasksFromAnnotations(pr.GetAnnotations())
+		if err != nil {
+			continue
+		}
+		if pipeline, err := matcher.GrabPipelineFromAnnotations(pr.GetAnnotations()); err == nil && pipeline != "" {
+			uris = append(uris, pipeline)
+		}
+		for _, uri := range uris {
+			if lockfile.IsRemote(uri) {
+				return true
+			}
+		}
+	}
+	return false
+}
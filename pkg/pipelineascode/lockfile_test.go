@@ -0,0 +1,78 @@
+package pipelineascode
+
+import (
+	"testing"
+
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/lockfile"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/params/info"
+	testprovider "github.com/openshift-pipelines/pipelines-as-code/pkg/test/provider"
+	tektonv1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+	"go.uber.org/zap"
+	"gotest.tools/v3/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	rtesting "knative.dev/pkg/reconciler/testing"
+)
+
+func TestGetLock(t *testing.T) {
+	tests := []struct {
+		name        string
+		annotations map[string]string
+		lockfile    string
+		want        map[string]string
+		wantErr     string
+	}{
+		{
+			name:        "no remote annotation",
+			annotations: map[string]string{"pipelinesascode.tekton.dev/task": "[.tekton/task.yaml]"},
+			lockfile:    "resources:\n  git-clone: sha256:abcd\n",
+		},
+		{
+			name:        "remote task",
+			annotations: map[string]string{"pipelinesascode.tekton.dev/task": "[git-clone]"},
+			lockfile:    "resources:\n  git-clone: sha256:abcd\n",
+			want:        map[string]string{"git-clone": "sha256:abcd"},
+		},
+		{
+			name:        "remote pipeline",
+			annotations: map[string]string{"pipelinesascode.tekton.dev/pipeline": "https://example.com/pipeline.yaml"},
+			lockfile:    "resources: {}\n",
+			want:        map[string]string{},
+		},
+		{
+			name:        "no lockfile",
+			annotations: map[string]string{"pipelinesascode.tekton.dev/task": "[git-clone]"},
+		},
+		{
+			name:        "invalid lockfile",
+			annotations: map[string]string{"pipelinesascode.tekton.dev/task": "[git-clone]"},
+			lockfile:    "resources: [git-clone]\n",
+			wantErr:     "cannot parse pac.lock",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, _ := rtesting.SetupFakeContext(t)
+			files := map[string]string{}
+			if tt.lockfile != "" {
+				files[".tekton/"+lockfile.Filename] = tt.lockfile
+			}
+			p := &PacRun{
+				event:  info.NewEvent(),
+				vcx:    &testprovider.TestProviderImp{FilesInsideRepo: files},
+				logger: zap.NewNop().Sugar(),
+			}
+			prs := []*tektonv1.PipelineRun{{ObjectMeta: metav1.ObjectMeta{Name: "pr", Annotations: tt.annotations}}}
+			lock, err := p.getLock(ctx, prs)
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			assert.NilError(t, err)
+			if tt.want == nil {
+				assert.Assert(t, lock == nil)
+				return
+			}
+			assert.DeepEqual(t, lock.Resources, tt.want)
+		})
+	}
+}
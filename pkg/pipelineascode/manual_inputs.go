@@ -0,0 +1,59 @@
+package pipelineascode
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	apincoming "github.com/openshift-pipelines/pipelines-as-code/pkg/apis/incoming"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/apis/pipelinesascode/keys"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/params/triggertype"
+	tektonv1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+)
+
+// applyManualInputs validates the params of an incoming request against the
+// inputs declared in the manual-inputs annotation of the targeted PipelineRun
+// and sets the default values of the inputs that were not provided, before
+// the params get substituted in the templates.
+func (p *PacRun) applyManualInputs(pipelineRuns []*tektonv1.PipelineRun) error {
+	if p.event.EventType != triggertype.Incoming.String() || p.event.Request == nil {
+		return nil
+	}
+	var annotation string
+	for _, pr := range pipelineRuns {
+		name := pr.GetGenerateName()
+		if name == "" {
+			name = pr.GetName()
+		}
+		if strings.TrimSuffix(name, "-") == p.event.TargetPipelineRun {
+			annotation = pr.GetAnnotations()[keys.ManualInputs]
+			break
+		}
+	}
+	if annotation == "" {
+		return nil
+	}
+
+	inputs, err := apincoming.ParseManualInputs(annotation)
+	if err != nil {
+		return fmt.Errorf("pipelinerun %s: %w", p.event.TargetPipelineRun, err)
+	}
+	// keep the other keys of the body (repository, branch...) untouched
+	body := map[string]any{}
+	if len(p.event.Request.Payload) > 0 {
+		if err := json.Unmarshal(p.event.Request.Payload, &body); err != nil {
+			return fmt.Errorf("cannot parse incoming payload: %w", err)
+		}
+	}
+	payload, _ := apincoming.ParseIncomingPayload(p.event.Request.Payload)
+	params, err := apincoming.ValidateManualInputs(inputs, payload.Params)
+	if err != nil {
+		return fmt.Errorf("invalid inputs for pipelinerun %s: %w", p.event.TargetPipelineRun, err)
+	}
+	body["params"] = params
+	if p.event.Request.Payload, err = json.Marshal(body); err != nil {
+		return err
+	}
+	p.event.Event = apincoming.Payload{Params: params}
+	return nil
+}
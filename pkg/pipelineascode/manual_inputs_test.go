@@ -0,0 +1,93 @@
+package pipelineascode
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/apis/pipelinesascode/keys"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/params/info"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/params/triggertype"
+	tektonv1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+	"gotest.tools/v3/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestApplyManualInputs(t *testing.T) {
+	prs := []*tektonv1.PipelineRun{
+		{
+			ObjectMeta: metav1.ObjectMeta{
+				GenerateName: "release-",
+				Annotations: map[string]string{
+					keys.ManualInputs: `[{"name": "environment", "type": "choice", "options": ["staging", "production"], "default": "staging"}]`,
+				},
+			},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "no-inputs"},
+		},
+	}
+	tests := []struct {
+		name      string
+		eventType string
+		target    string
+		payload   string
+		wantBody  map[string]any
+		wantErr   string
+	}{
+		{
+			name:      "default is set",
+			eventType: triggertype.Incoming.String(),
+			target:    "release",
+			payload:   `{"repository": "repo", "params": {}}`,
+			wantBody:  map[string]any{"repository": "repo", "params": map[string]any{"environment": "staging"}},
+		},
+		{
+			name:      "provided input",
+			eventType: triggertype.Incoming.String(),
+			target:    "release",
+			payload:   `{"params": {"environment": "production"}}`,
+			wantBody:  map[string]any{"params": map[string]any{"environment": "production"}},
+		},
+		{
+			name:      "invalid input",
+			eventType: triggertype.Incoming.String(),
+			target:    "release",
+			payload:   `{"params": {"environment": "dev"}}`,
+			wantErr:   "invalid inputs for pipelinerun release: input environment must be one of [staging production], got dev",
+		},
+		{
+			name:      "pipelinerun without inputs",
+			eventType: triggertype.Incoming.String(),
+			target:    "no-inputs",
+			payload:   `{"params": {"environment": "dev"}}`,
+		},
+		{
+			name:      "not an incoming event",
+			eventType: triggertype.Push.String(),
+			target:    "release",
+			payload:   `{"params": {"environment": "dev"}}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := &PacRun{event: &info.Event{
+				EventType:         tt.eventType,
+				TargetPipelineRun: tt.target,
+				Request:           &info.Request{Payload: []byte(tt.payload)},
+			}}
+			err := p.applyManualInputs(prs)
+			if tt.wantErr != "" {
+				assert.Error(t, err, tt.wantErr)
+				return
+			}
+			assert.NilError(t, err)
+			if tt.wantBody == nil {
+				assert.Equal(t, string(p.event.Request.Payload), tt.payload)
+				return
+			}
+			body := map[string]any{}
+			assert.NilError(t, json.Unmarshal(p.event.Request.Payload, &body))
+			assert.DeepEqual(t, body, tt.wantBody)
+		})
+	}
+}
@@ -3,23 +3,27 @@ package pipelineascode
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
-	"regexp"
 	"strings"
 
 	apipac "github.com/openshift-pipelines/pipelines-as-code/pkg/apis/pipelinesascode/keys"
 	"github.com/openshift-pipelines/pipelines-as-code/pkg/apis/pipelinesascode/v1alpha1"
 	pacerrors "github.com/openshift-pipelines/pipelines-as-code/pkg/errors"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/lockfile"
 	"github.com/openshift-pipelines/pipelines-as-code/pkg/matcher"
 	"github.com/openshift-pipelines/pipelines-as-code/pkg/opscomments"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/params/info"
 	"github.com/openshift-pipelines/pipelines-as-code/pkg/params/triggertype"
 	"github.com/openshift-pipelines/pipelines-as-code/pkg/provider"
 	"github.com/openshift-pipelines/pipelines-as-code/pkg/provider/github"
 	"github.com/openshift-pipelines/pipelines-as-code/pkg/resolve"
 	"github.com/openshift-pipelines/pipelines-as-code/pkg/secrets"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/sort"
 	"github.com/openshift-pipelines/pipelines-as-code/pkg/templates"
 	tektonv1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
 	"go.uber.org/zap"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 func (p *PacRun) matchRepoPR(ctx context.Context) ([]matcher.Match, *v1alpha1.Repository, error) {
@@ -35,11 +39,37 @@ func (p *PacRun) matchRepoPR(ctx context.Context) ([]matcher.Match, *v1alpha1.Re
 		return nil, repo, p.cancelPipelineRunsOpsComment(ctx, repo)
 	}
 
+	if p.isSupersededPush(ctx, repo) {
+		return nil, repo, nil
+	}
+
 	matchedPRs, err := p.getPipelineRunsFromRepo(ctx, repo)
 	if err != nil {
 		return nil, repo, err
 	}
 
+	// the pull request PipelineRuns still run when the baselines cannot
+	if matchedPRs, err = p.addCanaryBaselines(ctx, repo, matchedPRs); err != nil {
+		p.eventEmitter.EmitMessage(repo, zap.WarnLevel, "RepositoryCanaryBaseline", err.Error())
+	}
+
+	matchedPRs, err = expandMatrix(matchedPRs)
+	if err != nil {
+		p.eventEmitter.EmitFailure(repo, zap.ErrorLevel, v1alpha1.FailureReasonYAMLInvalid, "RepositoryInvalidMatrix", err.Error())
+		return nil, repo, err
+	}
+
+	matchedPRs, err = expandPerCommit(p.event, matchedPRs)
+	if err != nil {
+		p.eventEmitter.EmitFailure(repo, zap.ErrorLevel, v1alpha1.FailureReasonYAMLInvalid, "RepositoryInvalidPerCommit", err.Error())
+		return nil, repo, err
+	}
+
+	if err := checkDependencies(matchedPRs); err != nil {
+		p.eventEmitter.EmitFailure(repo, zap.ErrorLevel, v1alpha1.FailureReasonYAMLInvalid, "RepositoryInvalidDependencies", err.Error())
+		return nil, repo, err
+	}
+
 	return matchedPRs, repo, nil
 }
 
@@ -52,12 +82,38 @@ func (p *PacRun) verifyRepoAndUser(ctx context.Context) (*v1alpha1.Repository, e
 		return nil, fmt.Errorf("error matching Repository for event: %w", err)
 	}
 
+	// the repositories of an organization without a Repository get one from
+	// the organization Repository, once the payload has been validated
+	var orgRepo *v1alpha1.Repository
+	if repo == nil {
+		repositories, err := p.run.Clients.PipelineAsCode.PipelinesascodeV1alpha1().Repositories("").List(ctx, metav1.ListOptions{})
+		if err != nil {
+			return nil, fmt.Errorf("error matching organization Repository for event: %w", err)
+		}
+		sort.RepositorySortByCreationOldestTime(repositories.Items)
+		candidates := []*v1alpha1.Repository{}
+		for i := range repositories.Items {
+			if repositories.Items[i].Spec.Organization != nil {
+				candidates = append(candidates, &repositories.Items[i])
+			}
+		}
+		if orgRepo = matcher.MatchOrgRepository(p.event.URL, candidates); orgRepo != nil {
+			if repo, err = newOrgMatchedRepository(orgRepo, p.event.URL); err != nil {
+				return nil, err
+			}
+		}
+	}
+
 	if repo == nil {
 		msg := fmt.Sprintf("cannot find a repository match for %s", p.event.URL)
 		p.eventEmitter.EmitMessage(nil, zap.WarnLevel, "RepositoryNamespaceMatch", msg)
 		return nil, nil
 	}
 
+	if err := p.inheritOrgRepository(ctx, repo); err != nil {
+		return nil, err
+	}
+
 	secretNS := repo.GetNamespace()
 	if repo.Spec.GitProvider != nil && repo.Spec.GitProvider.Secret == nil && p.globalRepo.Spec.GitProvider != nil && p.globalRepo.Spec.GitProvider.Secret != nil {
 		secretNS = p.globalRepo.GetNamespace()
@@ -69,35 +125,14 @@ func (p *PacRun) verifyRepoAndUser(ctx context.Context) (*v1alpha1.Repository, e
 	p.logger = p.logger.With("namespace", repo.Namespace)
 	p.vcx.SetLogger(p.logger)
 	p.eventEmitter.SetLogger(p.logger)
-	// If we have a git_provider field in repository spec, then get all the
-	// information from there, including the webhook secret.
-	// otherwise get the secret from the current ns (i.e: pipelines-as-code/openshift-pipelines.)
-	//
-	// TODO: there is going to be some improvements later we may want to do if
-	// they are use cases for it :
-	// allow webhook providers users to have a global webhook secret to be used,
-	// so instead of having to specify their in Repo each time, they use a
-	// shared one from pac.
-	if p.event.InstallationID > 0 {
-		p.event.Provider.WebhookSecret, _ = GetCurrentNSWebhookSecret(ctx, p.k8int, p.run)
-	} else {
-		scm := SecretFromRepository{
-			K8int:       p.k8int,
-			Config:      p.vcx.GetConfig(),
-			Event:       p.event,
-			Repo:        repo,
-			WebhookType: p.pacInfo.WebhookType,
-			Logger:      p.logger,
-			Namespace:   secretNS,
-		}
-		if err := scm.Get(ctx); err != nil {
-			return repo, fmt.Errorf("cannot get secret from repository: %w", err)
-		}
+	if err := p.getProviderSecrets(ctx, repo, secretNS); err != nil {
+		return repo, err
 	}
 
 	// validate payload  for webhook secret
-	// we don't need to validate it in incoming since we already do this
-	if p.event.EventType != "incoming" {
+	// we don't need to validate it in incoming since we already do this, the
+	// polled events have no payload
+	if p.event.EventType != "incoming" && !p.event.Polled {
 		if err := p.vcx.Validate(ctx, p.run, p.event); err != nil {
 			// check that webhook secret has no /n or space into it
 			if strings.ContainsAny(p.event.Provider.WebhookSecret, "\n ") {
@@ -108,6 +143,15 @@ is that what you want? make sure you use -n when generating the secret, eg: echo
 			}
 			return repo, fmt.Errorf("could not validate payload, check your webhook secret?: %w", err)
 		}
+		if p.payloadValidated != nil {
+			p.payloadValidated()
+		}
+	}
+
+	if orgRepo != nil {
+		if repo, err = p.createOrgMatchedRepository(ctx, orgRepo, repo); repo == nil || err != nil {
+			return nil, err
+		}
 	}
 
 	// Set the client, we should error out if there is a problem with
@@ -156,7 +200,7 @@ is that what you want? make sure you use -n when generating the secret, eg: echo
 		status := provider.StatusOpts{
 			Status:       queuedStatus,
 			Title:        "Pending approval, waiting for an /ok-to-test",
-			Conclusion:   pendingConclusion,
+			Conclusion:   actionRequiredConclusion,
 			DetailsURL:   p.event.URL,
 			AccessDenied: true,
 		}
@@ -167,37 +211,98 @@ is that what you want? make sure you use -n when generating the secret, eg: echo
 	return repo, nil
 }
 
+// getProviderSecrets sets the token and the webhook secret of the event.
+// If we have a git_provider field in repository spec, then get all the
+// information from there, including the webhook secret.
+// otherwise get the secret from the current ns (i.e: pipelines-as-code/openshift-pipelines.)
+//
+// The webhooks of a Bitbucket Data Center project are delivered for all its
+// repositories with the same secret, with the bitbucket-datacenter-project-webhook
+// setting the Repositories without a webhook secret use the one of the
+// current ns, while still having their own token.
+func (p *PacRun) getProviderSecrets(ctx context.Context, repo *v1alpha1.Repository, secretNS string) error {
+	if p.event.InstallationID > 0 {
+		p.event.Provider.WebhookSecret, _ = GetCurrentNSWebhookSecret(ctx, p.k8int, p.run)
+		return nil
+	}
+	scm := SecretFromRepository{
+		K8int:       p.k8int,
+		Config:      p.vcx.GetConfig(),
+		Event:       p.event,
+		Repo:        repo,
+		WebhookType: p.pacInfo.WebhookType,
+		Logger:      p.logger,
+		Namespace:   secretNS,
+	}
+	if err := scm.Get(ctx); err != nil {
+		return fmt.Errorf("cannot get secret from repository: %w", err)
+	}
+	if p.pacInfo.BitbucketDataCenterProjectWebhook && p.vcx.GetConfig().Name == "bitbucket-datacenter" &&
+		repo.Spec.GitProvider.WebhookSecret == nil {
+		webhookSecret, err := GetCurrentNSWebhookSecret(ctx, p.k8int, p.run)
+		if err != nil {
+			return fmt.Errorf("cannot get the webhook secret of the bitbucket data center project: %w", err)
+		}
+		p.event.Provider.WebhookSecret = webhookSecret
+	}
+	return nil
+}
+
 // getPipelineRunsFromRepo fetches pipelineruns from git repository and prepare them for creation.
 func (p *PacRun) getPipelineRunsFromRepo(ctx context.Context, repo *v1alpha1.Repository) ([]matcher.Match, error) {
 	provenance := "source"
 	if repo.Spec.Settings != nil && repo.Spec.Settings.PipelineRunProvenance != "" {
 		provenance = repo.Spec.Settings.PipelineRunProvenance
 	}
-	rawTemplates, err := p.vcx.GetTektonDir(ctx, p.event, tektonDir, provenance)
-	if err != nil && p.event.TriggerTarget == triggertype.PullRequest && strings.Contains(err.Error(), "error unmarshalling yaml file") {
+	// the event the PipelineRun definitions are read for
+	tektonEvent := p.event
+	if repo.Spec.GetTektonChangesPolicy() == v1alpha1.TektonChangesPolicyTargetBranch {
+		guarded, err := p.isGuardedTektonChange(ctx, repo)
+		if err != nil {
+			return nil, err
+		}
+		if guarded {
+			p.eventEmitter.EmitMessage(repo, zap.InfoLevel, "RepositoryTektonChangesGuarded",
+				fmt.Sprintf("the %s directory has been changed by %s who is not an owner, using the PipelineRuns of the target branch %s", tektonDir, p.event.Sender, p.event.BaseBranch))
+			tektonEvent = info.NewEvent()
+			p.event.DeepCopyInto(tektonEvent)
+			tektonEvent.DefaultBranch = p.event.BaseBranch
+			provenance = "default_branch"
+		}
+	}
+	var rawTemplates string
+	var err error
+	if repo.Spec.GitProvider != nil && repo.Spec.GitProvider.SSH != nil {
+		rawTemplates, err = p.getTektonDirOverSSH(ctx, repo, tektonDir, provenance)
+	} else {
+		rawTemplates, err = p.vcx.GetTektonDir(ctx, tektonEvent, tektonDir, provenance)
+	}
+	var yamlErr *pacerrors.YamlError
+	if errors.As(err, &yamlErr) && p.event.TriggerTarget == triggertype.PullRequest {
 		// make the error a bit more friendly for users who don't know what marshalling or intricacies of the yaml parser works
-		// format is "error unmarshalling yaml file pr-bad-format.yaml: yaml: line 3: could not find expected ':'"
-		// get the filename with a regexp
-		reg := regexp.MustCompile(`error unmarshalling yaml file\s([^:]*):\s*(yaml:\s*)?(.*)`)
-		matches := reg.FindStringSubmatch(err.Error())
-		if len(matches) == 4 {
-			p.reportValidationErrors(ctx, repo,
-				[]*pacerrors.PacYamlValidations{
-					{
-						Name:   matches[1],
-						Err:    fmt.Errorf("yaml validation error: %s", matches[3]),
-						Schema: pacerrors.GenericBadYAMLValidation,
-					},
+		// error is "yaml: line 3: could not find expected ':'"
+		p.reportValidationErrors(ctx, repo,
+			[]*pacerrors.PacYamlValidations{
+				{
+					Name:     yamlErr.Position.File,
+					Err:      fmt.Errorf("yaml validation error: %s", strings.TrimPrefix(yamlErr.Err.Error(), "yaml: ")),
+					Schema:   pacerrors.GenericBadYAMLValidation,
+					Position: yamlErr.Position,
 				},
-			)
-			return nil, nil
-		}
+			},
+		)
+		return nil, nil
+	}
 
-		return nil, err
+	if err == nil && rawTemplates == "" {
+		if rawTemplates, err = p.getDefaultPipelines(ctx); err != nil {
+			p.eventEmitter.EmitMessage(repo, zap.ErrorLevel, "RepositoryDefaultPipelines", err.Error())
+			return nil, err
+		}
 	}
 
 	if rawTemplates == "" && p.event.EventType == opscomments.OkToTestCommentEventType.String() {
-		err = p.createNeutralStatus(ctx, ".tekton directory not found", tektonDirMissingError)
+		err = p.createCompletedStatus(ctx, neutralConclusion, ".tekton directory not found", tektonDirMissingError)
 		if err != nil {
 			p.eventEmitter.EmitMessage(nil, zap.ErrorLevel, "RepositoryCreateStatus", err.Error())
 		}
@@ -252,6 +357,11 @@ func (p *PacRun) getPipelineRunsFromRepo(ctx context.Context, repo *v1alpha1.Rep
 		}
 		// Don't fail or do anything if we don't have a match yet, we will do it properly later in this function
 		_, _ = matcher.MatchPipelinerunByAnnotation(ctx, p.logger, rtypes.PipelineRuns, p.run, p.event, p.vcx, p.eventEmitter, repo)
+
+		if err := p.applyManualInputs(rtypes.PipelineRuns); err != nil {
+			p.eventEmitter.EmitMessage(repo, zap.ErrorLevel, "RepositoryInvalidManualInputs", err.Error())
+			return nil, err
+		}
 	}
 	// Replace those {{var}} placeholders user has in her template to the run.Info variable
 	allTemplates := p.makeTemplate(ctx, repo, rawTemplates)
@@ -264,6 +374,9 @@ func (p *PacRun) getPipelineRunsFromRepo(ctx context.Context, repo *v1alpha1.Rep
 	if len(types.ValidationErrors) > 0 && p.event.TriggerTarget == triggertype.PullRequest {
 		p.reportValidationErrors(ctx, repo, types.ValidationErrors)
 	}
+	if len(types.Suggestions) > 0 && p.event.TriggerTarget == triggertype.PullRequest {
+		p.reportSuggestions(ctx, repo, types.Suggestions)
+	}
 	pipelineRuns := types.PipelineRuns
 	if len(pipelineRuns) == 0 {
 		msg := fmt.Sprintf("cannot locate valid templates in %s/ directory for this repository in %s", tektonDir, p.event.HeadBranch)
@@ -272,26 +385,29 @@ func (p *PacRun) getPipelineRunsFromRepo(ctx context.Context, repo *v1alpha1.Rep
 	}
 	pipelineRuns, err = resolve.MetadataResolve(pipelineRuns)
 	if err != nil && len(pipelineRuns) == 0 {
-		p.eventEmitter.EmitMessage(repo, zap.ErrorLevel, "FailedToResolvePipelineRunMetadata", err.Error())
+		p.eventEmitter.EmitFailure(repo, zap.ErrorLevel, v1alpha1.FailureReasonYAMLInvalid, "FailedToResolvePipelineRunMetadata", err.Error())
 		return nil, err
 	}
 
 	// Match the PipelineRun with annotation
 	var matchedPRs []matcher.Match
 	if p.event.TargetTestPipelineRun == "" {
-		if matchedPRs, err = matcher.MatchPipelinerunByAnnotation(ctx, p.logger, pipelineRuns, p.run, p.event, p.vcx, p.eventEmitter, repo); err != nil {
+		var skippedPRs []matcher.Skipped
+		matchedPRs, skippedPRs, err = matcher.MatchPipelinerunByAnnotationWithSkipped(ctx, p.logger, pipelineRuns, p.run, p.event, p.vcx, p.eventEmitter, repo)
+		p.reportSkippedPipelineRuns(ctx, repo, skippedPRs)
+		if err != nil {
 			// Don't fail when you don't have a match between pipeline and annotations
-			p.eventEmitter.EmitMessage(nil, zap.WarnLevel, "RepositoryNoMatch", err.Error())
+			p.eventEmitter.EmitMessage(repo, zap.WarnLevel, "RepositoryNoMatch", err.Error())
 			// In a scenario where an external user submits a pull request and the repository owner uses the
 			// GitOps command `/ok-to-test` to trigger CI, but no matching pull request is found,
 			// a neutral check-run will be created on the pull request to indicate that no PipelineRun was triggered
 			if p.event.EventType == opscomments.OkToTestCommentEventType.String() && len(matchedPRs) == 0 {
 				text := fmt.Sprintf("No matching PipelineRun found for the '%s' event in .tekton/ directory. Please ensure that PipelineRun is configured for '%s' event.", p.event.TriggerTarget.String(), p.event.TriggerTarget.String())
-				err = p.createNeutralStatus(ctx, "No PipelineRun matched", text)
+				err = p.createCompletedStatus(ctx, skippedConclusion, "No PipelineRun matched", text)
 				if err != nil {
 					p.eventEmitter.EmitMessage(nil, zap.WarnLevel, "RepositoryCreateStatus", err.Error())
 				}
-				p.eventEmitter.EmitMessage(nil, zap.InfoLevel, "RepositoryNoMatch", text)
+				p.eventEmitter.EmitMessage(repo, zap.InfoLevel, "RepositoryNoMatch", text)
 			}
 			return nil, nil
 		}
@@ -303,7 +419,7 @@ func (p *PacRun) getPipelineRunsFromRepo(ctx context.Context, repo *v1alpha1.Rep
 		status := provider.StatusOpts{
 			Status:       queuedStatus,
 			Title:        "Pending approval, waiting for an /ok-to-test",
-			Conclusion:   pendingConclusion,
+			Conclusion:   actionRequiredConclusion,
 			DetailsURL:   p.event.URL,
 			AccessDenied: true,
 		}
@@ -345,14 +461,23 @@ func (p *PacRun) getPipelineRunsFromRepo(ctx context.Context, repo *v1alpha1.Rep
 				}
 			}
 		}
-		pipelineRuns, err = resolve.Resolve(ctx, p.run, p.logger, p.vcx, types, p.event, &resolve.Opts{
-			GenerateName: true,
-			RemoteTasks:  true,
-		})
+		var lock *lockfile.Lock
+		ropt := &resolve.Opts{GenerateName: true, RemoteTasks: true}
+		if lock, err = p.getLock(ctx, types.PipelineRuns); err == nil {
+			ropt.Lock = lock
+			pipelineRuns, err = resolve.Resolve(ctx, p.run, p.logger, p.vcx, types, tektonEvent, ropt)
+		}
 		if err != nil {
-			p.eventEmitter.EmitMessage(repo, zap.ErrorLevel, "RepositoryFailedToMatch", fmt.Sprintf("failed to match pipelineRuns: %s", err.Error()))
+			p.eventEmitter.EmitFailure(repo, zap.ErrorLevel, v1alpha1.FailureReasonResolverError, "RepositoryFailedToMatch", fmt.Sprintf("failed to match pipelineRuns: %s", err.Error()))
 			return nil, err
 		}
+		if len(ropt.ReferenceErrors) > 0 && p.event.TriggerTarget == triggertype.PullRequest {
+			p.reportValidationErrors(ctx, repo, ropt.ReferenceErrors)
+		}
+	}
+
+	if p.scanSecrets(ctx, repo, pipelineRuns) {
+		return nil, nil
 	}
 
 	err = p.changePipelineRun(ctx, repo, pipelineRuns)
@@ -372,13 +497,35 @@ func (p *PacRun) getPipelineRunsFromRepo(ctx context.Context, repo *v1alpha1.Rep
 	matchedPRs, err = matcher.MatchPipelinerunByAnnotation(ctx, p.logger, pipelineRuns, p.run, p.event, p.vcx, p.eventEmitter, repo)
 	if err != nil {
 		// Don't fail when you don't have a match between pipeline and annotations
-		p.eventEmitter.EmitMessage(nil, zap.WarnLevel, "RepositoryNoMatch", err.Error())
+		p.eventEmitter.EmitMessage(repo, zap.WarnLevel, "RepositoryNoMatch", err.Error())
 		return nil, nil
 	}
 
 	return matchedPRs, nil
 }
 
+// inheritOrgRepository makes repo inherit the settings of the organization
+// level Repository of its namespace, if there is one. The settings of repo
+// take precedence over the organization ones, which take precedence over the
+// global Repository ones.
+func (p *PacRun) inheritOrgRepository(ctx context.Context, repo *v1alpha1.Repository) error {
+	repositories, err := p.run.Clients.PipelineAsCode.PipelinesascodeV1alpha1().Repositories(repo.GetNamespace()).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return fmt.Errorf("error listing Repositories in namespace %s: %w", repo.GetNamespace(), err)
+	}
+	candidates := make([]*v1alpha1.Repository, 0, len(repositories.Items))
+	for i := range repositories.Items {
+		candidates = append(candidates, &repositories.Items[i])
+	}
+	p.orgRepo = matcher.MatchOrgRepository(repo.Spec.URL, candidates)
+	if p.orgRepo == nil {
+		return nil
+	}
+	p.logger.Infof("repository %s inherits from the organization repository %s", repo.GetName(), p.orgRepo.GetName())
+	repo.Spec.Inherit(p.orgRepo.Spec)
+	return nil
+}
+
 func filterRunningPipelineRunOnTargetTest(testPipeline string, prs []*tektonv1.PipelineRun) *tektonv1.PipelineRun {
 	for _, pr := range prs {
 		if prName, ok := pr.GetAnnotations()[apipac.OriginalPRName]; ok {
@@ -393,6 +540,7 @@ func filterRunningPipelineRunOnTargetTest(testPipeline string, prs []*tektonv1.P
 // changePipelineRun go over each pipelineruns and modify things into it.
 //
 // - the secret template variable with a random one as generated from GetBasicAuthSecretName
+// - the sparse checkout template variable with the directories of the sparse-checkout annotation
 // - the template variable with the one from the event (this includes the remote pipeline that has template variables).
 func (p *PacRun) changePipelineRun(ctx context.Context, repo *v1alpha1.Repository, prs []*tektonv1.PipelineRun) error {
 	for k, pr := range prs {
@@ -404,6 +552,7 @@ func (p *PacRun) changePipelineRun(ctx context.Context, repo *v1alpha1.Repositor
 		name := secrets.GenerateBasicAuthSecretName()
 		processed := templates.ReplacePlaceHoldersVariables(string(b), map[string]string{
 			"git_auth_secret": name,
+			"sparse_checkout": sparseCheckoutDirectories(pr.GetAnnotations()[apipac.SparseCheckout]),
 		}, nil, nil, map[string]any{})
 		processed = p.makeTemplate(ctx, repo, processed)
 
@@ -435,12 +584,46 @@ func (p *PacRun) checkNeedUpdate(_ string) (string, bool) {
 	return "", false
 }
 
-func (p *PacRun) createNeutralStatus(ctx context.Context, title, text string) error {
+// reportSkippedPipelineRuns creates a skipped status for every PipelineRun
+// that didn't match the event with the reason why, when the Repository has
+// the report_skipped_pipelineruns setting enabled.
+func (p *PacRun) reportSkippedPipelineRuns(ctx context.Context, repo *v1alpha1.Repository, skippedPRs []matcher.Skipped) {
+	if repo.Spec.Settings == nil || !repo.Spec.Settings.ReportSkippedPipelineRuns {
+		return
+	}
+	if p.event.TriggerTarget != triggertype.PullRequest && p.event.TriggerTarget != triggertype.Push {
+		return
+	}
+	if opscomments.IsAnyOpsEventType(p.event.EventType) || p.event.EventType == opscomments.NoOpsCommentEventType.String() {
+		return
+	}
+	for _, skipped := range skippedPRs {
+		name := strings.TrimSuffix(skipped.PipelineRun.GetGenerateName(), "-")
+		if name == "" {
+			name = skipped.PipelineRun.GetName()
+		}
+		status := provider.StatusOpts{
+			Status:                  CompletedStatus,
+			Title:                   "Skipped",
+			Text:                    fmt.Sprintf("PipelineRun %s has been skipped: %s.", name, skipped.Reason),
+			Conclusion:              skippedConclusion,
+			DetailsURL:              p.event.URL,
+			PipelineRun:             skipped.PipelineRun,
+			OriginalPipelineRunName: name,
+		}
+		if err := p.vcx.CreateStatus(ctx, p.event, status); err != nil {
+			p.eventEmitter.EmitFailure(repo, zap.WarnLevel, v1alpha1.FailureReasonProviderAPIError, "RepositoryCreateStatus",
+				fmt.Sprintf("cannot report skipped pipelinerun %s: %s", name, err.Error()))
+		}
+	}
+}
+
+func (p *PacRun) createCompletedStatus(ctx context.Context, conclusion, title, text string) error {
 	status := provider.StatusOpts{
 		Status:     CompletedStatus,
 		Title:      title,
 		Text:       text,
-		Conclusion: neutralConclusion,
+		Conclusion: conclusion,
 		DetailsURL: p.event.URL,
 	}
 	if err := p.vcx.CreateStatus(ctx, p.event, status); err != nil {
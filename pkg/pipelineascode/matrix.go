@@ -0,0 +1,171 @@
+package pipelineascode
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/apis/pipelinesascode/keys"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/formatting"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/matcher"
+	tektonv1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+)
+
+// maxMatrixCells is the maximum number of PipelineRuns a matrix can fan out to.
+const maxMatrixCells = 64
+
+var (
+	matrixPlaceholderRe = regexp.MustCompile(`{{\s*matrix\.([^}\s]+)\s*}}`)
+	matrixInvalidNameRe = regexp.MustCompile(`[^a-z0-9.-]+`)
+)
+
+type matrixCell struct {
+	keys   []string
+	values map[string]string
+}
+
+// suffix returns the values of the cell in a form usable in a PipelineRun name.
+func (c matrixCell) suffix() string {
+	parts := make([]string, 0, len(c.keys))
+	for _, key := range c.keys {
+		parts = append(parts, strings.Trim(matrixInvalidNameRe.ReplaceAllString(strings.ToLower(c.values[key]), "-"), "-."))
+	}
+	return strings.Join(parts, "-")
+}
+
+func (c matrixCell) String() string {
+	parts := make([]string, 0, len(c.keys))
+	for _, key := range c.keys {
+		parts = append(parts, fmt.Sprintf("%s=%s", key, c.values[key]))
+	}
+	return strings.Join(parts, ", ")
+}
+
+// parseMatrix returns all the combinations of the matrix annotation, ordered
+// by the sorted keys of the matrix.
+func parseMatrix(annotation string) ([]matrixCell, error) {
+	matrix := map[string][]string{}
+	if err := json.Unmarshal([]byte(annotation), &matrix); err != nil {
+		return nil, fmt.Errorf("cannot parse the %s annotation: %w", keys.Matrix, err)
+	}
+	if len(matrix) == 0 {
+		return nil, fmt.Errorf("the %s annotation has no values", keys.Matrix)
+	}
+	matrixKeys := make([]string, 0, len(matrix))
+	total := 1
+	for key, values := range matrix {
+		if len(values) == 0 {
+			return nil, fmt.Errorf("the %s annotation has no values for %s", keys.Matrix, key)
+		}
+		matrixKeys = append(matrixKeys, key)
+		total *= len(values)
+		if total > maxMatrixCells {
+			return nil, fmt.Errorf("the %s annotation has more than %d combinations", keys.Matrix, maxMatrixCells)
+		}
+	}
+	sort.Strings(matrixKeys)
+
+	cells := []matrixCell{{keys: matrixKeys, values: map[string]string{}}}
+	for _, key := range matrixKeys {
+		expanded := make([]matrixCell, 0, len(cells)*len(matrix[key]))
+		for _, cell := range cells {
+			for _, value := range matrix[key] {
+				values := make(map[string]string, len(cell.values)+1)
+				for k, v := range cell.values {
+					values[k] = v
+				}
+				values[key] = value
+				expanded = append(expanded, matrixCell{keys: matrixKeys, values: values})
+			}
+		}
+		cells = expanded
+	}
+	return cells, nil
+}
+
+// newMatrixPipelineRun returns a copy of pr for the cell with the {{ matrix.key }}
+// placeholders replaced by the values of the cell.
+func newMatrixPipelineRun(pr *tektonv1.PipelineRun, cell matrixCell) (*tektonv1.PipelineRun, error) {
+	originalName := pr.GetAnnotations()[keys.OriginalPRName]
+	if originalName == "" {
+		originalName = strings.TrimSuffix(pr.GetGenerateName(), "-")
+		if pr.GetName() != "" {
+			originalName = pr.GetName()
+		}
+	}
+	raw, err := json.Marshal(pr)
+	if err != nil {
+		return nil, err
+	}
+	var replaceErr error
+	replaced := matrixPlaceholderRe.ReplaceAllStringFunc(string(raw), func(s string) string {
+		key := matrixPlaceholderRe.FindStringSubmatch(s)[1]
+		value, ok := cell.values[key]
+		if !ok {
+			replaceErr = fmt.Errorf("pipelinerun %s references the unknown matrix key %s", originalName, key)
+			return s
+		}
+		// the value ends up inside a json string and needs to be escaped
+		escaped, _ := json.Marshal(value)
+		return strings.Trim(string(escaped), `"`)
+	})
+	if replaceErr != nil {
+		return nil, replaceErr
+	}
+	cellPR := &tektonv1.PipelineRun{}
+	if err := json.Unmarshal([]byte(replaced), cellPR); err != nil {
+		return nil, err
+	}
+
+	suffix := cell.suffix()
+	if cellPR.GetName() != "" {
+		cellPR.SetName(fmt.Sprintf("%s-%s", cellPR.GetName(), suffix))
+	}
+	if cellPR.GetGenerateName() != "" {
+		cellPR.SetGenerateName(fmt.Sprintf("%s-%s-", strings.TrimSuffix(cellPR.GetGenerateName(), "-"), suffix))
+	}
+	cellName := fmt.Sprintf("%s-%s", originalName, suffix)
+
+	if cellPR.Annotations == nil {
+		cellPR.Annotations = map[string]string{}
+	}
+	if cellPR.Labels == nil {
+		cellPR.Labels = map[string]string{}
+	}
+	delete(cellPR.Annotations, keys.Matrix)
+	cellPR.Annotations[keys.OriginalPRName] = cellName
+	cellPR.Annotations[keys.MatrixParent] = originalName
+	cellPR.Annotations[keys.MatrixCell] = cell.String()
+	cellPR.Labels[keys.OriginalPRName] = formatting.CleanValueKubernetes(cellName)
+	cellPR.Labels[keys.MatrixParent] = formatting.CleanValueKubernetes(originalName)
+	return cellPR, nil
+}
+
+// expandMatrix fans out the matched PipelineRuns having a matrix annotation
+// into one PipelineRun per combination of the matrix.
+func expandMatrix(matches []matcher.Match) ([]matcher.Match, error) {
+	ret := make([]matcher.Match, 0, len(matches))
+	for _, match := range matches {
+		annotation, ok := match.PipelineRun.GetAnnotations()[keys.Matrix]
+		if !ok {
+			ret = append(ret, match)
+			continue
+		}
+		cells, err := parseMatrix(annotation)
+		if err != nil {
+			return nil, fmt.Errorf("pipelinerun %s: %w", match.PipelineRun.GetGenerateName()+match.PipelineRun.GetName(), err)
+		}
+		for _, cell := range cells {
+			cellPR, err := newMatrixPipelineRun(match.PipelineRun, cell)
+			if err != nil {
+				return nil, err
+			}
+			cellMatch := match
+			cellMatch.PipelineRun = cellPR
+			ret = append(ret, cellMatch)
+		}
+	}
+	return ret, nil
+}
@@ -0,0 +1,102 @@
+package pipelineascode
+
+import (
+	"testing"
+
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/apis/pipelinesascode/keys"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/matcher"
+	tektonv1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+	"gotest.tools/v3/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestExpandMatrix(t *testing.T) {
+	makePR := func(generateName, matrix string) *tektonv1.PipelineRun {
+		annotations := map[string]string{keys.OriginalPRName: "build"}
+		if matrix != "" {
+			annotations[keys.Matrix] = matrix
+		}
+		return &tektonv1.PipelineRun{
+			ObjectMeta: metav1.ObjectMeta{
+				GenerateName: generateName,
+				Annotations:  annotations,
+				Labels:       map[string]string{keys.OriginalPRName: "build"},
+			},
+			Spec: tektonv1.PipelineRunSpec{
+				Params: []tektonv1.Param{
+					{Name: "go", Value: *tektonv1.NewStructuredValues("{{ matrix.go }}")},
+					{Name: "os", Value: *tektonv1.NewStructuredValues("{{matrix.os}}")},
+				},
+			},
+		}
+	}
+
+	tests := []struct {
+		name      string
+		matches   []matcher.Match
+		wantNames []string
+		wantCells []string
+		wantGo    []string
+		wantErr   string
+	}{
+		{
+			name:      "no matrix",
+			matches:   []matcher.Match{{PipelineRun: makePR("build-", "")}},
+			wantNames: []string{"build-"},
+			wantCells: []string{""},
+			wantGo:    []string{"{{ matrix.go }}"},
+		},
+		{
+			name:      "fan out all combinations",
+			matches:   []matcher.Match{{PipelineRun: makePR("build-", `{"os": ["linux", "darwin"], "go": ["1.21", "1.22"]}`)}},
+			wantNames: []string{"build-1.21-linux-", "build-1.21-darwin-", "build-1.22-linux-", "build-1.22-darwin-"},
+			wantCells: []string{"go=1.21, os=linux", "go=1.21, os=darwin", "go=1.22, os=linux", "go=1.22, os=darwin"},
+			wantGo:    []string{"1.21", "1.21", "1.22", "1.22"},
+		},
+		{
+			name:    "unknown key",
+			matches: []matcher.Match{{PipelineRun: makePR("build-", `{"go": ["1.21"]}`)}},
+			wantErr: "pipelinerun build references the unknown matrix key os",
+		},
+		{
+			name:    "invalid annotation",
+			matches: []matcher.Match{{PipelineRun: makePR("build-", `["1.21"]`)}},
+			wantErr: "pipelinerun build-: cannot parse the pipelinesascode.tekton.dev/matrix annotation: json: cannot unmarshal array into Go value of type map[string][]string",
+		},
+		{
+			name:    "empty values",
+			matches: []matcher.Match{{PipelineRun: makePR("build-", `{"go": []}`)}},
+			wantErr: "pipelinerun build-: the pipelinesascode.tekton.dev/matrix annotation has no values for go",
+		},
+		{
+			name:    "too many combinations",
+			matches: []matcher.Match{{PipelineRun: makePR("build-", `{"a": ["1","2","3","4","5","6","7","8"], "b": ["1","2","3","4","5","6","7","8","9"]}`)}},
+			wantErr: "pipelinerun build-: the pipelinesascode.tekton.dev/matrix annotation has more than 64 combinations",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := expandMatrix(tt.matches)
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			assert.NilError(t, err)
+			assert.Equal(t, len(got), len(tt.wantNames))
+			for i, match := range got {
+				pr := match.PipelineRun
+				assert.Equal(t, pr.GetGenerateName(), tt.wantNames[i])
+				assert.Equal(t, pr.GetAnnotations()[keys.MatrixCell], tt.wantCells[i])
+				assert.Equal(t, pr.Spec.Params[0].Value.StringVal, tt.wantGo[i])
+				if tt.wantCells[i] == "" {
+					continue
+				}
+				_, hasMatrix := pr.GetAnnotations()[keys.Matrix]
+				assert.Assert(t, !hasMatrix)
+				assert.Equal(t, pr.GetAnnotations()[keys.MatrixParent], "build")
+				assert.Equal(t, pr.GetLabels()[keys.MatrixParent], "build")
+				assert.Equal(t, pr.GetAnnotations()[keys.OriginalPRName], "build-"+tt.wantNames[i][len("build-"):len(tt.wantNames[i])-1])
+			}
+		})
+	}
+}
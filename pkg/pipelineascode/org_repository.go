@@ -0,0 +1,122 @@
+package pipelineascode
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/apis/pipelinesascode/keys"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/apis/pipelinesascode/v1alpha1"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/formatting"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/templates"
+	"go.uber.org/zap"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// namespacePolicyCreate is the namespace policy of the organization
+// Repositories allowing to create the namespaces of their Repositories.
+const namespacePolicyCreate = "create"
+
+// newOrgMatchedRepository returns the Repository of the event repository
+// matched by the organization Repository. It is in the namespace of the
+// organization Repository until it is created, for the payload of the event
+// to be validated with the webhook secret of the organization first.
+func newOrgMatchedRepository(orgRepo *v1alpha1.Repository, repoURL string) (*v1alpha1.Repository, error) {
+	repoURL = formatting.CanonicalRepoURL(repoURL)
+	_, repoName, err := formatting.GetRepoOwnerSplitted(repoURL)
+	if err != nil {
+		return nil, err
+	}
+	return &v1alpha1.Repository{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        formatting.CleanKubernetesName(repoName),
+			Namespace:   orgRepo.GetNamespace(),
+			Annotations: map[string]string{keys.OrgRepository: orgRepo.GetNamespace() + "/" + orgRepo.GetName()},
+		},
+		Spec: v1alpha1.RepositorySpec{URL: repoURL},
+	}, nil
+}
+
+// orgRepositoryNamespace returns the namespace of the Repository from the
+// namespace template of the organization Repository.
+func orgRepositoryNamespace(orgRepo *v1alpha1.Repository, repoURL string) (string, error) {
+	if orgRepo.Spec.Organization.NamespaceTemplate == "" {
+		return orgRepo.GetNamespace(), nil
+	}
+	repoOwner, repoName, err := formatting.GetRepoOwnerSplitted(repoURL)
+	if err != nil {
+		return "", err
+	}
+	placeholders := map[string]string{
+		"repo_owner": repoOwner,
+		"repo_name":  repoName,
+	}
+	ns := templates.ReplacePlaceHoldersVariables(orgRepo.Spec.Organization.NamespaceTemplate, placeholders, nil, http.Header{}, map[string]any{})
+	return formatting.CleanKubernetesName(ns), nil
+}
+
+// createOrgMatchedRepository creates the Repository matched by the
+// organization Repository, in the namespace of its template. It returns nil
+// when the namespace doesn't exist and the namespace policy doesn't allow to
+// create it. A Repository in another namespace than the organization
+// Repository doesn't inherit from it.
+func (p *PacRun) createOrgMatchedRepository(ctx context.Context, orgRepo, repo *v1alpha1.Repository) (*v1alpha1.Repository, error) {
+	ns, err := orgRepositoryNamespace(orgRepo, repo.Spec.URL)
+	if err != nil {
+		return nil, fmt.Errorf("cannot generate the namespace of the repository %s: %w", repo.Spec.URL, err)
+	}
+
+	if ns != orgRepo.GetNamespace() {
+		if _, err := p.run.Clients.Kube.CoreV1().Namespaces().Get(ctx, ns, metav1.GetOptions{}); err != nil {
+			if !errors.IsNotFound(err) {
+				return nil, fmt.Errorf("cannot get namespace %s: %w", ns, err)
+			}
+			if orgRepo.Spec.Organization.NamespacePolicy != namespacePolicyCreate {
+				msg := fmt.Sprintf("namespace %s of repository %s doesn't exist, the organization repository %s/%s doesn't allow to create it",
+					ns, repo.Spec.URL, orgRepo.GetNamespace(), orgRepo.GetName())
+				p.eventEmitter.EmitMessage(orgRepo, zap.WarnLevel, "RepositoryNamespaceMissing", msg)
+				return nil, nil
+			}
+			namespace := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: ns}}
+			if _, err := p.run.Clients.Kube.CoreV1().Namespaces().Create(ctx, namespace, metav1.CreateOptions{}); err != nil && !errors.IsAlreadyExists(err) {
+				return nil, fmt.Errorf("cannot create namespace %s: %w", ns, err)
+			}
+		}
+	}
+
+	toCreate := &v1alpha1.Repository{
+		ObjectMeta: metav1.ObjectMeta{Name: repo.GetName(), Namespace: ns, Annotations: repo.GetAnnotations()},
+		Spec:       v1alpha1.RepositorySpec{URL: repo.Spec.URL},
+	}
+	repositories := p.run.Clients.PipelineAsCode.PipelinesascodeV1alpha1().Repositories(ns)
+	created, err := repositories.Create(ctx, toCreate, metav1.CreateOptions{})
+	if errors.IsAlreadyExists(err) {
+		// another event of the repository has created it first
+		created, err = repositories.Get(ctx, toCreate.GetName(), metav1.GetOptions{})
+		if err == nil && formatting.NormalizeRepoURL(created.Spec.URL) != formatting.NormalizeRepoURL(repo.Spec.URL) {
+			err = fmt.Errorf("repository %s/%s already exists with the URL %s", ns, created.GetName(), created.Spec.URL)
+		}
+	}
+	if err != nil {
+		return nil, fmt.Errorf("cannot create the repository of %s: %w", repo.Spec.URL, err)
+	}
+	msg := fmt.Sprintf("created repository %s/%s for %s from the organization repository %s/%s",
+		created.GetNamespace(), created.GetName(), created.Spec.URL, orgRepo.GetNamespace(), orgRepo.GetName())
+	p.eventEmitter.EmitMessage(created, zap.InfoLevel, "RepositoryCreated", msg)
+
+	if ns == orgRepo.GetNamespace() {
+		// the spec has already inherited from the organization Repository
+		repo.ObjectMeta = created.ObjectMeta
+		return repo, nil
+	}
+	p.orgRepo = nil
+	if p.globalRepo != nil {
+		created.Spec.Merge(p.globalRepo.Spec)
+	}
+	p.logger = p.logger.With("namespace", created.GetNamespace())
+	p.vcx.SetLogger(p.logger)
+	p.eventEmitter.SetLogger(p.logger)
+	return created, nil
+}
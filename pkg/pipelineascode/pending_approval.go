@@ -0,0 +1,106 @@
+package pipelineascode
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"strings"
+	"text/template"
+
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/acl"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/apis/pipelinesascode/v1alpha1"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/opscomments"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/params/triggertype"
+	"go.uber.org/zap"
+)
+
+// pendingApprovalMarker is hidden in the comment to update it on the next
+// pushes instead of posting a new one.
+const pendingApprovalMarker = "<!-- pipelines-as-code: pending approval -->"
+
+const pendingApprovalTemplate = `> [!NOTE]
+> CI has not been run on this pull request from {{ .Sender }}, it needs to be approved first.
+
+A maintainer of this repository needs to comment ` + "`{{ .Command }}`" + ` to run CI on this pull request.
+{{- if .Approvers }}
+
+The users allowed to approve it are: {{ .Approvers }}.
+{{- end }}`
+
+type pendingApprovalData struct {
+	Sender    string
+	Approvers string
+	Command   string
+}
+
+// postPendingApprovalComment posts a comment explaining how to approve
+// running CI on the pull request, unless it has been disabled on the
+// repository. The comment is only posted on the pull request events, not on
+// the GitOps comments of the users not allowed to run CI.
+func (p *PacRun) postPendingApprovalComment(ctx context.Context, repo *v1alpha1.Repository) {
+	if p.event.TriggerTarget != triggertype.PullRequest || p.event.PullRequestNumber == 0 || opscomments.IsAnyOpsEventType(p.event.EventType) {
+		return
+	}
+	var settings *v1alpha1.PendingApprovalComment
+	if repo.Spec.Settings != nil {
+		settings = repo.Spec.Settings.PendingApprovalComment
+	}
+	if settings != nil && settings.Disabled {
+		return
+	}
+
+	approvers, err := p.ownersApprovers(ctx)
+	if err != nil {
+		p.logger.Debugf("cannot get the approvers from the OWNERS file: %v", err)
+	}
+	comment, err := pendingApprovalComment(settings, p.event.Sender, approvers)
+	if err != nil {
+		p.eventEmitter.EmitMessage(repo, zap.ErrorLevel, "PendingApprovalCommentTemplateError", err.Error())
+		return
+	}
+	if err := p.vcx.CreateComment(ctx, p.event, comment+"\n\n"+pendingApprovalMarker, pendingApprovalMarker); err != nil {
+		p.eventEmitter.EmitMessage(repo, zap.ErrorLevel, "PendingApprovalCommentCreationError",
+			fmt.Sprintf("failed to create comment: %s", err.Error()))
+	}
+}
+
+// ownersApprovers returns the approvers and reviewers of the OWNERS file on
+// the default branch.
+func (p *PacRun) ownersApprovers(ctx context.Context) ([]string, error) {
+	owners, err := p.vcx.GetFileInsideRepo(ctx, p.event, "OWNERS", p.event.DefaultBranch)
+	if err != nil {
+		return nil, err
+	}
+	aliases, err := p.vcx.GetFileInsideRepo(ctx, p.event, "OWNERS_ALIASES", p.event.DefaultBranch)
+	if err != nil {
+		aliases = ""
+	}
+	return acl.Owners(owners, aliases)
+}
+
+// pendingApprovalComment renders the template of the repository, or the
+// default one, of the comment of a pull request waiting for an approval.
+func pendingApprovalComment(settings *v1alpha1.PendingApprovalComment, sender string, approvers []string) (string, error) {
+	tmpl := pendingApprovalTemplate
+	if settings != nil && settings.Template != "" {
+		tmpl = settings.Template
+	}
+	t, err := template.New("pending-approval").Parse(tmpl)
+	if err != nil {
+		return "", fmt.Errorf("cannot parse the pending approval comment template: %w", err)
+	}
+	quoted := make([]string, 0, len(approvers))
+	for _, approver := range approvers {
+		quoted = append(quoted, "`"+approver+"`")
+	}
+	data := pendingApprovalData{
+		Sender:    sender,
+		Approvers: strings.Join(quoted, ", "),
+		Command:   "/ok-to-test",
+	}
+	var out bytes.Buffer
+	if err := t.Execute(&out, data); err != nil {
+		return "", fmt.Errorf("cannot render the pending approval comment template: %w", err)
+	}
+	return out.String(), nil
+}
@@ -0,0 +1,103 @@
+package pipelineascode
+
+import (
+	"context"
+	"testing"
+
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/apis/pipelinesascode/v1alpha1"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/params/info"
+	testprovider "github.com/openshift-pipelines/pipelines-as-code/pkg/test/provider"
+	"gotest.tools/v3/assert"
+)
+
+func TestPendingApprovalComment(t *testing.T) {
+	tests := []struct {
+		name      string
+		settings  *v1alpha1.PendingApprovalComment
+		approvers []string
+		want      string
+		wantErr   string
+	}{
+		{
+			name:      "default template",
+			approvers: []string{"alice", "bob"},
+			want: "> [!NOTE]\n> CI has not been run on this pull request from contributor, it needs to be approved first.\n\n" +
+				"A maintainer of this repository needs to comment `/ok-to-test` to run CI on this pull request.\n\n" +
+				"The users allowed to approve it are: `alice`, `bob`.",
+		},
+		{
+			name: "default template without approvers",
+			want: "> [!NOTE]\n> CI has not been run on this pull request from contributor, it needs to be approved first.\n\n" +
+				"A maintainer of this repository needs to comment `/ok-to-test` to run CI on this pull request.",
+		},
+		{
+			name:      "custom template",
+			settings:  &v1alpha1.PendingApprovalComment{Template: "Hello {{ .Sender }}, ask {{ .Approvers }} for a {{ .Command }}"},
+			approvers: []string{"alice"},
+			want:      "Hello contributor, ask `alice` for a /ok-to-test",
+		},
+		{
+			name:     "bad template",
+			settings: &v1alpha1.PendingApprovalComment{Template: "{{ .Sender "},
+			wantErr:  "cannot parse the pending approval comment template",
+		},
+		{
+			name:     "unknown field",
+			settings: &v1alpha1.PendingApprovalComment{Template: "{{ .Unknown }}"},
+			wantErr:  "cannot render the pending approval comment template",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := pendingApprovalComment(tt.settings, "contributor", tt.approvers)
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			assert.NilError(t, err)
+			assert.Equal(t, got, tt.want)
+		})
+	}
+}
+
+func TestOwnersApprovers(t *testing.T) {
+	tests := []struct {
+		name    string
+		files   map[string]string
+		want    []string
+		wantErr bool
+	}{
+		{
+			name: "owners with aliases",
+			files: map[string]string{
+				"OWNERS":         "approvers:\n- maintainers\nreviewers:\n- carol\n",
+				"OWNERS_ALIASES": "aliases:\n  maintainers:\n  - bob\n  - alice\n",
+			},
+			want: []string{"alice", "bob", "carol"},
+		},
+		{
+			name:  "owners without aliases",
+			files: map[string]string{"OWNERS": "approvers:\n- alice\n"},
+			want:  []string{"alice"},
+		},
+		{
+			name:    "no owners file",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := &PacRun{
+				event: &info.Event{DefaultBranch: "main"},
+				vcx:   &testprovider.TestProviderImp{FilesInsideRepo: tt.files},
+			}
+			got, err := p.ownersApprovers(context.Background())
+			if tt.wantErr {
+				assert.Assert(t, err != nil)
+				return
+			}
+			assert.NilError(t, err)
+			assert.DeepEqual(t, got, tt.want)
+		})
+	}
+}
@@ -0,0 +1,124 @@
+package pipelineascode
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/apis/pipelinesascode/keys"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/formatting"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/matcher"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/params/info"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/params/triggertype"
+	tektonv1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+)
+
+// maxPerCommitRuns is the maximum number of commits of a push a PipelineRun
+// is run for.
+const maxPerCommitRuns = 20
+
+// perCommitLimit returns the number of commits a PipelineRun with the
+// per-commit annotation is run for, the annotation is either "true" or the
+// number of the most recent commits to run it for.
+func perCommitLimit(annotation string) (int, error) {
+	if annotation == "true" {
+		return maxPerCommitRuns, nil
+	}
+	limit, err := strconv.Atoi(annotation)
+	if err != nil || limit < 1 {
+		return 0, fmt.Errorf("the %s annotation must be \"true\" or a positive number, got %q", keys.PerCommit, annotation)
+	}
+	return min(limit, maxPerCommitRuns), nil
+}
+
+// newPerCommitPipelineRun returns a copy of pr for commit. The templates of
+// pr have already been applied for the head commit of the push, its SHA is
+// replaced by the SHA of commit.
+func newPerCommitPipelineRun(pr *tektonv1.PipelineRun, headSHA string, commit info.Commit) (*tektonv1.PipelineRun, error) {
+	originalName := pr.GetAnnotations()[keys.OriginalPRName]
+	if originalName == "" {
+		originalName = strings.TrimSuffix(pr.GetGenerateName(), "-")
+		if pr.GetName() != "" {
+			originalName = pr.GetName()
+		}
+	}
+	raw, err := json.Marshal(pr)
+	if err != nil {
+		return nil, err
+	}
+	commitPR := &tektonv1.PipelineRun{}
+	if err := json.Unmarshal([]byte(strings.ReplaceAll(string(raw), headSHA, commit.SHA)), commitPR); err != nil {
+		return nil, err
+	}
+
+	suffix := formatting.ShortSHA(commit.SHA)
+	if commitPR.GetName() != "" {
+		commitPR.SetName(fmt.Sprintf("%s-%s", commitPR.GetName(), suffix))
+	}
+	if commitPR.GetGenerateName() != "" {
+		commitPR.SetGenerateName(fmt.Sprintf("%s-%s-", strings.TrimSuffix(commitPR.GetGenerateName(), "-"), suffix))
+	}
+	commitName := fmt.Sprintf("%s-%s", originalName, suffix)
+
+	if commitPR.Annotations == nil {
+		commitPR.Annotations = map[string]string{}
+	}
+	if commitPR.Labels == nil {
+		commitPR.Labels = map[string]string{}
+	}
+	delete(commitPR.Annotations, keys.PerCommit)
+	commitPR.Annotations[keys.OriginalPRName] = commitName
+	commitPR.Annotations[keys.PerCommitParent] = originalName
+	commitPR.Labels[keys.OriginalPRName] = formatting.CleanValueKubernetes(commitName)
+	commitPR.Labels[keys.PerCommitParent] = formatting.CleanValueKubernetes(originalName)
+	return commitPR, nil
+}
+
+// expandPerCommit fans out the matched PipelineRuns having a per-commit
+// annotation into one PipelineRun per commit of a push, each one with its own
+// event so its status is reported on its commit.
+func expandPerCommit(event *info.Event, matches []matcher.Match) ([]matcher.Match, error) {
+	if event.TriggerTarget != triggertype.Push || len(event.PushCommits) < 2 {
+		return matches, nil
+	}
+	ret := make([]matcher.Match, 0, len(matches))
+	for _, match := range matches {
+		annotation, ok := match.PipelineRun.GetAnnotations()[keys.PerCommit]
+		if !ok || annotation == "false" {
+			ret = append(ret, match)
+			continue
+		}
+		name := match.PipelineRun.GetGenerateName() + match.PipelineRun.GetName()
+		limit, err := perCommitLimit(annotation)
+		if err != nil {
+			return nil, fmt.Errorf("pipelinerun %s: %w", name, err)
+		}
+		// the PipelineRuns of a commit can only depend on the PipelineRuns of
+		// the same commit
+		if _, ok := match.PipelineRun.GetAnnotations()[keys.DependsOn]; ok {
+			return nil, fmt.Errorf("pipelinerun %s: the %s annotation cannot be used with %s", name, keys.PerCommit, keys.DependsOn)
+		}
+		commits := event.PushCommits
+		if len(commits) > limit {
+			commits = commits[len(commits)-limit:]
+		}
+		for _, commit := range commits {
+			commitPR, err := newPerCommitPipelineRun(match.PipelineRun, event.SHA, commit)
+			if err != nil {
+				return nil, err
+			}
+			commitEvent := info.NewEvent()
+			event.DeepCopyInto(commitEvent)
+			commitEvent.SHA = commit.SHA
+			commitEvent.SHAURL = commit.URL
+			commitEvent.SHATitle = commit.Title
+
+			commitMatch := match
+			commitMatch.PipelineRun = commitPR
+			commitMatch.Event = commitEvent
+			ret = append(ret, commitMatch)
+		}
+	}
+	return ret, nil
+}
@@ -0,0 +1,125 @@
+package pipelineascode
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/apis/pipelinesascode/keys"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/matcher"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/params/info"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/params/triggertype"
+	tektonv1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+	"gotest.tools/v3/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestExpandPerCommit(t *testing.T) {
+	headSHA := strings.Repeat("c", 40)
+	commits := []info.Commit{
+		{SHA: strings.Repeat("a", 40), Title: "first"},
+		{SHA: strings.Repeat("b", 40), Title: "second"},
+		{SHA: headSHA, Title: "third"},
+	}
+	makePR := func(annotations map[string]string) *tektonv1.PipelineRun {
+		annotations[keys.OriginalPRName] = "build"
+		return &tektonv1.PipelineRun{
+			ObjectMeta: metav1.ObjectMeta{
+				GenerateName: "build-",
+				Annotations:  annotations,
+				Labels:       map[string]string{keys.OriginalPRName: "build"},
+			},
+			Spec: tektonv1.PipelineRunSpec{
+				Params: []tektonv1.Param{
+					{Name: "revision", Value: *tektonv1.NewStructuredValues(headSHA)},
+				},
+			},
+		}
+	}
+
+	tests := []struct {
+		name          string
+		event         *info.Event
+		matches       []matcher.Match
+		wantNames     []string
+		wantRevisions []string
+		wantErr       string
+	}{
+		{
+			name:          "no annotation",
+			event:         &info.Event{TriggerTarget: triggertype.Push, SHA: headSHA, PushCommits: commits},
+			matches:       []matcher.Match{{PipelineRun: makePR(map[string]string{})}},
+			wantNames:     []string{"build-"},
+			wantRevisions: []string{headSHA},
+		},
+		{
+			name:          "every commit",
+			event:         &info.Event{TriggerTarget: triggertype.Push, SHA: headSHA, PushCommits: commits},
+			matches:       []matcher.Match{{PipelineRun: makePR(map[string]string{keys.PerCommit: "true"})}},
+			wantNames:     []string{"build-aaaaaaa-", "build-bbbbbbb-", "build-ccccccc-"},
+			wantRevisions: []string{commits[0].SHA, commits[1].SHA, headSHA},
+		},
+		{
+			name:          "most recent commits",
+			event:         &info.Event{TriggerTarget: triggertype.Push, SHA: headSHA, PushCommits: commits},
+			matches:       []matcher.Match{{PipelineRun: makePR(map[string]string{keys.PerCommit: "2"})}},
+			wantNames:     []string{"build-bbbbbbb-", "build-ccccccc-"},
+			wantRevisions: []string{commits[1].SHA, headSHA},
+		},
+		{
+			name:          "single commit",
+			event:         &info.Event{TriggerTarget: triggertype.Push, SHA: headSHA, PushCommits: commits[2:]},
+			matches:       []matcher.Match{{PipelineRun: makePR(map[string]string{keys.PerCommit: "true"})}},
+			wantNames:     []string{"build-"},
+			wantRevisions: []string{headSHA},
+		},
+		{
+			name:          "pull request",
+			event:         &info.Event{TriggerTarget: triggertype.PullRequest, SHA: headSHA, PushCommits: commits},
+			matches:       []matcher.Match{{PipelineRun: makePR(map[string]string{keys.PerCommit: "true"})}},
+			wantNames:     []string{"build-"},
+			wantRevisions: []string{headSHA},
+		},
+		{
+			name:    "invalid annotation",
+			event:   &info.Event{TriggerTarget: triggertype.Push, SHA: headSHA, PushCommits: commits},
+			matches: []matcher.Match{{PipelineRun: makePR(map[string]string{keys.PerCommit: "yes"})}},
+			wantErr: `pipelinerun build-: the pipelinesascode.tekton.dev/per-commit annotation must be "true" or a positive number, got "yes"`,
+		},
+		{
+			name:  "depends on",
+			event: &info.Event{TriggerTarget: triggertype.Push, SHA: headSHA, PushCommits: commits},
+			matches: []matcher.Match{{PipelineRun: makePR(map[string]string{
+				keys.PerCommit: "true",
+				keys.DependsOn: "[lint]",
+			})}},
+			wantErr: "pipelinerun build-: the pipelinesascode.tekton.dev/per-commit annotation cannot be used with pipelinesascode.tekton.dev/depends-on",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := expandPerCommit(tt.event, tt.matches)
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			assert.NilError(t, err)
+			assert.Equal(t, len(got), len(tt.wantNames))
+			for i, match := range got {
+				pr := match.PipelineRun
+				assert.Equal(t, pr.GetGenerateName(), tt.wantNames[i])
+				assert.Equal(t, pr.Spec.Params[0].Value.StringVal, tt.wantRevisions[i])
+				if match.Event == nil {
+					continue
+				}
+				assert.Equal(t, match.Event.SHA, tt.wantRevisions[i])
+				assert.Equal(t, match.Event.SHATitle, tt.event.PushCommits[len(tt.event.PushCommits)-len(got)+i].Title)
+				assert.Equal(t, pr.GetAnnotations()[keys.PerCommitParent], "build")
+				assert.Equal(t, pr.GetAnnotations()[keys.OriginalPRName], "build-"+tt.wantRevisions[i][:7])
+				_, ok := pr.GetAnnotations()[keys.PerCommit]
+				assert.Assert(t, !ok)
+			}
+			// the event being processed is left untouched
+			assert.Equal(t, tt.event.SHA, headSHA)
+		})
+	}
+}
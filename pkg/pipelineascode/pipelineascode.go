@@ -2,14 +2,19 @@ package pipelineascode
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"slices"
 	"sync"
+	"time"
 
 	"github.com/openshift-pipelines/pipelines-as-code/pkg/action"
 	"github.com/openshift-pipelines/pipelines-as-code/pkg/apis/pipelinesascode/keys"
 	"github.com/openshift-pipelines/pipelines-as-code/pkg/apis/pipelinesascode/v1alpha1"
 	"github.com/openshift-pipelines/pipelines-as-code/pkg/customparams"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/ephemeralnamespace"
 	"github.com/openshift-pipelines/pipelines-as-code/pkg/events"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/executioncluster"
 	"github.com/openshift-pipelines/pipelines-as-code/pkg/formatting"
 	"github.com/openshift-pipelines/pipelines-as-code/pkg/kubeinteraction"
 	"github.com/openshift-pipelines/pipelines-as-code/pkg/matcher"
@@ -19,6 +24,7 @@ import (
 	"github.com/openshift-pipelines/pipelines-as-code/pkg/params/triggertype"
 	"github.com/openshift-pipelines/pipelines-as-code/pkg/provider"
 	"github.com/openshift-pipelines/pipelines-as-code/pkg/secrets"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/pod"
 	tektonv1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
 	"go.uber.org/zap"
 	"k8s.io/apimachinery/pkg/api/errors"
@@ -33,6 +39,12 @@ const (
 	failureConclusion = "failure"
 	pendingConclusion = "pending"
 	neutralConclusion = "neutral"
+	// skippedConclusion is reported for the PipelineRuns which did not match
+	// the event.
+	skippedConclusion = "skipped"
+	// actionRequiredConclusion is reported while the event waits for an
+	// /ok-to-test from an allowed user.
+	actionRequiredConclusion = "action_required"
 )
 
 type PacRun struct {
@@ -45,16 +57,43 @@ type PacRun struct {
 	manager      *ConcurrencyManager
 	pacInfo      *info.PacOpts
 	globalRepo   *v1alpha1.Repository
+	orgRepo      *v1alpha1.Repository
+	// newExecutionClients creates the clients of the execution clusters
+	newExecutionClients executioncluster.NewClientsFunc
+	// submoduleURLs are the urls of the submodules of the repository, added
+	// to the git auth secrets
+	submoduleURLs []string
+	// scheduled keeps the PipelineRuns pending until nextTriggerWindow when
+	// the event has been received outside of the trigger windows
+	scheduled         bool
+	nextTriggerWindow time.Time
+	// payloadValidated is called once the payload of the event has been
+	// validated with the webhook secret
+	payloadValidated func()
+	// paramValues are the values of the params replaced in the templates,
+	// they are not credentials committed in git
+	paramValues []string
 }
 
 func NewPacs(event *info.Event, vcx provider.Interface, run *params.Run, pacInfo *info.PacOpts, k8int kubeinteraction.Interface, logger *zap.SugaredLogger, globalRepo *v1alpha1.Repository) PacRun {
+	eventEmitter := events.NewEventEmitter(run.Clients.Kube, logger)
+	eventEmitter.SetRepositoryClient(run.Clients.PipelineAsCode)
+	if pacInfo != nil {
+		eventEmitter.SetEnabled(func() bool { return pacInfo.EnableRepositoryEvents })
+	}
 	return PacRun{
 		event: event, run: run, vcx: vcx, k8int: k8int, pacInfo: pacInfo, logger: logger, globalRepo: globalRepo,
-		eventEmitter: events.NewEventEmitter(run.Clients.Kube, logger),
+		eventEmitter: eventEmitter,
 		manager:      NewConcurrencyManager(),
 	}
 }
 
+// SetPayloadValidated sets the function called once the payload of the event
+// has been validated with the webhook secret of the Repository.
+func (p *PacRun) SetPayloadValidated(f func()) {
+	p.payloadValidated = f
+}
+
 func (p *PacRun) Run(ctx context.Context) error {
 	// For PullRequestClosed events, skip matching logic and go straight to cancellation
 	if p.event.TriggerTarget == triggertype.PullRequestClosed {
@@ -80,12 +119,26 @@ func (p *PacRun) Run(ctx context.Context) error {
 		})
 		p.eventEmitter.EmitMessage(repo, zap.ErrorLevel, "RepositoryCreateStatus", fmt.Sprintf("an error occurred: %s", err))
 		if createStatusErr != nil {
-			p.eventEmitter.EmitMessage(repo, zap.ErrorLevel, "RepositoryCreateStatus", fmt.Sprintf("cannot create status: %s: %s", err, createStatusErr))
+			p.eventEmitter.EmitFailure(repo, zap.ErrorLevel, v1alpha1.FailureReasonProviderAPIError, "RepositoryCreateStatus", fmt.Sprintf("cannot create status: %s: %s", err, createStatusErr))
+		}
+	}
+	if isDryRun(repo) {
+		if len(matchedPRs) > 0 {
+			p.reportDryRun(ctx, repo, matchedPRs)
+		}
+		return nil
+	}
+	if repo != nil {
+		if err := p.cancelForcePushedPipelineRuns(ctx, repo); err != nil {
+			p.eventEmitter.EmitMessage(repo, zap.ErrorLevel, "RepositoryPipelineRun", fmt.Sprintf("error cancelling pipelineRuns of force pushed sha %s: %s", p.event.BeforeSHA, err))
 		}
 	}
 	if len(matchedPRs) == 0 {
 		return nil
 	}
+	if p.isOutsideTriggerWindows(ctx, repo, matchedPRs) {
+		return nil
+	}
 	if repo.Spec.ConcurrencyLimit != nil && *repo.Spec.ConcurrencyLimit != 0 {
 		p.manager.Enable()
 	}
@@ -99,6 +152,10 @@ func (p *PacRun) Run(ctx context.Context) error {
 	}
 	p.run.Clients.ConsoleUI().SetParams(maptemplate)
 
+	if p.pacInfo.SecretAutoCreation {
+		p.submoduleURLs = p.getSubmoduleURLs(ctx)
+	}
+
 	var wg sync.WaitGroup
 	for i, match := range matchedPRs {
 		if match.Repo == nil {
@@ -106,7 +163,10 @@ func (p *PacRun) Run(ctx context.Context) error {
 		}
 
 		// After matchRepo func fetched repo from k8s api repo is updated and
-		// need to merge global repo again
+		// need to inherit from the org repo and merge global repo again
+		if p.orgRepo != nil && p.orgRepo.GetNamespace() == match.Repo.GetNamespace() {
+			match.Repo.Spec.Inherit(p.orgRepo.Spec)
+		}
 		if p.globalRepo != nil {
 			match.Repo.Spec.Merge(p.globalRepo.Spec)
 		}
@@ -119,8 +179,12 @@ func (p *PacRun) Run(ctx context.Context) error {
 			if err != nil {
 				errMsg := fmt.Sprintf("There was an error starting the PipelineRun %s, %s", match.PipelineRun.GetGenerateName(), err.Error())
 				errMsgM := fmt.Sprintf("There was an error creating the PipelineRun: <b>%s</b>\n\n%s", match.PipelineRun.GetGenerateName(), err.Error())
-				p.eventEmitter.EmitMessage(repo, zap.ErrorLevel, "RepositoryPipelineRun", errMsg)
-				createStatusErr := p.vcx.CreateStatus(ctx, p.event, provider.StatusOpts{
+				if isQuotaExceeded(err) {
+					p.eventEmitter.EmitFailure(repo, zap.ErrorLevel, v1alpha1.FailureReasonQuotaExceeded, "RepositoryPipelineRun", errMsg)
+				} else {
+					p.eventEmitter.EmitMessage(repo, zap.ErrorLevel, "RepositoryPipelineRun", errMsg)
+				}
+				createStatusErr := p.vcx.CreateStatus(ctx, p.eventOf(match), provider.StatusOpts{
 					Status:                   CompletedStatus,
 					Conclusion:               failureConclusion,
 					Text:                     errMsgM,
@@ -128,7 +192,7 @@ func (p *PacRun) Run(ctx context.Context) error {
 					InstanceCountForCheckRun: i,
 				})
 				if createStatusErr != nil {
-					p.eventEmitter.EmitMessage(repo, zap.ErrorLevel, "RepositoryCreateStatus", fmt.Sprintf("Cannot create status: %s: %s", err, createStatusErr))
+					p.eventEmitter.EmitFailure(repo, zap.ErrorLevel, v1alpha1.FailureReasonProviderAPIError, "RepositoryCreateStatus", fmt.Sprintf("Cannot create status: %s: %s", err, createStatusErr))
 				}
 			}
 			p.manager.AddPipelineRun(pr)
@@ -158,8 +222,128 @@ func (p *PacRun) Run(ctx context.Context) error {
 	return nil
 }
 
-func (p *PacRun) startPR(ctx context.Context, match matcher.Match) (*tektonv1.PipelineRun, error) {
+// pipelineRunNamespace returns the namespace to create the pipelineRun of
+// match in, the namespace of its Repository unless the pipelineRun has a
+// pipelinerun-namespace annotation allowed by the Repository settings.
+func pipelineRunNamespace(match matcher.Match) (string, error) {
+	namespace := match.PipelineRun.GetAnnotations()[keys.PipelineRunNamespace]
+	if namespace == "" || namespace == match.Repo.GetNamespace() {
+		return match.Repo.GetNamespace(), nil
+	}
+	if match.Repo.Spec.Settings == nil || !slices.Contains(match.Repo.Spec.Settings.AllowedPipelineRunNamespaces, namespace) {
+		return "", fmt.Errorf("namespace %s of the %s annotation is not in the allowed_pipelinerun_namespaces settings of the repository %s/%s",
+			namespace, keys.PipelineRunNamespace, match.Repo.GetNamespace(), match.Repo.GetName())
+	}
+	if match.Repo.Spec.ConcurrencyLimit != nil && *match.Repo.Spec.ConcurrencyLimit != 0 {
+		return "", fmt.Errorf("the %s annotation is not supported with a concurrency_limit", keys.PipelineRunNamespace)
+	}
+	if match.Repo.Spec.Settings.ExecutionCluster != nil {
+		return "", fmt.Errorf("the %s annotation is not supported with an execution cluster", keys.PipelineRunNamespace)
+	}
+	return namespace, nil
+}
+
+// setDefaultPodSecurityContext sets the default pod security context of the
+// settings on the pod template of pr, the fields already set by the
+// pipelineRun are kept.
+func setDefaultPodSecurityContext(pr *tektonv1.PipelineRun, defaultSecurityContext string) error {
+	if defaultSecurityContext == "" {
+		return nil
+	}
+	securityContext, err := settings.ParsePodSecurityContext(defaultSecurityContext)
+	if err != nil {
+		return err
+	}
+	if pr.Spec.TaskRunTemplate.PodTemplate == nil {
+		pr.Spec.TaskRunTemplate.PodTemplate = &pod.PodTemplate{}
+	}
+	if existing := pr.Spec.TaskRunTemplate.PodTemplate.SecurityContext; existing != nil {
+		overrides, err := json.Marshal(existing)
+		if err != nil {
+			return err
+		}
+		if err := json.Unmarshal(overrides, securityContext); err != nil {
+			return err
+		}
+	}
+	pr.Spec.TaskRunTemplate.PodTemplate.SecurityContext = securityContext
+	return nil
+}
+
+// eventOf returns the event of the PipelineRun of match, the event being
+// processed unless the PipelineRun has its own.
+func (p *PacRun) eventOf(match matcher.Match) *info.Event {
+	if match.Event != nil {
+		return match.Event
+	}
+	return p.event
+}
+
+// getSubmoduleURLs returns the urls of the submodules declared in the
+// .gitmodules of the repository at the revision of the event, if any.
+func (p *PacRun) getSubmoduleURLs(ctx context.Context) []string {
+	gitmodules, err := p.vcx.GetFileInsideRepo(ctx, p.event, ".gitmodules", "")
+	if err != nil || gitmodules == "" {
+		return nil
+	}
+	return secrets.SubmoduleURLs(gitmodules)
+}
+
+func (p *PacRun) startPR(ctx context.Context, match matcher.Match) (pr *tektonv1.PipelineRun, err error) {
 	var gitAuthSecretName string
+	event := p.eventOf(match)
+
+	namespace, err := pipelineRunNamespace(match)
+	if err != nil {
+		return nil, err
+	}
+	gitClone, err := gitCloneOptionsOf(match.PipelineRun)
+	if err != nil {
+		return nil, err
+	}
+
+	// the pipelineRun and its secret are created on the execution cluster of
+	// the repository when it has one
+	tekton, k8int := p.run.Clients.Tekton, p.k8int
+	remote, err := executioncluster.Get(ctx, p.run.Clients.Kube, match.Repo, p.newExecutionClients)
+	if err != nil {
+		return nil, err
+	}
+	if remote != nil {
+		if match.Repo.Spec.ConcurrencyLimit != nil && *match.Repo.Spec.ConcurrencyLimit != 0 {
+			return nil, fmt.Errorf("concurrency_limit is not supported with an execution cluster")
+		}
+		remoteRun := *p.run
+		remoteRun.Clients.Kube, remoteRun.Clients.Tekton = remote.Kube, remote.Tekton
+		tekton, k8int = remote.Tekton, &kubeinteraction.Interaction{Run: &remoteRun}
+	}
+
+	// the pipelineRun runs in a namespace created for it, the watcher deletes
+	// it once the pipelineRun has completed
+	if namespace == match.Repo.GetNamespace() && ephemeralnamespace.Enabled(match.Repo, p.event) {
+		if remote != nil {
+			return nil, fmt.Errorf("ephemeral_namespace is not supported with an execution cluster")
+		}
+		if match.Repo.Spec.ConcurrencyLimit != nil && *match.Repo.Spec.ConcurrencyLimit != 0 {
+			return nil, fmt.Errorf("ephemeral_namespace is not supported with a concurrency_limit")
+		}
+		if namespace, err = ephemeralnamespace.Create(ctx, p.logger, p.run.Clients.Kube, match.Repo); err != nil {
+			return nil, err
+		}
+		// remove the namespace when the pipelineRun doesn't get created
+		defer func() {
+			if pr != nil {
+				return
+			}
+			if err := ephemeralnamespace.Delete(ctx, p.run.Clients.Kube, namespace); err != nil {
+				p.logger.Errorf("removing ephemeral namespace %s has failed: %v", namespace, err)
+			}
+		}()
+	}
+
+	if err := p.syncCIVariables(ctx, k8int, match.Repo, event, namespace); err != nil {
+		return nil, err
+	}
 
 	// Automatically create a secret with the token to be reused by git-clone task
 	if p.pacInfo.SecretAutoCreation {
@@ -169,19 +353,19 @@ func (p *PacRun) startPR(ctx context.Context, match matcher.Match) (*tektonv1.Pi
 			return nil, fmt.Errorf("cannot get annotation %s as set on PR", keys.GitAuthSecret)
 		}
 
-		authSecret, err := secrets.MakeBasicAuthSecret(p.event, gitAuthSecretName)
+		authSecret, err := secrets.MakeBasicAuthSecret(event, gitAuthSecretName, p.submoduleURLs...)
 		if err != nil {
 			return nil, fmt.Errorf("making basic auth secret: %s has failed: %w ", gitAuthSecretName, err)
 		}
 
-		if err = p.k8int.CreateSecret(ctx, match.Repo.GetNamespace(), authSecret); err != nil {
+		if err = k8int.CreateSecret(ctx, namespace, authSecret); err != nil {
 			// NOTE: Handle AlreadyExists errors due to etcd/API server timing issues.
 			// Investigation found: slow etcd response causes API server retry, resulting in
 			// duplicate secret creation attempts for the same PR. This is a workaround, not
 			// designed behavior - reuse existing secret to prevent PipelineRun failure.
 			if errors.IsAlreadyExists(err) {
 				msg := fmt.Sprintf("Secret %s already exists in namespace %s, reusing existing secret",
-					authSecret.GetName(), match.Repo.GetNamespace())
+					authSecret.GetName(), namespace)
 				p.eventEmitter.EmitMessage(match.Repo, zap.WarnLevel, "RepositorySecretReused", msg)
 			} else {
 				return nil, fmt.Errorf("creating basic auth secret: %s has failed: %w ", authSecret.GetName(), err)
@@ -190,9 +374,30 @@ func (p *PacRun) startPR(ctx context.Context, match matcher.Match) (*tektonv1.Pi
 	}
 
 	// Add labels and annotations to pipelinerun
-	err := kubeinteraction.AddLabelsAndAnnotations(p.event, match.PipelineRun, match.Repo, p.vcx.GetConfig(), p.run)
+	err = kubeinteraction.AddLabelsAndAnnotations(event, match.PipelineRun, match.Repo, p.vcx.GetConfig(), p.run)
 	if err != nil {
-		p.logger.Errorf("Error adding labels/annotations to PipelineRun '%s' in namespace '%s': %v", match.PipelineRun.GetName(), match.Repo.GetNamespace(), err)
+		p.logger.Errorf("Error adding labels/annotations to PipelineRun '%s' in namespace '%s': %v", match.PipelineRun.GetName(), namespace, err)
+	}
+
+	if err := setDefaultPodSecurityContext(match.PipelineRun, p.pacInfo.DefaultPodSecurityContext); err != nil {
+		return nil, fmt.Errorf("cannot set the default pod security context: %w", err)
+	}
+
+	if err := setPipelineRunTimeout(match.PipelineRun); err != nil {
+		return nil, err
+	}
+
+	if gitClone != nil {
+		injectGitClone(match.PipelineRun, gitClone, event, p.pacInfo.GitCloneImage, gitAuthSecretName)
+	}
+
+	// the watcher finds the Repository of a pipelineRun created in another
+	// namespace with this annotation, it can't be set from the template
+	if namespace != match.Repo.GetNamespace() {
+		match.PipelineRun.Namespace = namespace
+		match.PipelineRun.Annotations[keys.RepositoryNamespace] = match.Repo.GetNamespace()
+	} else {
+		delete(match.PipelineRun.Annotations, keys.RepositoryNamespace)
 	}
 
 	// if concurrency is defined then start the pipelineRun in pending state
@@ -200,26 +405,36 @@ func (p *PacRun) startPR(ctx context.Context, match matcher.Match) (*tektonv1.Pi
 		// pending status
 		match.PipelineRun.Spec.Status = tektonv1.PipelineRunSpecStatusPending
 	}
+	// if the pipelineRun depends on other ones, keep it pending until the
+	// watcher sees them succeed
+	dependsOn, waiting := match.PipelineRun.GetAnnotations()[keys.DependsOn]
+	if waiting {
+		match.PipelineRun.Spec.Status = tektonv1.PipelineRunSpecStatusPending
+	}
+	// keep the pipelineRun pending until the next trigger window opens
+	if p.scheduled {
+		match.PipelineRun.Spec.Status = tektonv1.PipelineRunSpecStatusPending
+	}
 
 	// Create the actual pipelineRun
-	pr, err := p.run.Clients.Tekton.TektonV1().PipelineRuns(match.Repo.GetNamespace()).Create(ctx,
+	pr, err = tekton.TektonV1().PipelineRuns(namespace).Create(ctx,
 		match.PipelineRun, metav1.CreateOptions{})
 	if err != nil {
 		// cleanup the gitauth secret because ownerRef isn't set when the pipelineRun creation failed
 		if p.pacInfo.SecretAutoCreation {
-			if errDelSec := p.k8int.DeleteSecret(ctx, p.logger, match.Repo.GetNamespace(), gitAuthSecretName); errDelSec != nil {
+			if errDelSec := k8int.DeleteSecret(ctx, p.logger, namespace, gitAuthSecretName); errDelSec != nil {
 				// don't overshadow the pipelineRun creation error, just log
-				p.logger.Errorf("removing auto created secret: %s in namespace %s has failed: %w ", gitAuthSecretName, match.Repo.GetNamespace(), errDelSec)
+				p.logger.Errorf("removing auto created secret: %s in namespace %s has failed: %w ", gitAuthSecretName, namespace, errDelSec)
 			}
 		}
 		// we need to make difference between markdown error and normal error that goes to namespace/controller stream
 		return nil, fmt.Errorf("creating pipelinerun %s in namespace %s has failed.\n\nTekton Controller has reported this error: ```%w``` ", match.PipelineRun.GetGenerateName(),
-			match.Repo.GetNamespace(), err)
+			namespace, err)
 	}
 
 	// update ownerRef of secret with pipelineRun, so that it gets cleanedUp with pipelineRun
 	if p.pacInfo.SecretAutoCreation {
-		err := p.k8int.UpdateSecretWithOwnerRef(ctx, p.logger, pr.Namespace, gitAuthSecretName, pr)
+		err := k8int.UpdateSecretWithOwnerRef(ctx, p.logger, pr.Namespace, gitAuthSecretName, pr)
 		if err != nil {
 			// we still return the created PR with error, and allow caller to decide what to do with the PR, and avoid
 			// unneeded SIGSEGV's
@@ -229,12 +444,12 @@ func (p *PacRun) startPR(ctx context.Context, match matcher.Match) (*tektonv1.Pi
 
 	// Create status with the log url
 	p.logger.Infof("PipelineRun %s has been created in namespace %s with status %s for SHA: %s Target Branch: %s",
-		pr.GetName(), match.Repo.GetNamespace(), pr.Spec.Status, p.event.SHA, p.event.BaseBranch)
+		pr.GetName(), namespace, pr.Spec.Status, event.SHA, event.BaseBranch)
 
 	consoleURL := p.run.Clients.ConsoleUI().DetailURL(pr)
 	mt := formatting.MessageTemplate{
 		PipelineRunName: pr.GetName(),
-		Namespace:       match.Repo.GetNamespace(),
+		Namespace:       namespace,
 		ConsoleName:     p.run.Clients.ConsoleUI().GetName(),
 		ConsoleURL:      consoleURL,
 		TknBinary:       settings.TknBinaryName,
@@ -271,6 +486,16 @@ func (p *PacRun) startPR(ctx context.Context, match matcher.Match) (*tektonv1.Pi
 		whatPatching = "annotations.state and labels.state"
 		patchAnnotations[keys.State] = kubeinteraction.StateQueued
 		patchLabels[keys.State] = kubeinteraction.StateQueued
+		if waiting {
+			status.Text = fmt.Sprintf("Waiting for the PipelineRun %s to succeed before starting.\n\n%s", dependsOn, status.Text)
+			patchAnnotations[keys.State] = kubeinteraction.StateWaiting
+			patchLabels[keys.State] = kubeinteraction.StateWaiting
+		}
+		if p.scheduled {
+			status.Text = fmt.Sprintf("Outside of the trigger windows, %s.\n\n%s", formatNextTriggerWindow(p.nextTriggerWindow), status.Text)
+			patchAnnotations[keys.State] = kubeinteraction.StateScheduled
+			patchLabels[keys.State] = kubeinteraction.StateScheduled
+		}
 	} else {
 		// Mark that the start will be reported to the Git provider
 		patchAnnotations[keys.SCMReportingPLRStarted] = "true"
@@ -282,7 +507,7 @@ func (p *PacRun) startPR(ctx context.Context, match matcher.Match) (*tektonv1.Pi
 		)
 	}
 
-	if err := p.vcx.CreateStatus(ctx, p.event, status); err != nil {
+	if err := p.vcx.CreateStatus(ctx, event, status); err != nil {
 		// we still return the created PR with error, and allow caller to decide what to do with the PR, and avoid
 		// unneeded SIGSEGV's
 		return pr, fmt.Errorf("cannot use the API on the provider platform to create a in_progress status: %w", err)
@@ -295,7 +520,7 @@ func (p *PacRun) startPR(ctx context.Context, match matcher.Match) (*tektonv1.Pi
 	}
 
 	if len(patchAnnotations) > 0 || len(patchLabels) > 0 {
-		pr, err = action.PatchPipelineRun(ctx, p.logger, whatPatching, p.run.Clients.Tekton, pr, getMergePatch(patchAnnotations, patchLabels))
+		pr, err = action.PatchPipelineRun(ctx, p.logger, whatPatching, tekton, pr, getMergePatch(patchAnnotations, patchLabels))
 		if err != nil {
 			// we still return the created PR with error, and allow caller to decide what to do with the PR, and avoid
 			// unneeded SIGSEGV's
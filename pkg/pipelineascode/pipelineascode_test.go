@@ -20,6 +20,7 @@ import (
 	"github.com/openshift-pipelines/pipelines-as-code/pkg/apis/pipelinesascode/v1alpha1"
 	"github.com/openshift-pipelines/pipelines-as-code/pkg/consoleui"
 	"github.com/openshift-pipelines/pipelines-as-code/pkg/kubeinteraction"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/matcher"
 	"github.com/openshift-pipelines/pipelines-as-code/pkg/params"
 	"github.com/openshift-pipelines/pipelines-as-code/pkg/params/clients"
 	"github.com/openshift-pipelines/pipelines-as-code/pkg/params/info"
@@ -30,6 +31,7 @@ import (
 	kitesthelper "github.com/openshift-pipelines/pipelines-as-code/pkg/test/kubernetestint"
 	testnewrepo "github.com/openshift-pipelines/pipelines-as-code/pkg/test/repository"
 	tektontest "github.com/openshift-pipelines/pipelines-as-code/pkg/test/tekton"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/pod"
 	pipelinev1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
 	"go.uber.org/zap"
 	zapobserver "go.uber.org/zap/zaptest/observer"
@@ -440,8 +442,8 @@ func TestRun(t *testing.T) {
 				TriggerTarget: "pull_request",
 			},
 			tektondir:                    "testdata/pull_request",
-			finalStatus:                  "skipped",
-			finalStatusText:              "is not allowed to run CI on this repo",
+			finalStatus:                  "action_required",
+			finalStatusText:              "is not allowed to trigger CI via pull_request in this repo",
 			skipReplyingOrgPublicMembers: true,
 		},
 		{
@@ -697,7 +699,7 @@ func TestRun(t *testing.T) {
 				assert.Assert(t, len(logmsg) > 0, "log messages", logmsg, tt.expectedLogSnippet)
 			}
 
-			if tt.finalStatus != "skipped" {
+			if tt.finalStatus != "skipped" && tt.finalStatus != "action_required" {
 				prs, err := cs.Clients.Tekton.TektonV1().PipelineRuns("").List(ctx, metav1.ListOptions{})
 				assert.NilError(t, err)
 				if len(prs.Items) == 0 {
@@ -788,3 +790,132 @@ func TestGetExecutionOrderPatch(t *testing.T) {
 		})
 	}
 }
+
+func TestPipelineRunNamespace(t *testing.T) {
+	limit := 1
+	tests := []struct {
+		name       string
+		annotation string
+		settings   *v1alpha1.Settings
+		limit      *int
+		want       string
+		wantErr    string
+	}{
+		{
+			name: "no annotation",
+			want: "repo-ns",
+		},
+		{
+			name:       "repository namespace",
+			annotation: "repo-ns",
+			want:       "repo-ns",
+		},
+		{
+			name:       "allowed namespace",
+			annotation: "deployments",
+			settings:   &v1alpha1.Settings{AllowedPipelineRunNamespaces: []string{"deployments"}},
+			want:       "deployments",
+		},
+		{
+			name:       "namespace not allowed",
+			annotation: "other",
+			settings:   &v1alpha1.Settings{AllowedPipelineRunNamespaces: []string{"deployments"}},
+			wantErr:    "namespace other of the pipelinesascode.tekton.dev/pipelinerun-namespace annotation is not in the allowed_pipelinerun_namespaces settings of the repository repo-ns/repo",
+		},
+		{
+			name:       "no allowed namespaces",
+			annotation: "deployments",
+			wantErr:    "is not in the allowed_pipelinerun_namespaces settings",
+		},
+		{
+			name:       "concurrency limit",
+			annotation: "deployments",
+			settings:   &v1alpha1.Settings{AllowedPipelineRunNamespaces: []string{"deployments"}},
+			limit:      &limit,
+			wantErr:    "not supported with a concurrency_limit",
+		},
+		{
+			name:       "execution cluster",
+			annotation: "deployments",
+			settings: &v1alpha1.Settings{
+				AllowedPipelineRunNamespaces: []string{"deployments"},
+				ExecutionCluster:             &v1alpha1.ExecutionCluster{Secret: &v1alpha1.Secret{Name: "cluster"}},
+			},
+			wantErr: "not supported with an execution cluster",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			match := matcher.Match{
+				PipelineRun: &pipelinev1.PipelineRun{
+					ObjectMeta: metav1.ObjectMeta{Annotations: map[string]string{}},
+				},
+				Repo: &v1alpha1.Repository{
+					ObjectMeta: metav1.ObjectMeta{Name: "repo", Namespace: "repo-ns"},
+					Spec: v1alpha1.RepositorySpec{
+						Settings:         tt.settings,
+						ConcurrencyLimit: tt.limit,
+					},
+				},
+			}
+			if tt.annotation != "" {
+				match.PipelineRun.Annotations[keys.PipelineRunNamespace] = tt.annotation
+			}
+			namespace, err := pipelineRunNamespace(match)
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			assert.NilError(t, err)
+			assert.Equal(t, namespace, tt.want)
+		})
+	}
+}
+
+func TestSetDefaultPodSecurityContext(t *testing.T) {
+	nonRoot := true
+	user := int64(1000)
+	restricted := "runAsNonRoot: true\nseccompProfile:\n  type: RuntimeDefault"
+	tests := []struct {
+		name     string
+		setting  string
+		existing *corev1.PodSecurityContext
+		want     *corev1.PodSecurityContext
+	}{
+		{
+			name: "no default",
+		},
+		{
+			name:    "no pod template",
+			setting: restricted,
+			want: &corev1.PodSecurityContext{
+				RunAsNonRoot:   &nonRoot,
+				SeccompProfile: &corev1.SeccompProfile{Type: corev1.SeccompProfileTypeRuntimeDefault},
+			},
+		},
+		{
+			name:     "keep the fields of the pipelinerun",
+			setting:  restricted,
+			existing: &corev1.PodSecurityContext{RunAsUser: &user, SeccompProfile: &corev1.SeccompProfile{Type: corev1.SeccompProfileTypeUnconfined}},
+			want: &corev1.PodSecurityContext{
+				RunAsNonRoot:   &nonRoot,
+				RunAsUser:      &user,
+				SeccompProfile: &corev1.SeccompProfile{Type: corev1.SeccompProfileTypeUnconfined},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pr := &pipelinev1.PipelineRun{}
+			if tt.existing != nil {
+				pr.Spec.TaskRunTemplate.PodTemplate = &pod.PodTemplate{SecurityContext: tt.existing}
+			}
+			assert.NilError(t, setDefaultPodSecurityContext(pr, tt.setting))
+			if tt.want == nil {
+				assert.Assert(t, pr.Spec.TaskRunTemplate.PodTemplate == nil)
+				return
+			}
+			assert.DeepEqual(t, pr.Spec.TaskRunTemplate.PodTemplate.SecurityContext, tt.want)
+		})
+	}
+}
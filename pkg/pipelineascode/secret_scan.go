@@ -0,0 +1,119 @@
+package pipelineascode
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/apis/pipelinesascode/keys"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/apis/pipelinesascode/v1alpha1"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/params/triggertype"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/provider"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/secretscan"
+	tektonv1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+	"go.uber.org/zap"
+	"sigs.k8s.io/yaml"
+)
+
+// minMaskedParamLength is the length of the shortest param value masked
+// before scanning, the shorter values are not credentials.
+const minMaskedParamLength = 8
+
+// pipelineRunFinding is a credential found in a PipelineRun.
+type pipelineRunFinding struct {
+	secretscan.Finding
+	pipelineRun string
+}
+
+func (f *pipelineRunFinding) String() string {
+	return fmt.Sprintf("%s %s in %s", f.Rule, f.Redacted, f.pipelineRun)
+}
+
+// scanSecrets looks for the credentials committed in the PipelineRuns, once
+// their remote tasks and pipelines are resolved, when the repository enables
+// the secret scanning, and reports them. It returns whether the PipelineRuns
+// must not be created.
+//
+// The values of the params replaced in the templates are masked, the values
+// coming from the Secrets of the Repository params are not credentials
+// committed in git.
+func (p *PacRun) scanSecrets(ctx context.Context, repo *v1alpha1.Repository, pipelineRuns []*tektonv1.PipelineRun) bool {
+	severity := repo.Spec.GetSecretScanning()
+	if severity == v1alpha1.SecretScanningOff {
+		return false
+	}
+	masked := []string{}
+	for _, value := range p.paramValues {
+		if len(value) >= minMaskedParamLength {
+			masked = append(masked, value)
+		}
+	}
+	// the longest values first, for a value containing another one to be
+	// masked entirely
+	sort.Slice(masked, func(i, j int) bool { return len(masked[i]) > len(masked[j]) })
+	oldnew := make([]string, 0, 2*len(masked))
+	for _, value := range masked {
+		oldnew = append(oldnew, value, "****")
+	}
+	mask := strings.NewReplacer(oldnew...)
+
+	findings := []pipelineRunFinding{}
+	for _, pr := range pipelineRuns {
+		name := pr.GetAnnotations()[keys.OriginalPRName]
+		data, err := yaml.Marshal(pr)
+		if err != nil {
+			p.logger.Errorf("cannot scan the pipelinerun %s for credentials: %v", name, err)
+			continue
+		}
+		for _, finding := range secretscan.Scan(mask.Replace(string(data))) {
+			findings = append(findings, pipelineRunFinding{Finding: finding, pipelineRun: name})
+		}
+	}
+	if len(findings) == 0 {
+		return false
+	}
+	locations := make([]string, 0, len(findings))
+	for i := range findings {
+		locations = append(locations, findings[i].String())
+	}
+	msg := fmt.Sprintf("credentials found in the pipelineruns: %s", strings.Join(locations, ", "))
+	markdown := secretFindingsMarkdown(findings)
+
+	if severity != v1alpha1.SecretScanningBlock {
+		p.eventEmitter.EmitMessage(repo, zap.WarnLevel, "RepositorySecretDetected", msg)
+		if p.event.TriggerTarget != triggertype.PullRequest {
+			return false
+		}
+		if err := p.vcx.CreateComment(ctx, p.event, markdown, provider.SecretScanTemplate); err != nil {
+			p.eventEmitter.EmitFailure(repo, zap.ErrorLevel, v1alpha1.FailureReasonProviderAPIError, "PipelineRunCommentCreationError",
+				fmt.Sprintf("failed to create comment: %s", err.Error()))
+		}
+		return false
+	}
+
+	p.eventEmitter.EmitFailure(repo, zap.ErrorLevel, v1alpha1.FailureReasonYAMLInvalid, "RepositorySecretDetected",
+		fmt.Sprintf("not creating the pipelineruns, %s", msg))
+	status := provider.StatusOpts{
+		Status:     CompletedStatus,
+		Title:      "Credentials found",
+		Conclusion: failureConclusion,
+		Text:       markdown,
+		DetailsURL: p.event.URL,
+	}
+	if err := p.vcx.CreateStatus(ctx, p.event, status); err != nil {
+		p.eventEmitter.EmitFailure(repo, zap.ErrorLevel, v1alpha1.FailureReasonProviderAPIError, "RepositoryCreateStatus",
+			fmt.Sprintf("cannot report the credentials found: %s", err.Error()))
+	}
+	return true
+}
+
+// secretFindingsMarkdown renders the credentials found as a table, with their
+// values redacted.
+func secretFindingsMarkdown(findings []pipelineRunFinding) string {
+	rows := make([]string, 0, len(findings))
+	for _, finding := range findings {
+		rows = append(rows, fmt.Sprintf("| `%s` | %s `%s` |", finding.pipelineRun, finding.Rule, finding.Redacted))
+	}
+	return fmt.Sprintf("%s\n%s", provider.SecretScanTemplate, strings.Join(rows, "\n"))
+}
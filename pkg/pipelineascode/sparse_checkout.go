@@ -0,0 +1,27 @@
+package pipelineascode
+
+import (
+	"path"
+	"strings"
+)
+
+// sparseCheckoutDirectories returns the directories of the sparse-checkout
+// annotation as the comma separated patterns expected by the
+// sparseCheckoutDirectories param of git-clone and git-init. The patterns are
+// anchored to the root of the repository so a directory doesn't match the
+// directories of the same name deeper in the tree. Everything is checked out
+// when the annotation is empty or has the root of the repository.
+func sparseCheckoutDirectories(annotation string) string {
+	dirs := []string{}
+	for _, dir := range strings.Split(annotation, ",") {
+		if dir = strings.TrimSpace(dir); dir == "" {
+			continue
+		}
+		dir = path.Clean("/" + dir)
+		if dir == "/" {
+			return ""
+		}
+		dirs = append(dirs, dir+"/")
+	}
+	return strings.Join(dirs, ",")
+}
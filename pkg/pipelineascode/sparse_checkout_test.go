@@ -0,0 +1,38 @@
+package pipelineascode
+
+import (
+	"testing"
+
+	"gotest.tools/v3/assert"
+)
+
+func TestSparseCheckoutDirectories(t *testing.T) {
+	tests := []struct {
+		name       string
+		annotation string
+		want       string
+	}{
+		{
+			name: "no annotation",
+		},
+		{
+			name:       "directories",
+			annotation: "services/foo, libs/bar",
+			want:       "/services/foo/,/libs/bar/",
+		},
+		{
+			name:       "cleaned directories",
+			annotation: " ./services/foo/ ,/libs//bar,,",
+			want:       "/services/foo/,/libs/bar/",
+		},
+		{
+			name:       "root of the repository",
+			annotation: "services/foo, .",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, sparseCheckoutDirectories(tt.annotation), tt.want)
+		})
+	}
+}
@@ -0,0 +1,97 @@
+package pipelineascode
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path"
+	"slices"
+	"strings"
+
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/apis/pipelinesascode/v1alpha1"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/git"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/provider"
+	ktypes "github.com/openshift-pipelines/pipelines-as-code/pkg/secrets/types"
+)
+
+const (
+	defaultSSHPrivateKeySecretKey = "ssh-privatekey"
+	sshKnownHostsSecretKey        = "known_hosts"
+)
+
+// getTektonDirOverSSH returns the yaml files of the tekton directory as one
+// multi document yaml like the GetTektonDir of the providers, fetched with
+// git archive over SSH with the deploy key of the Repository.
+func (p *PacRun) getTektonDirOverSSH(ctx context.Context, repo *v1alpha1.Repository, tektonDir, provenance string) (string, error) {
+	sshFetch := repo.Spec.GitProvider.SSH
+	if sshFetch.Secret == nil {
+		return "", fmt.Errorf("no secret in the ssh section of the git_provider of repository %s/%s", repo.GetNamespace(), repo.GetName())
+	}
+	remote := sshFetch.URL
+	if remote == "" {
+		var err error
+		if remote, err = git.SSHURL(repo.Spec.URL); err != nil {
+			return "", err
+		}
+	}
+	key := sshFetch.Secret.Key
+	if key == "" {
+		key = defaultSSHPrivateKeySecretKey
+	}
+	privateKey, err := p.k8int.GetSecret(ctx, ktypes.GetSecretOpt{
+		Namespace: repo.GetNamespace(),
+		Name:      sshFetch.Secret.Name,
+		Key:       key,
+	})
+	if err != nil {
+		return "", fmt.Errorf("cannot get the ssh private key from secret %s: %w", sshFetch.Secret.Name, err)
+	}
+	// the host key of the git server is never trusted without known hosts
+	knownHosts, err := p.k8int.GetSecret(ctx, ktypes.GetSecretOpt{
+		Namespace: repo.GetNamespace(),
+		Name:      sshFetch.Secret.Name,
+		Key:       sshKnownHostsSecretKey,
+	})
+	if err != nil || strings.TrimSpace(knownHosts) == "" {
+		return "", fmt.Errorf("no %s in secret %s to check the host key of %s", sshKnownHostsSecretKey, sshFetch.Secret.Name, remote)
+	}
+
+	revision := p.event.SHA
+	if provenance == "default_branch" {
+		revision = p.event.DefaultBranch
+	}
+	p.logger.Infof("Fetching the PipelineRun definitions over ssh from %s at %s", remote, revision)
+	opts := git.SSHArchiveOpts{
+		Remote:     remote,
+		Revision:   revision,
+		Path:       tektonDir,
+		PrivateKey: []byte(strings.TrimSpace(privateKey) + "\n"),
+		KnownHosts: []byte(knownHosts),
+	}
+	files, err := git.SSHArchive(ctx, opts)
+	if errors.Is(err, git.ErrArchiveNoSuchRef) && revision == p.event.SHA && p.event.HeadBranch != "" {
+		p.logger.Warnf("%v, fetching the head branch %s instead, it may have moved since the commit %s",
+			err, p.event.HeadBranch, p.event.SHA)
+		opts.Revision = p.event.HeadBranch
+		files, err = git.SSHArchive(ctx, opts)
+	}
+	if err != nil {
+		return "", err
+	}
+
+	paths := make([]string, 0, len(files))
+	for name := range files {
+		if ext := path.Ext(name); ext == ".yaml" || ext == ".yml" {
+			paths = append(paths, name)
+		}
+	}
+	slices.Sort(paths)
+	allTemplates := ""
+	for _, name := range paths {
+		if err := provider.ValidateYaml(files[name], name); err != nil {
+			return "", err
+		}
+		allTemplates = provider.AppendYamlFile(allTemplates, name, string(files[name]))
+	}
+	return allTemplates, nil
+}
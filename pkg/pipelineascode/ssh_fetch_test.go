@@ -0,0 +1,151 @@
+package pipelineascode
+
+import (
+	"context"
+	"errors"
+	"os"
+	"os/exec"
+	"strings"
+	"testing"
+
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/apis/pipelinesascode/v1alpha1"
+	pacerrors "github.com/openshift-pipelines/pipelines-as-code/pkg/errors"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/git"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/params"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/params/info"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/test/gitssh"
+	kitesthelper "github.com/openshift-pipelines/pipelines-as-code/pkg/test/kubernetestint"
+	"go.uber.org/zap"
+	zapobserver "go.uber.org/zap/zaptest/observer"
+	"gotest.tools/v3/assert"
+	"gotest.tools/v3/env"
+	"gotest.tools/v3/fs"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestGetTektonDirOverSSH(t *testing.T) {
+	if os.Getenv("TEST_SKIP_GIT") != "" {
+		t.Skip("Skipping Git test")
+		return
+	}
+	if gitPath, _ := exec.LookPath("git"); gitPath == "" {
+		t.Skip("could not find the git binary in path, skipping test")
+		return
+	}
+	tmpFile := fs.NewFile(t, "gitconfig-")
+	defer tmpFile.Remove()
+	defer env.PatchAll(t, map[string]string{
+		"HOME": tmpFile.Path(),
+		"PATH": "/usr/local/bin:/usr/bin:/bin:/usr/sbin:/sbin",
+	})()
+
+	remote := fs.NewDir(t, "TestGetTektonDirOverSSH",
+		fs.WithDir(".tekton",
+			fs.WithFile("push.yaml", "kind: PipelineRun\nmetadata:\n  name: push\n"),
+			fs.WithFile("pr.yaml", "kind: PipelineRun\nmetadata:\n  name: pr\n"),
+			fs.WithFile("README.md", "not yaml: [")))
+	defer remote.Remove()
+	for _, args := range [][]string{
+		{"init", "-b", "main"},
+		{"config", "--local", "user.email", "foo@foo.com"},
+		{"config", "--local", "user.name", "Mister Ze Foo"},
+		{"add", "."},
+		{"commit", "-m", "tekton"},
+		{"checkout", "-b", "broken"},
+	} {
+		_, err := git.RunGit(remote.Path(), args...)
+		assert.NilError(t, err)
+	}
+	assert.NilError(t, os.WriteFile(remote.Join(".tekton", "broken.yaml"), []byte("kind: [\n"), 0o600))
+	for _, args := range [][]string{{"add", "."}, {"commit", "-m", "broken"}} {
+		_, err := git.RunGit(remote.Path(), args...)
+		assert.NilError(t, err)
+	}
+	server := gitssh.NewServer(t, remote.Path())
+
+	tests := []struct {
+		name       string
+		event      *info.Event
+		provenance string
+		secret     *v1alpha1.Secret
+		wantNames  []string
+		wantErr    string
+		wantYAML   bool
+	}{
+		{
+			name:       "head branch when the sha is not a ref",
+			event:      &info.Event{SHA: "0123456789abcdef", HeadBranch: "main"},
+			provenance: "source",
+			secret:     &v1alpha1.Secret{Name: "ssh"},
+			wantNames:  []string{"name: pr", "name: push"},
+		},
+		{
+			name:       "default branch",
+			event:      &info.Event{SHA: "0123456789abcdef", DefaultBranch: "main"},
+			provenance: "default_branch",
+			secret:     &v1alpha1.Secret{Name: "ssh"},
+			wantNames:  []string{"name: pr", "name: push"},
+		},
+		{
+			name:       "invalid yaml",
+			event:      &info.Event{SHA: "0123456789abcdef", HeadBranch: "broken"},
+			provenance: "source",
+			secret:     &v1alpha1.Secret{Name: "ssh"},
+			wantYAML:   true,
+		},
+		{
+			name:       "missing secret",
+			event:      &info.Event{SHA: "0123456789abcdef", HeadBranch: "main"},
+			provenance: "source",
+			secret:     &v1alpha1.Secret{Name: "missing"},
+			wantErr:    "cannot get the ssh private key from secret missing",
+		},
+		{
+			name:       "no known hosts",
+			event:      &info.Event{SHA: "0123456789abcdef", HeadBranch: "main"},
+			provenance: "source",
+			secret:     &v1alpha1.Secret{Name: "nohosts"},
+			wantErr:    "no known_hosts in secret nohosts to check the host key of " + server.Remote,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			observer, _ := zapobserver.New(zap.InfoLevel)
+			logger := zap.New(observer).Sugar()
+			repo := &v1alpha1.Repository{
+				ObjectMeta: metav1.ObjectMeta{Name: "repo", Namespace: "ns"},
+				Spec: v1alpha1.RepositorySpec{
+					URL: "https://gitlab.example.com/group/repo",
+					GitProvider: &v1alpha1.GitProvider{
+						SSH: &v1alpha1.SSHFetch{Secret: tt.secret, URL: server.Remote},
+					},
+				},
+			}
+			k8int := &kitesthelper.KinterfaceTest{GetSecretKeyResult: map[string]string{
+				"ssh/ssh-privatekey":     string(server.PrivateKey),
+				"ssh/known_hosts":        string(server.KnownHosts),
+				"nohosts/ssh-privatekey": string(server.PrivateKey),
+			}}
+			p := NewPacs(tt.event, nil, &params.Run{}, &info.PacOpts{}, k8int, logger, nil)
+
+			got, err := p.getTektonDirOverSSH(context.Background(), repo, ".tekton", tt.provenance)
+			if tt.wantYAML {
+				var yamlErr *pacerrors.YamlError
+				assert.Assert(t, errors.As(err, &yamlErr), "got %v", err)
+				return
+			}
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			assert.NilError(t, err)
+			last := -1
+			for _, name := range tt.wantNames {
+				index := strings.Index(got, name)
+				assert.Assert(t, index > last, "%s not found in order in %s", name, got)
+				last = index
+			}
+			assert.Assert(t, !strings.Contains(got, "not yaml"))
+		})
+	}
+}
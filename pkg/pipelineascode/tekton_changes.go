@@ -0,0 +1,37 @@
+package pipelineascode
+
+import (
+	"context"
+	"slices"
+
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/apis/pipelinesascode/v1alpha1"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/params/triggertype"
+)
+
+// isGuardedTektonChange returns whether the event is a pull request changing
+// the .tekton directory from a sender who is not an owner of the repository,
+// the approvers and reviewers of the OWNERS file of the default branch or the
+// users of the ok_to_test policy. The PipelineRuns of such a pull request
+// could expose the secrets of the repository, the tekton_changes_policy
+// setting decides if they run.
+func (p *PacRun) isGuardedTektonChange(ctx context.Context, repo *v1alpha1.Repository) (bool, error) {
+	if p.event.TriggerTarget != triggertype.PullRequest || repo.Spec.GetTektonChangesPolicy() == v1alpha1.TektonChangesPolicyAllow {
+		return false, nil
+	}
+	files, err := p.vcx.GetFiles(ctx, p.event)
+	if err != nil {
+		return false, err
+	}
+	if !tektonDirChanged(files.All) {
+		return false, nil
+	}
+	if repo.Spec.Settings.Policy != nil && slices.Contains(repo.Spec.Settings.Policy.OkToTest, p.event.Sender) {
+		return false, nil
+	}
+	// without an OWNERS file nobody is an owner
+	owners, err := p.ownersApprovers(ctx)
+	if err != nil {
+		p.logger.Debugf("cannot get the owners from the OWNERS file: %v", err)
+	}
+	return !slices.Contains(owners, p.event.Sender), nil
+}
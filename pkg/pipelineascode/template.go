@@ -32,5 +32,9 @@ func (p *PacRun) makeTemplate(ctx context.Context, repo *v1alpha1.Repository, te
 		headers = p.event.Request.Header
 	}
 
+	for _, value := range maptemplate {
+		p.paramValues = append(p.paramValues, value)
+	}
+
 	return templates.ReplacePlaceHoldersVariables(template, maptemplate, p.event.Event, headers, changedFiles)
 }
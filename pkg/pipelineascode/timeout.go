@@ -0,0 +1,36 @@
+package pipelineascode
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/apis/pipelinesascode/keys"
+	tektonv1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// setPipelineRunTimeout sets the pipeline timeout of pr from the timeout
+// annotation. When the timeout is only set in the spec it is copied to the
+// annotation, for the watcher to tell the timeouts of the PipelineRun from the
+// default timeout of Tekton, which is set on the spec once created.
+func setPipelineRunTimeout(pr *tektonv1.PipelineRun) error {
+	if value, ok := pr.GetAnnotations()[keys.Timeout]; ok {
+		timeout, err := time.ParseDuration(value)
+		if err != nil || timeout < 0 {
+			return fmt.Errorf("invalid %s annotation %q on pipelinerun %s, it should be a duration like 1h30m", keys.Timeout, value, pr.GetGenerateName())
+		}
+		if pr.Spec.Timeouts == nil {
+			pr.Spec.Timeouts = &tektonv1.TimeoutFields{}
+		}
+		pr.Spec.Timeouts.Pipeline = &metav1.Duration{Duration: timeout}
+		return nil
+	}
+	if pr.Spec.Timeouts == nil || pr.Spec.Timeouts.Pipeline == nil {
+		return nil
+	}
+	if pr.Annotations == nil {
+		pr.Annotations = map[string]string{}
+	}
+	pr.Annotations[keys.Timeout] = pr.Spec.Timeouts.Pipeline.Duration.String()
+	return nil
+}
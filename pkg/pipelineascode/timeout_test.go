@@ -0,0 +1,72 @@
+package pipelineascode
+
+import (
+	"testing"
+	"time"
+
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/apis/pipelinesascode/keys"
+	tektonv1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+	"gotest.tools/v3/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestSetPipelineRunTimeout(t *testing.T) {
+	tests := []struct {
+		name           string
+		annotations    map[string]string
+		timeouts       *tektonv1.TimeoutFields
+		wantTimeouts   *tektonv1.TimeoutFields
+		wantAnnotation string
+		wantErr        string
+	}{
+		{
+			name:           "timeout from the annotation",
+			annotations:    map[string]string{keys.Timeout: "2h"},
+			wantTimeouts:   &tektonv1.TimeoutFields{Pipeline: &metav1.Duration{Duration: 2 * time.Hour}},
+			wantAnnotation: "2h",
+		},
+		{
+			name:        "annotation overrides the spec",
+			annotations: map[string]string{keys.Timeout: "30m"},
+			timeouts: &tektonv1.TimeoutFields{
+				Pipeline: &metav1.Duration{Duration: time.Hour},
+				Finally:  &metav1.Duration{Duration: 5 * time.Minute},
+			},
+			wantTimeouts: &tektonv1.TimeoutFields{
+				Pipeline: &metav1.Duration{Duration: 30 * time.Minute},
+				Finally:  &metav1.Duration{Duration: 5 * time.Minute},
+			},
+			wantAnnotation: "30m",
+		},
+		{
+			name:           "timeout of the spec copied to the annotation",
+			timeouts:       &tektonv1.TimeoutFields{Pipeline: &metav1.Duration{Duration: 90 * time.Minute}},
+			wantTimeouts:   &tektonv1.TimeoutFields{Pipeline: &metav1.Duration{Duration: 90 * time.Minute}},
+			wantAnnotation: "1h30m0s",
+		},
+		{
+			name: "no timeout",
+		},
+		{
+			name:        "invalid annotation",
+			annotations: map[string]string{keys.Timeout: "1 hour"},
+			wantErr:     `invalid pipelinesascode.tekton.dev/timeout annotation "1 hour" on pipelinerun pr-, it should be a duration like 1h30m`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pr := &tektonv1.PipelineRun{
+				ObjectMeta: metav1.ObjectMeta{GenerateName: "pr-", Annotations: tt.annotations},
+				Spec:       tektonv1.PipelineRunSpec{Timeouts: tt.timeouts},
+			}
+			err := setPipelineRunTimeout(pr)
+			if tt.wantErr != "" {
+				assert.Error(t, err, tt.wantErr)
+				return
+			}
+			assert.NilError(t, err)
+			assert.DeepEqual(t, pr.Spec.Timeouts, tt.wantTimeouts)
+			assert.Equal(t, pr.GetAnnotations()[keys.Timeout], tt.wantAnnotation)
+		})
+	}
+}
@@ -0,0 +1,75 @@
+package pipelineascode
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/apis/pipelinesascode/v1alpha1"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/matcher"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/opscomments"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/params/triggertype"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/provider"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/triggerwindows"
+	"go.uber.org/zap"
+)
+
+// formatNextTriggerWindow describes when the next trigger window opens.
+func formatNextTriggerWindow(next time.Time) string {
+	return fmt.Sprintf("the next trigger window of the repository opens on %s", next.Format("Mon, 02 Jan 2006 15:04 MST"))
+}
+
+// isOutsideTriggerWindows tells whether the event automatically triggering
+// the matched PipelineRuns has been received outside of the trigger windows
+// of the repository. The PipelineRuns are then either skipped, reported with
+// a neutral status, or created pending until the next window opens. The
+// GitOps comments and the incoming webhooks always run.
+func (p *PacRun) isOutsideTriggerWindows(ctx context.Context, repo *v1alpha1.Repository, matchedPRs []matcher.Match) bool {
+	if (p.event.TriggerTarget != triggertype.Push && p.event.TriggerTarget != triggertype.PullRequest) ||
+		p.event.EventType == triggertype.Incoming.String() || p.event.EventType == triggertype.PullRequestLabeled.String() ||
+		p.event.EventType == triggertype.PullRequestReview.String() || p.event.EventType == triggertype.PullRequestReviewComment.String() ||
+		opscomments.IsAnyOpsEventType(p.event.EventType) {
+		return false
+	}
+	open, next, err := triggerwindows.Check(repo, time.Now())
+	if err != nil {
+		p.eventEmitter.EmitMessage(repo, zap.WarnLevel, "RepositoryInvalidTriggerWindows", err.Error())
+		return false
+	}
+	if open {
+		return false
+	}
+	if triggerwindows.Outside(repo) == v1alpha1.TriggerWindowsOutsideQueue {
+		p.scheduled = true
+		p.nextTriggerWindow = next
+		return false
+	}
+
+	names := []string{}
+	for i, match := range matchedPRs {
+		name := strings.TrimSuffix(match.PipelineRun.GetGenerateName(), "-")
+		if name == "" {
+			name = match.PipelineRun.GetName()
+		}
+		names = append(names, name)
+		status := provider.StatusOpts{
+			Status:     CompletedStatus,
+			Title:      "Outside of the trigger windows",
+			Conclusion: neutralConclusion,
+			Text: fmt.Sprintf("PipelineRun %s has been skipped since the event has been received outside of the trigger windows of the repository, %s. Comment `/retest` to run it.",
+				name, formatNextTriggerWindow(next)),
+			DetailsURL:               p.eventOf(match).URL,
+			PipelineRun:              match.PipelineRun,
+			OriginalPipelineRunName:  name,
+			InstanceCountForCheckRun: i,
+		}
+		if err := p.vcx.CreateStatus(ctx, p.eventOf(match), status); err != nil {
+			p.eventEmitter.EmitFailure(repo, zap.WarnLevel, v1alpha1.FailureReasonProviderAPIError, "RepositoryCreateStatus",
+				fmt.Sprintf("cannot report the skipped pipelinerun %s: %s", name, err.Error()))
+		}
+	}
+	p.eventEmitter.EmitMessage(repo, zap.InfoLevel, "RepositoryOutsideTriggerWindows",
+		fmt.Sprintf("skipping the pipelineruns %s of sha %s outside of the trigger windows", strings.Join(names, ", "), p.event.SHA))
+	return true
+}
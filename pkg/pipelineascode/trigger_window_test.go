@@ -0,0 +1,120 @@
+package pipelineascode
+
+import (
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/apis/pipelinesascode/v1alpha1"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/events"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/matcher"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/opscomments"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/params/info"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/params/triggertype"
+	testclient "github.com/openshift-pipelines/pipelines-as-code/pkg/test/clients"
+	tektonv1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+	"go.uber.org/zap"
+	zapobserver "go.uber.org/zap/zaptest/observer"
+	"gotest.tools/v3/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	rtesting "knative.dev/pkg/reconciler/testing"
+)
+
+func TestIsOutsideTriggerWindows(t *testing.T) {
+	// a window in three days is never open now
+	closed := []v1alpha1.TriggerWindow{{Days: strconv.Itoa((int(time.Now().UTC().Weekday()) + 3) % 7), Start: "00:00", End: "01:00"}}
+	always := []v1alpha1.TriggerWindow{{Start: "00:00", End: "00:00"}}
+	tests := []struct {
+		name          string
+		event         *info.Event
+		windows       *v1alpha1.TriggerWindows
+		wantOutside   bool
+		wantScheduled bool
+		wantStatuses  int
+		wantLog       string
+	}{
+		{
+			name:  "no trigger windows",
+			event: &info.Event{TriggerTarget: triggertype.Push, EventType: "push"},
+		},
+		{
+			name:    "inside a window",
+			event:   &info.Event{TriggerTarget: triggertype.Push, EventType: "push"},
+			windows: &v1alpha1.TriggerWindows{Windows: always},
+		},
+		{
+			name:         "push outside of the windows",
+			event:        &info.Event{TriggerTarget: triggertype.Push, EventType: "push", SHA: "sha"},
+			windows:      &v1alpha1.TriggerWindows{Windows: closed},
+			wantOutside:  true,
+			wantStatuses: 1,
+			wantLog:      "skipping the pipelineruns pull-request of sha sha outside of the trigger windows",
+		},
+		{
+			name:          "queued outside of the windows",
+			event:         &info.Event{TriggerTarget: triggertype.PullRequest, EventType: "pull_request"},
+			windows:       &v1alpha1.TriggerWindows{Windows: closed, Outside: v1alpha1.TriggerWindowsOutsideQueue},
+			wantScheduled: true,
+		},
+		{
+			name:    "retest comment",
+			event:   &info.Event{TriggerTarget: triggertype.PullRequest, EventType: opscomments.RetestAllCommentEventType.String()},
+			windows: &v1alpha1.TriggerWindows{Windows: closed},
+		},
+		{
+			name:    "incoming webhook",
+			event:   &info.Event{TriggerTarget: triggertype.Push, EventType: triggertype.Incoming.String()},
+			windows: &v1alpha1.TriggerWindows{Windows: closed},
+		},
+		{
+			name:    "invalid windows",
+			event:   &info.Event{TriggerTarget: triggertype.Push, EventType: "push"},
+			windows: &v1alpha1.TriggerWindows{Windows: []v1alpha1.TriggerWindow{{Start: "9am", End: "17:00"}}},
+			wantLog: `invalid trigger_windows of repository repo: invalid time "9am", it needs to be like 09:00`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, _ := rtesting.SetupFakeContext(t)
+			observer, logs := zapobserver.New(zap.InfoLevel)
+			logger := zap.New(observer).Sugar()
+			stdata, _ := testclient.SeedTestData(t, ctx, testclient.Data{})
+			repo := &v1alpha1.Repository{
+				ObjectMeta: metav1.ObjectMeta{Name: "repo", Namespace: "ns"},
+				Spec:       v1alpha1.RepositorySpec{Settings: &v1alpha1.Settings{TriggerWindows: tt.windows}},
+			}
+			matches := []matcher.Match{
+				{PipelineRun: &tektonv1.PipelineRun{ObjectMeta: metav1.ObjectMeta{GenerateName: "pull-request-"}}},
+			}
+			vcx := &statusRecorder{}
+			p := &PacRun{
+				event:        tt.event,
+				vcx:          vcx,
+				logger:       logger,
+				eventEmitter: events.NewEventEmitter(stdata.Kube, logger),
+			}
+
+			assert.Equal(t, p.isOutsideTriggerWindows(ctx, repo, matches), tt.wantOutside)
+			assert.Equal(t, p.scheduled, tt.wantScheduled)
+			if tt.wantScheduled {
+				assert.Assert(t, p.nextTriggerWindow.After(time.Now()))
+			}
+			assert.Equal(t, len(vcx.statuses), tt.wantStatuses)
+			if tt.wantStatuses > 0 {
+				assert.Equal(t, vcx.statuses[0].Conclusion, neutralConclusion)
+				assert.Assert(t, strings.HasPrefix(vcx.statuses[0].Text,
+					"PipelineRun pull-request has been skipped since the event has been received outside of the trigger windows of the repository, the next trigger window of the repository opens on "),
+					vcx.statuses[0].Text)
+			}
+			if tt.wantLog != "" {
+				assert.Equal(t, logs.FilterMessage(tt.wantLog).Len(), 1, logs.All())
+			}
+		})
+	}
+}
+
+func TestFormatNextTriggerWindow(t *testing.T) {
+	assert.Equal(t, formatNextTriggerWindow(time.Date(2026, 10, 19, 9, 0, 0, 0, time.UTC)),
+		"the next trigger window of the repository opens on Mon, 19 Oct 2026 09:00 UTC")
+}
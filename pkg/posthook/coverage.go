@@ -0,0 +1,220 @@
+package posthook
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/apis/pipelinesascode/keys"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/apis/pipelinesascode/v1alpha1"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/formatting"
+	kstatus "github.com/openshift-pipelines/pipelines-as-code/pkg/kubeinteraction/status"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/params/triggertype"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/pipelineascode"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/provider"
+	tektonv1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// CoverageHookName is the name of the hook reporting the code coverage.
+	CoverageHookName = "coverage"
+	// CoverageResult is the task result read by the coverage hook, unless
+	// another one is set with the coverage-result annotation.
+	CoverageResult = "COVERAGE"
+)
+
+// coverageHook reports the coverage of the PipelineRuns of the pull requests
+// as a comment, compared to the coverage of their target branch which is
+// stored in the Repository status by the PipelineRuns of the push events.
+type coverageHook struct{}
+
+func (coverageHook) Run(ctx context.Context, opts Options) error {
+	annotations := opts.PipelineRun.GetAnnotations()
+	resultName := annotations[keys.CoverageResult]
+	if resultName == "" {
+		resultName = CoverageResult
+	}
+	trStatus := kstatus.GetStatusFromTaskStatusOrFromAsking(ctx, opts.PipelineRun, opts.Run)
+	coverage, found, err := coverageFromTaskRuns(trStatus, resultName)
+	if err != nil {
+		return err
+	}
+	if !found {
+		opts.Logger.Debugf("no %s task result in pipelinerun %s, skipping the coverage report", resultName, opts.PipelineRun.GetName())
+		return nil
+	}
+
+	var threshold *float64
+	if value, ok := annotations[keys.CoverageThreshold]; ok {
+		t, err := parseCoverage(value)
+		if err != nil {
+			return fmt.Errorf("invalid %s annotation: %w", keys.CoverageThreshold, err)
+		}
+		threshold = &t
+	}
+
+	name := annotations[keys.OriginalPRName]
+	if name == "" {
+		name = opts.PipelineRun.GetName()
+	}
+	branch := formatting.SanitizeBranch(opts.Event.BaseBranch)
+
+	// the base branch the coverage is compared to, only for the pull requests
+	baseBranch := ""
+	var base *float64
+	switch opts.Event.TriggerTarget {
+	case triggertype.Push:
+		if err := storeBranchCoverage(ctx, opts, v1alpha1.BranchCoverage{
+			Branch:     branch,
+			Percentage: formatCoverage(coverage),
+			SHA:        opts.Event.SHA,
+		}); err != nil {
+			return err
+		}
+	case triggertype.PullRequest:
+		baseBranch = branch
+		if opts.Repository.RepositoryStatus != nil {
+			for _, bc := range opts.Repository.RepositoryStatus.Coverage {
+				if bc.Branch != branch {
+					continue
+				}
+				if b, err := parseCoverage(bc.Percentage); err == nil {
+					base = &b
+				}
+			}
+		}
+		marker := fmt.Sprintf("<!-- pipelines-as-code: coverage %s -->", name)
+		comment := coverageComment(name, baseBranch, coverage, base, threshold)
+		if err := opts.Provider.CreateComment(ctx, opts.Event, comment+"\n\n"+marker, marker); err != nil {
+			return fmt.Errorf("cannot comment the coverage on the pull request: %w", err)
+		}
+	default:
+		return nil
+	}
+
+	if threshold == nil {
+		return nil
+	}
+	conclusion := "success"
+	if coverage < *threshold {
+		conclusion = "failure"
+	}
+	return opts.Provider.CreateStatus(ctx, opts.Event, provider.StatusOpts{
+		Status:                  pipelineascode.CompletedStatus,
+		Conclusion:              conclusion,
+		Title:                   fmt.Sprintf("Coverage: %s%% (threshold %s%%)", formatCoverage(coverage), formatCoverage(*threshold)),
+		Text:                    coverageComment(name, baseBranch, coverage, base, threshold),
+		PipelineRunName:         opts.PipelineRun.GetName() + "-coverage",
+		OriginalPipelineRunName: name + "/coverage",
+		DetailsURL:              opts.Run.Clients.ConsoleUI().DetailURL(opts.PipelineRun),
+	})
+}
+
+// parseCoverage parses a percentage like 83.4 or 83.4%.
+func parseCoverage(value string) (float64, error) {
+	value = strings.TrimSuffix(strings.TrimSpace(value), "%")
+	coverage, err := strconv.ParseFloat(strings.TrimSpace(value), 64)
+	if err != nil {
+		return 0, fmt.Errorf("%q is not a percentage", value)
+	}
+	if coverage < 0 || coverage > 100 {
+		return 0, fmt.Errorf("%s is not between 0 and 100", value)
+	}
+	return coverage, nil
+}
+
+func formatCoverage(coverage float64) string {
+	return strconv.FormatFloat(coverage, 'f', -1, 64)
+}
+
+// coverageFromTaskRuns returns the coverage reported in the result of the
+// TaskRuns, only one task of the PipelineRun can report it.
+func coverageFromTaskRuns(trStatus map[string]*tektonv1.PipelineRunTaskRunStatus, resultName string) (float64, bool, error) {
+	tasks := []string{}
+	var coverage float64
+	for _, tr := range trStatus {
+		if tr == nil || tr.Status == nil {
+			continue
+		}
+		for _, result := range tr.Status.Results {
+			if result.Name != resultName {
+				continue
+			}
+			c, err := parseCoverage(result.Value.StringVal)
+			if err != nil {
+				return 0, false, fmt.Errorf("cannot parse the %s result of task %s: %w", resultName, tr.PipelineTaskName, err)
+			}
+			coverage = c
+			tasks = append(tasks, tr.PipelineTaskName)
+		}
+	}
+	if len(tasks) > 1 {
+		sort.Strings(tasks)
+		return 0, false, fmt.Errorf("the %s result is reported by the tasks %s, only one task can report the coverage", resultName, strings.Join(tasks, ", "))
+	}
+	return coverage, len(tasks) == 1, nil
+}
+
+// coverageComment renders the coverage report, compared to the base branch
+// when it is set.
+func coverageComment(name, baseBranch string, coverage float64, base, threshold *float64) string {
+	var text strings.Builder
+	fmt.Fprintf(&text, "### Coverage of %s\n\n", name)
+	fmt.Fprintf(&text, "**Coverage:** %s%%\n", formatCoverage(coverage))
+	if base != nil {
+		delta := strconv.FormatFloat(coverage-*base, 'f', 2, 64)
+		if !strings.HasPrefix(delta, "-") {
+			delta = "+" + delta
+		}
+		fmt.Fprintf(&text, "\n**Delta:** %s%% compared to %s%% on `%s`\n", delta, formatCoverage(*base), baseBranch)
+	} else if baseBranch != "" {
+		fmt.Fprintf(&text, "\n**Delta:** no coverage has been reported on `%s` yet\n", baseBranch)
+	}
+	if threshold != nil {
+		result := ":white_check_mark: passed"
+		if coverage < *threshold {
+			result = ":x: failed"
+		}
+		fmt.Fprintf(&text, "\n**Threshold:** %s%% %s\n", formatCoverage(*threshold), result)
+	}
+	return text.String()
+}
+
+// setBranchCoverage replaces the coverage of the branch in the list.
+func setBranchCoverage(coverages []v1alpha1.BranchCoverage, coverage v1alpha1.BranchCoverage) []v1alpha1.BranchCoverage {
+	updated := []v1alpha1.BranchCoverage{}
+	for _, bc := range coverages {
+		if bc.Branch != coverage.Branch {
+			updated = append(updated, bc)
+		}
+	}
+	updated = append(updated, coverage)
+	sort.Slice(updated, func(i, j int) bool { return updated[i].Branch < updated[j].Branch })
+	return updated
+}
+
+// storeBranchCoverage stores the coverage of the branch in the Repository
+// status, retrying on conflicts with the other updates of the status.
+func storeBranchCoverage(ctx context.Context, opts Options, coverage v1alpha1.BranchCoverage) error {
+	repositories := opts.Run.Clients.PipelineAsCode.PipelinesascodeV1alpha1().Repositories(opts.Repository.GetNamespace())
+	maxRun := 10
+	for i := 0; i < maxRun; i++ {
+		repo, err := repositories.Get(ctx, opts.Repository.GetName(), metav1.GetOptions{})
+		if err != nil {
+			return err
+		}
+		if repo.RepositoryStatus == nil {
+			repo.RepositoryStatus = &v1alpha1.RepositoryStatus{}
+		}
+		repo.RepositoryStatus.Coverage = setBranchCoverage(repo.RepositoryStatus.Coverage, coverage)
+		if _, err := repositories.UpdateStatus(ctx, repo, metav1.UpdateOptions{}); err != nil {
+			opts.Logger.Infof("Could not store the coverage of branch %s in repo %s, retrying %d/%d: %s", coverage.Branch, repo.GetName(), i, maxRun, err.Error())
+			continue
+		}
+		return nil
+	}
+	return fmt.Errorf("cannot store the coverage of branch %s in the status of %s", coverage.Branch, opts.Repository.GetName())
+}
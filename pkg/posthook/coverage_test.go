@@ -0,0 +1,166 @@
+package posthook
+
+import (
+	"testing"
+
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/apis/pipelinesascode/v1alpha1"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/params"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/params/clients"
+	testclient "github.com/openshift-pipelines/pipelines-as-code/pkg/test/clients"
+	tektonv1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+	"go.uber.org/zap"
+	"gotest.tools/v3/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	rtesting "knative.dev/pkg/reconciler/testing"
+)
+
+func TestCoverageFromTaskRuns(t *testing.T) {
+	tests := []struct {
+		name       string
+		taskRuns   map[string]*tektonv1.PipelineRunTaskRunStatus
+		resultName string
+		wantFound  bool
+		want       float64
+		wantErr    string
+	}{
+		{
+			name: "coverage of one task",
+			taskRuns: map[string]*tektonv1.PipelineRunTaskRunStatus{
+				"tr-test":  taskRunWithResults("unit-tests", map[string]string{CoverageResult: "83.4%\n"}),
+				"tr-build": taskRunWithResults("build", map[string]string{"IMAGE_DIGEST": "sha256:1234"}),
+				"tr-nil":   nil,
+			},
+			resultName: CoverageResult,
+			wantFound:  true,
+			want:       83.4,
+		},
+		{
+			name: "result set by annotation",
+			taskRuns: map[string]*tektonv1.PipelineRunTaskRunStatus{
+				"tr-test": taskRunWithResults("unit-tests", map[string]string{"GO_COVERAGE": "71"}),
+			},
+			resultName: "GO_COVERAGE",
+			wantFound:  true,
+			want:       71,
+		},
+		{
+			name: "no coverage",
+			taskRuns: map[string]*tektonv1.PipelineRunTaskRunStatus{
+				"tr-test": taskRunWithResults("unit-tests", map[string]string{"GO_COVERAGE": "71"}),
+			},
+			resultName: CoverageResult,
+		},
+		{
+			name: "invalid coverage",
+			taskRuns: map[string]*tektonv1.PipelineRunTaskRunStatus{
+				"tr-test": taskRunWithResults("unit-tests", map[string]string{CoverageResult: "120"}),
+			},
+			resultName: CoverageResult,
+			wantErr:    "cannot parse the COVERAGE result of task unit-tests: 120 is not between 0 and 100",
+		},
+		{
+			name: "coverage of several tasks",
+			taskRuns: map[string]*tektonv1.PipelineRunTaskRunStatus{
+				"tr-go":   taskRunWithResults("go-tests", map[string]string{CoverageResult: "80"}),
+				"tr-node": taskRunWithResults("node-tests", map[string]string{CoverageResult: "60"}),
+			},
+			resultName: CoverageResult,
+			wantErr:    "the COVERAGE result is reported by the tasks go-tests, node-tests, only one task can report the coverage",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			coverage, found, err := coverageFromTaskRuns(tt.taskRuns, tt.resultName)
+			if tt.wantErr != "" {
+				assert.Error(t, err, tt.wantErr)
+				return
+			}
+			assert.NilError(t, err)
+			assert.Equal(t, found, tt.wantFound)
+			assert.Equal(t, coverage, tt.want)
+		})
+	}
+}
+
+func TestCoverageComment(t *testing.T) {
+	base := 80.5
+	threshold := 82.0
+	tests := []struct {
+		name       string
+		baseBranch string
+		coverage   float64
+		base       *float64
+		threshold  *float64
+		want       string
+	}{
+		{
+			name:       "increased coverage passing the threshold",
+			baseBranch: "main",
+			coverage:   83.4,
+			base:       &base,
+			threshold:  &threshold,
+			want: "### Coverage of unit\n\n**Coverage:** 83.4%\n\n" +
+				"**Delta:** +2.90% compared to 80.5% on `main`\n\n" +
+				"**Threshold:** 82% :white_check_mark: passed\n",
+		},
+		{
+			name:       "decreased coverage failing the threshold",
+			baseBranch: "main",
+			coverage:   79,
+			base:       &base,
+			threshold:  &threshold,
+			want: "### Coverage of unit\n\n**Coverage:** 79%\n\n" +
+				"**Delta:** -1.50% compared to 80.5% on `main`\n\n" +
+				"**Threshold:** 82% :x: failed\n",
+		},
+		{
+			name:       "no coverage on the base branch",
+			baseBranch: "main",
+			coverage:   79,
+			want:       "### Coverage of unit\n\n**Coverage:** 79%\n\n**Delta:** no coverage has been reported on `main` yet\n",
+		},
+		{
+			name:     "no base branch",
+			coverage: 79,
+			want:     "### Coverage of unit\n\n**Coverage:** 79%\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, coverageComment("unit", tt.baseBranch, tt.coverage, tt.base, tt.threshold), tt.want)
+		})
+	}
+}
+
+func TestStoreBranchCoverage(t *testing.T) {
+	ctx, _ := rtesting.SetupFakeContext(t)
+	repo := &v1alpha1.Repository{
+		ObjectMeta: metav1.ObjectMeta{Name: "repo", Namespace: "ns"},
+		Spec:       v1alpha1.RepositorySpec{URL: "https://github.com/owner/repo"},
+		RepositoryStatus: &v1alpha1.RepositoryStatus{
+			LastStatus: "Succeeded",
+			Coverage: []v1alpha1.BranchCoverage{
+				{Branch: "release", Percentage: "70", SHA: "abc"},
+				{Branch: "main", Percentage: "80", SHA: "def"},
+			},
+		},
+	}
+	stdata, _ := testclient.SeedTestData(t, ctx, testclient.Data{Repositories: []*v1alpha1.Repository{repo}})
+	opts := Options{
+		Run:        &params.Run{Clients: clients.Clients{PipelineAsCode: stdata.PipelineAsCode}},
+		Repository: repo,
+		Logger:     zap.NewNop().Sugar(),
+	}
+
+	assert.NilError(t, storeBranchCoverage(ctx, opts, v1alpha1.BranchCoverage{Branch: "main", Percentage: "83.4", SHA: "123"}))
+	assert.NilError(t, storeBranchCoverage(ctx, opts, v1alpha1.BranchCoverage{Branch: "feature", Percentage: "50", SHA: "456"}))
+
+	got, err := stdata.PipelineAsCode.PipelinesascodeV1alpha1().Repositories("ns").Get(ctx, "repo", metav1.GetOptions{})
+	assert.NilError(t, err)
+	assert.Equal(t, got.RepositoryStatus.LastStatus, "Succeeded")
+	assert.DeepEqual(t, got.RepositoryStatus.Coverage, []v1alpha1.BranchCoverage{
+		{Branch: "feature", Percentage: "50", SHA: "456"},
+		{Branch: "main", Percentage: "83.4", SHA: "123"},
+		{Branch: "release", Percentage: "70", SHA: "abc"},
+	})
+}
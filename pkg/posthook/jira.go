@@ -0,0 +1,57 @@
+package posthook
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/apis/pipelinesascode/keys"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/formatting"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/issuetracker"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// JiraHookName is the name of the hook commenting on the Jira issues.
+const JiraHookName = "jira"
+
+// jiraHook comments the result of the PipelineRuns on the Jira issue of their
+// ticket, for the repositories with a Jira issue tracker integration.
+type jiraHook struct{}
+
+func (jiraHook) Run(ctx context.Context, opts Options) error {
+	tracker := issuetracker.Get(opts.Repository)
+	if tracker == nil || tracker.Jira == nil {
+		return nil
+	}
+	ticketID := opts.PipelineRun.GetAnnotations()[keys.TicketID]
+	if ticketID == "" {
+		opts.Logger.Debugf("no ticket found for pipelinerun %s, skipping the jira comment", opts.PipelineRun.GetName())
+		return nil
+	}
+	jira := tracker.Jira
+	if jira.Secret == nil {
+		return fmt.Errorf("the jira integration of repository %s has no secret", opts.Repository.GetName())
+	}
+	key := jira.Secret.Key
+	if key == "" {
+		key = issuetracker.DefaultJiraTokenKey
+	}
+	secret, err := opts.Run.Clients.Kube.CoreV1().Secrets(opts.Repository.GetNamespace()).Get(ctx, jira.Secret.Name, metav1.GetOptions{})
+	if err != nil {
+		return fmt.Errorf("cannot get the jira secret %s: %w", jira.Secret.Name, err)
+	}
+	token := strings.TrimSpace(string(secret.Data[key]))
+	if token == "" {
+		return fmt.Errorf("the jira secret %s has no %s key", jira.Secret.Name, key)
+	}
+
+	name := opts.PipelineRun.GetAnnotations()[keys.OriginalPRName]
+	if name == "" {
+		name = opts.PipelineRun.GetName()
+	}
+	comment := fmt.Sprintf("PipelineRun %s of %s has completed with the status %s on commit %s.\n%s",
+		name, opts.Event.URL, formatting.PipelineRunStatus(opts.PipelineRun), opts.Event.SHA,
+		opts.Run.Clients.ConsoleUI().DetailURL(opts.PipelineRun))
+	return issuetracker.CommentJira(ctx, http.DefaultClient, jira, token, ticketID, comment)
+}
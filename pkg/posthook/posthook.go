@@ -0,0 +1,92 @@
+package posthook
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/apis/pipelinesascode/v1alpha1"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/params"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/params/info"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/provider"
+	tektonv1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+	"go.uber.org/zap"
+)
+
+// Options are passed to the hooks once a PipelineRun has completed and its
+// final status has been reported to the provider.
+type Options struct {
+	Run         *params.Run
+	Event       *info.Event
+	Repository  *v1alpha1.Repository
+	PipelineRun *tektonv1.PipelineRun
+	Provider    provider.Interface
+	Logger      *zap.SugaredLogger
+}
+
+// Hook is an integration run by the watcher on the completed PipelineRuns.
+type Hook interface {
+	Run(ctx context.Context, opts Options) error
+}
+
+var (
+	mutex sync.RWMutex
+	hooks = map[string]Hook{
+		CoverageHookName: coverageHook{},
+		SBOMHookName:     sbomHook{},
+		JiraHookName:     jiraHook{},
+	}
+)
+
+// Register adds a hook which can be enabled with its name in the
+// post-run-hooks setting of the global ConfigMap.
+func Register(name string, hook Hook) {
+	mutex.Lock()
+	defer mutex.Unlock()
+	hooks[name] = hook
+}
+
+// Names returns the sorted names of the registered hooks.
+func Names() []string {
+	mutex.RLock()
+	defer mutex.RUnlock()
+	names := make([]string, 0, len(hooks))
+	for name := range hooks {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// ParseNames returns the hook names of a comma separated list.
+func ParseNames(value string) []string {
+	names := []string{}
+	for _, name := range strings.Split(value, ",") {
+		if name = strings.TrimSpace(name); name != "" {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+// Run runs the enabled hooks in order, a failing hook doesn't prevent the next
+// ones from running and the errors are returned together.
+func Run(ctx context.Context, enabled []string, opts Options) error {
+	var errs []error
+	for _, name := range enabled {
+		mutex.RLock()
+		hook, ok := hooks[name]
+		mutex.RUnlock()
+		if !ok {
+			errs = append(errs, fmt.Errorf("unknown post-run hook %s, available hooks are: %s", name, strings.Join(Names(), ", ")))
+			continue
+		}
+		if err := hook.Run(ctx, opts); err != nil {
+			errs = append(errs, fmt.Errorf("post-run hook %s has failed: %w", name, err))
+		}
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,74 @@
+package posthook
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"gotest.tools/v3/assert"
+)
+
+type fakeHook struct {
+	calls *[]string
+	name  string
+	err   error
+}
+
+func (f fakeHook) Run(_ context.Context, _ Options) error {
+	*f.calls = append(*f.calls, f.name)
+	return f.err
+}
+
+func TestRun(t *testing.T) {
+	calls := []string{}
+	Register("fake-ok", fakeHook{calls: &calls, name: "fake-ok"})
+	Register("fake-failing", fakeHook{calls: &calls, name: "fake-failing", err: fmt.Errorf("cannot upload")})
+	defer func() {
+		mutex.Lock()
+		delete(hooks, "fake-ok")
+		delete(hooks, "fake-failing")
+		mutex.Unlock()
+	}()
+
+	tests := []struct {
+		name      string
+		enabled   string
+		wantCalls []string
+		wantErr   string
+	}{
+		{
+			name:      "hooks run in order",
+			enabled:   "fake-ok, fake-ok",
+			wantCalls: []string{"fake-ok", "fake-ok"},
+		},
+		{
+			name:      "failing hook doesn't stop the next ones",
+			enabled:   "fake-failing,fake-ok",
+			wantCalls: []string{"fake-failing", "fake-ok"},
+			wantErr:   "post-run hook fake-failing has failed: cannot upload",
+		},
+		{
+			name:      "unknown hook",
+			enabled:   "unknown,fake-ok",
+			wantCalls: []string{"fake-ok"},
+			wantErr:   "unknown post-run hook unknown, available hooks are: coverage, fake-failing, fake-ok, jira, sbom",
+		},
+		{
+			name:      "no hooks",
+			enabled:   " , ",
+			wantCalls: []string{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			calls = []string{}
+			err := Run(context.Background(), ParseNames(tt.enabled), Options{})
+			if tt.wantErr != "" {
+				assert.Error(t, err, tt.wantErr)
+			} else {
+				assert.NilError(t, err)
+			}
+			assert.DeepEqual(t, calls, tt.wantCalls)
+		})
+	}
+}
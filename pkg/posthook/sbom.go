@@ -0,0 +1,140 @@
+package posthook
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/apis/pipelinesascode/keys"
+	kstatus "github.com/openshift-pipelines/pipelines-as-code/pkg/kubeinteraction/status"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/pipelineascode"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/provider"
+	tektonv1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+)
+
+const (
+	// SBOMHookName is the name of the hook reporting the SBOM summary.
+	SBOMHookName = "sbom"
+	// SBOMSummaryResult is the task result read by the SBOM hook.
+	SBOMSummaryResult = "SBOM_SUMMARY"
+)
+
+// vulnerabilitySeverities are the severities reported, in order.
+var vulnerabilitySeverities = []string{"critical", "high", "medium", "low", "unknown"}
+
+// sbomSummary is the JSON value of the SBOM_SUMMARY task result, written by
+// the SBOM and licence scanning tasks.
+type sbomSummary struct {
+	Components      int            `json:"components"`
+	Licenses        map[string]int `json:"licenses,omitempty"`
+	Vulnerabilities map[string]int `json:"vulnerabilities,omitempty"`
+}
+
+func (s *sbomSummary) add(other sbomSummary) {
+	s.Components += other.Components
+	for license, count := range other.Licenses {
+		s.Licenses[license] += count
+	}
+	for severity, count := range other.Vulnerabilities {
+		s.Vulnerabilities[strings.ToLower(severity)] += count
+	}
+}
+
+// sbomHook reports the counts of the SBOM_SUMMARY task results of the
+// PipelineRun as a status of its own.
+type sbomHook struct{}
+
+func (sbomHook) Run(ctx context.Context, opts Options) error {
+	trStatus := kstatus.GetStatusFromTaskStatusOrFromAsking(ctx, opts.PipelineRun, opts.Run)
+	summary, found, err := sbomSummaryFromTaskRuns(trStatus)
+	if err != nil {
+		return err
+	}
+	if !found {
+		opts.Logger.Debugf("no %s task result in pipelinerun %s, skipping the SBOM report", SBOMSummaryResult, opts.PipelineRun.GetName())
+		return nil
+	}
+
+	name := opts.PipelineRun.GetAnnotations()[keys.OriginalPRName]
+	if name == "" {
+		name = opts.PipelineRun.GetName()
+	}
+	status := provider.StatusOpts{
+		Status:                  pipelineascode.CompletedStatus,
+		Conclusion:              "neutral",
+		Title:                   fmt.Sprintf("SBOM: %d components", summary.Components),
+		Text:                    summary.markdown(),
+		PipelineRunName:         opts.PipelineRun.GetName() + "-sbom",
+		OriginalPipelineRunName: name + "/sbom",
+		DetailsURL:              opts.Run.Clients.ConsoleUI().DetailURL(opts.PipelineRun),
+	}
+	return opts.Provider.CreateStatus(ctx, opts.Event, status)
+}
+
+// sbomSummaryFromTaskRuns sums the SBOM_SUMMARY results of the TaskRuns, it
+// returns false when none of them has the result.
+func sbomSummaryFromTaskRuns(trStatus map[string]*tektonv1.PipelineRunTaskRunStatus) (*sbomSummary, bool, error) {
+	summary := &sbomSummary{Licenses: map[string]int{}, Vulnerabilities: map[string]int{}}
+	found := false
+	for _, tr := range trStatus {
+		if tr == nil || tr.Status == nil {
+			continue
+		}
+		for _, result := range tr.Status.Results {
+			if result.Name != SBOMSummaryResult {
+				continue
+			}
+			var taskSummary sbomSummary
+			if err := json.Unmarshal([]byte(result.Value.StringVal), &taskSummary); err != nil {
+				return nil, false, fmt.Errorf("cannot parse the %s result of task %s: %w", SBOMSummaryResult, tr.PipelineTaskName, err)
+			}
+			summary.add(taskSummary)
+			found = true
+		}
+	}
+	return summary, found, nil
+}
+
+func (s *sbomSummary) markdown() string {
+	var text strings.Builder
+	fmt.Fprintf(&text, "**Components:** %d\n", s.Components)
+
+	if len(s.Vulnerabilities) > 0 {
+		text.WriteString("\n| Severity | Vulnerabilities |\n|---|---|\n")
+		severities := append([]string{}, vulnerabilitySeverities...)
+		others := []string{}
+		for severity := range s.Vulnerabilities {
+			if !slices.Contains(vulnerabilitySeverities, severity) {
+				others = append(others, severity)
+			}
+		}
+		sort.Strings(others)
+		for _, severity := range append(severities, others...) {
+			if count, ok := s.Vulnerabilities[severity]; ok {
+				fmt.Fprintf(&text, "| %s | %d |\n", severity, count)
+			}
+		}
+	}
+
+	if len(s.Licenses) > 0 {
+		licenses := make([]string, 0, len(s.Licenses))
+		for license := range s.Licenses {
+			licenses = append(licenses, license)
+		}
+		// the most used licences first
+		sort.Slice(licenses, func(i, j int) bool {
+			if s.Licenses[licenses[i]] != s.Licenses[licenses[j]] {
+				return s.Licenses[licenses[i]] > s.Licenses[licenses[j]]
+			}
+			return licenses[i] < licenses[j]
+		})
+		text.WriteString("\n| Licence | Components |\n|---|---|\n")
+		for _, license := range licenses {
+			fmt.Fprintf(&text, "| %s | %d |\n", license, s.Licenses[license])
+		}
+	}
+	return text.String()
+}
@@ -0,0 +1,80 @@
+package posthook
+
+import (
+	"testing"
+
+	tektonv1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+	"gotest.tools/v3/assert"
+)
+
+func taskRunWithResults(task string, results map[string]string) *tektonv1.PipelineRunTaskRunStatus {
+	status := &tektonv1.TaskRunStatus{}
+	for name, value := range results {
+		status.Results = append(status.Results, tektonv1.TaskRunResult{
+			Name:  name,
+			Value: *tektonv1.NewStructuredValues(value),
+		})
+	}
+	return &tektonv1.PipelineRunTaskRunStatus{PipelineTaskName: task, Status: status}
+}
+
+func TestSBOMSummaryFromTaskRuns(t *testing.T) {
+	tests := []struct {
+		name      string
+		taskRuns  map[string]*tektonv1.PipelineRunTaskRunStatus
+		wantFound bool
+		want      string
+		wantErr   string
+	}{
+		{
+			name: "summary of one task",
+			taskRuns: map[string]*tektonv1.PipelineRunTaskRunStatus{
+				"tr-sbom": taskRunWithResults("sbom", map[string]string{
+					SBOMSummaryResult: `{"components": 3, "licenses": {"MIT": 1, "Apache-2.0": 2}, "vulnerabilities": {"High": 1, "critical": 0, "negligible": 4}}`,
+				}),
+				"tr-build": taskRunWithResults("build", map[string]string{"IMAGE_DIGEST": "sha256:1234"}),
+			},
+			wantFound: true,
+			want: "**Components:** 3\n\n" +
+				"| Severity | Vulnerabilities |\n|---|---|\n| critical | 0 |\n| high | 1 |\n| negligible | 4 |\n\n" +
+				"| Licence | Components |\n|---|---|\n| Apache-2.0 | 2 |\n| MIT | 1 |\n",
+		},
+		{
+			name: "summaries of several tasks added up",
+			taskRuns: map[string]*tektonv1.PipelineRunTaskRunStatus{
+				"tr-go":   taskRunWithResults("sbom-go", map[string]string{SBOMSummaryResult: `{"components": 2, "licenses": {"MIT": 2}}`}),
+				"tr-node": taskRunWithResults("sbom-node", map[string]string{SBOMSummaryResult: `{"components": 5, "licenses": {"MIT": 4, "ISC": 1}}`}),
+			},
+			wantFound: true,
+			want:      "**Components:** 7\n\n| Licence | Components |\n|---|---|\n| MIT | 6 |\n| ISC | 1 |\n",
+		},
+		{
+			name: "no summary",
+			taskRuns: map[string]*tektonv1.PipelineRunTaskRunStatus{
+				"tr-build": taskRunWithResults("build", map[string]string{"IMAGE_DIGEST": "sha256:1234"}),
+				"tr-nil":   nil,
+			},
+		},
+		{
+			name: "invalid summary",
+			taskRuns: map[string]*tektonv1.PipelineRunTaskRunStatus{
+				"tr-sbom": taskRunWithResults("sbom", map[string]string{SBOMSummaryResult: "120 components"}),
+			},
+			wantErr: "cannot parse the SBOM_SUMMARY result of task sbom",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			summary, found, err := sbomSummaryFromTaskRuns(tt.taskRuns)
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			assert.NilError(t, err)
+			assert.Equal(t, found, tt.wantFound)
+			if tt.wantFound {
+				assert.Equal(t, summary.markdown(), tt.want)
+			}
+		})
+	}
+}
@@ -2,6 +2,10 @@ package bitbucketcloud
 
 import (
 	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
 	"strconv"
 	"strings"
 
@@ -40,6 +44,53 @@ func (v *Provider) isWorkspaceMember(event *info.Event) (bool, error) {
 	return false, nil
 }
 
+// repositoryPermission returns the permission (read, write or admin) the
+// sender has on the repository. The endpoint requires the token to have admin
+// access on the workspace, found is false when it cannot be used, in which
+// case the caller falls back to the workspace membership.
+func (v *Provider) repositoryPermission(ctx context.Context, event *info.Event) (permission string, found bool, err error) {
+	client := v.Client()
+	query := url.Values{}
+	query.Set("q", fmt.Sprintf("user.account_id=%q", event.AccountID))
+	permURL := fmt.Sprintf("%s/workspaces/%s/permissions/repositories/%s?%s", client.GetApiBaseURL(),
+		url.PathEscape(event.Organization), url.PathEscape(event.Repository), query.Encode())
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, permURL, nil)
+	if err != nil {
+		return "", false, err
+	}
+	if v.Username != nil && v.Token != nil {
+		req.SetBasicAuth(*v.Username, *v.Token)
+	}
+	httpClient := client.HttpClient
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return "", false, err
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+	case http.StatusUnauthorized, http.StatusForbidden, http.StatusNotFound:
+		return "", false, nil
+	default:
+		return "", false, fmt.Errorf("cannot get repository permissions of %s: %s", event.AccountID, resp.Status)
+	}
+
+	permissions := &types.RepositoryPermissions{}
+	if err := json.NewDecoder(resp.Body).Decode(permissions); err != nil {
+		return "", false, err
+	}
+	for _, perm := range permissions.Values {
+		if perm.User.AccountID == event.AccountID {
+			return perm.Permission, true, nil
+		}
+	}
+	return "", true, nil
+}
+
 // IsAllowedOwnersFile get the owner files (OWNERS, OWNERS_ALIASES) from main branch
 // and check if we have explicitly allowed the user in there.
 func (v *Provider) IsAllowedOwnersFile(ctx context.Context, event *info.Event) (bool, error) {
@@ -63,12 +114,24 @@ func (v *Provider) IsAllowedOwnersFile(ctx context.Context, event *info.Event) (
 }
 
 func (v *Provider) checkMember(ctx context.Context, event *info.Event) (bool, error) {
-	// If sender is a member that can write to the workspace then allow it.
-	allowed, err := v.isWorkspaceMember(event)
+	// If sender has write access to the repository then allow it.
+	permission, found, err := v.repositoryPermission(ctx, event)
 	if err != nil {
 		return false, err
-	} else if allowed {
-		return true, err
+	}
+	if found {
+		if permission == "write" || permission == "admin" {
+			return true, nil
+		}
+	} else {
+		// The token cannot read the repository permissions, allow the
+		// members of the workspace instead.
+		allowed, err := v.isWorkspaceMember(event)
+		if err != nil {
+			return false, err
+		} else if allowed {
+			return true, err
+		}
 	}
 
 	// Check if sender (which in bitbucket-cloud mean the accountID) is inside the Owner file
@@ -97,7 +160,7 @@ func (v *Provider) checkOkToTestCommentFromApprovedMember(ctx context.Context, e
 		return false, err
 	}
 	for _, comment := range comments.Values {
-		if acl.MatchRegexp(acl.OKToTestCommentRegexp, comment.Content.Raw) {
+		if acl.MatchRegexp(acl.OKToTestRegexp(), comment.Content.Raw) {
 			commenterEvent := info.NewEvent()
 			commenterEvent.Event = event.Event
 			commenterEvent.Sender = comment.User.Nickname
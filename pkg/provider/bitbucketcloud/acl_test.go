@@ -12,6 +12,7 @@ import (
 func TestIsAllowed(t *testing.T) {
 	type fields struct {
 		workspaceMembers []types.Member
+		permissions      []types.RepositoryPermission
 		comments         []types.Comment
 		filescontents    map[string]string
 	}
@@ -22,6 +23,56 @@ func TestIsAllowed(t *testing.T) {
 		want    bool
 		wantErr bool
 	}{
+		{
+			name:  "allowed/user has write permission on repository",
+			event: bbcloudtest.MakeEvent(&info.Event{Sender: "writer", AccountID: "Writer"}),
+			fields: fields{
+				permissions: []types.RepositoryPermission{
+					{Permission: "write", User: types.User{AccountID: "Writer"}},
+				},
+			},
+			want: true,
+		},
+		{
+			name:  "allowed/user has admin permission on repository",
+			event: bbcloudtest.MakeEvent(&info.Event{Sender: "admin", AccountID: "Admin"}),
+			fields: fields{
+				permissions: []types.RepositoryPermission{
+					{Permission: "admin", User: types.User{AccountID: "Admin"}},
+				},
+			},
+			want: true,
+		},
+		{
+			name:  "disallowed/workspace member with read permission on repository",
+			event: bbcloudtest.MakeEvent(&info.Event{Sender: "reader", AccountID: "Reader"}),
+			fields: fields{
+				workspaceMembers: []types.Member{
+					{User: types.User{AccountID: "Reader"}},
+				},
+				permissions: []types.RepositoryPermission{
+					{Permission: "read", User: types.User{AccountID: "Reader"}},
+				},
+			},
+			want: false,
+		},
+		{
+			name: "allowed/read permission on repository but in owner file",
+			event: bbcloudtest.MakeEvent(&info.Event{
+				SHA:       "abcd",
+				Sender:    "reader",
+				AccountID: "Reader",
+			}),
+			fields: fields{
+				permissions: []types.RepositoryPermission{
+					{Permission: "read", User: types.User{AccountID: "Reader"}},
+				},
+				filescontents: map[string]string{
+					"OWNERS": "---\n approvers:\n  - Reader\n",
+				},
+			},
+			want: true,
+		},
 		{
 			name:  "allowed/user is owner",
 			event: bbcloudtest.MakeEvent(&info.Event{Sender: "member", AccountID: "IsaMember"}),
@@ -176,6 +227,7 @@ func TestIsAllowed(t *testing.T) {
 			defer tearDown()
 
 			bbcloudtest.MuxOrgMember(t, mux, tt.event, tt.fields.workspaceMembers)
+			bbcloudtest.MuxRepositoryPermissions(t, mux, tt.event, tt.fields.permissions)
 			bbcloudtest.MuxComments(t, mux, tt.event, tt.fields.comments)
 			bbcloudtest.MuxFiles(t, mux, tt.event, tt.fields.filescontents, "")
 
@@ -90,6 +90,13 @@ func (v *Provider) CreateStatus(_ context.Context, event *info.Event, statusopts
 	case "neutral":
 		statusopts.Conclusion = "STOPPED"
 		statusopts.Title = "➖ CI has stopped"
+	case "cancelled":
+		statusopts.Conclusion = "STOPPED"
+		statusopts.Title = "⏹️ Cancelled"
+	case "action_required":
+		// Bitbucket Cloud has no pending state
+		statusopts.Conclusion = "INPROGRESS"
+		statusopts.Title = "⏳ Waiting for an /ok-to-test"
 	case "failure":
 		statusopts.Conclusion = "FAILED"
 		statusopts.Title = "❌ Failed"
@@ -108,8 +115,12 @@ func (v *Provider) CreateStatus(_ context.Context, event *info.Event, statusopts
 		detailsURL = statusopts.DetailsURL
 	}
 
+	key := v.pacInfo.ApplicationName
+	if statusContext := provider.GetStatusContext(statusopts); statusContext != "" {
+		key = statusContext
+	}
 	cso := &bitbucket.CommitStatusOptions{
-		Key:         v.pacInfo.ApplicationName,
+		Key:         key,
 		Url:         detailsURL,
 		State:       statusopts.Conclusion,
 		Description: statusopts.Title,
@@ -128,7 +139,7 @@ func (v *Provider) CreateStatus(_ context.Context, event *info.Event, statusopts
 	if err != nil {
 		// Only emit an event to notify the user that something went wrong with the commit status API,
 		// and proceed with creating the comment (if applicable).
-		v.eventEmitter.EmitMessage(v.repo, zap.ErrorLevel, "FailedToSetCommitStatus",
+		v.eventEmitter.EmitFailure(v.repo, zap.ErrorLevel, v1alpha1.FailureReasonProviderAPIError, "FailedToSetCommitStatus",
 			"cannot set status with the Bitbucket Cloud token because of: "+err.Error())
 	}
 
@@ -294,10 +305,7 @@ func (v *Provider) concatAllYamlFiles(objects []bitbucket.RepositoryFile, event
 				return "", err
 			}
 
-			if allTemplates != "" && !strings.HasPrefix(data, "---") {
-				allTemplates += "---"
-			}
-			allTemplates += "\n" + data + "\n"
+			allTemplates = provider.AppendYamlFile(allTemplates, value.Path, data)
 		}
 	}
 	return allTemplates, nil
@@ -324,6 +332,43 @@ func (v *Provider) CreateToken(_ context.Context, _ []string, _ *info.Event) (st
 	return "", nil
 }
 
+func (v *Provider) GetCIVariables(_ context.Context, _ *info.Event, _ []string) (map[string]string, error) {
+	return nil, fmt.Errorf("CI variables are not supported on Bitbucket Cloud")
+}
+
+func (v *Provider) GetPollEvents(_ context.Context, _ *info.Event, _ []string, _ bool) ([]*info.Event, error) {
+	return nil, fmt.Errorf("polling is not supported on Bitbucket Cloud")
+}
+
+func (v *Provider) GetReviewDecision(_ context.Context, _ *info.Event) (string, error) {
+	return "", fmt.Errorf("review decisions are not supported on Bitbucket Cloud")
+}
+
+func (v *Provider) CreateLineComment(_ context.Context, _ *info.Event, _ string, _ int, _ string) error {
+	return fmt.Errorf("line comments are not supported on Bitbucket Cloud")
+}
+
+// GetUserProfile returns the profile of the sender of the event, Bitbucket
+// Cloud doesn't expose the email of the other users.
+func (v *Provider) GetUserProfile(_ context.Context, event *info.Event) (*provider.UserProfile, error) {
+	if v.bbClient == nil {
+		return nil, fmt.Errorf("no token has been set, cannot get the user profile")
+	}
+	account := event.AccountID
+	if account == "" {
+		account = event.Sender
+	}
+	user, err := v.Client().Users.Get(account)
+	if err != nil {
+		return nil, fmt.Errorf("cannot get the user %s: %w", event.Sender, err)
+	}
+	profile := &provider.UserProfile{FullName: user.DisplayName}
+	if avatar, ok := user.Links["avatar"].(map[string]any); ok {
+		profile.AvatarURL, _ = avatar["href"].(string)
+	}
+	return profile, nil
+}
+
 func (v *Provider) GetTemplate(commentType provider.CommentType) string {
 	return provider.GetMarkdownTemplate(commentType)
 }
@@ -267,6 +267,22 @@ func TestCreateStatus(t *testing.T) {
 			},
 			expectedDescSubstr: "stopped",
 		},
+		{
+			name: "cancelled",
+			status: provider.StatusOpts{
+				Conclusion: "cancelled",
+			},
+			expectedDescSubstr: "Cancelled",
+		},
+		{
+			name: "action required",
+			status: provider.StatusOpts{
+				Conclusion: "action_required",
+				Status:     "queued",
+				Title:      "Pending approval, waiting for an /ok-to-test",
+			},
+			expectedDescSubstr: "Waiting for an /ok-to-test",
+		},
 		{
 			name: "completed with comment",
 			status: provider.StatusOpts{
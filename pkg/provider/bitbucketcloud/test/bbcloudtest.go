@@ -84,6 +84,24 @@ func MuxOrgMember(t *testing.T, mux *http.ServeMux, event *info.Event, members [
 		})
 }
 
+// MuxRepositoryPermissions returns the permissions of the repository, or a
+// forbidden error when permissions is nil like when the token is not admin of
+// the workspace.
+func MuxRepositoryPermissions(t *testing.T, mux *http.ServeMux, event *info.Event, permissions []types.RepositoryPermission) {
+	t.Helper()
+	mux.HandleFunc("/workspaces/"+event.Organization+"/permissions/repositories/"+event.Repository,
+		func(rw http.ResponseWriter, r *http.Request) {
+			if permissions == nil {
+				rw.WriteHeader(http.StatusForbidden)
+				return
+			}
+			assert.Equal(t, r.URL.Query().Get("q"), fmt.Sprintf("user.account_id=%q", event.AccountID))
+			b, err := json.Marshal(&types.RepositoryPermissions{Values: permissions})
+			assert.NilError(t, err)
+			fmt.Fprint(rw, string(b))
+		})
+}
+
 func MuxFiles(t *testing.T, mux *http.ServeMux, event *info.Event, filescontents map[string]string, provenance string) {
 	t.Helper()
 
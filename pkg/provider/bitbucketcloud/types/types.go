@@ -112,6 +112,16 @@ type Members struct {
 	Values []Member
 }
 
+// RepositoryPermission https://developer.atlassian.com/cloud/bitbucket/rest/api-group-workspaces/#api-workspaces-workspace-permissions-repositories-repo-slug-get
+type RepositoryPermission struct {
+	Permission string `json:"permission"`
+	User       User   `json:"user"`
+}
+
+type RepositoryPermissions struct {
+	Values []RepositoryPermission `json:"values"`
+}
+
 type Content struct {
 	Raw string `json:"raw"`
 }
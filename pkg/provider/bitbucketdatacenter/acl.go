@@ -7,6 +7,7 @@ import (
 
 	"github.com/openshift-pipelines/pipelines-as-code/pkg/acl"
 	"github.com/openshift-pipelines/pipelines-as-code/pkg/params/info"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/provider"
 
 	"github.com/jenkins-x/go-scm/scm"
 )
@@ -44,24 +45,25 @@ func (v *Provider) IsAllowedOwnersFile(ctx context.Context, event *info.Event) (
 func (v *Provider) checkOkToTestCommentFromApprovedMember(ctx context.Context, event *info.Event) (bool, error) {
 	allComments := []*scm.Comment{}
 	OrgAndRepo := fmt.Sprintf("%s/%s", event.Organization, event.Repository)
-	opts := &scm.ListOptions{Page: 1, Size: apiResponseLimit}
-	for {
-		comments, _, err := v.Client().PullRequests.ListComments(ctx, OrgAndRepo, v.pullRequestNumber, opts)
+	_, err := provider.Paginate(func(page int) ([]*scm.Comment, int, error) {
+		comments, _, err := v.Client().PullRequests.ListComments(ctx, OrgAndRepo, v.pullRequestNumber, &scm.ListOptions{Page: page, Size: apiResponseLimit})
 		if err != nil {
-			return false, err
+			return nil, 0, err
 		}
-
-		allComments = append(allComments, comments...)
-
 		if len(comments) < apiResponseLimit {
-			break
+			return comments, 0, nil
 		}
-
-		opts.Page++
+		return comments, page + 1, nil
+	}, func(comment *scm.Comment) bool {
+		allComments = append(allComments, comment)
+		return false
+	})
+	if err != nil {
+		return false, err
 	}
 
 	for _, comment := range allComments {
-		if acl.MatchRegexp(acl.OKToTestCommentRegexp, comment.Body) {
+		if acl.MatchRegexp(acl.OKToTestRegexp(), comment.Body) {
 			commenterEvent := info.NewEvent()
 			commenterEvent.Sender = comment.Author.Login
 			commenterEvent.AccountID = fmt.Sprintf("%d", comment.Author.ID)
@@ -95,8 +97,20 @@ func (v *Provider) checkMemberShip(ctx context.Context, event *info.Event) (bool
 	}
 
 	orgAndRepo := fmt.Sprintf("%s/%s", event.Organization, event.Repository)
-	// Get permissions from repo
-	allowed, _, err = v.Client().Repositories.IsCollaborator(ctx, orgAndRepo, event.Sender)
+	// Get permissions from repo, IsCollaborator only reads the first page of
+	// the users.
+	allowed, err = provider.Paginate(func(page int) ([]scm.User, int, error) {
+		users, resp, err := v.Client().Repositories.ListCollaborators(ctx, orgAndRepo, &scm.ListOptions{Page: page})
+		if err != nil {
+			return nil, 0, err
+		}
+		if len(users) == 0 {
+			return nil, 0, nil
+		}
+		return users, resp.Page.Next, nil
+	}, func(user scm.User) bool {
+		return user.Name == event.Sender || user.Login == event.Sender
+	})
 	if err != nil {
 		return false, err
 	}
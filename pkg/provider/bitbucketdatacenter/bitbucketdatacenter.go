@@ -97,6 +97,13 @@ func (v *Provider) CreateStatus(ctx context.Context, event *info.Event, statusOp
 	case "neutral":
 		statusOpts.Conclusion = "FAILED"
 		statusOpts.Title = "➖ CI has stopped"
+	case "cancelled":
+		statusOpts.Conclusion = "FAILED"
+		statusOpts.Title = "⏹️ Cancelled"
+	case "action_required":
+		// the title is the key of the status when there is no PipelineRun,
+		// it is kept to be overwritten by the PipelineRuns
+		statusOpts.Conclusion = "UNKNOWN"
 	case "failure":
 		statusOpts.Conclusion = "FAILED"
 		statusOpts.Title = "❌ Failed"
@@ -129,6 +136,9 @@ func (v *Provider) CreateStatus(ctx context.Context, event *info.Event, statusOp
 	if v.pacInfo.ApplicationName != "" {
 		key = fmt.Sprintf("%s / %s", v.pacInfo.ApplicationName, key)
 	}
+	if statusContext := provider.GetStatusContext(statusOpts); statusContext != "" {
+		key = statusContext
+	}
 
 	OrgAndRepo := fmt.Sprintf("%s/%s", event.Organization, event.Repository)
 	opts := &scm.StatusInput{
@@ -194,10 +204,7 @@ func (v *Provider) concatAllYamlFiles(ctx context.Context, objects []string, sha
 				return "", err
 			}
 
-			if allTemplates != "" && !strings.HasPrefix(data, "---") {
-				allTemplates += "---"
-			}
-			allTemplates += "\n" + data + "\n"
+			allTemplates = provider.AppendYamlFile(allTemplates, value, data)
 		}
 	}
 	return allTemplates, nil
@@ -437,6 +444,38 @@ func (v *Provider) CreateToken(_ context.Context, _ []string, _ *info.Event) (st
 	return "", nil
 }
 
+func (v *Provider) GetCIVariables(_ context.Context, _ *info.Event, _ []string) (map[string]string, error) {
+	return nil, fmt.Errorf("CI variables are not supported on Bitbucket Data Center")
+}
+
+func (v *Provider) GetPollEvents(_ context.Context, _ *info.Event, _ []string, _ bool) ([]*info.Event, error) {
+	return nil, fmt.Errorf("polling is not supported on Bitbucket Data Center")
+}
+
+func (v *Provider) GetReviewDecision(_ context.Context, _ *info.Event) (string, error) {
+	return "", fmt.Errorf("review decisions are not supported on Bitbucket Data Center")
+}
+
+// GetUserProfile returns the profile of the sender of the event.
+func (v *Provider) GetUserProfile(ctx context.Context, event *info.Event) (*provider.UserProfile, error) {
+	if v.client == nil {
+		return nil, fmt.Errorf("no token has been set, cannot get the user profile")
+	}
+	user, _, err := v.Client().Users.FindLogin(ctx, event.Sender)
+	if err != nil {
+		return nil, fmt.Errorf("cannot get the user %s: %w", event.Sender, err)
+	}
+	return &provider.UserProfile{
+		Email:     user.Email,
+		FullName:  user.Name,
+		AvatarURL: user.Avatar,
+	}, nil
+}
+
+func (v *Provider) CreateLineComment(_ context.Context, _ *info.Event, _ string, _ int, _ string) error {
+	return fmt.Errorf("line comments are not supported on Bitbucket Data Center")
+}
+
 func (v *Provider) GetTemplate(commentType provider.CommentType) string {
 	return provider.GetMarkdownTemplate(commentType)
 }
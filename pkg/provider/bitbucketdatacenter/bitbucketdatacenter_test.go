@@ -204,6 +204,26 @@ func TestCreateStatus(t *testing.T) {
 				Text:       "Pending approval, waiting for an /ok-to-test",
 			},
 
+			pacOpts: pacopts,
+		},
+		{
+			name: "good/action required",
+			status: provider.StatusOpts{
+				Conclusion: "action_required",
+				Status:     "queued",
+				Title:      "Pending approval, waiting for an /ok-to-test",
+				Text:       "Pending approval, waiting for an /ok-to-test",
+			},
+
+			pacOpts: pacopts,
+		},
+		{
+			name: "good/cancelled",
+			status: provider.StatusOpts{
+				Conclusion: "cancelled",
+				Text:       "Cancelled",
+			},
+
 			pacOpts: pacopts,
 		},
 	}
@@ -138,7 +138,11 @@ func (v *Provider) ParsePayload(_ context.Context, _ *params.Run, request *http.
 
 		// TODO: It's Really not an OWNER but a PROJECT
 		processedEvent.Organization = e.PullRequest.ToRef.Repository.Project.Key
-		processedEvent.Repository = e.PullRequest.ToRef.Repository.Name
+		// route by slug, the name may differ and is not usable in the API
+		processedEvent.Repository = e.PullRequest.ToRef.Repository.Slug
+		if processedEvent.Repository == "" {
+			processedEvent.Repository = e.PullRequest.ToRef.Repository.Name
+		}
 		processedEvent.SHA = e.PullRequest.FromRef.LatestCommit
 		processedEvent.PullRequestNumber = e.PullRequest.ID
 		processedEvent.URL = e.PullRequest.ToRef.Repository.Links.Self[0].Href
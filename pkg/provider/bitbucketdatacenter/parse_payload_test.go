@@ -574,6 +574,7 @@ func TestParsePayload(t *testing.T) {
 		rawStr                  string
 		targetPipelinerun       string
 		canceltargetPipelinerun string
+		wantRepository          string
 	}{
 		{
 			name:          "bad/invalid event type",
@@ -608,6 +609,18 @@ func TestParsePayload(t *testing.T) {
 			payloadEvent: bbv1test.MakePREvent(ev1, ""),
 			expEvent:     ev1,
 		},
+		{
+			name:      "good/pull_request routed by repository slug",
+			eventType: "pr:opened",
+			payloadEvent: func() *types.PullRequestEvent {
+				pr := bbv1test.MakePREvent(ev1, "")
+				pr.PullRequest.ToRef.Repository.Name = "My Repository"
+				pr.PullRequest.ToRef.Repository.Slug = "my-repository"
+				return pr
+			}(),
+			expEvent:       ev1,
+			wantRepository: "my-repository",
+		},
 		{
 			name:         "good/push",
 			eventType:    "repo:refs_changed",
@@ -714,6 +727,10 @@ func TestParsePayload(t *testing.T) {
 
 			assert.Equal(t, got.CloneURL, tt.expEvent.CloneURL)
 
+			if tt.wantRepository != "" {
+				assert.Equal(t, got.Repository, tt.wantRepository)
+			}
+
 			if tt.targetPipelinerun != "" {
 				assert.Equal(t, got.TargetTestPipelineRun, tt.targetPipelinerun)
 			}
@@ -257,7 +257,8 @@ func MuxRepoMemberShip(t *testing.T, mux *http.ServeMux, event *info.Event, user
 			fmt.Fprintf(rw, "{\"values\": []}")
 		}
 		resp := map[string]any{
-			"values": userperms,
+			"values":     userperms,
+			"isLastPage": true,
 		}
 		b, err := json.Marshal(resp)
 		assert.NilError(t, err)
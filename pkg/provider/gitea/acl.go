@@ -11,6 +11,7 @@ import (
 	"github.com/openshift-pipelines/pipelines-as-code/pkg/acl"
 	"github.com/openshift-pipelines/pipelines-as-code/pkg/params/info"
 	"github.com/openshift-pipelines/pipelines-as-code/pkg/policy"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/provider"
 )
 
 func (v *Provider) CheckPolicyAllowing(_ context.Context, event *info.Event, allowedTeams []string) (bool, string) {
@@ -18,8 +19,23 @@ func (v *Provider) CheckPolicyAllowing(_ context.Context, event *info.Event, all
 		return true, ""
 	}
 	// TODO: caching
-	orgTeams, resp, err := v.Client().ListOrgTeams(event.Organization, gitea.ListTeamsOptions{})
-	if resp.StatusCode == http.StatusNotFound {
+	orgTeams := []*gitea.Team{}
+	notFound := false
+	_, err := provider.Paginate(func(page int) ([]*gitea.Team, int, error) {
+		teams, resp, err := v.Client().ListOrgTeams(event.Organization, gitea.ListTeamsOptions{ListOptions: gitea.ListOptions{Page: page}})
+		if resp != nil && resp.StatusCode == http.StatusNotFound {
+			notFound = true
+			return nil, 0, nil
+		}
+		if err != nil {
+			return nil, 0, err
+		}
+		return teams, resp.NextPage, nil
+	}, func(team *gitea.Team) bool {
+		orgTeams = append(orgTeams, team)
+		return false
+	})
+	if notFound {
 		// we explicitly disallow the policy when there is no team on org
 		return false, fmt.Sprintf("no teams on org %s", event.Organization)
 	}
@@ -123,7 +139,7 @@ func (v *Provider) aclAllowedOkToTestFromAnOwner(ctx context.Context, event *inf
 		return false, nil
 	}
 
-	comments, err := v.GetStringPullRequestComment(ctx, revent, acl.OKToTestCommentRegexp)
+	comments, err := v.GetStringPullRequestComment(ctx, revent, acl.OKToTestRegexp())
 	if err != nil {
 		return false, err
 	}
@@ -148,7 +164,7 @@ func (v *Provider) aclAllowedOkToTestCurrentComment(ctx context.Context, revent
 	if err != nil {
 		return false, err
 	}
-	if acl.MatchRegexp(acl.OKToTestCommentRegexp, comment.Body) {
+	if acl.MatchRegexp(acl.OKToTestRegexp(), comment.Body) {
 		revent.Sender = comment.Poster.UserName
 		allowed, err := v.aclCheckAll(ctx, revent)
 		if err != nil {
@@ -227,14 +243,21 @@ func (v *Provider) GetStringPullRequestComment(_ context.Context, runevent *info
 		return nil, err
 	}
 
-	comments, _, err := v.Client().ListIssueComments(runevent.Organization, runevent.Repository, int64(prNumber), gitea.ListIssueCommentOptions{})
+	_, err = provider.Paginate(func(page int) ([]*gitea.Comment, int, error) {
+		comments, resp, err := v.Client().ListIssueComments(runevent.Organization, runevent.Repository, int64(prNumber),
+			gitea.ListIssueCommentOptions{ListOptions: gitea.ListOptions{Page: page}})
+		if err != nil {
+			return nil, 0, err
+		}
+		return comments, resp.NextPage, nil
+	}, func(comment *gitea.Comment) bool {
+		if acl.MatchRegexp(reg, comment.Body) {
+			ret = append(ret, comment)
+		}
+		return false
+	})
 	if err != nil {
 		return nil, err
 	}
-	for _, v := range comments {
-		if acl.MatchRegexp(reg, v.Body) {
-			ret = append(ret, v)
-		}
-	}
 	return ret, nil
 }
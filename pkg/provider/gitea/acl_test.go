@@ -115,6 +115,7 @@ func TestOkToTestComment(t *testing.T) {
 	tests := []struct {
 		name             string
 		commentsReply    string
+		nextPageReply    string
 		runevent         info.Event
 		allowed          bool
 		wantErr          bool
@@ -148,6 +149,21 @@ func TestOkToTestComment(t *testing.T) {
 			wantErr:          false,
 			rememberOkToTest: true,
 		},
+		{
+			name:          "allowed_from_org/ok-to-test on the second page of comments",
+			commentsReply: `[{"body": "Foo Bar", "user": {"login": "owner"}}]`,
+			nextPageReply: `[{"body": "/ok-to-test", "user": {"login": "owner"}}]`,
+			runevent: info.Event{
+				Organization: "owner",
+				Repository:   "repo",
+				Sender:       "nonowner",
+				EventType:    "issue_comment",
+				Event:        issueCommentPayload,
+			},
+			allowed:          true,
+			wantErr:          false,
+			rememberOkToTest: true,
+		},
 		{
 			name:          "disallowed/bad event origin",
 			commentsReply: `[{"body": "/ok-to-test", "user": {"login": "owner"}}]`,
@@ -271,8 +287,15 @@ func TestOkToTestComment(t *testing.T) {
 			mux.HandleFunc(fmt.Sprintf("/repos/%s/%s/issues/1/comments", tt.runevent.Organization,
 				tt.runevent.Repository),
 				func(rw http.ResponseWriter,
-					_ *http.Request,
+					r *http.Request,
 				) {
+					if tt.nextPageReply != "" && r.URL.Query().Get("page") == "2" {
+						fmt.Fprint(rw, tt.nextPageReply)
+						return
+					}
+					if tt.nextPageReply != "" {
+						rw.Header().Set("Link", fmt.Sprintf(`<%s?page=2>; rel="next"`, r.URL.Path))
+					}
 					fmt.Fprint(rw, tt.commentsReply)
 				})
 			mux.HandleFunc(fmt.Sprintf("/repos/%s/%s/issues/comments/1", tt.runevent.Organization,
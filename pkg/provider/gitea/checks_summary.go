@@ -0,0 +1,146 @@
+package gitea
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"code.gitea.io/sdk/gitea"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/formatting"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/params/info"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/provider"
+)
+
+const (
+	checksSummaryStrategy = "checks_summary"
+	disableAllStrategy    = "disable_all"
+
+	// checksSummaryMarker identifies the checks summary comment of a pull request.
+	checksSummaryMarker = "<!-- pipelines-as-code/checks-summary -->"
+)
+
+var (
+	checksSummarySHA = regexp.MustCompile(`<!-- sha: (\w+) -->`)
+	checksSummaryRow = regexp.MustCompile(`^\| \S+ (\w+) \| (?:\[(.+?)\]\((.*?)\)|(.+?)) \| (.*) \|$`)
+)
+
+// checkRow is a status in the checks summary comment.
+type checkRow struct {
+	state       gitea.StatusState
+	name        string
+	targetURL   string
+	description string
+}
+
+func (r checkRow) String() string {
+	name := r.name
+	if r.targetURL != "" {
+		name = fmt.Sprintf("[%s](%s)", r.name, r.targetURL)
+	}
+	description := strings.ReplaceAll(r.description, "|", `\|`)
+	return fmt.Sprintf("| %s %s | %s | %s |", stateEmoji(r.state), r.state, name, description)
+}
+
+func stateEmoji(state gitea.StatusState) string {
+	switch state {
+	case gitea.StatusSuccess:
+		return "✅"
+	case gitea.StatusFailure, gitea.StatusError:
+		return "❌"
+	case gitea.StatusWarning:
+		return "⚠️"
+	default:
+		return "⏳"
+	}
+}
+
+// parseChecksSummary returns the statuses of a checks summary comment, none
+// when the comment is about another commit.
+func parseChecksSummary(body, sha string) []checkRow {
+	if m := checksSummarySHA.FindStringSubmatch(body); m == nil || m[1] != sha {
+		return nil
+	}
+	rows := []checkRow{}
+	for _, line := range strings.Split(body, "\n") {
+		m := checksSummaryRow.FindStringSubmatch(strings.TrimSpace(line))
+		if m == nil || m[1] == "" {
+			continue
+		}
+		row := checkRow{
+			state:       gitea.StatusState(m[1]),
+			name:        m[2],
+			targetURL:   m[3],
+			description: strings.ReplaceAll(m[5], `\|`, "|"),
+		}
+		if row.name == "" {
+			row.name = m[4]
+		}
+		rows = append(rows, row)
+	}
+	return rows
+}
+
+// formatChecksSummary renders the checks summary comment of a commit.
+func formatChecksSummary(applicationName, sha string, rows []checkRow) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s\n<!-- sha: %s -->\n", checksSummaryMarker, sha)
+	fmt.Fprintf(&b, "### %s checks summary\n\n", applicationName)
+	fmt.Fprintf(&b, "Statuses of the commit %s\n\n", formatting.ShortSHA(sha))
+	b.WriteString("| Status | Check | Description |\n")
+	b.WriteString("| --- | --- | --- |\n")
+	for _, row := range rows {
+		b.WriteString(row.String())
+		b.WriteString("\n")
+	}
+	return b.String()
+}
+
+// updateChecksSummary adds or updates the status in the checks summary
+// comment of the pull request, Gitea has no checks tab to show them together.
+func (v *Provider) updateChecksSummary(event *info.Event, status checkRow) error {
+	var existing *gitea.Comment
+	_, err := provider.Paginate(func(page int) ([]*gitea.Comment, int, error) {
+		comments, resp, err := v.Client().ListIssueComments(event.Organization, event.Repository, int64(event.PullRequestNumber),
+			gitea.ListIssueCommentOptions{ListOptions: gitea.ListOptions{Page: page}})
+		if err != nil {
+			return nil, 0, err
+		}
+		return comments, resp.NextPage, nil
+	}, func(comment *gitea.Comment) bool {
+		if strings.Contains(comment.Body, checksSummaryMarker) {
+			existing = comment
+			return true
+		}
+		return false
+	})
+	if err != nil {
+		return err
+	}
+
+	var rows []checkRow
+	if existing != nil {
+		rows = parseChecksSummary(existing.Body, event.SHA)
+	}
+	updated := false
+	for i := range rows {
+		if rows[i].name == status.name {
+			rows[i] = status
+			updated = true
+		}
+	}
+	if !updated {
+		rows = append(rows, status)
+	}
+
+	body := formatChecksSummary(v.pacInfo.ApplicationName, event.SHA, rows)
+	if existing != nil {
+		_, _, err = v.Client().EditIssueComment(event.Organization, event.Repository, existing.ID, gitea.EditIssueCommentOption{
+			Body: body,
+		})
+		return err
+	}
+	_, _, err = v.Client().CreateIssueComment(event.Organization, event.Repository, int64(event.PullRequestNumber), gitea.CreateIssueCommentOption{
+		Body: body,
+	})
+	return err
+}
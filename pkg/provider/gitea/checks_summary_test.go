@@ -0,0 +1,106 @@
+package gitea
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"code.gitea.io/sdk/gitea"
+	"github.com/google/go-cmp/cmp"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/params/info"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/params/settings"
+	tgitea "github.com/openshift-pipelines/pipelines-as-code/pkg/provider/gitea/test"
+	"gotest.tools/v3/assert"
+)
+
+var cmpCheckRow = cmp.AllowUnexported(checkRow{})
+
+func TestParseChecksSummary(t *testing.T) {
+	rows := []checkRow{
+		{state: gitea.StatusSuccess, name: "myapp / build", targetURL: "https://console/build", description: "Success"},
+		{state: gitea.StatusPending, name: "myapp", description: "Pending approval | waiting"},
+	}
+	body := formatChecksSummary("myapp", "123456", rows)
+
+	assert.DeepEqual(t, parseChecksSummary(body, "123456"), rows, cmpCheckRow)
+	assert.Assert(t, parseChecksSummary(body, "abcdef") == nil)
+	assert.Assert(t, parseChecksSummary("a comment", "123456") == nil)
+}
+
+func TestUpdateChecksSummary(t *testing.T) {
+	build := checkRow{state: gitea.StatusSuccess, name: "myapp / build", targetURL: "https://console/build", description: "Success"}
+	lint := checkRow{state: gitea.StatusPending, name: "myapp / lint", targetURL: "https://console/lint", description: "CI has Started"}
+	failedLint := checkRow{state: gitea.StatusFailure, name: "myapp / lint", targetURL: "https://console/lint", description: "Failed"}
+
+	tests := []struct {
+		name     string
+		comments []*gitea.Comment
+		status   checkRow
+		wantEdit bool
+		wantRows []checkRow
+	}{
+		{
+			name:     "create the comment",
+			comments: []*gitea.Comment{{ID: 1, Body: "lgtm"}},
+			status:   build,
+			wantRows: []checkRow{build},
+		},
+		{
+			name:     "add a status",
+			comments: []*gitea.Comment{{ID: 2, Body: formatChecksSummary("myapp", "123456", []checkRow{build})}},
+			status:   lint,
+			wantEdit: true,
+			wantRows: []checkRow{build, lint},
+		},
+		{
+			name:     "update a status",
+			comments: []*gitea.Comment{{ID: 2, Body: formatChecksSummary("myapp", "123456", []checkRow{build, lint})}},
+			status:   failedLint,
+			wantEdit: true,
+			wantRows: []checkRow{build, failedLint},
+		},
+		{
+			name:     "reset on a new commit",
+			comments: []*gitea.Comment{{ID: 2, Body: formatChecksSummary("myapp", "abcdef", []checkRow{build, lint})}},
+			status:   failedLint,
+			wantEdit: true,
+			wantRows: []checkRow{failedLint},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fakeclient, mux, teardown := tgitea.Setup(t)
+			defer teardown()
+
+			var posted string
+			mux.HandleFunc("/repos/org/repo/issues/123/comments", func(rw http.ResponseWriter, r *http.Request) {
+				if r.Method == http.MethodGet {
+					b, _ := json.Marshal(tt.comments)
+					fmt.Fprint(rw, string(b))
+					return
+				}
+				assert.Assert(t, !tt.wantEdit, "comment created instead of edited")
+				opt := gitea.CreateIssueCommentOption{}
+				assert.NilError(t, json.NewDecoder(r.Body).Decode(&opt))
+				posted = opt.Body
+				fmt.Fprint(rw, `{}`)
+			})
+			mux.HandleFunc("/repos/org/repo/issues/comments/2", func(rw http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, r.Method, "PATCH")
+				opt := gitea.EditIssueCommentOption{}
+				assert.NilError(t, json.NewDecoder(r.Body).Decode(&opt))
+				posted = opt.Body
+				fmt.Fprint(rw, `{}`)
+			})
+
+			v := &Provider{
+				giteaClient: fakeclient,
+				pacInfo:     &info.PacOpts{Settings: settings.Settings{ApplicationName: "myapp"}},
+			}
+			event := &info.Event{Organization: "org", Repository: "repo", PullRequestNumber: 123, SHA: "123456"}
+			assert.NilError(t, v.updateChecksSummary(event, tt.status))
+			assert.DeepEqual(t, parseChecksSummary(posted, "123456"), tt.wantRows, cmpCheckRow)
+		})
+	}
+}
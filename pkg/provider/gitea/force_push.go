@@ -0,0 +1,59 @@
+package gitea
+
+import (
+	"encoding/json"
+
+	giteaStructs "code.gitea.io/gitea/modules/structs"
+	"code.gitea.io/sdk/gitea"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/params/info"
+)
+
+// timelinePullPush is the type of the timeline comment added by Gitea when
+// commits are pushed to the head branch of a pull request.
+const timelinePullPush = "pull_push"
+
+// pullPushComment is the body of a pull_push timeline comment, on a force
+// push CommitIDs holds the previous and the new head SHA.
+type pullPushComment struct {
+	IsForcePush bool     `json:"is_force_push"`
+	CommitIDs   []string `json:"commit_ids"`
+}
+
+// isPullRequestSynchronize tells whether the event is a push on the head
+// branch of a pull request.
+func isPullRequestSynchronize(runevent *info.Event) bool {
+	payload, ok := runevent.Event.(*giteaStructs.PullRequestPayload)
+	return ok && payload.Action == giteaStructs.HookIssueSynchronized
+}
+
+// detectForcePush sets ForcePush and BeforeSHA on the event when the head of
+// the pull request has been force pushed to its SHA. The Gitea webhook
+// doesn't carry the previous head SHA, it is looked up in the pull_push
+// comments of the pull request timeline.
+func (v *Provider) detectForcePush(runevent *info.Event) error {
+	opt := gitea.ListIssueCommentOptions{ListOptions: gitea.ListOptions{Page: 1, PageSize: 50}}
+	for {
+		comments, _, err := v.Client().ListIssueTimeline(runevent.Organization, runevent.Repository, int64(runevent.PullRequestNumber), opt)
+		if err != nil {
+			return err
+		}
+		for _, comment := range comments {
+			if comment.Type != timelinePullPush {
+				continue
+			}
+			pushed := pullPushComment{}
+			if err := json.Unmarshal([]byte(comment.Body), &pushed); err != nil {
+				continue
+			}
+			if pushed.IsForcePush && len(pushed.CommitIDs) == 2 && pushed.CommitIDs[1] == runevent.SHA {
+				runevent.ForcePush = true
+				runevent.BeforeSHA = pushed.CommitIDs[0]
+			}
+		}
+
+		if len(comments) < opt.PageSize {
+			return nil
+		}
+		opt.Page++
+	}
+}
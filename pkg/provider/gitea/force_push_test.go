@@ -0,0 +1,68 @@
+package gitea
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	giteaStructs "code.gitea.io/gitea/modules/structs"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/params/info"
+	tgitea "github.com/openshift-pipelines/pipelines-as-code/pkg/provider/gitea/test"
+	"gotest.tools/v3/assert"
+)
+
+func TestDetectForcePush(t *testing.T) {
+	tests := []struct {
+		name          string
+		timeline      string
+		wantForcePush bool
+		wantBeforeSHA string
+	}{
+		{
+			name:          "force push",
+			timeline:      `[{"type": "comment", "body": "hello"}, {"type": "pull_push", "body": "{\"is_force_push\":true,\"commit_ids\":[\"oldsha\",\"newsha\"]}"}]`,
+			wantForcePush: true,
+			wantBeforeSHA: "oldsha",
+		},
+		{
+			name:          "latest force push",
+			timeline:      `[{"type": "pull_push", "body": "{\"is_force_push\":true,\"commit_ids\":[\"oldersha\",\"newsha\"]}"}, {"type": "pull_push", "body": "{\"is_force_push\":true,\"commit_ids\":[\"oldsha\",\"newsha\"]}"}]`,
+			wantForcePush: true,
+			wantBeforeSHA: "oldsha",
+		},
+		{
+			name:     "regular push",
+			timeline: `[{"type": "pull_push", "body": "{\"is_force_push\":false,\"commit_ids\":[\"newsha\"]}"}]`,
+		},
+		{
+			name:     "force push to another sha",
+			timeline: `[{"type": "pull_push", "body": "{\"is_force_push\":true,\"commit_ids\":[\"oldsha\",\"othersha\"]}"}]`,
+		},
+		{
+			name:     "invalid body",
+			timeline: `[{"type": "pull_push", "body": "not json"}]`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fakeclient, mux, teardown := tgitea.Setup(t)
+			defer teardown()
+			mux.HandleFunc("/repos/org/repo/issues/1/timeline", func(rw http.ResponseWriter, _ *http.Request) {
+				fmt.Fprint(rw, tt.timeline)
+			})
+
+			event := &info.Event{
+				Organization:      "org",
+				Repository:        "repo",
+				PullRequestNumber: 1,
+				SHA:               "newsha",
+				Event:             &giteaStructs.PullRequestPayload{Action: giteaStructs.HookIssueSynchronized},
+			}
+			assert.Assert(t, isPullRequestSynchronize(event))
+			v := &Provider{giteaClient: fakeclient}
+			assert.NilError(t, v.detectForcePush(event))
+			assert.Equal(t, event.ForcePush, tt.wantForcePush)
+			assert.Equal(t, event.BeforeSHA, tt.wantBeforeSHA)
+		})
+	}
+}
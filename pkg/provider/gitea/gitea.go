@@ -5,7 +5,6 @@ import (
 	"encoding/base64"
 	"encoding/json"
 	"fmt"
-	"net/http"
 	"path"
 	"regexp"
 	"strconv"
@@ -52,6 +51,7 @@ type Provider struct {
 	giteaInstanceURL string
 	// only exposed for e2e tests
 	Password     string
+	TLS          TLSOptions
 	repo         *v1alpha1.Repository
 	eventEmitter *events.EventEmitter
 	run          *params.Run
@@ -85,19 +85,30 @@ func (v *Provider) CreateComment(_ context.Context, event *info.Event, commit, u
 
 	// List comments of the PR
 	if updateMarker != "" {
-		comments, _, err := v.Client().ListIssueComments(event.Organization, event.Repository, int64(event.PullRequestNumber), gitea.ListIssueCommentOptions{})
-		if err != nil {
-			return err
-		}
-
 		re := regexp.MustCompile(updateMarker)
-		for _, comment := range comments {
+		var existing *gitea.Comment
+		_, err := provider.Paginate(func(page int) ([]*gitea.Comment, int, error) {
+			comments, resp, err := v.Client().ListIssueComments(event.Organization, event.Repository, int64(event.PullRequestNumber),
+				gitea.ListIssueCommentOptions{ListOptions: gitea.ListOptions{Page: page}})
+			if err != nil {
+				return nil, 0, err
+			}
+			return comments, resp.NextPage, nil
+		}, func(comment *gitea.Comment) bool {
 			if re.MatchString(comment.Body) {
-				_, _, err := v.Client().EditIssueComment(event.Organization, event.Repository, comment.ID, gitea.EditIssueCommentOption{
-					Body: commit,
-				})
-				return err
+				existing = comment
+				return true
 			}
+			return false
+		})
+		if err != nil {
+			return err
+		}
+		if existing != nil {
+			_, _, err = v.Client().EditIssueComment(event.Organization, event.Repository, existing.ID, gitea.EditIssueCommentOption{
+				Body: commit,
+			})
+			return err
 		}
 	}
 
@@ -149,12 +160,12 @@ func (v *Provider) SetClient(_ context.Context, run *params.Run, runevent *info.
 	apiURL := runevent.Provider.URL
 	// password is not exposed to CRD, it's only used from the e2e tests
 	if v.Password != "" && runevent.Provider.User != "" {
-		v.giteaClient, err = gitea.NewClient(apiURL, gitea.SetBasicAuth(runevent.Provider.User, v.Password))
+		v.giteaClient, err = NewClient(apiURL, v.TLS, gitea.SetBasicAuth(runevent.Provider.User, v.Password))
 	} else {
 		if runevent.Provider.Token == "" {
 			return fmt.Errorf("no git_provider.secret has been set in the repo crd")
 		}
-		v.giteaClient, err = gitea.NewClient(apiURL, gitea.SetToken(runevent.Provider.Token))
+		v.giteaClient, err = NewClient(apiURL, v.TLS, gitea.SetToken(runevent.Provider.Token))
 	}
 	if err != nil {
 		return err
@@ -192,6 +203,16 @@ func (v *Provider) CreateStatus(_ context.Context, event *info.Event, statusOpts
 	case "neutral":
 		statusOpts.Title = "Unknown"
 		statusOpts.Summary = "doesn't know what happened with this commit."
+	case "action_required":
+		statusOpts.Summary = "is waiting for approval."
+	case "cancelled":
+		statusOpts.Title = "Cancelled"
+		statusOpts.Summary = "has been cancelled."
+	case "skipped":
+		if statusOpts.Title == "" {
+			statusOpts.Title = "Skipped"
+		}
+		statusOpts.Summary = "has been skipped."
 	}
 
 	if statusOpts.Status == "in_progress" {
@@ -211,21 +232,33 @@ func (v *Provider) CreateStatus(_ context.Context, event *info.Event, statusOpts
 
 func (v *Provider) createStatusCommit(event *info.Event, pacopts *info.PacOpts, status provider.StatusOpts) error {
 	state := gitea.StatusState(status.Conclusion)
+	// the title of the status tells the conclusions Gitea has no state for
 	switch status.Conclusion {
 	case "neutral":
+		state = gitea.StatusWarning
+	case "skipped":
 		state = gitea.StatusSuccess // We don't have a choice than setting as success, no pending here.c
 	case "pending":
 		if status.Title != "" {
 			state = gitea.StatusPending
 		}
+	case "action_required":
+		state = gitea.StatusPending
+	case "cancelled":
+		state = gitea.StatusError
 	}
 	if status.Status == "in_progress" {
 		state = gitea.StatusPending
 	}
 
+	// link the statuses without a PipelineRun to the event
+	targetURL := status.DetailsURL
+	if targetURL == "" {
+		targetURL = event.URL
+	}
 	gStatus := gitea.CreateStatusOption{
 		State:       state,
-		TargetURL:   status.DetailsURL,
+		TargetURL:   targetURL,
 		Description: status.Title,
 		Context:     provider.GetCheckName(status, pacopts),
 	}
@@ -237,7 +270,29 @@ func (v *Provider) createStatusCommit(event *info.Event, pacopts *info.PacOpts,
 	if opscomments.IsAnyOpsEventType(eventType.String()) {
 		eventType = triggertype.PullRequest
 	}
-	if status.Text != "" && (eventType == triggertype.PullRequest || event.TriggerTarget == triggertype.PullRequest) {
+	onPullRequest := eventType == triggertype.PullRequest || event.TriggerTarget == triggertype.PullRequest
+
+	var commentStrategy string
+	if v.repo != nil && v.repo.Spec.Settings != nil && v.repo.Spec.Settings.Gitea != nil {
+		commentStrategy = v.repo.Spec.Settings.Gitea.CommentStrategy
+	}
+	switch commentStrategy {
+	case disableAllStrategy:
+		v.Logger.Warn("gitea: comments related to PipelineRuns status have been disabled for Gitea pull requests")
+		return nil
+	case checksSummaryStrategy:
+		if !onPullRequest || event.PullRequestNumber == 0 {
+			return nil
+		}
+		return v.updateChecksSummary(event, checkRow{
+			state:       state,
+			name:        gStatus.Context,
+			targetURL:   gStatus.TargetURL,
+			description: gStatus.Description,
+		})
+	}
+
+	if status.Text != "" && onPullRequest {
 		status.Text = strings.ReplaceAll(strings.TrimSpace(status.Text), "<br>", "\n")
 		_, _, err := v.Client().CreateIssueComment(event.Organization, event.Repository,
 			int64(event.PullRequestNumber), gitea.CreateIssueCommentOption{
@@ -308,10 +363,7 @@ func (v *Provider) concatAllYamlFiles(objects []gitea.GitEntry, event *info.Even
 			if err := provider.ValidateYaml(data, value.Path); err != nil {
 				return "", err
 			}
-			if allTemplates != "" && !strings.HasPrefix(string(data), "---") {
-				allTemplates += "---"
-			}
-			allTemplates += "\n" + string(data) + "\n"
+			allTemplates = provider.AppendYamlFile(allTemplates, value.Path, string(data))
 		}
 	}
 	return allTemplates, nil
@@ -347,6 +399,42 @@ func (v *Provider) GetFileInsideRepo(_ context.Context, runevent *info.Event, pa
 	return string(decoded), nil
 }
 
+// GetCIVariables returns the Actions variables of the repository.
+func (v *Provider) GetCIVariables(_ context.Context, event *info.Event, names []string) (map[string]string, error) {
+	if v.giteaClient == nil {
+		return nil, fmt.Errorf("no gitea client has been initialized")
+	}
+	ret := map[string]string{}
+	for _, name := range names {
+		variable, _, err := v.Client().GetRepoActionVariable(event.Organization, event.Repository, name)
+		if err != nil {
+			return nil, fmt.Errorf("cannot get the Actions variable %s of %s/%s: %w", name, event.Organization, event.Repository, err)
+		}
+		ret[name] = variable.Value
+	}
+	return ret, nil
+}
+
+func (v *Provider) CreateLineComment(_ context.Context, _ *info.Event, _ string, _ int, _ string) error {
+	return fmt.Errorf("line comments are not supported on Gitea")
+}
+
+// GetUserProfile returns the profile of the sender of the event.
+func (v *Provider) GetUserProfile(_ context.Context, event *info.Event) (*provider.UserProfile, error) {
+	if v.giteaClient == nil {
+		return nil, fmt.Errorf("no gitea client has been initialized")
+	}
+	user, _, err := v.Client().GetUserInfo(event.Sender)
+	if err != nil {
+		return nil, fmt.Errorf("cannot get the user %s: %w", event.Sender, err)
+	}
+	return &provider.UserProfile{
+		Email:     user.Email,
+		FullName:  user.FullName,
+		AvatarURL: user.AvatarURL,
+	}, nil
+}
+
 func (v *Provider) GetCommitInfo(_ context.Context, runevent *info.Event) error {
 	if v.giteaClient == nil {
 		return fmt.Errorf("no gitea client has been initialized, " +
@@ -377,22 +465,12 @@ func (v *Provider) GetCommitInfo(_ context.Context, runevent *info.Event) error
 	runevent.SHAURL = commit.HTMLURL
 	runevent.SHATitle = strings.Split(commit.RepoCommit.Message, "\n\n")[0]
 	runevent.SHA = commit.SHA
-	return nil
-}
-
-func ShouldGetNextPage(resp *gitea.Response, currentPage int) (bool, int) {
-	val, exists := resp.Header[http.CanonicalHeaderKey("x-pagecount")]
-	if !exists {
-		return false, 0
-	}
-	i, err := strconv.Atoi(val[0])
-	if err != nil {
-		return false, 0
-	}
-	if i >= currentPage {
-		return false, i
+	if isPullRequestSynchronize(runevent) {
+		if err := v.detectForcePush(runevent); err != nil && v.Logger != nil {
+			v.Logger.Warnf("cannot detect a force push on pull request %d: %v", runevent.PullRequestNumber, err)
+		}
 	}
-	return true, (currentPage + 1)
+	return nil
 }
 
 type PushPayload struct {
@@ -405,33 +483,31 @@ func (v *Provider) GetFiles(_ context.Context, runevent *info.Event) (changedfil
 	//nolint:exhaustive // we don't need to handle all cases
 	switch runevent.TriggerTarget {
 	case triggertype.PullRequest, triggertype.PullRequestClosed:
-		opt := gitea.ListPullRequestFilesOptions{ListOptions: gitea.ListOptions{Page: 1, PageSize: 50}}
-		shouldGetNextPage := false
-		for {
+		_, err := provider.Paginate(func(page int) ([]*gitea.ChangedFile, int, error) {
+			opt := gitea.ListPullRequestFilesOptions{ListOptions: gitea.ListOptions{Page: page, PageSize: 50}}
 			prChangedFiles, resp, err := v.Client().ListPullRequestFiles(runevent.Organization, runevent.Repository, int64(runevent.PullRequestNumber), opt)
 			if err != nil {
-				return changedfiles.ChangedFiles{}, err
+				return nil, 0, err
 			}
-			for j := range prChangedFiles {
-				changedFiles.All = append(changedFiles.All, prChangedFiles[j].Filename)
-				if prChangedFiles[j].Status == "added" {
-					changedFiles.Added = append(changedFiles.Added, prChangedFiles[j].Filename)
-				}
-				if prChangedFiles[j].Status == "deleted" {
-					changedFiles.Deleted = append(changedFiles.Deleted, prChangedFiles[j].Filename)
-				}
-				if prChangedFiles[j].Status == "changed" {
-					changedFiles.Modified = append(changedFiles.Modified, prChangedFiles[j].Filename)
-				}
-				if prChangedFiles[j].Status == "renamed" {
-					changedFiles.Renamed = append(changedFiles.Renamed, prChangedFiles[j].Filename)
-				}
+			return prChangedFiles, resp.NextPage, nil
+		}, func(prChangedFile *gitea.ChangedFile) bool {
+			changedFiles.All = append(changedFiles.All, prChangedFile.Filename)
+			if prChangedFile.Status == "added" {
+				changedFiles.Added = append(changedFiles.Added, prChangedFile.Filename)
 			}
-
-			shouldGetNextPage, opt.Page = ShouldGetNextPage(resp, opt.Page)
-			if !shouldGetNextPage {
-				break
+			if prChangedFile.Status == "deleted" {
+				changedFiles.Deleted = append(changedFiles.Deleted, prChangedFile.Filename)
+			}
+			if prChangedFile.Status == "changed" {
+				changedFiles.Modified = append(changedFiles.Modified, prChangedFile.Filename)
 			}
+			if prChangedFile.Status == "renamed" {
+				changedFiles.Renamed = append(changedFiles.Renamed, prChangedFile.Filename)
+			}
+			return false
+		})
+		if err != nil {
+			return changedfiles.ChangedFiles{}, err
 		}
 	case triggertype.Push:
 		pushPayload := PushPayload{}
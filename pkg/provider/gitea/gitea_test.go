@@ -296,6 +296,7 @@ func TestProvider_CreateStatusCommit(t *testing.T) {
 		event   *info.Event
 		pacopts *info.PacOpts
 		status  provider.StatusOpts
+		repo    *v1alpha1.Repository
 	}
 	tests := []struct {
 		name                            string
@@ -304,7 +305,7 @@ func TestProvider_CreateStatusCommit(t *testing.T) {
 		wantCommentJSON, wantStatusJSON string
 	}{
 		{
-			name: "success",
+			name: "neutral",
 			args: args{
 				pacopts: &info.PacOpts{Settings: settings.Settings{
 					ApplicationName: "myapp",
@@ -320,7 +321,53 @@ func TestProvider_CreateStatusCommit(t *testing.T) {
 					Conclusion: "neutral",
 				},
 			},
-			wantStatusJSON: `{"state":"success","target_url":"","description":"","context":"myapp"}`,
+			wantStatusJSON: `{"state":"warning","target_url":"","description":"","context":"myapp"}`,
+		},
+		{
+			name: "target url of the event",
+			args: args{
+				pacopts: &info.PacOpts{Settings: settings.Settings{
+					ApplicationName: "myapp",
+				}},
+				event: &info.Event{
+					Organization:      "myorg",
+					Repository:        "myrepo",
+					PullRequestNumber: 1,
+					TriggerTarget:     "pull_request",
+					SHA:               "123456",
+					URL:               "https://gitea/myorg/myrepo/pulls/1",
+				},
+				status: provider.StatusOpts{
+					Conclusion: "skipped",
+					Title:      "Skipped",
+				},
+			},
+			wantStatusJSON: `{"state":"success","target_url":"https://gitea/myorg/myrepo/pulls/1","description":"Skipped","context":"myapp"}`,
+		},
+		{
+			name: "comments disabled",
+			args: args{
+				pacopts: &info.PacOpts{Settings: settings.Settings{
+					ApplicationName: "myapp",
+				}},
+				event: &info.Event{
+					Organization:      "myorg",
+					Repository:        "myrepo",
+					PullRequestNumber: 1,
+					TriggerTarget:     "pull_request",
+					SHA:               "123456",
+				},
+				status: provider.StatusOpts{
+					Conclusion: "failure",
+					Title:      "Failed",
+					DetailsURL: "https://console/pr",
+					Text:       "it failed",
+				},
+				repo: &v1alpha1.Repository{Spec: v1alpha1.RepositorySpec{Settings: &v1alpha1.Settings{
+					Gitea: &v1alpha1.GiteaSettings{CommentStrategy: "disable_all"},
+				}}},
+			},
+			wantStatusJSON: `{"state":"failure","target_url":"https://console/pr","description":"Failed","context":"myapp"}`,
 		},
 		{
 			name: "pending",
@@ -463,8 +510,12 @@ func TestProvider_CreateStatusCommit(t *testing.T) {
 				_, _ = rw.Write([]byte(`{"body":"Pipeline run for myapp has been triggered"}`))
 			})
 
+			observer, _ := zapobserver.New(zap.InfoLevel)
+			logger := zap.New(observer).Sugar()
 			v := &Provider{
 				giteaClient: fakeclient,
+				repo:        tt.args.repo,
+				Logger:      logger,
 			}
 
 			if err := v.createStatusCommit(tt.args.event, tt.args.pacopts, tt.args.status); (err != nil) != tt.wantErr {
@@ -68,6 +68,13 @@ func (v *Provider) ParsePayload(_ context.Context, _ *params.Run, request *http.
 		}
 		processedEvent.SHAURL = gitEvent.HeadCommit.URL
 		processedEvent.SHATitle = gitEvent.HeadCommit.Message
+		for _, commit := range gitEvent.Commits {
+			processedEvent.PushCommits = append(processedEvent.PushCommits, info.Commit{
+				SHA:   commit.ID,
+				URL:   commit.URL,
+				Title: commit.Message,
+			})
+		}
 		processedEvent.Organization = gitEvent.Repo.Owner.UserName
 		processedEvent.Repository = gitEvent.Repo.Name
 		processedEvent.DefaultBranch = gitEvent.Repo.DefaultBranch
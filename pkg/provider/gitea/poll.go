@@ -0,0 +1,107 @@
+package gitea
+
+import (
+	"context"
+	"fmt"
+
+	"code.gitea.io/sdk/gitea"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/params/info"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/params/triggertype"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/provider"
+)
+
+// GetPollEvents returns a push event for the head of each branch and, with
+// pullRequests, a pull_request event for the head of each open pull request
+// of the repository of the event.
+func (v *Provider) GetPollEvents(_ context.Context, event *info.Event, branches []string, pullRequests bool) ([]*info.Event, error) {
+	if v.giteaClient == nil {
+		return nil, fmt.Errorf("no gitea client has been initialized")
+	}
+	repo, _, err := v.Client().GetRepo(event.Organization, event.Repository)
+	if err != nil {
+		return nil, fmt.Errorf("cannot get the repository %s/%s: %w", event.Organization, event.Repository, err)
+	}
+	if len(branches) == 0 {
+		branches = []string{repo.DefaultBranch}
+	}
+
+	ret := []*info.Event{}
+	for _, name := range branches {
+		branch, _, err := v.Client().GetRepoBranch(event.Organization, event.Repository, name)
+		if err != nil {
+			return nil, fmt.Errorf("cannot get the branch %s of %s/%s: %w", name, event.Organization, event.Repository, err)
+		}
+		pushEvent := newPollEvent(event, repo)
+		pushEvent.EventType = triggertype.Push.String()
+		pushEvent.TriggerTarget = triggertype.Push
+		if branch.Commit != nil {
+			pushEvent.SHA = branch.Commit.ID
+			pushEvent.SHAURL = branch.Commit.URL
+			pushEvent.SHATitle = branch.Commit.Message
+			if branch.Commit.Author != nil {
+				pushEvent.Sender = branch.Commit.Author.UserName
+			}
+		}
+		pushEvent.BaseBranch = "refs/heads/" + name
+		pushEvent.HeadBranch = pushEvent.BaseBranch
+		ret = append(ret, pushEvent)
+	}
+	if !pullRequests {
+		return ret, nil
+	}
+
+	_, err = provider.Paginate(func(page int) ([]*gitea.PullRequest, int, error) {
+		opt := gitea.ListPullRequestsOptions{ListOptions: gitea.ListOptions{Page: page, PageSize: 50}, State: gitea.StateOpen}
+		prs, resp, err := v.Client().ListRepoPullRequests(event.Organization, event.Repository, opt)
+		if err != nil {
+			return nil, 0, err
+		}
+		return prs, resp.NextPage, nil
+	}, func(pr *gitea.PullRequest) bool {
+		if pr.Head == nil || pr.Base == nil {
+			return false
+		}
+		prEvent := newPollEvent(event, repo)
+		prEvent.EventType = triggertype.PullRequest.String()
+		prEvent.TriggerTarget = triggertype.PullRequest
+		prEvent.SHA = pr.Head.Sha
+		prEvent.SHAURL = fmt.Sprintf("%s/commit/%s", pr.HTMLURL, pr.Head.Sha)
+		prEvent.BaseBranch = pr.Base.Ref
+		prEvent.HeadBranch = pr.Head.Ref
+		if pr.Base.Repository != nil {
+			prEvent.BaseURL = pr.Base.Repository.HTMLURL
+		}
+		if pr.Head.Repository != nil {
+			prEvent.HeadURL = pr.Head.Repository.HTMLURL
+		}
+		if pr.Poster != nil {
+			prEvent.Sender = pr.Poster.UserName
+		}
+		prEvent.PullRequestNumber = int(pr.Index)
+		prEvent.PullRequestTitle = pr.Title
+		for _, label := range pr.Labels {
+			prEvent.PullRequestLabel = append(prEvent.PullRequestLabel, label.Name)
+		}
+		ret = append(ret, prEvent)
+		return false
+	})
+	if err != nil {
+		return nil, fmt.Errorf("cannot list the pull requests of %s/%s: %w", event.Organization, event.Repository, err)
+	}
+	return ret, nil
+}
+
+func newPollEvent(event *info.Event, repo *gitea.Repository) *info.Event {
+	ret := info.NewEvent()
+	ret.Organization = event.Organization
+	ret.Repository = event.Repository
+	ret.DefaultBranch = repo.DefaultBranch
+	// the URL of the Repository the events are matched to
+	ret.URL = event.URL
+	ret.BaseURL = ret.URL
+	ret.HeadURL = ret.URL
+	ret.Provider.Token = event.Provider.Token
+	ret.Provider.URL = event.Provider.URL
+	ret.Provider.User = event.Provider.User
+	return ret
+}
@@ -0,0 +1,48 @@
+package gitea
+
+import (
+	"context"
+	"fmt"
+
+	"code.gitea.io/sdk/gitea"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/params/info"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/provider"
+)
+
+// GetReviewDecision returns whether the pull request of the event has been
+// approved or had changes requested by its reviewers, the dismissed reviews
+// are ignored.
+func (v *Provider) GetReviewDecision(_ context.Context, event *info.Event) (string, error) {
+	if v.giteaClient == nil {
+		return "", fmt.Errorf("no gitea client has been initialized")
+	}
+	if event.PullRequestNumber == 0 {
+		return "", nil
+	}
+	reviews := []provider.Review{}
+	_, err := provider.Paginate(func(page int) ([]*gitea.PullReview, int, error) {
+		prReviews, resp, err := v.Client().ListPullReviews(event.Organization, event.Repository, int64(event.PullRequestNumber),
+			gitea.ListPullReviewsOptions{ListOptions: gitea.ListOptions{Page: page}})
+		if err != nil {
+			return nil, 0, err
+		}
+		return prReviews, resp.NextPage, nil
+	}, func(review *gitea.PullReview) bool {
+		if review.Dismissed || review.Reviewer == nil {
+			return false
+		}
+		state := ""
+		switch review.State {
+		case gitea.ReviewStateApproved:
+			state = provider.ReviewDecisionApproved
+		case gitea.ReviewStateRequestChanges:
+			state = provider.ReviewDecisionChangesRequested
+		}
+		reviews = append(reviews, provider.Review{Reviewer: review.Reviewer.UserName, State: state})
+		return false
+	})
+	if err != nil {
+		return "", fmt.Errorf("cannot list the reviews of the pull request %d: %w", event.PullRequestNumber, err)
+	}
+	return provider.ReviewDecision(reviews), nil
+}
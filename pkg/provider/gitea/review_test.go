@@ -0,0 +1,56 @@
+package gitea
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/params/info"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/provider"
+	tgitea "github.com/openshift-pipelines/pipelines-as-code/pkg/provider/gitea/test"
+	"gotest.tools/v3/assert"
+	rtesting "knative.dev/pkg/reconciler/testing"
+)
+
+func TestGetReviewDecision(t *testing.T) {
+	tests := []struct {
+		name    string
+		reviews string
+		want    string
+	}{
+		{
+			name:    "approved",
+			reviews: `[{"state":"APPROVED","user":{"login":"reviewer"}},{"state":"COMMENT","user":{"login":"other"}}]`,
+			want:    provider.ReviewDecisionApproved,
+		},
+		{
+			name:    "changes requested",
+			reviews: `[{"state":"APPROVED","user":{"login":"reviewer"}},{"state":"REQUEST_CHANGES","user":{"login":"other"}}]`,
+			want:    provider.ReviewDecisionChangesRequested,
+		},
+		{
+			name:    "dismissed request for changes",
+			reviews: `[{"state":"APPROVED","user":{"login":"reviewer"}},{"state":"REQUEST_CHANGES","dismissed":true,"user":{"login":"other"}}]`,
+			want:    provider.ReviewDecisionApproved,
+		},
+		{
+			name:    "not reviewed",
+			reviews: `[]`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fakeclient, mux, teardown := tgitea.Setup(t)
+			defer teardown()
+			mux.HandleFunc("/repos/myorg/myrepo/pulls/1/reviews", func(rw http.ResponseWriter, _ *http.Request) {
+				fmt.Fprint(rw, tt.reviews)
+			})
+			v := &Provider{giteaClient: fakeclient}
+			ctx, _ := rtesting.SetupFakeContext(t)
+			event := &info.Event{Organization: "myorg", Repository: "myrepo", PullRequestNumber: 1}
+			got, err := v.GetReviewDecision(ctx, event)
+			assert.NilError(t, err)
+			assert.Equal(t, got, tt.want)
+		})
+	}
+}
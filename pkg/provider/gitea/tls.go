@@ -0,0 +1,60 @@
+package gitea
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net/http"
+	"os"
+
+	"code.gitea.io/sdk/gitea"
+)
+
+// TLSOptions configures how the TLS certificate of a Gitea instance is
+// verified, for instances using a self-signed certificate.
+type TLSOptions struct {
+	// InsecureSkipVerify disables the verification of the certificate.
+	InsecureSkipVerify bool
+	// CAFile is a PEM file with the certificate authorities to trust on top
+	// of the system ones.
+	CAFile string
+}
+
+// HTTPClient returns the HTTP client to use for the Gitea API, or nil when
+// the default one can be used.
+func (o TLSOptions) HTTPClient() (*http.Client, error) {
+	if !o.InsecureSkipVerify && o.CAFile == "" {
+		return nil, nil
+	}
+	//nolint:gosec // skipping the verification is explicitly requested
+	tlsConfig := &tls.Config{InsecureSkipVerify: o.InsecureSkipVerify}
+	if o.CAFile != "" {
+		pem, err := os.ReadFile(o.CAFile)
+		if err != nil {
+			return nil, fmt.Errorf("cannot read CA file: %w", err)
+		}
+		pool, err := x509.SystemCertPool()
+		if err != nil {
+			pool = x509.NewCertPool()
+		}
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("no certificate found in CA file %s", o.CAFile)
+		}
+		tlsConfig.RootCAs = pool
+	}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.TLSClientConfig = tlsConfig
+	return &http.Client{Transport: transport}, nil
+}
+
+// NewClient creates a Gitea API client for apiURL with the TLS options.
+func NewClient(apiURL string, tlsOpts TLSOptions, options ...gitea.ClientOption) (*gitea.Client, error) {
+	httpClient, err := tlsOpts.HTTPClient()
+	if err != nil {
+		return nil, err
+	}
+	if httpClient != nil {
+		options = append(options, gitea.SetHTTPClient(httpClient))
+	}
+	return gitea.NewClient(apiURL, options...)
+}
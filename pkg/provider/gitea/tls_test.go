@@ -0,0 +1,71 @@
+package gitea
+
+import (
+	"encoding/pem"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"gotest.tools/v3/assert"
+)
+
+func TestNewClientTLS(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		fmt.Fprint(w, `{"version": "1.20.0"}`)
+	}))
+	defer server.Close()
+
+	dir := t.TempDir()
+	caFile := filepath.Join(dir, "ca.pem")
+	assert.NilError(t, os.WriteFile(caFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw}), 0o600))
+	invalidCAFile := filepath.Join(dir, "invalid.pem")
+	assert.NilError(t, os.WriteFile(invalidCAFile, []byte("not a certificate"), 0o600))
+
+	tests := []struct {
+		name    string
+		opts    TLSOptions
+		wantErr string
+	}{
+		{
+			name:    "self-signed certificate",
+			wantErr: "certificate",
+		},
+		{
+			name: "insecure skip verify",
+			opts: TLSOptions{InsecureSkipVerify: true},
+		},
+		{
+			name: "ca file",
+			opts: TLSOptions{CAFile: caFile},
+		},
+		{
+			name:    "missing ca file",
+			opts:    TLSOptions{CAFile: filepath.Join(dir, "missing.pem")},
+			wantErr: "cannot read CA file",
+		},
+		{
+			name:    "invalid ca file",
+			opts:    TLSOptions{CAFile: invalidCAFile},
+			wantErr: "no certificate found in CA file",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewClient(server.URL, tt.opts)
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			assert.NilError(t, err)
+		})
+	}
+}
+
+func TestTLSOptionsHTTPClient(t *testing.T) {
+	client, err := TLSOptions{}.HTTPClient()
+	assert.NilError(t, err)
+	assert.Assert(t, client == nil)
+}
@@ -4,6 +4,7 @@ import (
 	"os"
 	"testing"
 
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/test/payloads"
 	"gotest.tools/v3/assert"
 )
 
@@ -37,3 +38,14 @@ func Test_parseWebhook(t *testing.T) {
 		})
 	}
 }
+
+func Test_parseWebhookFixtures(t *testing.T) {
+	fixtures, err := payloads.List("gitea")
+	assert.NilError(t, err)
+	for _, fixture := range fixtures {
+		t.Run(fixture.Name, func(t *testing.T) {
+			_, err := parseWebhook(whEventType(fixture.Event), fixture.Payload)
+			assert.NilError(t, err)
+		})
+	}
+}
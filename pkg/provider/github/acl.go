@@ -10,38 +10,58 @@ import (
 	"github.com/openshift-pipelines/pipelines-as-code/pkg/acl"
 	"github.com/openshift-pipelines/pipelines-as-code/pkg/params/info"
 	"github.com/openshift-pipelines/pipelines-as-code/pkg/policy"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/provider"
 )
 
 // CheckPolicyAllowing check that policy is allowing the event to be processed
 // we  check the membership of the team allowed
 // if the team is not found we explicitly disallow the policy, user have to correct the setting.
 func (v *Provider) CheckPolicyAllowing(ctx context.Context, event *info.Event, allowedTeams []string) (bool, string) {
+	if !v.graphQLUnavailable && len(allowedTeams) > 0 {
+		if membership, err := v.getTeamsMembershipGraphQL(ctx, event, allowedTeams); err == nil {
+			for _, team := range allowedTeams {
+				isMember, found := membership[team]
+				if !found {
+					return false, fmt.Sprintf("team: %s is not found on the organization: %s", team, event.Organization)
+				}
+				if isMember {
+					return true, fmt.Sprintf("allowing user: %s as a member of the team: %s", event.Sender, team)
+				}
+			}
+			return false, fmt.Sprintf("user: %s is not a member of any of the allowed teams: %v", event.Sender, allowedTeams)
+		}
+	}
 	for _, team := range allowedTeams {
 		// TODO: caching
-		opt := github.ListOptions{PerPage: v.PaginedNumber}
-		for {
+		notFound := false
+		isMember, err := provider.Paginate(func(page int) ([]*github.User, int, error) {
+			opt := github.ListOptions{PerPage: v.PaginedNumber, Page: page}
 			members, resp, err := wrapAPI(v, "list_team_members_by_slug", func() ([]*github.User, *github.Response, error) {
 				return v.Client().Teams.ListTeamMembersBySlug(ctx, event.Organization, team, &github.TeamListTeamMembersOptions{ListOptions: opt})
 			})
-			if resp.StatusCode == http.StatusNotFound {
-				// we explicitly disallow the policy when the team is not found
-				// maybe we should ignore it instead? i'd rather keep this explicit
-				// and conservative since being security related.
-				return false, fmt.Sprintf("team: %s is not found on the organization: %s", team, event.Organization)
+			if resp != nil && resp.StatusCode == http.StatusNotFound {
+				notFound = true
+				return nil, 0, nil
 			}
 			if err != nil {
-				// probably a 500 or another api error, no need to try again and again with other teams
-				return false, fmt.Sprintf("error while getting team membership for user: %s in team: %s, error: %s", event.Sender, team, err.Error())
-			}
-			for _, member := range members {
-				if member.GetLogin() == event.Sender {
-					return true, fmt.Sprintf("allowing user: %s as a member of the team: %s", event.Sender, team)
-				}
+				return nil, 0, err
 			}
-			if resp.NextPage == 0 {
-				break
-			}
-			opt.Page = resp.NextPage
+			return members, resp.NextPage, nil
+		}, func(member *github.User) bool {
+			return member.GetLogin() == event.Sender
+		})
+		if notFound {
+			// we explicitly disallow the policy when the team is not found
+			// maybe we should ignore it instead? i'd rather keep this explicit
+			// and conservative since being security related.
+			return false, fmt.Sprintf("team: %s is not found on the organization: %s", team, event.Organization)
+		}
+		if err != nil {
+			// probably a 500 or another api error, no need to try again and again with other teams
+			return false, fmt.Sprintf("error while getting team membership for user: %s in team: %s, error: %s", event.Sender, team, err.Error())
+		}
+		if isMember {
+			return true, fmt.Sprintf("allowing user: %s as a member of the team: %s", event.Sender, team)
 		}
 	}
 
@@ -150,7 +170,7 @@ func (v *Provider) aclAllowedOkToTestFromAnOwner(ctx context.Context, event *inf
 		return false, nil
 	}
 
-	comments, err := v.GetStringPullRequestComment(ctx, revent, acl.OKToTestCommentRegexp)
+	comments, err := v.GetStringPullRequestComment(ctx, revent, acl.OKToTestRegexp())
 	if err != nil {
 		return false, err
 	}
@@ -177,7 +197,7 @@ func (v *Provider) aclAllowedOkToTestCurrentComment(ctx context.Context, revent
 	if err != nil {
 		return false, err
 	}
-	if acl.MatchRegexp(acl.OKToTestCommentRegexp, comment.GetBody()) {
+	if acl.MatchRegexp(acl.OKToTestRegexp(), comment.GetBody()) {
 		revent.Sender = comment.User.GetLogin()
 		allowed, err := v.aclCheckAll(ctx, revent)
 		if err != nil {
@@ -259,33 +279,24 @@ func (v *Provider) checkPullRequestForSameURL(ctx context.Context, runevent *inf
 // checkSenderOrgMembership Get sender user's organization. We can
 // only get the one that the user sets as public 🤷.
 func (v *Provider) checkSenderOrgMembership(ctx context.Context, runevent *info.Event) (bool, error) {
-	opt := &github.ListMembersOptions{
-		ListOptions: github.ListOptions{PerPage: v.PaginedNumber},
-	}
-
-	for {
+	return provider.Paginate(func(page int) ([]*github.User, int, error) {
+		opt := &github.ListMembersOptions{
+			ListOptions: github.ListOptions{PerPage: v.PaginedNumber, Page: page},
+		}
 		users, resp, err := wrapAPI(v, "list_org_members", func() ([]*github.User, *github.Response, error) {
 			return v.Client().Organizations.ListMembers(ctx, runevent.Organization, opt)
 		})
 		// If we are 404 it means we are checking a repo owner and not a org so let's bail out with grace
 		if resp != nil && resp.StatusCode == http.StatusNotFound {
-			return false, nil
+			return nil, 0, nil
 		}
-
 		if err != nil {
-			return false, err
-		}
-		for _, v := range users {
-			if v.GetLogin() == runevent.Sender {
-				return true, nil
-			}
-		}
-		if resp.NextPage == 0 {
-			break
+			return nil, 0, err
 		}
-		opt.Page = resp.NextPage
-	}
-	return false, nil
+		return users, resp.NextPage, nil
+	}, func(user *github.User) bool {
+		return user.GetLogin() == runevent.Sender
+	})
 }
 
 // checkSenderRepoMembership check if user is allowed to run CI.
@@ -319,26 +330,26 @@ func (v *Provider) GetStringPullRequestComment(ctx context.Context, runevent *in
 		return nil, err
 	}
 
-	opt := &github.IssueListCommentsOptions{
-		ListOptions: github.ListOptions{PerPage: v.PaginedNumber},
-	}
-	for {
+	_, err = provider.Paginate(func(page int) ([]*github.IssueComment, int, error) {
+		opt := &github.IssueListCommentsOptions{
+			ListOptions: github.ListOptions{PerPage: v.PaginedNumber, Page: page},
+		}
 		comments, resp, err := wrapAPI(v, "list_issue_comments", func() ([]*github.IssueComment, *github.Response, error) {
 			return v.Client().Issues.ListComments(ctx, runevent.Organization, runevent.Repository,
 				prNumber, opt)
 		})
 		if err != nil {
-			return nil, err
-		}
-		for _, v := range comments {
-			if acl.MatchRegexp(reg, v.GetBody()) {
-				ret = append(ret, v)
-			}
+			return nil, 0, err
 		}
-		if resp.NextPage == 0 {
-			break
+		return comments, resp.NextPage, nil
+	}, func(comment *github.IssueComment) bool {
+		if acl.MatchRegexp(reg, comment.GetBody()) {
+			ret = append(ret, comment)
 		}
-		opt.Page = resp.NextPage
+		return false
+	})
+	if err != nil {
+		return nil, err
 	}
 	return ret, nil
 }
@@ -68,7 +68,13 @@ func (ip *Install) GetAndUpdateInstallationID(ctx context.Context) (string, stri
 	}
 	apiURL := *ip.ghClient.APIURL
 	enterpriseHost := ip.request.Header.Get("X-GitHub-Enterprise-Host")
-	if enterpriseHost != "" {
+	if _, ok := github.GHECloudAPIURL(repoURL.Host); ok && enterpriseHost == "" {
+		// the incoming webhooks of a repository on a ghe.com tenant
+		enterpriseHost = repoURL.Host
+	}
+	if gheCloudURL, ok := github.GHECloudAPIURL(enterpriseHost); ok {
+		apiURL = gheCloudURL
+	} else if enterpriseHost != "" {
 		apiURL = fmt.Sprintf("https://%s/api/v3", strings.TrimSuffix(enterpriseHost, "/"))
 	}
 
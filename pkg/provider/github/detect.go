@@ -14,6 +14,9 @@ import (
 var (
 	pullRequestOpenSyncEvent = []string{"opened", "synchronize", "synchronized", "reopened", "ready_for_review"}
 	pullRequestLabelEvent    = []string{"labeled"}
+	// pullRequestReviewEvent are the actions of the reviews submitted on or
+	// dismissed from a pull request.
+	pullRequestReviewEvent = []string{"submitted", "dismissed"}
 )
 
 // Detect processes event and detect if it is a github event, whether to process or reject it
@@ -72,6 +75,22 @@ func (v *Provider) detectTriggerTypeFromPayload(ghEventType string, eventInt any
 			return triggertype.PullRequest, ""
 		}
 		return "", fmt.Sprintf("pull_request: unsupported action \"%s\"", event.GetAction())
+	case *github.PullRequestReviewEvent:
+		if !provider.Valid(event.GetAction(), pullRequestReviewEvent) {
+			return "", fmt.Sprintf("pull_request_review: unsupported action \"%s\"", event.GetAction())
+		}
+		if event.GetPullRequest().GetState() != "open" {
+			return "", "pull_request_review: the pull request is not open"
+		}
+		return triggertype.PullRequest, ""
+	case *github.PullRequestReviewCommentEvent:
+		if event.GetAction() != "created" {
+			return "", fmt.Sprintf("pull_request_review_comment: unsupported action \"%s\"", event.GetAction())
+		}
+		if event.GetPullRequest().GetState() != "open" {
+			return "", "pull_request_review_comment: the pull request is not open"
+		}
+		return triggertype.PullRequest, ""
 	case *github.IssueCommentEvent:
 		if event.GetAction() == "created" &&
 			event.GetIssue().IsPullRequest() &&
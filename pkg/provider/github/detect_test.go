@@ -87,6 +87,76 @@ func TestProvider_Detect(t *testing.T) {
 			isGH:       true,
 			processReq: false,
 		},
+		{
+			name: "pull request review approving",
+			event: github.PullRequestReviewEvent{
+				Action:      github.Ptr("submitted"),
+				Review:      &github.PullRequestReview{State: github.Ptr("approved")},
+				PullRequest: &github.PullRequest{State: github.Ptr("open")},
+			},
+			eventType:  "pull_request_review",
+			isGH:       true,
+			processReq: true,
+		},
+		{
+			name: "pull request review dismissed",
+			event: github.PullRequestReviewEvent{
+				Action:      github.Ptr("dismissed"),
+				Review:      &github.PullRequestReview{State: github.Ptr("dismissed")},
+				PullRequest: &github.PullRequest{State: github.Ptr("open")},
+			},
+			eventType:  "pull_request_review",
+			isGH:       true,
+			processReq: true,
+		},
+		{
+			name: "pull request review commenting",
+			event: github.PullRequestReviewEvent{
+				Action:      github.Ptr("submitted"),
+				Review:      &github.PullRequestReview{State: github.Ptr("commented")},
+				PullRequest: &github.PullRequest{State: github.Ptr("open")},
+			},
+			eventType:  "pull_request_review",
+			isGH:       true,
+			processReq: true,
+		},
+		{
+			name: "pull request review comment",
+			event: github.PullRequestReviewCommentEvent{
+				Action:      github.Ptr("created"),
+				PullRequest: &github.PullRequest{State: github.Ptr("open")},
+			},
+			eventType:  "pull_request_review_comment",
+			isGH:       true,
+			processReq: true,
+		},
+		{
+			name: "pull request review comment edited",
+			event: github.PullRequestReviewCommentEvent{
+				Action:      github.Ptr("edited"),
+				PullRequest: &github.PullRequest{State: github.Ptr("open")},
+			},
+			eventType:  "pull_request_review_comment",
+			wantReason: "pull_request_review_comment: unsupported action \"edited\"",
+		},
+		{
+			name: "pull request review edited",
+			event: github.PullRequestReviewEvent{
+				Action: github.Ptr("edited"),
+			},
+			eventType:  "pull_request_review",
+			wantReason: "pull_request_review: unsupported action \"edited\"",
+		},
+		{
+			name: "pull request review on a closed pull request",
+			event: github.PullRequestReviewEvent{
+				Action:      github.Ptr("submitted"),
+				Review:      &github.PullRequestReview{State: github.Ptr("approved")},
+				PullRequest: &github.PullRequest{State: github.Ptr("closed")},
+			},
+			eventType:  "pull_request_review",
+			wantReason: "the pull request is not open",
+		},
 		{
 			name: "invalid issue comment Event",
 			event: github.IssueCommentEvent{
@@ -0,0 +1,60 @@
+package github
+
+import (
+	"encoding/json"
+	"net/url"
+	"strings"
+)
+
+// gheCloudDomain is the domain of the GitHub Enterprise Cloud tenants with
+// data residency, ie: octocorp.ghe.com. Their API is on the api subdomain of
+// the tenant instead of the /api/v3 path of GitHub Enterprise Server.
+const gheCloudDomain = ".ghe.com"
+
+// gheCloudTenant returns the tenant host of a GitHub Enterprise Cloud host or
+// URL with data residency, ie: octocorp.ghe.com for
+// https://api.octocorp.ghe.com/repos/org/repo.
+func gheCloudTenant(hostOrURL string) (string, bool) {
+	host := hostOrURL
+	if !strings.Contains(host, "://") {
+		host = "https://" + host
+	}
+	u, err := url.Parse(host)
+	if err != nil {
+		return "", false
+	}
+	host = strings.TrimPrefix(strings.ToLower(u.Hostname()), "api.")
+	tenant, ok := strings.CutSuffix(host, gheCloudDomain)
+	if !ok || tenant == "" || strings.Contains(tenant, ".") {
+		return "", false
+	}
+	return host, true
+}
+
+// GHECloudAPIURL returns the API URL of a GitHub Enterprise Cloud tenant
+// with data residency, ie: https://api.octocorp.ghe.com for
+// octocorp.ghe.com, and false for any other host.
+func GHECloudAPIURL(hostOrURL string) (string, bool) {
+	tenant, ok := gheCloudTenant(hostOrURL)
+	if !ok {
+		return "", false
+	}
+	return "https://api." + tenant, true
+}
+
+// gheCloudHostFromPayload returns the tenant host of the repository of the
+// payload when it is on GitHub Enterprise Cloud with data residency, for the
+// events without the X-GitHub-Enterprise-Host header. The ghe.com tenants are
+// all hosted by GitHub, the app token cannot be sent to another server.
+func gheCloudHostFromPayload(payload string) string {
+	var data struct {
+		Repository struct {
+			HTMLURL string `json:"html_url"`
+		} `json:"repository"`
+	}
+	if err := json.Unmarshal([]byte(payload), &data); err != nil || data.Repository.HTMLURL == "" {
+		return ""
+	}
+	tenant, _ := gheCloudTenant(data.Repository.HTMLURL)
+	return tenant
+}
@@ -0,0 +1,88 @@
+package github
+
+import (
+	"testing"
+
+	"gotest.tools/v3/assert"
+)
+
+func TestGHECloudAPIURL(t *testing.T) {
+	tests := []struct {
+		name      string
+		hostOrURL string
+		want      string
+	}{
+		{
+			name:      "tenant host",
+			hostOrURL: "octocorp.ghe.com",
+			want:      "https://api.octocorp.ghe.com",
+		},
+		{
+			name:      "tenant url",
+			hostOrURL: "https://OctoCorp.ghe.com/org/repo",
+			want:      "https://api.octocorp.ghe.com",
+		},
+		{
+			name:      "tenant api url",
+			hostOrURL: "https://api.octocorp.ghe.com/repos/org/repo",
+			want:      "https://api.octocorp.ghe.com",
+		},
+		{
+			name:      "ghe.com itself",
+			hostOrURL: "ghe.com",
+		},
+		{
+			name:      "subdomain of a tenant",
+			hostOrURL: "evil.octocorp.ghe.com",
+		},
+		{
+			name:      "ghe.com suffix of another domain",
+			hostOrURL: "octocorp.ghe.com.example.com",
+		},
+		{
+			name:      "github enterprise server",
+			hostOrURL: "ghe.example.com",
+		},
+		{
+			name: "public github",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := GHECloudAPIURL(tt.hostOrURL)
+			assert.Equal(t, ok, tt.want != "")
+			assert.Equal(t, got, tt.want)
+		})
+	}
+}
+
+func TestGHECloudHostFromPayload(t *testing.T) {
+	tests := []struct {
+		name    string
+		payload string
+		want    string
+	}{
+		{
+			name:    "repository on a tenant",
+			payload: `{"repository": {"html_url": "https://octocorp.ghe.com/org/repo"}}`,
+			want:    "octocorp.ghe.com",
+		},
+		{
+			name:    "repository on github",
+			payload: `{"repository": {"html_url": "https://github.com/org/repo"}}`,
+		},
+		{
+			name:    "no repository",
+			payload: `{"installation": {"id": 1}}`,
+		},
+		{
+			name:    "invalid payload",
+			payload: `{`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, gheCloudHostFromPayload(tt.payload), tt.want)
+		})
+	}
+}
@@ -47,6 +47,8 @@ type Provider struct {
 	pacInfo       *info.PacOpts
 	Token, APIURL *string
 	ApplicationID *int64
+	// AppSlug is the slug of the GitHub App the client has been created for.
+	AppSlug       string
 	providerName  string
 	provenance    string
 	RepositoryIDs []int64
@@ -54,6 +56,9 @@ type Provider struct {
 	eventEmitter  *events.EventEmitter
 	PaginedNumber int
 	userType      string // The type of user i.e bot or not
+	// graphQLUnavailable is set when a GraphQL query has failed, the REST
+	// API is used instead from then on.
+	graphQLUnavailable bool
 	skippedRun
 	triggerEvent string
 }
@@ -207,7 +212,9 @@ func MakeClient(ctx context.Context, apiURL, token string) (*github.Client, stri
 	)
 
 	tc := oauth2.NewClient(ctx, ts)
-	if apiURL != "" {
+	if gheCloudURL, ok := GHECloudAPIURL(apiURL); ok {
+		apiURL = gheCloudURL
+	} else if apiURL != "" {
 		if !strings.HasPrefix(apiURL, "https") && !strings.HasPrefix(apiURL, "http") {
 			apiURL = "https://" + apiURL
 		}
@@ -454,10 +461,7 @@ func (v *Provider) concatAllYamlFiles(ctx context.Context, objects []*github.Tre
 			if err := provider.ValidateYaml(data, value.GetPath()); err != nil {
 				return "", err
 			}
-			if allTemplates != "" && !strings.HasPrefix(string(data), "---") {
-				allTemplates += "---"
-			}
-			allTemplates += "\n" + string(data) + "\n"
+			allTemplates = provider.AppendYamlFile(allTemplates, value.GetPath(), string(data))
 		}
 	}
 	return allTemplates, nil
@@ -503,6 +507,11 @@ func (v *Provider) getPullRequest(ctx context.Context, runevent *info.Event) (*i
 // GetFiles get a files from pull request.
 func (v *Provider) GetFiles(ctx context.Context, runevent *info.Event) (changedfiles.ChangedFiles, error) {
 	if runevent.TriggerTarget == triggertype.PullRequest {
+		if !v.graphQLUnavailable {
+			if changedFiles, err := v.getPullRequestFilesGraphQL(ctx, runevent); err == nil {
+				return changedFiles, nil
+			}
+		}
 		opt := &github.ListOptions{PerPage: v.PaginedNumber}
 		changedFiles := changedfiles.ChangedFiles{}
 		for {
@@ -607,7 +616,17 @@ func ListRepos(ctx context.Context, v *Provider) ([]string, error) {
 }
 
 func (v *Provider) CreateToken(ctx context.Context, repository []string, event *info.Event) (string, error) {
+	// the repositories are looked up in the ones of the installation first,
+	// which are cached, instead of fetching them one by one on every event
+	installed, err := v.getInstallationRepositories(ctx, event.InstallationID)
+	if err != nil && v.Logger != nil {
+		v.Logger.Debugf("cannot list the repositories of the installation %d: %v", event.InstallationID, err)
+	}
 	for _, r := range repository {
+		if id, ok := installed[strings.ToLower(r)]; ok {
+			v.RepositoryIDs = uniqueRepositoryID(v.RepositoryIDs, id)
+			continue
+		}
 		split := strings.Split(r, "/")
 		infoData, _, err := wrapAPI(v, "get_repository", func() (*github.Repository, *github.Response, error) {
 			return v.Client().Repositories.Get(ctx, split[0], split[1])
@@ -626,6 +645,43 @@ func (v *Provider) CreateToken(ctx context.Context, repository []string, event *
 	return token, nil
 }
 
+// GetCIVariables returns the Actions variables of the repository, the values
+// of the Actions secrets can't be read from the API.
+func (v *Provider) GetCIVariables(ctx context.Context, event *info.Event, names []string) (map[string]string, error) {
+	if v.ghClient == nil {
+		return nil, fmt.Errorf("no github client has been initialized")
+	}
+	ret := map[string]string{}
+	for _, name := range names {
+		variable, _, err := wrapAPI(v, "get_repo_variable", func() (*github.ActionsVariable, *github.Response, error) {
+			return v.Client().Actions.GetRepoVariable(ctx, event.Organization, event.Repository, name)
+		})
+		if err != nil {
+			return nil, fmt.Errorf("cannot get the Actions variable %s of %s/%s: %w", name, event.Organization, event.Repository, err)
+		}
+		ret[name] = variable.Value
+	}
+	return ret, nil
+}
+
+// GetUserProfile returns the public profile of the sender of the event.
+func (v *Provider) GetUserProfile(ctx context.Context, event *info.Event) (*provider.UserProfile, error) {
+	if v.ghClient == nil {
+		return nil, fmt.Errorf("no github client has been initialized")
+	}
+	user, _, err := wrapAPI(v, "get_user", func() (*github.User, *github.Response, error) {
+		return v.Client().Users.Get(ctx, event.Sender)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("cannot get the user %s: %w", event.Sender, err)
+	}
+	return &provider.UserProfile{
+		Email:     user.GetEmail(),
+		FullName:  user.GetName(),
+		AvatarURL: user.GetAvatarURL(),
+	}, nil
+}
+
 func uniqueRepositoryID(repoIDs []int64, id int64) []int64 {
 	r := repoIDs
 	m := make(map[int64]bool)
@@ -640,8 +696,9 @@ func uniqueRepositoryID(repoIDs []int64, id int64) []int64 {
 	return r
 }
 
-// isHeadCommitOfBranch checks whether provided branch is valid or not and SHA is HEAD commit of the branch.
-func (v *Provider) isHeadCommitOfBranch(ctx context.Context, runevent *info.Event, branchName string) error {
+// isCommitInBranch checks whether provided branch is valid or not and SHA is
+// part of the history of the branch.
+func (v *Provider) isCommitInBranch(ctx context.Context, runevent *info.Event, branchName string) error {
 	if v.ghClient == nil {
 		return fmt.Errorf("no github client has been initialized, " +
 			"exiting... (hint: did you forget setting a secret on your repo?)")
@@ -656,7 +713,18 @@ func (v *Provider) isHeadCommitOfBranch(ctx context.Context, runevent *info.Even
 	if branchInfo.Commit.GetSHA() == runevent.SHA {
 		return nil
 	}
-	return fmt.Errorf("provided SHA %s is not the HEAD commit of the branch %s", runevent.SHA, branchName)
+
+	// the branch is ahead of the SHA when the SHA is one of its ancestors
+	comparison, _, err := wrapAPI(v, "compare_commits", func() (*github.CommitsComparison, *github.Response, error) {
+		return v.Client().Repositories.CompareCommits(ctx, runevent.Organization, runevent.Repository, runevent.SHA, branchName, &github.ListOptions{PerPage: 1})
+	})
+	if err != nil {
+		return fmt.Errorf("provided SHA %s is not part of the branch %s: %w", runevent.SHA, branchName, err)
+	}
+	if comparison.GetStatus() == "ahead" || comparison.GetStatus() == "identical" {
+		return nil
+	}
+	return fmt.Errorf("provided SHA %s is not part of the branch %s", runevent.SHA, branchName)
 }
 
 func (v *Provider) GetTemplate(commentType provider.CommentType) string {
@@ -709,3 +777,45 @@ func (v *Provider) CreateComment(ctx context.Context, event *info.Event, commit,
 	})
 	return err
 }
+
+// CreateLineComment creates a review comment on a line of a file of a Pull
+// Request, the comment can hold a suggestion block the user can commit. GitHub
+// refuses it when the line is not part of the diff of the Pull Request. The
+// comment is not created again when it is already on the line.
+func (v *Provider) CreateLineComment(ctx context.Context, event *info.Event, path string, line int, comment string) error {
+	if v.ghClient == nil {
+		return fmt.Errorf("no github client has been initialized")
+	}
+
+	if event.PullRequestNumber == 0 {
+		return fmt.Errorf("create line comment only works on pull requests")
+	}
+
+	comments, _, err := wrapAPI(v, "list_review_comments", func() ([]*github.PullRequestComment, *github.Response, error) {
+		return v.Client().PullRequests.ListComments(ctx, event.Organization, event.Repository, event.PullRequestNumber, &github.PullRequestListCommentsOptions{
+			ListOptions: github.ListOptions{
+				Page:    1,
+				PerPage: 100,
+			},
+		})
+	})
+	if err != nil {
+		return err
+	}
+	for _, existing := range comments {
+		if existing.GetPath() == path && existing.GetLine() == line && existing.GetBody() == comment {
+			return nil
+		}
+	}
+
+	_, _, err = wrapAPI(v, "create_review_comment", func() (*github.PullRequestComment, *github.Response, error) {
+		return v.Client().PullRequests.CreateComment(ctx, event.Organization, event.Repository, event.PullRequestNumber, &github.PullRequestComment{
+			Body:     github.Ptr(comment),
+			CommitID: github.Ptr(event.SHA),
+			Path:     github.Ptr(path),
+			Line:     github.Ptr(line),
+			Side:     github.Ptr("RIGHT"),
+		})
+	})
+	return err
+}
@@ -26,6 +26,7 @@ import (
 	"github.com/openshift-pipelines/pipelines-as-code/pkg/params/info"
 	"github.com/openshift-pipelines/pipelines-as-code/pkg/params/settings"
 	"github.com/openshift-pipelines/pipelines-as-code/pkg/params/triggertype"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/provider"
 	testclient "github.com/openshift-pipelines/pipelines-as-code/pkg/test/clients"
 	ghtesthelper "github.com/openshift-pipelines/pipelines-as-code/pkg/test/github"
 	"github.com/openshift-pipelines/pipelines-as-code/pkg/test/logger"
@@ -485,6 +486,89 @@ func TestGetFileInsideRepo(t *testing.T) {
 	}
 }
 
+func TestGetCIVariables(t *testing.T) {
+	tests := []struct {
+		name       string
+		names      []string
+		want       map[string]string
+		wantErrStr string
+	}{
+		{
+			name:  "variables",
+			names: []string{"REGISTRY", "REGION"},
+			want:  map[string]string{"REGISTRY": "quay.io", "REGION": "eu"},
+		},
+		{
+			name:       "variable not found",
+			names:      []string{"REGISTRY", "MISSING"},
+			wantErrStr: "cannot get the Actions variable MISSING of tekton/thecat",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, _ := rtesting.SetupFakeContext(t)
+			fakeclient, mux, _, teardown := ghtesthelper.SetupGH()
+			defer teardown()
+			for name, value := range map[string]string{"REGISTRY": "quay.io", "REGION": "eu"} {
+				mux.HandleFunc("/repos/tekton/thecat/actions/variables/"+name, func(w http.ResponseWriter, _ *http.Request) {
+					fmt.Fprintf(w, `{"name": %q, "value": %q}`, name, value)
+				})
+			}
+			gvcs := Provider{ghClient: fakeclient}
+			event := &info.Event{Organization: "tekton", Repository: "thecat"}
+			got, err := gvcs.GetCIVariables(ctx, event, tt.names)
+			if tt.wantErrStr != "" {
+				assert.ErrorContains(t, err, tt.wantErrStr)
+				return
+			}
+			assert.NilError(t, err)
+			assert.DeepEqual(t, got, tt.want)
+		})
+	}
+}
+
+func TestGetUserProfile(t *testing.T) {
+	tests := []struct {
+		name       string
+		sender     string
+		want       *provider.UserProfile
+		wantErrStr string
+	}{
+		{
+			name:   "profile",
+			sender: "jane",
+			want: &provider.UserProfile{
+				Email:     "jane@example.com",
+				FullName:  "Jane Doe",
+				AvatarURL: "https://avatars.example.com/jane",
+			},
+		},
+		{
+			name:       "user not found",
+			sender:     "ghost",
+			wantErrStr: "cannot get the user ghost",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, _ := rtesting.SetupFakeContext(t)
+			fakeclient, mux, _, teardown := ghtesthelper.SetupGH()
+			defer teardown()
+			mux.HandleFunc("/users/jane", func(w http.ResponseWriter, _ *http.Request) {
+				fmt.Fprint(w, `{"login": "jane", "name": "Jane Doe", "email": "jane@example.com", "avatar_url": "https://avatars.example.com/jane"}`)
+			})
+			gvcs := Provider{ghClient: fakeclient}
+			got, err := gvcs.GetUserProfile(ctx, &info.Event{Sender: tt.sender})
+			if tt.wantErrStr != "" {
+				assert.ErrorContains(t, err, tt.wantErrStr)
+				return
+			}
+			assert.NilError(t, err)
+			assert.DeepEqual(t, got, tt.want)
+		})
+	}
+}
+
 func TestCheckSenderOrgMembership(t *testing.T) {
 	tests := []struct {
 		name      string
@@ -661,8 +745,21 @@ func TestGithubSetClient(t *testing.T) {
 		event          *info.Event
 		expectedURL    string
 		isGHE          bool
+		isGHECloud     bool
 		installationID int64
 	}{
+		{
+			name: "ghe.com tenant",
+			event: &info.Event{
+				Provider: &info.Provider{
+					URL: "octocorp.ghe.com",
+				},
+			},
+			expectedURL:    "https://api.octocorp.ghe.com",
+			isGHE:          true,
+			isGHECloud:     true,
+			installationID: 12345,
+		},
 		{
 			name: "api url set",
 			event: &info.Event{
@@ -697,7 +794,7 @@ func TestGithubSetClient(t *testing.T) {
 			assert.NilError(t, err)
 			assert.Equal(t, tt.expectedURL, *v.APIURL)
 			assert.Equal(t, "https", v.Client().BaseURL.Scheme)
-			if tt.isGHE {
+			if tt.isGHE && !tt.isGHECloud {
 				assert.Equal(t, "/api/v3/", v.Client().BaseURL.Path)
 			} else {
 				assert.Equal(t, "/", v.Client().BaseURL.Path)
@@ -1254,20 +1351,28 @@ func TestCreateToken(t *testing.T) {
 	}
 }
 
-func TestIsHeadCommitOfBranch(t *testing.T) {
+func TestIsCommitInBranch(t *testing.T) {
 	tests := []struct {
 		name       string
 		sha        string
 		branchName string
 		wantErr    bool
 	}{{
-		name:    "sha exist in the branch",
+		name:    "sha is the head of the branch",
 		sha:     "SHA1",
 		wantErr: false,
 	}, {
-		name:    "sha doesn't exist in the branch",
+		name:    "sha is an ancestor of the branch head",
+		sha:     "SHA0",
+		wantErr: false,
+	}, {
+		name:    "sha is not part of the branch",
 		sha:     "SHA2",
 		wantErr: true,
+	}, {
+		name:    "sha doesn't exist",
+		sha:     "SHA3",
+		wantErr: true,
 	}}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -1289,10 +1394,18 @@ func TestIsHeadCommitOfBranch(t *testing.T) {
 			}}}`)
 				assert.NilError(t, err)
 			})
+			mux.HandleFunc(fmt.Sprintf("/repos/%s/%s/compare/SHA0...test1",
+				runEvent.Organization, runEvent.Repository), func(rw http.ResponseWriter, _ *http.Request) {
+				_, _ = fmt.Fprint(rw, `{"status": "ahead"}`)
+			})
+			mux.HandleFunc(fmt.Sprintf("/repos/%s/%s/compare/SHA2...test1",
+				runEvent.Organization, runEvent.Repository), func(rw http.ResponseWriter, _ *http.Request) {
+				_, _ = fmt.Fprint(rw, `{"status": "diverged"}`)
+			})
 
 			ctx, _ := rtesting.SetupFakeContext(t)
 			provider := &Provider{ghClient: fakeclient}
-			err := provider.isHeadCommitOfBranch(ctx, runEvent, "test1")
+			err := provider.isCommitInBranch(ctx, runEvent, "test1")
 			assert.Equal(t, err != nil, tt.wantErr)
 		})
 	}
@@ -1304,6 +1417,10 @@ func resetMetrics() {
 		"pipelines_as_code_pipelinerun_duration_seconds_sum",
 		"pipelines_as_code_running_pipelineruns_count",
 		"pipelines_as_code_git_provider_api_request_count",
+		"pipelines_as_code_repository_invalid_provider_secret",
+		"pipelines_as_code_pipelinerun_timeout_count",
+		"pipelines_as_code_event_queue_depth",
+		"pipelines_as_code_repository_failure_count",
 	)
 
 	// have to reset sync.Once to allow recreation of Recorder.
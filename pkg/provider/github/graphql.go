@@ -0,0 +1,236 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/google/go-github/v74/github"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/changedfiles"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/params/info"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/provider"
+)
+
+// graphQLPageSize is the maximum number of nodes GitHub returns in a page of
+// a GraphQL connection.
+const graphQLPageSize = 100
+
+type graphQLRequest struct {
+	Query     string         `json:"query"`
+	Variables map[string]any `json:"variables,omitempty"`
+}
+
+type graphQLError struct {
+	Type    string `json:"type"`
+	Message string `json:"message"`
+}
+
+type graphQLResponse[T any] struct {
+	Data   T              `json:"data"`
+	Errors []graphQLError `json:"errors"`
+}
+
+type graphQLPageInfo struct {
+	HasNextPage bool   `json:"hasNextPage"`
+	EndCursor   string `json:"endCursor"`
+}
+
+// graphQLEndpoint returns the URL of the GraphQL API relative to the base URL
+// of the REST API, GitHub Enterprise serves it on /api/graphql next to
+// /api/v3.
+func (v *Provider) graphQLEndpoint() string {
+	if strings.HasSuffix(v.Client().BaseURL.Path, "/api/v3/") {
+		return "../graphql"
+	}
+	return "graphql"
+}
+
+// graphQL runs a GraphQL query. Any error, including the errors of the
+// response, disables the GraphQL API for the provider so the callers fall
+// back to the REST API without trying it again for every call.
+func graphQL[T any](ctx context.Context, v *Provider, operation, query string, variables map[string]any) (T, error) {
+	var data T
+	req, err := v.Client().NewRequest(http.MethodPost, v.graphQLEndpoint(), graphQLRequest{Query: query, Variables: variables})
+	if err != nil {
+		return data, err
+	}
+	response, _, err := wrapAPI(v, operation, func() (*graphQLResponse[T], *github.Response, error) {
+		response := &graphQLResponse[T]{}
+		resp, err := v.Client().Do(ctx, req, response)
+		return response, resp, err
+	})
+	if err == nil && len(response.Errors) > 0 {
+		err = fmt.Errorf("%s: %s", response.Errors[0].Type, response.Errors[0].Message)
+	}
+	if err != nil {
+		v.graphQLUnavailable = true
+		if v.Logger != nil {
+			v.Logger.Infof("cannot use the GitHub GraphQL API, falling back to the REST API: %v", err)
+		}
+		return data, err
+	}
+	return response.Data, nil
+}
+
+const pullRequestFilesQuery = `query($owner: String!, $name: String!, $number: Int!, $first: Int!, $cursor: String) {
+  repository(owner: $owner, name: $name) {
+    pullRequest(number: $number) {
+      files(first: $first, after: $cursor) {
+        nodes { path changeType }
+        pageInfo { hasNextPage endCursor }
+      }
+    }
+  }
+}`
+
+type pullRequestFilesData struct {
+	Repository struct {
+		PullRequest struct {
+			Files struct {
+				Nodes []struct {
+					Path       string `json:"path"`
+					ChangeType string `json:"changeType"`
+				} `json:"nodes"`
+				PageInfo graphQLPageInfo `json:"pageInfo"`
+			} `json:"files"`
+		} `json:"pullRequest"`
+	} `json:"repository"`
+}
+
+// getPullRequestFilesGraphQL gets the files changed by a pull request with
+// the GraphQL API, the pages are smaller than the REST ones since they do not
+// carry the patches of the files.
+func (v *Provider) getPullRequestFilesGraphQL(ctx context.Context, runevent *info.Event) (changedfiles.ChangedFiles, error) {
+	changedFiles := changedfiles.ChangedFiles{}
+	variables := map[string]any{
+		"owner":  runevent.Organization,
+		"name":   runevent.Repository,
+		"number": runevent.PullRequestNumber,
+		"first":  graphQLPageSize,
+	}
+	for {
+		data, err := graphQL[pullRequestFilesData](ctx, v, "graphql_pull_request_files", pullRequestFilesQuery, variables)
+		if err != nil {
+			return changedfiles.ChangedFiles{}, err
+		}
+		files := data.Repository.PullRequest.Files
+		for _, file := range files.Nodes {
+			changedFiles.All = append(changedFiles.All, file.Path)
+			switch file.ChangeType {
+			case "ADDED":
+				changedFiles.Added = append(changedFiles.Added, file.Path)
+			case "DELETED":
+				changedFiles.Deleted = append(changedFiles.Deleted, file.Path)
+			case "MODIFIED":
+				changedFiles.Modified = append(changedFiles.Modified, file.Path)
+			case "RENAMED":
+				changedFiles.Renamed = append(changedFiles.Renamed, file.Path)
+			}
+		}
+		if !files.PageInfo.HasNextPage {
+			return changedFiles, nil
+		}
+		variables["cursor"] = files.PageInfo.EndCursor
+	}
+}
+
+type teamMembers struct {
+	Members struct {
+		Nodes []struct {
+			Login string `json:"login"`
+		} `json:"nodes"`
+	} `json:"members"`
+}
+
+// teamMembershipQuery returns a query looking for a user in all the teams at
+// once, each team has its own alias and variable.
+func teamMembershipQuery(teams []string) string {
+	var params, fields strings.Builder
+	for i := range teams {
+		fmt.Fprintf(&params, ", $team%d: String!", i)
+		fmt.Fprintf(&fields, "    team%d: team(slug: $team%d) { members(query: $login, first: %d) { nodes { login } } }\n", i, i, graphQLPageSize)
+	}
+	return fmt.Sprintf("query($org: String!, $login: String!%s) {\n  organization(login: $org) {\n%s  }\n}", params.String(), fields.String())
+}
+
+// getTeamsMembershipGraphQL tells for each of the teams whether the sender of
+// the event is a member, with a single GraphQL query instead of listing the
+// members of the teams page by page. A team not found on the organization is
+// missing from the result.
+func (v *Provider) getTeamsMembershipGraphQL(ctx context.Context, event *info.Event, teams []string) (map[string]bool, error) {
+	variables := map[string]any{
+		"org":   event.Organization,
+		"login": event.Sender,
+	}
+	for i, team := range teams {
+		variables[fmt.Sprintf("team%d", i)] = team
+	}
+	type data struct {
+		Organization map[string]*teamMembers `json:"organization"`
+	}
+	result, err := graphQL[data](ctx, v, "graphql_teams_membership", teamMembershipQuery(teams), variables)
+	if err != nil {
+		return nil, err
+	}
+	membership := map[string]bool{}
+	for i, team := range teams {
+		members := result.Organization[fmt.Sprintf("team%d", i)]
+		if members == nil {
+			continue
+		}
+		membership[team] = false
+		for _, member := range members.Members.Nodes {
+			if member.Login == event.Sender {
+				membership[team] = true
+			}
+		}
+	}
+	return membership, nil
+}
+
+const pullRequestReviewsQuery = `query($owner: String!, $name: String!, $number: Int!, $first: Int!) {
+  repository(owner: $owner, name: $name) {
+    pullRequest(number: $number) {
+      latestOpinionatedReviews(first: $first) {
+        nodes { state author { login } }
+      }
+    }
+  }
+}`
+
+type pullRequestReviewsData struct {
+	Repository struct {
+		PullRequest struct {
+			LatestOpinionatedReviews struct {
+				Nodes []struct {
+					State  string `json:"state"`
+					Author struct {
+						Login string `json:"login"`
+					} `json:"author"`
+				} `json:"nodes"`
+			} `json:"latestOpinionatedReviews"`
+		} `json:"pullRequest"`
+	} `json:"repository"`
+}
+
+// getPullRequestReviewsGraphQL gets the last approval or request for changes
+// of each reviewer of a pull request with the GraphQL API, in a single call
+// instead of going through all the reviews.
+func (v *Provider) getPullRequestReviewsGraphQL(ctx context.Context, runevent *info.Event) ([]provider.Review, error) {
+	variables := map[string]any{
+		"owner":  runevent.Organization,
+		"name":   runevent.Repository,
+		"number": runevent.PullRequestNumber,
+		"first":  graphQLPageSize,
+	}
+	data, err := graphQL[pullRequestReviewsData](ctx, v, "graphql_pull_request_reviews", pullRequestReviewsQuery, variables)
+	if err != nil {
+		return nil, err
+	}
+	reviews := []provider.Review{}
+	for _, node := range data.Repository.PullRequest.LatestOpinionatedReviews.Nodes {
+		reviews = append(reviews, provider.Review{Reviewer: node.Author.Login, State: reviewState(node.State)})
+	}
+	return reviews, nil
+}
@@ -0,0 +1,218 @@
+package github
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/google/go-github/v74/github"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/changedfiles"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/params/info"
+	ghtesthelper "github.com/openshift-pipelines/pipelines-as-code/pkg/test/github"
+	"go.uber.org/zap"
+	zapobserver "go.uber.org/zap/zaptest/observer"
+	"gotest.tools/v3/assert"
+	rtesting "knative.dev/pkg/reconciler/testing"
+)
+
+func TestGraphQLEndpoint(t *testing.T) {
+	tests := []struct {
+		name    string
+		baseURL string
+		want    string
+	}{
+		{
+			name:    "public github",
+			baseURL: "https://api.github.com/",
+			want:    "https://api.github.com/graphql",
+		},
+		{
+			name:    "github enterprise",
+			baseURL: "https://ghe.example.com/api/v3/",
+			want:    "https://ghe.example.com/api/graphql",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := github.NewClient(nil)
+			baseURL, err := url.Parse(tt.baseURL)
+			assert.NilError(t, err)
+			client.BaseURL = baseURL
+			v := &Provider{ghClient: client}
+			req, err := client.NewRequest(http.MethodPost, v.graphQLEndpoint(), nil)
+			assert.NilError(t, err)
+			assert.Equal(t, req.URL.String(), tt.want)
+		})
+	}
+}
+
+func TestGetFilesGraphQL(t *testing.T) {
+	tests := []struct {
+		name            string
+		graphQLReply    []string
+		wantFiles       changedfiles.ChangedFiles
+		wantUnavailable bool
+		wantRESTCalls   int
+	}{
+		{
+			name: "paginated",
+			graphQLReply: []string{
+				`{"data":{"repository":{"pullRequest":{"files":{"nodes":[{"path":"added.go","changeType":"ADDED"},{"path":"modified.go","changeType":"MODIFIED"}],"pageInfo":{"hasNextPage":true,"endCursor":"cursor1"}}}}}}`,
+				`{"data":{"repository":{"pullRequest":{"files":{"nodes":[{"path":"deleted.go","changeType":"DELETED"},{"path":"renamed.go","changeType":"RENAMED"}],"pageInfo":{"hasNextPage":false,"endCursor":"cursor2"}}}}}}`,
+			},
+			wantFiles: changedfiles.ChangedFiles{
+				All:      []string{"added.go", "modified.go", "deleted.go", "renamed.go"},
+				Added:    []string{"added.go"},
+				Deleted:  []string{"deleted.go"},
+				Modified: []string{"modified.go"},
+				Renamed:  []string{"renamed.go"},
+			},
+		},
+		{
+			name:         "graphql errors fall back to rest",
+			graphQLReply: []string{`{"data":null,"errors":[{"type":"FORBIDDEN","message":"Resource not accessible by integration"}]}`},
+			wantFiles: changedfiles.ChangedFiles{
+				All:      []string{"rest.go"},
+				Modified: []string{"rest.go"},
+			},
+			wantUnavailable: true,
+			wantRESTCalls:   1,
+		},
+		{
+			name: "no graphql api falls back to rest",
+			wantFiles: changedfiles.ChangedFiles{
+				All:      []string{"rest.go"},
+				Modified: []string{"rest.go"},
+			},
+			wantUnavailable: true,
+			wantRESTCalls:   1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fakeclient, mux, _, teardown := ghtesthelper.SetupGH()
+			defer teardown()
+			event := &info.Event{
+				TriggerTarget:     "pull_request",
+				Organization:      "owner",
+				Repository:        "repo",
+				PullRequestNumber: 10,
+			}
+			graphQLCalls := 0
+			if tt.graphQLReply != nil {
+				mux.HandleFunc("/graphql", func(rw http.ResponseWriter, r *http.Request) {
+					assert.Equal(t, r.Method, http.MethodPost)
+					body := graphQLRequest{}
+					assert.NilError(t, json.NewDecoder(r.Body).Decode(&body))
+					assert.Equal(t, body.Variables["owner"], "owner")
+					assert.Equal(t, body.Variables["number"], float64(10))
+					if graphQLCalls > 0 {
+						assert.Equal(t, body.Variables["cursor"], fmt.Sprintf("cursor%d", graphQLCalls))
+					}
+					fmt.Fprint(rw, tt.graphQLReply[graphQLCalls])
+					graphQLCalls++
+				})
+			}
+			restCalls := 0
+			mux.HandleFunc("/repos/owner/repo/pulls/10/files", func(rw http.ResponseWriter, _ *http.Request) {
+				restCalls++
+				fmt.Fprint(rw, `[{"filename":"rest.go","status":"modified"}]`)
+			})
+			v := &Provider{ghClient: fakeclient, PaginedNumber: 100}
+			ctx, _ := rtesting.SetupFakeContext(t)
+			files, err := v.GetFiles(ctx, event)
+			assert.NilError(t, err)
+			assert.DeepEqual(t, files, tt.wantFiles)
+			assert.Equal(t, v.graphQLUnavailable, tt.wantUnavailable)
+			assert.Equal(t, restCalls, tt.wantRESTCalls)
+			assert.Equal(t, graphQLCalls, len(tt.graphQLReply))
+
+			// once the graphql api failed, only the rest api is used
+			if tt.wantUnavailable {
+				_, err := v.GetFiles(ctx, event)
+				assert.NilError(t, err)
+				assert.Equal(t, graphQLCalls, len(tt.graphQLReply))
+			}
+		})
+	}
+}
+
+func TestCheckPolicyAllowingGraphQL(t *testing.T) {
+	tests := []struct {
+		name         string
+		allowedTeams []string
+		reply        string
+		wantAllowed  bool
+		wantReason   string
+		wantREST     bool
+	}{
+		{
+			name:         "member of the second team",
+			allowedTeams: []string{"team-a", "team-b"},
+			reply:        `{"data":{"organization":{"team0":{"members":{"nodes":[{"login":"allowedUser2"}]}},"team1":{"members":{"nodes":[{"login":"allowedUser"}]}}}}}`,
+			wantAllowed:  true,
+			wantReason:   "allowing user: allowedUser as a member of the team: team-b",
+		},
+		{
+			name:         "not a member",
+			allowedTeams: []string{"team-a"},
+			reply:        `{"data":{"organization":{"team0":{"members":{"nodes":[]}}}}}`,
+			wantReason:   "user: allowedUser is not a member of any of the allowed teams: [team-a]",
+		},
+		{
+			name:         "team not found",
+			allowedTeams: []string{"nothere", "team-a"},
+			reply:        `{"data":{"organization":{"team0":null,"team1":{"members":{"nodes":[{"login":"allowedUser"}]}}}}}`,
+			wantReason:   "team: nothere is not found on the organization: myorg",
+		},
+		{
+			name:         "graphql errors fall back to rest",
+			allowedTeams: []string{"team-a"},
+			reply:        `{"data":null,"errors":[{"type":"INSUFFICIENT_SCOPES","message":"Your token has not been granted the required scopes"}]}`,
+			wantAllowed:  true,
+			wantReason:   "allowing user: allowedUser as a member of the team: team-a",
+			wantREST:     true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fakeclient, mux, _, teardown := ghtesthelper.SetupGH()
+			defer teardown()
+			event := &info.Event{Organization: "myorg", Sender: "allowedUser"}
+			mux.HandleFunc("/graphql", func(rw http.ResponseWriter, r *http.Request) {
+				body := graphQLRequest{}
+				assert.NilError(t, json.NewDecoder(r.Body).Decode(&body))
+				assert.Equal(t, body.Query, teamMembershipQuery(tt.allowedTeams))
+				assert.Equal(t, body.Variables["login"], "allowedUser")
+				for i, team := range tt.allowedTeams {
+					assert.Equal(t, body.Variables[fmt.Sprintf("team%d", i)], team)
+				}
+				fmt.Fprint(rw, tt.reply)
+			})
+			restCalled := false
+			mux.HandleFunc("/orgs/myorg/teams/team-a/members", func(rw http.ResponseWriter, _ *http.Request) {
+				restCalled = true
+				fmt.Fprint(rw, `[{"login": "allowedUser"}]`)
+			})
+			observer, _ := zapobserver.New(zap.InfoLevel)
+			v := &Provider{ghClient: fakeclient, Logger: zap.New(observer).Sugar(), PaginedNumber: 100}
+			ctx, _ := rtesting.SetupFakeContext(t)
+			gotAllowed, gotReason := v.CheckPolicyAllowing(ctx, event, tt.allowedTeams)
+			assert.Equal(t, gotAllowed, tt.wantAllowed)
+			assert.Equal(t, gotReason, tt.wantReason)
+			assert.Equal(t, restCalled, tt.wantREST)
+		})
+	}
+}
+
+func TestTeamMembershipQuery(t *testing.T) {
+	want := `query($org: String!, $login: String!, $team0: String!, $team1: String!) {
+  organization(login: $org) {
+    team0: team(slug: $team0) { members(query: $login, first: 100) { nodes { login } } }
+    team1: team(slug: $team1) { members(query: $login, first: 100) { nodes { login } } }
+  }
+}`
+	assert.Equal(t, teamMembershipQuery([]string{"a", "b"}), want)
+}
@@ -0,0 +1,145 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/bradleyfalzon/ghinstallation/v2"
+	"github.com/google/go-github/v74/github"
+)
+
+const (
+	// appSlugTTL is how long the slug of a GitHub App is kept, it only
+	// changes when the App is renamed.
+	appSlugTTL = time.Hour
+	// installationRepositoriesTTL is how long the repositories of an
+	// installation are kept, the installation_repositories events forget
+	// them sooner when the App is installed on more repositories.
+	installationRepositoriesTTL = 5 * time.Minute
+)
+
+type ttlEntry[T any] struct {
+	value   T
+	expires time.Time
+}
+
+// ttlCache keeps the values of the GitHub API for a while, to not query it on
+// every event of the same installation.
+type ttlCache[T any] struct {
+	mu      sync.Mutex
+	entries map[string]ttlEntry[T]
+}
+
+func newTTLCache[T any]() *ttlCache[T] {
+	return &ttlCache[T]{entries: map[string]ttlEntry[T]{}}
+}
+
+func (c *ttlCache[T]) get(key string, now time.Time) (T, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	entry, ok := c.entries[key]
+	if !ok || now.After(entry.expires) {
+		var zero T
+		return zero, false
+	}
+	return entry.value, true
+}
+
+func (c *ttlCache[T]) set(key string, value T, now time.Time, ttl time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for k, entry := range c.entries {
+		if now.After(entry.expires) {
+			delete(c.entries, k)
+		}
+	}
+	c.entries[key] = ttlEntry[T]{value: value, expires: now.Add(ttl)}
+}
+
+// forget removes the entries whose key ends with suffix.
+func (c *ttlCache[T]) forget(suffix string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for k := range c.entries {
+		if strings.HasSuffix(k, suffix) {
+			delete(c.entries, k)
+		}
+	}
+}
+
+var (
+	appSlugs                 = newTTLCache[string]()
+	installationRepositories = newTTLCache[map[string]int64]()
+)
+
+// installationKey returns the key of the cached repositories of an
+// installation, the installation IDs are only unique on a GitHub instance.
+func installationKey(apiURL string, installationID int64) string {
+	return fmt.Sprintf("%s/%d", apiURL, installationID)
+}
+
+// forgetInstallationRepositories forgets the cached repositories of an
+// installation, when they have changed.
+func forgetInstallationRepositories(installationID int64) {
+	installationRepositories.forget(fmt.Sprintf("/%d", installationID))
+}
+
+// getAppSlug returns the slug of the GitHub App, identifying it when several
+// Pipelines-as-Code are installed on the same repositories. It is an empty
+// string when the App cannot be fetched.
+func (v *Provider) getAppSlug(ctx context.Context, applicationID int64, privateKey []byte) string {
+	key := fmt.Sprintf("%s/%d", v.Client().BaseURL.String(), applicationID)
+	if slug, ok := appSlugs.get(key, time.Now()); ok {
+		return slug
+	}
+	atr, err := ghinstallation.NewAppsTransport(http.DefaultTransport, applicationID, privateKey)
+	if err != nil {
+		return ""
+	}
+	appsClient := github.NewClient(&http.Client{Transport: atr})
+	appsClient.BaseURL = v.Client().BaseURL
+	app, _, err := wrapAPI(v, "get_app", func() (*github.App, *github.Response, error) {
+		return appsClient.Apps.Get(ctx, "")
+	})
+	if err != nil {
+		if v.Logger != nil {
+			v.Logger.Debugf("cannot get the slug of the github app %d: %v", applicationID, err)
+		}
+		return ""
+	}
+	appSlugs.set(key, app.GetSlug(), time.Now(), appSlugTTL)
+	return app.GetSlug()
+}
+
+// getInstallationRepositories returns the IDs of the repositories the
+// installation has access to by their full name, from GET
+// /installation/repositories.
+func (v *Provider) getInstallationRepositories(ctx context.Context, installationID int64) (map[string]int64, error) {
+	key := installationKey(v.Client().BaseURL.String(), installationID)
+	if repositories, ok := installationRepositories.get(key, time.Now()); ok {
+		return repositories, nil
+	}
+	repositories := map[string]int64{}
+	opt := &github.ListOptions{PerPage: v.PaginedNumber}
+	for {
+		repoList, resp, err := wrapAPI(v, "list_app_repos", func() (*github.ListRepositories, *github.Response, error) {
+			return v.Client().Apps.ListRepos(ctx, opt)
+		})
+		if err != nil {
+			return nil, err
+		}
+		for _, repo := range repoList.Repositories {
+			repositories[strings.ToLower(repo.GetFullName())] = repo.GetID()
+		}
+		if resp.NextPage == 0 {
+			break
+		}
+		opt.Page = resp.NextPage
+	}
+	installationRepositories.set(key, repositories, time.Now(), installationRepositoriesTTL)
+	return repositories, nil
+}
@@ -0,0 +1,79 @@
+package github
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	ghtesthelper "github.com/openshift-pipelines/pipelines-as-code/pkg/test/github"
+	"gotest.tools/v3/assert"
+	rtesting "knative.dev/pkg/reconciler/testing"
+)
+
+func TestTTLCache(t *testing.T) {
+	now := time.Now()
+	cache := newTTLCache[string]()
+	cache.set("https://api.github.com/1", "one", now, time.Minute)
+	cache.set("https://ghe.example.com/api/v3/1", "ghe", now, time.Minute)
+	cache.set("https://api.github.com/2", "two", now, time.Minute)
+
+	got, ok := cache.get("https://api.github.com/1", now.Add(30*time.Second))
+	assert.Assert(t, ok)
+	assert.Equal(t, got, "one")
+	_, ok = cache.get("https://api.github.com/1", now.Add(2*time.Minute))
+	assert.Assert(t, !ok)
+
+	cache.forget("/1")
+	_, ok = cache.get("https://api.github.com/1", now)
+	assert.Assert(t, !ok)
+	_, ok = cache.get("https://ghe.example.com/api/v3/1", now)
+	assert.Assert(t, !ok)
+	_, ok = cache.get("https://api.github.com/2", now)
+	assert.Assert(t, ok)
+}
+
+func TestGetInstallationRepositories(t *testing.T) {
+	fakeclient, mux, _, teardown := ghtesthelper.SetupGH()
+	defer teardown()
+	calls := 0
+	mux.HandleFunc("/installation/repositories", func(w http.ResponseWriter, _ *http.Request) {
+		calls++
+		_, _ = fmt.Fprint(w, `{"total_count": 2,"repositories": [{"id":1,"full_name":"Owner/Project1"},{"id":2,"full_name":"owner/project2"}]}`)
+	})
+
+	ctx, _ := rtesting.SetupFakeContext(t)
+	v := &Provider{ghClient: fakeclient, PaginedNumber: defaultPaginedNumber}
+	repositories, err := v.getInstallationRepositories(ctx, 42)
+	assert.NilError(t, err)
+	assert.DeepEqual(t, repositories, map[string]int64{"owner/project1": 1, "owner/project2": 2})
+
+	_, err = v.getInstallationRepositories(ctx, 42)
+	assert.NilError(t, err)
+	assert.Equal(t, calls, 1)
+
+	forgetInstallationRepositories(42)
+	_, err = v.getInstallationRepositories(ctx, 42)
+	assert.NilError(t, err)
+	assert.Equal(t, calls, 2)
+}
+
+func TestGetAppSlug(t *testing.T) {
+	fakeclient, mux, _, teardown := ghtesthelper.SetupGH()
+	defer teardown()
+	calls := 0
+	mux.HandleFunc("/app", func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		assert.Assert(t, r.Header.Get("Authorization") != "")
+		_, _ = fmt.Fprint(w, `{"id":12345,"slug":"pipelines-as-code","name":"Pipelines as Code"}`)
+	})
+
+	ctx, _ := rtesting.SetupFakeContext(t)
+	v := &Provider{ghClient: fakeclient}
+	assert.Equal(t, v.getAppSlug(ctx, 12345, []byte(fakePrivateKey)), "pipelines-as-code")
+	assert.Equal(t, v.getAppSlug(ctx, 12345, []byte(fakePrivateKey)), "pipelines-as-code")
+	assert.Equal(t, calls, 1)
+
+	assert.Equal(t, v.getAppSlug(ctx, 12345, []byte("not a key")), "pipelines-as-code")
+	assert.Equal(t, v.getAppSlug(ctx, 6789, []byte("not a key")), "")
+}
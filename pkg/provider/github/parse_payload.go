@@ -69,6 +69,9 @@ func (v *Provider) GetAppToken(ctx context.Context, kube kubernetes.Interface, g
 		gheURL = strings.TrimSuffix(reqTokenURL, "/api/v3")
 	}
 
+	if gheCloudURL, ok := GHECloudAPIURL(gheURL); ok {
+		gheURL = gheCloudURL
+	}
 	if gheURL != "" {
 		if !strings.HasPrefix(gheURL, "https://") && !strings.HasPrefix(gheURL, "http://") {
 			gheURL = "https://" + gheURL
@@ -86,6 +89,7 @@ func (v *Provider) GetAppToken(ctx context.Context, kube kubernetes.Interface, g
 		return "", err
 	}
 	v.Token = github.Ptr(token)
+	v.AppSlug = v.getAppSlug(ctx, applicationID, privateKey)
 
 	return token, err
 }
@@ -152,6 +156,9 @@ func (v *Provider) ParsePayload(ctx context.Context, run *params.Run, request *h
 	if err := v.parseEventType(request, event); err != nil {
 		return nil, err
 	}
+	if event.Provider.URL == "" {
+		event.Provider.URL = gheCloudHostFromPayload(payload)
+	}
 
 	installationIDFrompayload, err := getInstallationIDFromPayload(payload)
 	if err != nil {
@@ -181,6 +188,7 @@ func (v *Provider) ParsePayload(ctx context.Context, run *params.Run, request *h
 	processedEvent.Event = eventInt
 	processedEvent.InstallationID = installationIDFrompayload
 	processedEvent.GHEURL = event.Provider.URL
+	processedEvent.GithubAppSlug = v.AppSlug
 	processedEvent.Provider.URL = event.Provider.URL
 
 	// regenerate token scoped to the repo IDs
@@ -371,6 +379,13 @@ func (v *Provider) processEvent(ctx context.Context, event *info.Event, eventInt
 		processedEvent.SHA = sha
 		processedEvent.SHAURL = gitEvent.GetHeadCommit().GetURL()
 		processedEvent.SHATitle = gitEvent.GetHeadCommit().GetMessage()
+		for _, commit := range gitEvent.Commits {
+			processedEvent.PushCommits = append(processedEvent.PushCommits, info.Commit{
+				SHA:   commit.GetID(),
+				URL:   commit.GetURL(),
+				Title: commit.GetMessage(),
+			})
+		}
 		processedEvent.Sender = gitEvent.GetSender().GetLogin()
 		processedEvent.BaseBranch = gitEvent.GetRef()
 		processedEvent.EventType = event.TriggerTarget.String()
@@ -379,21 +394,11 @@ func (v *Provider) processEvent(ctx context.Context, event *info.Event, eventInt
 		processedEvent.HeadURL = processedEvent.BaseURL // in push events Head URL is the same as BaseURL
 		v.userType = gitEvent.GetSender().GetType()
 	case *github.PullRequestEvent:
-		processedEvent.Repository = gitEvent.GetRepo().GetName()
 		if gitEvent.GetRepo() == nil {
 			return nil, errors.New("error parsing payload the repository should not be nil")
 		}
-		processedEvent.Organization = gitEvent.GetRepo().Owner.GetLogin()
-		processedEvent.DefaultBranch = gitEvent.GetRepo().GetDefaultBranch()
-		processedEvent.SHA = gitEvent.GetPullRequest().Head.GetSHA()
-		processedEvent.URL = gitEvent.GetRepo().GetHTMLURL()
-		processedEvent.BaseBranch = gitEvent.GetPullRequest().Base.GetRef()
-		processedEvent.HeadBranch = gitEvent.GetPullRequest().Head.GetRef()
-		processedEvent.BaseURL = gitEvent.GetPullRequest().Base.GetRepo().GetHTMLURL()
-		processedEvent.HeadURL = gitEvent.GetPullRequest().Head.GetRepo().GetHTMLURL()
-		processedEvent.Sender = gitEvent.GetPullRequest().GetUser().GetLogin()
+		v.setPullRequest(processedEvent, gitEvent.GetRepo(), gitEvent.GetPullRequest())
 		processedEvent.EventType = event.EventType
-		v.userType = gitEvent.GetPullRequest().GetUser().GetType()
 
 		if gitEvent.Action != nil && provider.Valid(*gitEvent.Action, pullRequestLabelEvent) {
 			processedEvent.EventType = string(triggertype.PullRequestLabeled)
@@ -402,17 +407,21 @@ func (v *Provider) processEvent(ctx context.Context, event *info.Event, eventInt
 		if gitEvent.GetAction() == "closed" {
 			processedEvent.TriggerTarget = triggertype.PullRequestClosed
 		}
-
-		processedEvent.PullRequestNumber = gitEvent.GetPullRequest().GetNumber()
-		processedEvent.PullRequestTitle = gitEvent.GetPullRequest().GetTitle()
-		// getting the repository ids of the base and head of the pull request
-		// to scope the token to
-		v.RepositoryIDs = []int64{
-			gitEvent.GetPullRequest().GetBase().GetRepo().GetID(),
+	case *github.PullRequestReviewEvent:
+		if gitEvent.GetRepo() == nil {
+			return nil, errors.New("error parsing payload the repository should not be nil")
 		}
-		for _, label := range gitEvent.GetPullRequest().Labels {
-			processedEvent.PullRequestLabel = append(processedEvent.PullRequestLabel, label.GetName())
+		v.setPullRequest(processedEvent, gitEvent.GetRepo(), gitEvent.GetPullRequest())
+		processedEvent.EventType = triggertype.PullRequestReview.String()
+		processedEvent.ReviewState = strings.ToLower(gitEvent.GetReview().GetState())
+		processedEvent.Reviewer = gitEvent.GetReview().GetUser().GetLogin()
+	case *github.PullRequestReviewCommentEvent:
+		if gitEvent.GetRepo() == nil {
+			return nil, errors.New("error parsing payload the repository should not be nil")
 		}
+		v.setPullRequest(processedEvent, gitEvent.GetRepo(), gitEvent.GetPullRequest())
+		processedEvent.EventType = triggertype.PullRequestReviewComment.String()
+		processedEvent.Reviewer = gitEvent.GetComment().GetUser().GetLogin()
 	default:
 		return nil, errors.New("this event is not supported")
 	}
@@ -598,7 +607,7 @@ func (v *Provider) handleCommitCommentEvent(ctx context.Context, event *github.C
 	}
 
 	// Check if the specified branch contains the commit
-	if err = v.isHeadCommitOfBranch(ctx, runevent, branchName); err != nil {
+	if err = v.isCommitInBranch(ctx, runevent, branchName); err != nil {
 		if provider.IsCancelComment(event.GetComment().GetBody()) {
 			runevent.CancelPipelineRuns = false
 		}
@@ -611,3 +620,30 @@ func (v *Provider) handleCommitCommentEvent(ctx context.Context, event *github.C
 	v.Logger.Infof("github commit_comment: pipelinerun %s on %s/%s#%s has been requested", action, runevent.Organization, runevent.Repository, runevent.SHA)
 	return runevent, nil
 }
+
+// setPullRequest sets the fields of the event about the pull request, the
+// sender is the author of the pull request and not the user who labeled or
+// reviewed it.
+func (v *Provider) setPullRequest(processedEvent *info.Event, repo *github.Repository, pr *github.PullRequest) {
+	processedEvent.Repository = repo.GetName()
+	processedEvent.Organization = repo.GetOwner().GetLogin()
+	processedEvent.DefaultBranch = repo.GetDefaultBranch()
+	processedEvent.SHA = pr.GetHead().GetSHA()
+	processedEvent.URL = repo.GetHTMLURL()
+	processedEvent.BaseBranch = pr.GetBase().GetRef()
+	processedEvent.HeadBranch = pr.GetHead().GetRef()
+	processedEvent.BaseURL = pr.GetBase().GetRepo().GetHTMLURL()
+	processedEvent.HeadURL = pr.GetHead().GetRepo().GetHTMLURL()
+	processedEvent.Sender = pr.GetUser().GetLogin()
+	v.userType = pr.GetUser().GetType()
+	processedEvent.PullRequestNumber = pr.GetNumber()
+	processedEvent.PullRequestTitle = pr.GetTitle()
+	// getting the repository ids of the base and head of the pull request
+	// to scope the token to
+	v.RepositoryIDs = []int64{
+		pr.GetBase().GetRepo().GetID(),
+	}
+	for _, label := range pr.Labels {
+		processedEvent.PullRequestLabel = append(processedEvent.PullRequestLabel, label.GetName())
+	}
+}
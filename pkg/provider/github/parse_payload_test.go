@@ -371,6 +371,8 @@ func TestParsePayLoad(t *testing.T) {
 		wantedBranchName           string
 		wantedTagName              string
 		isCancelPipelineRunEnabled bool
+		wantPushCommits            []info.Commit
+		wantReviewState            string
 	}{
 		{
 			name:          "bad/unknown event",
@@ -388,9 +390,9 @@ func TestParsePayLoad(t *testing.T) {
 		{
 			name:               "bad/not supported",
 			wantErrString:      "this event is not supported",
-			eventType:          "pull_request_review_comment",
-			triggerTarget:      "pull_request",
-			payloadEventStruct: github.PullRequestReviewCommentEvent{Action: github.Ptr("created")},
+			eventType:          "release",
+			triggerTarget:      "push",
+			payloadEventStruct: github.ReleaseEvent{Action: github.Ptr("published")},
 		},
 		{
 			name:               "bad/check run only issue recheck supported",
@@ -563,6 +565,34 @@ func TestParsePayLoad(t *testing.T) {
 			payloadEventStruct: samplePRevent,
 			shaRet:             "sampleHeadsha",
 		},
+		{
+			name:          "good/pull request review",
+			eventType:     "pull_request_review",
+			triggerTarget: triggertype.PullRequest.String(),
+			payloadEventStruct: github.PullRequestReviewEvent{
+				Action: github.Ptr("submitted"),
+				Review: &github.PullRequestReview{
+					State: github.Ptr("approved"),
+					User:  &github.User{Login: github.Ptr("reviewer")},
+				},
+				PullRequest: samplePRevent.PullRequest,
+				Repo:        sampleRepo,
+			},
+			shaRet:          "sampleHeadsha",
+			wantReviewState: "approved",
+		},
+		{
+			name:          "good/pull request review comment",
+			eventType:     "pull_request_review_comment",
+			triggerTarget: triggertype.PullRequest.String(),
+			payloadEventStruct: github.PullRequestReviewCommentEvent{
+				Action:      github.Ptr("created"),
+				Comment:     &github.PullRequestComment{User: &github.User{Login: github.Ptr("reviewer")}},
+				PullRequest: samplePRevent.PullRequest,
+				Repo:        sampleRepo,
+			},
+			shaRet: "sampleHeadsha",
+		},
 		{
 			name:               "good/pull request closed",
 			eventType:          "pull_request",
@@ -580,8 +610,16 @@ func TestParsePayLoad(t *testing.T) {
 					Name:  github.Ptr("pushRepo"),
 				},
 				HeadCommit: &github.HeadCommit{ID: github.Ptr("SHAPush")},
+				Commits: []*github.HeadCommit{
+					{ID: github.Ptr("SHAFirst"), Message: github.Ptr("first")},
+					{ID: github.Ptr("SHAPush"), Message: github.Ptr("second")},
+				},
 			},
 			shaRet: "SHAPush",
+			wantPushCommits: []info.Commit{
+				{SHA: "SHAFirst", Title: "first"},
+				{SHA: "SHAPush", Title: "second"},
+			},
 		},
 		{
 			name:          "good/issue comment for retest",
@@ -879,7 +917,7 @@ func TestParsePayLoad(t *testing.T) {
 			wantErrString:              "404 Not Found",
 		},
 		{
-			name:          "commit comment to retest a pr with a SHA is not HEAD commit of the main branch",
+			name:          "commit comment to retest a pr with a SHA is not part of the main branch",
 			eventType:     "commit_comment",
 			triggerTarget: "push",
 			githubClient:  true,
@@ -895,7 +933,7 @@ func TestParsePayLoad(t *testing.T) {
 			shaRet:            "samplePRshanew",
 			targetPipelinerun: "dummy",
 			wantedBranchName:  "main",
-			wantErrString:     "provided SHA samplePRshanew is not the HEAD commit of the branch main",
+			wantErrString:     "provided SHA samplePRshanew is not part of the branch main",
 		},
 	}
 	for _, tt := range tests {
@@ -989,9 +1027,16 @@ func TestParsePayLoad(t *testing.T) {
 			assert.NilError(t, err)
 			assert.Assert(t, ret != nil)
 			assert.Equal(t, tt.shaRet, ret.SHA)
+			assert.DeepEqual(t, tt.wantPushCommits, ret.PushCommits)
 			if tt.eventType == triggertype.PullRequest.String() {
 				assert.Equal(t, "my first PR", ret.PullRequestTitle)
 			}
+			if tt.eventType == "pull_request_review" || tt.eventType == "pull_request_review_comment" {
+				assert.Equal(t, tt.eventType, ret.EventType)
+				assert.Equal(t, "user", ret.Sender)
+				assert.Equal(t, "reviewer", ret.Reviewer)
+				assert.Equal(t, tt.wantReviewState, ret.ReviewState)
+			}
 			if tt.eventType == "commit_comment" {
 				assert.Equal(t, tt.wantedBranchName, ret.HeadBranch)
 				assert.Equal(t, tt.wantedBranchName, ret.BaseBranch)
@@ -0,0 +1,102 @@
+package github
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/go-github/v74/github"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/params/info"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/params/triggertype"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/provider"
+)
+
+// GetPollEvents returns a push event for the head of each branch and, with
+// pullRequests, a pull_request event for the head of each open pull request
+// of the repository of the event.
+func (v *Provider) GetPollEvents(ctx context.Context, event *info.Event, branches []string, pullRequests bool) ([]*info.Event, error) {
+	if v.ghClient == nil {
+		return nil, fmt.Errorf("no github client has been initialized")
+	}
+	repo, _, err := wrapAPI(v, "get_repository", func() (*github.Repository, *github.Response, error) {
+		return v.Client().Repositories.Get(ctx, event.Organization, event.Repository)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("cannot get the repository %s/%s: %w", event.Organization, event.Repository, err)
+	}
+	if len(branches) == 0 {
+		branches = []string{repo.GetDefaultBranch()}
+	}
+
+	ret := []*info.Event{}
+	for _, name := range branches {
+		branch, _, err := wrapAPI(v, "get_branch", func() (*github.Branch, *github.Response, error) {
+			return v.Client().Repositories.GetBranch(ctx, event.Organization, event.Repository, name, 1)
+		})
+		if err != nil {
+			return nil, fmt.Errorf("cannot get the branch %s of %s/%s: %w", name, event.Organization, event.Repository, err)
+		}
+		pushEvent := newPollEvent(event, repo)
+		pushEvent.EventType = triggertype.Push.String()
+		pushEvent.TriggerTarget = triggertype.Push
+		pushEvent.SHA = branch.GetCommit().GetSHA()
+		pushEvent.SHAURL = branch.GetCommit().GetHTMLURL()
+		pushEvent.SHATitle = branch.GetCommit().GetCommit().GetMessage()
+		pushEvent.Sender = branch.GetCommit().GetAuthor().GetLogin()
+		pushEvent.BaseBranch = "refs/heads/" + name
+		pushEvent.HeadBranch = pushEvent.BaseBranch
+		ret = append(ret, pushEvent)
+	}
+	if !pullRequests {
+		return ret, nil
+	}
+
+	_, err = provider.Paginate(func(page int) ([]*github.PullRequest, int, error) {
+		opt := &github.PullRequestListOptions{
+			State:       "open",
+			ListOptions: github.ListOptions{PerPage: v.PaginedNumber, Page: page},
+		}
+		prs, resp, err := wrapAPI(v, "list_pull_requests", func() ([]*github.PullRequest, *github.Response, error) {
+			return v.Client().PullRequests.List(ctx, event.Organization, event.Repository, opt)
+		})
+		if err != nil {
+			return nil, 0, err
+		}
+		return prs, resp.NextPage, nil
+	}, func(pr *github.PullRequest) bool {
+		prEvent := newPollEvent(event, repo)
+		prEvent.EventType = triggertype.PullRequest.String()
+		prEvent.TriggerTarget = triggertype.PullRequest
+		prEvent.SHA = pr.GetHead().GetSHA()
+		prEvent.BaseBranch = pr.GetBase().GetRef()
+		prEvent.HeadBranch = pr.GetHead().GetRef()
+		prEvent.BaseURL = pr.GetBase().GetRepo().GetHTMLURL()
+		prEvent.HeadURL = pr.GetHead().GetRepo().GetHTMLURL()
+		prEvent.Sender = pr.GetUser().GetLogin()
+		prEvent.PullRequestNumber = pr.GetNumber()
+		prEvent.PullRequestTitle = pr.GetTitle()
+		for _, label := range pr.Labels {
+			prEvent.PullRequestLabel = append(prEvent.PullRequestLabel, label.GetName())
+		}
+		ret = append(ret, prEvent)
+		return false
+	})
+	if err != nil {
+		return nil, fmt.Errorf("cannot list the pull requests of %s/%s: %w", event.Organization, event.Repository, err)
+	}
+	return ret, nil
+}
+
+func newPollEvent(event *info.Event, repo *github.Repository) *info.Event {
+	ret := info.NewEvent()
+	ret.Organization = event.Organization
+	ret.Repository = event.Repository
+	ret.DefaultBranch = repo.GetDefaultBranch()
+	// the URL of the Repository the events are matched to
+	ret.URL = event.URL
+	ret.BaseURL = ret.URL
+	ret.HeadURL = ret.URL
+	ret.Provider.Token = event.Provider.Token
+	ret.Provider.URL = event.Provider.URL
+	ret.Provider.User = event.Provider.User
+	return ret
+}
@@ -0,0 +1,82 @@
+package github
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/params/info"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/params/triggertype"
+	ghtesthelper "github.com/openshift-pipelines/pipelines-as-code/pkg/test/github"
+	"gotest.tools/v3/assert"
+	rtesting "knative.dev/pkg/reconciler/testing"
+)
+
+func TestGetPollEvents(t *testing.T) {
+	tests := []struct {
+		name         string
+		branches     []string
+		pullRequests bool
+		wantKeys     []string
+		wantErrStr   string
+	}{
+		{
+			name:     "default branch",
+			wantKeys: []string{"refs/heads/main@sha-main"},
+		},
+		{
+			name:         "branches and pull requests",
+			branches:     []string{"main", "release"},
+			pullRequests: true,
+			wantKeys:     []string{"refs/heads/main@sha-main", "refs/heads/release@sha-release", "feature#7@sha-pr"},
+		},
+		{
+			name:       "unknown branch",
+			branches:   []string{"ghost"},
+			wantErrStr: "cannot get the branch ghost of owner/repo",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, _ := rtesting.SetupFakeContext(t)
+			fakeclient, mux, _, teardown := ghtesthelper.SetupGH()
+			defer teardown()
+			mux.HandleFunc("/repos/owner/repo", func(w http.ResponseWriter, _ *http.Request) {
+				fmt.Fprint(w, `{"default_branch": "main", "html_url": "https://github.com/owner/repo"}`)
+			})
+			for _, branch := range []string{"main", "release"} {
+				mux.HandleFunc("/repos/owner/repo/branches/"+branch, func(w http.ResponseWriter, _ *http.Request) {
+					fmt.Fprintf(w, `{"name": %q, "commit": {"sha": "sha-%s", "commit": {"message": "a commit"}, "author": {"login": "jane"}}}`, branch, branch)
+				})
+			}
+			mux.HandleFunc("/repos/owner/repo/pulls", func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, r.URL.Query().Get("state"), "open")
+				fmt.Fprint(w, `[{"number": 7, "title": "a feature", "user": {"login": "joe"},
+"head": {"ref": "feature", "sha": "sha-pr"}, "base": {"ref": "main"}}]`)
+			})
+			gvcs := &Provider{ghClient: fakeclient}
+			event := &info.Event{Organization: "owner", Repository: "repo", URL: "https://github.com/owner/repo", Provider: &info.Provider{Token: "token"}}
+			got, err := gvcs.GetPollEvents(ctx, event, tt.branches, tt.pullRequests)
+			if tt.wantErrStr != "" {
+				assert.ErrorContains(t, err, tt.wantErrStr)
+				return
+			}
+			assert.NilError(t, err)
+			keys := []string{}
+			for _, polled := range got {
+				assert.Equal(t, polled.URL, event.URL)
+				assert.Equal(t, polled.DefaultBranch, "main")
+				assert.Equal(t, polled.Provider.Token, "token")
+				if polled.TriggerTarget == triggertype.PullRequest {
+					assert.Equal(t, polled.Sender, "joe")
+					keys = append(keys, fmt.Sprintf("%s#%d@%s", polled.HeadBranch, polled.PullRequestNumber, polled.SHA))
+					continue
+				}
+				assert.Equal(t, polled.Sender, "jane")
+				assert.Equal(t, polled.EventType, triggertype.Push.String())
+				keys = append(keys, polled.BaseBranch+"@"+polled.SHA)
+			}
+			assert.DeepEqual(t, keys, tt.wantKeys)
+		})
+	}
+}
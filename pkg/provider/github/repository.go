@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"net/url"
 
 	"github.com/google/go-github/v74/github"
 	"github.com/openshift-pipelines/pipelines-as-code/pkg/apis/pipelinesascode/v1alpha1"
@@ -17,6 +18,7 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
 )
 
 const (
@@ -25,8 +27,14 @@ const (
 )
 
 func ConfigureRepository(ctx context.Context, run *params.Run, req *http.Request, payload string, pacInfo *info.PacOpts, logger *zap.SugaredLogger) (bool, bool, error) {
+	if req.Header.Get("X-Github-Event") == "installation_repositories" && req.Header.Get("X-Gitea-Event-Type") == "" {
+		// the repositories of the installation have changed
+		if installationID, err := getInstallationIDFromPayload(payload); err == nil {
+			forgetInstallationRepositories(installationID)
+		}
+	}
 	// check if repo auto configuration is enabled
-	if !pacInfo.AutoConfigureNewGitHubRepo {
+	if !pacInfo.AutoConfigureNewGitHubRepo && !pacInfo.AutoConfigureGitHubAppInstallations {
 		return false, false, nil
 	}
 	// gitea set x-github-event too, so skip it for the gitea driver
@@ -34,7 +42,11 @@ func ConfigureRepository(ctx context.Context, run *params.Run, req *http.Request
 		return false, false, nil
 	}
 	event := req.Header.Get("X-Github-Event")
-	if event != "repository" {
+	switch {
+	case event == "repository" && pacInfo.AutoConfigureNewGitHubRepo:
+	case (event == "installation" || event == "installation_repositories") && pacInfo.AutoConfigureGitHubAppInstallations:
+		return configureInstallationRepositories(ctx, run, event, payload, pacInfo, logger)
+	default:
 		return false, false, nil
 	}
 
@@ -51,9 +63,7 @@ func ConfigureRepository(ctx context.Context, run *params.Run, req *http.Request
 	}
 
 	logger.Infof("github: configuring repository cr for repo: %v", repoEvent.Repo.GetHTMLURL())
-	nsTemplate := pacInfo.AutoConfigureRepoNamespaceTemplate
-	repoTemplate := pacInfo.AutoConfigureRepoRepositoryTemplate
-	if err := createRepository(ctx, nsTemplate, repoTemplate, run.Clients, repoEvent, logger); err != nil {
+	if err := createRepository(ctx, pacInfo, run.Clients, repoEvent.Repo.GetHTMLURL(), logger); err != nil {
 		logger.Errorf("failed repository creation: %v", err)
 		return true, true, err
 	}
@@ -61,29 +71,101 @@ func ConfigureRepository(ctx context.Context, run *params.Run, req *http.Request
 	return true, true, nil
 }
 
-func createRepository(ctx context.Context, nsTemplate, repoTemplate string, clients clients.Clients, gitEvent *github.RepositoryEvent, logger *zap.SugaredLogger) error {
-	repoNsName, repoCRName, err := generateNamespaceAndRepositoryName(nsTemplate, repoTemplate, gitEvent)
+// configureInstallationRepositories creates the Repository CRs of the
+// repositories the GitHub App has been installed on.
+func configureInstallationRepositories(ctx context.Context, run *params.Run, event, payload string, pacInfo *info.PacOpts, logger *zap.SugaredLogger) (bool, bool, error) {
+	eventInt, err := github.ParseWebHook(event, []byte(payload))
 	if err != nil {
-		return fmt.Errorf("failed to generate namespace for repo: %w", err)
+		return true, false, err
 	}
 
-	logger.Info("github: generated namespace name: ", repoNsName)
+	var repos []*github.Repository
+	var installation *github.Installation
+	switch e := eventInt.(type) {
+	case *github.InstallationEvent:
+		if e.GetAction() != "created" {
+			logger.Infof("github: installation event \"%v\" is not supported", e.GetAction())
+			return true, false, nil
+		}
+		repos, installation = e.Repositories, e.GetInstallation()
+	case *github.InstallationRepositoriesEvent:
+		if e.GetAction() != "added" {
+			logger.Infof("github: installation_repositories event \"%v\" is not supported", e.GetAction())
+			return true, false, nil
+		}
+		repos, installation = e.RepositoriesAdded, e.GetInstallation()
+	}
+
+	var errs []error
+	for _, repo := range repos {
+		repoURL, err := installationRepositoryURL(installation, repo)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		logger.Infof("github: configuring repository cr for repo: %v installed on the GitHub App", repoURL)
+		err = createRepository(ctx, pacInfo, run.Clients, repoURL, logger)
+		if errors.IsAlreadyExists(err) {
+			logger.Infof("github: repository cr for repo %v already exists", repoURL)
+			continue
+		}
+		if err != nil {
+			logger.Errorf("failed repository creation: %v", err)
+			errs = append(errs, err)
+		}
+	}
+	return true, true, utilerrors.NewAggregate(errs)
+}
 
-	// create namespace
-	repoNs := &corev1.Namespace{
-		ObjectMeta: metav1.ObjectMeta{
-			Name: repoNsName,
-		},
+// installationRepositoryURL returns the URL of a repository of an
+// installation event, which only has its full name, from the URL of the
+// account the App is installed on.
+func installationRepositoryURL(installation *github.Installation, repo *github.Repository) (string, error) {
+	if repo.GetHTMLURL() != "" {
+		return repo.GetHTMLURL(), nil
 	}
-	repoNs, err = clients.Kube.CoreV1().Namespaces().Create(ctx, repoNs, metav1.CreateOptions{})
-	if err != nil && !errors.IsAlreadyExists(err) {
-		return fmt.Errorf("failed to create namespace %v: %w", repoNs.Name, err)
+	accountURL, err := url.Parse(installation.GetAccount().GetHTMLURL())
+	if err != nil || accountURL.Host == "" {
+		return "", fmt.Errorf("cannot get the url of repository %s from the installation account", repo.GetFullName())
 	}
+	accountURL.Path = "/" + repo.GetFullName()
+	return accountURL.String(), nil
+}
+
+func createRepository(ctx context.Context, pacInfo *info.PacOpts, clients clients.Clients, repoURL string, logger *zap.SugaredLogger) error {
+	repoNsName, repoCRName, err := generateNamespaceAndRepositoryName(pacInfo.AutoConfigureRepoNamespaceTemplate, pacInfo.AutoConfigureRepoRepositoryTemplate, repoURL)
+	if err != nil {
+		return fmt.Errorf("failed to generate namespace for repo: %w", err)
+	}
+
+	logger.Info("github: generated namespace name: ", repoNsName)
 
-	if errors.IsAlreadyExists(err) {
-		logger.Infof("github: namespace %v already exists, creating repository", repoNsName)
+	if pacInfo.AutoConfigureExistingNamespacesOnly {
+		// only suggest the repository in the namespaces that already exist
+		if _, err := clients.Kube.CoreV1().Namespaces().Get(ctx, repoNsName, metav1.GetOptions{}); err != nil {
+			if errors.IsNotFound(err) {
+				logger.Infof("github: namespace %v doesn't exist, skipping the creation of repository cr for repo: %v", repoNsName, repoURL)
+				return nil
+			}
+			return fmt.Errorf("failed to get namespace %v: %w", repoNsName, err)
+		}
 	} else {
-		logger.Info("github: created repository namespace: ", repoNs.Name)
+		// create namespace
+		repoNs := &corev1.Namespace{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: repoNsName,
+			},
+		}
+		repoNs, err = clients.Kube.CoreV1().Namespaces().Create(ctx, repoNs, metav1.CreateOptions{})
+		if err != nil && !errors.IsAlreadyExists(err) {
+			return fmt.Errorf("failed to create namespace %v: %w", repoNs.Name, err)
+		}
+
+		if errors.IsAlreadyExists(err) {
+			logger.Infof("github: namespace %v already exists, creating repository", repoNsName)
+		} else {
+			logger.Info("github: created repository namespace: ", repoNs.Name)
+		}
 	}
 
 	// create repository
@@ -93,20 +175,20 @@ func createRepository(ctx context.Context, nsTemplate, repoTemplate string, clie
 			Namespace: repoNsName,
 		},
 		Spec: v1alpha1.RepositorySpec{
-			URL: gitEvent.Repo.GetHTMLURL(),
+			URL: repoURL,
 		},
 	}
 	repo, err = clients.PipelineAsCode.PipelinesascodeV1alpha1().Repositories(repoNsName).Create(ctx, repo, metav1.CreateOptions{})
 	if err != nil {
-		return fmt.Errorf("failed to create repository for repo: %v: %w", gitEvent.Repo.GetHTMLURL(), err)
+		return fmt.Errorf("failed to create repository for repo: %v: %w", repoURL, err)
 	}
 	logger = logger.With("namespace", repo.Namespace)
 	logger.Infof("github: repository created: %s/%s ", repo.Namespace, repo.Name)
 	return nil
 }
 
-func generateNamespaceAndRepositoryName(nsTemplate, repoTemplate string, gitEvent *github.RepositoryEvent) (string, string, error) {
-	repoOwner, repoName, err := formatting.GetRepoOwnerSplitted(gitEvent.Repo.GetHTMLURL())
+func generateNamespaceAndRepositoryName(nsTemplate, repoTemplate, repoURL string) (string, string, error) {
+	repoOwner, repoName, err := formatting.GetRepoOwnerSplitted(repoURL)
 	if err != nil {
 		return "", "", fmt.Errorf("failed to parse git repo url: %w", err)
 	}
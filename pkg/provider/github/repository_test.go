@@ -9,6 +9,7 @@ import (
 	"testing"
 
 	"github.com/google/go-github/v74/github"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/apis/pipelinesascode/v1alpha1"
 	"github.com/openshift-pipelines/pipelines-as-code/pkg/params"
 	"github.com/openshift-pipelines/pipelines-as-code/pkg/params/clients"
 	"github.com/openshift-pipelines/pipelines-as-code/pkg/params/info"
@@ -172,12 +173,146 @@ func TestConfigureRepository(t *testing.T) {
 	}
 }
 
+func TestConfigureRepositoryInstallation(t *testing.T) {
+	account := &github.User{Login: github.Ptr("pac"), HTMLURL: github.Ptr("https://github.com/pac")}
+	installationCreated, err := json.Marshal(github.InstallationEvent{
+		Action:       github.Ptr("created"),
+		Installation: &github.Installation{Account: account},
+		Repositories: []*github.Repository{
+			{FullName: github.Ptr("pac/first")},
+			{FullName: github.Ptr("pac/second")},
+		},
+	})
+	assert.NilError(t, err)
+	installationDeleted, err := json.Marshal(github.InstallationEvent{
+		Action:       github.Ptr("deleted"),
+		Installation: &github.Installation{Account: account},
+		Repositories: []*github.Repository{{FullName: github.Ptr("pac/first")}},
+	})
+	assert.NilError(t, err)
+	repositoriesAdded, err := json.Marshal(github.InstallationRepositoriesEvent{
+		Action:            github.Ptr("added"),
+		Installation:      &github.Installation{Account: account},
+		RepositoriesAdded: []*github.Repository{{FullName: github.Ptr("pac/first")}},
+	})
+	assert.NilError(t, err)
+
+	tests := []struct {
+		name                   string
+		eventType              string
+		event                  []byte
+		disabled               bool
+		existingNamespacesOnly bool
+		detected               bool
+		configuring            bool
+		expectedRepos          map[string]string
+		testData               testclient.Data
+	}{
+		{
+			name:      "installation events disabled",
+			eventType: "installation",
+			event:     installationCreated,
+			disabled:  true,
+		},
+		{
+			name:        "installation created",
+			eventType:   "installation",
+			event:       installationCreated,
+			detected:    true,
+			configuring: true,
+			expectedRepos: map[string]string{
+				"first-pipelines":  "first-repo-cr",
+				"second-pipelines": "second-repo-cr",
+			},
+		},
+		{
+			name:      "installation deleted",
+			eventType: "installation",
+			event:     installationDeleted,
+			detected:  true,
+		},
+		{
+			name:          "installation repositories added",
+			eventType:     "installation_repositories",
+			event:         repositoriesAdded,
+			detected:      true,
+			configuring:   true,
+			expectedRepos: map[string]string{"first-pipelines": "first-repo-cr"},
+		},
+		{
+			name:        "repository already exists",
+			eventType:   "installation_repositories",
+			event:       repositoriesAdded,
+			detected:    true,
+			configuring: true,
+			testData: testclient.Data{
+				Namespaces: []*v12.Namespace{{ObjectMeta: v1.ObjectMeta{Name: "first-pipelines"}}},
+				Repositories: []*v1alpha1.Repository{
+					{
+						ObjectMeta: v1.ObjectMeta{Name: "first-repo-cr", Namespace: "first-pipelines"},
+						Spec:       v1alpha1.RepositorySpec{URL: "https://github.com/pac/first"},
+					},
+				},
+			},
+			expectedRepos: map[string]string{"first-pipelines": "first-repo-cr"},
+		},
+		{
+			name:                   "existing namespaces only",
+			eventType:              "installation",
+			event:                  installationCreated,
+			existingNamespacesOnly: true,
+			detected:               true,
+			configuring:            true,
+			testData: testclient.Data{
+				Namespaces: []*v12.Namespace{{ObjectMeta: v1.ObjectMeta{Name: "second-pipelines"}}},
+			},
+			expectedRepos: map[string]string{"second-pipelines": "second-repo-cr"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			observer, _ := zapobserver.New(zap.InfoLevel)
+			logger := zap.New(observer).Sugar()
+			ctx, _ := rtesting.SetupFakeContext(t)
+			cs, _ := testclient.SeedTestData(t, ctx, tt.testData)
+			run := &params.Run{
+				Clients: clients.Clients{
+					PipelineAsCode: cs.PipelineAsCode,
+					Kube:           cs.Kube,
+				},
+			}
+			req, err := http.NewRequestWithContext(context.Background(), http.MethodPost, "URL", bytes.NewReader(tt.event))
+			assert.NilError(t, err)
+			req.Header.Set("X-Github-Event", tt.eventType)
+
+			infoPac := &info.PacOpts{
+				Settings: settings.Settings{
+					AutoConfigureGitHubAppInstallations: !tt.disabled,
+					AutoConfigureExistingNamespacesOnly: tt.existingNamespacesOnly,
+				},
+			}
+			detected, configuring, err := ConfigureRepository(ctx, run, req, string(tt.event), infoPac, logger)
+			assert.NilError(t, err)
+			assert.Equal(t, detected, tt.detected)
+			assert.Equal(t, configuring, tt.configuring)
+
+			repos, err := run.Clients.PipelineAsCode.PipelinesascodeV1alpha1().Repositories("").List(ctx, v1.ListOptions{})
+			assert.NilError(t, err)
+			assert.Equal(t, len(repos.Items), len(tt.expectedRepos))
+			for ns, name := range tt.expectedRepos {
+				_, err := run.Clients.PipelineAsCode.PipelinesascodeV1alpha1().Repositories(ns).Get(ctx, name, v1.GetOptions{})
+				assert.NilError(t, err)
+			}
+		})
+	}
+}
+
 func TestGenerateNamespaceAndRepositoryName(t *testing.T) {
 	tests := []struct {
 		name         string
 		nsTemplate   string
 		repoTemplate string
-		gitEvent     *github.RepositoryEvent
+		repoURL      string
 		want         string
 		wantRepo     string
 	}{
@@ -185,52 +320,36 @@ func TestGenerateNamespaceAndRepositoryName(t *testing.T) {
 			name:         "no template",
 			nsTemplate:   "",
 			repoTemplate: "",
-			gitEvent: &github.RepositoryEvent{
-				Repo: &github.Repository{
-					HTMLURL: github.Ptr("https://github.com/user/pac"),
-				},
-			},
-			want:     "pac-pipelines",
-			wantRepo: "pac-repo-cr",
+			repoURL:      "https://github.com/user/pac",
+			want:         "pac-pipelines",
+			wantRepo:     "pac-repo-cr",
 		},
 		{
 			name:         "template",
 			nsTemplate:   "{{repo_owner}}-{{repo_name}}-ci",
 			repoTemplate: "{{repo_owner}}-{{repo_name}}-repo-cr",
-			gitEvent: &github.RepositoryEvent{
-				Repo: &github.Repository{
-					HTMLURL: github.Ptr("https://github.com/user/pac"),
-				},
-			},
-			want:     "user-pac-ci",
-			wantRepo: "user-pac-repo-cr",
+			repoURL:      "https://github.com/user/pac",
+			want:         "user-pac-ci",
+			wantRepo:     "user-pac-repo-cr",
 		},
 		{
 			name:       "empty repo template",
 			nsTemplate: "{{repo_owner}}-{{repo_name}}-ci",
-			gitEvent: &github.RepositoryEvent{
-				Repo: &github.Repository{
-					HTMLURL: github.Ptr("https://github.com/user/pac"),
-				},
-			},
-			want:     "user-pac-ci",
-			wantRepo: "pac-repo-cr",
+			repoURL:    "https://github.com/user/pac",
+			want:       "user-pac-ci",
+			wantRepo:   "pac-repo-cr",
 		},
 		{
 			name:         "empty ns template",
 			repoTemplate: "{{repo_owner}}-{{repo_name}}-repo-cr",
-			gitEvent: &github.RepositoryEvent{
-				Repo: &github.Repository{
-					HTMLURL: github.Ptr("https://github.com/user/pac"),
-				},
-			},
-			want:     "pac-pipelines",
-			wantRepo: "user-pac-repo-cr",
+			repoURL:      "https://github.com/user/pac",
+			want:         "pac-pipelines",
+			wantRepo:     "user-pac-repo-cr",
 		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			got, gotRepo, err := generateNamespaceAndRepositoryName(tt.nsTemplate, tt.repoTemplate, tt.gitEvent)
+			got, gotRepo, err := generateNamespaceAndRepositoryName(tt.nsTemplate, tt.repoTemplate, tt.repoURL)
 			assert.NilError(t, err)
 			assert.Equal(t, got, tt.want)
 			assert.Equal(t, gotRepo, tt.wantRepo)
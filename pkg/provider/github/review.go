@@ -0,0 +1,57 @@
+package github
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/go-github/v74/github"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/params/info"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/provider"
+)
+
+// reviewState returns the review decision of the state of a GitHub review,
+// empty for the comments and the dismissed reviews.
+func reviewState(state string) string {
+	switch state {
+	case "APPROVED":
+		return provider.ReviewDecisionApproved
+	case "CHANGES_REQUESTED":
+		return provider.ReviewDecisionChangesRequested
+	}
+	return ""
+}
+
+// GetReviewDecision returns whether the pull request of the event has been
+// approved or had changes requested by its reviewers.
+func (v *Provider) GetReviewDecision(ctx context.Context, event *info.Event) (string, error) {
+	if v.ghClient == nil {
+		return "", fmt.Errorf("no github client has been initialized")
+	}
+	if event.PullRequestNumber == 0 {
+		return "", nil
+	}
+	if !v.graphQLUnavailable {
+		if reviews, err := v.getPullRequestReviewsGraphQL(ctx, event); err == nil {
+			return provider.ReviewDecision(reviews), nil
+		}
+	}
+
+	reviews := []provider.Review{}
+	opt := &github.ListOptions{PerPage: v.PaginedNumber}
+	for {
+		page, resp, err := wrapAPI(v, "list_pull_request_reviews", func() ([]*github.PullRequestReview, *github.Response, error) {
+			return v.Client().PullRequests.ListReviews(ctx, event.Organization, event.Repository, event.PullRequestNumber, opt)
+		})
+		if err != nil {
+			return "", fmt.Errorf("cannot list the reviews of the pull request %d: %w", event.PullRequestNumber, err)
+		}
+		for _, review := range page {
+			reviews = append(reviews, provider.Review{Reviewer: review.GetUser().GetLogin(), State: reviewState(review.GetState())})
+		}
+		if resp.NextPage == 0 {
+			break
+		}
+		opt.Page = resp.NextPage
+	}
+	return provider.ReviewDecision(reviews), nil
+}
@@ -0,0 +1,67 @@
+package github
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/params/info"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/provider"
+	ghtesthelper "github.com/openshift-pipelines/pipelines-as-code/pkg/test/github"
+	"gotest.tools/v3/assert"
+	rtesting "knative.dev/pkg/reconciler/testing"
+)
+
+func TestGetReviewDecision(t *testing.T) {
+	tests := []struct {
+		name         string
+		graphQLReply string
+		restReply    string
+		want         string
+		wantREST     bool
+	}{
+		{
+			name:         "approved",
+			graphQLReply: `{"data":{"repository":{"pullRequest":{"latestOpinionatedReviews":{"nodes":[{"state":"APPROVED","author":{"login":"reviewer"}}]}}}}}`,
+			want:         provider.ReviewDecisionApproved,
+		},
+		{
+			name:         "changes requested by another reviewer",
+			graphQLReply: `{"data":{"repository":{"pullRequest":{"latestOpinionatedReviews":{"nodes":[{"state":"APPROVED","author":{"login":"reviewer"}},{"state":"CHANGES_REQUESTED","author":{"login":"other"}}]}}}}}`,
+			want:         provider.ReviewDecisionChangesRequested,
+		},
+		{
+			name:         "not reviewed",
+			graphQLReply: `{"data":{"repository":{"pullRequest":{"latestOpinionatedReviews":{"nodes":[]}}}}}`,
+		},
+		{
+			name:      "rest fallback with a new approval after the changes requested",
+			restReply: `[{"state":"CHANGES_REQUESTED","user":{"login":"reviewer"}},{"state":"COMMENTED","user":{"login":"other"}},{"state":"APPROVED","user":{"login":"reviewer"}}]`,
+			want:      provider.ReviewDecisionApproved,
+			wantREST:  true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fakeclient, mux, _, teardown := ghtesthelper.SetupGH()
+			defer teardown()
+			if tt.graphQLReply != "" {
+				mux.HandleFunc("/graphql", func(rw http.ResponseWriter, _ *http.Request) {
+					fmt.Fprint(rw, tt.graphQLReply)
+				})
+			}
+			restCalled := false
+			mux.HandleFunc("/repos/owner/repo/pulls/10/reviews", func(rw http.ResponseWriter, _ *http.Request) {
+				restCalled = true
+				fmt.Fprint(rw, tt.restReply)
+			})
+			v := &Provider{ghClient: fakeclient, PaginedNumber: 100}
+			ctx, _ := rtesting.SetupFakeContext(t)
+			event := &info.Event{Organization: "owner", Repository: "repo", PullRequestNumber: 10}
+			got, err := v.GetReviewDecision(ctx, event)
+			assert.NilError(t, err)
+			assert.Equal(t, got, tt.want)
+			assert.Equal(t, restCalled, tt.wantREST)
+		})
+	}
+}
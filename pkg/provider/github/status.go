@@ -251,6 +251,11 @@ func (v *Provider) getOrUpdateCheckRunStatus(ctx context.Context, runevent *info
 		}
 	}
 
+	if v.AppSlug != "" {
+		// several installations of Pipelines-as-Code may report on the same
+		// commit with the same application name
+		text = fmt.Sprintf("%s\n\n<sub>Reported by the GitHub App %s</sub>", text, v.AppSlug)
+	}
 	checkRunOutput.Text = github.Ptr(text)
 
 	opts := github.UpdateCheckRunOptions{
@@ -265,8 +270,10 @@ func (v *Provider) getOrUpdateCheckRunStatus(ctx context.Context, runevent *info
 		opts.DetailsURL = &statusOpts.DetailsURL
 	}
 
-	// Only set completed-at if conclusion is set (which means finished)
+	// Only set completed-at if conclusion is set (which means finished), an
+	// action_required check run is completed until an /ok-to-test reuses it.
 	if statusOpts.Conclusion != "" && statusOpts.Conclusion != "pending" {
+		opts.Status = github.Ptr("completed")
 		opts.CompletedAt = &github.Timestamp{Time: time.Now()}
 		opts.Conclusion = &statusOpts.Conclusion
 	}
@@ -309,13 +316,19 @@ func metadataPatch(checkRunID *int64, logURL string) map[string]any {
 func (v *Provider) createStatusCommit(ctx context.Context, runevent *info.Event, status provider.StatusOpts) error {
 	var err error
 	now := time.Now()
+	// the commit statuses only have the error, failure, pending and success
+	// states, the title tells the actual conclusion.
 	switch status.Conclusion {
-	case "neutral":
+	case "neutral", "skipped":
 		status.Conclusion = "success" // We don't have a choice than setting as success, no pending here.
 	case "pending":
 		if status.Title != "" {
 			status.Conclusion = "pending"
 		}
+	case "action_required":
+		status.Conclusion = "pending"
+	case "cancelled":
+		status.Conclusion = "error"
 	}
 	if status.Status == "in_progress" {
 		status.Conclusion = "pending"
@@ -388,16 +401,27 @@ func (v *Provider) CreateStatus(ctx context.Context, runevent *info.Event, statu
 		statusOpts.Summary = "has <b>failed</b>."
 	case "pending":
 		// for concurrency set title as pending
-		if statusOpts.Title == "" {
+		switch {
+		case statusOpts.Title == "":
 			statusOpts.Title = "Pending"
 			statusOpts.Summary = "is skipping this commit."
-		} else {
+		case statusOpts.Status == "queued" && statusOpts.PipelineRunName != "":
+			// queued by the concurrency limit, the title has the position in the queue
+			statusOpts.Summary = "is queued."
+		default:
 			// for unauthorized user set title as Pending approval
 			statusOpts.Summary = "is waiting for approval."
 		}
+	case "action_required":
+		statusOpts.Summary = "is waiting for approval."
 	case "cancelled":
 		statusOpts.Title = "Cancelled"
 		statusOpts.Summary = "has been <b>cancelled</b>."
+	case "skipped":
+		if statusOpts.Title == "" {
+			statusOpts.Title = "Skipped"
+		}
+		statusOpts.Summary = "has been <b>skipped</b>."
 	case "neutral":
 		if statusOpts.Title == "" {
 			statusOpts.Title = "Unknown"
@@ -250,6 +250,7 @@ func TestGithubProviderCreateStatus(t *testing.T) {
 		githubApps         bool
 		accessDenied       bool
 		isBot              bool
+		appSlug            string
 	}
 	tests := []struct {
 		name                 string
@@ -257,6 +258,7 @@ func TestGithubProviderCreateStatus(t *testing.T) {
 		pr                   *tektonv1.PipelineRun
 		want                 *github.CheckRun
 		wantErr              bool
+		wantText             string
 		notoken              bool
 		addExistingCheckruns bool
 	}{
@@ -394,6 +396,21 @@ func TestGithubProviderCreateStatus(t *testing.T) {
 			want:    &github.CheckRun{ID: &resultid},
 			wantErr: false,
 		},
+		{
+			name: "with the slug of the app",
+			args: args{
+				runevent:    runEvent,
+				status:      "completed",
+				conclusion:  "success",
+				text:        "Yay",
+				detailsURL:  "https://cireport.com",
+				titleSubstr: "Success",
+				githubApps:  true,
+				appSlug:     "pipelines-as-code",
+			},
+			want:     &github.CheckRun{ID: &resultid},
+			wantText: "Yay\n\n<sub>Reported by the GitHub App pipelines-as-code</sub>",
+		},
 		{
 			name:    "no token set",
 			wantErr: true,
@@ -413,6 +430,11 @@ func TestGithubProviderCreateStatus(t *testing.T) {
 			if tt.args.isBot {
 				gcvs.userType = "Bot"
 			}
+			gcvs.AppSlug = tt.args.appSlug
+			wantText := tt.args.text
+			if tt.wantText != "" {
+				wantText = tt.wantText
+			}
 
 			checkRunCreated := false
 			mux.HandleFunc("/repos/check/run/statuses/sha", func(_ http.ResponseWriter, _ *http.Request) {})
@@ -432,7 +454,7 @@ func TestGithubProviderCreateStatus(t *testing.T) {
 				if tt.args.conclusion != "pending" {
 					assert.Equal(t, checkRun.GetConclusion(), tt.args.conclusion)
 				}
-				assert.Equal(t, checkRun.Output.GetText(), tt.args.text)
+				assert.Equal(t, checkRun.Output.GetText(), wantText)
 				assert.Equal(t, checkRun.GetDetailsURL(), tt.args.detailsURL)
 				assert.Assert(t, strings.Contains(checkRun.Output.GetTitle(), tt.args.titleSubstr))
 				_, err = fmt.Fprintf(rw, `{"id": %d}`, resultid)
@@ -10,30 +10,76 @@ import (
 	gitlab "gitlab.com/gitlab-org/api/client-go"
 )
 
+// ownersFiles are the OWNERS and OWNERS_ALIASES files of a branch.
+type ownersFiles struct {
+	owners  string
+	aliases string
+	err     error
+}
+
+// aclCache memoizes the lookups done to check the ACL of an event, every
+// /ok-to-test comment of a merge request is checked against the membership of
+// its author and the OWNERS files.
+type aclCache struct {
+	members map[int]bool
+	owners  map[string]*ownersFiles
+}
+
+func (v *Provider) getACLCache() *aclCache {
+	if v.aclCache == nil {
+		v.aclCache = &aclCache{members: map[int]bool{}, owners: map[string]*ownersFiles{}}
+	}
+	return v.aclCache
+}
+
+// getOwnersFiles gets the OWNERS files of branch once per event.
+func (v *Provider) getOwnersFiles(branch string) *ownersFiles {
+	cache := v.getACLCache()
+	if files, ok := cache.owners[branch]; ok {
+		return files
+	}
+	files := &ownersFiles{}
+	ownerContent, _, _ := v.getObject("OWNERS", branch, v.targetProjectID)
+	files.owners = string(ownerContent)
+	if files.owners != "" {
+		// OWNERS_ALIASES file existence is not required, if we get "not found" continue
+		ownerAliasesContent, resp, err := v.getObject("OWNERS_ALIASES", branch, v.targetProjectID)
+		if resp == nil || (resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusNotFound) {
+			files.err = err
+		}
+		files.aliases = string(ownerAliasesContent)
+	}
+	cache.owners[branch] = files
+	return files
+}
+
 // IsAllowedOwnersFile get the owner files (OWNERS, OWNERS_ALIASES) from main branch
 // and check if we have explicitly allowed the user in there.
 func (v *Provider) IsAllowedOwnersFile(_ context.Context, event *info.Event) (bool, error) {
-	ownerContent, _, _ := v.getObject("OWNERS", event.DefaultBranch, v.targetProjectID)
-	if string(ownerContent) == "" {
+	files := v.getOwnersFiles(event.DefaultBranch)
+	if files.owners == "" {
 		return false, nil
 	}
-	// OWNERS_ALIASES file existence is not required, if we get "not found" continue
-	ownerAliasesContent, resp, err := v.getObject("OWNERS_ALIASES", event.DefaultBranch, v.targetProjectID)
-	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusNotFound {
-		return false, err
+	if files.err != nil {
+		return false, files.err
 	}
-	allowed, _ := acl.UserInOwnerFile(string(ownerContent), string(ownerAliasesContent), event.Sender)
+	allowed, _ := acl.UserInOwnerFile(files.owners, files.aliases, event.Sender)
 	return allowed, nil
 }
 
 func (v *Provider) checkMembership(ctx context.Context, event *info.Event, userid int) bool {
-	member, _, err := v.Client().ProjectMembers.GetInheritedProjectMember(v.targetProjectID, userid)
-	if err == nil && member.ID != 0 && member.ID == userid {
-		return true
+	cache := v.getACLCache()
+	if allowed, ok := cache.members[userid]; ok {
+		return allowed
 	}
 
-	isAllowed, _ := v.IsAllowedOwnersFile(ctx, event)
-	return isAllowed
+	member, _, err := v.Client().ProjectMembers.GetInheritedProjectMember(v.targetProjectID, userid)
+	allowed := err == nil && member.ID != 0 && member.ID == userid
+	if !allowed {
+		allowed, _ = v.IsAllowedOwnersFile(ctx, event)
+	}
+	cache.members[userid] = allowed
+	return allowed
 }
 
 func (v *Provider) checkOkToTestCommentFromApprovedMember(ctx context.Context, event *info.Event, page int) (bool, error) {
@@ -50,14 +96,13 @@ func (v *Provider) checkOkToTestCommentFromApprovedMember(ctx context.Context, e
 	for _, comment := range discussions {
 		// TODO: maybe we do threads in the future but for now we just check the top thread for ops related comments
 		topthread := comment.Notes[0]
-		if acl.MatchRegexp(acl.OKToTestCommentRegexp, topthread.Body) {
+		if acl.MatchRegexp(acl.OKToTestRegexp(), topthread.Body) {
 			commenterEvent := info.NewEvent()
 			commenterEvent.Event = event.Event
 			commenterEvent.Sender = topthread.Author.Username
 			commenterEvent.BaseBranch = event.BaseBranch
 			commenterEvent.HeadBranch = event.HeadBranch
 			commenterEvent.DefaultBranch = event.DefaultBranch
-			// TODO: we could probably do with caching when checking all issues?
 			if v.checkMembership(ctx, commenterEvent, topthread.Author.ID) {
 				return true, nil
 			}
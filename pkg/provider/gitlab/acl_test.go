@@ -1,10 +1,13 @@
 package gitlab
 
 import (
+	"fmt"
+	"net/http"
 	"testing"
 
 	"github.com/openshift-pipelines/pipelines-as-code/pkg/params/info"
 	thelp "github.com/openshift-pipelines/pipelines-as-code/pkg/provider/gitlab/test"
+	"gotest.tools/v3/assert"
 	rtesting "knative.dev/pkg/reconciler/testing"
 )
 
@@ -130,3 +133,39 @@ func TestIsAllowed(t *testing.T) {
 		})
 	}
 }
+
+func TestIsAllowedCachesLookups(t *testing.T) {
+	ctx, _ := rtesting.SetupFakeContext(t)
+	client, mux, tearDown := thelp.Setup(t)
+	defer tearDown()
+
+	calls := map[string]int{}
+	mux.HandleFunc("/projects/2525/members/all/", func(rw http.ResponseWriter, r *http.Request) {
+		calls[r.URL.Path]++
+		fmt.Fprint(rw, `{}`)
+	})
+	mux.HandleFunc("/projects/2525/repository/files/OWNERS/raw", func(rw http.ResponseWriter, r *http.Request) {
+		calls[r.URL.Path]++
+		fmt.Fprint(rw, "---\n approvers:\n  - someoneelse\n")
+	})
+	mux.HandleFunc("/projects/2525/repository/files/OWNERS_ALIASES/raw", func(rw http.ResponseWriter, _ *http.Request) {
+		rw.WriteHeader(http.StatusNotFound)
+	})
+	mux.HandleFunc("/projects/2525/merge_requests/1/discussions", func(rw http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("page") == "1" {
+			rw.Header().Set("X-Next-Page", "2")
+		}
+		note := `{"notes": [{"body": "/ok-to-test", "author": {"username": "notallowed", "id": 1111}}]}`
+		fmt.Fprintf(rw, "[%s, %s]", note, note)
+	})
+
+	v := &Provider{gitlabClient: client, targetProjectID: 2525, userID: 6666}
+	allowed, err := v.IsAllowed(ctx, &info.Event{Sender: "noowner", PullRequestNumber: 1, DefaultBranch: "main"})
+	assert.NilError(t, err)
+	assert.Assert(t, !allowed)
+	assert.DeepEqual(t, calls, map[string]int{
+		"/projects/2525/members/all/6666":            1,
+		"/projects/2525/members/all/1111":            1,
+		"/projects/2525/repository/files/OWNERS/raw": 1,
+	})
+}
@@ -10,6 +10,10 @@ import (
 	"go.uber.org/zap"
 )
 
+// mergeRequestApprovalActions are the actions of a user approving a merge
+// request or revoking an approval.
+var mergeRequestApprovalActions = []string{"approval", "unapproval"}
+
 // Detect detects events and validates if it is a valid gitlab event Pipelines as Code supports and
 // decides whether to process or reject it.
 // returns a boolean value whether to process or reject, logger with event metadata, and error if any occurred.
@@ -58,6 +62,9 @@ func (v *Provider) Detect(req *http.Request, payload string, logger *zap.Sugared
 		if provider.Valid(gitEvent.ObjectAttributes.Action, []string{"open", "reopen", "close"}) {
 			return setLoggerAndProceed(true, "", nil)
 		}
+		if provider.Valid(gitEvent.ObjectAttributes.Action, mergeRequestApprovalActions) {
+			return setLoggerAndProceed(true, "", nil)
+		}
 
 		return setLoggerAndProceed(false, fmt.Sprintf("not a merge event we care about: \"%s\"", gitEvent.ObjectAttributes.Action), nil)
 	case *gitlab.PushEvent, *gitlab.TagEvent:
@@ -65,6 +65,27 @@ func TestProvider_Detect(t *testing.T) {
 			isGL:       true,
 			processReq: false,
 		},
+		{
+			name:       "good/mergeRequest approval Event",
+			event:      sample.MREventAsJSON("approval", ""),
+			eventType:  gitlab.EventTypeMergeRequest,
+			isGL:       true,
+			processReq: true,
+		},
+		{
+			name:       "good/mergeRequest unapproval Event",
+			event:      sample.MREventAsJSON("unapproval", ""),
+			eventType:  gitlab.EventTypeMergeRequest,
+			isGL:       true,
+			processReq: true,
+		},
+		{
+			name:       "bad/mergeRequest approved Event",
+			event:      sample.MREventAsJSON("approved", ""),
+			eventType:  gitlab.EventTypeMergeRequest,
+			isGL:       true,
+			processReq: false,
+		},
 		{
 			name:       "good/mergeRequest update Event with commit",
 			event:      sample.MREventAsJSON("update", `"oldrev": "123"`),
@@ -10,6 +10,7 @@ import (
 	"path/filepath"
 	"regexp"
 	"strings"
+	"time"
 
 	"github.com/openshift-pipelines/pipelines-as-code/pkg/apis/pipelinesascode/v1alpha1"
 	"github.com/openshift-pipelines/pipelines-as-code/pkg/changedfiles"
@@ -62,6 +63,7 @@ type Provider struct {
 	eventEmitter      *events.EventEmitter
 	repo              *v1alpha1.Repository
 	triggerEvent      string
+	aclCache          *aclCache
 }
 
 func (v *Provider) Client() *gitlab.Client {
@@ -165,7 +167,7 @@ func (v *Provider) GetConfig() *info.ProviderConfig {
 	}
 }
 
-func (v *Provider) SetClient(_ context.Context, run *params.Run, runevent *info.Event, repo *v1alpha1.Repository, eventsEmitter *events.EventEmitter) error {
+func (v *Provider) SetClient(ctx context.Context, run *params.Run, runevent *info.Event, repo *v1alpha1.Repository, eventsEmitter *events.EventEmitter) error {
 	var err error
 	if runevent.Provider.Token == "" {
 		return fmt.Errorf("no git_provider.secret has been set in the repo crd")
@@ -195,8 +197,23 @@ func (v *Provider) SetClient(_ context.Context, run *params.Run, runevent *info.
 	}
 	v.apiURL = apiURL
 
+	accessToken, isOAuth, err := oauthToken(ctx, run.Clients.Kube, repo, apiURL, time.Now())
+	if err != nil {
+		return err
+	}
+	if isOAuth {
+		runevent.Provider.Token = accessToken
+		if runevent.Provider.User == "" {
+			runevent.Provider.User = oauthUser
+		}
+	}
+
 	if v.gitlabClient == nil {
-		v.gitlabClient, err = gitlab.NewClient(runevent.Provider.Token, gitlab.WithBaseURL(apiURL))
+		if isOAuth {
+			v.gitlabClient, err = gitlab.NewOAuthClient(runevent.Provider.Token, gitlab.WithBaseURL(apiURL))
+		} else {
+			v.gitlabClient, err = gitlab.NewClient(runevent.Provider.Token, gitlab.WithBaseURL(apiURL))
+		}
 		if err != nil {
 			return err
 		}
@@ -212,6 +229,16 @@ func (v *Provider) SetClient(_ context.Context, run *params.Run, runevent *info.
 	if v.sourceProjectID == 0 && runevent.SourceProjectID > 0 {
 		v.sourceProjectID = runevent.SourceProjectID
 	}
+	// the merge requests found by polling have no webhook payload to take the
+	// author and the target project from
+	if mr, ok := runevent.Event.(*gitlab.BasicMergeRequest); ok {
+		if v.targetProjectID == 0 {
+			v.targetProjectID = mr.TargetProjectID
+		}
+		if v.userID == 0 && mr.Author != nil {
+			v.userID = mr.Author.ID
+		}
+	}
 
 	// check that we have access to the source project if it's a private repo, this should only occur on Merge Requests
 	if runevent.TriggerTarget == triggertype.PullRequest {
@@ -229,8 +256,7 @@ func (v *Provider) SetClient(_ context.Context, run *params.Run, runevent *info.
 	// if we don't have sourceProjectID (ie: incoming-webhook) then try to set
 	// it ASAP if we can.
 	if v.sourceProjectID == 0 && runevent.Organization != "" && runevent.Repository != "" {
-		projectSlug := path.Join(runevent.Organization, runevent.Repository)
-		projectinfo, _, err := v.Client().Projects.GetProject(projectSlug, &gitlab.GetProjectOptions{})
+		projectinfo, err := v.resolveProject(ctx, run.Clients.PipelineAsCode, repo, path.Join(runevent.Organization, runevent.Repository))
 		if err != nil {
 			return err
 		}
@@ -258,8 +284,14 @@ func (v *Provider) CreateStatus(_ context.Context, event *info.Event, statusOpts
 	}
 	switch statusOpts.Conclusion {
 	case "skipped":
-		statusOpts.Conclusion = "canceled"
+		statusOpts.Conclusion = "skipped"
 		statusOpts.Title = "skipped validating this commit"
+	case "cancelled":
+		statusOpts.Conclusion = "canceled"
+		statusOpts.Title = "been cancelled"
+	case "action_required":
+		// the title asks for an /ok-to-test
+		statusOpts.Conclusion = "pending"
 	case "neutral":
 		statusOpts.Conclusion = "canceled"
 		statusOpts.Title = "stopped"
@@ -319,7 +351,7 @@ func (v *Provider) CreateStatus(_ context.Context, event *info.Event, statusOpts
 	// we only show the first error as it's likely something the user has more control to fix
 	// the second err is cryptic as it needs a dummy gitlab pipeline to start
 	// with and will only give more confusion in the event namespace
-	v.eventEmitter.EmitMessage(v.repo, zap.InfoLevel, "FailedToSetCommitStatus",
+	v.eventEmitter.EmitFailure(v.repo, zap.InfoLevel, v1alpha1.FailureReasonProviderAPIError, "FailedToSetCommitStatus",
 		fmt.Sprintf("failed to create commit status: source project ID %d, target project ID %d. "+
 			"If you want Gitlab Pipeline Status update, ensure your GitLab token giving it access "+
 			"to the source repository. %v",
@@ -423,17 +455,14 @@ func (v *Provider) concatAllYamlFiles(objects []*gitlab.TreeNode, revision strin
 			if err := provider.ValidateYaml(data, value.Path); err != nil {
 				return "", err
 			}
-			if allTemplates != "" && !strings.HasPrefix(string(data), "---") {
-				allTemplates += "---"
-			}
-			allTemplates += "\n" + string(data) + "\n"
+			allTemplates = provider.AppendYamlFile(allTemplates, value.Path, string(data))
 		}
 	}
 
 	return allTemplates, nil
 }
 
-func (v *Provider) getObject(fname, branch string, pid int) ([]byte, *gitlab.Response, error) {
+func (v *Provider) getObject(fname, branch string, pid any) ([]byte, *gitlab.Response, error) {
 	opt := &gitlab.GetRawFileOptions{
 		Ref: gitlab.Ptr(branch),
 	}
@@ -557,8 +586,77 @@ func (v *Provider) CreateToken(_ context.Context, _ []string, _ *info.Event) (st
 	return "", nil
 }
 
-// isHeadCommitOfBranch validates that branch exists and the SHA is HEAD commit of the branch.
-func (v *Provider) isHeadCommitOfBranch(runevent *info.Event, branchName string) error {
+// GetCIVariables returns the CI/CD variables of the target project, the token
+// needs the Maintainer role to read them.
+func (v *Provider) GetCIVariables(_ context.Context, event *info.Event, names []string) (map[string]string, error) {
+	if v.gitlabClient == nil {
+		return nil, fmt.Errorf("no gitlab client has been initialized")
+	}
+	projectID := event.TargetProjectID
+	if projectID == 0 {
+		projectID = v.sourceProjectID
+	}
+	ret := map[string]string{}
+	for _, name := range names {
+		variable, _, err := v.Client().ProjectVariables.GetVariable(projectID, name, nil)
+		if err != nil {
+			return nil, fmt.Errorf("cannot get the CI/CD variable %s of project %d: %w", name, projectID, err)
+		}
+		ret[name] = variable.Value
+	}
+	return ret, nil
+}
+
+func (v *Provider) CreateLineComment(_ context.Context, _ *info.Event, _ string, _ int, _ string) error {
+	return fmt.Errorf("line comments are not supported on GitLab")
+}
+
+// GetUserProfile returns the profile of the sender of the event, the email is
+// the public one unless the token belongs to an administrator.
+func (v *Provider) GetUserProfile(_ context.Context, event *info.Event) (*provider.UserProfile, error) {
+	if v.gitlabClient == nil {
+		return nil, fmt.Errorf("no gitlab client has been initialized")
+	}
+	users, _, err := v.Client().Users.ListUsers(&gitlab.ListUsersOptions{Username: gitlab.Ptr(event.Sender)})
+	if err != nil {
+		return nil, fmt.Errorf("cannot get the user %s: %w", event.Sender, err)
+	}
+	if len(users) == 0 {
+		return nil, fmt.Errorf("cannot find the user %s", event.Sender)
+	}
+	email := users[0].PublicEmail
+	if email == "" {
+		email = users[0].Email
+	}
+	return &provider.UserProfile{
+		Email:     email,
+		FullName:  users[0].Name,
+		AvatarURL: users[0].AvatarURL,
+	}, nil
+}
+
+// GetReviewDecision returns whether the merge request of the event has been
+// approved, GitLab has no review requesting changes which blocks the merge.
+func (v *Provider) GetReviewDecision(_ context.Context, event *info.Event) (string, error) {
+	if v.gitlabClient == nil {
+		return "", fmt.Errorf("no gitlab client has been initialized")
+	}
+	if event.PullRequestNumber == 0 {
+		return "", nil
+	}
+	approvals, _, err := v.Client().MergeRequestApprovals.GetConfiguration(v.targetProjectID, event.PullRequestNumber)
+	if err != nil {
+		return "", fmt.Errorf("cannot get the approvals of the merge request %d: %w", event.PullRequestNumber, err)
+	}
+	if len(approvals.ApprovedBy) > 0 {
+		return provider.ReviewDecisionApproved, nil
+	}
+	return "", nil
+}
+
+// isCommitInBranch validates that branch exists and the SHA is part of the
+// history of the branch.
+func (v *Provider) isCommitInBranch(runevent *info.Event, branchName string) error {
 	if v.gitlabClient == nil {
 		return fmt.Errorf("no gitlab client has been initialized, " +
 			"exiting... (hint: did you forget setting a secret on your repo?)")
@@ -572,7 +670,24 @@ func (v *Provider) isHeadCommitOfBranch(runevent *info.Event, branchName string)
 		return nil
 	}
 
-	return fmt.Errorf("provided SHA %s is not the HEAD commit of the branch %s", runevent.SHA, branchName)
+	opt := &gitlab.GetCommitRefsOptions{Type: gitlab.Ptr("branch")}
+	for {
+		refs, resp, err := v.Client().Commits.GetCommitRefs(v.sourceProjectID, runevent.SHA, opt)
+		if err != nil {
+			return fmt.Errorf("provided SHA %s is not part of the branch %s: %w", runevent.SHA, branchName, err)
+		}
+		for _, ref := range refs {
+			if ref.Name == branchName {
+				return nil
+			}
+		}
+		if resp.NextPage == 0 {
+			break
+		}
+		opt.Page = resp.NextPage
+	}
+
+	return fmt.Errorf("provided SHA %s is not part of the branch %s", runevent.SHA, branchName)
 }
 
 func (v *Provider) GetTemplate(commentType provider.CommentType) string {
@@ -86,6 +86,35 @@ func TestCreateStatus(t *testing.T) {
 				postStr: "has stopped",
 			},
 		},
+		{
+			name:       "cancelled conclusion",
+			wantClient: true,
+			wantErr:    false,
+			args: args{
+				statusOpts: provider.StatusOpts{
+					Conclusion: "cancelled",
+				},
+				event: &info.Event{
+					TriggerTarget: "pull_request",
+				},
+				postStr: "has been cancelled",
+			},
+		},
+		{
+			name:       "action required conclusion",
+			wantClient: true,
+			wantErr:    false,
+			args: args{
+				statusOpts: provider.StatusOpts{
+					Conclusion: "action_required",
+					Title:      "Pending approval, waiting for an /ok-to-test",
+				},
+				event: &info.Event{
+					TriggerTarget: "pull_request",
+				},
+				postStr: "has Pending approval, waiting for an /ok-to-test",
+			},
+		},
 		{
 			name:       "failure conclusion",
 			wantClient: true,
@@ -1084,7 +1113,7 @@ func TestGetFiles(t *testing.T) {
 	}
 }
 
-func TestIsHeadCommitOfBranch(t *testing.T) {
+func TestIsCommitInBranch(t *testing.T) {
 	tests := []struct {
 		name          string
 		event         *info.Event
@@ -1113,11 +1142,17 @@ func TestIsHeadCommitOfBranch(t *testing.T) {
 			ErrMsg:        "404",
 		},
 		{
-			name:       "bad/SHA is not HEAD of the branch",
+			name:       "bad/SHA is not part of the branch",
 			wantClient: true,
 			event:      &info.Event{SHA: "IAmNotHEAD"},
 			branchName: "cool-branch",
-			ErrMsg:     "provided SHA IAmNotHEAD is not the HEAD commit of the branch cool-branch",
+			ErrMsg:     "provided SHA IAmNotHEAD is not part of the branch cool-branch",
+		},
+		{
+			name:       "good/SHA is an ancestor of the branch HEAD",
+			wantClient: true,
+			event:      &info.Event{SHA: "IAmOld"},
+			branchName: "cool-branch",
 		},
 		{
 			name:       "good/SHA is HEAD commit",
@@ -1145,8 +1180,16 @@ func TestIsHeadCommitOfBranch(t *testing.T) {
 						bytes, _ := json.Marshal(branch)
 						_, _ = rw.Write(bytes)
 					})
+				mux.HandleFunc("/projects/1/repository/commits/IAmNotHEAD/refs",
+					func(rw http.ResponseWriter, _ *http.Request) {
+						_, _ = rw.Write([]byte(`[{"type": "branch", "name": "other-branch"}]`))
+					})
+				mux.HandleFunc("/projects/1/repository/commits/IAmOld/refs",
+					func(rw http.ResponseWriter, _ *http.Request) {
+						_, _ = rw.Write([]byte(`[{"type": "branch", "name": "other-branch"}, {"type": "branch", "name": "cool-branch"}]`))
+					})
 			}
-			err := glProvider.isHeadCommitOfBranch(tt.event, tt.branchName)
+			err := glProvider.isCommitInBranch(tt.event, tt.branchName)
 			if tt.ErrMsg != "" {
 				assert.ErrorContains(t, err, tt.ErrMsg)
 				return
@@ -0,0 +1,146 @@
+package gitlab
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/apis/pipelinesascode/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/util/retry"
+)
+
+const (
+	defaultTokenKey = "provider.token"
+
+	// OAuthRefreshTokenKey is the key of the git_provider secret with the
+	// refresh token of a GitLab OAuth application, the token of the secret
+	// is then an OAuth access token refreshed when it expires.
+	OAuthRefreshTokenKey = "provider.refresh-token"
+	// OAuthClientIDKey is the key of the git_provider secret with the ID of
+	// the GitLab OAuth application.
+	OAuthClientIDKey = "provider.oauth-client-id"
+	// OAuthClientSecretKey is the key of the git_provider secret with the
+	// secret of the GitLab OAuth application.
+	OAuthClientSecretKey = "provider.oauth-client-secret"
+	// OAuthExpiresAtKey is the key of the git_provider secret where the
+	// expiry of the access token is kept, in the RFC 3339 format.
+	OAuthExpiresAtKey = "provider.token-expires-at"
+
+	// oauthUser is the user to clone with an OAuth access token.
+	oauthUser = "oauth2"
+	// oauthRefreshMargin is how long before its expiry the access token
+	// gets refreshed.
+	oauthRefreshMargin = 5 * time.Minute
+)
+
+type oauthTokenResponse struct {
+	AccessToken  string `json:"access_token"`
+	RefreshToken string `json:"refresh_token"`
+	ExpiresIn    int64  `json:"expires_in"`
+	CreatedAt    int64  `json:"created_at"`
+}
+
+// oauthToken returns the OAuth access token of the git_provider secret of
+// repo when it has a refresh token, refreshing it against apiURL when it
+// is about to expire and persisting the rotated tokens back to the secret.
+// It returns false when the secret doesn't hold an OAuth token.
+func oauthToken(ctx context.Context, kube kubernetes.Interface, repo *v1alpha1.Repository, apiURL string, now time.Time) (string, bool, error) {
+	if kube == nil || repo == nil || repo.Spec.GitProvider == nil || repo.Spec.GitProvider.Secret == nil {
+		return "", false, nil
+	}
+	secretName := repo.Spec.GitProvider.Secret.Name
+	tokenKey := repo.Spec.GitProvider.Secret.Key
+	if tokenKey == "" {
+		tokenKey = defaultTokenKey
+	}
+	secret, err := kube.CoreV1().Secrets(repo.GetNamespace()).Get(ctx, secretName, metav1.GetOptions{})
+	if errors.IsNotFound(err) {
+		// the secret may be inherited from the global repository
+		return "", false, nil
+	} else if err != nil {
+		return "", false, err
+	}
+	if len(secret.Data[OAuthRefreshTokenKey]) == 0 {
+		return "", false, nil
+	}
+
+	accessToken := string(secret.Data[tokenKey])
+	if expiresAt, err := time.Parse(time.RFC3339, string(secret.Data[OAuthExpiresAtKey])); err == nil && now.Add(oauthRefreshMargin).Before(expiresAt) {
+		return accessToken, true, nil
+	}
+
+	refreshed, err := refreshOAuthToken(ctx, apiURL, secret)
+	if err != nil {
+		// another replica may have refreshed it first, the refresh token is
+		// only usable once
+		latest, getErr := kube.CoreV1().Secrets(repo.GetNamespace()).Get(ctx, secretName, metav1.GetOptions{})
+		if getErr == nil && string(latest.Data[tokenKey]) != accessToken {
+			return string(latest.Data[tokenKey]), true, nil
+		}
+		return "", true, err
+	}
+
+	createdAt := now
+	if refreshed.CreatedAt > 0 {
+		createdAt = time.Unix(refreshed.CreatedAt, 0)
+	}
+	expiresAt := createdAt.Add(time.Duration(refreshed.ExpiresIn) * time.Second)
+	err = retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		latest, err := kube.CoreV1().Secrets(repo.GetNamespace()).Get(ctx, secretName, metav1.GetOptions{})
+		if err != nil {
+			return err
+		}
+		latest.Data[tokenKey] = []byte(refreshed.AccessToken)
+		latest.Data[OAuthRefreshTokenKey] = []byte(refreshed.RefreshToken)
+		latest.Data[OAuthExpiresAtKey] = []byte(expiresAt.UTC().Format(time.RFC3339))
+		_, err = kube.CoreV1().Secrets(repo.GetNamespace()).Update(ctx, latest, metav1.UpdateOptions{})
+		return err
+	})
+	if err != nil {
+		return "", true, fmt.Errorf("cannot store the refreshed gitlab oauth token in secret %s: %w", secretName, err)
+	}
+	return refreshed.AccessToken, true, nil
+}
+
+// refreshOAuthToken exchanges the refresh token of secret for a new access
+// token.
+func refreshOAuthToken(ctx context.Context, apiURL string, secret *corev1.Secret) (*oauthTokenResponse, error) {
+	form := url.Values{}
+	form.Set("grant_type", "refresh_token")
+	form.Set("refresh_token", string(secret.Data[OAuthRefreshTokenKey]))
+	if clientID := string(secret.Data[OAuthClientIDKey]); clientID != "" {
+		form.Set("client_id", clientID)
+	}
+	if clientSecret := string(secret.Data[OAuthClientSecretKey]); clientSecret != "" {
+		form.Set("client_secret", clientSecret)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, strings.TrimSuffix(apiURL, "/")+"/oauth/token", strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("cannot refresh the gitlab oauth token: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("cannot refresh the gitlab oauth token: %s", resp.Status)
+	}
+	refreshed := &oauthTokenResponse{}
+	if err := json.NewDecoder(resp.Body).Decode(refreshed); err != nil {
+		return nil, fmt.Errorf("cannot refresh the gitlab oauth token: %w", err)
+	}
+	if refreshed.AccessToken == "" {
+		return nil, fmt.Errorf("cannot refresh the gitlab oauth token: no access token returned")
+	}
+	return refreshed, nil
+}
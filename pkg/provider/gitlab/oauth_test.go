@@ -0,0 +1,133 @@
+package gitlab
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/apis/pipelinesascode/v1alpha1"
+	"gotest.tools/v3/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+	rtesting "knative.dev/pkg/reconciler/testing"
+)
+
+func TestOAuthToken(t *testing.T) {
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name          string
+		data          map[string]string
+		status        int
+		wantOAuth     bool
+		wantToken     string
+		wantRefreshed bool
+		wantErr       string
+	}{
+		{
+			name:      "personal access token",
+			data:      map[string]string{defaultTokenKey: "pat"},
+			wantOAuth: false,
+		},
+		{
+			name: "access token still valid",
+			data: map[string]string{
+				defaultTokenKey:      "access",
+				OAuthRefreshTokenKey: "refresh",
+				OAuthExpiresAtKey:    now.Add(time.Hour).Format(time.RFC3339),
+			},
+			wantOAuth: true,
+			wantToken: "access",
+		},
+		{
+			name: "access token expired",
+			data: map[string]string{
+				defaultTokenKey:      "access",
+				OAuthRefreshTokenKey: "refresh",
+				OAuthClientIDKey:     "client",
+				OAuthClientSecretKey: "secret",
+				OAuthExpiresAtKey:    now.Add(time.Minute).Format(time.RFC3339),
+			},
+			status:        http.StatusOK,
+			wantOAuth:     true,
+			wantToken:     "new-access",
+			wantRefreshed: true,
+		},
+		{
+			name: "unknown expiry",
+			data: map[string]string{
+				defaultTokenKey:      "access",
+				OAuthRefreshTokenKey: "refresh",
+			},
+			status:        http.StatusOK,
+			wantOAuth:     true,
+			wantToken:     "new-access",
+			wantRefreshed: true,
+		},
+		{
+			name: "refresh token revoked",
+			data: map[string]string{
+				defaultTokenKey:      "access",
+				OAuthRefreshTokenKey: "refresh",
+			},
+			status:    http.StatusBadRequest,
+			wantOAuth: true,
+			wantErr:   "cannot refresh the gitlab oauth token: 400 Bad Request",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, _ := rtesting.SetupFakeContext(t)
+			refreshed := false
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, r.URL.Path, "/oauth/token")
+				assert.NilError(t, r.ParseForm())
+				assert.Equal(t, r.PostForm.Get("grant_type"), "refresh_token")
+				assert.Equal(t, r.PostForm.Get("refresh_token"), "refresh")
+				assert.Equal(t, r.PostForm.Get("client_id"), tt.data[OAuthClientIDKey])
+				assert.Equal(t, r.PostForm.Get("client_secret"), tt.data[OAuthClientSecretKey])
+				refreshed = true
+				w.WriteHeader(tt.status)
+				fmt.Fprintf(w, `{"access_token": "new-access", "refresh_token": "new-refresh", "expires_in": 7200, "created_at": %d}`, now.Unix())
+			}))
+			defer server.Close()
+
+			data := map[string][]byte{}
+			for k, v := range tt.data {
+				data[k] = []byte(v)
+			}
+			kube := fake.NewSimpleClientset(&corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Name: "provider", Namespace: "ns"},
+				Data:       data,
+			})
+			repo := &v1alpha1.Repository{
+				ObjectMeta: metav1.ObjectMeta{Name: "repo", Namespace: "ns"},
+				Spec: v1alpha1.RepositorySpec{
+					GitProvider: &v1alpha1.GitProvider{Secret: &v1alpha1.Secret{Name: "provider"}},
+				},
+			}
+
+			token, isOAuth, err := oauthToken(ctx, kube, repo, server.URL, now)
+			assert.Equal(t, isOAuth, tt.wantOAuth)
+			assert.Equal(t, refreshed, tt.wantRefreshed || tt.wantErr != "")
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			assert.NilError(t, err)
+			assert.Equal(t, token, tt.wantToken)
+
+			secret, err := kube.CoreV1().Secrets("ns").Get(ctx, "provider", metav1.GetOptions{})
+			assert.NilError(t, err)
+			if tt.wantRefreshed {
+				assert.Equal(t, string(secret.Data[defaultTokenKey]), "new-access")
+				assert.Equal(t, string(secret.Data[OAuthRefreshTokenKey]), "new-refresh")
+				assert.Equal(t, string(secret.Data[OAuthExpiresAtKey]), now.Add(2*time.Hour).Format(time.RFC3339))
+			} else {
+				assert.DeepEqual(t, secret.Data, data)
+			}
+		})
+	}
+}
@@ -78,6 +78,18 @@ func (v *Provider) ParsePayload(ctx context.Context, run *params.Run, request *h
 		if gitEvent.ObjectAttributes.Action == "close" {
 			processedEvent.TriggerTarget = triggertype.PullRequestClosed
 		}
+		// An approval of a user is a review, the "approved" and "unapproved"
+		// actions sent when the approval rules are met are left out.
+		switch gitEvent.ObjectAttributes.Action {
+		case "approval":
+			processedEvent.EventType = triggertype.PullRequestReview.String()
+			processedEvent.ReviewState = "approved"
+			processedEvent.Reviewer = gitEvent.User.Username
+		case "unapproval":
+			processedEvent.EventType = triggertype.PullRequestReview.String()
+			processedEvent.ReviewState = "dismissed"
+			processedEvent.Reviewer = gitEvent.User.Username
+		}
 	case *gitlab.TagEvent:
 		// GitLab sends same event for both Tag creation and deletion i.e. "Tag Push Hook".
 		// if gitEvent.After is containing all zeros and gitEvent.CheckoutSHA is empty
@@ -123,6 +135,13 @@ func (v *Provider) ParsePayload(ctx context.Context, run *params.Run, request *h
 		processedEvent.SHA = gitEvent.Commits[lastCommitIdx].ID
 		processedEvent.SHAURL = gitEvent.Commits[lastCommitIdx].URL
 		processedEvent.SHATitle = gitEvent.Commits[lastCommitIdx].Title
+		for _, commit := range gitEvent.Commits {
+			processedEvent.PushCommits = append(processedEvent.PushCommits, info.Commit{
+				SHA:   commit.ID,
+				URL:   commit.URL,
+				Title: commit.Title,
+			})
+		}
 		processedEvent.HeadBranch = gitEvent.Ref
 		processedEvent.BaseBranch = gitEvent.Ref
 		processedEvent.HeadURL = gitEvent.Project.WebURL
@@ -282,15 +301,15 @@ func (v *Provider) handleCommitCommentEvent(ctx context.Context, event *gitlab.C
 		branchName = processedEvent.HeadBranch
 	}
 
-	// since we're going to make an API call to ensure that the commit is HEAD of the branch
+	// since we're going to make an API call to ensure that the commit is part of the branch
 	// therefore we need to initialize GitLab client here
 	processedEvent, err = v.initGitLabClient(ctx, processedEvent)
 	if err != nil {
 		return processedEvent, err
 	}
 
-	// check if the commit on which comment is made, is HEAD commit of the branch
-	if err := v.isHeadCommitOfBranch(processedEvent, branchName); err != nil {
+	// check if the commit on which comment is made, is part of the branch
+	if err := v.isCommitInBranch(processedEvent, branchName); err != nil {
 		if provider.IsCancelComment(event.ObjectAttributes.Note) {
 			processedEvent.CancelPipelineRuns = false
 		}
@@ -18,6 +18,7 @@ import (
 	thelp "github.com/openshift-pipelines/pipelines-as-code/pkg/provider/gitlab/test"
 	testclient "github.com/openshift-pipelines/pipelines-as-code/pkg/test/clients"
 	"github.com/openshift-pipelines/pipelines-as-code/pkg/test/logger"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/test/payloads"
 
 	gitlab "gitlab.com/gitlab-org/api/client-go"
 	"gotest.tools/v3/assert"
@@ -104,6 +105,36 @@ func TestParsePayload(t *testing.T) {
 				Repository:    "project",
 			},
 		},
+		{
+			name: "merge event approval",
+			args: args{
+				event:   gitlab.EventTypeMergeRequest,
+				payload: sample.MREventAsJSON("approval", ""),
+			},
+			want: &info.Event{
+				EventType:     triggertype.PullRequestReview.String(),
+				TriggerTarget: triggertype.PullRequest,
+				Organization:  "hello/this/is/me/ze",
+				Repository:    "project",
+				ReviewState:   "approved",
+				Reviewer:      "foo",
+			},
+		},
+		{
+			name: "merge event unapproval",
+			args: args{
+				event:   gitlab.EventTypeMergeRequest,
+				payload: sample.MREventAsJSON("unapproval", ""),
+			},
+			want: &info.Event{
+				EventType:     triggertype.PullRequestReview.String(),
+				TriggerTarget: triggertype.PullRequest,
+				Organization:  "hello/this/is/me/ze",
+				Repository:    "project",
+				ReviewState:   "dismissed",
+				Reviewer:      "foo",
+			},
+		},
 		{
 			name: "push event no commits",
 			args: args{
@@ -392,6 +423,8 @@ func TestParsePayload(t *testing.T) {
 				assert.Equal(t, tt.want.EventType, got.EventType)
 				assert.Equal(t, tt.want.Organization, got.Organization)
 				assert.Equal(t, tt.want.Repository, got.Repository)
+				assert.Equal(t, tt.want.ReviewState, got.ReviewState)
+				assert.Equal(t, tt.want.Reviewer, got.Reviewer)
 				if tt.want.TargetTestPipelineRun != "" {
 					assert.Equal(t, tt.want.TargetTestPipelineRun, got.TargetTestPipelineRun)
 				}
@@ -402,3 +435,26 @@ func TestParsePayload(t *testing.T) {
 		})
 	}
 }
+
+func TestParsePayloadFixtures(t *testing.T) {
+	fixtures, err := payloads.List("gitlab")
+	assert.NilError(t, err)
+	for _, fixture := range fixtures {
+		t.Run(fixture.Name, func(t *testing.T) {
+			ctx, _ := rtesting.SetupFakeContext(t)
+			logger, _ := logger.GetLogger()
+			run := &params.Run{Info: info.NewInfo()}
+			v := &Provider{
+				run:          run,
+				pacInfo:      &info.PacOpts{Settings: settings.Settings{ApplicationName: settings.PACApplicationNameDefaultValue}},
+				eventEmitter: events.NewEventEmitter(run.Clients.Kube, logger),
+				Logger:       logger,
+			}
+			request := &http.Request{Header: map[string][]string{}}
+			request.Header.Set("X-Gitlab-Event", fixture.Event)
+			got, err := v.ParsePayload(ctx, run, request, string(fixture.Payload))
+			assert.NilError(t, err)
+			assert.Assert(t, got.SHA != "")
+		})
+	}
+}
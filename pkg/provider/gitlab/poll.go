@@ -0,0 +1,115 @@
+package gitlab
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/params/info"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/params/triggertype"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/provider"
+	gitlab "gitlab.com/gitlab-org/api/client-go"
+)
+
+// GetPollEvents returns a push event for the head of each branch and, with
+// pullRequests, a pull_request event for the head of each open merge request
+// of the project of the event.
+func (v *Provider) GetPollEvents(_ context.Context, event *info.Event, branches []string, pullRequests bool) ([]*info.Event, error) {
+	if v.gitlabClient == nil {
+		return nil, fmt.Errorf("no gitlab client has been initialized")
+	}
+	projectID := event.TargetProjectID
+	if projectID == 0 {
+		projectID = v.sourceProjectID
+	}
+	defaultBranch := event.DefaultBranch
+	if defaultBranch == "" {
+		project, _, err := v.Client().Projects.GetProject(projectID, &gitlab.GetProjectOptions{})
+		if err != nil {
+			return nil, fmt.Errorf("cannot get the project %d: %w", projectID, err)
+		}
+		defaultBranch = project.DefaultBranch
+	}
+	if len(branches) == 0 {
+		branches = []string{defaultBranch}
+	}
+	newPollEvent := func() *info.Event {
+		ret := info.NewEvent()
+		ret.Organization = event.Organization
+		ret.Repository = event.Repository
+		ret.DefaultBranch = defaultBranch
+		// the URL of the Repository the events are matched to
+		ret.URL = event.URL
+		ret.BaseURL = ret.URL
+		ret.HeadURL = ret.URL
+		ret.SourceProjectID = projectID
+		ret.TargetProjectID = projectID
+		ret.Provider.Token = event.Provider.Token
+		ret.Provider.URL = event.Provider.URL
+		ret.Provider.User = event.Provider.User
+		return ret
+	}
+
+	ret := []*info.Event{}
+	for _, name := range branches {
+		branch, _, err := v.Client().Branches.GetBranch(projectID, name)
+		if err != nil {
+			return nil, fmt.Errorf("cannot get the branch %s of project %d: %w", name, projectID, err)
+		}
+		pushEvent := newPollEvent()
+		pushEvent.EventType = triggertype.Push.String()
+		pushEvent.TriggerTarget = triggertype.Push
+		if branch.Commit != nil {
+			pushEvent.SHA = branch.Commit.ID
+			pushEvent.SHAURL = branch.Commit.WebURL
+			pushEvent.SHATitle = branch.Commit.Title
+			pushEvent.Sender = branch.Commit.AuthorName
+		}
+		pushEvent.BaseBranch = "refs/heads/" + name
+		pushEvent.HeadBranch = pushEvent.BaseBranch
+		ret = append(ret, pushEvent)
+	}
+	if !pullRequests {
+		return ret, nil
+	}
+
+	// the web URLs of the projects the merge requests come from
+	sourceURLs := map[int]string{projectID: event.URL}
+	_, err := provider.Paginate(func(page int) ([]*gitlab.BasicMergeRequest, int, error) {
+		opt := &gitlab.ListProjectMergeRequestsOptions{
+			ListOptions: gitlab.ListOptions{Page: page, PerPage: 100},
+			State:       gitlab.Ptr("opened"),
+		}
+		mrs, resp, err := v.Client().MergeRequests.ListProjectMergeRequests(projectID, opt)
+		if err != nil {
+			return nil, 0, err
+		}
+		return mrs, resp.NextPage, nil
+	}, func(mr *gitlab.BasicMergeRequest) bool {
+		prEvent := newPollEvent()
+		prEvent.EventType = triggertype.PullRequest.String()
+		prEvent.TriggerTarget = triggertype.PullRequest
+		prEvent.Event = mr
+		prEvent.SHA = mr.SHA
+		prEvent.BaseBranch = mr.TargetBranch
+		prEvent.HeadBranch = mr.SourceBranch
+		prEvent.SourceProjectID = mr.SourceProjectID
+		if _, ok := sourceURLs[mr.SourceProjectID]; !ok {
+			if project, _, err := v.Client().Projects.GetProject(mr.SourceProjectID, &gitlab.GetProjectOptions{}); err == nil {
+				sourceURLs[mr.SourceProjectID] = project.WebURL
+			}
+		}
+		prEvent.HeadURL = sourceURLs[mr.SourceProjectID]
+		if mr.Author != nil {
+			prEvent.Sender = mr.Author.Username
+		}
+		prEvent.PullRequestNumber = mr.IID
+		prEvent.PullRequestTitle = mr.Title
+		prEvent.PullRequestLabel = append(prEvent.PullRequestLabel, mr.Labels...)
+		ret = append(ret, prEvent)
+		return false
+	})
+	if err != nil {
+		return nil, fmt.Errorf("cannot list the merge requests of project %d: %w", projectID, err)
+	}
+	return ret, nil
+}
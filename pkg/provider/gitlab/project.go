@@ -0,0 +1,96 @@
+package gitlab
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/apis/pipelinesascode/v1alpha1"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/generated/clientset/versioned"
+	gitlab "gitlab.com/gitlab-org/api/client-go"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/util/retry"
+)
+
+// projectPath returns the path with namespace of the GitLab project of
+// repoURL, ie: group/subgroup/subsubgroup/project. apiURL is the URL of the
+// GitLab instance, which may be served under a relative path that is not
+// part of the project path.
+func projectPath(repoURL, apiURL string) (string, error) {
+	u, err := url.Parse(repoURL)
+	if err != nil {
+		return "", err
+	}
+	projectPath := strings.Trim(u.Path, "/")
+	if a, err := url.Parse(apiURL); err == nil && a.Host == u.Host {
+		prefix := strings.TrimSuffix(strings.Trim(a.Path, "/"), "api/v4")
+		if prefix = strings.Trim(prefix, "/"); prefix != "" && strings.HasPrefix(projectPath, prefix+"/") {
+			projectPath = strings.TrimPrefix(projectPath, prefix+"/")
+		}
+	}
+	// strip the pages of the project, ie: /-/tree/main or /-/blob/main/file
+	if before, _, found := strings.Cut(projectPath, "/-/"); found {
+		projectPath = before
+	}
+	projectPath = strings.TrimSuffix(strings.TrimSuffix(projectPath, "/"), ".git")
+	if !strings.Contains(projectPath, "/") {
+		return "", fmt.Errorf("cannot find the project path of the GitLab URL %s", repoURL)
+	}
+	return projectPath, nil
+}
+
+// resolveProject returns the GitLab project of repo. The ID of the project
+// is resolved from its path with the API and cached on the status of the
+// repository, the path is only resolved again when the repository URL
+// changes or when the project has moved. fallbackPath is the project path
+// used when there is no repository.
+func (v *Provider) resolveProject(ctx context.Context, pacClient versioned.Interface, repo *v1alpha1.Repository, fallbackPath string) (*gitlab.Project, error) {
+	pPath := fallbackPath
+	if repo != nil && repo.Spec.URL != "" {
+		var err error
+		if pPath, err = projectPath(repo.Spec.URL, v.apiURL); err != nil {
+			return nil, err
+		}
+	}
+
+	if repo != nil && repo.RepositoryStatus != nil && repo.RepositoryStatus.GitLabProjectID > 0 &&
+		strings.EqualFold(repo.RepositoryStatus.GitLabProjectPath, pPath) {
+		project, _, err := v.Client().Projects.GetProject(repo.RepositoryStatus.GitLabProjectID, &gitlab.GetProjectOptions{}, gitlab.WithContext(ctx))
+		if err == nil && strings.EqualFold(project.PathWithNamespace, pPath) {
+			return project, nil
+		}
+	}
+
+	project, _, err := v.Client().Projects.GetProject(pPath, &gitlab.GetProjectOptions{}, gitlab.WithContext(ctx))
+	if err != nil {
+		return nil, fmt.Errorf("cannot get the GitLab project %s: %w", pPath, err)
+	}
+	if repo != nil && pacClient != nil {
+		if err := cacheProjectID(ctx, pacClient, repo, pPath, project.ID); err != nil && v.Logger != nil {
+			v.Logger.Warnf("cannot cache the GitLab project ID on repository %s/%s: %v", repo.GetNamespace(), repo.GetName(), err)
+		}
+	}
+	return project, nil
+}
+
+// cacheProjectID stores the ID of the GitLab project on the status of the
+// repository.
+func cacheProjectID(ctx context.Context, pacClient versioned.Interface, repo *v1alpha1.Repository, projectPath string, projectID int) error {
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		lastrepo, err := pacClient.PipelinesascodeV1alpha1().Repositories(repo.GetNamespace()).Get(ctx, repo.GetName(), metav1.GetOptions{})
+		if err != nil {
+			return err
+		}
+		if lastrepo.RepositoryStatus == nil {
+			lastrepo.RepositoryStatus = &v1alpha1.RepositoryStatus{}
+		}
+		if lastrepo.RepositoryStatus.GitLabProjectID == projectID && lastrepo.RepositoryStatus.GitLabProjectPath == projectPath {
+			return nil
+		}
+		lastrepo.RepositoryStatus.GitLabProjectID = projectID
+		lastrepo.RepositoryStatus.GitLabProjectPath = projectPath
+		_, err = pacClient.PipelinesascodeV1alpha1().Repositories(repo.GetNamespace()).UpdateStatus(ctx, lastrepo, metav1.UpdateOptions{})
+		return err
+	})
+}
@@ -0,0 +1,142 @@
+package gitlab
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/apis/pipelinesascode/v1alpha1"
+	thelp "github.com/openshift-pipelines/pipelines-as-code/pkg/provider/gitlab/test"
+	testclient "github.com/openshift-pipelines/pipelines-as-code/pkg/test/clients"
+	"gotest.tools/v3/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	rtesting "knative.dev/pkg/reconciler/testing"
+)
+
+func TestProjectPath(t *testing.T) {
+	tests := []struct {
+		name    string
+		repoURL string
+		apiURL  string
+		want    string
+		wantErr string
+	}{
+		{
+			name:    "project in a group",
+			repoURL: "https://gitlab.com/group/project",
+			apiURL:  "https://gitlab.com",
+			want:    "group/project",
+		},
+		{
+			name:    "project in nested subgroups",
+			repoURL: "https://gitlab.example.com/a/b/c/d/project",
+			apiURL:  "https://gitlab.example.com",
+			want:    "a/b/c/d/project",
+		},
+		{
+			name:    "instance under a relative path",
+			repoURL: "https://example.com/gitlab/a/b/c/project",
+			apiURL:  "https://example.com/gitlab/api/v4",
+			want:    "a/b/c/project",
+		},
+		{
+			name:    "git suffix and trailing slash",
+			repoURL: "https://gitlab.example.com/a/b/project.git/",
+			apiURL:  "https://gitlab.example.com",
+			want:    "a/b/project",
+		},
+		{
+			name:    "file of a project",
+			repoURL: "https://gitlab.example.com/a/b/c/project/-/raw/main/task.yaml",
+			apiURL:  "https://gitlab.example.com",
+			want:    "a/b/c/project",
+		},
+		{
+			name:    "no group",
+			repoURL: "https://gitlab.example.com/project",
+			apiURL:  "https://gitlab.example.com",
+			wantErr: "cannot find the project path of the GitLab URL https://gitlab.example.com/project",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := projectPath(tt.repoURL, tt.apiURL)
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			assert.NilError(t, err)
+			assert.Equal(t, got, tt.want)
+		})
+	}
+}
+
+func TestResolveProject(t *testing.T) {
+	tests := []struct {
+		name            string
+		status          *v1alpha1.RepositoryStatus
+		cachedProject   string
+		wantPathLookups int
+	}{
+		{
+			name:            "not cached",
+			wantPathLookups: 1,
+		},
+		{
+			name:          "cached",
+			status:        &v1alpha1.RepositoryStatus{GitLabProjectID: 42, GitLabProjectPath: "a/b/c/d/project"},
+			cachedProject: "a/b/c/d/project",
+		},
+		{
+			name:            "cached project has moved",
+			status:          &v1alpha1.RepositoryStatus{GitLabProjectID: 42, GitLabProjectPath: "a/b/c/d/project"},
+			cachedProject:   "a/b/other",
+			wantPathLookups: 1,
+		},
+		{
+			name:            "repository url has changed",
+			status:          &v1alpha1.RepositoryStatus{GitLabProjectID: 7, GitLabProjectPath: "a/b/old"},
+			wantPathLookups: 1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, _ := rtesting.SetupFakeContext(t)
+			client, mux, tearDown := thelp.Setup(t)
+			defer tearDown()
+
+			pathLookups := 0
+			mux.HandleFunc("/projects/", func(w http.ResponseWriter, r *http.Request) {
+				switch r.URL.EscapedPath() {
+				case "/projects/a%2Fb%2Fc%2Fd%2Fproject":
+					pathLookups++
+					fmt.Fprint(w, `{"id": 42, "path_with_namespace": "a/b/c/d/project", "default_branch": "main"}`)
+				case "/projects/42":
+					fmt.Fprintf(w, `{"id": 42, "path_with_namespace": %q, "default_branch": "main"}`, tt.cachedProject)
+				default:
+					w.WriteHeader(http.StatusNotFound)
+				}
+			})
+
+			repo := &v1alpha1.Repository{
+				ObjectMeta:       metav1.ObjectMeta{Name: "repo", Namespace: "ns"},
+				Spec:             v1alpha1.RepositorySpec{URL: "https://gitlab.example.com/a/b/c/d/project"},
+				RepositoryStatus: tt.status,
+			}
+			stdata, _ := testclient.SeedTestData(t, ctx, testclient.Data{Repositories: []*v1alpha1.Repository{repo}})
+
+			v := &Provider{gitlabClient: client, apiURL: "https://gitlab.example.com"}
+			project, err := v.resolveProject(ctx, stdata.PipelineAsCode, repo, "a/b/c/d")
+			assert.NilError(t, err)
+			assert.Equal(t, project.ID, 42)
+			assert.Equal(t, project.DefaultBranch, "main")
+			assert.Equal(t, pathLookups, tt.wantPathLookups)
+
+			got, err := stdata.PipelineAsCode.PipelinesascodeV1alpha1().Repositories("ns").Get(ctx, "repo", metav1.GetOptions{})
+			assert.NilError(t, err)
+			assert.Assert(t, got.RepositoryStatus != nil)
+			assert.Equal(t, got.RepositoryStatus.GitLabProjectID, 42)
+			assert.Equal(t, got.RepositoryStatus.GitLabProjectPath, "a/b/c/d/project")
+		})
+	}
+}
@@ -65,7 +65,7 @@ func extractGitLabInfo(gitlabURL string) (*gitLabInfo, error) {
 
 // GetTaskURI if we are getting a URL from the same URL where the provider is,
 // it means we can try to get the file with the provider token.
-func (v *Provider) GetTaskURI(ctx context.Context, event *info.Event, uri string) (bool, string, error) {
+func (v *Provider) GetTaskURI(_ context.Context, event *info.Event, uri string) (bool, string, error) {
 	if ret := provider.CompareHostOfURLS(uri, event.URL); !ret {
 		return false, "", nil
 	}
@@ -74,13 +74,14 @@ func (v *Provider) GetTaskURI(ctx context.Context, event *info.Event, uri string
 		return false, "", err
 	}
 
-	nEvent := info.NewEvent()
-	nEvent.Organization = extracted.GroupOrUser
-	nEvent.Repository = extracted.Repository
-	nEvent.BaseBranch = extracted.Revision
-	ret, err := v.GetFileInsideRepo(ctx, nEvent, extracted.FilePath, extracted.Revision)
+	// the project may be in nested subgroups, take its full path
+	pid, err := projectPath(uri, v.apiURL)
 	if err != nil {
 		return false, "", err
 	}
-	return true, ret, nil
+	ret, _, err := v.getObject(extracted.FilePath, extracted.Revision, pid)
+	if err != nil {
+		return false, "", err
+	}
+	return true, string(ret), nil
 }
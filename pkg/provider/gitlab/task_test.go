@@ -1,9 +1,14 @@
 package gitlab
 
 import (
+	"fmt"
+	"net/http"
 	"testing"
 
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/params/info"
+	thelp "github.com/openshift-pipelines/pipelines-as-code/pkg/provider/gitlab/test"
 	"gotest.tools/v3/assert"
+	rtesting "knative.dev/pkg/reconciler/testing"
 )
 
 func TestExtractGitLabInfo(t *testing.T) {
@@ -55,3 +60,23 @@ func TestExtractGitLabInfo(t *testing.T) {
 		})
 	}
 }
+
+func TestGetTaskURINestedSubgroups(t *testing.T) {
+	ctx, _ := rtesting.SetupFakeContext(t)
+	client, mux, tearDown := thelp.Setup(t)
+	defer tearDown()
+	mux.HandleFunc("/projects/", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.EscapedPath() != "/projects/a%2Fb%2Fc%2Fd%2Fproject/repository/files/tasks%2Ftask%2Eyaml/raw" || r.URL.Query().Get("ref") != "v1" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		fmt.Fprint(w, "task")
+	})
+
+	v := &Provider{gitlabClient: client, apiURL: "https://gitlab.example.com", sourceProjectID: 10}
+	event := &info.Event{URL: "https://gitlab.example.com/other/project", HeadBranch: "main"}
+	fetched, task, err := v.GetTaskURI(ctx, event, "https://gitlab.example.com/a/b/c/d/project/-/raw/v1/tasks/task.yaml")
+	assert.NilError(t, err)
+	assert.Assert(t, fetched)
+	assert.Equal(t, task, "task")
+}
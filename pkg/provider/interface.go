@@ -27,6 +27,14 @@ type StatusOpts struct {
 	AccessDenied             bool
 }
 
+// UserProfile is the profile of a user on the Git provider, the fields the
+// provider doesn't expose are empty.
+type UserProfile struct {
+	Email     string
+	FullName  string
+	AvatarURL string
+}
+
 type Interface interface {
 	SetLogger(*zap.SugaredLogger)
 	Validate(ctx context.Context, params *params.Run, event *info.Event) error
@@ -47,6 +55,11 @@ type Interface interface {
 	CheckPolicyAllowing(context.Context, *info.Event, []string) (bool, string)
 	GetTemplate(CommentType) string
 	CreateComment(ctx context.Context, event *info.Event, comment, updateMarker string) error
+	CreateLineComment(ctx context.Context, event *info.Event, path string, line int, comment string) error
+	GetCIVariables(ctx context.Context, event *info.Event, names []string) (map[string]string, error)
+	GetUserProfile(ctx context.Context, event *info.Event) (*UserProfile, error)
+	GetPollEvents(ctx context.Context, event *info.Event, branches []string, pullRequests bool) ([]*info.Event, error)
+	GetReviewDecision(ctx context.Context, event *info.Event) (string, error)
 }
 
 const DefaultProviderAPIUser = "git"
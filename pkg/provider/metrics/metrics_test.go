@@ -53,6 +53,10 @@ func TestRecordAPIUsage(t *testing.T) {
 					"pipelines_as_code_pipelinerun_duration_seconds_sum",
 					"pipelines_as_code_running_pipelineruns_count",
 					"pipelines_as_code_git_provider_api_request_count",
+					"pipelines_as_code_repository_invalid_provider_secret",
+					"pipelines_as_code_pipelinerun_timeout_count",
+					"pipelines_as_code_event_queue_depth",
+					"pipelines_as_code_repository_failure_count",
 				)
 				metrics.ResetRecorder()
 			}()
@@ -0,0 +1,28 @@
+package provider
+
+// Paginate calls list with the page numbers, starting with the first one and
+// following the next page it returns, until there is no next page or found
+// returns true for one of the items. It returns whether an item has been
+// found.
+//
+// list returns 0 as the next page on the last page, a next page which is not
+// after the current one is also considered as the last page so a provider
+// answering the same page again does not loop forever.
+func Paginate[T any](list func(page int) (items []T, nextPage int, err error), found func(item T) bool) (bool, error) {
+	page := 1
+	for {
+		items, nextPage, err := list(page)
+		if err != nil {
+			return false, err
+		}
+		for _, item := range items {
+			if found(item) {
+				return true, nil
+			}
+		}
+		if nextPage <= page {
+			return false, nil
+		}
+		page = nextPage
+	}
+}
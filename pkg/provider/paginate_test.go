@@ -0,0 +1,83 @@
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"gotest.tools/v3/assert"
+)
+
+func TestPaginate(t *testing.T) {
+	pages := map[int][]string{
+		1: {"a", "b"},
+		2: {"c", "d"},
+		3: {"e"},
+	}
+	tests := []struct {
+		name      string
+		search    string
+		nextPage  func(page int) int
+		failPage  int
+		want      bool
+		wantPages []int
+		wantErr   string
+	}{
+		{
+			name:      "found on the first page",
+			search:    "b",
+			want:      true,
+			wantPages: []int{1},
+		},
+		{
+			name:      "found on the last page",
+			search:    "e",
+			want:      true,
+			wantPages: []int{1, 2, 3},
+		},
+		{
+			name:      "not found",
+			search:    "z",
+			wantPages: []int{1, 2, 3},
+		},
+		{
+			name:      "next page going back",
+			search:    "z",
+			nextPage:  func(int) int { return 1 },
+			wantPages: []int{1},
+		},
+		{
+			name:      "error on a page",
+			search:    "e",
+			failPage:  2,
+			wantPages: []int{1, 2},
+			wantErr:   "cannot list page 2",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			listed := []int{}
+			found, err := Paginate(func(page int) ([]string, int, error) {
+				listed = append(listed, page)
+				if page == tt.failPage {
+					return nil, 0, fmt.Errorf("cannot list page %d", page)
+				}
+				nextPage := 0
+				if tt.nextPage != nil {
+					nextPage = tt.nextPage(page)
+				} else if _, ok := pages[page+1]; ok {
+					nextPage = page + 1
+				}
+				return pages[page], nextPage, nil
+			}, func(item string) bool {
+				return item == tt.search
+			})
+			if tt.wantErr != "" {
+				assert.Error(t, err, tt.wantErr)
+			} else {
+				assert.NilError(t, err)
+			}
+			assert.Equal(t, found, tt.want)
+			assert.DeepEqual(t, listed, tt.wantPages)
+		})
+	}
+}
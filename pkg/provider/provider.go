@@ -6,6 +6,9 @@ import (
 	"regexp"
 	"strings"
 
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/acl"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/apis/pipelinesascode/keys"
+	pacerrors "github.com/openshift-pipelines/pipelines-as-code/pkg/errors"
 	"github.com/openshift-pipelines/pipelines-as-code/pkg/formatting"
 	"github.com/openshift-pipelines/pipelines-as-code/pkg/params/info"
 	"gopkg.in/yaml.v2"
@@ -17,10 +20,26 @@ const ValidationErrorTemplate = `> [!CAUTION]
 | PipelineRun | Error |
 |------|-------|`
 
+const SuggestionTemplate = `> [!TIP]
+> Some annotations of your PipelineRun templates look misspelled.
+
+| PipelineRun | Suggestion |
+|------|-------|`
+
+const SecretScanTemplate = `> [!WARNING]
+> Some credentials look committed in your PipelineRuns or their tasks, store them in a Kubernetes Secret instead.
+
+| PipelineRun | Credential |
+|------|-------|`
+
+// SourceMarker starts the comment recording the file and the line a yaml
+// document of the .tekton directory comes from.
+const SourceMarker = "# pipelinesascode.tekton.dev/source: "
+
 var (
+	yamlDocSeparatorRe    = regexp.MustCompile(`(?m)^---\s*$`)
 	testRetestAllRegex    = regexp.MustCompile(`(?m)^(/retest|/test)\s*$`)
 	testRetestSingleRegex = regexp.MustCompile(`(?m)^(/test|/retest)[ \t]+\S+`)
-	oktotestRegex         = regexp.MustCompile(`(?m)^/ok-to-test\s*$`)
 	cancelAllRegex        = regexp.MustCompile(`(?m)^(/cancel)\s*$`)
 	cancelSingleRegex     = regexp.MustCompile(`(?m)^(/cancel)[ \t]+\S+`)
 )
@@ -81,7 +100,7 @@ func IsTestRetestComment(comment string) bool {
 }
 
 func IsOkToTestComment(comment string) bool {
-	return oktotestRegex.MatchString(comment)
+	return acl.IsOKToTestComment(comment)
 }
 
 func IsCancelComment(comment string) bool {
@@ -190,18 +209,53 @@ func CompareHostOfURLS(uri1, uri2 string) bool {
 func ValidateYaml(content []byte, filename string) error {
 	var validYaml any
 	if err := yaml.Unmarshal(content, &validYaml); err != nil {
-		return fmt.Errorf("error unmarshalling yaml file %s: %w", filename, err)
+		return pacerrors.NewYamlError(filename, string(content), err)
 	}
 	return nil
 }
 
+// AppendYamlFile appends the yaml documents of the file filename to
+// allTemplates. Each document is preceded by a SourceMarker comment with the
+// file and the line of the file before its first line, so errors found when
+// parsing them can be located.
+func AppendYamlFile(allTemplates, filename, data string) string {
+	start, line := 0, 0
+	separators := yamlDocSeparatorRe.FindAllStringIndex(data, -1)
+	for i := 0; i <= len(separators); i++ {
+		end := len(data)
+		if i < len(separators) {
+			end = separators[i][0]
+		}
+		doc := data[start:end]
+		if i == 0 {
+			// the other documents start at the end of their separator line
+			doc = "\n" + doc
+		}
+		if strings.TrimSpace(doc) != "" {
+			if allTemplates != "" {
+				allTemplates += "---"
+			}
+			allTemplates += fmt.Sprintf("\n%s%s:%d%s\n", SourceMarker, filename, line, strings.TrimRight(doc, "\n"))
+		}
+		if i < len(separators) {
+			start = separators[i][1]
+			line = strings.Count(data[:start], "\n") + 1
+		}
+	}
+	return allTemplates
+}
+
 // GetCheckName returns the name of the check to be created based on the status
 // and the pacopts.
 // If the pacopts.ApplicationName is set, it will be used as the check name.
 // Otherwise, the OriginalPipelineRunName will be used.
 // If the OriginalPipelineRunName is not set, an empty string will be returned.
 // The check name will be in the format "ApplicationName / OriginalPipelineRunName".
+// The status-context annotation of the PipelineRun overrides all of the above.
 func GetCheckName(status StatusOpts, pacopts *info.PacOpts) string {
+	if statusContext := GetStatusContext(status); statusContext != "" {
+		return statusContext
+	}
 	if pacopts.ApplicationName != "" {
 		if status.OriginalPipelineRunName == "" {
 			return pacopts.ApplicationName
@@ -211,6 +265,15 @@ func GetCheckName(status StatusOpts, pacopts *info.PacOpts) string {
 	return status.OriginalPipelineRunName
 }
 
+// GetStatusContext returns the status context name set on the PipelineRun of
+// the status with the status-context annotation, or an empty string.
+func GetStatusContext(status StatusOpts) string {
+	if status.PipelineRun == nil {
+		return ""
+	}
+	return strings.TrimSpace(status.PipelineRun.GetAnnotations()[keys.StatusContext])
+}
+
 func IsZeroSHA(sha string) bool {
 	return sha == "0000000000000000000000000000000000000000"
 }
@@ -1,11 +1,16 @@
 package provider
 
 import (
+	"errors"
 	"testing"
 
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/apis/pipelinesascode/keys"
+	pacerrors "github.com/openshift-pipelines/pipelines-as-code/pkg/errors"
 	"github.com/openshift-pipelines/pipelines-as-code/pkg/params/info"
 	"github.com/openshift-pipelines/pipelines-as-code/pkg/params/settings"
+	tektonv1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
 	"gotest.tools/v3/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 func TestIsOkToTestComment(t *testing.T) {
@@ -551,6 +556,32 @@ func TestGetCheckName(t *testing.T) {
 			},
 			want: "PAC",
 		},
+		{
+			name: "status context annotation",
+			args: args{
+				status: StatusOpts{
+					OriginalPipelineRunName: "MOTO",
+					PipelineRun: &tektonv1.PipelineRun{
+						ObjectMeta: metav1.ObjectMeta{
+							Annotations: map[string]string{keys.StatusContext: "ci/build"},
+						},
+					},
+				},
+				pacopts: &info.PacOpts{Settings: settings.Settings{ApplicationName: "PAC"}},
+			},
+			want: "ci/build",
+		},
+		{
+			name: "pipelinerun without status context annotation",
+			args: args{
+				status: StatusOpts{
+					OriginalPipelineRunName: "MOTO",
+					PipelineRun:             &tektonv1.PipelineRun{},
+				},
+				pacopts: &info.PacOpts{Settings: settings.Settings{ApplicationName: "PAC"}},
+			},
+			want: "PAC / MOTO",
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -560,3 +591,24 @@ func TestGetCheckName(t *testing.T) {
 		})
 	}
 }
+
+func TestValidateYaml(t *testing.T) {
+	err := ValidateYaml([]byte("kind: PipelineRun\nmetadata:\n  name foo\n  namespace: bar\n"), ".tekton/pr.yaml")
+	assert.ErrorContains(t, err, "error unmarshalling yaml file .tekton/pr.yaml: yaml: line 4")
+
+	var yamlErr *pacerrors.YamlError
+	assert.Assert(t, errors.As(err, &yamlErr))
+	assert.Equal(t, yamlErr.Position.String(), ".tekton/pr.yaml:4:3")
+	assert.Equal(t, yamlErr.Position.Snippet, "2 | metadata:\n3 |   name foo\n4 |   namespace: bar\n  |   ^")
+
+	assert.NilError(t, ValidateYaml([]byte("kind: PipelineRun\n"), ".tekton/pr.yaml"))
+}
+
+func TestAppendYamlFile(t *testing.T) {
+	allTemplates := AppendYamlFile("", "a.yaml", "kind: Task\n---\n\nkind: Pipeline\n")
+	allTemplates = AppendYamlFile(allTemplates, "b.yaml", "---\nkind: PipelineRun\n---\n")
+	assert.Equal(t, allTemplates,
+		"\n# pipelinesascode.tekton.dev/source: a.yaml:0\nkind: Task\n"+
+			"---\n# pipelinesascode.tekton.dev/source: a.yaml:3\nkind: Pipeline\n"+
+			"---\n# pipelinesascode.tekton.dev/source: b.yaml:1\nkind: PipelineRun\n")
+}
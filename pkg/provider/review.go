@@ -0,0 +1,36 @@
+package provider
+
+// The review decisions of a pull request, it is empty when the pull request
+// has neither been approved nor had changes requested.
+const (
+	ReviewDecisionApproved         = "approved"
+	ReviewDecisionChangesRequested = "changes_requested"
+)
+
+// Review is the review of a pull request by a reviewer, its state is one of
+// the review decisions or empty for the reviews only commenting.
+type Review struct {
+	Reviewer string
+	State    string
+}
+
+// ReviewDecision returns the decision of the reviews of a pull request, given
+// in the order they have been submitted. Only the last approval or request
+// for changes of each reviewer counts and a single request for changes wins
+// over all the approvals.
+func ReviewDecision(reviews []Review) string {
+	latest := map[string]string{}
+	for _, review := range reviews {
+		if review.State != "" {
+			latest[review.Reviewer] = review.State
+		}
+	}
+	decision := ""
+	for _, state := range latest {
+		if state == ReviewDecisionChangesRequested {
+			return ReviewDecisionChangesRequested
+		}
+		decision = ReviewDecisionApproved
+	}
+	return decision
+}
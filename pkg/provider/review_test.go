@@ -0,0 +1,50 @@
+package provider
+
+import (
+	"testing"
+
+	"gotest.tools/v3/assert"
+)
+
+func TestReviewDecision(t *testing.T) {
+	tests := []struct {
+		name    string
+		reviews []Review
+		want    string
+	}{
+		{
+			name: "no reviews",
+		},
+		{
+			name:    "only comments",
+			reviews: []Review{{Reviewer: "alice"}},
+		},
+		{
+			name:    "approved",
+			reviews: []Review{{Reviewer: "alice", State: ReviewDecisionApproved}, {Reviewer: "bob"}},
+			want:    ReviewDecisionApproved,
+		},
+		{
+			name: "changes requested wins",
+			reviews: []Review{
+				{Reviewer: "alice", State: ReviewDecisionApproved},
+				{Reviewer: "bob", State: ReviewDecisionChangesRequested},
+			},
+			want: ReviewDecisionChangesRequested,
+		},
+		{
+			name: "approved after requesting changes",
+			reviews: []Review{
+				{Reviewer: "bob", State: ReviewDecisionChangesRequested},
+				{Reviewer: "bob"},
+				{Reviewer: "bob", State: ReviewDecisionApproved},
+			},
+			want: ReviewDecisionApproved,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, ReviewDecision(tt.reviews), tt.want)
+		})
+	}
+}
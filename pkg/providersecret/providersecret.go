@@ -0,0 +1,216 @@
+package providersecret
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/apis/pipelinesascode/v1alpha1"
+)
+
+const (
+	githubPublicAPIURL     = "https://api.github.com"
+	gitlabPublicAPIURL     = "https://gitlab.com"
+	bitbucketCloudAPIURL   = "https://api.bitbucket.org/2.0"
+	githubExpirationLayout = "2006-01-02 15:04:05 MST"
+)
+
+// ErrInvalid is wrapped by the errors of Validate when the token has been
+// revoked, has expired or is missing a scope, as opposed to the errors
+// preventing the validation.
+var ErrInvalid = errors.New("invalid provider token")
+
+// Result is what is known about a valid token.
+type Result struct {
+	// ExpiresAt is when the token expires, nil when it doesn't or when the
+	// git provider doesn't tell.
+	ExpiresAt *time.Time
+}
+
+// ProviderType returns the git provider type of repo, inferred from the host
+// of its URL when git_provider.type is not set, or an empty string when it
+// cannot be validated.
+func ProviderType(repo *v1alpha1.Repository) string {
+	if repo.Spec.GitProvider != nil && repo.Spec.GitProvider.Type != "" {
+		switch repo.Spec.GitProvider.Type {
+		case "github", "gitlab", "gitea", "bitbucket-cloud", "bitbucket-datacenter":
+			return repo.Spec.GitProvider.Type
+		}
+		return ""
+	}
+	repoURL, err := url.Parse(repo.Spec.URL)
+	if err != nil {
+		return ""
+	}
+	switch repoURL.Host {
+	case "github.com":
+		return "github"
+	case "gitlab.com":
+		return "gitlab"
+	case "bitbucket.org":
+		return "bitbucket-cloud"
+	}
+	return ""
+}
+
+// Validate checks the token of a Repository against the API of its git
+// provider. apiURL is the git_provider.url of the Repository and defaults to
+// the public instance of the provider.
+func Validate(ctx context.Context, providerType, apiURL, user, token string) (*Result, error) {
+	if token == "" {
+		return nil, fmt.Errorf("%w: the token is empty", ErrInvalid)
+	}
+	apiURL = strings.TrimSuffix(apiURL, "/")
+	switch providerType {
+	case "github":
+		return validateGitHub(ctx, apiURL, token)
+	case "gitlab":
+		return validateGitLab(ctx, apiURL, token)
+	case "gitea":
+		return validateGitea(ctx, apiURL, token)
+	case "bitbucket-cloud":
+		return validateBitbucketCloud(ctx, apiURL, user, token)
+	case "bitbucket-datacenter":
+		return validateBitbucketDataCenter(ctx, apiURL, user, token)
+	}
+	return nil, fmt.Errorf("cannot validate the token of git provider type %q", providerType)
+}
+
+// get does a GET on the API and returns the response when the token has been
+// accepted.
+func get(ctx context.Context, rawURL string, auth func(*http.Request)) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, rawURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	auth(req)
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	switch {
+	case resp.StatusCode == http.StatusUnauthorized:
+		resp.Body.Close()
+		return nil, fmt.Errorf("%w: the token has been revoked or has expired", ErrInvalid)
+	case resp.StatusCode >= 300:
+		resp.Body.Close()
+		return nil, fmt.Errorf("cannot validate the token on %s: %s", rawURL, resp.Status)
+	}
+	return resp, nil
+}
+
+func validateGitHub(ctx context.Context, apiURL, token string) (*Result, error) {
+	if apiURL == "" {
+		apiURL = githubPublicAPIURL
+	}
+	resp, err := get(ctx, apiURL+"/user", func(req *http.Request) {
+		req.Header.Set("Authorization", "Bearer "+token)
+	})
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	// only classic tokens have scopes, fine-grained ones are limited to
+	// repositories instead
+	if scopes := resp.Header.Values("X-OAuth-Scopes"); scopes != nil {
+		list := strings.Split(strings.ReplaceAll(strings.Join(scopes, ","), " ", ""), ",")
+		if !slices.Contains(list, "repo") && !slices.Contains(list, "public_repo") {
+			return nil, fmt.Errorf("%w: the token is missing the repo scope", ErrInvalid)
+		}
+	}
+	result := &Result{}
+	if expiration := resp.Header.Get("GitHub-Authentication-Token-Expiration"); expiration != "" {
+		if expiresAt, err := time.Parse(githubExpirationLayout, expiration); err == nil {
+			result.ExpiresAt = &expiresAt
+		}
+	}
+	return result, nil
+}
+
+type gitlabToken struct {
+	Active    bool     `json:"active"`
+	Revoked   bool     `json:"revoked"`
+	Scopes    []string `json:"scopes"`
+	ExpiresAt string   `json:"expires_at"`
+}
+
+func validateGitLab(ctx context.Context, apiURL, token string) (*Result, error) {
+	if apiURL == "" {
+		apiURL = gitlabPublicAPIURL
+	}
+	resp, err := get(ctx, apiURL+"/api/v4/personal_access_tokens/self", func(req *http.Request) {
+		req.Header.Set("PRIVATE-TOKEN", token)
+	})
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	pat := &gitlabToken{}
+	if err := json.NewDecoder(resp.Body).Decode(pat); err != nil {
+		return nil, err
+	}
+	if pat.Revoked || !pat.Active {
+		return nil, fmt.Errorf("%w: the token has been revoked or has expired", ErrInvalid)
+	}
+	if !slices.Contains(pat.Scopes, "api") {
+		return nil, fmt.Errorf("%w: the token is missing the api scope", ErrInvalid)
+	}
+	result := &Result{}
+	if pat.ExpiresAt != "" {
+		if expiresAt, err := time.Parse(time.DateOnly, pat.ExpiresAt); err == nil {
+			result.ExpiresAt = &expiresAt
+		}
+	}
+	return result, nil
+}
+
+func validateGitea(ctx context.Context, apiURL, token string) (*Result, error) {
+	if apiURL == "" {
+		return nil, fmt.Errorf("cannot validate the token, no git_provider.url is set")
+	}
+	resp, err := get(ctx, apiURL+"/api/v1/user", func(req *http.Request) {
+		req.Header.Set("Authorization", "token "+token)
+	})
+	if err != nil {
+		return nil, err
+	}
+	resp.Body.Close()
+	return &Result{}, nil
+}
+
+func validateBitbucketCloud(ctx context.Context, apiURL, user, token string) (*Result, error) {
+	if apiURL == "" {
+		apiURL = bitbucketCloudAPIURL
+	}
+	resp, err := get(ctx, apiURL+"/user", func(req *http.Request) {
+		req.SetBasicAuth(user, token)
+	})
+	if err != nil {
+		return nil, err
+	}
+	resp.Body.Close()
+	return &Result{}, nil
+}
+
+func validateBitbucketDataCenter(ctx context.Context, apiURL, user, token string) (*Result, error) {
+	if apiURL == "" {
+		return nil, fmt.Errorf("cannot validate the token, no git_provider.url is set")
+	}
+	apiURL = strings.TrimSuffix(apiURL, "/rest")
+	resp, err := get(ctx, apiURL+"/rest/api/1.0/users/"+url.PathEscape(user), func(req *http.Request) {
+		req.Header.Set("Authorization", "Bearer "+token)
+	})
+	if err != nil {
+		return nil, err
+	}
+	resp.Body.Close()
+	return &Result{}, nil
+}
@@ -0,0 +1,219 @@
+package providersecret
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/apis/pipelinesascode/v1alpha1"
+	"gotest.tools/v3/assert"
+	rtesting "knative.dev/pkg/reconciler/testing"
+)
+
+func TestProviderType(t *testing.T) {
+	tests := []struct {
+		name        string
+		url         string
+		gitProvider *v1alpha1.GitProvider
+		want        string
+	}{
+		{
+			name:        "from git_provider type",
+			url:         "https://forge.example.com/owner/repo",
+			gitProvider: &v1alpha1.GitProvider{Type: "gitea"},
+			want:        "gitea",
+		},
+		{
+			name:        "unknown git_provider type",
+			url:         "https://github.com/owner/repo",
+			gitProvider: &v1alpha1.GitProvider{Type: "forge"},
+			want:        "",
+		},
+		{
+			name: "from github url",
+			url:  "https://github.com/owner/repo",
+			want: "github",
+		},
+		{
+			name: "from bitbucket url",
+			url:  "https://bitbucket.org/owner/repo",
+			want: "bitbucket-cloud",
+		},
+		{
+			name: "unknown host",
+			url:  "https://forge.example.com/owner/repo",
+			want: "",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &v1alpha1.Repository{Spec: v1alpha1.RepositorySpec{URL: tt.url, GitProvider: tt.gitProvider}}
+			assert.Equal(t, ProviderType(repo), tt.want)
+		})
+	}
+}
+
+func TestValidate(t *testing.T) {
+	expiresAt := time.Date(2030, 1, 2, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name          string
+		providerType  string
+		user          string
+		token         string
+		path          string
+		handler       http.HandlerFunc
+		wantExpiresAt *time.Time
+		wantInvalid   string
+		wantErr       string
+	}{
+		{
+			name:         "github valid",
+			providerType: "github",
+			token:        "token",
+			path:         "/user",
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, r.Header.Get("Authorization"), "Bearer token")
+				w.Header().Set("X-OAuth-Scopes", "read:org, repo")
+				w.Header().Set("GitHub-Authentication-Token-Expiration", "2030-01-02 00:00:00 UTC")
+				fmt.Fprint(w, `{}`)
+			},
+			wantExpiresAt: &expiresAt,
+		},
+		{
+			name:         "github missing scope",
+			providerType: "github",
+			token:        "token",
+			path:         "/user",
+			handler: func(w http.ResponseWriter, _ *http.Request) {
+				w.Header().Set("X-OAuth-Scopes", "read:org")
+				fmt.Fprint(w, `{}`)
+			},
+			wantInvalid: "missing the repo scope",
+		},
+		{
+			name:         "github revoked",
+			providerType: "github",
+			token:        "token",
+			path:         "/user",
+			handler: func(w http.ResponseWriter, _ *http.Request) {
+				w.WriteHeader(http.StatusUnauthorized)
+			},
+			wantInvalid: "revoked or has expired",
+		},
+		{
+			name:         "gitlab valid",
+			providerType: "gitlab",
+			token:        "token",
+			path:         "/api/v4/personal_access_tokens/self",
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, r.Header.Get("PRIVATE-TOKEN"), "token")
+				fmt.Fprint(w, `{"active": true, "scopes": ["api"], "expires_at": "2030-01-02"}`)
+			},
+			wantExpiresAt: &expiresAt,
+		},
+		{
+			name:         "gitlab missing scope",
+			providerType: "gitlab",
+			token:        "token",
+			path:         "/api/v4/personal_access_tokens/self",
+			handler: func(w http.ResponseWriter, _ *http.Request) {
+				fmt.Fprint(w, `{"active": true, "scopes": ["read_api"]}`)
+			},
+			wantInvalid: "missing the api scope",
+		},
+		{
+			name:         "gitlab revoked",
+			providerType: "gitlab",
+			token:        "token",
+			path:         "/api/v4/personal_access_tokens/self",
+			handler: func(w http.ResponseWriter, _ *http.Request) {
+				fmt.Fprint(w, `{"active": false, "revoked": true, "scopes": ["api"]}`)
+			},
+			wantInvalid: "revoked or has expired",
+		},
+		{
+			name:         "gitea valid",
+			providerType: "gitea",
+			token:        "token",
+			path:         "/api/v1/user",
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, r.Header.Get("Authorization"), "token token")
+				fmt.Fprint(w, `{}`)
+			},
+		},
+		{
+			name:         "bitbucket cloud valid",
+			providerType: "bitbucket-cloud",
+			user:         "user",
+			token:        "token",
+			path:         "/user",
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				user, password, ok := r.BasicAuth()
+				assert.Assert(t, ok)
+				assert.Equal(t, user, "user")
+				assert.Equal(t, password, "token")
+				fmt.Fprint(w, `{}`)
+			},
+		},
+		{
+			name:         "bitbucket datacenter revoked",
+			providerType: "bitbucket-datacenter",
+			user:         "user",
+			token:        "token",
+			path:         "/rest/api/1.0/users/user",
+			handler: func(w http.ResponseWriter, _ *http.Request) {
+				w.WriteHeader(http.StatusUnauthorized)
+			},
+			wantInvalid: "revoked or has expired",
+		},
+		{
+			name:         "server error",
+			providerType: "gitea",
+			token:        "token",
+			path:         "/api/v1/user",
+			handler: func(w http.ResponseWriter, _ *http.Request) {
+				w.WriteHeader(http.StatusBadGateway)
+			},
+			wantErr: "502 Bad Gateway",
+		},
+		{
+			name:         "empty token",
+			providerType: "gitea",
+			wantInvalid:  "the token is empty",
+		},
+		{
+			name:         "unsupported provider",
+			providerType: "forge",
+			token:        "token",
+			wantErr:      "cannot validate the token of git provider type \"forge\"",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, _ := rtesting.SetupFakeContext(t)
+			mux := http.NewServeMux()
+			if tt.handler != nil {
+				mux.HandleFunc(tt.path, tt.handler)
+			}
+			server := httptest.NewServer(mux)
+			defer server.Close()
+
+			result, err := Validate(ctx, tt.providerType, server.URL, tt.user, tt.token)
+			if tt.wantInvalid != "" {
+				assert.Assert(t, errors.Is(err, ErrInvalid))
+				assert.ErrorContains(t, err, tt.wantInvalid)
+				return
+			}
+			if tt.wantErr != "" {
+				assert.Assert(t, !errors.Is(err, ErrInvalid))
+				assert.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			assert.NilError(t, err)
+			assert.DeepEqual(t, result.ExpiresAt, tt.wantExpiresAt)
+		})
+	}
+}
@@ -0,0 +1,244 @@
+package providerwebhook
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"code.gitea.io/sdk/gitea"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/apis/pipelinesascode/v1alpha1"
+	gitlab "gitlab.com/gitlab-org/api/client-go"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+const (
+	defaultTokenKey         = "provider.token"
+	defaultWebhookSecretKey = "webhook.secret"
+)
+
+// IsSupported returns whether the webhook of repo can be configured on its
+// git provider.
+func IsSupported(repo *v1alpha1.Repository) bool {
+	if repo.Spec.GitProvider == nil {
+		return false
+	}
+	switch repo.Spec.GitProvider.Type {
+	case "gitea", "gitlab":
+		return true
+	}
+	return false
+}
+
+// hook is the webhook of a Repository on its git provider.
+type hook struct {
+	project       string
+	controllerURL string
+	secret        string
+}
+
+// Configure creates the webhook of repo on its Gitea or GitLab project
+// pointing at controllerURL, or updates the one already pointing there, and
+// returns its ID.
+func Configure(ctx context.Context, kube kubernetes.Interface, repo *v1alpha1.Repository, controllerURL string) (string, error) {
+	apiURL, token, h, err := getHook(ctx, kube, repo)
+	if err != nil {
+		return "", err
+	}
+	h.controllerURL = controllerURL
+	if repo.Spec.GitProvider.WebhookSecret != nil {
+		if h.secret, err = getSecretValue(ctx, kube, repo.GetNamespace(), repo.Spec.GitProvider.WebhookSecret, defaultWebhookSecretKey); err != nil {
+			return "", err
+		}
+	}
+	switch repo.Spec.GitProvider.Type {
+	case "gitea":
+		return configureGitea(ctx, apiURL, token, h)
+	case "gitlab":
+		return configureGitLab(ctx, apiURL, token, h)
+	}
+	return "", fmt.Errorf("cannot configure the webhook of git provider type %q", repo.Spec.GitProvider.Type)
+}
+
+// Delete removes the webhook id of repo from its Gitea or GitLab project.
+func Delete(ctx context.Context, kube kubernetes.Interface, repo *v1alpha1.Repository, id string) error {
+	apiURL, token, h, err := getHook(ctx, kube, repo)
+	if err != nil {
+		return err
+	}
+	switch repo.Spec.GitProvider.Type {
+	case "gitea":
+		return deleteGitea(ctx, apiURL, token, h, id)
+	case "gitlab":
+		return deleteGitLab(ctx, apiURL, token, h, id)
+	}
+	return fmt.Errorf("cannot delete the webhook of git provider type %q", repo.Spec.GitProvider.Type)
+}
+
+// getHook returns the API URL, the token and the project of the webhook of
+// repo, the API URL defaults to the host of the repository URL.
+func getHook(ctx context.Context, kube kubernetes.Interface, repo *v1alpha1.Repository) (string, string, hook, error) {
+	if !IsSupported(repo) {
+		return "", "", hook{}, fmt.Errorf("repository %s has no gitea or gitlab git_provider", repo.GetName())
+	}
+	if repo.Spec.GitProvider.Secret == nil {
+		return "", "", hook{}, fmt.Errorf("repository %s has no git_provider secret", repo.GetName())
+	}
+	repoURL, err := url.Parse(repo.Spec.URL)
+	if err != nil || repoURL.Host == "" {
+		return "", "", hook{}, fmt.Errorf("invalid url %q for repository %s", repo.Spec.URL, repo.GetName())
+	}
+	apiURL := repo.Spec.GitProvider.URL
+	if apiURL == "" {
+		apiURL = fmt.Sprintf("%s://%s", repoURL.Scheme, repoURL.Host)
+	}
+	token, err := getSecretValue(ctx, kube, repo.GetNamespace(), repo.Spec.GitProvider.Secret, defaultTokenKey)
+	if err != nil {
+		return "", "", hook{}, err
+	}
+	return apiURL, token, hook{project: strings.Trim(repoURL.Path, "/")}, nil
+}
+
+func getSecretValue(ctx context.Context, kube kubernetes.Interface, ns string, secretRef *v1alpha1.Secret, defaultKey string) (string, error) {
+	key := secretRef.Key
+	if key == "" {
+		key = defaultKey
+	}
+	secret, err := kube.CoreV1().Secrets(ns).Get(ctx, secretRef.Name, metav1.GetOptions{})
+	if err != nil {
+		return "", fmt.Errorf("cannot get secret %s: %w", secretRef.Name, err)
+	}
+	value, ok := secret.Data[key]
+	if !ok {
+		return "", fmt.Errorf("secret %s has no key %s", secretRef.Name, key)
+	}
+	return strings.TrimSpace(string(value)), nil
+}
+
+func newGiteaClient(ctx context.Context, apiURL, token string) (*gitea.Client, error) {
+	return gitea.NewClient(apiURL, gitea.SetToken(token), gitea.SetContext(ctx))
+}
+
+func configureGitea(ctx context.Context, apiURL, token string, h hook) (string, error) {
+	client, err := newGiteaClient(ctx, apiURL, token)
+	if err != nil {
+		return "", err
+	}
+	owner, repo, ok := strings.Cut(h.project, "/")
+	if !ok {
+		return "", fmt.Errorf("invalid gitea repository %s", h.project)
+	}
+	config := map[string]string{
+		"url":          h.controllerURL,
+		"content_type": "json",
+		"secret":       h.secret,
+	}
+	events := []string{"push", "pull_request", "issue_comment"}
+
+	hooks, _, err := client.ListRepoHooks(owner, repo, gitea.ListHooksOptions{})
+	if err != nil {
+		return "", fmt.Errorf("cannot list the webhooks of %s: %w", h.project, err)
+	}
+	for _, existing := range hooks {
+		if existing.Config["url"] != h.controllerURL {
+			continue
+		}
+		if _, err := client.EditRepoHook(owner, repo, existing.ID, gitea.EditHookOption{
+			Config: config,
+			Events: events,
+			Active: gitea.OptionalBool(true),
+		}); err != nil {
+			return "", fmt.Errorf("cannot update the webhook of %s: %w", h.project, err)
+		}
+		return strconv.FormatInt(existing.ID, 10), nil
+	}
+
+	created, _, err := client.CreateRepoHook(owner, repo, gitea.CreateHookOption{
+		Type:   gitea.HookTypeGitea,
+		Config: config,
+		Events: events,
+		Active: true,
+	})
+	if err != nil {
+		return "", fmt.Errorf("cannot create the webhook of %s: %w", h.project, err)
+	}
+	return strconv.FormatInt(created.ID, 10), nil
+}
+
+func deleteGitea(ctx context.Context, apiURL, token string, h hook, id string) error {
+	hookID, err := strconv.ParseInt(id, 10, 64)
+	if err != nil {
+		return fmt.Errorf("invalid webhook id %s: %w", id, err)
+	}
+	client, err := newGiteaClient(ctx, apiURL, token)
+	if err != nil {
+		return err
+	}
+	owner, repo, ok := strings.Cut(h.project, "/")
+	if !ok {
+		return fmt.Errorf("invalid gitea repository %s", h.project)
+	}
+	if _, err := client.DeleteRepoHook(owner, repo, hookID); err != nil {
+		return fmt.Errorf("cannot delete the webhook of %s: %w", h.project, err)
+	}
+	return nil
+}
+
+func configureGitLab(ctx context.Context, apiURL, token string, h hook) (string, error) {
+	client, err := gitlab.NewClient(token, gitlab.WithBaseURL(apiURL))
+	if err != nil {
+		return "", err
+	}
+	hooks, _, err := client.Projects.ListProjectHooks(h.project, &gitlab.ListProjectHooksOptions{}, gitlab.WithContext(ctx))
+	if err != nil {
+		return "", fmt.Errorf("cannot list the webhooks of %s: %w", h.project, err)
+	}
+	for _, existing := range hooks {
+		if existing.URL != h.controllerURL {
+			continue
+		}
+		if _, _, err := client.Projects.EditProjectHook(h.project, existing.ID, &gitlab.EditProjectHookOptions{
+			EnableSSLVerification: gitlab.Ptr(true),
+			MergeRequestsEvents:   gitlab.Ptr(true),
+			NoteEvents:            gitlab.Ptr(true),
+			PushEvents:            gitlab.Ptr(true),
+			TagPushEvents:         gitlab.Ptr(true),
+			Token:                 gitlab.Ptr(h.secret),
+			URL:                   gitlab.Ptr(h.controllerURL),
+		}, gitlab.WithContext(ctx)); err != nil {
+			return "", fmt.Errorf("cannot update the webhook of %s: %w", h.project, err)
+		}
+		return strconv.Itoa(existing.ID), nil
+	}
+
+	created, _, err := client.Projects.AddProjectHook(h.project, &gitlab.AddProjectHookOptions{
+		EnableSSLVerification: gitlab.Ptr(true),
+		MergeRequestsEvents:   gitlab.Ptr(true),
+		NoteEvents:            gitlab.Ptr(true),
+		PushEvents:            gitlab.Ptr(true),
+		TagPushEvents:         gitlab.Ptr(true),
+		Token:                 gitlab.Ptr(h.secret),
+		URL:                   gitlab.Ptr(h.controllerURL),
+	}, gitlab.WithContext(ctx))
+	if err != nil {
+		return "", fmt.Errorf("cannot create the webhook of %s: %w", h.project, err)
+	}
+	return strconv.Itoa(created.ID), nil
+}
+
+func deleteGitLab(ctx context.Context, apiURL, token string, h hook, id string) error {
+	hookID, err := strconv.Atoi(id)
+	if err != nil {
+		return fmt.Errorf("invalid webhook id %s: %w", id, err)
+	}
+	client, err := gitlab.NewClient(token, gitlab.WithBaseURL(apiURL))
+	if err != nil {
+		return err
+	}
+	if _, err := client.Projects.DeleteProjectHook(h.project, hookID, gitlab.WithContext(ctx)); err != nil {
+		return fmt.Errorf("cannot delete the webhook of %s: %w", h.project, err)
+	}
+	return nil
+}
@@ -0,0 +1,221 @@
+package providerwebhook
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/apis/pipelinesascode/v1alpha1"
+	"gotest.tools/v3/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+	rtesting "knative.dev/pkg/reconciler/testing"
+)
+
+const controllerURL = "https://pac.example.com"
+
+func makeRepo(providerType, apiURL string) *v1alpha1.Repository {
+	return &v1alpha1.Repository{
+		ObjectMeta: metav1.ObjectMeta{Name: "repo", Namespace: "ns"},
+		Spec: v1alpha1.RepositorySpec{
+			URL: "https://forge.example.com/owner/repo",
+			GitProvider: &v1alpha1.GitProvider{
+				Type:          providerType,
+				URL:           apiURL,
+				Secret:        &v1alpha1.Secret{Name: "provider"},
+				WebhookSecret: &v1alpha1.Secret{Name: "provider"},
+			},
+		},
+	}
+}
+
+func makeKube() *fake.Clientset {
+	return fake.NewSimpleClientset(&corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "provider", Namespace: "ns"},
+		Data: map[string][]byte{
+			defaultTokenKey:         []byte("token"),
+			defaultWebhookSecretKey: []byte("shhh\n"),
+		},
+	})
+}
+
+func TestConfigureGitea(t *testing.T) {
+	tests := []struct {
+		name     string
+		existing string
+		wantID   string
+	}{
+		{
+			name:     "create",
+			existing: `[{"id": 1, "config": {"url": "https://other.example.com"}}]`,
+			wantID:   "2",
+		},
+		{
+			name:     "update",
+			existing: fmt.Sprintf(`[{"id": 1, "config": {"url": %q}}]`, controllerURL),
+			wantID:   "1",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, _ := rtesting.SetupFakeContext(t)
+			mux := http.NewServeMux()
+			mux.HandleFunc("/api/v1/version", func(w http.ResponseWriter, _ *http.Request) {
+				fmt.Fprint(w, `{"version": "1.20.0"}`)
+			})
+			var gotConfig map[string]string
+			mux.HandleFunc("/api/v1/repos/owner/repo/hooks", func(w http.ResponseWriter, r *http.Request) {
+				if r.Method == http.MethodGet {
+					fmt.Fprint(w, tt.existing)
+					return
+				}
+				var opt struct {
+					Config map[string]string `json:"config"`
+				}
+				assert.NilError(t, json.NewDecoder(r.Body).Decode(&opt))
+				gotConfig = opt.Config
+				w.WriteHeader(http.StatusCreated)
+				fmt.Fprint(w, `{"id": 2}`)
+			})
+			mux.HandleFunc("/api/v1/repos/owner/repo/hooks/1", func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, r.Method, http.MethodPatch)
+				var opt struct {
+					Config map[string]string `json:"config"`
+				}
+				assert.NilError(t, json.NewDecoder(r.Body).Decode(&opt))
+				gotConfig = opt.Config
+				fmt.Fprint(w, `{"id": 1}`)
+			})
+			server := httptest.NewServer(mux)
+			defer server.Close()
+
+			id, err := Configure(ctx, makeKube(), makeRepo("gitea", server.URL), controllerURL)
+			assert.NilError(t, err)
+			assert.Equal(t, id, tt.wantID)
+			assert.Equal(t, gotConfig["url"], controllerURL)
+			assert.Equal(t, gotConfig["secret"], "shhh")
+		})
+	}
+}
+
+func TestConfigureGitLab(t *testing.T) {
+	tests := []struct {
+		name     string
+		existing string
+		wantID   string
+	}{
+		{
+			name:     "create",
+			existing: `[]`,
+			wantID:   "2",
+		},
+		{
+			name:     "update",
+			existing: fmt.Sprintf(`[{"id": 1, "url": %q}]`, controllerURL),
+			wantID:   "1",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, _ := rtesting.SetupFakeContext(t)
+			mux := http.NewServeMux()
+			var gotToken string
+			mux.HandleFunc("/api/v4/projects/owner%2Frepo/hooks", func(w http.ResponseWriter, r *http.Request) {
+				if r.Method == http.MethodGet {
+					fmt.Fprint(w, tt.existing)
+					return
+				}
+				var opt struct {
+					Token string `json:"token"`
+				}
+				assert.NilError(t, json.NewDecoder(r.Body).Decode(&opt))
+				gotToken = opt.Token
+				w.WriteHeader(http.StatusCreated)
+				fmt.Fprint(w, `{"id": 2}`)
+			})
+			mux.HandleFunc("/api/v4/projects/owner%2Frepo/hooks/1", func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, r.Method, http.MethodPut)
+				var opt struct {
+					Token string `json:"token"`
+				}
+				assert.NilError(t, json.NewDecoder(r.Body).Decode(&opt))
+				gotToken = opt.Token
+				fmt.Fprint(w, `{"id": 1}`)
+			})
+			server := httptest.NewServer(mux)
+			defer server.Close()
+
+			id, err := Configure(ctx, makeKube(), makeRepo("gitlab", server.URL), controllerURL)
+			assert.NilError(t, err)
+			assert.Equal(t, id, tt.wantID)
+			assert.Equal(t, gotToken, "shhh")
+		})
+	}
+}
+
+func TestDelete(t *testing.T) {
+	for _, providerType := range []string{"gitea", "gitlab"} {
+		t.Run(providerType, func(t *testing.T) {
+			ctx, _ := rtesting.SetupFakeContext(t)
+			mux := http.NewServeMux()
+			mux.HandleFunc("/api/v1/version", func(w http.ResponseWriter, _ *http.Request) {
+				fmt.Fprint(w, `{"version": "1.20.0"}`)
+			})
+			deleted := false
+			handler := func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, r.Method, http.MethodDelete)
+				deleted = true
+				w.WriteHeader(http.StatusNoContent)
+			}
+			mux.HandleFunc("/api/v1/repos/owner/repo/hooks/1", handler)
+			mux.HandleFunc("/api/v4/projects/owner%2Frepo/hooks/1", handler)
+			server := httptest.NewServer(mux)
+			defer server.Close()
+
+			assert.NilError(t, Delete(ctx, makeKube(), makeRepo(providerType, server.URL), "1"))
+			assert.Assert(t, deleted)
+		})
+	}
+}
+
+func TestConfigureErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		repo    func() *v1alpha1.Repository
+		wantErr string
+	}{
+		{
+			name:    "unsupported provider",
+			repo:    func() *v1alpha1.Repository { return makeRepo("github", "") },
+			wantErr: "repository repo has no gitea or gitlab git_provider",
+		},
+		{
+			name: "no secret",
+			repo: func() *v1alpha1.Repository {
+				repo := makeRepo("gitlab", "")
+				repo.Spec.GitProvider.Secret = nil
+				return repo
+			},
+			wantErr: "repository repo has no git_provider secret",
+		},
+		{
+			name: "missing secret key",
+			repo: func() *v1alpha1.Repository {
+				repo := makeRepo("gitlab", "")
+				repo.Spec.GitProvider.Secret.Key = "missing"
+				return repo
+			},
+			wantErr: "secret provider has no key missing",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, _ := rtesting.SetupFakeContext(t)
+			_, err := Configure(ctx, makeKube(), tt.repo(), controllerURL)
+			assert.ErrorContains(t, err, tt.wantErr)
+		})
+	}
+}
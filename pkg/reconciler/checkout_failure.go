@@ -0,0 +1,114 @@
+package reconciler
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/apis/pipelinesascode/keys"
+	pacv1a1 "github.com/openshift-pipelines/pipelines-as-code/pkg/apis/pipelinesascode/v1alpha1"
+	tektonv1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+)
+
+// gitCloneTask is the name of the task of the catalog checking out the source
+// with the git auth secret.
+const gitCloneTask = "git-clone"
+
+// checkoutCauses are the probable causes of a failed checkout, from the
+// errors of git in the logs of the task, the first matching one is used.
+var checkoutCauses = []struct {
+	re    *regexp.Regexp
+	cause string
+}{
+	{
+		re:    regexp.MustCompile(`(?i)git-lfs|\blfs\b`),
+		cause: "the Git LFS objects cannot be fetched, check LFS is enabled on the repository and the token can read them",
+	},
+	{
+		re:    regexp.MustCompile(`(?i)submodule`),
+		cause: "a submodule cannot be cloned, the token is scoped to the repository of the event and needs access to the repositories of the submodules",
+	},
+	{
+		re:    regexp.MustCompile(`(?i)repository .*not found|\b404\b`),
+		cause: "the repository cannot be found with the token, it may be private and not visible to the token or the GitHub App may not be installed on it",
+	},
+	{
+		re:    regexp.MustCompile(`(?i)authentication failed|could not read (username|password)|terminal prompts disabled|access denied|\b40[13]\b|permission denied`),
+		cause: "the Git provider refused the credentials, check the scopes of the token of the repository and that it has not expired",
+	},
+	{
+		re:    regexp.MustCompile(`(?i)couldn't find remote ref|not our ref|reference is not a tree|unable to read tree`),
+		cause: "the revision cannot be found, it may have been deleted by a force push",
+	},
+}
+
+// checkoutTasks returns the pipeline tasks checking out the source of the
+// PipelineRun: the ones using the workspace of the git auth secret
+// Pipelines-as-Code has created for it, and the git-clone tasks.
+func checkoutTasks(pr *tektonv1.PipelineRun) map[string]bool {
+	spec := pr.Spec.PipelineSpec
+	if pr.Status.PipelineSpec != nil {
+		spec = pr.Status.PipelineSpec
+	}
+	tasks := map[string]bool{}
+	if spec == nil {
+		return tasks
+	}
+	authWorkspaces := map[string]bool{}
+	if secretName := pr.GetAnnotations()[keys.GitAuthSecret]; secretName != "" {
+		for _, workspace := range pr.Spec.Workspaces {
+			if workspace.Secret != nil && workspace.Secret.SecretName == secretName {
+				authWorkspaces[workspace.Name] = true
+			}
+		}
+	}
+	for _, task := range spec.Tasks {
+		for _, workspace := range task.Workspaces {
+			if authWorkspaces[workspace.Workspace] {
+				tasks[task.Name] = true
+			}
+		}
+		if task.TaskRef == nil {
+			continue
+		}
+		if task.TaskRef.Name == gitCloneTask {
+			tasks[task.Name] = true
+		}
+		for _, param := range task.TaskRef.Params {
+			if param.Name == "name" && param.Value.StringVal == gitCloneTask {
+				tasks[task.Name] = true
+			}
+		}
+	}
+	return tasks
+}
+
+// checkoutFailureCause returns the probable cause of a failed checkout from
+// the logs or the message of the task.
+func checkoutFailureCause(failed pacv1a1.TaskInfos) string {
+	text := failed.LogSnippet + "\n" + failed.Message
+	for _, c := range checkoutCauses {
+		if c.re.MatchString(text) {
+			return c.cause
+		}
+	}
+	return "check the token of the repository can read it and the revision still exists"
+}
+
+// checkoutFailure returns why the source checkout of the PipelineRun has
+// failed when the first failed task is checking out the source, an empty
+// string otherwise. failedTasks are sorted by completion time.
+func checkoutFailure(pr *tektonv1.PipelineRun, failedTasks []pacv1a1.TaskInfos) string {
+	if len(failedTasks) == 0 {
+		return ""
+	}
+	first := failedTasks[0]
+	if first.Reason == tektonv1.TaskRunReasonCancelled.String() || !checkoutTasks(pr)[first.Name] {
+		return ""
+	}
+	name := first.Name
+	if first.DisplayName != "" {
+		name = strings.ToLower(first.DisplayName)
+	}
+	return fmt.Sprintf("task %s: %s", name, checkoutFailureCause(first))
+}
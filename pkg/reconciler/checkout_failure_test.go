@@ -0,0 +1,129 @@
+package reconciler
+
+import (
+	"testing"
+
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/apis/pipelinesascode/keys"
+	pacv1a1 "github.com/openshift-pipelines/pipelines-as-code/pkg/apis/pipelinesascode/v1alpha1"
+	tektonv1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+	"gotest.tools/v3/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func makeCheckoutPR() *tektonv1.PipelineRun {
+	return &tektonv1.PipelineRun{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "pr",
+			Annotations: map[string]string{keys.GitAuthSecret: "pac-gitauth-abcd"},
+		},
+		Spec: tektonv1.PipelineRunSpec{
+			Workspaces: []tektonv1.WorkspaceBinding{
+				{Name: "basic-auth", Secret: &corev1.SecretVolumeSource{SecretName: "pac-gitauth-abcd"}},
+				{Name: "source"},
+			},
+			PipelineSpec: &tektonv1.PipelineSpec{
+				Tasks: []tektonv1.PipelineTask{
+					{
+						Name:       "fetch",
+						Workspaces: []tektonv1.WorkspacePipelineTaskBinding{{Name: "basic-auth", Workspace: "basic-auth"}},
+					},
+					{
+						Name:    "clone",
+						TaskRef: &tektonv1.TaskRef{Name: "git-clone"},
+					},
+					{
+						Name: "hub-clone",
+						TaskRef: &tektonv1.TaskRef{ResolverRef: tektonv1.ResolverRef{
+							Resolver: "hub",
+							Params:   tektonv1.Params{{Name: "name", Value: *tektonv1.NewStructuredValues("git-clone")}},
+						}},
+					},
+					{
+						Name:       "build",
+						Workspaces: []tektonv1.WorkspacePipelineTaskBinding{{Name: "source", Workspace: "source"}},
+					},
+				},
+			},
+		},
+	}
+}
+
+func TestCheckoutTasks(t *testing.T) {
+	assert.DeepEqual(t, checkoutTasks(makeCheckoutPR()), map[string]bool{"fetch": true, "clone": true, "hub-clone": true})
+	assert.DeepEqual(t, checkoutTasks(&tektonv1.PipelineRun{}), map[string]bool{})
+}
+
+func TestCheckoutFailure(t *testing.T) {
+	tests := []struct {
+		name        string
+		failedTasks []pacv1a1.TaskInfos
+		want        string
+	}{
+		{
+			name: "authentication failed",
+			failedTasks: []pacv1a1.TaskInfos{{
+				Name:       "fetch",
+				Reason:     "Failed",
+				LogSnippet: "fatal: Authentication failed for 'https://github.com/owner/repo/'",
+			}},
+			want: "task fetch: the Git provider refused the credentials, check the scopes of the token of the repository and that it has not expired",
+		},
+		{
+			name: "repository not visible",
+			failedTasks: []pacv1a1.TaskInfos{{
+				Name:       "clone",
+				Reason:     "Failed",
+				LogSnippet: "remote: Repository not found.\nfatal: repository 'https://github.com/owner/private/' not found",
+			}},
+			want: "task clone: the repository cannot be found with the token, it may be private and not visible to the token or the GitHub App may not be installed on it",
+		},
+		{
+			name: "submodule",
+			failedTasks: []pacv1a1.TaskInfos{{
+				Name:        "hub-clone",
+				DisplayName: "Clone Source",
+				Reason:      "Failed",
+				LogSnippet:  "fatal: clone of 'https://github.com/owner/lib' into submodule path 'lib' failed",
+			}},
+			want: "task clone source: a submodule cannot be cloned, the token is scoped to the repository of the event and needs access to the repositories of the submodules",
+		},
+		{
+			name: "lfs",
+			failedTasks: []pacv1a1.TaskInfos{{
+				Name:       "fetch",
+				Reason:     "Failed",
+				LogSnippet: "Error downloading object: model.bin: Smudge error: batch response: git-lfs authentication required",
+			}},
+			want: "task fetch: the Git LFS objects cannot be fetched, check LFS is enabled on the repository and the token can read them",
+		},
+		{
+			name: "unknown cause",
+			failedTasks: []pacv1a1.TaskInfos{{
+				Name:    "fetch",
+				Reason:  "Failed",
+				Message: "step clone exited with code 128",
+			}},
+			want: "task fetch: check the token of the repository can read it and the revision still exists",
+		},
+		{
+			name: "first failure is not a checkout",
+			failedTasks: []pacv1a1.TaskInfos{
+				{Name: "build", Reason: "Failed", LogSnippet: "Authentication failed"},
+				{Name: "fetch", Reason: "Failed", LogSnippet: "Authentication failed"},
+			},
+		},
+		{
+			name:        "cancelled",
+			failedTasks: []pacv1a1.TaskInfos{{Name: "fetch", Reason: tektonv1.TaskRunReasonCancelled.String()}},
+		},
+		{
+			name: "no failure",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, checkoutFailure(makeCheckoutPR(), tt.failedTasks), tt.want)
+		})
+	}
+}
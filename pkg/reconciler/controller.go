@@ -6,6 +6,7 @@ import (
 
 	"github.com/openshift-pipelines/pipelines-as-code/pkg/apis/pipelinesascode"
 	"github.com/openshift-pipelines/pipelines-as-code/pkg/apis/pipelinesascode/keys"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/apis/pipelinesascode/v1alpha1"
 	"github.com/openshift-pipelines/pipelines-as-code/pkg/events"
 	"github.com/openshift-pipelines/pipelines-as-code/pkg/generated/injection/informers/pipelinesascode/v1alpha1/repository"
 	"github.com/openshift-pipelines/pipelines-as-code/pkg/kubeinteraction"
@@ -17,6 +18,7 @@ import (
 	tektonPipelineRunInformerv1 "github.com/tektoncd/pipeline/pkg/client/injection/informers/pipeline/v1/pipelinerun"
 	tektonPipelineRunReconcilerv1 "github.com/tektoncd/pipeline/pkg/client/injection/reconciler/pipeline/v1/pipelinerun"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/cache"
 	"knative.dev/pkg/configmap"
 	"knative.dev/pkg/controller"
 	"knative.dev/pkg/kmeta"
@@ -57,13 +59,45 @@ func NewController() func(context.Context, configmap.Watcher) *controller.Impl {
 			qm:                sync.NewQueueManager(run.Clients.Log),
 			metrics:           metrics,
 			eventEmitter:      events.NewEventEmitter(run.Clients.Kube, run.Clients.Log),
+			queuePositions:    &queuePositions{},
 		}
+		r.eventEmitter.SetRepositoryClient(run.Clients.PipelineAsCode)
+		r.eventEmitter.SetEnabled(func() bool { return run.Info.GetPacOpts().EnableRepositoryEvents })
 		impl := tektonPipelineRunReconcilerv1.NewImpl(ctx, r, ctrlOpts())
 
 		if err := r.qm.InitQueues(ctx, run.Clients.Tekton, run.Clients.PipelineAsCode); err != nil {
 			log.Fatal("failed to init queues", err)
 		}
 
+		// the pipelineRuns of the execution clusters are not seen by the
+		// informer, they get polled instead
+		go r.pollExecutionClusters(ctx, log, nil)
+
+		go r.pollProviderSecrets(ctx, log)
+
+		go r.pollEphemeralNamespaces(ctx, log, leaderFor(impl, ephemeralNamespacesKey))
+
+		if _, err := repository.Get(ctx).Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+			AddFunc: func(obj any) {
+				if repo, ok := obj.(*v1alpha1.Repository); ok {
+					go func() {
+						r.ensureRepositoryFinalizer(ctx, log, repo)
+						r.configureRepositoryWebhook(ctx, log, repo)
+					}()
+				}
+			},
+			UpdateFunc: func(_, obj any) {
+				if repo, ok := obj.(*v1alpha1.Repository); ok {
+					go func() {
+						r.ensureRepositoryFinalizer(ctx, log, repo)
+						r.finalizeRepository(ctx, log, repo)
+					}()
+				}
+			},
+		}); err != nil {
+			logging.FromContext(ctx).Panicf("Couldn't register Repository informer event handler: %w", err)
+		}
+
 		if _, err := pipelineRunInformer.Informer().AddEventHandler(controller.HandleAll(checkStateAndEnqueue(impl))); err != nil {
 			logging.FromContext(ctx).Panicf("Couldn't register PipelineRun informer event handler: %w", err)
 		}
@@ -72,6 +106,18 @@ func NewController() func(context.Context, configmap.Watcher) *controller.Impl {
 	}
 }
 
+// leaderFor returns a function telling whether the reconciler of impl is the
+// leader of key, for the periodic jobs to only run in a single replica.
+func leaderFor(impl *controller.Impl, key types.NamespacedName) func() bool {
+	leader, ok := impl.Reconciler.(interface {
+		IsLeaderFor(key types.NamespacedName) bool
+	})
+	if !ok {
+		return func() bool { return true }
+	}
+	return func() bool { return leader.IsLeaderFor(key) }
+}
+
 // enqueue only the pipelineruns which are in `started` state
 // pipelinerun will have a label `pipelinesascode.tekton.dev/state` to describe the state.
 func checkStateAndEnqueue(impl *controller.Impl) func(obj any) {
@@ -13,7 +13,9 @@ import (
 	zapobserver "go.uber.org/zap/zaptest/observer"
 	"gotest.tools/v3/assert"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
 	"knative.dev/pkg/controller"
+	pkgreconciler "knative.dev/pkg/reconciler"
 )
 
 type fakeReconciler struct{}
@@ -81,3 +83,21 @@ func TestCtrlOpts(t *testing.T) {
 	// Assert that the promote filter function returns true.
 	assert.Assert(t, promote)
 }
+
+type fakeLeaderReconciler struct {
+	fakeReconciler
+	pkgreconciler.LeaderAwareFuncs
+}
+
+func TestLeaderFor(t *testing.T) {
+	key := types.NamespacedName{Name: "key"}
+	assert.Assert(t, leaderFor(&controller.Impl{Reconciler: &fakeReconciler{}}, key)())
+
+	leader := &fakeLeaderReconciler{}
+	isLeader := leaderFor(&controller.Impl{Reconciler: leader}, key)
+	assert.Assert(t, !isLeader())
+	assert.NilError(t, leader.Promote(pkgreconciler.UniversalBucket(), func(pkgreconciler.Bucket, types.NamespacedName) {}))
+	assert.Assert(t, isLeader())
+	leader.Demote(pkgreconciler.UniversalBucket())
+	assert.Assert(t, !isLeader())
+}
@@ -0,0 +1,121 @@
+package reconciler
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/action"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/apis/pipelinesascode/keys"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/formatting"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/kubeinteraction"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/matcher"
+	tektonv1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+	"go.uber.org/zap"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+// getDependencies returns the latest PipelineRuns of the same event as pr
+// named dep, or the cells of the matrix named dep.
+func (r *Reconciler) getDependencies(ctx context.Context, pr *tektonv1.PipelineRun, dep string) ([]tektonv1.PipelineRun, error) {
+	for _, key := range []string{keys.OriginalPRName, keys.MatrixParent} {
+		selector := labels.SelectorFromSet(labels.Set{
+			keys.SHA:        pr.GetLabels()[keys.SHA],
+			keys.Repository: pr.GetLabels()[keys.Repository],
+			key:             formatting.CleanValueKubernetes(dep),
+		})
+		prs, err := r.run.Clients.Tekton.TektonV1().PipelineRuns(pr.GetNamespace()).List(ctx, metav1.ListOptions{
+			LabelSelector: selector.String(),
+		})
+		if err != nil {
+			return nil, fmt.Errorf("cannot list the pipelineruns %s depends on: %w", pr.GetName(), err)
+		}
+		if len(prs.Items) > 0 {
+			return latestByOriginalPRName(prs.Items), nil
+		}
+	}
+	return nil, nil
+}
+
+// startWaitingPipelineRun starts pr once all the PipelineRuns it depends on
+// have succeeded, or cancels it as soon as one of them has not.
+func (r *Reconciler) startWaitingPipelineRun(ctx context.Context, logger *zap.SugaredLogger, pr *tektonv1.PipelineRun) error {
+	deps, err := matcher.GetAnnotationValues(pr.GetAnnotations()[keys.DependsOn])
+	if err != nil {
+		return fmt.Errorf("pipelinerun %s: %w", pr.GetName(), err)
+	}
+	for _, dep := range deps {
+		depPRs, err := r.getDependencies(ctx, pr, dep)
+		if err != nil {
+			return err
+		}
+		if len(depPRs) == 0 {
+			logger.Infof("pipelinerun %s is waiting for %s to be created", pr.GetName(), dep)
+			return nil
+		}
+		for i := range depPRs {
+			if !depPRs[i].IsDone() {
+				logger.Infof("pipelinerun %s is waiting for %s to finish", pr.GetName(), depPRs[i].GetName())
+				return nil
+			}
+			if formatting.PipelineRunStatus(&depPRs[i]) != "success" {
+				logger.Infof("cancelling pipelinerun %s since %s has not succeeded", pr.GetName(), depPRs[i].GetName())
+				mergePatch := map[string]any{
+					"spec": map[string]any{
+						"status": tektonv1.PipelineRunSpecStatusCancelled,
+					},
+				}
+				_, err := action.PatchPipelineRun(ctx, logger, "cancel on failed dependency", r.run.Clients.Tekton, pr, mergePatch)
+				return err
+			}
+		}
+	}
+
+	repo, err := r.repoLister.Repositories(r.repositoryNamespace(ctx, pr)).Get(pr.GetAnnotations()[keys.Repository])
+	if err != nil {
+		return fmt.Errorf("failed to get repository CR: %w", err)
+	}
+	r.inheritOrgRepository(repo)
+	if r.globalRepo, err = r.repoLister.Repositories(r.run.Info.Kube.Namespace).Get(r.run.Info.Controller.GlobalRepository); err == nil && r.globalRepo != nil {
+		repo.Spec.Merge(r.globalRepo.Spec)
+	}
+	// hand it over to the queue when a concurrency limit is set
+	if repo.Spec.ConcurrencyLimit != nil && *repo.Spec.ConcurrencyLimit != 0 {
+		_, err := r.updatePipelineRunState(ctx, logger, pr, kubeinteraction.StateQueued)
+		return err
+	}
+	return r.updatePipelineRunToInProgress(ctx, logger, repo, pr)
+}
+
+// startDependentPipelineRuns checks the PipelineRuns of the same event
+// waiting on pr once it is done.
+func (r *Reconciler) startDependentPipelineRuns(ctx context.Context, logger *zap.SugaredLogger, pr *tektonv1.PipelineRun) error {
+	name := pr.GetAnnotations()[keys.OriginalPRName]
+	parent := pr.GetAnnotations()[keys.MatrixParent]
+	selector := labels.SelectorFromSet(labels.Set{
+		keys.SHA:        pr.GetLabels()[keys.SHA],
+		keys.Repository: pr.GetLabels()[keys.Repository],
+		keys.State:      kubeinteraction.StateWaiting,
+	})
+	prs, err := r.run.Clients.Tekton.TektonV1().PipelineRuns(pr.GetNamespace()).List(ctx, metav1.ListOptions{
+		LabelSelector: selector.String(),
+	})
+	if err != nil {
+		return fmt.Errorf("cannot list the pipelineruns waiting on %s: %w", pr.GetName(), err)
+	}
+	for i := range prs.Items {
+		waiting := &prs.Items[i]
+		if waiting.Spec.Status != tektonv1.PipelineRunSpecStatusPending {
+			continue
+		}
+		deps, err := matcher.GetAnnotationValues(waiting.GetAnnotations()[keys.DependsOn])
+		if err != nil || (!slices.Contains(deps, name) && (parent == "" || !slices.Contains(deps, parent))) {
+			continue
+		}
+		if err := r.startWaitingPipelineRun(ctx, logger, waiting); err != nil {
+			logger.Errorf("cannot start pipelinerun %s waiting on %s: %v", waiting.GetName(), pr.GetName(), err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,142 @@
+package reconciler
+
+import (
+	"testing"
+
+	"github.com/jonboulle/clockwork"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/apis/pipelinesascode/keys"
+	pacv1alpha1 "github.com/openshift-pipelines/pipelines-as-code/pkg/apis/pipelinesascode/v1alpha1"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/consoleui"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/kubeinteraction"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/params"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/params/clients"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/params/info"
+	testclient "github.com/openshift-pipelines/pipelines-as-code/pkg/test/clients"
+	tektontest "github.com/openshift-pipelines/pipelines-as-code/pkg/test/tekton"
+	tektonv1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+	"go.uber.org/zap"
+	zapobserver "go.uber.org/zap/zaptest/observer"
+	"gotest.tools/v3/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"knative.dev/pkg/apis"
+	rtesting "knative.dev/pkg/reconciler/testing"
+)
+
+func TestStartWaitingPipelineRun(t *testing.T) {
+	clock := clockwork.NewFakeClock()
+	ns := "ns"
+	eventLabels := func(name string) map[string]string {
+		return map[string]string{
+			keys.SHA:            "sha",
+			keys.Repository:     "repo",
+			keys.OriginalPRName: name,
+		}
+	}
+	makeLint := func(runstatus string) *tektonv1.PipelineRun {
+		return tektontest.MakePRCompletion(clock, "lint-abcde", ns, runstatus, map[string]string{keys.OriginalPRName: "lint"}, eventLabels("lint"), 0)
+	}
+	runningLint := makeLint("")
+	runningLint.Status.Conditions = []apis.Condition{{Type: apis.ConditionSucceeded, Status: corev1.ConditionUnknown, Reason: "Running"}}
+	makeDeploy := func() *tektonv1.PipelineRun {
+		deployLabels := eventLabels("deploy")
+		deployLabels[keys.State] = kubeinteraction.StateWaiting
+		return &tektonv1.PipelineRun{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "deploy-abcde",
+				Namespace: ns,
+				Labels:    deployLabels,
+				Annotations: map[string]string{
+					keys.OriginalPRName: "deploy",
+					keys.Repository:     "repo",
+					keys.DependsOn:      "[lint]",
+					keys.State:          kubeinteraction.StateWaiting,
+				},
+			},
+			Spec: tektonv1.PipelineRunSpec{Status: tektonv1.PipelineRunSpecStatusPending},
+		}
+	}
+
+	tests := []struct {
+		name             string
+		dependencies     []*tektonv1.PipelineRun
+		concurrencyLimit *int
+		wantSpecStatus   tektonv1.PipelineRunSpecStatus
+		wantState        string
+		wantLog          string
+	}{
+		{
+			name:           "dependency not created yet",
+			wantSpecStatus: tektonv1.PipelineRunSpecStatusPending,
+			wantState:      kubeinteraction.StateWaiting,
+			wantLog:        "pipelinerun deploy-abcde is waiting for lint to be created",
+		},
+		{
+			name:           "dependency running",
+			dependencies:   []*tektonv1.PipelineRun{runningLint},
+			wantSpecStatus: tektonv1.PipelineRunSpecStatusPending,
+			wantState:      kubeinteraction.StateWaiting,
+			wantLog:        "pipelinerun deploy-abcde is waiting for lint-abcde to finish",
+		},
+		{
+			name:           "dependency failed",
+			dependencies:   []*tektonv1.PipelineRun{makeLint(tektonv1.PipelineRunReasonFailed.String())},
+			wantSpecStatus: tektonv1.PipelineRunSpecStatusCancelled,
+			wantState:      kubeinteraction.StateWaiting,
+		},
+		{
+			name:           "dependency succeeded",
+			dependencies:   []*tektonv1.PipelineRun{makeLint("")},
+			wantSpecStatus: "",
+			wantState:      kubeinteraction.StateStarted,
+		},
+		{
+			name:             "dependency succeeded with a concurrency limit",
+			dependencies:     []*tektonv1.PipelineRun{makeLint("")},
+			concurrencyLimit: func() *int { i := 1; return &i }(),
+			wantSpecStatus:   tektonv1.PipelineRunSpecStatusPending,
+			wantState:        kubeinteraction.StateQueued,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			observer, logcatch := zapobserver.New(zap.InfoLevel)
+			fakelogger := zap.New(observer).Sugar()
+			ctx, _ := rtesting.SetupFakeContext(t)
+			deploy := makeDeploy()
+			repo := &pacv1alpha1.Repository{
+				ObjectMeta: metav1.ObjectMeta{Name: "repo", Namespace: ns},
+				Spec: pacv1alpha1.RepositorySpec{
+					URL:              "https://github.com/owner/repo",
+					ConcurrencyLimit: tt.concurrencyLimit,
+				},
+			}
+			stdata, informers := testclient.SeedTestData(t, ctx, testclient.Data{
+				Repositories: []*pacv1alpha1.Repository{repo},
+				PipelineRuns: append([]*tektonv1.PipelineRun{deploy}, tt.dependencies...),
+			})
+			run := params.New()
+			run.Info.Kube = &info.KubeOpts{Namespace: "global"}
+			run.Info.Controller = &info.ControllerInfo{}
+			run.Clients = clients.Clients{
+				PipelineAsCode: stdata.PipelineAsCode,
+				Tekton:         stdata.Pipeline,
+				Kube:           stdata.Kube,
+				Log:            fakelogger,
+			}
+			run.Clients.SetConsoleUI(consoleui.FallBackConsole{})
+			r := &Reconciler{run: run, repoLister: informers.Repository.Lister()}
+
+			err := r.startWaitingPipelineRun(ctx, fakelogger, deploy)
+			assert.NilError(t, err)
+
+			got, err := stdata.Pipeline.TektonV1().PipelineRuns(ns).Get(ctx, deploy.GetName(), metav1.GetOptions{})
+			assert.NilError(t, err)
+			assert.Equal(t, got.Spec.Status, tt.wantSpecStatus)
+			assert.Equal(t, got.GetAnnotations()[keys.State], tt.wantState)
+			if tt.wantLog != "" {
+				assert.Assert(t, logcatch.FilterMessage(tt.wantLog).Len() != 0, "We didn't get the expected log message", logcatch.All())
+			}
+		})
+	}
+}
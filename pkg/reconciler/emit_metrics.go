@@ -19,6 +19,12 @@ func (r *Reconciler) emitMetrics(pr *tektonv1.PipelineRun) error {
 		return err
 	}
 
+	if _, source, ok := pipelineRunTimeout(pr); ok {
+		if err := r.metrics.CountTimeout(pr.GetNamespace(), pr.GetAnnotations()[keys.Repository], source); err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
@@ -263,6 +263,43 @@ func TestCalculatePipelineRunDuration(t *testing.T) {
 	}
 }
 
+func TestEmitMetricsTimeout(t *testing.T) {
+	unregisterMetrics()
+	m, err := metrics.NewRecorder()
+	assert.NilError(t, err)
+	r := &Reconciler{
+		metrics: m,
+	}
+	pr := &tektonv1.PipelineRun{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "pac-ns",
+			Annotations: map[string]string{
+				keys.GitProvider: "gitlab",
+				keys.EventType:   "push",
+				keys.Repository:  "pac-repo",
+				keys.Timeout:     "10m",
+			},
+		},
+		Status: tektonv1.PipelineRunStatus{
+			Status: duckv1.Status{Conditions: []apis.Condition{
+				{
+					Type:   apis.ConditionSucceeded,
+					Status: corev1.ConditionFalse,
+					Reason: tektonv1.PipelineRunReasonTimedOut.String(),
+				},
+			}},
+		},
+	}
+	metricstest.AssertNoMetric(t, "pipelines_as_code_pipelinerun_timeout_count")
+
+	assert.NilError(t, r.emitMetrics(pr))
+	metricstest.CheckCountData(t, "pipelines_as_code_pipelinerun_timeout_count", map[string]string{
+		"namespace":      "pac-ns",
+		"repository":     "pac-repo",
+		"timeout-source": "annotation",
+	}, 1)
+}
+
 func TestCountRunningPRs(t *testing.T) {
 	annotations := map[string]string{
 		keys.GitProvider: "github",
@@ -313,6 +350,10 @@ func unregisterMetrics() {
 		"pipelines_as_code_pipelinerun_duration_seconds_sum",
 		"pipelines_as_code_running_pipelineruns_count",
 		"pipelines_as_code_git_provider_api_request_count",
+		"pipelines_as_code_repository_invalid_provider_secret",
+		"pipelines_as_code_pipelinerun_timeout_count",
+		"pipelines_as_code_event_queue_depth",
+		"pipelines_as_code_repository_failure_count",
 	)
 
 	// have to reset sync.Once to allow recreation of Recorder.
@@ -0,0 +1,103 @@
+package reconciler
+
+import (
+	"context"
+	"time"
+
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/apis/pipelinesascode/keys"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/ephemeralnamespace"
+	"go.uber.org/zap"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// ephemeralNamespaceCleanupInterval is how often the ephemeral namespaces
+// are checked for deletion.
+const ephemeralNamespaceCleanupInterval = time.Minute
+
+// ephemeralNamespacesKey is the key whose leader deletes the ephemeral
+// namespaces, for a single replica of the watcher to do it.
+var ephemeralNamespacesKey = types.NamespacedName{Name: "pipelines-as-code-ephemeral-namespaces"}
+
+// pollEphemeralNamespaces deletes the expired ephemeral namespaces when
+// isLeader, until ctx is done.
+func (r *Reconciler) pollEphemeralNamespaces(ctx context.Context, logger *zap.SugaredLogger, isLeader func() bool) {
+	ticker := time.NewTicker(ephemeralNamespaceCleanupInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if isLeader() {
+				r.cleanupEphemeralNamespaces(ctx, logger, time.Now())
+			}
+		}
+	}
+}
+
+// cleanupEphemeralNamespaces deletes the ephemeral namespaces whose
+// PipelineRuns have all completed for longer than the TTL of the namespace.
+// Only the namespaces created by Pipelines-as-Code for an existing Repository
+// are deleted, not the ones anybody could label as ephemeral.
+func (r *Reconciler) cleanupEphemeralNamespaces(ctx context.Context, logger *zap.SugaredLogger, now time.Time) {
+	namespaces, err := r.run.Clients.Kube.CoreV1().Namespaces().List(ctx, metav1.ListOptions{
+		LabelSelector: ephemeralnamespace.Selector,
+	})
+	if err != nil {
+		logger.Errorf("cannot list the ephemeral namespaces: %v", err)
+		return
+	}
+	for i := range namespaces.Items {
+		ns := &namespaces.Items[i]
+		if ns.GetDeletionTimestamp() != nil {
+			continue
+		}
+		repoNamespace, repoName, err := ephemeralnamespace.Repository(ns)
+		if err != nil {
+			logger.Warnf("skipping the ephemeral namespace %s: %v", ns.GetName(), err)
+			continue
+		}
+		if _, err := r.repoLister.Repositories(repoNamespace).Get(repoName); err != nil {
+			logger.Warnf("skipping the ephemeral namespace %s, cannot get its repository %s/%s: %v", ns.GetName(), repoNamespace, repoName, err)
+			continue
+		}
+		expired, err := r.ephemeralNamespaceExpired(ctx, ns, now)
+		if err != nil {
+			logger.Errorf("cannot check the ephemeral namespace %s: %v", ns.GetName(), err)
+			continue
+		}
+		if !expired {
+			continue
+		}
+		if err := ephemeralnamespace.Delete(ctx, r.run.Clients.Kube, ns.GetName()); err != nil {
+			logger.Errorf("cannot delete the ephemeral namespace %s: %v", ns.GetName(), err)
+			continue
+		}
+		logger.Infof("ephemeral namespace %s has been deleted", ns.GetName())
+	}
+}
+
+// ephemeralNamespaceExpired tells whether the PipelineRuns of ns have all
+// completed for longer than its TTL, or since its creation when it has none.
+func (r *Reconciler) ephemeralNamespaceExpired(ctx context.Context, ns *corev1.Namespace, now time.Time) (bool, error) {
+	ttl, err := time.ParseDuration(ns.GetAnnotations()[keys.EphemeralNamespaceTTL])
+	if err != nil {
+		ttl = ephemeralnamespace.DefaultTTL
+	}
+	prs, err := r.run.Clients.Tekton.TektonV1().PipelineRuns(ns.GetName()).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return false, err
+	}
+	completedAt := ns.GetCreationTimestamp().Time
+	for _, pr := range prs.Items {
+		if !pr.IsDone() {
+			return false, nil
+		}
+		if pr.Status.CompletionTime != nil && pr.Status.CompletionTime.After(completedAt) {
+			completedAt = pr.Status.CompletionTime.Time
+		}
+	}
+	return now.Sub(completedAt) >= ttl, nil
+}
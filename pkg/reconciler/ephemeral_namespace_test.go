@@ -0,0 +1,97 @@
+package reconciler
+
+import (
+	"testing"
+	"time"
+
+	"github.com/jonboulle/clockwork"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/apis/pipelinesascode"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/apis/pipelinesascode/keys"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/apis/pipelinesascode/v1alpha1"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/params"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/params/clients"
+	testclient "github.com/openshift-pipelines/pipelines-as-code/pkg/test/clients"
+	tektontest "github.com/openshift-pipelines/pipelines-as-code/pkg/test/tekton"
+	tektonv1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+	"go.uber.org/zap"
+	zapobserver "go.uber.org/zap/zaptest/observer"
+	"gotest.tools/v3/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	rtesting "knative.dev/pkg/reconciler/testing"
+)
+
+func TestCleanupEphemeralNamespaces(t *testing.T) {
+	clock := clockwork.NewFakeClock()
+	makeNamespace := func(name string, ephemeral bool, age time.Duration) *corev1.Namespace {
+		ns := &corev1.Namespace{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:              name,
+				CreationTimestamp: metav1.Time{Time: clock.Now().Add(-age)},
+				Annotations: map[string]string{
+					keys.EphemeralNamespaceTTL: "1h0m0s",
+					keys.RepositoryNamespace:   "ns",
+				},
+			},
+		}
+		if ephemeral {
+			ns.Labels = map[string]string{
+				"app.kubernetes.io/managed-by": pipelinesascode.GroupName,
+				keys.EphemeralNamespace:        "true",
+				keys.Repository:                "repo",
+			}
+		}
+		return ns
+	}
+	notManaged := makeNamespace("not-managed", true, 3*time.Hour)
+	delete(notManaged.Labels, "app.kubernetes.io/managed-by")
+	noRepositoryNamespace := makeNamespace("no-repository-namespace", true, 3*time.Hour)
+	delete(noRepositoryNamespace.Annotations, keys.RepositoryNamespace)
+	deletedRepository := makeNamespace("deleted-repository", true, 3*time.Hour)
+	deletedRepository.Labels[keys.Repository] = "deleted"
+	running := &tektonv1.PipelineRun{ObjectMeta: metav1.ObjectMeta{Name: "running", Namespace: "running"}}
+
+	observer, _ := zapobserver.New(zap.InfoLevel)
+	fakelogger := zap.New(observer).Sugar()
+	ctx, _ := rtesting.SetupFakeContext(t)
+	stdata, informers := testclient.SeedTestData(t, ctx, testclient.Data{
+		Namespaces: []*corev1.Namespace{
+			makeNamespace("completed-long-ago", true, 3*time.Hour),
+			makeNamespace("completed-recently", true, 3*time.Hour),
+			makeNamespace("running", true, 3*time.Hour),
+			makeNamespace("empty", true, 3*time.Hour),
+			makeNamespace("new", true, time.Minute),
+			makeNamespace("not-ephemeral", false, 3*time.Hour),
+			notManaged,
+			noRepositoryNamespace,
+			deletedRepository,
+		},
+		Repositories: []*v1alpha1.Repository{
+			{ObjectMeta: metav1.ObjectMeta{Name: "repo", Namespace: "ns"}},
+		},
+		PipelineRuns: []*tektonv1.PipelineRun{
+			tektontest.MakePRCompletion(clock, "pr", "completed-long-ago", "", nil, nil, 120),
+			tektontest.MakePRCompletion(clock, "pr", "completed-recently", "", nil, nil, 10),
+			running,
+		},
+	})
+	run := params.New()
+	run.Clients = clients.Clients{
+		Kube:   stdata.Kube,
+		Tekton: stdata.Pipeline,
+		Log:    fakelogger,
+	}
+	r := &Reconciler{run: run, repoLister: informers.Repository.Lister()}
+
+	r.cleanupEphemeralNamespaces(ctx, fakelogger, clock.Now())
+
+	namespaces, err := stdata.Kube.CoreV1().Namespaces().List(ctx, metav1.ListOptions{})
+	assert.NilError(t, err)
+	remaining := []string{}
+	for _, ns := range namespaces.Items {
+		remaining = append(remaining, ns.GetName())
+	}
+	assert.DeepEqual(t, remaining, []string{
+		"completed-recently", "deleted-repository", "new", "no-repository-namespace", "not-ephemeral", "not-managed", "running",
+	})
+}
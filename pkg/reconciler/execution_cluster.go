@@ -0,0 +1,85 @@
+package reconciler
+
+import (
+	"context"
+	"time"
+
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/apis/pipelinesascode/keys"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/executioncluster"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/kubeinteraction"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/params/info"
+	"go.uber.org/zap"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/selection"
+	"knative.dev/pkg/controller"
+)
+
+// executionClusterPollInterval is how often the PipelineRuns running on the
+// execution clusters are checked, they are not watched by the informers.
+const executionClusterPollInterval = 30 * time.Second
+
+// pollExecutionClusters reconciles the PipelineRuns of the repositories
+// with an execution cluster until ctx is done.
+func (r *Reconciler) pollExecutionClusters(ctx context.Context, logger *zap.SugaredLogger, newClients executioncluster.NewClientsFunc) {
+	ticker := time.NewTicker(executionClusterPollInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			r.reconcileExecutionClusters(ctx, logger, newClients)
+		}
+	}
+}
+
+// reconcileExecutionClusters reconciles once the PipelineRuns started by
+// Pipelines as Code on the execution clusters of the repositories, the
+// reconciler only differs from the local one by the Tekton client it uses.
+func (r *Reconciler) reconcileExecutionClusters(ctx context.Context, logger *zap.SugaredLogger, newClients executioncluster.NewClientsFunc) {
+	repos, err := r.repoLister.List(labels.Everything())
+	if err != nil {
+		logger.Errorf("cannot list repositories: %v", err)
+		return
+	}
+	controllerInfo := r.run.Info.Controller
+	if controllerInfo == nil {
+		controllerInfo = info.GetControllerInfoFromEnvOrDefault()
+	}
+	inState, _ := labels.NewRequirement(keys.State, selection.In, []string{kubeinteraction.StateStarted, kubeinteraction.StateWaiting, kubeinteraction.StateScheduled})
+	for _, repo := range repos {
+		repo = repo.DeepCopy()
+		r.inheritOrgRepository(repo)
+		if globalRepo, err := r.repoLister.Repositories(r.run.Info.Kube.Namespace).Get(controllerInfo.GlobalRepository); err == nil && globalRepo != nil {
+			repo.Spec.Merge(globalRepo.Spec)
+		}
+		remote, err := executioncluster.Get(ctx, r.run.Clients.Kube, repo, newClients)
+		if err != nil {
+			logger.Errorf("cannot get the execution cluster of repository %s/%s: %v", repo.GetNamespace(), repo.GetName(), err)
+			continue
+		}
+		if remote == nil {
+			continue
+		}
+
+		selector := labels.SelectorFromSet(labels.Set{keys.Repository: repo.GetName()}).Add(*inState)
+		prs, err := remote.Tekton.TektonV1().PipelineRuns(repo.GetNamespace()).List(ctx, metav1.ListOptions{LabelSelector: selector.String()})
+		if err != nil {
+			logger.Errorf("cannot list pipelineRuns of repository %s/%s on its execution cluster: %v", repo.GetNamespace(), repo.GetName(), err)
+			continue
+		}
+
+		remoteRun := *r.run
+		remoteRun.Clients.Tekton = remote.Tekton
+		remoteReconciler := *r
+		remoteReconciler.run = &remoteRun
+		for i := range prs.Items {
+			err := remoteReconciler.ReconcileKind(ctx, &prs.Items[i])
+			// the scheduled pipelineRuns are checked again on the next pass
+			if requeue, _ := controller.IsRequeueKey(err); err != nil && !requeue {
+				logger.Errorf("cannot reconcile pipelineRun %s/%s on its execution cluster: %v", repo.GetNamespace(), prs.Items[i].GetName(), err)
+			}
+		}
+	}
+}
@@ -0,0 +1,100 @@
+package reconciler
+
+import (
+	"testing"
+
+	"github.com/jonboulle/clockwork"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/apis/pipelinesascode/keys"
+	pacv1alpha1 "github.com/openshift-pipelines/pipelines-as-code/pkg/apis/pipelinesascode/v1alpha1"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/consoleui"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/executioncluster"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/kubeinteraction"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/params"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/params/clients"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/params/info"
+	testclient "github.com/openshift-pipelines/pipelines-as-code/pkg/test/clients"
+	tektontest "github.com/openshift-pipelines/pipelines-as-code/pkg/test/tekton"
+	tektonv1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+	faketekton "github.com/tektoncd/pipeline/pkg/client/clientset/versioned/fake"
+	"go.uber.org/zap"
+	zapobserver "go.uber.org/zap/zaptest/observer"
+	"gotest.tools/v3/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	rtesting "knative.dev/pkg/reconciler/testing"
+)
+
+func TestReconcileExecutionClusters(t *testing.T) {
+	clock := clockwork.NewFakeClock()
+	ns := "ns"
+	eventLabels := func(name string) map[string]string {
+		return map[string]string{
+			keys.SHA:            "sha",
+			keys.Repository:     "repo",
+			keys.OriginalPRName: name,
+		}
+	}
+	lint := tektontest.MakePRCompletion(clock, "lint-abcde", ns, tektonv1.PipelineRunReasonFailed.String(), map[string]string{keys.OriginalPRName: "lint"}, eventLabels("lint"), 0)
+	deployLabels := eventLabels("deploy")
+	deployLabels[keys.State] = kubeinteraction.StateWaiting
+	deploy := &tektonv1.PipelineRun{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "deploy-abcde",
+			Namespace: ns,
+			Labels:    deployLabels,
+			Annotations: map[string]string{
+				keys.OriginalPRName: "deploy",
+				keys.Repository:     "repo",
+				keys.DependsOn:      "[lint]",
+				keys.State:          kubeinteraction.StateWaiting,
+			},
+		},
+		Spec: tektonv1.PipelineRunSpec{Status: tektonv1.PipelineRunSpecStatusPending},
+	}
+	repo := &pacv1alpha1.Repository{
+		ObjectMeta: metav1.ObjectMeta{Name: "repo", Namespace: ns},
+		Spec: pacv1alpha1.RepositorySpec{
+			URL: "https://github.com/owner/repo",
+			Settings: &pacv1alpha1.Settings{
+				ExecutionCluster: &pacv1alpha1.ExecutionCluster{Secret: &pacv1alpha1.Secret{Name: "remote"}},
+			},
+		},
+	}
+
+	observer, _ := zapobserver.New(zap.InfoLevel)
+	fakelogger := zap.New(observer).Sugar()
+	ctx, _ := rtesting.SetupFakeContext(t)
+	stdata, informers := testclient.SeedTestData(t, ctx, testclient.Data{
+		Repositories: []*pacv1alpha1.Repository{repo},
+		Secret: []*corev1.Secret{{
+			ObjectMeta: metav1.ObjectMeta{Name: "remote", Namespace: ns},
+			Data:       map[string][]byte{executioncluster.DefaultSecretKey: []byte("kubeconfig")},
+		}},
+	})
+	remoteTekton := faketekton.NewSimpleClientset(lint, deploy)
+	newClients := func(kubeconfig []byte) (*executioncluster.Clients, error) {
+		assert.Equal(t, string(kubeconfig), "kubeconfig")
+		return &executioncluster.Clients{Tekton: remoteTekton}, nil
+	}
+
+	run := params.New()
+	run.Info.Kube = &info.KubeOpts{Namespace: "global"}
+	run.Info.Controller = &info.ControllerInfo{}
+	run.Clients = clients.Clients{
+		PipelineAsCode: stdata.PipelineAsCode,
+		Tekton:         stdata.Pipeline,
+		Kube:           stdata.Kube,
+		Log:            fakelogger,
+	}
+	run.Clients.SetConsoleUI(consoleui.FallBackConsole{})
+	r := &Reconciler{run: run, repoLister: informers.Repository.Lister()}
+
+	r.reconcileExecutionClusters(ctx, fakelogger, newClients)
+
+	// the dependency failed on the execution cluster so the waiting
+	// pipelineRun has been cancelled there
+	got, err := remoteTekton.TektonV1().PipelineRuns(ns).Get(ctx, deploy.GetName(), metav1.GetOptions{})
+	assert.NilError(t, err)
+	assert.Equal(t, got.Spec.Status, tektonv1.PipelineRunSpecStatus(tektonv1.PipelineRunSpecStatusCancelled))
+	assert.Equal(t, r.run.Clients.Tekton, stdata.Pipeline)
+}
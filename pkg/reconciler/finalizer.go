@@ -26,17 +26,19 @@ func (r *Reconciler) FinalizeKind(ctx context.Context, pr *tektonv1.PipelineRun)
 		if !ok {
 			return nil
 		}
-		repo, err := r.repoLister.Repositories(pr.Namespace).Get(repoName)
+		repoNamespace := r.repositoryNamespace(ctx, pr)
+		repo, err := r.repoLister.Repositories(repoNamespace).Get(repoName)
 		// if repository is not found then remove the queue for that repository if exist
 		if errors.IsNotFound(err) {
 			r.qm.RemoveRepository(&v1alpha1.Repository{
-				ObjectMeta: metav1.ObjectMeta{Name: repoName, Namespace: pr.Namespace},
+				ObjectMeta: metav1.ObjectMeta{Name: repoName, Namespace: repoNamespace},
 			})
 			return nil
 		}
 		if err != nil {
 			return err
 		}
+		r.inheritOrgRepository(repo)
 		r.secretNS = repo.GetNamespace()
 		if r.globalRepo, err = r.repoLister.Repositories(r.run.Info.Kube.Namespace).Get(r.run.Info.Controller.GlobalRepository); err == nil && r.globalRepo != nil {
 			if repo.Spec.GitProvider != nil && repo.Spec.GitProvider.Secret == nil && r.globalRepo.Spec.GitProvider != nil && r.globalRepo.Spec.GitProvider.Secret != nil {
@@ -57,6 +59,7 @@ func (r *Reconciler) FinalizeKind(ctx context.Context, pr *tektonv1.PipelineRun)
 				logger.Errorf("failed to update status: %w", err)
 				return err
 			}
+			r.reportQueuePositions(ctx, logger, repo, "")
 			return nil
 		}
 	}
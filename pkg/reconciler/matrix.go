@@ -0,0 +1,101 @@
+package reconciler
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/apis/pipelinesascode/keys"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/formatting"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/params/info"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/pipelineascode"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/provider"
+	tektonv1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+	"go.uber.org/zap"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+// matrixSummaryConclusion returns the conclusion of the matrix, a failure of
+// any cell fails the whole matrix.
+func matrixSummaryConclusion(cells []tektonv1.PipelineRun) string {
+	conclusion := "success"
+	for i := range cells {
+		switch formatting.PipelineRunStatus(&cells[i]) {
+		case "failure":
+			return "failure"
+		case "cancelled":
+			conclusion = "cancelled"
+		}
+	}
+	return conclusion
+}
+
+// latestByOriginalPRName keeps only the latest PipelineRun of every original
+// PipelineRun name, older ones being from a previous retest of the same commit.
+func latestByOriginalPRName(prs []tektonv1.PipelineRun) []tektonv1.PipelineRun {
+	latest := map[string]tektonv1.PipelineRun{}
+	for _, pr := range prs {
+		name := pr.GetAnnotations()[keys.OriginalPRName]
+		if current, ok := latest[name]; ok && !current.CreationTimestamp.Before(&pr.CreationTimestamp) {
+			continue
+		}
+		latest[name] = pr
+	}
+	cells := make([]tektonv1.PipelineRun, 0, len(latest))
+	for _, pr := range latest {
+		cells = append(cells, pr)
+	}
+	sort.Slice(cells, func(i, j int) bool {
+		return cells[i].GetAnnotations()[keys.OriginalPRName] < cells[j].GetAnnotations()[keys.OriginalPRName]
+	})
+	return cells
+}
+
+// reportMatrixSummary posts a status summarizing all the cells of the matrix
+// pr belongs to, once every one of them has finished.
+func (r *Reconciler) reportMatrixSummary(ctx context.Context, logger *zap.SugaredLogger, vcx provider.Interface, event *info.Event, pr *tektonv1.PipelineRun) error {
+	if pr == nil {
+		return nil
+	}
+	parent, ok := pr.GetAnnotations()[keys.MatrixParent]
+	if !ok {
+		return nil
+	}
+	selector := labels.SelectorFromSet(labels.Set{
+		keys.SHA:          formatting.CleanValueKubernetes(event.SHA),
+		keys.MatrixParent: formatting.CleanValueKubernetes(parent),
+	})
+	prs, err := r.run.Clients.Tekton.TektonV1().PipelineRuns(pr.GetNamespace()).List(ctx, metav1.ListOptions{
+		LabelSelector: selector.String(),
+	})
+	if err != nil {
+		return fmt.Errorf("cannot list the pipelineruns of matrix %s: %w", parent, err)
+	}
+
+	cells := latestByOriginalPRName(prs.Items)
+	var text strings.Builder
+	text.WriteString("| Status | PipelineRun | Matrix |\n|---|---|---|\n")
+	for i := range cells {
+		if !cells[i].IsDone() {
+			logger.Debugf("matrix %s is still running pipelinerun %s", parent, cells[i].GetName())
+			return nil
+		}
+		fmt.Fprintf(&text, "| %s | [%s](%s) | %s |\n",
+			formatting.ConditionEmoji(cells[i].Status.Conditions),
+			cells[i].GetName(),
+			r.run.Clients.ConsoleUI().DetailURL(&cells[i]),
+			cells[i].GetAnnotations()[keys.MatrixCell])
+	}
+
+	status := provider.StatusOpts{
+		Status:                  pipelineascode.CompletedStatus,
+		Conclusion:              matrixSummaryConclusion(cells),
+		Title:                   "Matrix summary",
+		Text:                    text.String(),
+		DetailsURL:              r.run.Clients.ConsoleUI().NamespaceURL(pr),
+		OriginalPipelineRunName: parent,
+	}
+	return createStatusWithRetry(ctx, logger, vcx, event, status)
+}
@@ -0,0 +1,145 @@
+package reconciler
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/jonboulle/clockwork"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/apis/pipelinesascode/keys"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/consoleui"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/params"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/params/clients"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/params/info"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/provider"
+	testclient "github.com/openshift-pipelines/pipelines-as-code/pkg/test/clients"
+	tprovider "github.com/openshift-pipelines/pipelines-as-code/pkg/test/provider"
+	tektontest "github.com/openshift-pipelines/pipelines-as-code/pkg/test/tekton"
+	tektonv1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+	"go.uber.org/zap"
+	zapobserver "go.uber.org/zap/zaptest/observer"
+	"gotest.tools/v3/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"knative.dev/pkg/apis"
+	rtesting "knative.dev/pkg/reconciler/testing"
+)
+
+type statusRecorder struct {
+	tprovider.TestProviderImp
+	statuses []provider.StatusOpts
+}
+
+func (s *statusRecorder) CreateStatus(_ context.Context, _ *info.Event, status provider.StatusOpts) error {
+	s.statuses = append(s.statuses, status)
+	return nil
+}
+
+func makeMatrixCell(clock *clockwork.FakeClock, name, cell, runstatus string, minutesAgo int) *tektonv1.PipelineRun {
+	labels := map[string]string{
+		keys.SHA:            "sha",
+		keys.MatrixParent:   "build",
+		keys.OriginalPRName: "build-" + cell,
+	}
+	annotations := map[string]string{
+		keys.MatrixParent:   "build",
+		keys.OriginalPRName: "build-" + cell,
+		keys.MatrixCell:     "go=" + cell,
+	}
+	pr := tektontest.MakePRCompletion(clock, name, "ns", runstatus, annotations, labels, 0)
+	pr.CreationTimestamp = metav1.Time{Time: clock.Now().Add(-time.Duration(minutesAgo) * time.Minute)}
+	return pr
+}
+
+func TestReportMatrixSummary(t *testing.T) {
+	clock := clockwork.NewFakeClock()
+	running := makeMatrixCell(clock, "build-1.22-abcde", "1.22", "", 0)
+	running.Status.Conditions = []apis.Condition{{Type: apis.ConditionSucceeded, Status: corev1.ConditionUnknown, Reason: "Running"}}
+
+	tests := []struct {
+		name           string
+		pr             *tektonv1.PipelineRun
+		pipelineRuns   []*tektonv1.PipelineRun
+		wantConclusion string
+		wantNoStatus   bool
+		wantText       string
+	}{
+		{
+			name: "not a matrix",
+			pr:   tektontest.MakePRCompletion(clock, "other", "ns", "", nil, map[string]string{}, 0),
+			pipelineRuns: []*tektonv1.PipelineRun{
+				makeMatrixCell(clock, "build-1.21-abcde", "1.21", "", 0),
+			},
+			wantNoStatus: true,
+		},
+		{
+			name: "all cells succeeded",
+			pr:   makeMatrixCell(clock, "build-1.21-abcde", "1.21", "", 0),
+			pipelineRuns: []*tektonv1.PipelineRun{
+				makeMatrixCell(clock, "build-1.21-abcde", "1.21", "", 0),
+				makeMatrixCell(clock, "build-1.22-abcde", "1.22", "", 0),
+			},
+			wantConclusion: "success",
+			wantText: "| Status | PipelineRun | Matrix |\n|---|---|---|\n" +
+				"| 🟢 Succeeded | [build-1.21-abcde](https://dashboard.is.not.configured) | go=1.21 |\n" +
+				"| 🟢 Succeeded | [build-1.22-abcde](https://dashboard.is.not.configured) | go=1.22 |\n",
+		},
+		{
+			name: "a cell failed",
+			pr:   makeMatrixCell(clock, "build-1.21-abcde", "1.21", "", 0),
+			pipelineRuns: []*tektonv1.PipelineRun{
+				makeMatrixCell(clock, "build-1.21-abcde", "1.21", "", 0),
+				makeMatrixCell(clock, "build-1.22-abcde", "1.22", tektonv1.PipelineRunReasonFailed.String(), 0),
+			},
+			wantConclusion: "failure",
+		},
+		{
+			name: "a cell failed before being retested successfully",
+			pr:   makeMatrixCell(clock, "build-1.21-abcde", "1.21", "", 0),
+			pipelineRuns: []*tektonv1.PipelineRun{
+				makeMatrixCell(clock, "build-1.21-abcde", "1.21", "", 0),
+				makeMatrixCell(clock, "build-1.22-old", "1.22", tektonv1.PipelineRunReasonFailed.String(), 10),
+				makeMatrixCell(clock, "build-1.22-new", "1.22", "", 0),
+			},
+			wantConclusion: "success",
+		},
+		{
+			name: "a cell is still running",
+			pr:   makeMatrixCell(clock, "build-1.21-abcde", "1.21", "", 0),
+			pipelineRuns: []*tektonv1.PipelineRun{
+				makeMatrixCell(clock, "build-1.21-abcde", "1.21", "", 0),
+				running,
+			},
+			wantNoStatus: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			observer, _ := zapobserver.New(zap.InfoLevel)
+			fakelogger := zap.New(observer).Sugar()
+			ctx, _ := rtesting.SetupFakeContext(t)
+			stdata, _ := testclient.SeedTestData(t, ctx, testclient.Data{PipelineRuns: tt.pipelineRuns})
+			run := params.New()
+			run.Clients = clients.Clients{
+				Kube:   stdata.Kube,
+				Tekton: stdata.Pipeline,
+			}
+			run.Clients.SetConsoleUI(consoleui.FallBackConsole{})
+			r := &Reconciler{run: run}
+			vcx := &statusRecorder{}
+
+			err := r.reportMatrixSummary(ctx, fakelogger, vcx, &info.Event{SHA: "sha"}, tt.pr)
+			assert.NilError(t, err)
+			if tt.wantNoStatus {
+				assert.Equal(t, len(vcx.statuses), 0)
+				return
+			}
+			assert.Equal(t, len(vcx.statuses), 1)
+			assert.Equal(t, vcx.statuses[0].Conclusion, tt.wantConclusion)
+			assert.Equal(t, vcx.statuses[0].OriginalPipelineRunName, "build")
+			if tt.wantText != "" {
+				assert.Equal(t, vcx.statuses[0].Text, tt.wantText)
+			}
+		})
+	}
+}
@@ -0,0 +1,47 @@
+package reconciler
+
+import (
+	"fmt"
+
+	"github.com/hako/durafmt"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/apis/pipelinesascode/keys"
+	tektonv1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+)
+
+const (
+	// timeoutSourceAnnotation is the source of the timeouts set on the
+	// PipelineRun, with the timeout annotation or in its spec.
+	timeoutSourceAnnotation = "annotation"
+	// timeoutSourceDefault is the source of the default timeout of Tekton.
+	timeoutSourceDefault = "default"
+)
+
+// pipelineRunTimeout returns the timeout of a PipelineRun which has timed out
+// and where it comes from, false when it has not timed out.
+func pipelineRunTimeout(pr *tektonv1.PipelineRun) (string, string, bool) {
+	if !pr.IsTimeoutConditionSet() {
+		return "", "", false
+	}
+	source := timeoutSourceDefault
+	if _, ok := pr.GetAnnotations()[keys.Timeout]; ok {
+		source = timeoutSourceAnnotation
+	}
+	timeout := ""
+	if pr.Spec.Timeouts != nil && pr.Spec.Timeouts.Pipeline != nil {
+		timeout = durafmt.Parse(pr.Spec.Timeouts.Pipeline.Duration).String()
+	}
+	return timeout, source, true
+}
+
+// timeoutText describes the timeout of a PipelineRun for the provider status,
+// ie: "after 1 hour, the default timeout of Tekton Pipelines".
+func timeoutText(timeout, source string) string {
+	from := "the default timeout of Tekton Pipelines"
+	if source == timeoutSourceAnnotation {
+		from = fmt.Sprintf("the timeout set on the PipelineRun with the %s annotation", keys.Timeout)
+	}
+	if timeout == "" {
+		return from
+	}
+	return fmt.Sprintf("after %s, %s", timeout, from)
+}
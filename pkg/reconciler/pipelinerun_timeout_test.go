@@ -0,0 +1,76 @@
+package reconciler
+
+import (
+	"testing"
+	"time"
+
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/apis/pipelinesascode/keys"
+	tektonv1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+	"gotest.tools/v3/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"knative.dev/pkg/apis"
+	duckv1 "knative.dev/pkg/apis/duck/v1"
+)
+
+func TestPipelineRunTimeout(t *testing.T) {
+	tests := []struct {
+		name        string
+		annotations map[string]string
+		reason      string
+		timeouts    *tektonv1.TimeoutFields
+		want        string
+		wantSource  string
+		wantTimeout bool
+	}{
+		{
+			name:        "default timeout",
+			reason:      tektonv1.PipelineRunReasonTimedOut.String(),
+			timeouts:    &tektonv1.TimeoutFields{Pipeline: &metav1.Duration{Duration: time.Hour}},
+			want:        "after 1 hour, the default timeout of Tekton Pipelines",
+			wantSource:  timeoutSourceDefault,
+			wantTimeout: true,
+		},
+		{
+			name:        "timeout of the annotation",
+			annotations: map[string]string{keys.Timeout: "1h30m0s"},
+			reason:      tektonv1.PipelineRunReasonTimedOut.String(),
+			timeouts:    &tektonv1.TimeoutFields{Pipeline: &metav1.Duration{Duration: 90 * time.Minute}},
+			want:        "after 1 hour 30 minutes, the timeout set on the PipelineRun with the pipelinesascode.tekton.dev/timeout annotation",
+			wantSource:  timeoutSourceAnnotation,
+			wantTimeout: true,
+		},
+		{
+			name:        "unknown timeout",
+			reason:      tektonv1.PipelineRunReasonTimedOut.String(),
+			want:        "the default timeout of Tekton Pipelines",
+			wantSource:  timeoutSourceDefault,
+			wantTimeout: true,
+		},
+		{
+			name:     "failed pipelinerun",
+			reason:   tektonv1.PipelineRunReasonFailed.String(),
+			timeouts: &tektonv1.TimeoutFields{Pipeline: &metav1.Duration{Duration: time.Hour}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pr := &tektonv1.PipelineRun{
+				ObjectMeta: metav1.ObjectMeta{Annotations: tt.annotations},
+				Spec:       tektonv1.PipelineRunSpec{Timeouts: tt.timeouts},
+				Status: tektonv1.PipelineRunStatus{
+					Status: duckv1.Status{Conditions: []apis.Condition{
+						{Type: apis.ConditionSucceeded, Status: corev1.ConditionFalse, Reason: tt.reason},
+					}},
+				},
+			}
+			timeout, source, ok := pipelineRunTimeout(pr)
+			assert.Equal(t, ok, tt.wantTimeout)
+			if !tt.wantTimeout {
+				return
+			}
+			assert.Equal(t, source, tt.wantSource)
+			assert.Equal(t, timeoutText(timeout, source), tt.want)
+		})
+	}
+}
@@ -0,0 +1,29 @@
+package reconciler
+
+import (
+	"context"
+
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/apis/pipelinesascode/v1alpha1"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/params/info"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/posthook"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/provider"
+	tektonv1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+	"go.uber.org/zap"
+)
+
+// runPostRunHooks runs the integrations enabled in the post-run-hooks setting
+// on the completed PipelineRun.
+func (r *Reconciler) runPostRunHooks(ctx context.Context, logger *zap.SugaredLogger, pacInfo *info.PacOpts, vcx provider.Interface, event *info.Event, repo *v1alpha1.Repository, pr *tektonv1.PipelineRun) error {
+	enabled := posthook.ParseNames(pacInfo.PostRunHooks)
+	if pr == nil || len(enabled) == 0 {
+		return nil
+	}
+	return posthook.Run(ctx, enabled, posthook.Options{
+		Run:         r.run,
+		Event:       event,
+		Repository:  repo,
+		PipelineRun: pr,
+		Provider:    vcx,
+		Logger:      logger,
+	})
+}
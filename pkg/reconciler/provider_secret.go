@@ -0,0 +1,152 @@
+package reconciler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/apis/pipelinesascode/v1alpha1"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/pipelineascode"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/providersecret"
+	ktypes "github.com/openshift-pipelines/pipelines-as-code/pkg/secrets/types"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+const (
+	// providerSecretValidCondition is the condition of the Repository status
+	// telling whether the token of its git provider is valid.
+	providerSecretValidCondition = "ProviderSecretValid"
+
+	// providerSecretExpiryWarning is how long before the expiry of a token
+	// an event starts warning about it.
+	providerSecretExpiryWarning = 7 * 24 * time.Hour
+
+	// providerSecretDisabledRecheck is how often the setting is checked
+	// again when the validation is disabled.
+	providerSecretDisabledRecheck = time.Hour
+)
+
+// providerSecretValidationInterval returns the interval of the validation of
+// the provider secrets from the settings, zero when it is disabled.
+func (r *Reconciler) providerSecretValidationInterval() time.Duration {
+	interval, err := time.ParseDuration(r.run.Info.GetPacOpts().ProviderSecretValidationInterval)
+	if err != nil {
+		return 24 * time.Hour
+	}
+	return interval
+}
+
+// pollProviderSecrets validates the provider secrets of the repositories on
+// the interval of the settings until ctx is done.
+func (r *Reconciler) pollProviderSecrets(ctx context.Context, logger *zap.SugaredLogger) {
+	for {
+		interval := r.providerSecretValidationInterval()
+		wait := interval
+		if wait <= 0 {
+			wait = providerSecretDisabledRecheck
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(wait):
+		}
+		if interval > 0 {
+			r.validateProviderSecrets(ctx, logger, time.Now())
+		}
+	}
+}
+
+// validateProviderSecrets checks the token of the git_provider secret of
+// each repository against its git provider, the result is recorded as a
+// condition of the repository status and reported by an event and a metric
+// when the token is invalid. Repositories inheriting their secret are
+// covered by the validation of the repository they inherit it from.
+func (r *Reconciler) validateProviderSecrets(ctx context.Context, logger *zap.SugaredLogger, now time.Time) {
+	repos, err := r.repoLister.List(labels.Everything())
+	if err != nil {
+		logger.Errorf("cannot list repositories: %v", err)
+		return
+	}
+	for _, repo := range repos {
+		if repo.Spec.GitProvider == nil || repo.Spec.GitProvider.Secret == nil {
+			continue
+		}
+		providerType := providersecret.ProviderType(repo)
+		if providerType == "" {
+			continue
+		}
+
+		key := repo.Spec.GitProvider.Secret.Key
+		if key == "" {
+			key = pipelineascode.DefaultGitProviderSecretKey
+		}
+		token, err := r.kinteract.GetSecret(ctx, ktypes.GetSecretOpt{
+			Namespace: repo.GetNamespace(),
+			Name:      repo.Spec.GitProvider.Secret.Name,
+			Key:       key,
+		})
+		var result *providersecret.Result
+		if err != nil {
+			err = fmt.Errorf("%w: cannot get secret %s: %w", providersecret.ErrInvalid, repo.Spec.GitProvider.Secret.Name, err)
+		} else {
+			result, err = providersecret.Validate(ctx, providerType, repo.Spec.GitProvider.URL, repo.Spec.GitProvider.User, token)
+		}
+
+		condition := metav1.Condition{
+			Type:   providerSecretValidCondition,
+			Status: metav1.ConditionTrue,
+			Reason: "Valid",
+		}
+		switch {
+		case err != nil && !errors.Is(err, providersecret.ErrInvalid):
+			logger.Warnf("cannot validate the provider secret of repository %s/%s: %v", repo.GetNamespace(), repo.GetName(), err)
+			continue
+		case err != nil:
+			condition.Status = metav1.ConditionFalse
+			condition.Reason = "Invalid"
+			condition.Message = err.Error()
+			r.eventEmitter.EmitMessage(repo, zapcore.WarnLevel, "ProviderSecretInvalid",
+				fmt.Sprintf("the git provider token of repository %s/%s is not valid: %v", repo.GetNamespace(), repo.GetName(), err))
+		case result.ExpiresAt != nil:
+			condition.Message = fmt.Sprintf("the token expires on %s", result.ExpiresAt.Format(time.DateOnly))
+			if result.ExpiresAt.Sub(now) < providerSecretExpiryWarning {
+				r.eventEmitter.EmitMessage(repo, zapcore.WarnLevel, "ProviderSecretExpiring",
+					fmt.Sprintf("the git provider token of repository %s/%s expires on %s", repo.GetNamespace(), repo.GetName(), result.ExpiresAt.Format(time.DateOnly)))
+			}
+		}
+
+		if r.metrics != nil {
+			if err := r.metrics.InvalidProviderSecret(repo.GetNamespace(), repo.GetName(), condition.Status == metav1.ConditionFalse); err != nil {
+				logger.Warnf("cannot report the provider secret metric: %v", err)
+			}
+		}
+		if err := r.setRepositoryCondition(ctx, repo, condition); err != nil {
+			logger.Errorf("cannot update the status of repository %s/%s: %v", repo.GetNamespace(), repo.GetName(), err)
+		}
+	}
+}
+
+// setRepositoryCondition sets a condition of the repository status, the
+// status is only updated when the condition changes.
+func (r *Reconciler) setRepositoryCondition(ctx context.Context, repo *v1alpha1.Repository, condition metav1.Condition) error {
+	lastrepo, err := r.run.Clients.PipelineAsCode.PipelinesascodeV1alpha1().Repositories(
+		repo.GetNamespace()).Get(ctx, repo.GetName(), metav1.GetOptions{})
+	if err != nil {
+		return err
+	}
+	if lastrepo.RepositoryStatus == nil {
+		lastrepo.RepositoryStatus = &v1alpha1.RepositoryStatus{}
+	}
+	condition.ObservedGeneration = lastrepo.GetGeneration()
+	if !meta.SetStatusCondition(&lastrepo.RepositoryStatus.Conditions, condition) {
+		return nil
+	}
+	_, err = r.run.Clients.PipelineAsCode.PipelinesascodeV1alpha1().Repositories(lastrepo.GetNamespace()).UpdateStatus(
+		ctx, lastrepo, metav1.UpdateOptions{})
+	return err
+}
@@ -0,0 +1,125 @@
+package reconciler
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	pacv1alpha1 "github.com/openshift-pipelines/pipelines-as-code/pkg/apis/pipelinesascode/v1alpha1"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/events"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/params"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/params/clients"
+	testclient "github.com/openshift-pipelines/pipelines-as-code/pkg/test/clients"
+	kitesthelper "github.com/openshift-pipelines/pipelines-as-code/pkg/test/kubernetestint"
+	"go.uber.org/zap"
+	zapobserver "go.uber.org/zap/zaptest/observer"
+	"gotest.tools/v3/assert"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	rtesting "knative.dev/pkg/reconciler/testing"
+)
+
+func TestProviderSecretValidationInterval(t *testing.T) {
+	tests := []struct {
+		name    string
+		setting string
+		want    time.Duration
+	}{
+		{name: "set", setting: "1h", want: time.Hour},
+		{name: "disabled", setting: "0s", want: 0},
+		{name: "invalid", setting: "nope", want: 24 * time.Hour},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			run := params.New()
+			run.Info.Pac.ProviderSecretValidationInterval = tt.setting
+			r := &Reconciler{run: run}
+			assert.Equal(t, r.providerSecretValidationInterval(), tt.want)
+		})
+	}
+}
+
+func TestValidateProviderSecrets(t *testing.T) {
+	ns := "ns"
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/v1/user", func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "token valid" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		fmt.Fprint(w, `{}`)
+	})
+	mux.HandleFunc("/api/v4/personal_access_tokens/self", func(w http.ResponseWriter, _ *http.Request) {
+		fmt.Fprint(w, `{"active": true, "scopes": ["api"], "expires_at": "2026-01-03"}`)
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	makeRepo := func(name, providerType, secret string) *pacv1alpha1.Repository {
+		repo := &pacv1alpha1.Repository{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: ns},
+			Spec: pacv1alpha1.RepositorySpec{
+				URL: server.URL + "/owner/" + name,
+			},
+		}
+		if providerType != "" {
+			repo.Spec.GitProvider = &pacv1alpha1.GitProvider{
+				Type:   providerType,
+				URL:    server.URL,
+				Secret: &pacv1alpha1.Secret{Name: secret},
+			}
+		}
+		return repo
+	}
+
+	observer, logs := zapobserver.New(zap.InfoLevel)
+	fakelogger := zap.New(observer).Sugar()
+	ctx, _ := rtesting.SetupFakeContext(t)
+	stdata, informers := testclient.SeedTestData(t, ctx, testclient.Data{
+		Repositories: []*pacv1alpha1.Repository{
+			makeRepo("valid", "gitea", "valid"),
+			makeRepo("revoked", "gitea", "revoked"),
+			makeRepo("missing", "gitea", "missing"),
+			makeRepo("expiring", "gitlab", "valid"),
+			makeRepo("inherited", "", ""),
+		},
+	})
+	run := params.New()
+	run.Clients = clients.Clients{
+		PipelineAsCode: stdata.PipelineAsCode,
+		Kube:           stdata.Kube,
+		Log:            fakelogger,
+	}
+	r := &Reconciler{
+		run:          run,
+		repoLister:   informers.Repository.Lister(),
+		kinteract:    &kitesthelper.KinterfaceTest{GetSecretResult: map[string]string{"valid": "valid", "revoked": "revoked"}},
+		eventEmitter: events.NewEventEmitter(stdata.Kube, fakelogger),
+	}
+
+	r.validateProviderSecrets(ctx, fakelogger, now)
+
+	for name, want := range map[string]metav1.ConditionStatus{
+		"valid":    metav1.ConditionTrue,
+		"revoked":  metav1.ConditionFalse,
+		"missing":  metav1.ConditionFalse,
+		"expiring": metav1.ConditionTrue,
+	} {
+		repo, err := stdata.PipelineAsCode.PipelinesascodeV1alpha1().Repositories(ns).Get(ctx, name, metav1.GetOptions{})
+		assert.NilError(t, err)
+		assert.Assert(t, repo.RepositoryStatus != nil, name)
+		condition := meta.FindStatusCondition(repo.RepositoryStatus.Conditions, providerSecretValidCondition)
+		assert.Assert(t, condition != nil, name)
+		assert.Equal(t, condition.Status, want, name)
+	}
+
+	inherited, err := stdata.PipelineAsCode.PipelinesascodeV1alpha1().Repositories(ns).Get(ctx, "inherited", metav1.GetOptions{})
+	assert.NilError(t, err)
+	assert.Assert(t, inherited.RepositoryStatus == nil)
+
+	assert.Equal(t, logs.FilterMessageSnippet("is not valid").Len(), 2)
+	assert.Equal(t, logs.FilterMessageSnippet("expires on 2026-01-03").Len(), 1)
+}
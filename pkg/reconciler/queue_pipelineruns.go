@@ -30,7 +30,7 @@ func (r *Reconciler) queuePipelineRun(ctx context.Context, logger *zap.SugaredLo
 	if repoName == "" {
 		return fmt.Errorf("annotation %s is empty", keys.Repository)
 	}
-	repo, err := r.repoLister.Repositories(pr.Namespace).Get(repoName)
+	repo, err := r.repoLister.Repositories(r.repositoryNamespace(ctx, pr)).Get(repoName)
 	if err != nil {
 		// if repository is not found, then skip processing the pipelineRun and return nil
 		if errors.IsNotFound(err) {
@@ -45,8 +45,9 @@ func (r *Reconciler) queuePipelineRun(ctx context.Context, logger *zap.SugaredLo
 		return fmt.Errorf("error getting PipelineRun: %w", err)
 	}
 
-	// merge local repo with global repo here in order to derive settings from global
-	// for further concurrency and other operations.
+	// merge local repo with the org and global repos here in order to derive
+	// settings from them for further concurrency and other operations.
+	r.inheritOrgRepository(repo)
 	if r.globalRepo, err = r.repoLister.Repositories(r.run.Info.Kube.Namespace).Get(r.run.Info.Controller.GlobalRepository); err == nil && r.globalRepo != nil {
 		logger.Info("Merging global repository settings with local repository settings")
 		repo.Spec.Merge(r.globalRepo.Spec)
@@ -62,6 +63,9 @@ func (r *Reconciler) queuePipelineRun(ctx context.Context, logger *zap.SugaredLo
 		return nil
 	}
 
+	// report the position in the queue of the reconciled pipelineRun once we are done
+	defer r.reportQueuePositions(ctx, logger, repo, sync.PrKey(pr))
+
 	var processed bool
 	var itered int
 	maxIterations := 5
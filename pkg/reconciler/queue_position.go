@@ -0,0 +1,167 @@
+package reconciler
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	gosync "sync"
+	"time"
+
+	"github.com/hako/durafmt"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/apis/pipelinesascode/keys"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/apis/pipelinesascode/v1alpha1"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/formatting"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/params/settings"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/provider"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/sync"
+	tektonv1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+	"go.uber.org/zap"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// queuePositions are the queue positions reported to the git provider for
+// the PipelineRuns of each Repository, for their status to only be updated
+// when it changes. A nil queuePositions reports every status.
+type queuePositions struct {
+	mu       gosync.Mutex
+	reported map[string]map[string]string
+}
+
+// changed returns whether status is not the one reported for the PipelineRun.
+func (q *queuePositions) changed(repoKey, prKey, status string) bool {
+	if q == nil {
+		return true
+	}
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return q.reported[repoKey][prKey] != status
+}
+
+func (q *queuePositions) set(repoKey, prKey, status string) {
+	if q == nil {
+		return
+	}
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if q.reported == nil {
+		q.reported = map[string]map[string]string{}
+	}
+	if q.reported[repoKey] == nil {
+		q.reported[repoKey] = map[string]string{}
+	}
+	q.reported[repoKey][prKey] = status
+}
+
+// keep forgets the PipelineRuns of the Repository which are not queued anymore.
+func (q *queuePositions) keep(repoKey string, queued []string) {
+	if q == nil {
+		return
+	}
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if len(queued) == 0 {
+		delete(q.reported, repoKey)
+		return
+	}
+	kept := map[string]string{}
+	for _, prKey := range queued {
+		if status, ok := q.reported[repoKey][prKey]; ok {
+			kept[prKey] = status
+		}
+	}
+	if q.reported != nil {
+		q.reported[repoKey] = kept
+	}
+}
+
+// reportQueuePositions updates the provider status of the PipelineRuns queued
+// by the concurrency limit with their position in the queue and an estimation
+// of when they are going to start, based on the duration of the previous runs
+// of the Repository. When onlyKey is set only the status of that PipelineRun
+// is updated, since the positions of the others have not changed. Only the
+// PipelineRuns whose position or estimation changed since the last report are
+// fetched and updated, for a completion to not cost a provider call for every
+// PipelineRun of the queue.
+func (r *Reconciler) reportQueuePositions(ctx context.Context, logger *zap.SugaredLogger, repo *v1alpha1.Repository, onlyKey string) {
+	repoKey := sync.RepoKey(repo)
+	if repo.Spec.ConcurrencyLimit == nil || *repo.Spec.ConcurrencyLimit == 0 {
+		r.queuePositions.keep(repoKey, nil)
+		return
+	}
+	limit := *repo.Spec.ConcurrencyLimit
+	average := formatting.AverageRunDuration(repo.Status)
+
+	queued := r.qm.QueuedPipelineRuns(repo)
+	if onlyKey == "" {
+		r.queuePositions.keep(repoKey, queued)
+	}
+	for i, key := range queued {
+		if onlyKey != "" && key != onlyKey {
+			continue
+		}
+		estimatedStart := ""
+		if average > 0 {
+			// every PipelineRun ahead in the queue has to wait for a slot to be freed
+			estimatedStart = durafmt.ParseShort(average * time.Duration(i/limit+1)).String()
+		}
+		status := fmt.Sprintf("%d/%s", i+1, estimatedStart)
+		if !r.queuePositions.changed(repoKey, key, status) {
+			continue
+		}
+		nsName := strings.Split(key, "/")
+		pr, err := r.run.Clients.Tekton.TektonV1().PipelineRuns(nsName[0]).Get(ctx, nsName[1], metav1.GetOptions{})
+		if err != nil || pr.Spec.Status != tektonv1.PipelineRunSpecStatusPending {
+			continue
+		}
+		if err := r.updateQueuePositionStatus(ctx, logger, repo, pr, i+1, estimatedStart); err != nil {
+			logger.Warnf("cannot report queue position of pipelineRun %s: %v", key, err)
+			continue
+		}
+		r.queuePositions.set(repoKey, key, status)
+	}
+}
+
+func (r *Reconciler) updateQueuePositionStatus(ctx context.Context, logger *zap.SugaredLogger, repo *v1alpha1.Repository, pr *tektonv1.PipelineRun, position int, estimatedStart string) error {
+	pacInfo := r.run.Info.GetPacOpts()
+	detectedProvider, event, err := r.detectProvider(ctx, logger, pr)
+	if err != nil {
+		return err
+	}
+	detectedProvider.SetPacInfo(&pacInfo)
+	if err := r.setProviderClient(ctx, logger, repo, &pacInfo, detectedProvider, event); err != nil {
+		return err
+	}
+
+	consoleURL := r.run.Clients.ConsoleUI().DetailURL(pr)
+	mt := formatting.MessageTemplate{
+		PipelineRunName: pr.GetName(),
+		Namespace:       repo.GetNamespace(),
+		ConsoleName:     r.run.Clients.ConsoleUI().GetName(),
+		ConsoleURL:      consoleURL,
+		TknBinary:       settings.TknBinaryName,
+		TknBinaryURL:    settings.TknBinaryURL,
+		QueuePosition:   position,
+		EstimatedStart:  estimatedStart,
+	}
+	msg, err := mt.MakeTemplate(detectedProvider.GetTemplate(provider.QueueingPipelineType))
+	if err != nil {
+		return fmt.Errorf("cannot create message template: %w", err)
+	}
+
+	title := fmt.Sprintf("Queued at position %d", position)
+	if estimatedStart != "" {
+		title = fmt.Sprintf("%s, starting in about %s", title, estimatedStart)
+	}
+	status := provider.StatusOpts{
+		Status:                  "queued",
+		Conclusion:              "pending",
+		Title:                   title,
+		Text:                    msg,
+		DetailsURL:              consoleURL,
+		PipelineRunName:         pr.GetName(),
+		PipelineRun:             pr,
+		OriginalPipelineRunName: pr.GetAnnotations()[keys.OriginalPRName],
+	}
+	// this is only informative, don't retry and slow down the reconciliation if it fails
+	return detectedProvider.CreateStatus(ctx, event, status)
+}
@@ -0,0 +1,114 @@
+package reconciler
+
+import (
+	"testing"
+
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/apis/pipelinesascode/keys"
+	pacv1alpha1 "github.com/openshift-pipelines/pipelines-as-code/pkg/apis/pipelinesascode/v1alpha1"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/kubeinteraction"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/params"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/params/clients"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/params/info"
+	testclient "github.com/openshift-pipelines/pipelines-as-code/pkg/test/clients"
+	testconcurrency "github.com/openshift-pipelines/pipelines-as-code/pkg/test/concurrency"
+	tektonv1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+	"go.uber.org/zap"
+	zapobserver "go.uber.org/zap/zaptest/observer"
+	"gotest.tools/v3/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	rtesting "knative.dev/pkg/reconciler/testing"
+)
+
+func TestReportQueuePositions(t *testing.T) {
+	queuedPR := func(name string, status tektonv1.PipelineRunSpecStatus) *tektonv1.PipelineRun {
+		return &tektonv1.PipelineRun{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: "ns",
+				// no git-provider annotation, reporting to the provider fails
+				Annotations: map[string]string{keys.State: kubeinteraction.StateQueued},
+			},
+			Spec: tektonv1.PipelineRunSpec{Status: status},
+		}
+	}
+	tests := []struct {
+		name             string
+		concurrencyLimit *int
+		onlyKey          string
+		reported         map[string]string
+		wantReported     int
+		wantKept         map[string]string
+	}{
+		{
+			name:         "no concurrency limit",
+			wantReported: 0,
+		},
+		{
+			name:             "all queued pipelineruns",
+			concurrencyLimit: intPtr(1),
+			wantReported:     2,
+		},
+		{
+			name:             "unchanged positions are skipped",
+			concurrencyLimit: intPtr(1),
+			reported:         map[string]string{"ns/first": "1/", "ns/second": "1/", "ns/started": "1/"},
+			wantReported:     1,
+			wantKept:         map[string]string{"ns/first": "1/", "ns/second": "1/"},
+		},
+		{
+			name:             "only the reconciled pipelinerun",
+			concurrencyLimit: intPtr(1),
+			onlyKey:          "ns/second",
+			wantReported:     1,
+		},
+		{
+			name:             "not pending anymore",
+			concurrencyLimit: intPtr(1),
+			onlyKey:          "ns/cancelled",
+			wantReported:     0,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			observer, logcatch := zapobserver.New(zap.InfoLevel)
+			fakelogger := zap.New(observer).Sugar()
+			ctx, _ := rtesting.SetupFakeContext(t)
+
+			repo := &pacv1alpha1.Repository{
+				ObjectMeta: metav1.ObjectMeta{Name: "repo", Namespace: "ns"},
+				Spec:       pacv1alpha1.RepositorySpec{URL: randomURL, ConcurrencyLimit: tt.concurrencyLimit},
+			}
+			stdata, _ := testclient.SeedTestData(t, ctx, testclient.Data{
+				PipelineRuns: []*tektonv1.PipelineRun{
+					queuedPR("first", tektonv1.PipelineRunSpecStatusPending),
+					queuedPR("second", tektonv1.PipelineRunSpecStatusPending),
+					queuedPR("cancelled", tektonv1.PipelineRunSpecStatusCancelled),
+				},
+			})
+			positions := &queuePositions{}
+			for key, status := range tt.reported {
+				positions.set("ns/repo", key, status)
+			}
+			r := &Reconciler{
+				queuePositions: positions,
+				qm: testconcurrency.TestQMI{
+					QueuedPrs: []string{"ns/first", "ns/second", "ns/cancelled", "ns/deleted"},
+				},
+				run: &params.Run{
+					Info: info.Info{Pac: &info.PacOpts{}},
+					Clients: clients.Clients{
+						Tekton: stdata.Pipeline,
+					},
+				},
+			}
+
+			r.reportQueuePositions(ctx, fakelogger, repo, tt.onlyKey)
+			assert.Equal(t, logcatch.FilterMessageSnippet("cannot report queue position").Len(), tt.wantReported)
+			if tt.wantKept != nil {
+				assert.DeepEqual(t, positions.reported["ns/repo"], tt.wantKept)
+			}
+		})
+	}
+}
+
+func intPtr(i int) *int { return &i }
@@ -0,0 +1,75 @@
+package reconciler
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/action"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/apis/pipelinesascode/keys"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/apis/pipelinesascode/v1alpha1"
+	tektonv1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+	"go.uber.org/zap"
+)
+
+// queuePendingTimeout returns the duration a PipelineRun is allowed to stay
+// in the queue, zero means there is no timeout.
+func (r *Reconciler) queuePendingTimeout(logger *zap.SugaredLogger) time.Duration {
+	value := r.run.Info.GetPacOpts().QueuePendingTimeout
+	if value == "" {
+		return 0
+	}
+	timeout, err := time.ParseDuration(value)
+	if err != nil {
+		logger.Warnf("invalid queue-pending-timeout setting %s: %v", value, err)
+		return 0
+	}
+	return timeout
+}
+
+// checkQueuePendingTimeout cancels the queued PipelineRun when it has been
+// waiting for longer than the queue-pending-timeout setting. When the timeout
+// has not been reached yet it returns how long is left so the caller can
+// check it again once it expires.
+func (r *Reconciler) checkQueuePendingTimeout(ctx context.Context, logger *zap.SugaredLogger, pr *tektonv1.PipelineRun) (bool, time.Duration, error) {
+	timeout := r.queuePendingTimeout(logger)
+	if timeout == 0 {
+		return false, 0, nil
+	}
+
+	remaining := timeout - time.Since(pr.GetCreationTimestamp().Time)
+	if remaining > 0 {
+		return false, remaining, nil
+	}
+
+	logger.Infof("pipelineRun %s/%s has been queued for more than %s, cancelling it", pr.GetNamespace(), pr.GetName(), timeout)
+	mergePatch := map[string]any{
+		"metadata": map[string]any{
+			"annotations": map[string]string{
+				keys.QueuePendingTimeout: timeout.String(),
+			},
+		},
+		"spec": map[string]any{
+			"status": tektonv1.PipelineRunSpecStatusCancelled,
+		},
+	}
+	if _, err := action.PatchPipelineRun(ctx, logger, "queue pending timeout", r.run.Clients.Tekton, pr, mergePatch); err != nil {
+		return false, 0, fmt.Errorf("cannot cancel pipelineRun queued for too long: %w", err)
+	}
+
+	if repo, err := r.repoLister.Repositories(r.repositoryNamespace(ctx, pr)).Get(pr.GetAnnotations()[keys.Repository]); err == nil {
+		r.eventEmitter.EmitFailure(repo, zap.WarnLevel, v1alpha1.FailureReasonTimeout, "QueuePendingTimeout",
+			fmt.Sprintf("pipelineRun %s has been cancelled after being queued for more than %s", pr.GetName(), timeout))
+	}
+	return true, 0, nil
+}
+
+// queuePendingTimeoutText returns the text to report on the provider when the
+// PipelineRun has been cancelled because it was queued for too long.
+func queuePendingTimeoutText(pr *tektonv1.PipelineRun) string {
+	timeout, ok := pr.GetAnnotations()[keys.QueuePendingTimeout]
+	if !ok {
+		return ""
+	}
+	return fmt.Sprintf("PipelineRun was queued too long and has been cancelled after waiting more than %s for a free slot in the concurrency queue.", timeout)
+}
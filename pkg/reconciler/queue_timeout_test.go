@@ -0,0 +1,116 @@
+package reconciler
+
+import (
+	"testing"
+	"time"
+
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/apis/pipelinesascode/keys"
+	pacv1alpha1 "github.com/openshift-pipelines/pipelines-as-code/pkg/apis/pipelinesascode/v1alpha1"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/events"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/kubeinteraction"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/params"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/params/clients"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/params/info"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/params/settings"
+	testclient "github.com/openshift-pipelines/pipelines-as-code/pkg/test/clients"
+	tektonv1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+	"go.uber.org/zap"
+	zapobserver "go.uber.org/zap/zaptest/observer"
+	"gotest.tools/v3/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	rtesting "knative.dev/pkg/reconciler/testing"
+)
+
+func TestCheckQueuePendingTimeout(t *testing.T) {
+	tests := []struct {
+		name          string
+		timeout       string
+		queuedSince   time.Duration
+		wantCancelled bool
+		wantRemaining bool
+	}{
+		{
+			name:        "no timeout configured",
+			queuedSince: 10 * time.Hour,
+		},
+		{
+			name:        "invalid timeout is ignored",
+			timeout:     "forever",
+			queuedSince: 10 * time.Hour,
+		},
+		{
+			name:          "timeout not reached",
+			timeout:       "1h",
+			queuedSince:   10 * time.Minute,
+			wantRemaining: true,
+		},
+		{
+			name:          "timeout reached",
+			timeout:       "1h",
+			queuedSince:   2 * time.Hour,
+			wantCancelled: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			observer, _ := zapobserver.New(zap.InfoLevel)
+			fakelogger := zap.New(observer).Sugar()
+			ctx, _ := rtesting.SetupFakeContext(t)
+
+			repo := &pacv1alpha1.Repository{
+				ObjectMeta: metav1.ObjectMeta{Name: "repo", Namespace: "ns"},
+				Spec:       pacv1alpha1.RepositorySpec{URL: randomURL},
+			}
+			pr := &tektonv1.PipelineRun{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:              "queued",
+					Namespace:         "ns",
+					CreationTimestamp: metav1.Time{Time: time.Now().Add(-tt.queuedSince)},
+					Annotations: map[string]string{
+						keys.State:      kubeinteraction.StateQueued,
+						keys.Repository: repo.GetName(),
+					},
+				},
+				Spec: tektonv1.PipelineRunSpec{Status: tektonv1.PipelineRunSpecStatusPending},
+			}
+			stdata, informers := testclient.SeedTestData(t, ctx, testclient.Data{
+				Repositories: []*pacv1alpha1.Repository{repo},
+				PipelineRuns: []*tektonv1.PipelineRun{pr},
+			})
+			r := &Reconciler{
+				repoLister:   informers.Repository.Lister(),
+				eventEmitter: events.NewEventEmitter(stdata.Kube, fakelogger),
+				run: &params.Run{
+					Info: info.Info{
+						Pac: &info.PacOpts{Settings: settings.Settings{QueuePendingTimeout: tt.timeout}},
+					},
+					Clients: clients.Clients{
+						Tekton: stdata.Pipeline,
+						Kube:   stdata.Kube,
+					},
+				},
+			}
+
+			cancelled, remaining, err := r.checkQueuePendingTimeout(ctx, fakelogger, pr)
+			assert.NilError(t, err)
+			assert.Equal(t, cancelled, tt.wantCancelled)
+			assert.Equal(t, remaining > 0, tt.wantRemaining)
+
+			updated, err := stdata.Pipeline.TektonV1().PipelineRuns("ns").Get(ctx, "queued", metav1.GetOptions{})
+			assert.NilError(t, err)
+			if !tt.wantCancelled {
+				assert.Equal(t, updated.Spec.Status, tektonv1.PipelineRunSpecStatus(tektonv1.PipelineRunSpecStatusPending))
+				assert.Equal(t, queuePendingTimeoutText(updated), "")
+				return
+			}
+			assert.Equal(t, updated.Spec.Status, tektonv1.PipelineRunSpecStatus(tektonv1.PipelineRunSpecStatusCancelled))
+			assert.Equal(t, updated.GetAnnotations()[keys.QueuePendingTimeout], "1h0m0s")
+			assert.Assert(t, queuePendingTimeoutText(updated) != "")
+
+			evs, err := stdata.Kube.CoreV1().Events("ns").List(ctx, metav1.ListOptions{})
+			assert.NilError(t, err)
+			assert.Equal(t, len(evs.Items), 1)
+			assert.Equal(t, evs.Items[0].Reason, "QueuePendingTimeout")
+		})
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"slices"
 	"strings"
 
 	tektonv1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
@@ -11,6 +12,8 @@ import (
 	tektonv1lister "github.com/tektoncd/pipeline/pkg/client/listers/pipeline/v1"
 	"go.uber.org/zap"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"knative.dev/pkg/controller"
 	"knative.dev/pkg/logging"
 	pkgreconciler "knative.dev/pkg/reconciler"
 	"knative.dev/pkg/system"
@@ -19,10 +22,12 @@ import (
 	"github.com/openshift-pipelines/pipelines-as-code/pkg/apis/pipelinesascode/keys"
 	"github.com/openshift-pipelines/pipelines-as-code/pkg/apis/pipelinesascode/v1alpha1"
 	"github.com/openshift-pipelines/pipelines-as-code/pkg/customparams"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/ephemeralnamespace"
 	"github.com/openshift-pipelines/pipelines-as-code/pkg/events"
 	"github.com/openshift-pipelines/pipelines-as-code/pkg/formatting"
 	pacapi "github.com/openshift-pipelines/pipelines-as-code/pkg/generated/listers/pipelinesascode/v1alpha1"
 	"github.com/openshift-pipelines/pipelines-as-code/pkg/kubeinteraction"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/matcher"
 	"github.com/openshift-pipelines/pipelines-as-code/pkg/metrics"
 	"github.com/openshift-pipelines/pipelines-as-code/pkg/params"
 	"github.com/openshift-pipelines/pipelines-as-code/pkg/params/info"
@@ -43,6 +48,7 @@ type Reconciler struct {
 	eventEmitter      *events.EventEmitter
 	globalRepo        *v1alpha1.Repository
 	secretNS          string
+	queuePositions    *queuePositions
 }
 
 var (
@@ -90,7 +96,7 @@ func (r *Reconciler) ReconcileKind(ctx context.Context, pr *tektonv1.PipelineRun
 	if reason == string(tektonv1.PipelineRunReasonRunning) && !startReported {
 		logger.Infof("pipelineRun %s/%s is running but not yet reported to provider, updating status", pr.GetNamespace(), pr.GetName())
 		repoName := pr.GetAnnotations()[keys.Repository]
-		repo, err := r.repoLister.Repositories(pr.Namespace).Get(repoName)
+		repo, err := r.repoLister.Repositories(r.repositoryNamespace(ctx, pr)).Get(repoName)
 		if err != nil {
 			return fmt.Errorf("failed to get repository CR: %w", err)
 		}
@@ -108,7 +114,33 @@ func (r *Reconciler) ReconcileKind(ctx context.Context, pr *tektonv1.PipelineRun
 	// queue pipelines which are in queued state and pending status
 	// if status is not pending, it could be canceled so let it be reported, even if state is queued
 	if state == kubeinteraction.StateQueued && pr.Spec.Status == tektonv1.PipelineRunSpecStatusPending {
-		return r.queuePipelineRun(ctx, logger, pr)
+		cancelled, remaining, err := r.checkQueuePendingTimeout(ctx, logger, pr)
+		if err != nil {
+			return err
+		}
+		if cancelled {
+			// the cancellation will trigger a new reconciliation to report the status
+			return nil
+		}
+		if err := r.queuePipelineRun(ctx, logger, pr); err != nil {
+			return err
+		}
+		if remaining > 0 {
+			// come back when the timeout expires if the pipelineRun is still queued
+			return controller.NewRequeueAfter(remaining)
+		}
+		return nil
+	}
+
+	// start pipelines created outside of the trigger windows once the next
+	// one opens
+	if state == kubeinteraction.StateScheduled && pr.Spec.Status == tektonv1.PipelineRunSpecStatusPending {
+		return r.startScheduledPipelineRun(ctx, logger, pr)
+	}
+
+	// start pipelines waiting on other ones, if those have already finished
+	if state == kubeinteraction.StateWaiting && pr.Spec.Status == tektonv1.PipelineRunSpecStatusPending {
+		return r.startWaitingPipelineRun(ctx, logger, pr)
 	}
 
 	if !pr.IsDone() && !pr.IsCancelled() {
@@ -181,12 +213,44 @@ func (r *Reconciler) ReconcileKind(ctx context.Context, pr *tektonv1.PipelineRun
 	return nil
 }
 
+// repositoryNamespace returns the namespace of the Repository of pr, it is
+// the namespace of pr unless pr has been created in another namespace with
+// the pipelinerun-namespace annotation or in an ephemeral namespace. Anybody
+// able to create a PipelineRun can set its repository-namespace annotation,
+// it is only honored when the namespace of pr is in the
+// allowed_pipelinerun_namespaces settings of that Repository or is one of
+// its ephemeral namespaces.
+func (r *Reconciler) repositoryNamespace(ctx context.Context, pr *tektonv1.PipelineRun) string {
+	namespace := pr.GetAnnotations()[keys.RepositoryNamespace]
+	if namespace == "" || namespace == pr.GetNamespace() {
+		return pr.GetNamespace()
+	}
+	repoName := pr.GetAnnotations()[keys.Repository]
+	repo, err := r.repoLister.Repositories(namespace).Get(repoName)
+	if err != nil {
+		return pr.GetNamespace()
+	}
+	if repo.Spec.Settings != nil && slices.Contains(repo.Spec.Settings.AllowedPipelineRunNamespaces, pr.GetNamespace()) {
+		return namespace
+	}
+	ns, err := r.run.Clients.Kube.CoreV1().Namespaces().Get(ctx, pr.GetNamespace(), metav1.GetOptions{})
+	if err != nil {
+		return pr.GetNamespace()
+	}
+	if repoNamespace, nsRepoName, err := ephemeralnamespace.Repository(ns); err == nil &&
+		repoNamespace == namespace && nsRepoName == formatting.CleanValueKubernetes(repoName) {
+		return namespace
+	}
+	return pr.GetNamespace()
+}
+
 func (r *Reconciler) reportFinalStatus(ctx context.Context, logger *zap.SugaredLogger, pacInfo *info.PacOpts, event *info.Event, pr *tektonv1.PipelineRun, provider provider.Interface) (*v1alpha1.Repository, error) {
 	repoName := pr.GetAnnotations()[keys.Repository]
-	repo, err := r.repoLister.Repositories(pr.Namespace).Get(repoName)
+	repo, err := r.repoLister.Repositories(r.repositoryNamespace(ctx, pr)).Get(repoName)
 	if err != nil {
 		return nil, fmt.Errorf("reportFinalStatus: %w", err)
 	}
+	r.inheritOrgRepository(repo)
 
 	r.secretNS = repo.GetNamespace()
 	if r.globalRepo, err = r.repoLister.Repositories(r.run.Info.Kube.Namespace).Get(r.run.Info.Controller.GlobalRepository); err == nil && r.globalRepo != nil {
@@ -230,12 +294,29 @@ func (r *Reconciler) reportFinalStatus(ctx context.Context, logger *zap.SugaredL
 	}
 
 	finalState := kubeinteraction.StateCompleted
-	newPr, err := r.postFinalStatus(ctx, logger, pacInfo, provider, event, pr)
+	newPr, err := r.postFinalStatus(ctx, logger, pacInfo, provider, event, repo, pr)
 	if err != nil {
 		logger.Errorf("failed to post final status, moving on: %v", err)
 		finalState = kubeinteraction.StateFailed
 	}
 
+	if timeout, source, ok := pipelineRunTimeout(pr); ok {
+		r.eventEmitter.EmitFailure(repo, zap.WarnLevel, v1alpha1.FailureReasonTimeout, "PipelineRunTimedOut",
+			fmt.Sprintf("PipelineRun %s has timed out %s", pr.GetName(), timeoutText(timeout, source)))
+	}
+
+	if err := r.reportMatrixSummary(ctx, logger, provider, event, newPr); err != nil {
+		logger.Errorf("failed to post matrix summary status, moving on: %v", err)
+	}
+
+	if err := r.runPostRunHooks(ctx, logger, pacInfo, provider, event, repo, newPr); err != nil {
+		logger.Errorf("failed to run the post-run hooks, moving on: %v", err)
+	}
+
+	if err := r.startDependentPipelineRuns(ctx, logger, pr); err != nil {
+		logger.Errorf("failed to start the pipelineruns depending on %s, moving on: %v", pr.GetName(), err)
+	}
+
 	if err := r.updateRepoRunStatus(ctx, logger, newPr, repo, event); err != nil {
 		return repo, fmt.Errorf("cannot update run status: %w", err)
 	}
@@ -268,6 +349,12 @@ func (r *Reconciler) reportFinalStatus(ctx context.Context, logger *zap.SugaredL
 		}
 		break
 	}
+	r.reportQueuePositions(ctx, logger, repo, "")
+
+	usage := r.pipelineRunUsage(ctx, logger, pacInfo, pr)
+	if err := r.updateRepositoryStatus(ctx, logger, newPr, repo, provider.GetConfig().Name, event, usage); err != nil {
+		logger.Errorf("failed to update the status of repository %s, moving on: %v", repo.GetName(), err)
+	}
 
 	if err := r.cleanupPipelineRuns(ctx, logger, pacInfo, repo, pr); err != nil {
 		return repo, fmt.Errorf("error cleaning pipelineruns: %w", err)
@@ -289,32 +376,8 @@ func (r *Reconciler) updatePipelineRunToInProgress(ctx context.Context, logger *
 	}
 	detectedProvider.SetPacInfo(&pacInfo)
 
-	if event.InstallationID > 0 {
-		event.Provider.WebhookSecret, _ = pac.GetCurrentNSWebhookSecret(ctx, r.kinteract, r.run)
-	} else {
-		// secretNS is needed when git provider is other than Github.
-		secretNS := repo.GetNamespace()
-		if repo.Spec.GitProvider != nil && repo.Spec.GitProvider.Secret == nil && r.globalRepo != nil && r.globalRepo.Spec.GitProvider != nil && r.globalRepo.Spec.GitProvider.Secret != nil {
-			secretNS = r.globalRepo.GetNamespace()
-		}
-
-		secretFromRepo := pac.SecretFromRepository{
-			K8int:       r.kinteract,
-			Config:      detectedProvider.GetConfig(),
-			Event:       event,
-			Repo:        repo,
-			WebhookType: pacInfo.WebhookType,
-			Logger:      logger,
-			Namespace:   secretNS,
-		}
-		if err := secretFromRepo.Get(ctx); err != nil {
-			return fmt.Errorf("cannot get secret from repository: %w", err)
-		}
-	}
-
-	err = detectedProvider.SetClient(ctx, r.run, event, repo, r.eventEmitter)
-	if err != nil {
-		return fmt.Errorf("cannot set client: %w", err)
+	if err := r.setProviderClient(ctx, logger, repo, &pacInfo, detectedProvider, event); err != nil {
+		return err
 	}
 
 	consoleURL := r.run.Clients.ConsoleUI().DetailURL(pr)
@@ -352,6 +415,51 @@ func (r *Reconciler) updatePipelineRunToInProgress(ctx context.Context, logger *
 	return nil
 }
 
+// inheritOrgRepository makes repo inherit the settings of the organization
+// level Repository of its namespace, before the global Repository ones get
+// merged.
+func (r *Reconciler) inheritOrgRepository(repo *v1alpha1.Repository) {
+	candidates, err := r.repoLister.Repositories(repo.GetNamespace()).List(labels.Everything())
+	if err != nil {
+		return
+	}
+	if orgRepo := matcher.MatchOrgRepository(repo.Spec.URL, candidates); orgRepo != nil {
+		repo.Spec.Inherit(orgRepo.Spec)
+	}
+}
+
+// setProviderClient gets the secret for the event and sets the client of the
+// provider so it can be used to report statuses.
+func (r *Reconciler) setProviderClient(ctx context.Context, logger *zap.SugaredLogger, repo *v1alpha1.Repository, pacInfo *info.PacOpts, detectedProvider provider.Interface, event *info.Event) error {
+	if event.InstallationID > 0 {
+		event.Provider.WebhookSecret, _ = pac.GetCurrentNSWebhookSecret(ctx, r.kinteract, r.run)
+	} else {
+		// secretNS is needed when git provider is other than Github.
+		secretNS := repo.GetNamespace()
+		if repo.Spec.GitProvider != nil && repo.Spec.GitProvider.Secret == nil && r.globalRepo != nil && r.globalRepo.Spec.GitProvider != nil && r.globalRepo.Spec.GitProvider.Secret != nil {
+			secretNS = r.globalRepo.GetNamespace()
+		}
+
+		secretFromRepo := pac.SecretFromRepository{
+			K8int:       r.kinteract,
+			Config:      detectedProvider.GetConfig(),
+			Event:       event,
+			Repo:        repo,
+			WebhookType: pacInfo.WebhookType,
+			Logger:      logger,
+			Namespace:   secretNS,
+		}
+		if err := secretFromRepo.Get(ctx); err != nil {
+			return fmt.Errorf("cannot get secret from repository: %w", err)
+		}
+	}
+
+	if err := detectedProvider.SetClient(ctx, r.run, event, repo, r.eventEmitter); err != nil {
+		return fmt.Errorf("cannot set client: %w", err)
+	}
+	return nil
+}
+
 func (r *Reconciler) updatePipelineRunState(ctx context.Context, logger *zap.SugaredLogger, pr *tektonv1.PipelineRun, state string) (*tektonv1.PipelineRun, error) {
 	currentState := pr.GetAnnotations()[keys.State]
 	logger.Infof("updating pipelineRun %v/%v state from %s to %s", pr.GetNamespace(), pr.GetName(), currentState, state)
@@ -10,6 +10,7 @@ import (
 
 	"github.com/google/go-github/v74/github"
 	"github.com/jonboulle/clockwork"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/apis/pipelinesascode"
 	"github.com/openshift-pipelines/pipelines-as-code/pkg/apis/pipelinesascode/keys"
 	"github.com/openshift-pipelines/pipelines-as-code/pkg/apis/pipelinesascode/v1alpha1"
 	"github.com/openshift-pipelines/pipelines-as-code/pkg/consoleui"
@@ -485,3 +486,86 @@ func TestReconcileKind_SCMReportingLogic(t *testing.T) {
 		})
 	}
 }
+
+func TestRepositoryNamespace(t *testing.T) {
+	ephemeral := &corev1.Namespace{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "repo-ns-abcde",
+			Labels: map[string]string{
+				"app.kubernetes.io/managed-by": pipelinesascode.GroupName,
+				keys.EphemeralNamespace:        "true",
+				keys.Repository:                "repo",
+			},
+			Annotations: map[string]string{keys.RepositoryNamespace: "repo-ns"},
+		},
+	}
+	forgedEphemeral := ephemeral.DeepCopy()
+	forgedEphemeral.Name = "attacker-abcde"
+	delete(forgedEphemeral.Labels, "app.kubernetes.io/managed-by")
+
+	tests := []struct {
+		name        string
+		namespace   string
+		annotations map[string]string
+		want        string
+	}{
+		{
+			name:        "no annotation",
+			namespace:   "deployments",
+			annotations: map[string]string{keys.Repository: "repo"},
+			want:        "deployments",
+		},
+		{
+			name:        "allowed pipelinerun namespace",
+			namespace:   "deployments",
+			annotations: map[string]string{keys.Repository: "repo", keys.RepositoryNamespace: "repo-ns"},
+			want:        "repo-ns",
+		},
+		{
+			name:        "ephemeral namespace of the repository",
+			namespace:   ephemeral.Name,
+			annotations: map[string]string{keys.Repository: "repo", keys.RepositoryNamespace: "repo-ns"},
+			want:        "repo-ns",
+		},
+		{
+			name:        "forged annotation",
+			namespace:   "attacker",
+			annotations: map[string]string{keys.Repository: "repo", keys.RepositoryNamespace: "repo-ns"},
+			want:        "attacker",
+		},
+		{
+			name:        "forged annotation in a namespace not created by pipelines-as-code",
+			namespace:   forgedEphemeral.Name,
+			annotations: map[string]string{keys.Repository: "repo", keys.RepositoryNamespace: "repo-ns"},
+			want:        forgedEphemeral.Name,
+		},
+		{
+			name:        "forged annotation with a repository not in the namespace",
+			namespace:   "deployments",
+			annotations: map[string]string{keys.Repository: "other", keys.RepositoryNamespace: "repo-ns"},
+			want:        "deployments",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, _ := rtesting.SetupFakeContext(t)
+			stdata, informers := testclient.SeedTestData(t, ctx, testclient.Data{
+				Namespaces: []*corev1.Namespace{ephemeral, forgedEphemeral},
+				Repositories: []*v1alpha1.Repository{
+					{
+						ObjectMeta: metav1.ObjectMeta{Name: "repo", Namespace: "repo-ns"},
+						Spec: v1alpha1.RepositorySpec{
+							Settings: &v1alpha1.Settings{AllowedPipelineRunNamespaces: []string{"deployments"}},
+						},
+					},
+				},
+			})
+			r := &Reconciler{
+				run:        &params.Run{Clients: clients.Clients{Kube: stdata.Kube}},
+				repoLister: informers.Repository.Lister(),
+			}
+			pr := &tektonv1.PipelineRun{ObjectMeta: metav1.ObjectMeta{Namespace: tt.namespace, Annotations: tt.annotations}}
+			assert.Equal(t, r.repositoryNamespace(ctx, pr), tt.want)
+		})
+	}
+}
@@ -0,0 +1,147 @@
+package reconciler
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"path"
+	"slices"
+
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/action"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/apis/pipelinesascode"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/apis/pipelinesascode/keys"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/apis/pipelinesascode/v1alpha1"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/kubeinteraction"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/params/info"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/pipelineascode"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/provider"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/providerwebhook"
+	tektonv1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/selection"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// repositoryFinalizer lets the watcher clean up after a Repository before it
+// gets deleted.
+var repositoryFinalizer = path.Join(pipelinesascode.GroupName, pipelinesascode.FinalizerName)
+
+// patchRepositoryFinalizers replaces the finalizers of repo.
+func (r *Reconciler) patchRepositoryFinalizers(ctx context.Context, repo *v1alpha1.Repository, finalizers []string) error {
+	patch, _ := json.Marshal(map[string]any{
+		"metadata": map[string]any{
+			"finalizers":      finalizers,
+			"resourceVersion": repo.GetResourceVersion(),
+		},
+	})
+	_, err := r.run.Clients.PipelineAsCode.PipelinesascodeV1alpha1().Repositories(repo.GetNamespace()).Patch(ctx, repo.GetName(), types.MergePatchType, patch, metav1.PatchOptions{})
+	return err
+}
+
+// ensureRepositoryFinalizer adds the finalizer to a Repository which is not
+// being deleted.
+func (r *Reconciler) ensureRepositoryFinalizer(ctx context.Context, logger *zap.SugaredLogger, repo *v1alpha1.Repository) {
+	if repo.GetDeletionTimestamp() != nil || slices.Contains(repo.GetFinalizers(), repositoryFinalizer) {
+		return
+	}
+	if err := r.patchRepositoryFinalizers(ctx, repo, append(slices.Clone(repo.GetFinalizers()), repositoryFinalizer)); err != nil {
+		logger.Errorf("cannot add the finalizer to repository %s/%s: %v", repo.GetNamespace(), repo.GetName(), err)
+	}
+}
+
+// finalizeRepository cleans up after a deleted Repository: the webhook
+// created on its git provider is removed, its queued PipelineRuns are
+// cancelled and the pending statuses of its PipelineRuns are reported as
+// cancelled since they would otherwise never be updated. Failures are only
+// reported as events, the Repository is always let go.
+func (r *Reconciler) finalizeRepository(ctx context.Context, logger *zap.SugaredLogger, repo *v1alpha1.Repository) {
+	if repo.GetDeletionTimestamp() == nil || !slices.Contains(repo.GetFinalizers(), repositoryFinalizer) {
+		return
+	}
+	logger = logger.With("namespace", repo.GetNamespace())
+	logger.Infof("repository %s/%s is being deleted, cleaning up", repo.GetNamespace(), repo.GetName())
+
+	if id := repo.GetAnnotations()[keys.WebhookID]; id != "" {
+		if err := providerwebhook.Delete(ctx, r.run.Clients.Kube, repo, id); err != nil {
+			r.eventEmitter.EmitFailure(repo, zapcore.WarnLevel, v1alpha1.FailureReasonProviderAPIError, "RepositoryWebhookDeleteFailed",
+				fmt.Sprintf("cannot delete the webhook %s of repository %s/%s: %v", id, repo.GetNamespace(), repo.GetName(), err))
+		}
+	}
+
+	r.finalizeRepositoryPipelineRuns(ctx, logger, repo)
+	r.qm.RemoveRepository(repo)
+
+	finalizers := slices.DeleteFunc(slices.Clone(repo.GetFinalizers()), func(f string) bool { return f == repositoryFinalizer })
+	if err := r.patchRepositoryFinalizers(ctx, repo, finalizers); err != nil {
+		logger.Errorf("cannot remove the finalizer of repository %s/%s: %v", repo.GetNamespace(), repo.GetName(), err)
+	}
+}
+
+// finalizeRepositoryPipelineRuns cancels the queued, waiting and scheduled
+// PipelineRuns of a deleted Repository and reports all of its outstanding
+// PipelineRuns as cancelled on the git provider.
+func (r *Reconciler) finalizeRepositoryPipelineRuns(ctx context.Context, logger *zap.SugaredLogger, repo *v1alpha1.Repository) {
+	outstanding, _ := labels.NewRequirement(keys.State, selection.In, []string{kubeinteraction.StateQueued, kubeinteraction.StateWaiting, kubeinteraction.StateScheduled, kubeinteraction.StateStarted})
+	selector := labels.SelectorFromSet(labels.Set{keys.Repository: repo.GetName()}).Add(*outstanding)
+	prs, err := r.run.Clients.Tekton.TektonV1().PipelineRuns(repo.GetNamespace()).List(ctx, metav1.ListOptions{LabelSelector: selector.String()})
+	if err != nil {
+		logger.Errorf("cannot list the pipelineRuns of repository %s/%s: %v", repo.GetNamespace(), repo.GetName(), err)
+		return
+	}
+
+	// the provider secret may be inherited from the org or global repository
+	fullRepo := repo.DeepCopy()
+	r.inheritOrgRepository(fullRepo)
+	if r.run.Info.Controller == nil {
+		r.run.Info.Controller = info.GetControllerInfoFromEnvOrDefault()
+	}
+	if r.globalRepo, err = r.repoLister.Repositories(r.run.Info.Kube.Namespace).Get(r.run.Info.Controller.GlobalRepository); err == nil && r.globalRepo != nil {
+		fullRepo.Spec.Merge(r.globalRepo.Spec)
+	}
+	pacInfo := r.run.Info.GetPacOpts()
+
+	for i := range prs.Items {
+		pr := &prs.Items[i]
+		state := pr.GetAnnotations()[keys.State]
+		mergePatch := map[string]any{
+			"metadata": map[string]any{
+				"labels":      map[string]string{keys.State: kubeinteraction.StateCompleted},
+				"annotations": map[string]string{keys.State: kubeinteraction.StateCompleted},
+			},
+		}
+		text := fmt.Sprintf("Repository %s has been deleted, the result of this PipelineRun will not be reported.", repo.GetName())
+		if state != kubeinteraction.StateStarted {
+			mergePatch["spec"] = map[string]any{"status": tektonv1.PipelineRunSpecStatusCancelled}
+			text = fmt.Sprintf("PipelineRun has been cancelled because Repository %s has been deleted.", repo.GetName())
+		}
+		if _, err := action.PatchPipelineRun(ctx, logger, "repository deleted", r.run.Clients.Tekton, pr, mergePatch); err != nil {
+			logger.Errorf("cannot cancel pipelineRun %s/%s: %v", pr.GetNamespace(), pr.GetName(), err)
+			continue
+		}
+
+		detectedProvider, event, err := r.detectProvider(ctx, logger, pr)
+		if err != nil {
+			logger.Errorf("cannot report the status of pipelineRun %s/%s: %v", pr.GetNamespace(), pr.GetName(), err)
+			continue
+		}
+		detectedProvider.SetPacInfo(&pacInfo)
+		if err := r.setProviderClient(ctx, logger, fullRepo, &pacInfo, detectedProvider, event); err != nil {
+			logger.Errorf("cannot report the status of pipelineRun %s/%s: %v", pr.GetNamespace(), pr.GetName(), err)
+			continue
+		}
+		if err := detectedProvider.CreateStatus(ctx, event, provider.StatusOpts{
+			Status:                  pipelineascode.CompletedStatus,
+			Conclusion:              "cancelled",
+			Text:                    text,
+			PipelineRun:             pr,
+			PipelineRunName:         pr.GetName(),
+			DetailsURL:              r.run.Clients.ConsoleUI().DetailURL(pr),
+			OriginalPipelineRunName: pr.GetAnnotations()[keys.OriginalPRName],
+		}); err != nil {
+			logger.Errorf("cannot report the status of pipelineRun %s/%s: %v", pr.GetNamespace(), pr.GetName(), err)
+		}
+	}
+}
@@ -0,0 +1,191 @@
+package reconciler
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/apis/pipelinesascode/keys"
+	pacv1alpha1 "github.com/openshift-pipelines/pipelines-as-code/pkg/apis/pipelinesascode/v1alpha1"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/consoleui"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/events"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/kubeinteraction"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/params"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/params/clients"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/params/info"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/sync"
+	testclient "github.com/openshift-pipelines/pipelines-as-code/pkg/test/clients"
+	kitesthelper "github.com/openshift-pipelines/pipelines-as-code/pkg/test/kubernetestint"
+	tektonv1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+	"go.uber.org/zap"
+	zapobserver "go.uber.org/zap/zaptest/observer"
+	"gotest.tools/v3/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	rtesting "knative.dev/pkg/reconciler/testing"
+)
+
+func TestEnsureRepositoryFinalizer(t *testing.T) {
+	now := metav1.Now()
+	tests := []struct {
+		name              string
+		deletionTimestamp *metav1.Time
+		finalizers        []string
+		want              []string
+	}{
+		{
+			name:       "added",
+			finalizers: []string{"other"},
+			want:       []string{"other", repositoryFinalizer},
+		},
+		{
+			name:       "already there",
+			finalizers: []string{repositoryFinalizer},
+			want:       []string{repositoryFinalizer},
+		},
+		{
+			name:              "being deleted",
+			deletionTimestamp: &now,
+			finalizers:        []string{"other"},
+			want:              []string{"other"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			observer, _ := zapobserver.New(zap.InfoLevel)
+			fakelogger := zap.New(observer).Sugar()
+			ctx, _ := rtesting.SetupFakeContext(t)
+			repo := &pacv1alpha1.Repository{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:              "repo",
+					Namespace:         "ns",
+					DeletionTimestamp: tt.deletionTimestamp,
+					Finalizers:        tt.finalizers,
+				},
+			}
+			stdata, _ := testclient.SeedTestData(t, ctx, testclient.Data{Repositories: []*pacv1alpha1.Repository{repo}})
+			run := params.New()
+			run.Clients = clients.Clients{PipelineAsCode: stdata.PipelineAsCode, Log: fakelogger}
+			r := &Reconciler{run: run}
+
+			r.ensureRepositoryFinalizer(ctx, fakelogger, repo)
+
+			got, err := stdata.PipelineAsCode.PipelinesascodeV1alpha1().Repositories("ns").Get(ctx, "repo", metav1.GetOptions{})
+			assert.NilError(t, err)
+			assert.DeepEqual(t, got.GetFinalizers(), tt.want)
+		})
+	}
+}
+
+func TestFinalizeRepository(t *testing.T) {
+	ns := "ns"
+	webhookDeleted := false
+	statuses := map[string]string{}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/v1/version", func(w http.ResponseWriter, _ *http.Request) {
+		fmt.Fprint(w, `{"version": "1.20.0"}`)
+	})
+	mux.HandleFunc("/api/v1/repos/owner/repo/hooks/1", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, r.Method, http.MethodDelete)
+		webhookDeleted = true
+		w.WriteHeader(http.StatusNoContent)
+	})
+	mux.HandleFunc("/api/v1/repos/owner/repo/statuses/", func(w http.ResponseWriter, r *http.Request) {
+		statuses[r.URL.Path[len("/api/v1/repos/owner/repo/statuses/"):]] = r.Method
+		w.WriteHeader(http.StatusCreated)
+		fmt.Fprint(w, `{}`)
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	now := metav1.Now()
+	repo := &pacv1alpha1.Repository{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:              "repo",
+			Namespace:         ns,
+			DeletionTimestamp: &now,
+			Finalizers:        []string{repositoryFinalizer},
+			Annotations:       map[string]string{keys.WebhookID: "1"},
+		},
+		Spec: pacv1alpha1.RepositorySpec{
+			URL: server.URL + "/owner/repo",
+			GitProvider: &pacv1alpha1.GitProvider{
+				Type:   "gitea",
+				URL:    server.URL,
+				Secret: &pacv1alpha1.Secret{Name: "provider"},
+			},
+		},
+	}
+	makePR := func(name, state string, specStatus tektonv1.PipelineRunSpecStatus) *tektonv1.PipelineRun {
+		return &tektonv1.PipelineRun{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: ns,
+				Labels:    map[string]string{keys.Repository: "repo", keys.State: state},
+				Annotations: map[string]string{
+					keys.Repository:     "repo",
+					keys.State:          state,
+					keys.GitProvider:    "gitea",
+					keys.SHA:            name,
+					keys.URLOrg:         "owner",
+					keys.URLRepository:  "repo",
+					keys.OriginalPRName: name,
+				},
+			},
+			Spec: tektonv1.PipelineRunSpec{Status: specStatus},
+		}
+	}
+
+	observer, _ := zapobserver.New(zap.InfoLevel)
+	fakelogger := zap.New(observer).Sugar()
+	ctx, _ := rtesting.SetupFakeContext(t)
+	stdata, informers := testclient.SeedTestData(t, ctx, testclient.Data{
+		Repositories: []*pacv1alpha1.Repository{repo},
+		PipelineRuns: []*tektonv1.PipelineRun{
+			makePR("queued", kubeinteraction.StateQueued, tektonv1.PipelineRunSpecStatusPending),
+			makePR("started", kubeinteraction.StateStarted, ""),
+			makePR("completed", kubeinteraction.StateCompleted, ""),
+		},
+		Secret: []*corev1.Secret{{
+			ObjectMeta: metav1.ObjectMeta{Name: "provider", Namespace: ns},
+			Data:       map[string][]byte{"provider.token": []byte("token")},
+		}},
+	})
+	run := params.New()
+	run.Info.Kube = &info.KubeOpts{Namespace: "global"}
+	run.Info.Controller = &info.ControllerInfo{}
+	run.Clients = clients.Clients{
+		PipelineAsCode: stdata.PipelineAsCode,
+		Tekton:         stdata.Pipeline,
+		Kube:           stdata.Kube,
+		Log:            fakelogger,
+	}
+	run.Clients.SetConsoleUI(consoleui.FallBackConsole{})
+	r := &Reconciler{
+		run:          run,
+		repoLister:   informers.Repository.Lister(),
+		kinteract:    &kitesthelper.KinterfaceTest{GetSecretResult: map[string]string{"provider": "token"}},
+		qm:           sync.NewQueueManager(fakelogger),
+		eventEmitter: events.NewEventEmitter(stdata.Kube, fakelogger),
+	}
+
+	r.finalizeRepository(ctx, fakelogger, repo)
+
+	assert.Assert(t, webhookDeleted)
+	assert.DeepEqual(t, statuses, map[string]string{"queued": http.MethodPost, "started": http.MethodPost})
+
+	queued, err := stdata.Pipeline.TektonV1().PipelineRuns(ns).Get(ctx, "queued", metav1.GetOptions{})
+	assert.NilError(t, err)
+	assert.Equal(t, queued.Spec.Status, tektonv1.PipelineRunSpecStatus(tektonv1.PipelineRunSpecStatusCancelled))
+	assert.Equal(t, queued.GetLabels()[keys.State], kubeinteraction.StateCompleted)
+
+	started, err := stdata.Pipeline.TektonV1().PipelineRuns(ns).Get(ctx, "started", metav1.GetOptions{})
+	assert.NilError(t, err)
+	assert.Equal(t, started.Spec.Status, tektonv1.PipelineRunSpecStatus(""))
+	assert.Equal(t, started.GetLabels()[keys.State], kubeinteraction.StateCompleted)
+
+	got, err := stdata.PipelineAsCode.PipelinesascodeV1alpha1().Repositories(ns).Get(ctx, "repo", metav1.GetOptions{})
+	assert.NilError(t, err)
+	assert.Equal(t, len(got.GetFinalizers()), 0)
+}
@@ -0,0 +1,66 @@
+package reconciler
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/apis/pipelinesascode/keys"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/apis/pipelinesascode/v1alpha1"
+	pacinfo "github.com/openshift-pipelines/pipelines-as-code/pkg/cli/info"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/providerwebhook"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// configureRepositoryWebhook creates the webhook of a repository opting in
+// with the auto-configure-webhook annotation on its Gitea or GitLab project,
+// the ID of the webhook is kept in an annotation so it only gets done once.
+func (r *Reconciler) configureRepositoryWebhook(ctx context.Context, logger *zap.SugaredLogger, repo *v1alpha1.Repository) {
+	if repo.GetAnnotations()[keys.AutoConfigureWebhook] != "true" || repo.GetAnnotations()[keys.WebhookID] != "" {
+		return
+	}
+	if !providerwebhook.IsSupported(repo) {
+		r.eventEmitter.EmitMessage(repo, zapcore.WarnLevel, "RepositoryWebhookNotSupported",
+			fmt.Sprintf("cannot auto-configure the webhook of repository %s/%s, only gitea and gitlab git_provider types are supported", repo.GetNamespace(), repo.GetName()))
+		return
+	}
+
+	controllerURL := ""
+	pacInfo, err := pacinfo.GetPACInfo(ctx, r.run, r.run.Info.Kube.Namespace)
+	if err == nil {
+		// the repository may target another listener of the controller, i.e:
+		// the in-cluster one for a Gitea instance running on the cluster
+		if controllerURL, err = pacInfo.GetControllerURL(repo.GetAnnotations()[keys.WebhookListener]); err != nil {
+			r.eventEmitter.EmitFailure(repo, zapcore.ErrorLevel, v1alpha1.FailureReasonProviderAPIError, "RepositoryWebhookFailed",
+				fmt.Sprintf("cannot auto-configure the webhook of repository %s/%s: %v", repo.GetNamespace(), repo.GetName(), err))
+			return
+		}
+	}
+	if controllerURL == "" {
+		r.eventEmitter.EmitFailure(repo, zapcore.ErrorLevel, v1alpha1.FailureReasonProviderAPIError, "RepositoryWebhookFailed",
+			fmt.Sprintf("cannot auto-configure the webhook of repository %s/%s, the controller-url of the pipelines-as-code-info configmap is not set", repo.GetNamespace(), repo.GetName()))
+		return
+	}
+
+	id, err := providerwebhook.Configure(ctx, r.run.Clients.Kube, repo, controllerURL)
+	if err != nil {
+		r.eventEmitter.EmitFailure(repo, zapcore.ErrorLevel, v1alpha1.FailureReasonProviderAPIError, "RepositoryWebhookFailed",
+			fmt.Sprintf("cannot auto-configure the webhook of repository %s/%s: %v", repo.GetNamespace(), repo.GetName(), err))
+		return
+	}
+
+	patch, _ := json.Marshal(map[string]any{
+		"metadata": map[string]any{
+			"annotations": map[string]string{keys.WebhookID: id},
+		},
+	})
+	if _, err := r.run.Clients.PipelineAsCode.PipelinesascodeV1alpha1().Repositories(repo.GetNamespace()).Patch(ctx, repo.GetName(), types.MergePatchType, patch, metav1.PatchOptions{}); err != nil {
+		logger.Errorf("cannot record the webhook id %s on repository %s/%s: %v", id, repo.GetNamespace(), repo.GetName(), err)
+		return
+	}
+	r.eventEmitter.EmitMessage(repo, zapcore.InfoLevel, "RepositoryWebhookConfigured",
+		fmt.Sprintf("webhook %s of repository %s/%s has been configured to %s", id, repo.GetNamespace(), repo.GetName(), controllerURL))
+}
@@ -0,0 +1,130 @@
+package reconciler
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/apis/pipelinesascode/keys"
+	pacv1alpha1 "github.com/openshift-pipelines/pipelines-as-code/pkg/apis/pipelinesascode/v1alpha1"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/events"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/params"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/params/clients"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/params/info"
+	testclient "github.com/openshift-pipelines/pipelines-as-code/pkg/test/clients"
+	"go.uber.org/zap"
+	zapobserver "go.uber.org/zap/zaptest/observer"
+	"gotest.tools/v3/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	rtesting "knative.dev/pkg/reconciler/testing"
+)
+
+func TestConfigureRepositoryWebhook(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/v4/projects/owner%2Frepo/hooks", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodGet {
+			fmt.Fprint(w, `[]`)
+			return
+		}
+		w.WriteHeader(http.StatusCreated)
+		fmt.Fprint(w, `{"id": 42}`)
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	infoConfigMap := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: "pipelines-as-code-info", Namespace: "pac"},
+		Data: map[string]string{
+			"controller-url":  "https://pac.example.com",
+			"controller-urls": "internal=http://pipelines-as-code-controller.pac:8080",
+		},
+	}
+	tests := []struct {
+		name        string
+		annotations map[string]string
+		configMaps  []*corev1.ConfigMap
+		wantID      string
+		wantLog     string
+	}{
+		{
+			name:       "not opted in",
+			configMaps: []*corev1.ConfigMap{infoConfigMap},
+		},
+		{
+			name:        "already configured",
+			annotations: map[string]string{keys.AutoConfigureWebhook: "true", keys.WebhookID: "1"},
+			configMaps:  []*corev1.ConfigMap{infoConfigMap},
+			wantID:      "1",
+		},
+		{
+			name:        "no controller url",
+			annotations: map[string]string{keys.AutoConfigureWebhook: "true"},
+			wantLog:     "cannot auto-configure the webhook of repository ns/repo, the controller-url of the pipelines-as-code-info configmap is not set",
+		},
+		{
+			name:        "configured",
+			annotations: map[string]string{keys.AutoConfigureWebhook: "true"},
+			configMaps:  []*corev1.ConfigMap{infoConfigMap},
+			wantID:      "42",
+			wantLog:     "webhook 42 of repository ns/repo has been configured to https://pac.example.com",
+		},
+		{
+			name:        "configured to another listener",
+			annotations: map[string]string{keys.AutoConfigureWebhook: "true", keys.WebhookListener: "internal"},
+			configMaps:  []*corev1.ConfigMap{infoConfigMap},
+			wantID:      "42",
+			wantLog:     "webhook 42 of repository ns/repo has been configured to http://pipelines-as-code-controller.pac:8080",
+		},
+		{
+			name:        "unknown listener",
+			annotations: map[string]string{keys.AutoConfigureWebhook: "true", keys.WebhookListener: "public"},
+			configMaps:  []*corev1.ConfigMap{infoConfigMap},
+			wantLog:     `cannot auto-configure the webhook of repository ns/repo: listener "public" is not in the controller-urls of the pipelines-as-code-info configmap, the listeners are: internal`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			observer, logs := zapobserver.New(zap.InfoLevel)
+			fakelogger := zap.New(observer).Sugar()
+			ctx, _ := rtesting.SetupFakeContext(t)
+			repo := &pacv1alpha1.Repository{
+				ObjectMeta: metav1.ObjectMeta{Name: "repo", Namespace: "ns", Annotations: tt.annotations},
+				Spec: pacv1alpha1.RepositorySpec{
+					URL: "https://gitlab.example.com/owner/repo",
+					GitProvider: &pacv1alpha1.GitProvider{
+						Type:   "gitlab",
+						URL:    server.URL,
+						Secret: &pacv1alpha1.Secret{Name: "provider"},
+					},
+				},
+			}
+			stdata, _ := testclient.SeedTestData(t, ctx, testclient.Data{
+				Repositories: []*pacv1alpha1.Repository{repo},
+				ConfigMap:    tt.configMaps,
+				Secret: []*corev1.Secret{{
+					ObjectMeta: metav1.ObjectMeta{Name: "provider", Namespace: "ns"},
+					Data:       map[string][]byte{"provider.token": []byte("token")},
+				}},
+			})
+			run := params.New()
+			run.Info.Kube = &info.KubeOpts{Namespace: "pac"}
+			run.Clients = clients.Clients{
+				PipelineAsCode: stdata.PipelineAsCode,
+				Kube:           stdata.Kube,
+				Log:            fakelogger,
+			}
+			r := &Reconciler{run: run, eventEmitter: events.NewEventEmitter(stdata.Kube, fakelogger)}
+
+			r.configureRepositoryWebhook(ctx, fakelogger, repo)
+
+			got, err := stdata.PipelineAsCode.PipelinesascodeV1alpha1().Repositories("ns").Get(ctx, "repo", metav1.GetOptions{})
+			assert.NilError(t, err)
+			assert.Equal(t, got.GetAnnotations()[keys.WebhookID], tt.wantID)
+			if tt.wantLog != "" {
+				assert.Equal(t, logs.FilterMessage(tt.wantLog).Len(), 1)
+			}
+		})
+	}
+}
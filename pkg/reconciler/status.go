@@ -3,13 +3,17 @@ package reconciler
 import (
 	"context"
 	"fmt"
+	"maps"
+	"slices"
 	"strings"
 	"time"
 
 	"github.com/google/go-github/v74/github"
+	"github.com/hako/durafmt"
 	apipac "github.com/openshift-pipelines/pipelines-as-code/pkg/apis/pipelinesascode/keys"
 	pacv1a1 "github.com/openshift-pipelines/pipelines-as-code/pkg/apis/pipelinesascode/v1alpha1"
 	"github.com/openshift-pipelines/pipelines-as-code/pkg/formatting"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/issuetracker"
 	kstatus "github.com/openshift-pipelines/pipelines-as-code/pkg/kubeinteraction/status"
 	"github.com/openshift-pipelines/pipelines-as-code/pkg/params/info"
 	"github.com/openshift-pipelines/pipelines-as-code/pkg/params/settings"
@@ -37,16 +41,17 @@ var backoffSchedule = []time.Duration{
 func (r *Reconciler) updateRepoRunStatus(ctx context.Context, logger *zap.SugaredLogger, pr *tektonv1.PipelineRun, repo *pacv1a1.Repository, event *info.Event) error {
 	refsanitized := formatting.SanitizeBranch(event.BaseBranch)
 	repoStatus := pacv1a1.RepositoryRunStatus{
-		Status:          pr.Status.Status,
-		PipelineRunName: pr.Name,
-		StartTime:       pr.Status.StartTime,
-		CompletionTime:  pr.Status.CompletionTime,
-		SHA:             &event.SHA,
-		SHAURL:          &event.SHAURL,
-		Title:           &event.SHATitle,
-		LogURL:          github.Ptr(r.run.Clients.ConsoleUI().DetailURL(pr)),
-		EventType:       &event.EventType,
-		TargetBranch:    &refsanitized,
+		Status:                  pr.Status.Status,
+		PipelineRunName:         pr.Name,
+		OriginalPipelineRunName: pr.GetAnnotations()[apipac.OriginalPRName],
+		StartTime:               pr.Status.StartTime,
+		CompletionTime:          pr.Status.CompletionTime,
+		SHA:                     &event.SHA,
+		SHAURL:                  &event.SHAURL,
+		Title:                   &event.SHATitle,
+		LogURL:                  github.Ptr(r.run.Clients.ConsoleUI().DetailURL(pr)),
+		EventType:               &event.EventType,
+		TargetBranch:            &refsanitized,
 	}
 
 	// Get repository again in case it was updated while we were running the CI
@@ -80,24 +85,75 @@ func (r *Reconciler) updateRepoRunStatus(ctx context.Context, logger *zap.Sugare
 	return fmt.Errorf("cannot update %s", repo.Name)
 }
 
-func (r *Reconciler) getFailureSnippet(ctx context.Context, pr *tektonv1.PipelineRun) string {
-	taskinfos := kstatus.CollectFailedTasksLogSnippet(ctx, r.run, r.kinteract, pr, logSnippetNumLines)
-	if len(taskinfos) == 0 {
+// updateRepositoryStatus records the last run, the queue, the daily usage and
+// the generation of the repository in its status subresource.
+func (r *Reconciler) updateRepositoryStatus(ctx context.Context, logger *zap.SugaredLogger, pr *tektonv1.PipelineRun, repo *pacv1a1.Repository, providerName string, event *info.Event, usage pacv1a1.DailyUsage) error {
+	queued := 0
+	if r.qm != nil {
+		queued = len(r.qm.QueuedPipelineRuns(repo))
+	}
+	lastStatus := ""
+	if cond := pr.Status.GetCondition(apis.ConditionSucceeded); cond != nil {
+		lastStatus = cond.Reason
+	}
+
+	maxRun := 10
+	for i := 0; i < maxRun; i++ {
+		lastrepo, err := r.run.Clients.PipelineAsCode.PipelinesascodeV1alpha1().Repositories(
+			repo.GetNamespace()).Get(ctx, repo.GetName(), metav1.GetOptions{})
+		if err != nil {
+			return err
+		}
+		var conditions []metav1.Condition
+		var coverage []pacv1a1.BranchCoverage
+		var lastFailure *pacv1a1.RepositoryFailure
+		var usages []pacv1a1.DailyUsage
+		if lastrepo.RepositoryStatus != nil {
+			conditions = lastrepo.RepositoryStatus.Conditions
+			coverage = lastrepo.RepositoryStatus.Coverage
+			lastFailure = lastrepo.RepositoryStatus.LastFailure
+			usages = lastrepo.RepositoryStatus.Usage
+		}
+		lastrepo.RepositoryStatus = &pacv1a1.RepositoryStatus{
+			ObservedGeneration: lastrepo.GetGeneration(),
+			Provider:           providerName,
+			LastEvent:          event.EventType,
+			LastStatus:         lastStatus,
+			Queued:             queued,
+			Conditions:         conditions,
+			Coverage:           coverage,
+			LastFailure:        lastFailure,
+			Usage:              addUsage(usages, usage, time.Now()),
+		}
+		if _, err := r.run.Clients.PipelineAsCode.PipelinesascodeV1alpha1().Repositories(lastrepo.GetNamespace()).UpdateStatus(
+			ctx, lastrepo, metav1.UpdateOptions{}); err != nil {
+			logger.Infof("Could not update the status of repo %s, retrying %d/%d: %s", lastrepo.GetName(), i, maxRun, err.Error())
+			continue
+		}
+		return nil
+	}
+
+	return fmt.Errorf("cannot update the status of %s", repo.GetName())
+}
+
+// failureSnippet returns the logs of the first failed task, failedTasks are
+// sorted by completion time.
+func failureSnippet(failedTasks []pacv1a1.TaskInfos) string {
+	if len(failedTasks) == 0 {
 		return ""
 	}
-	sortedTaskInfos := sort.TaskInfos(taskinfos)
-	text := strings.TrimSpace(sortedTaskInfos[0].LogSnippet)
+	text := strings.TrimSpace(failedTasks[0].LogSnippet)
 	if text == "" {
-		text = sortedTaskInfos[0].Message
+		text = failedTasks[0].Message
 	}
-	name := sortedTaskInfos[0].Name
-	if sortedTaskInfos[0].DisplayName != "" {
-		name = strings.ToLower(sortedTaskInfos[0].DisplayName)
+	name := failedTasks[0].Name
+	if failedTasks[0].DisplayName != "" {
+		name = strings.ToLower(failedTasks[0].DisplayName)
 	}
-	return fmt.Sprintf("task <b>%s</b> has the status <b>\"%s\"</b>:\n<pre>%s</pre>", name, sortedTaskInfos[0].Reason, text)
+	return fmt.Sprintf("task <b>%s</b> has the status <b>\"%s\"</b>:\n<pre>%s</pre>", name, failedTasks[0].Reason, text)
 }
 
-func (r *Reconciler) postFinalStatus(ctx context.Context, logger *zap.SugaredLogger, pacInfo *info.PacOpts, vcx provider.Interface, event *info.Event, createdPR *tektonv1.PipelineRun) (*tektonv1.PipelineRun, error) {
+func (r *Reconciler) postFinalStatus(ctx context.Context, logger *zap.SugaredLogger, pacInfo *info.PacOpts, vcx provider.Interface, event *info.Event, repo *pacv1a1.Repository, createdPR *tektonv1.PipelineRun) (*tektonv1.PipelineRun, error) {
 	pr, err := r.run.Clients.Tekton.TektonV1().PipelineRuns(createdPR.GetNamespace()).Get(
 		ctx, createdPR.GetName(), metav1.GetOptions{},
 	)
@@ -116,6 +172,9 @@ func (r *Reconciler) postFinalStatus(ctx context.Context, logger *zap.SugaredLog
 	} else {
 		taskStatusText = pr.Status.GetCondition(apis.ConditionSucceeded).Message
 	}
+	if timeoutText := queuePendingTimeoutText(pr); timeoutText != "" {
+		taskStatusText = timeoutText
+	}
 
 	namespaceURL := r.run.Clients.ConsoleUI().NamespaceURL(pr)
 	consoleURL := r.run.Clients.ConsoleUI().DetailURL(pr)
@@ -129,8 +188,30 @@ func (r *Reconciler) postFinalStatus(ctx context.Context, logger *zap.SugaredLog
 		TknBinaryURL:    settings.TknBinaryURL,
 		TaskStatus:      taskStatusText,
 	}
+	setDurationTrend(&mt, repo, pr)
+	if timeout, source, ok := pipelineRunTimeout(pr); ok {
+		mt.Timeout = timeoutText(timeout, source)
+	}
+	if summary, err := testSummary(pr, trStatus); err != nil {
+		logger.Warnf("cannot report the tests of pipelinerun %s: %v", pr.GetName(), err)
+	} else {
+		mt.TestSummary = summary
+	}
+	var failedTasks []pacv1a1.TaskInfos
+	if pacInfo.ErrorLogSnippet || formatting.PipelineRunStatus(pr) == "failure" {
+		failedTasks = sort.TaskInfos(kstatus.CollectFailedTasksLogSnippet(ctx, r.run, r.kinteract, pr, logSnippetNumLines))
+	}
+	if ticketID := pr.GetAnnotations()[apipac.TicketID]; ticketID != "" {
+		mt.TicketID = ticketID
+		mt.TicketURL = issuetracker.TicketURL(repo, ticketID)
+	}
+	if failure := checkoutFailure(pr, failedTasks); failure != "" {
+		mt.CheckoutFailure = failure
+		r.eventEmitter.EmitFailure(repo, zap.WarnLevel, pacv1a1.FailureReasonCheckoutFailed, "PipelineRunCheckoutFailed",
+			fmt.Sprintf("the source checkout of PipelineRun %s failed in %s", pr.GetName(), failure))
+	}
 	if pacInfo.ErrorLogSnippet {
-		failures := r.getFailureSnippet(ctx, pr)
+		failures := failureSnippet(failedTasks)
 		if failures != "" {
 			secretValues := secrets.GetSecretsAttachedToPipelineRun(ctx, r.kinteract, pr)
 			failures = secrets.ReplaceSecretsInText(failures, secretValues)
@@ -157,6 +238,59 @@ func (r *Reconciler) postFinalStatus(ctx context.Context, logger *zap.SugaredLog
 	return pr, err
 }
 
+// testSummary returns the summary of the JUnit reports of the task result set
+// in the junit-result annotation, nil when no task has the result.
+func testSummary(pr *tektonv1.PipelineRun, trStatus map[string]*tektonv1.PipelineRunTaskRunStatus) (*formatting.TestSummary, error) {
+	resultName := pr.GetAnnotations()[apipac.JUnitResult]
+	if resultName == "" {
+		return nil, nil
+	}
+	// the map of the TaskRuns is walked in order, for the same failed tests
+	// to be listed on every status update
+	var summary *formatting.TestSummary
+	for _, name := range slices.Sorted(maps.Keys(trStatus)) {
+		tr := trStatus[name]
+		if tr == nil || tr.Status == nil {
+			continue
+		}
+		for _, result := range tr.Status.Results {
+			if result.Name != resultName {
+				continue
+			}
+			if summary == nil {
+				summary = &formatting.TestSummary{}
+			}
+			if err := summary.ParseJUnit(result.Value.StringVal); err != nil {
+				return nil, fmt.Errorf("task %s: %w", tr.PipelineTaskName, err)
+			}
+		}
+	}
+	return summary, nil
+}
+
+// setDurationTrend adds to the message the duration of the PipelineRun and how
+// it compares to the previous runs of the same pipeline recorded in the
+// Repository status.
+func setDurationTrend(mt *formatting.MessageTemplate, repo *pacv1a1.Repository, pr *tektonv1.PipelineRun) {
+	if pr.Status.StartTime == nil || pr.Status.CompletionTime == nil {
+		return
+	}
+	duration := pr.Status.CompletionTime.Sub(pr.Status.StartTime.Time)
+	if duration <= 0 {
+		return
+	}
+	mt.Duration = durafmt.ParseShort(duration).String()
+	if repo == nil {
+		return
+	}
+	average := formatting.PipelineAverageDuration(repo.Status, pr.GetAnnotations()[apipac.OriginalPRName])
+	if average == 0 {
+		return
+	}
+	mt.AverageDuration = durafmt.ParseShort(average).String()
+	mt.DurationTrend = formatting.DurationTrend(duration, average)
+}
+
 func createStatusWithRetry(ctx context.Context, logger *zap.SugaredLogger, vcx provider.Interface, event *info.Event, status provider.StatusOpts) error {
 	var finalError error
 	for _, backoff := range backoffSchedule {
@@ -3,21 +3,27 @@ package reconciler
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/jonboulle/clockwork"
+	apipac "github.com/openshift-pipelines/pipelines-as-code/pkg/apis/pipelinesascode/keys"
+	pacv1a1 "github.com/openshift-pipelines/pipelines-as-code/pkg/apis/pipelinesascode/v1alpha1"
 	"github.com/openshift-pipelines/pipelines-as-code/pkg/consoleui"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/formatting"
 	"github.com/openshift-pipelines/pipelines-as-code/pkg/params"
 	"github.com/openshift-pipelines/pipelines-as-code/pkg/params/clients"
 	"github.com/openshift-pipelines/pipelines-as-code/pkg/params/info"
 	"github.com/openshift-pipelines/pipelines-as-code/pkg/params/settings"
 	"github.com/openshift-pipelines/pipelines-as-code/pkg/provider"
 	testclient "github.com/openshift-pipelines/pipelines-as-code/pkg/test/clients"
+	testconcurrency "github.com/openshift-pipelines/pipelines-as-code/pkg/test/concurrency"
 	tprovider "github.com/openshift-pipelines/pipelines-as-code/pkg/test/provider"
 	tektontest "github.com/openshift-pipelines/pipelines-as-code/pkg/test/tekton"
 	tektonv1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
 	"go.uber.org/zap"
 	zapobserver "go.uber.org/zap/zaptest/observer"
 	"gotest.tools/v3/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"knative.dev/pkg/apis"
 	rtesting "knative.dev/pkg/reconciler/testing"
 )
@@ -71,6 +77,140 @@ func TestPostFinalStatus(t *testing.T) {
 		},
 	}
 
-	_, err := r.postFinalStatus(ctx, fakelogger, pacInfo, vcx, info.NewEvent(), pr1)
+	_, err := r.postFinalStatus(ctx, fakelogger, pacInfo, vcx, info.NewEvent(), nil, pr1)
 	assert.NilError(t, err)
 }
+
+func TestSetDurationTrend(t *testing.T) {
+	clock := clockwork.NewFakeClock()
+	start := metav1.NewTime(clock.Now())
+	pr := &tektonv1.PipelineRun{
+		ObjectMeta: metav1.ObjectMeta{
+			Annotations: map[string]string{apipac.OriginalPRName: "pull-request"},
+		},
+	}
+	pr.Status.StartTime = &start
+	pr.Status.CompletionTime = &metav1.Time{Time: clock.Now().Add(9 * time.Minute)}
+	repo := &pacv1a1.Repository{
+		Status: []pacv1a1.RepositoryRunStatus{
+			{
+				OriginalPipelineRunName: "pull-request",
+				StartTime:               &start,
+				CompletionTime:          &metav1.Time{Time: clock.Now().Add(7 * time.Minute)},
+			},
+			{
+				OriginalPipelineRunName: "push",
+				StartTime:               &start,
+				CompletionTime:          &metav1.Time{Time: clock.Now().Add(time.Hour)},
+			},
+		},
+	}
+
+	mt := formatting.MessageTemplate{}
+	setDurationTrend(&mt, repo, pr)
+	assert.Equal(t, mt.Duration, "9 minutes")
+	assert.Equal(t, mt.AverageDuration, "7 minutes")
+	assert.Equal(t, mt.DurationTrend, "2 minutes slower than usual")
+
+	mt = formatting.MessageTemplate{}
+	setDurationTrend(&mt, &pacv1a1.Repository{}, pr)
+	assert.Equal(t, mt.Duration, "9 minutes")
+	assert.Equal(t, mt.AverageDuration, "")
+}
+
+func TestTestSummary(t *testing.T) {
+	junitResult := func(task, report string) *tektonv1.PipelineRunTaskRunStatus {
+		return &tektonv1.PipelineRunTaskRunStatus{
+			PipelineTaskName: task,
+			Status: &tektonv1.TaskRunStatus{
+				TaskRunStatusFields: tektonv1.TaskRunStatusFields{
+					Results: []tektonv1.TaskRunResult{{Name: "JUNIT", Value: *tektonv1.NewStructuredValues(report)}},
+				},
+			},
+		}
+	}
+	tests := []struct {
+		name        string
+		annotations map[string]string
+		trStatus    map[string]*tektonv1.PipelineRunTaskRunStatus
+		want        *formatting.TestSummary
+		wantErr     string
+	}{
+		{
+			name:        "reports of several tasks",
+			annotations: map[string]string{apipac.JUnitResult: "JUNIT"},
+			trStatus: map[string]*tektonv1.PipelineRunTaskRunStatus{
+				"tr-b": junitResult("e2e", `<testsuite><testcase name="TestE2E"><failure/></testcase></testsuite>`),
+				"tr-a": junitResult("unit", `<testsuite><testcase name="TestUnit"><failure/></testcase><testcase name="TestOK"/></testsuite>`),
+				"tr-c": nil,
+			},
+			want: &formatting.TestSummary{Passed: 1, Failed: 2, FailedTests: []string{"TestUnit", "TestE2E"}},
+		},
+		{
+			name:        "no task with the result",
+			annotations: map[string]string{apipac.JUnitResult: "REPORT"},
+			trStatus: map[string]*tektonv1.PipelineRunTaskRunStatus{
+				"tr-a": junitResult("unit", `<testsuite/>`),
+			},
+		},
+		{
+			name: "no annotation",
+			trStatus: map[string]*tektonv1.PipelineRunTaskRunStatus{
+				"tr-a": junitResult("unit", `<testsuite/>`),
+			},
+		},
+		{
+			name:        "invalid report",
+			annotations: map[string]string{apipac.JUnitResult: "JUNIT"},
+			trStatus: map[string]*tektonv1.PipelineRunTaskRunStatus{
+				"tr-a": junitResult("unit", "42 tests passed"),
+			},
+			wantErr: "task unit: cannot parse the JUnit report: EOF",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pr := &tektonv1.PipelineRun{ObjectMeta: metav1.ObjectMeta{Annotations: tt.annotations}}
+			got, err := testSummary(pr, tt.trStatus)
+			if tt.wantErr != "" {
+				assert.Error(t, err, tt.wantErr)
+				return
+			}
+			assert.NilError(t, err)
+			assert.DeepEqual(t, got, tt.want)
+		})
+	}
+}
+
+func TestUpdateRepositoryStatus(t *testing.T) {
+	observer, _ := zapobserver.New(zap.InfoLevel)
+	fakelogger := zap.New(observer).Sugar()
+	ns := "namespace"
+	clock := clockwork.NewFakeClock()
+	pr := tektontest.MakePRCompletion(clock, "pipeline", ns, tektonv1.PipelineRunReasonFailed.String(), nil, map[string]string{}, 10)
+	repo := &pacv1a1.Repository{
+		ObjectMeta: metav1.ObjectMeta{Name: "repo", Namespace: ns, Generation: 3},
+		Spec:       pacv1a1.RepositorySpec{URL: "https://github.com/owner/repo"},
+	}
+	ctx, _ := rtesting.SetupFakeContext(t)
+	stdata, _ := testclient.SeedTestData(t, ctx, testclient.Data{Repositories: []*pacv1a1.Repository{repo}})
+	r := &Reconciler{
+		run: &params.Run{Clients: clients.Clients{PipelineAsCode: stdata.PipelineAsCode}},
+		qm:  testconcurrency.TestQMI{QueuedPrs: []string{"namespace/queued1", "namespace/queued2"}},
+	}
+
+	usage := pacv1a1.DailyUsage{Date: time.Now().UTC().Format(time.DateOnly), Runs: 1, DurationSeconds: 10}
+	err := r.updateRepositoryStatus(ctx, fakelogger, pr, repo, "github", &info.Event{EventType: "pull_request"}, usage)
+	assert.NilError(t, err)
+
+	got, err := stdata.PipelineAsCode.PipelinesascodeV1alpha1().Repositories(ns).Get(ctx, repo.GetName(), metav1.GetOptions{})
+	assert.NilError(t, err)
+	assert.DeepEqual(t, got.RepositoryStatus, &pacv1a1.RepositoryStatus{
+		ObservedGeneration: 3,
+		Provider:           "github",
+		LastEvent:          "pull_request",
+		LastStatus:         tektonv1.PipelineRunReasonFailed.String(),
+		Queued:             2,
+		Usage:              []pacv1a1.DailyUsage{usage},
+	})
+}
@@ -0,0 +1,55 @@
+package reconciler
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/apis/pipelinesascode/keys"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/kubeinteraction"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/params/info"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/triggerwindows"
+	tektonv1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+	"go.uber.org/zap"
+	"knative.dev/pkg/controller"
+)
+
+// startScheduledPipelineRun starts pr, created outside of the trigger windows
+// of its Repository, once one of them is open and comes back when the next
+// one opens otherwise.
+func (r *Reconciler) startScheduledPipelineRun(ctx context.Context, logger *zap.SugaredLogger, pr *tektonv1.PipelineRun) error {
+	repo, err := r.repoLister.Repositories(r.repositoryNamespace(ctx, pr)).Get(pr.GetAnnotations()[keys.Repository])
+	if err != nil {
+		return fmt.Errorf("failed to get repository CR: %w", err)
+	}
+	repo = repo.DeepCopy()
+	r.inheritOrgRepository(repo)
+	if r.run.Info.Controller == nil {
+		r.run.Info.Controller = info.GetControllerInfoFromEnvOrDefault()
+	}
+	if r.globalRepo, err = r.repoLister.Repositories(r.run.Info.Kube.Namespace).Get(r.run.Info.Controller.GlobalRepository); err == nil && r.globalRepo != nil {
+		repo.Spec.Merge(r.globalRepo.Spec)
+	}
+
+	open, next, err := triggerwindows.Check(repo, time.Now())
+	switch {
+	case err != nil:
+		logger.Warnf("starting pipelinerun %s: %v", pr.GetName(), err)
+	case !open:
+		return controller.NewRequeueAfter(time.Until(next))
+	}
+
+	// the dependencies and the concurrency limit apply once the window is open
+	if _, waiting := pr.GetAnnotations()[keys.DependsOn]; waiting {
+		updated, err := r.updatePipelineRunState(ctx, logger, pr, kubeinteraction.StateWaiting)
+		if err != nil {
+			return err
+		}
+		return r.startWaitingPipelineRun(ctx, logger, updated)
+	}
+	if repo.Spec.ConcurrencyLimit != nil && *repo.Spec.ConcurrencyLimit != 0 {
+		_, err := r.updatePipelineRunState(ctx, logger, pr, kubeinteraction.StateQueued)
+		return err
+	}
+	return r.updatePipelineRunToInProgress(ctx, logger, repo, pr)
+}
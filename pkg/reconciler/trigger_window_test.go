@@ -0,0 +1,141 @@
+package reconciler
+
+import (
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/apis/pipelinesascode/keys"
+	pacv1alpha1 "github.com/openshift-pipelines/pipelines-as-code/pkg/apis/pipelinesascode/v1alpha1"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/consoleui"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/kubeinteraction"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/params"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/params/clients"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/params/info"
+	testclient "github.com/openshift-pipelines/pipelines-as-code/pkg/test/clients"
+	tektonv1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+	"go.uber.org/zap"
+	zapobserver "go.uber.org/zap/zaptest/observer"
+	"gotest.tools/v3/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"knative.dev/pkg/controller"
+	rtesting "knative.dev/pkg/reconciler/testing"
+)
+
+func TestStartScheduledPipelineRun(t *testing.T) {
+	ns := "ns"
+	// a window in three days is never open now
+	closed := []pacv1alpha1.TriggerWindow{{Days: strconv.Itoa((int(time.Now().UTC().Weekday()) + 3) % 7), Start: "00:00", End: "01:00"}}
+	always := []pacv1alpha1.TriggerWindow{{Start: "00:00", End: "00:00"}}
+
+	tests := []struct {
+		name             string
+		windows          []pacv1alpha1.TriggerWindow
+		dependsOn        string
+		concurrencyLimit *int
+		wantRequeue      bool
+		wantSpecStatus   tektonv1.PipelineRunSpecStatus
+		wantState        string
+		wantLog          string
+	}{
+		{
+			name:           "outside of the windows",
+			windows:        closed,
+			wantRequeue:    true,
+			wantSpecStatus: tektonv1.PipelineRunSpecStatusPending,
+			wantState:      kubeinteraction.StateScheduled,
+		},
+		{
+			name:           "window open",
+			windows:        always,
+			wantSpecStatus: "",
+			wantState:      kubeinteraction.StateStarted,
+		},
+		{
+			name:           "window removed",
+			wantSpecStatus: "",
+			wantState:      kubeinteraction.StateStarted,
+		},
+		{
+			name:             "window open with a concurrency limit",
+			windows:          always,
+			concurrencyLimit: func() *int { i := 1; return &i }(),
+			wantSpecStatus:   tektonv1.PipelineRunSpecStatusPending,
+			wantState:        kubeinteraction.StateQueued,
+		},
+		{
+			name:           "window open with a dependency",
+			windows:        always,
+			dependsOn:      "[lint]",
+			wantSpecStatus: tektonv1.PipelineRunSpecStatusPending,
+			wantState:      kubeinteraction.StateWaiting,
+			wantLog:        "pipelinerun deploy-abcde is waiting for lint to be created",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			observer, logcatch := zapobserver.New(zap.InfoLevel)
+			fakelogger := zap.New(observer).Sugar()
+			ctx, _ := rtesting.SetupFakeContext(t)
+			annotations := map[string]string{
+				keys.OriginalPRName: "deploy",
+				keys.Repository:     "repo",
+				keys.State:          kubeinteraction.StateScheduled,
+			}
+			if tt.dependsOn != "" {
+				annotations[keys.DependsOn] = tt.dependsOn
+			}
+			deploy := &tektonv1.PipelineRun{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        "deploy-abcde",
+					Namespace:   ns,
+					Labels:      map[string]string{keys.SHA: "sha", keys.Repository: "repo", keys.State: kubeinteraction.StateScheduled},
+					Annotations: annotations,
+				},
+				Spec: tektonv1.PipelineRunSpec{Status: tektonv1.PipelineRunSpecStatusPending},
+			}
+			repo := &pacv1alpha1.Repository{
+				ObjectMeta: metav1.ObjectMeta{Name: "repo", Namespace: ns},
+				Spec: pacv1alpha1.RepositorySpec{
+					URL:              "https://github.com/owner/repo",
+					ConcurrencyLimit: tt.concurrencyLimit,
+				},
+			}
+			if tt.windows != nil {
+				repo.Spec.Settings = &pacv1alpha1.Settings{TriggerWindows: &pacv1alpha1.TriggerWindows{Windows: tt.windows}}
+			}
+			stdata, informers := testclient.SeedTestData(t, ctx, testclient.Data{
+				Repositories: []*pacv1alpha1.Repository{repo},
+				PipelineRuns: []*tektonv1.PipelineRun{deploy},
+			})
+			run := params.New()
+			run.Info.Kube = &info.KubeOpts{Namespace: "global"}
+			run.Info.Controller = &info.ControllerInfo{}
+			run.Clients = clients.Clients{
+				PipelineAsCode: stdata.PipelineAsCode,
+				Tekton:         stdata.Pipeline,
+				Kube:           stdata.Kube,
+				Log:            fakelogger,
+			}
+			run.Clients.SetConsoleUI(consoleui.FallBackConsole{})
+			r := &Reconciler{run: run, repoLister: informers.Repository.Lister()}
+
+			err := r.startScheduledPipelineRun(ctx, fakelogger, deploy)
+			if tt.wantRequeue {
+				requeue, after := controller.IsRequeueKey(err)
+				assert.Assert(t, requeue, err)
+				assert.Assert(t, after > 0 && after <= 4*24*time.Hour, after)
+			} else {
+				assert.NilError(t, err)
+			}
+
+			got, err := stdata.Pipeline.TektonV1().PipelineRuns(ns).Get(ctx, deploy.GetName(), metav1.GetOptions{})
+			assert.NilError(t, err)
+			assert.Equal(t, got.Spec.Status, tt.wantSpecStatus)
+			assert.Equal(t, got.GetAnnotations()[keys.State], tt.wantState)
+			if tt.wantLog != "" {
+				assert.Assert(t, logcatch.FilterMessage(tt.wantLog).Len() != 0, "We didn't get the expected log message", logcatch.All())
+			}
+		})
+	}
+}
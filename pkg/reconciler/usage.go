@@ -0,0 +1,104 @@
+package reconciler
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/apis/pipelinesascode/keys"
+	pacv1a1 "github.com/openshift-pipelines/pipelines-as-code/pkg/apis/pipelinesascode/v1alpha1"
+	kstatus "github.com/openshift-pipelines/pipelines-as-code/pkg/kubeinteraction/status"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/params/info"
+	tektonv1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+	"go.uber.org/zap"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// usageRetentionDays is the number of days the daily usage is kept in the
+// status of the repositories.
+const usageRetentionDays = 90
+
+// pipelineRunUsage returns the usage of a completed PipelineRun, with the
+// resources requested by its TaskRuns when the usage-resource-accounting
+// setting is enabled. The resources are also reported as metrics.
+func (r *Reconciler) pipelineRunUsage(ctx context.Context, logger *zap.SugaredLogger, pacInfo *info.PacOpts, pr *tektonv1.PipelineRun) pacv1a1.DailyUsage {
+	var trStatus map[string]*tektonv1.PipelineRunTaskRunStatus
+	if pacInfo.UsageResourceAccounting {
+		trStatus = kstatus.GetStatusFromTaskStatusOrFromAsking(ctx, pr, r.run)
+	}
+	usage := computeUsage(pr, trStatus, time.Now())
+	if pacInfo.UsageResourceAccounting && r.metrics != nil {
+		if err := r.metrics.CountResourceUsage(pr.GetNamespace(), pr.GetAnnotations()[keys.Repository],
+			float64(usage.CPUMillicoreSeconds)/1000, float64(usage.MemoryMebibyteSeconds)*1024*1024); err != nil {
+			logger.Errorf("failed to emit the resource usage metrics: %v", err)
+		}
+	}
+	return usage
+}
+
+// computeUsage returns the duration of pr and the CPU and memory requested by
+// the steps and sidecars of its TaskRuns multiplied by their duration.
+func computeUsage(pr *tektonv1.PipelineRun, trStatus map[string]*tektonv1.PipelineRunTaskRunStatus, now time.Time) pacv1a1.DailyUsage {
+	completed := now
+	if pr.Status.CompletionTime != nil {
+		completed = pr.Status.CompletionTime.Time
+	}
+	usage := pacv1a1.DailyUsage{Date: completed.UTC().Format(time.DateOnly), Runs: 1}
+	if pr.Status.StartTime != nil {
+		usage.DurationSeconds = int64(completed.Sub(pr.Status.StartTime.Time).Seconds())
+	}
+
+	for _, tr := range trStatus {
+		if tr == nil || tr.Status == nil || tr.Status.StartTime == nil || tr.Status.TaskSpec == nil {
+			continue
+		}
+		end := completed
+		if tr.Status.CompletionTime != nil {
+			end = tr.Status.CompletionTime.Time
+		}
+		seconds := int64(end.Sub(tr.Status.StartTime.Time).Seconds())
+		requests := []corev1.ResourceList{}
+		for _, step := range tr.Status.TaskSpec.Steps {
+			requests = append(requests, step.ComputeResources.Requests)
+		}
+		for _, sidecar := range tr.Status.TaskSpec.Sidecars {
+			requests = append(requests, sidecar.ComputeResources.Requests)
+		}
+		for _, request := range requests {
+			if cpu, ok := request[corev1.ResourceCPU]; ok {
+				usage.CPUMillicoreSeconds += cpu.MilliValue() * seconds
+			}
+			if memory, ok := request[corev1.ResourceMemory]; ok {
+				usage.MemoryMebibyteSeconds += memory.Value() * seconds / (1024 * 1024)
+			}
+		}
+	}
+	return usage
+}
+
+// addUsage adds the usage of a PipelineRun to the usage of its day and drops
+// the days older than the retention.
+func addUsage(days []pacv1a1.DailyUsage, usage pacv1a1.DailyUsage, now time.Time) []pacv1a1.DailyUsage {
+	cutoff := now.UTC().AddDate(0, 0, -usageRetentionDays).Format(time.DateOnly)
+	updated := []pacv1a1.DailyUsage{}
+	found := false
+	for _, day := range days {
+		if day.Date < cutoff {
+			continue
+		}
+		if day.Date == usage.Date {
+			day.Runs += usage.Runs
+			day.DurationSeconds += usage.DurationSeconds
+			day.CPUMillicoreSeconds += usage.CPUMillicoreSeconds
+			day.MemoryMebibyteSeconds += usage.MemoryMebibyteSeconds
+			found = true
+		}
+		updated = append(updated, day)
+	}
+	if !found && usage.Date >= cutoff {
+		updated = append(updated, usage)
+	}
+	slices.SortFunc(updated, func(a, b pacv1a1.DailyUsage) int { return strings.Compare(a.Date, b.Date) })
+	return updated
+}
@@ -0,0 +1,106 @@
+package reconciler
+
+import (
+	"testing"
+	"time"
+
+	pacv1a1 "github.com/openshift-pipelines/pipelines-as-code/pkg/apis/pipelinesascode/v1alpha1"
+	tektonv1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+	"gotest.tools/v3/assert"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestComputeUsage(t *testing.T) {
+	now := time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+	started := metav1.NewTime(now.Add(-10 * time.Minute))
+	completed := metav1.NewTime(now.Add(-time.Minute))
+	requests := func(cpu, memory string) corev1.ResourceRequirements {
+		return corev1.ResourceRequirements{Requests: corev1.ResourceList{
+			corev1.ResourceCPU:    resource.MustParse(cpu),
+			corev1.ResourceMemory: resource.MustParse(memory),
+		}}
+	}
+	taskRun := func(start, end *metav1.Time, spec *tektonv1.TaskSpec) *tektonv1.PipelineRunTaskRunStatus {
+		return &tektonv1.PipelineRunTaskRunStatus{Status: &tektonv1.TaskRunStatus{
+			TaskRunStatusFields: tektonv1.TaskRunStatusFields{StartTime: start, CompletionTime: end, TaskSpec: spec},
+		}}
+	}
+
+	tests := []struct {
+		name     string
+		pr       *tektonv1.PipelineRun
+		trStatus map[string]*tektonv1.PipelineRunTaskRunStatus
+		want     pacv1a1.DailyUsage
+	}{
+		{
+			name: "duration only",
+			pr: &tektonv1.PipelineRun{Status: tektonv1.PipelineRunStatus{PipelineRunStatusFields: tektonv1.PipelineRunStatusFields{
+				StartTime: &started, CompletionTime: &completed,
+			}}},
+			want: pacv1a1.DailyUsage{Date: "2026-10-16", Runs: 1, DurationSeconds: 540},
+		},
+		{
+			name: "not started",
+			pr:   &tektonv1.PipelineRun{},
+			want: pacv1a1.DailyUsage{Date: "2026-10-16", Runs: 1},
+		},
+		{
+			name: "requested resources",
+			pr: &tektonv1.PipelineRun{Status: tektonv1.PipelineRunStatus{PipelineRunStatusFields: tektonv1.PipelineRunStatusFields{
+				StartTime: &started, CompletionTime: &completed,
+			}}},
+			trStatus: map[string]*tektonv1.PipelineRunTaskRunStatus{
+				"build": taskRun(&started, &completed, &tektonv1.TaskSpec{
+					Steps: []tektonv1.Step{
+						{Name: "compile", ComputeResources: requests("500m", "1Gi")},
+						{Name: "no-requests"},
+					},
+					Sidecars: []tektonv1.Sidecar{{Name: "registry", ComputeResources: requests("100m", "128Mi")}},
+				}),
+				// finishing with the pipelinerun
+				"test": taskRun(&started, nil, &tektonv1.TaskSpec{
+					Steps: []tektonv1.Step{{Name: "test", ComputeResources: requests("1", "512Mi")}},
+				}),
+				"skipped": taskRun(nil, nil, &tektonv1.TaskSpec{
+					Steps: []tektonv1.Step{{Name: "skipped", ComputeResources: requests("1", "512Mi")}},
+				}),
+			},
+			want: pacv1a1.DailyUsage{
+				Date: "2026-10-16", Runs: 1, DurationSeconds: 540,
+				CPUMillicoreSeconds:   600*540 + 1000*540,
+				MemoryMebibyteSeconds: (1024+128)*540 + 512*540,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.DeepEqual(t, computeUsage(tt.pr, tt.trStatus, now), tt.want)
+		})
+	}
+}
+
+func TestAddUsage(t *testing.T) {
+	now := time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+	days := []pacv1a1.DailyUsage{
+		{Date: "2026-07-17", Runs: 3, DurationSeconds: 300},
+		{Date: "2026-07-18", Runs: 1, DurationSeconds: 60},
+		{Date: "2026-10-16", Runs: 2, DurationSeconds: 120, CPUMillicoreSeconds: 1000},
+	}
+
+	got := addUsage(days, pacv1a1.DailyUsage{Date: "2026-10-16", Runs: 1, DurationSeconds: 30, CPUMillicoreSeconds: 500, MemoryMebibyteSeconds: 10}, now)
+	assert.DeepEqual(t, got, []pacv1a1.DailyUsage{
+		{Date: "2026-07-18", Runs: 1, DurationSeconds: 60},
+		{Date: "2026-10-16", Runs: 3, DurationSeconds: 150, CPUMillicoreSeconds: 1500, MemoryMebibyteSeconds: 10},
+	})
+	// the existing days are not modified
+	assert.Equal(t, days[2].Runs, 2)
+
+	got = addUsage(got, pacv1a1.DailyUsage{Date: "2026-10-15", Runs: 1, DurationSeconds: 5}, now)
+	assert.DeepEqual(t, got, []pacv1a1.DailyUsage{
+		{Date: "2026-07-18", Runs: 1, DurationSeconds: 60},
+		{Date: "2026-10-15", Runs: 1, DurationSeconds: 5},
+		{Date: "2026-10-16", Runs: 3, DurationSeconds: 150, CPUMillicoreSeconds: 1500, MemoryMebibyteSeconds: 10},
+	})
+}
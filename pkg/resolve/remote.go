@@ -6,6 +6,7 @@ import (
 	"net/url"
 	"path"
 
+	pacerrors "github.com/openshift-pipelines/pipelines-as-code/pkg/errors"
 	"github.com/openshift-pipelines/pipelines-as-code/pkg/matcher"
 	tektonv1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
 )
@@ -188,7 +189,20 @@ func resolveRemoteResources(ctx context.Context, rt *matcher.RemoteTasks, types
 
 		// if PipelineRef is used then, first resolve pipeline and replace all taskRef{Finally/Task} of Pipeline, then put inlinePipeline in PipelineRun
 		if pipelinerun.Spec.PipelineRef != nil && pipelinerun.Spec.PipelineRef.Resolver == "" {
-			pipelineResolved := fetchedResourcesForPipelineRun.Pipeline
+			// the same Pipeline can be inlined in multiple PipelineRuns, make
+			// sure they don't end up sharing the same PipelineSpec.
+			pipelineResolved := fetchedResourcesForPipelineRun.Pipeline.DeepCopy()
+			if err := validatePipelineRunReferences(pipelinerun, pipelineResolved); err != nil {
+				prName := pipelinerun.GetName()
+				if prName == "" {
+					prName = pipelinerun.GetGenerateName()
+				}
+				ropt.ReferenceErrors = append(ropt.ReferenceErrors, &pacerrors.PacYamlValidations{
+					Name:   prName,
+					Err:    err,
+					Schema: tektonv1.SchemeGroupVersion.Group,
+				})
+			}
 			turns, err := inlineTasks(pipelineResolved.Spec.Tasks, ropt, fetchedResourcesForPipelineRun)
 			if err != nil {
 				return nil, err
@@ -220,6 +234,10 @@ func resolveRemoteResources(ctx context.Context, rt *matcher.RemoteTasks, types
 			pipelinerun.Spec.PipelineSpec.Finally = fruns
 		}
 
+		if err := validatePipelineSpec(pipelinerun); err != nil {
+			return nil, err
+		}
+
 		// Add a GenerateName based on the pipeline name and a "-"
 		// if we already have a GenerateName then just keep it like this
 		if ropt.GenerateName && pipelinerun.GenerateName == "" {
@@ -5,11 +5,13 @@ import (
 	"fmt"
 	"regexp"
 	"slices"
+	"strconv"
 	"strings"
 
 	apipac "github.com/openshift-pipelines/pipelines-as-code/pkg/apis/pipelinesascode/keys"
 	pacerrors "github.com/openshift-pipelines/pipelines-as-code/pkg/errors"
 	"github.com/openshift-pipelines/pipelines-as-code/pkg/formatting"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/lockfile"
 	"github.com/openshift-pipelines/pipelines-as-code/pkg/matcher"
 	"github.com/openshift-pipelines/pipelines-as-code/pkg/params"
 	"github.com/openshift-pipelines/pipelines-as-code/pkg/params/info"
@@ -28,6 +30,9 @@ type TektonTypes struct {
 	TaskRuns         []*tektonv1.TaskRun
 	Tasks            []*tektonv1.Task
 	ValidationErrors []*pacerrors.PacYamlValidations
+	// Suggestions are the corrections of the likely mistakes in the
+	// annotations of the PipelineRuns.
+	Suggestions []*pacerrors.Suggestion
 }
 
 // Contains Fetched Resources for Event, with key equals to annotation value.
@@ -49,7 +54,30 @@ func NewTektonTypes() TektonTypes {
 	}
 }
 
-var yamlDocSeparatorRe = regexp.MustCompile(`(?m)^---\s*$`)
+var (
+	yamlDocSeparatorRe = regexp.MustCompile(`(?m)^---\s*$`)
+	sourceMarkerRe     = regexp.MustCompile(`(?m)^` + regexp.QuoteMeta(provider.SourceMarker) + `(.+):(\d+)$`)
+)
+
+// documentPosition returns where the error err decoding doc is located in the
+// .tekton directory, using the source marker added in front of doc when the
+// files were fetched. It returns nil when doc doesn't have a marker.
+func documentPosition(doc string, err error) *pacerrors.Position {
+	loc := sourceMarkerRe.FindStringSubmatchIndex(doc)
+	if loc == nil {
+		return nil
+	}
+	file := doc[loc[2]:loc[3]]
+	markerLine, _ := strconv.Atoi(doc[loc[4]:loc[5]])
+	line := pacerrors.YamlErrorLine(err)
+	if line == 0 {
+		// the error is not about a line, point at the start of the document
+		return &pacerrors.Position{File: file, Line: markerLine + 1}
+	}
+	markerIndex := strings.Count(doc[:loc[0]], "\n")
+	content := strings.TrimPrefix(doc[loc[1]:], "\n")
+	return pacerrors.NewPosition(file, content, markerLine+1, markerLine+line-1-markerIndex)
+}
 
 // detectAtleastNameOrGenerateNameAndSchemaFromPipelineRun detects the name or
 // generateName of a yaml files even if there is an error decoding it as tekton types.
@@ -147,13 +175,21 @@ type Opts struct {
 	RemoteTasks   bool     // whether to parse annotation to fetch tasks from remote
 	SkipInlining  []string // task to skip inlining
 	ProviderToken string
+	Lock          *lockfile.Lock // lock of the remote tasks and pipelines
+	UpdateLock    bool           // whether to record the remote tasks and pipelines in Lock instead of checking them
+	// ReferenceErrors are filled with the PipelineRuns not providing the
+	// params required by the Pipeline they reference, for the caller to
+	// report them.
+	ReferenceErrors []*pacerrors.PacYamlValidations
 }
 
 func ReadTektonTypes(ctx context.Context, log *zap.SugaredLogger, data string) (TektonTypes, error) {
 	types := NewTektonTypes()
 	decoder := k8scheme.Codecs.UniversalDeserializer()
 
-	for _, doc := range yamlDocSeparatorRe.Split(data, -1) {
+	docs, snippetErrors := expandSnippets(yamlDocSeparatorRe.Split(data, -1))
+	types.ValidationErrors = append(types.ValidationErrors, snippetErrors...)
+	for _, doc := range docs {
 		if strings.TrimSpace(doc) == "" {
 			continue
 		}
@@ -162,9 +198,10 @@ func ReadTektonTypes(ctx context.Context, log *zap.SugaredLogger, data string) (
 		if err != nil {
 			dt, dv := detectAtleastNameOrGenerateNameAndSchemaFromPipelineRun(doc)
 			types.ValidationErrors = append(types.ValidationErrors, &pacerrors.PacYamlValidations{
-				Name:   dt,
-				Err:    fmt.Errorf("error decoding yaml document: %w", err),
-				Schema: dv,
+				Name:     dt,
+				Err:      fmt.Errorf("error decoding yaml document: %w", err),
+				Schema:   dv,
+				Position: documentPosition(doc, err),
 			})
 			continue
 		}
@@ -181,6 +218,7 @@ func ReadTektonTypes(ctx context.Context, log *zap.SugaredLogger, data string) (
 				return types, fmt.Errorf("pipelinerun v1beta1 %s cannot be converted as v1: err: %w", o.GetName(), err)
 			}
 			types.PipelineRuns = append(types.PipelineRuns, c)
+			types.Suggestions = append(types.Suggestions, annotationSuggestions(doc, c)...)
 		case *tektonv1beta1.Task: //nolint: staticcheck // we need to support v1beta1
 			c := &tektonv1.Task{}
 			if err := o.ConvertTo(ctx, c); err != nil {
@@ -189,6 +227,7 @@ func ReadTektonTypes(ctx context.Context, log *zap.SugaredLogger, data string) (
 			types.Tasks = append(types.Tasks, c)
 		case *tektonv1.PipelineRun:
 			types.PipelineRuns = append(types.PipelineRuns, o)
+			types.Suggestions = append(types.Suggestions, annotationSuggestions(doc, o)...)
 		case *tektonv1.Pipeline:
 			types.Pipelines = append(types.Pipelines, o)
 		case *tektonv1.Task:
@@ -219,6 +258,8 @@ func Resolve(ctx context.Context, cs *params.Run, logger *zap.SugaredLogger, pro
 		Event:             event,
 		ProviderInterface: providerintf,
 		Logger:            logger,
+		Lock:              ropt.Lock,
+		UpdateLock:        ropt.UpdateLock,
 	}
 
 	fetchedResources, err := resolveRemoteResources(ctx, rt, types, ropt)
@@ -13,6 +13,7 @@ import (
 	"github.com/openshift-pipelines/pipelines-as-code/pkg/params"
 	"github.com/openshift-pipelines/pipelines-as-code/pkg/params/clients"
 	"github.com/openshift-pipelines/pipelines-as-code/pkg/params/info"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/provider"
 	testprovider "github.com/openshift-pipelines/pipelines-as-code/pkg/test/provider"
 	tektonv1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
 	"go.uber.org/zap"
@@ -254,6 +255,31 @@ func TestReportBadTektonYaml(t *testing.T) {
 	}
 }
 
+func TestReadTektonTypesPosition(t *testing.T) {
+	task := "apiVersion: tekton.dev/v1\nkind: Task\nmetadata:\n  name: task\n"
+	data := provider.AppendYamlFile("", "task.yaml", task)
+	data = provider.AppendYamlFile(data, "bad-spec.yaml",
+		"---\napiVersion: tekton.dev/v1\nkind: PipelineRun\nmetadata:\n  name: bad-spec\nspec:\n  pipelineSpec:\n    tasks: {}\n")
+	data = provider.AppendYamlFile(data, "bad-syntax.yaml",
+		task+"---\napiVersion: tekton.dev/v1\nkind: Task\nmetadata:\n  name foo\n  namespace: bar\n")
+
+	types, err := ReadTektonTypes(context.TODO(), nil, data)
+	assert.NilError(t, err)
+	assert.Equal(t, len(types.Tasks), 2)
+	positions := map[string]*pacerrors.Position{}
+	for _, validationError := range types.ValidationErrors {
+		assert.Assert(t, validationError.Position != nil, "no position for %s", validationError.Err)
+		positions[validationError.Position.File] = validationError.Position
+	}
+	assert.DeepEqual(t, positions["bad-spec.yaml"], &pacerrors.Position{File: "bad-spec.yaml", Line: 2})
+	assert.DeepEqual(t, positions["bad-syntax.yaml"], &pacerrors.Position{
+		File:    "bad-syntax.yaml",
+		Line:    10,
+		Column:  3,
+		Snippet: " 8 | metadata:\n 9 |   name foo\n10 |   namespace: bar\n   |   ^",
+	})
+}
+
 func TestDetectNameOrGenerateNameAndSchema(t *testing.T) {
 	tests := []struct {
 		name           string
@@ -689,3 +715,24 @@ func TestTaskRunPassMetadataAnnotations(t *testing.T) {
 	assert.Equal(t, len(ml), 1)
 	assert.Equal(t, ml["the.nitpicker.is.called"], "vincent")
 }
+
+func TestPipelineSharedByPipelineRuns(t *testing.T) {
+	ctx, _ := rtesting.SetupFakeContext(t)
+	data, err := os.ReadFile("testdata/pipeline-shared-by-pipelineruns.yaml")
+	assert.NilError(t, err)
+	observer, _ := zapobserver.New(zap.InfoLevel)
+	logger := zap.New(observer).Sugar()
+	types, err := ReadTektonTypes(ctx, logger, string(data))
+	assert.NilError(t, err)
+
+	ropt := &Opts{RemoteTasks: true}
+	resolved, err := Resolve(ctx, &params.Run{}, logger, &testprovider.TestProviderImp{}, types, &info.Event{}, ropt)
+	assert.NilError(t, err)
+	assert.Equal(t, len(resolved), 2)
+	assert.Equal(t, resolved[0].Spec.PipelineSpec.Tasks[0].TaskSpec.Steps[0].Name, "build")
+	assert.Assert(t, resolved[0].Spec.PipelineSpec != resolved[1].Spec.PipelineSpec, "pipelineruns should not share the same pipelineSpec")
+
+	assert.Equal(t, len(ropt.ReferenceErrors), 1)
+	assert.Equal(t, ropt.ReferenceErrors[0].Name, "pr-push")
+	assert.Error(t, ropt.ReferenceErrors[0].Err, "pipelinerun pr-push is invalid: the param event required by the pipeline shared-pipeline is not provided")
+}
@@ -0,0 +1,174 @@
+package resolve
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	pacerrors "github.com/openshift-pipelines/pipelines-as-code/pkg/errors"
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	// snippetRefKey is the key used in a yaml document to reference a snippet.
+	snippetRefKey = "$snippet"
+	// maxSnippetDepth is the maximum depth of snippets referencing other snippets.
+	maxSnippetDepth = 10
+)
+
+// snippetsDocRe matches the yaml documents declaring the snippets, usually the
+// .tekton/_common.yaml file.
+var snippetsDocRe = regexp.MustCompile(`(?m)^snippets:`)
+
+// expandSnippets removes the yaml documents declaring shared snippets from docs
+// and replaces the references to those snippets in the other documents.
+//
+// A snippet is referenced with a `$snippet: name` key. When the reference is
+// the only key of a list item and the snippet is a list, the items of the
+// snippet are inserted in place. When the snippet is a map, the other keys
+// next to the reference are merged on top of it. Otherwise the reference is
+// replaced by the value of the snippet.
+func expandSnippets(docs []string) ([]string, []*pacerrors.PacYamlValidations) {
+	snippets := map[string]any{}
+	validationErrors := []*pacerrors.PacYamlValidations{}
+	others := make([]string, 0, len(docs))
+	for _, doc := range docs {
+		if !snippetsDocRe.MatchString(doc) {
+			others = append(others, doc)
+			continue
+		}
+		var snippetsDoc struct {
+			Snippets map[string]any `json:"snippets"`
+		}
+		if err := yaml.Unmarshal([]byte(doc), &snippetsDoc); err != nil {
+			validationErrors = append(validationErrors, &pacerrors.PacYamlValidations{
+				Name: "snippets",
+				Err:  fmt.Errorf("error decoding snippets: %w", err),
+			})
+			continue
+		}
+		for name, value := range snippetsDoc.Snippets {
+			snippets[name] = value
+		}
+	}
+
+	ret := make([]string, 0, len(others))
+	for _, doc := range others {
+		if !strings.Contains(doc, snippetRefKey) {
+			ret = append(ret, doc)
+			continue
+		}
+		expanded, err := expandSnippetsInDoc(doc, snippets)
+		if err != nil {
+			name, schema := detectAtleastNameOrGenerateNameAndSchemaFromPipelineRun(doc)
+			validationErrors = append(validationErrors, &pacerrors.PacYamlValidations{
+				Name:   name,
+				Err:    err,
+				Schema: schema,
+			})
+			continue
+		}
+		ret = append(ret, expanded)
+	}
+	return ret, validationErrors
+}
+
+func expandSnippetsInDoc(doc string, snippets map[string]any) (string, error) {
+	var obj any
+	if err := yaml.Unmarshal([]byte(doc), &obj); err != nil {
+		return "", fmt.Errorf("error decoding yaml document: %w", err)
+	}
+	expanded, err := expandSnippetValue(obj, snippets, 0)
+	if err != nil {
+		return "", err
+	}
+	out, err := yaml.Marshal(expanded)
+	if err != nil {
+		return "", fmt.Errorf("error encoding yaml document with snippets: %w", err)
+	}
+	return string(out), nil
+}
+
+func expandSnippetValue(value any, snippets map[string]any, depth int) (any, error) {
+	if depth > maxSnippetDepth {
+		return nil, fmt.Errorf("snippets are nested more than %d levels, is there a snippet referencing itself?", maxSnippetDepth)
+	}
+	switch v := value.(type) {
+	case map[string]any:
+		ref, ok := v[snippetRefKey]
+		if !ok {
+			ret := make(map[string]any, len(v))
+			for key, item := range v {
+				expanded, err := expandSnippetValue(item, snippets, depth)
+				if err != nil {
+					return nil, err
+				}
+				ret[key] = expanded
+			}
+			return ret, nil
+		}
+		snippet, err := getSnippet(ref, snippets, depth)
+		if err != nil {
+			return nil, err
+		}
+		if len(v) == 1 {
+			return snippet, nil
+		}
+		snippetMap, ok := snippet.(map[string]any)
+		if !ok {
+			return nil, fmt.Errorf("snippet %v is not a map and cannot be merged with other keys", ref)
+		}
+		for key, item := range v {
+			if key == snippetRefKey {
+				continue
+			}
+			expanded, err := expandSnippetValue(item, snippets, depth)
+			if err != nil {
+				return nil, err
+			}
+			snippetMap[key] = expanded
+		}
+		return snippetMap, nil
+	case []any:
+		ret := make([]any, 0, len(v))
+		for _, item := range v {
+			if m, ok := item.(map[string]any); ok && len(m) == 1 {
+				if ref, ok := m[snippetRefKey]; ok {
+					snippet, err := getSnippet(ref, snippets, depth)
+					if err != nil {
+						return nil, err
+					}
+					if items, ok := snippet.([]any); ok {
+						ret = append(ret, items...)
+						continue
+					}
+					ret = append(ret, snippet)
+					continue
+				}
+			}
+			expanded, err := expandSnippetValue(item, snippets, depth)
+			if err != nil {
+				return nil, err
+			}
+			ret = append(ret, expanded)
+		}
+		return ret, nil
+	default:
+		return value, nil
+	}
+}
+
+// getSnippet returns a copy of the snippet ref with its own references expanded.
+func getSnippet(ref any, snippets map[string]any, depth int) (any, error) {
+	name, ok := ref.(string)
+	if !ok {
+		return nil, fmt.Errorf("%s reference must be a string, got %v", snippetRefKey, ref)
+	}
+	snippet, ok := snippets[name]
+	if !ok {
+		return nil, fmt.Errorf("cannot find snippet %s", name)
+	}
+	// expanding creates a copy of the snippet so the merged keys of a reference
+	// do not leak into the other references.
+	return expandSnippetValue(snippet, snippets, depth+1)
+}
@@ -0,0 +1,59 @@
+package resolve
+
+import (
+	"strings"
+	"testing"
+
+	"gotest.tools/v3/assert"
+)
+
+func TestSnippets(t *testing.T) {
+	resolved, _, err := readTDfile(t, "pipelinerun-with-snippets", false, true)
+	assert.NilError(t, err)
+	assert.Equal(t, len(resolved.Spec.Params), 3)
+	assert.Equal(t, resolved.Spec.Params[0].Name, "repo_url")
+	assert.Equal(t, resolved.Spec.Params[2].Name, "extra")
+	assert.Equal(t, len(resolved.Spec.PipelineSpec.Tasks), 2)
+	assert.Equal(t, resolved.Spec.PipelineSpec.Tasks[0].Name, "build")
+	assert.Equal(t, resolved.Spec.PipelineSpec.Tasks[1].Name, "build-again")
+	assert.Equal(t, resolved.Spec.PipelineSpec.Tasks[1].TaskSpec.Steps[0].Script, "make build")
+}
+
+func TestExpandSnippetsErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		docs    []string
+		wantErr string
+	}{
+		{
+			name:    "unknown snippet",
+			docs:    []string{"metadata:\n  name: pr\nspec:\n  $snippet: nothere\n"},
+			wantErr: "cannot find snippet nothere",
+		},
+		{
+			name: "merging keys on a list snippet",
+			docs: []string{
+				"snippets:\n  params:\n    - name: foo\n",
+				"metadata:\n  name: pr\nspec:\n  $snippet: params\n  foo: bar\n",
+			},
+			wantErr: "snippet params is not a map",
+		},
+		{
+			name: "snippet referencing itself",
+			docs: []string{
+				"snippets:\n  loop:\n    $snippet: loop\n",
+				"metadata:\n  name: pr\nspec:\n  $snippet: loop\n",
+			},
+			wantErr: "snippets are nested more than",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			docs, validationErrors := expandSnippets(tt.docs)
+			assert.Equal(t, len(docs), 0)
+			assert.Equal(t, len(validationErrors), 1)
+			assert.Equal(t, validationErrors[0].Name, "pr")
+			assert.Assert(t, strings.Contains(validationErrors[0].Err.Error(), tt.wantErr), validationErrors[0].Err.Error())
+		})
+	}
+}
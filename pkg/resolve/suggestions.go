@@ -0,0 +1,183 @@
+package resolve
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/apis/pipelinesascode"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/apis/pipelinesascode/keys"
+	pacerrors "github.com/openshift-pipelines/pipelines-as-code/pkg/errors"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/matcher"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/params/triggertype"
+	tektonv1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+)
+
+// maxSuggestionDistance is the maximum number of edits between a misspelled
+// word and the correction suggested for it.
+const maxSuggestionDistance = 2
+
+var (
+	// knownAnnotations are the annotations of Pipelines-as-Code users set on
+	// the PipelineRuns of their .tekton directory.
+	knownAnnotations = []string{
+		keys.OnEvent, keys.OnComment, keys.OnTargetBranch, keys.OnPathChange,
+		keys.OnLabel, keys.OnPathChangeIgnore, keys.OnCelExpression,
+		keys.TargetNamespace, keys.MaxKeepRuns, keys.CancelInProgress,
+		keys.Task, keys.Pipeline, keys.QueuePendingTimeout, keys.ManualInputs,
+		keys.Matrix, keys.PerCommit, keys.DependsOn, keys.ExportEncrypted,
+		keys.StatusContext, keys.PipelineRunNamespace, keys.EphemeralNamespace,
+		keys.EphemeralNamespaceTTL, keys.OnReviewApproved, keys.GitClone,
+		keys.GitCloneDepth, keys.GitCloneSubmodules, keys.GitCloneLFS,
+		keys.SparseCheckout,
+	}
+	// the remote tasks annotations can be numbered, ie: task-1.
+	numberedTaskAnnotationRe = regexp.MustCompile(`^` + regexp.QuoteMeta(keys.Task) + `-[0-9]+$`)
+	knownEvents              = []string{
+		triggertype.PullRequest.String(), triggertype.Push.String(),
+		triggertype.Incoming.String(), triggertype.PullRequestClosed.String(),
+		triggertype.PullRequestReview.String(), triggertype.PullRequestReviewComment.String(),
+	}
+)
+
+// annotationSuggestions returns the corrections of the annotations of
+// pipelinerun which are not known by Pipelines-as-Code but are close to one
+// which is, and of the unknown event names of its on-event annotation. doc is
+// the yaml document of pipelinerun, used to locate the mistakes.
+func annotationSuggestions(doc string, pipelinerun *tektonv1.PipelineRun) []*pacerrors.Suggestion {
+	annotations := pipelinerun.GetAnnotations()
+	names := make([]string, 0, len(annotations))
+	for name := range annotations {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	prName := pipelinerun.GetName()
+	if prName == "" {
+		prName = pipelinerun.GetGenerateName()
+	}
+	ret := []*pacerrors.Suggestion{}
+	for _, name := range names {
+		prefix, _, found := strings.Cut(name, "/")
+		if !found || levenshtein(prefix, pipelinesascode.GroupName) > maxSuggestionDistance {
+			continue
+		}
+		if isKnownAnnotation(name) {
+			continue
+		}
+		if correction := closest(name, knownAnnotations); correction != "" {
+			suggestion := &pacerrors.Suggestion{
+				Name:    prName,
+				Message: fmt.Sprintf("unknown annotation %q, did you mean %q?", name, correction),
+			}
+			locateSuggestion(suggestion, doc, name, func(line string) string {
+				return strings.Replace(line, name, correction, 1)
+			})
+			ret = append(ret, suggestion)
+		}
+	}
+
+	if value, ok := annotations[keys.OnEvent]; ok {
+		events, err := matcher.GetAnnotationValues(value)
+		if err != nil {
+			return ret
+		}
+		for _, event := range events {
+			if event == "" || slices.Contains(knownEvents, event) {
+				continue
+			}
+			correction := closest(event, knownEvents)
+			if correction == "" {
+				continue
+			}
+			suggestion := &pacerrors.Suggestion{
+				Name:    prName,
+				Message: fmt.Sprintf("unknown event %q in the %s annotation, did you mean %q?", event, keys.OnEvent, correction),
+			}
+			locateSuggestion(suggestion, doc, keys.OnEvent, func(line string) string {
+				key, value, _ := strings.Cut(line, ":")
+				return key + ":" + strings.Replace(value, event, correction, 1)
+			})
+			ret = append(ret, suggestion)
+		}
+	}
+	return ret
+}
+
+// locateSuggestion sets the position of the line of the annotation key in
+// doc on suggestion, with the line corrected by fix.
+func locateSuggestion(suggestion *pacerrors.Suggestion, doc, key string, fix func(string) string) {
+	loc := sourceMarkerRe.FindStringSubmatchIndex(doc)
+	if loc == nil {
+		return
+	}
+	file := doc[loc[2]:loc[3]]
+	markerLine, _ := strconv.Atoi(doc[loc[4]:loc[5]])
+	content := strings.TrimPrefix(doc[loc[1]:], "\n")
+
+	keyRe := regexp.MustCompile(`^\s*["']?` + regexp.QuoteMeta(key) + `["']?\s*:`)
+	for i, line := range strings.Split(content, "\n") {
+		if !keyRe.MatchString(line) {
+			continue
+		}
+		fixed := fix(line)
+		if fixed == line {
+			// the mistake is not on the line of the key, ie: a block scalar
+			return
+		}
+		suggestion.Position = pacerrors.NewPosition(file, content, markerLine+1, markerLine+1+i)
+		suggestion.Text = line
+		suggestion.Fix = fixed
+		return
+	}
+}
+
+func isKnownAnnotation(name string) bool {
+	return slices.Contains(knownAnnotations, name) || numberedTaskAnnotationRe.MatchString(name)
+}
+
+// closest returns the candidate the closest to word when it is at most
+// maxSuggestionDistance edits away, or an empty string. Case and separators
+// are ignored so "pullrequest" is corrected to "pull_request".
+func closest(word string, candidates []string) string {
+	best, bestDistance := "", maxSuggestionDistance+1
+	for _, candidate := range candidates {
+		if normalizeWord(word) == normalizeWord(candidate) {
+			return candidate
+		}
+		if distance := levenshtein(word, candidate); distance < bestDistance {
+			best, bestDistance = candidate, distance
+		}
+	}
+	return best
+}
+
+func normalizeWord(word string) string {
+	return strings.NewReplacer("-", "", "_", "", " ", "").Replace(strings.ToLower(word))
+}
+
+// levenshtein returns the number of single character edits needed to change
+// a into b.
+func levenshtein(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	previous := make([]int, len(rb)+1)
+	current := make([]int, len(rb)+1)
+	for j := range previous {
+		previous[j] = j
+	}
+	for i := 1; i <= len(ra); i++ {
+		current[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			current[j] = min(previous[j]+1, current[j-1]+1, previous[j-1]+cost)
+		}
+		previous, current = current, previous
+	}
+	return previous[len(rb)]
+}
@@ -0,0 +1,111 @@
+package resolve
+
+import (
+	"testing"
+
+	pacerrors "github.com/openshift-pipelines/pipelines-as-code/pkg/errors"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/provider"
+	tektonv1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+	"gotest.tools/v3/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestAnnotationSuggestions(t *testing.T) {
+	content := `apiVersion: tekton.dev/v1
+kind: PipelineRun
+metadata:
+  name: pr
+  annotations:
+    pipelinesascode.tekton.dev/on-evnt: "[pull_request]"
+    "pipelinesascode.tekton.dev/on-event": "[pullrequest, push]"
+    pipelinesascode.tekton.dev/task-1: "git-clone"
+    example.com/on-evnt: "value"
+`
+	doc := "\n" + provider.SourceMarker + "pr.yaml:0\n" + content
+	tests := []struct {
+		name        string
+		doc         string
+		annotations map[string]string
+		want        []*pacerrors.Suggestion
+	}{
+		{
+			name: "no mistakes",
+			annotations: map[string]string{
+				"pipelinesascode.tekton.dev/on-event":         "[pull_request, push]",
+				"pipelinesascode.tekton.dev/on-target-branch": "[main]",
+				"pipelinesascode.tekton.dev/task-1":           "git-clone",
+				"example.com/on-evnt":                         "value",
+			},
+			want: []*pacerrors.Suggestion{},
+		},
+		{
+			name: "unknown annotation without a close one",
+			annotations: map[string]string{
+				"pipelinesascode.tekton.dev/something-else": "value",
+			},
+			want: []*pacerrors.Suggestion{},
+		},
+		{
+			name: "misspelled annotation and event not located",
+			annotations: map[string]string{
+				"pipelinesascode.tekton.dev/on-evnt":  "[pull_request]",
+				"pipelinesascode.tekton.dev/on-event": "[pullrequest, push]",
+			},
+			want: []*pacerrors.Suggestion{
+				{
+					Name:    "pr",
+					Message: `unknown annotation "pipelinesascode.tekton.dev/on-evnt", did you mean "pipelinesascode.tekton.dev/on-event"?`,
+				},
+				{
+					Name:    "pr",
+					Message: `unknown event "pullrequest" in the pipelinesascode.tekton.dev/on-event annotation, did you mean "pull_request"?`,
+				},
+			},
+		},
+		{
+			name: "misspelled annotation and event located",
+			doc:  doc,
+			annotations: map[string]string{
+				"pipelinesascode.tekton.dev/on-evnt":  "[pull_request]",
+				"pipelinesascode.tekton.dev/on-event": "[pullrequest, push]",
+				"pipelinesascode.tekton.dev/task-1":   "git-clone",
+				"example.com/on-evnt":                 "value",
+			},
+			want: []*pacerrors.Suggestion{
+				{
+					Name:     "pr",
+					Message:  `unknown annotation "pipelinesascode.tekton.dev/on-evnt", did you mean "pipelinesascode.tekton.dev/on-event"?`,
+					Position: pacerrors.NewPosition("pr.yaml", content, 1, 6),
+					Text:     `    pipelinesascode.tekton.dev/on-evnt: "[pull_request]"`,
+					Fix:      `    pipelinesascode.tekton.dev/on-event: "[pull_request]"`,
+				},
+				{
+					Name:     "pr",
+					Message:  `unknown event "pullrequest" in the pipelinesascode.tekton.dev/on-event annotation, did you mean "pull_request"?`,
+					Position: pacerrors.NewPosition("pr.yaml", content, 1, 7),
+					Text:     `    "pipelinesascode.tekton.dev/on-event": "[pullrequest, push]"`,
+					Fix:      `    "pipelinesascode.tekton.dev/on-event": "[pull_request, push]"`,
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pr := &tektonv1.PipelineRun{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        "pr",
+					Annotations: tt.annotations,
+				},
+			}
+			assert.DeepEqual(t, annotationSuggestions(tt.doc, pr), tt.want)
+		})
+	}
+}
+
+func TestLevenshtein(t *testing.T) {
+	assert.Equal(t, levenshtein("", "push"), 4)
+	assert.Equal(t, levenshtein("push", "push"), 0)
+	assert.Equal(t, levenshtein("pushh", "push"), 1)
+	assert.Equal(t, levenshtein("on-evnt", "on-event"), 1)
+	assert.Equal(t, levenshtein("kitten", "sitting"), 3)
+}
@@ -0,0 +1,187 @@
+package resolve
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+
+	tektonv1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+)
+
+var (
+	// paramRefRe matches $(params.name), $(params.name.key), $(params.name[*])
+	// and $(params['name']) references.
+	paramRefRe = regexp.MustCompile(`\$\(params(?:\.([a-zA-Z0-9_-]+)|\[\\?['"]([^'"\\]+)\\?['"]\])`)
+	// taskResultRefRe matches $(tasks.name.results.result) references.
+	taskResultRefRe = regexp.MustCompile(`\$\(tasks\.([a-zA-Z0-9_-]+)\.results\.`)
+)
+
+// validatePipelineSpec checks that the embedded pipelineSpec of a resolved
+// PipelineRun only uses declared params, that its workspaces are bound and
+// that the task results it references come from tasks of the pipeline, so it
+// doesn't get created only to be rejected by Tekton.
+func validatePipelineSpec(pipelinerun *tektonv1.PipelineRun) error {
+	spec := pipelinerun.Spec.PipelineSpec
+	if spec == nil {
+		return nil
+	}
+	problems := []string{}
+
+	// the params of the PipelineRun are propagated to its pipelineSpec
+	pipelineParams := map[string]bool{}
+	for _, param := range pipelinerun.Spec.Params {
+		pipelineParams[param.Name] = true
+	}
+	for _, param := range spec.Params {
+		pipelineParams[param.Name] = true
+	}
+
+	boundWorkspaces := map[string]bool{}
+	for _, workspace := range pipelinerun.Spec.Workspaces {
+		boundWorkspaces[workspace.Name] = true
+	}
+	pipelineWorkspaces := map[string]bool{}
+	for _, workspace := range spec.Workspaces {
+		pipelineWorkspaces[workspace.Name] = true
+		if !workspace.Optional && !boundWorkspaces[workspace.Name] {
+			problems = append(problems, fmt.Sprintf("the workspace %s of the pipeline is not bound by the pipelinerun", workspace.Name))
+		}
+	}
+
+	tasks := slices.Concat(spec.Tasks, spec.Finally)
+	taskNames := map[string]bool{}
+	for _, task := range tasks {
+		taskNames[task.Name] = true
+	}
+
+	for _, task := range tasks {
+		// the params of the pipelineTask are evaluated in the scope of the
+		// pipeline and the ones of its taskSpec in the scope of the task
+		pipelineTask := task.DeepCopy()
+		pipelineTask.TaskSpec = nil
+		for _, name := range undeclaredParams(pipelineTask, pipelineParams) {
+			problems = append(problems, fmt.Sprintf("the param %s used by the task %s is not declared", name, task.Name))
+		}
+		if task.TaskSpec != nil {
+			// the params of the pipeline and of the pipelineTask are
+			// propagated to the embedded taskSpec
+			taskParams := map[string]bool{}
+			for name := range pipelineParams {
+				taskParams[name] = true
+			}
+			for _, param := range task.TaskSpec.Params {
+				taskParams[param.Name] = true
+			}
+			for _, param := range task.Params {
+				taskParams[param.Name] = true
+			}
+			if task.Matrix != nil {
+				for _, param := range task.Matrix.Params {
+					taskParams[param.Name] = true
+				}
+				for _, include := range task.Matrix.Include {
+					for _, param := range include.Params {
+						taskParams[param.Name] = true
+					}
+				}
+			}
+			for _, name := range undeclaredParams(task.TaskSpec, taskParams) {
+				problems = append(problems, fmt.Sprintf("the param %s used in the spec of the task %s is not declared", name, task.Name))
+			}
+
+			taskWorkspaces := map[string]bool{}
+			for _, binding := range task.Workspaces {
+				taskWorkspaces[binding.Name] = true
+			}
+			for _, workspace := range task.TaskSpec.Workspaces {
+				// the workspaces of the pipeline are propagated to the
+				// embedded taskSpec
+				if !workspace.Optional && !taskWorkspaces[workspace.Name] && !pipelineWorkspaces[workspace.Name] {
+					problems = append(problems, fmt.Sprintf("the workspace %s of the task %s is not bound", workspace.Name, task.Name))
+				}
+			}
+		}
+
+		for _, name := range references(taskResultRefRe, &task) {
+			if !taskNames[name] {
+				problems = append(problems, fmt.Sprintf("the task %s references the results of the task %s which doesn't exist", task.Name, name))
+			}
+		}
+	}
+	for _, result := range spec.Results {
+		for _, name := range references(taskResultRefRe, result) {
+			if !taskNames[name] {
+				problems = append(problems, fmt.Sprintf("the pipeline result %s references the results of the task %s which doesn't exist", result.Name, name))
+			}
+		}
+	}
+
+	if len(problems) == 0 {
+		return nil
+	}
+	prName := pipelinerun.GetName()
+	if prName == "" {
+		prName = pipelinerun.GetGenerateName()
+	}
+	return fmt.Errorf("pipelinerun %s is invalid: %s", prName, strings.Join(problems, ", "))
+}
+
+// undeclaredParams returns the params referenced in obj which are not in
+// declared.
+func undeclaredParams(obj any, declared map[string]bool) []string {
+	ret := []string{}
+	for _, name := range references(paramRefRe, obj) {
+		if !declared[name] {
+			ret = append(ret, name)
+		}
+	}
+	return ret
+}
+
+// references returns the unique names captured by re in the json
+// representation of obj, in the order they appear.
+func references(re *regexp.Regexp, obj any) []string {
+	data, err := json.Marshal(obj)
+	if err != nil {
+		return nil
+	}
+	ret := []string{}
+	seen := map[string]bool{}
+	for _, match := range re.FindAllStringSubmatch(string(data), -1) {
+		name := match[1]
+		if name == "" && len(match) > 2 {
+			name = match[2]
+		}
+		if !seen[name] {
+			seen[name] = true
+			ret = append(ret, name)
+		}
+	}
+	return ret
+}
+
+// validatePipelineRunReferences checks that the PipelineRun provides the params
+// required by the Pipeline it references, the unbound workspaces are reported
+// by validatePipelineSpec once the Pipeline is embedded.
+func validatePipelineRunReferences(pipelinerun *tektonv1.PipelineRun, pipeline *tektonv1.Pipeline) error {
+	params := map[string]bool{}
+	for _, param := range pipelinerun.Spec.Params {
+		params[param.Name] = true
+	}
+	problems := []string{}
+	for _, param := range pipeline.Spec.Params {
+		if param.Default == nil && !params[param.Name] {
+			problems = append(problems, fmt.Sprintf("the param %s required by the pipeline %s is not provided", param.Name, pipeline.GetName()))
+		}
+	}
+	if len(problems) == 0 {
+		return nil
+	}
+	prName := pipelinerun.GetName()
+	if prName == "" {
+		prName = pipelinerun.GetGenerateName()
+	}
+	return fmt.Errorf("pipelinerun %s is invalid: %s", prName, strings.Join(problems, ", "))
+}
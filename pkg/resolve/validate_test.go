@@ -0,0 +1,185 @@
+package resolve
+
+import (
+	"testing"
+
+	tektonv1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+	"gotest.tools/v3/assert"
+	"sigs.k8s.io/yaml"
+)
+
+func TestValidatePipelineSpec(t *testing.T) {
+	tests := []struct {
+		name    string
+		spec    string
+		wantErr string
+	}{
+		{
+			name: "no pipelineSpec",
+			spec: `
+pipelineRef:
+  name: pipeline
+  resolver: git`,
+		},
+		{
+			name: "valid",
+			spec: `
+params:
+  - name: revision
+    value: main
+workspaces:
+  - name: source
+    emptyDir: {}
+pipelineSpec:
+  params:
+    - name: url
+      default: https://example.com
+    - name: config
+      type: object
+  workspaces:
+    - name: source
+    - name: cache
+      optional: true
+  tasks:
+    - name: fetch
+      params:
+        - name: url
+          value: $(params.url)
+        - name: key
+          value: $(params.config.key)
+      taskSpec:
+        params:
+          - name: url
+        results:
+          - name: commit
+        steps:
+          - name: fetch
+            image: image
+            script: git clone $(params.url) -b $(params.revision) $(params["key"])
+    - name: build
+      params:
+        - name: commit
+          value: $(tasks.fetch.results.commit)
+      workspaces:
+        - name: output
+          workspace: source
+      taskSpec:
+        workspaces:
+          - name: output
+          - name: source
+        steps:
+          - name: build
+            image: image
+            script: make $(params.commit)
+  results:
+    - name: commit
+      value: $(tasks.fetch.results.commit)`,
+		},
+		{
+			name: "undeclared params",
+			spec: `
+pipelineSpec:
+  tasks:
+    - name: build
+      params:
+        - name: url
+          value: $(params.url)
+      taskSpec:
+        steps:
+          - name: build
+            image: image
+            script: make $(params.target)`,
+			wantErr: "pipelinerun pr is invalid: the param url used by the task build is not declared, " +
+				"the param target used in the spec of the task build is not declared",
+		},
+		{
+			name: "unbound workspaces",
+			spec: `
+pipelineSpec:
+  workspaces:
+    - name: source
+  tasks:
+    - name: build
+      taskSpec:
+        workspaces:
+          - name: cache
+        steps:
+          - name: build
+            image: image`,
+			wantErr: "pipelinerun pr is invalid: the workspace source of the pipeline is not bound by the pipelinerun, " +
+				"the workspace cache of the task build is not bound",
+		},
+		{
+			name: "results of unknown tasks",
+			spec: `
+pipelineSpec:
+  tasks:
+    - name: build
+      params:
+        - name: commit
+          value: $(tasks.fetch.results.commit)
+      taskRef:
+        name: build
+  results:
+    - name: image
+      value: $(tasks.publish.results.image)`,
+			wantErr: "pipelinerun pr is invalid: the task build references the results of the task fetch which doesn't exist, " +
+				"the pipeline result image references the results of the task publish which doesn't exist",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pr := &tektonv1.PipelineRun{}
+			pr.Name = "pr"
+			assert.NilError(t, yaml.Unmarshal([]byte(tt.spec), &pr.Spec))
+			err := validatePipelineSpec(pr)
+			if tt.wantErr != "" {
+				assert.Error(t, err, tt.wantErr)
+				return
+			}
+			assert.NilError(t, err)
+		})
+	}
+}
+
+func TestValidatePipelineRunReferences(t *testing.T) {
+	pipeline := &tektonv1.Pipeline{}
+	pipeline.Name = "pipeline"
+	assert.NilError(t, yaml.Unmarshal([]byte(`
+params:
+  - name: event
+  - name: target
+  - name: url
+    default: https://example.com`), &pipeline.Spec))
+
+	tests := []struct {
+		name    string
+		params  []string
+		wantErr string
+	}{
+		{
+			name:   "required params provided",
+			params: []string{"event", "target"},
+		},
+		{
+			name:    "required params missing",
+			params:  []string{"url"},
+			wantErr: "pipelinerun pr- is invalid: the param event required by the pipeline pipeline is not provided, the param target required by the pipeline pipeline is not provided",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pr := &tektonv1.PipelineRun{}
+			pr.GenerateName = "pr-"
+			for _, name := range tt.params {
+				pr.Spec.Params = append(pr.Spec.Params, tektonv1.Param{Name: name, Value: *tektonv1.NewStructuredValues("value")})
+			}
+			err := validatePipelineRunReferences(pr, pipeline)
+			if tt.wantErr != "" {
+				assert.Error(t, err, tt.wantErr)
+				return
+			}
+			assert.NilError(t, err)
+		})
+	}
+}
@@ -0,0 +1,222 @@
+package sdk
+
+import (
+	"context"
+	"fmt"
+	"io/fs"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/changedfiles"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/events"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/formatting"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/matcher"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/params/info"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/params/triggertype"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/provider"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/provider/github"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/resolve"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/templates"
+	tektonv1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+)
+
+// Event is the synthetic event the PipelineRuns of a .tekton directory are
+// matched against.
+type Event struct {
+	// EventType is the type of the event, pull_request or push.
+	EventType string
+	// URL is the URL of the repository, ie: https://github.com/org/repo.
+	URL string
+	// SHA is the commit of the event.
+	SHA string
+	// BaseBranch is the target branch of the pull request or the branch of
+	// the push.
+	BaseBranch string
+	// HeadBranch is the source branch of the pull request.
+	HeadBranch string
+	// PullRequestNumber is the number of the pull request.
+	PullRequestNumber int
+	// PullRequestLabels are the labels of the pull request.
+	PullRequestLabels []string
+	// Sender is the user who triggered the event.
+	Sender string
+	// ChangedFiles are the files changed by the event, for the on-path-change
+	// annotations and the files.* CEL variables.
+	ChangedFiles changedfiles.ChangedFiles
+	// Params are added to or override the standard parameters replaced in
+	// the templates, ie: revision or repo_url.
+	Params map[string]string
+}
+
+// ResolveOpts are the options to resolve a .tekton directory.
+type ResolveOpts struct {
+	// RemoteTasks fetches and inlines the remote tasks and pipelines of the
+	// annotations, they are kept as references otherwise.
+	RemoteTasks bool
+	// GenerateName sets a generateName to the PipelineRuns with a name.
+	GenerateName bool
+}
+
+// Resolution is the result of resolving a .tekton directory for an event.
+type Resolution struct {
+	// PipelineRuns are the resolved PipelineRuns matching the event.
+	PipelineRuns []*tektonv1.PipelineRun
+	// Skipped are the PipelineRuns not matching the event with the reason.
+	Skipped []matcher.Skipped
+}
+
+// localProvider serves the changed files of the synthetic event to the
+// matcher, it never reaches a git provider.
+type localProvider struct {
+	provider.Interface
+	changedFiles changedfiles.ChangedFiles
+}
+
+func (l *localProvider) GetFiles(context.Context, *info.Event) (changedfiles.ChangedFiles, error) {
+	return l.changedFiles, nil
+}
+
+// CreateComment drops the CEL validation errors, they are logged already.
+func (l *localProvider) CreateComment(context.Context, *info.Event, string, string) error {
+	return nil
+}
+
+func (l *localProvider) GetTaskURI(context.Context, *info.Event, string) (bool, string, error) {
+	return false, "", nil
+}
+
+// ResolveTektonDir returns the PipelineRuns of the yaml files of dir matching
+// the event, with their parameters replaced and their tasks resolved like the
+// controller would run them. It fails when no PipelineRun matches.
+func (c *Client) ResolveTektonDir(ctx context.Context, dir string, event Event, opts ResolveOpts) (*Resolution, error) {
+	rawTemplates, err := readTektonDir(dir)
+	if err != nil {
+		return nil, err
+	}
+	if rawTemplates == "" {
+		return nil, fmt.Errorf("cannot find any yaml file in %s", dir)
+	}
+
+	infoEvent, err := newInfoEvent(event)
+	if err != nil {
+		return nil, err
+	}
+	vcx := &localProvider{Interface: github.New(), changedFiles: event.ChangedFiles}
+	changedFiles := map[string]any{
+		"all":      event.ChangedFiles.All,
+		"added":    event.ChangedFiles.Added,
+		"deleted":  event.ChangedFiles.Deleted,
+		"modified": event.ChangedFiles.Modified,
+		"renamed":  event.ChangedFiles.Renamed,
+	}
+	allTemplates := templates.ReplacePlaceHoldersVariables(rawTemplates, standardParams(infoEvent, event.Params), nil, http.Header{}, changedFiles)
+
+	types, err := resolve.ReadTektonTypes(ctx, c.logger, allTemplates)
+	if err != nil {
+		return nil, err
+	}
+	if len(types.PipelineRuns) == 0 {
+		return nil, fmt.Errorf("cannot find any PipelineRun in %s", dir)
+	}
+	pipelineRuns, err := resolve.MetadataResolve(types.PipelineRuns)
+	if err != nil {
+		return nil, err
+	}
+
+	eventEmitter := events.NewEventEmitter(c.run.Clients.Kube, c.logger)
+	matched, skipped, err := matcher.MatchPipelinerunByAnnotationWithSkipped(ctx, c.logger, pipelineRuns, c.run, infoEvent, vcx, eventEmitter, nil)
+	if err != nil {
+		return &Resolution{Skipped: skipped}, err
+	}
+	types.PipelineRuns = make([]*tektonv1.PipelineRun, 0, len(matched))
+	for _, match := range matched {
+		types.PipelineRuns = append(types.PipelineRuns, match.PipelineRun)
+	}
+
+	resolved, err := resolve.Resolve(ctx, c.run, c.logger, vcx, types, infoEvent, &resolve.Opts{
+		RemoteTasks:  opts.RemoteTasks,
+		GenerateName: opts.GenerateName,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &Resolution{PipelineRuns: resolved, Skipped: skipped}, nil
+}
+
+// readTektonDir returns the yaml files of dir and its subdirectories as one
+// multi document yaml, in the order of their path.
+func readTektonDir(dir string) (string, error) {
+	allTemplates := ""
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if ext := filepath.Ext(path); d.IsDir() || (ext != ".yaml" && ext != ".yml") {
+			return nil
+		}
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		if err := provider.ValidateYaml(data, path); err != nil {
+			return err
+		}
+		allTemplates = provider.AppendYamlFile(allTemplates, path, string(data))
+		return nil
+	})
+	return allTemplates, err
+}
+
+// newInfoEvent returns the event of the matcher for the synthetic event.
+func newInfoEvent(event Event) (*info.Event, error) {
+	infoEvent := info.NewEvent()
+	switch event.EventType {
+	case triggertype.PullRequest.String():
+		infoEvent.TriggerTarget = triggertype.PullRequest
+	case triggertype.Push.String():
+		infoEvent.TriggerTarget = triggertype.Push
+	default:
+		return nil, fmt.Errorf("unsupported event type %q, it must be %s or %s", event.EventType, triggertype.PullRequest, triggertype.Push)
+	}
+	infoEvent.EventType = event.EventType
+	infoEvent.URL = event.URL
+	infoEvent.SHA = event.SHA
+	infoEvent.BaseBranch = event.BaseBranch
+	infoEvent.HeadBranch = event.HeadBranch
+	if infoEvent.HeadBranch == "" {
+		infoEvent.HeadBranch = event.BaseBranch
+	}
+	infoEvent.PullRequestNumber = event.PullRequestNumber
+	infoEvent.PullRequestLabel = event.PullRequestLabels
+	infoEvent.Sender = event.Sender
+	if event.URL != "" {
+		org, repo, err := formatting.GetRepoOwnerSplitted(event.URL)
+		if err != nil {
+			return nil, err
+		}
+		infoEvent.Organization, infoEvent.Repository = org, repo
+	}
+	return infoEvent, nil
+}
+
+// standardParams returns the standard parameters of the event, overridden by
+// params.
+func standardParams(event *info.Event, params map[string]string) map[string]string {
+	ret := map[string]string{
+		"revision":            event.SHA,
+		"repo_url":            event.URL,
+		"repo_owner":          strings.ToLower(event.Organization),
+		"repo_name":           strings.ToLower(event.Repository),
+		"target_branch":       formatting.SanitizeBranch(event.BaseBranch),
+		"source_branch":       formatting.SanitizeBranch(event.HeadBranch),
+		"sender":              strings.ToLower(event.Sender),
+		"event_type":          event.EventType,
+		"pull_request_labels": strings.Join(event.PullRequestLabel, "\\n"),
+	}
+	for k, v := range params {
+		ret[k] = v
+	}
+	return ret
+}
@@ -0,0 +1,167 @@
+package sdk
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/changedfiles"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/params"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/params/clients"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/params/info"
+	"gotest.tools/v3/assert"
+	rtesting "knative.dev/pkg/reconciler/testing"
+)
+
+const pullRequestRun = `apiVersion: tekton.dev/v1
+kind: PipelineRun
+metadata:
+  name: on-pull-request
+  annotations:
+    pipelinesascode.tekton.dev/on-event: "[pull_request]"
+    pipelinesascode.tekton.dev/on-target-branch: "[main]"
+spec:
+  params:
+    - name: revision
+      value: "{{ revision }}"
+  pipelineSpec:
+    tasks:
+      - name: task
+        taskSpec:
+          steps:
+            - name: step
+              image: busybox
+              script: echo {{ repo_owner }}/{{ repo_name }}
+`
+
+const docsRun = `apiVersion: tekton.dev/v1
+kind: PipelineRun
+metadata:
+  name: on-docs-change
+  annotations:
+    pipelinesascode.tekton.dev/on-cel-expression: event == "pull_request" && "docs/***".pathChanged()
+spec:
+  pipelineSpec:
+    tasks:
+      - name: task
+        taskSpec:
+          steps:
+            - name: step
+              image: busybox
+              script: echo docs
+`
+
+const pushRun = `apiVersion: tekton.dev/v1
+kind: PipelineRun
+metadata:
+  name: on-push
+  annotations:
+    pipelinesascode.tekton.dev/on-event: "[push]"
+    pipelinesascode.tekton.dev/on-target-branch: "[main]"
+spec:
+  pipelineSpec:
+    tasks:
+      - name: task
+        taskSpec:
+          steps:
+            - name: step
+              image: busybox
+              script: echo push
+`
+
+func TestResolveTektonDir(t *testing.T) {
+	tests := []struct {
+		name        string
+		event       Event
+		wantRuns    []string
+		wantSkipped int
+		wantScript  string
+		wantErr     string
+	}{
+		{
+			name: "pull request",
+			event: Event{
+				EventType:  "pull_request",
+				URL:        "https://github.com/Org/Repo",
+				SHA:        "abc123",
+				BaseBranch: "main",
+				HeadBranch: "feature",
+			},
+			wantRuns:    []string{"on-pull-request"},
+			wantSkipped: 2,
+			wantScript:  "echo org/repo",
+		},
+		{
+			name: "pull request changing the docs",
+			event: Event{
+				EventType:    "pull_request",
+				URL:          "https://github.com/org/repo",
+				SHA:          "abc123",
+				BaseBranch:   "main",
+				HeadBranch:   "feature",
+				ChangedFiles: changedfiles.ChangedFiles{All: []string{"docs/index.md"}, Modified: []string{"docs/index.md"}},
+				Params:       map[string]string{"repo_owner": "override"},
+			},
+			wantRuns:    []string{"on-docs-change", "on-pull-request"},
+			wantSkipped: 1,
+			wantScript:  "echo override/repo",
+		},
+		{
+			name: "push",
+			event: Event{
+				EventType:  "push",
+				URL:        "https://github.com/org/repo",
+				SHA:        "abc123",
+				BaseBranch: "main",
+			},
+			wantRuns:    []string{"on-push"},
+			wantSkipped: 2,
+		},
+		{
+			name: "no match",
+			event: Event{
+				EventType:  "push",
+				URL:        "https://github.com/org/repo",
+				BaseBranch: "release",
+			},
+			wantSkipped: 3,
+			wantErr:     "cannot match the event to any pipelineruns",
+		},
+		{
+			name:    "unsupported event type",
+			event:   Event{EventType: "incoming"},
+			wantErr: `unsupported event type "incoming"`,
+		},
+	}
+	dir := t.TempDir()
+	for name, content := range map[string]string{"pr.yaml": pullRequestRun, "docs/docs.yml": docsRun, "push.yaml": pushRun, "README.md": "not a pipelinerun"} {
+		assert.NilError(t, os.MkdirAll(filepath.Dir(filepath.Join(dir, name)), 0o755))
+		assert.NilError(t, os.WriteFile(filepath.Join(dir, name), []byte(content), 0o600))
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, _ := rtesting.SetupFakeContext(t)
+			client := NewClient(&params.Run{Clients: clients.Clients{}, Info: info.Info{}})
+
+			resolution, err := client.ResolveTektonDir(ctx, dir, tt.event, ResolveOpts{})
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, err, tt.wantErr)
+				if resolution != nil {
+					assert.Equal(t, len(resolution.Skipped), tt.wantSkipped)
+				}
+				return
+			}
+			assert.NilError(t, err)
+			names := []string{}
+			for _, pr := range resolution.PipelineRuns {
+				names = append(names, pr.GetName())
+			}
+			assert.DeepEqual(t, names, tt.wantRuns)
+			assert.Equal(t, len(resolution.Skipped), tt.wantSkipped)
+			if tt.wantScript != "" {
+				last := resolution.PipelineRuns[len(resolution.PipelineRuns)-1]
+				assert.Equal(t, last.Spec.PipelineSpec.Tasks[0].TaskSpec.Steps[0].Script, tt.wantScript)
+			}
+		})
+	}
+}
@@ -0,0 +1,67 @@
+// Package sdk embeds the logic of Pipelines-as-Code in other Go programs, to
+// validate the Repositories, resolve the PipelineRuns of a .tekton directory
+// for an event and query the runs of a Repository without running tkn-pac.
+package sdk
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/apis/pipelinesascode/v1alpha1"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/formatting"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/params"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/sort"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/webhook"
+	"go.uber.org/zap"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// Client runs the logic of Pipelines-as-Code with the clients of a
+// params.Run, its PipelineAsCode client is used to get the Repositories.
+type Client struct {
+	run    *params.Run
+	logger *zap.SugaredLogger
+}
+
+// NewClient returns a Client using the clients and the settings of run, the
+// logs are discarded when run has no logger.
+func NewClient(run *params.Run) *Client {
+	logger := run.Clients.Log
+	if logger == nil {
+		logger = zap.NewNop().Sugar()
+	}
+	return &Client{run: run, logger: logger}
+}
+
+// ValidateRepository returns the error the admission webhook would return
+// when creating repo, systemNamespace is the namespace where
+// Pipelines-as-Code is installed.
+func (c *Client) ValidateRepository(ctx context.Context, repo *v1alpha1.Repository, systemNamespace string) error {
+	if err := webhook.ValidateRepository(repo, systemNamespace); err != nil {
+		return err
+	}
+	if repo.Spec.URL == "" {
+		return nil
+	}
+	repositories, err := c.run.Clients.PipelineAsCode.PipelinesascodeV1alpha1().Repositories("").List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return err
+	}
+	for _, other := range repositories.Items {
+		if formatting.NormalizeRepoURL(other.Spec.URL) == formatting.NormalizeRepoURL(repo.Spec.URL) &&
+			(other.GetName() != repo.GetName() || other.GetNamespace() != repo.GetNamespace()) {
+			return fmt.Errorf("repository already exists with URL: %s", repo.Spec.URL)
+		}
+	}
+	return nil
+}
+
+// RunHistory returns the runs of the Repository recorded in its status, the
+// most recent first.
+func (c *Client) RunHistory(ctx context.Context, namespace, name string) ([]v1alpha1.RepositoryRunStatus, error) {
+	repo, err := c.run.Clients.PipelineAsCode.PipelinesascodeV1alpha1().Repositories(namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("cannot get repository %s/%s: %w", namespace, name, err)
+	}
+	return sort.RepositorySortRunStatus(repo.Status), nil
+}
@@ -0,0 +1,109 @@
+package sdk
+
+import (
+	"testing"
+	"time"
+
+	"github.com/jonboulle/clockwork"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/apis/pipelinesascode/v1alpha1"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/params"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/params/clients"
+	testclient "github.com/openshift-pipelines/pipelines-as-code/pkg/test/clients"
+	testnewrepo "github.com/openshift-pipelines/pipelines-as-code/pkg/test/repository"
+	"gotest.tools/v3/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	rtesting "knative.dev/pkg/reconciler/testing"
+)
+
+func TestValidateRepository(t *testing.T) {
+	existing := testnewrepo.NewRepo(testnewrepo.RepoTestcreationOpts{
+		Name:             "existing",
+		InstallNamespace: "existing-ns",
+		URL:              "https://github.com/org/existing",
+	})
+	tests := []struct {
+		name    string
+		repo    *v1alpha1.Repository
+		wantErr string
+	}{
+		{
+			name: "valid",
+			repo: testnewrepo.NewRepo(testnewrepo.RepoTestcreationOpts{
+				Name:             "repo",
+				InstallNamespace: "ns",
+				URL:              "https://github.com/org/repo",
+			}),
+		},
+		{
+			name: "no url",
+			repo: testnewrepo.NewRepo(testnewrepo.RepoTestcreationOpts{
+				Name:             "repo",
+				InstallNamespace: "ns",
+			}),
+			wantErr: "URL must be set",
+		},
+		{
+			name: "no url in the system namespace",
+			repo: testnewrepo.NewRepo(testnewrepo.RepoTestcreationOpts{
+				Name:             "repo",
+				InstallNamespace: "pipelines-as-code",
+			}),
+		},
+		{
+			name: "same url as another repository",
+			repo: testnewrepo.NewRepo(testnewrepo.RepoTestcreationOpts{
+				Name:             "repo",
+				InstallNamespace: "ns",
+				URL:              "https://github.com/org/existing/",
+			}),
+			wantErr: "repository already exists with URL: https://github.com/org/existing/",
+		},
+		{
+			name:    "existing repository itself",
+			repo:    existing,
+			wantErr: "",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, _ := rtesting.SetupFakeContext(t)
+			cs, _ := testclient.SeedTestData(t, ctx, testclient.Data{Repositories: []*v1alpha1.Repository{existing}})
+			client := NewClient(&params.Run{Clients: clients.Clients{PipelineAsCode: cs.PipelineAsCode}})
+
+			err := client.ValidateRepository(ctx, tt.repo, "pipelines-as-code")
+			if tt.wantErr != "" {
+				assert.Error(t, err, tt.wantErr)
+				return
+			}
+			assert.NilError(t, err)
+		})
+	}
+}
+
+func TestRunHistory(t *testing.T) {
+	cw := clockwork.NewFakeClock()
+	repo := testnewrepo.NewRepo(testnewrepo.RepoTestcreationOpts{
+		Name:             "repo",
+		InstallNamespace: "ns",
+		URL:              "https://github.com/org/repo",
+		RepoStatus: []v1alpha1.RepositoryRunStatus{
+			{PipelineRunName: "older", StartTime: &metav1.Time{Time: cw.Now().Add(-time.Hour)}},
+			{PipelineRunName: "newer", StartTime: &metav1.Time{Time: cw.Now().Add(-time.Minute)}},
+			{PipelineRunName: "oldest", StartTime: &metav1.Time{Time: cw.Now().Add(-2 * time.Hour)}},
+		},
+	})
+	ctx, _ := rtesting.SetupFakeContext(t)
+	cs, _ := testclient.SeedTestData(t, ctx, testclient.Data{Repositories: []*v1alpha1.Repository{repo}})
+	client := NewClient(&params.Run{Clients: clients.Clients{PipelineAsCode: cs.PipelineAsCode}})
+
+	history, err := client.RunHistory(ctx, "ns", "repo")
+	assert.NilError(t, err)
+	names := []string{}
+	for _, run := range history {
+		names = append(names, run.PipelineRunName)
+	}
+	assert.DeepEqual(t, names, []string{"newer", "older", "oldest"})
+
+	_, err = client.RunHistory(ctx, "ns", "missing")
+	assert.ErrorContains(t, err, "cannot get repository ns/missing")
+}
@@ -20,13 +20,29 @@ const (
 	[credential "%s"]
 	helper=store
 	`
+	// git-lfs asks for the credentials with the basic access of the
+	// endpoints of the provider host, the main repository and the submodules
+	// ones.
+	basicAuthLFSConfigData = `
+	[lfs "%s/"]
+	access = basic
+	`
+	// the ssh urls of the submodules on the provider host are cloned with
+	// https to reuse the credentials of the token.
+	basicAuthInsteadOfConfigData = `
+	[url "%s/"]
+	%s
+	`
 	//nolint:gosec
 	basicAuthSecretName = `pac-gitauth-%s`
 	ranStringSeedLen    = 6
 )
 
 // MakeBasicAuthSecret Make a secret for git-clone basic-auth workspace.
-func MakeBasicAuthSecret(runevent *info.Event, secretName string) (*corev1.Secret, error) {
+// submoduleURLs are the urls of the submodules of the repository as declared
+// in its .gitmodules, the ones on the host of the repository are fetched
+// with the token of the event.
+func MakeBasicAuthSecret(runevent *info.Event, secretName string, submoduleURLs ...string) (*corev1.Secret, error) {
 	// Bitbucket Data Center have a different Clone URL than it's Repository URL, so we
 	// have to separate them
 	cloneURL := runevent.URL
@@ -57,8 +73,16 @@ func MakeBasicAuthSecret(runevent *info.Event, secretName string) (*corev1.Secre
 
 	baseCloneURL := fmt.Sprintf("%s://%s", repoURL.Scheme, repoURL.Host)
 	urlWithToken := fmt.Sprintf("%s://%s:%s@%s%s", repoURL.Scheme, gitUser, token, repoURL.Host, repoURL.Path)
+	gitConfig := fmt.Sprintf(basicAuthGitConfigData, baseCloneURL) + fmt.Sprintf(basicAuthLFSConfigData, baseCloneURL)
+	if insteadOf := sshSubmodulePrefixes(repoURL, submoduleURLs); len(insteadOf) > 0 {
+		lines := make([]string, 0, len(insteadOf))
+		for _, prefix := range insteadOf {
+			lines = append(lines, fmt.Sprintf("insteadOf = %s", prefix))
+		}
+		gitConfig += fmt.Sprintf(basicAuthInsteadOfConfigData, baseCloneURL, strings.Join(lines, "\n\t"))
+	}
 	secretData := map[string]string{
-		".gitconfig":       fmt.Sprintf(basicAuthGitConfigData, baseCloneURL),
+		".gitconfig":       gitConfig,
 		".git-credentials": urlWithToken,
 		// With the GitHub APP method the token is available for 8h if you have
 		// the user to server token expiration.  the token is scoped to the
@@ -92,6 +116,65 @@ func MakeBasicAuthSecret(runevent *info.Event, secretName string) (*corev1.Secre
 	}, nil
 }
 
+// SubmoduleURLs returns the urls of the submodules declared in the content of
+// a .gitmodules file.
+func SubmoduleURLs(gitmodules string) []string {
+	urls := []string{}
+	for _, line := range strings.Split(gitmodules, "\n") {
+		key, value, ok := strings.Cut(strings.TrimSpace(line), "=")
+		if !ok || strings.TrimSpace(key) != "url" {
+			continue
+		}
+		if value = strings.TrimSpace(value); value != "" {
+			urls = append(urls, value)
+		}
+	}
+	return urls
+}
+
+// sshSubmodulePrefixes returns the prefixes of the ssh urls of the
+// submodules hosted on the host of the repository, the relative and https
+// urls already use the credentials of the host.
+func sshSubmodulePrefixes(repoURL *url.URL, submoduleURLs []string) []string {
+	seen := map[string]bool{}
+	prefixes := []string{}
+	for _, submoduleURL := range submoduleURLs {
+		prefix := ""
+		switch {
+		case strings.HasPrefix(submoduleURL, "ssh://"):
+			u, err := url.Parse(submoduleURL)
+			if err != nil || u.Hostname() != repoURL.Hostname() {
+				continue
+			}
+			prefix = fmt.Sprintf("ssh://%s/", u.Host)
+			if u.User != nil {
+				prefix = fmt.Sprintf("ssh://%s@%s/", u.User.Username(), u.Host)
+			}
+		case strings.Contains(submoduleURL, "://"):
+			continue
+		default:
+			// scp like syntax, user@host:path
+			userHost, _, ok := strings.Cut(submoduleURL, ":")
+			if !ok || strings.Contains(userHost, "/") {
+				continue
+			}
+			host := userHost
+			if _, h, ok := strings.Cut(userHost, "@"); ok {
+				host = h
+			}
+			if host != repoURL.Hostname() {
+				continue
+			}
+			prefix = userHost + ":"
+		}
+		if !seen[prefix] {
+			seen[prefix] = true
+			prefixes = append(prefixes, prefix)
+		}
+	}
+	return prefixes
+}
+
 func GenerateBasicAuthSecretName() string {
 	return strings.ToLower(
 		fmt.Sprintf(basicAuthSecretName, random.AlphaString(ranStringSeedLen)))
@@ -0,0 +1,42 @@
+package secrets
+
+import (
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/apis/pipelinesascode"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/apis/pipelinesascode/keys"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/apis/pipelinesascode/v1alpha1"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/formatting"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/params/info"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// MakeCIVariablesSecret makes the secret holding the CI variables of the Git
+// provider, keyed as mapped in the ci_variables setting of the Repository.
+func MakeCIVariablesSecret(runevent *info.Event, ciVariables *v1alpha1.CIVariables, values map[string]string) *corev1.Secret {
+	secretData := map[string]string{}
+	for _, variable := range ciVariables.Variables {
+		key := variable.Key
+		if key == "" {
+			key = variable.Name
+		}
+		secretData[key] = values[variable.Name]
+	}
+	return &corev1.Secret{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: corev1.SchemeGroupVersion.String(),
+			Kind:       "Secret",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name: ciVariables.SecretName,
+			Labels: map[string]string{
+				"app.kubernetes.io/managed-by": pipelinesascode.GroupName,
+				keys.URLOrg:                    formatting.CleanValueKubernetes(runevent.Organization),
+				keys.URLRepository:             formatting.CleanValueKubernetes(runevent.Repository),
+			},
+			Annotations: map[string]string{
+				keys.SHA: runevent.SHA,
+			},
+		},
+		StringData: secretData,
+	}
+}
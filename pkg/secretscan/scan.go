@@ -0,0 +1,150 @@
+package secretscan
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+
+	pacerrors "github.com/openshift-pipelines/pipelines-as-code/pkg/errors"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/provider"
+)
+
+const (
+	// minEntropyLength is the minimum length of a word checked for its
+	// entropy, shorter random strings are too common to be reported.
+	minEntropyLength = 32
+	// minEntropy is the Shannon entropy in bits per character above which a
+	// word looks like a random credential. The hexadecimal strings, like the
+	// SHAs and the digests, are below it.
+	minEntropy = 4.5
+	// minAssignedEntropy is the entropy above which a value assigned to a
+	// key named like a credential is reported.
+	minAssignedEntropy = 3
+)
+
+// rule detects a kind of credential.
+type rule struct {
+	name string
+	re   *regexp.Regexp
+}
+
+var rules = []rule{
+	{name: "AWS access key", re: regexp.MustCompile(`\b(?:AKIA|ASIA)[0-9A-Z]{16}\b`)},
+	{name: "GitHub token", re: regexp.MustCompile(`\b(?:gh[pousr]_[A-Za-z0-9]{36,}|github_pat_[A-Za-z0-9_]{22,})`)},
+	{name: "GitLab token", re: regexp.MustCompile(`\bglpat-[A-Za-z0-9_-]{20,}`)},
+	{name: "Slack token", re: regexp.MustCompile(`\bxox[abposr]-[A-Za-z0-9-]{10,}`)},
+	{name: "Google API key", re: regexp.MustCompile(`\bAIza[0-9A-Za-z_-]{35}\b`)},
+	{name: "Private key", re: regexp.MustCompile(`-----BEGIN (?:[A-Z]+ )*PRIVATE KEY-----`)},
+}
+
+var (
+	// assignmentRe matches a value assigned to a key named like a
+	// credential, the placeholders and the variables are not values.
+	assignmentRe = regexp.MustCompile(`(?i)\b[a-z_-]*(?:password|passwd|secret|token|api[_-]?key)["']?\s*[:=]\s*["']?([^\s"'{}$()<>]{8,})`)
+	wordRe       = regexp.MustCompile(`[A-Za-z0-9+/_=-]{32,}`)
+	markerRe     = regexp.MustCompile(`^` + regexp.QuoteMeta(provider.SourceMarker) + `(.+):(\d+)$`)
+)
+
+// Finding is a likely credential found in the PipelineRuns.
+type Finding struct {
+	Rule     string
+	Position pacerrors.Position
+	// Redacted is the beginning of the credential, the rest is masked.
+	Redacted string
+}
+
+func (f *Finding) String() string {
+	return fmt.Sprintf("%s %s at %s", f.Rule, f.Redacted, f.Position.String())
+}
+
+// redact masks all but the first characters of a credential.
+func redact(s string) string {
+	if len(s) <= 4 {
+		return "****"
+	}
+	return s[:4] + "****"
+}
+
+// entropy returns the Shannon entropy of s in bits per character.
+func entropy(s string) float64 {
+	counts := map[rune]int{}
+	for _, r := range s {
+		counts[r]++
+	}
+	var e float64
+	for _, count := range counts {
+		p := float64(count) / float64(len(s))
+		e -= p * math.Log2(p)
+	}
+	return e
+}
+
+// isRandom returns whether the word looks like a random credential: long
+// enough, mixing letters and digits with a high entropy.
+func isRandom(word string) bool {
+	if len(word) < minEntropyLength || !strings.ContainsAny(word, "0123456789") {
+		return false
+	}
+	return strings.IndexFunc(word, func(r rune) bool { return r >= 'A' && r <= 'Z' || r >= 'a' && r <= 'z' }) >= 0 &&
+		entropy(word) >= minEntropy
+}
+
+// match is a credential matched by a rule.
+type match struct {
+	rule string
+	text string
+}
+
+// scanLine returns the credentials found in the line, in the order of the
+// rules. The assignments and the high entropy strings are only checked when
+// no known credential has been found.
+func scanLine(line string) []match {
+	found := []match{}
+	for _, r := range rules {
+		if text := r.re.FindString(line); text != "" {
+			found = append(found, match{rule: r.name, text: text})
+		}
+	}
+	if len(found) > 0 {
+		return found
+	}
+	if m := assignmentRe.FindStringSubmatch(line); m != nil && entropy(m[1]) >= minAssignedEntropy {
+		return append(found, match{rule: "Hardcoded credential", text: m[1]})
+	}
+	for _, word := range wordRe.FindAllString(line, -1) {
+		if isRandom(word) {
+			return append(found, match{rule: "High entropy string", text: word})
+		}
+	}
+	return found
+}
+
+// Scan looks for credentials in yaml documents, like the templates of the
+// .tekton directory as concatenated by the providers or the PipelineRuns once
+// resolved. The findings are located with the source markers of the templates
+// when they have them.
+func Scan(templates string) []Finding {
+	findings := []Finding{}
+	file, line := "", 0
+	for _, text := range strings.Split(templates, "\n") {
+		if marker := markerRe.FindStringSubmatch(text); marker != nil {
+			file = marker[1]
+			line, _ = strconv.Atoi(marker[2])
+			continue
+		}
+		line++
+		if strings.HasPrefix(strings.TrimSpace(text), "#") {
+			continue
+		}
+		for _, m := range scanLine(text) {
+			findings = append(findings, Finding{
+				Rule:     m.rule,
+				Position: pacerrors.Position{File: file, Line: line},
+				Redacted: redact(m.text),
+			})
+		}
+	}
+	return findings
+}
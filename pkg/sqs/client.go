@@ -0,0 +1,134 @@
+package sqs
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+const (
+	// maxMessages is the maximum number of messages of a ReceiveMessage call.
+	maxMessages = 10
+	// waitTimeSeconds is the long polling duration of a ReceiveMessage call.
+	waitTimeSeconds = 20
+)
+
+// Message is a message of the queue.
+type Message struct {
+	MessageID     string `json:"MessageId"`
+	ReceiptHandle string `json:"ReceiptHandle"`
+	Body          string `json:"Body"`
+}
+
+// Client calls the SQS API of a queue with the JSON protocol.
+type Client struct {
+	queueURL string
+	endpoint string
+	region   string
+	creds    Credentials
+	http     *http.Client
+	now      func() time.Time
+}
+
+// NewClient returns a client of the queue, its region is read from the URL
+// of the queue when region is empty.
+func NewClient(queueURL, region string, creds Credentials, httpClient *http.Client) (*Client, error) {
+	u, err := url.Parse(queueURL)
+	if err != nil || u.Scheme == "" || u.Host == "" {
+		return nil, fmt.Errorf("invalid sqs queue url %q", queueURL)
+	}
+	if region == "" {
+		region = regionFromHost(u.Hostname())
+	}
+	if region == "" {
+		return nil, fmt.Errorf("cannot get the region of sqs queue %s, set sqs-region", queueURL)
+	}
+	return &Client{
+		queueURL: queueURL,
+		endpoint: fmt.Sprintf("%s://%s/", u.Scheme, u.Host),
+		region:   region,
+		creds:    creds,
+		http:     httpClient,
+		now:      time.Now,
+	}, nil
+}
+
+// regionFromHost returns the region of the sqs.<region>.amazonaws.com and
+// <region>.queue.amazonaws.com hosts.
+func regionFromHost(host string) string {
+	parts := strings.Split(host, ".")
+	switch {
+	case len(parts) >= 4 && parts[0] == "sqs":
+		return parts[1]
+	case len(parts) >= 4 && parts[1] == "queue":
+		return parts[0]
+	}
+	return ""
+}
+
+// Receive waits for the next messages of the queue, it returns no message
+// when none has arrived during the long polling.
+func (c *Client) Receive(ctx context.Context) ([]Message, error) {
+	out := struct {
+		Messages []Message `json:"Messages"`
+	}{}
+	err := c.call(ctx, "ReceiveMessage", map[string]any{
+		"QueueUrl":            c.queueURL,
+		"MaxNumberOfMessages": maxMessages,
+		"WaitTimeSeconds":     waitTimeSeconds,
+	}, &out)
+	return out.Messages, err
+}
+
+// Delete removes a message processed from the queue.
+func (c *Client) Delete(ctx context.Context, message Message) error {
+	return c.call(ctx, "DeleteMessage", map[string]any{
+		"QueueUrl":      c.queueURL,
+		"ReceiptHandle": message.ReceiptHandle,
+	}, nil)
+}
+
+func (c *Client) call(ctx context.Context, action string, in map[string]any, out any) error {
+	body, err := json.Marshal(in)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.endpoint, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/x-amz-json-1.0")
+	req.Header.Set("X-Amz-Target", "AmazonSQS."+action)
+	signV4(req, body, c.creds, c.region, "sqs", c.now())
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return fmt.Errorf("sqs %s has failed: %w", action, err)
+	}
+	defer resp.Body.Close()
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("cannot read the response of sqs %s: %w", action, err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		apiErr := struct {
+			Type    string `json:"__type"`
+			Message string `json:"message"`
+		}{}
+		_ = json.Unmarshal(respBody, &apiErr)
+		return fmt.Errorf("sqs %s has failed with status %d: %s %s", action, resp.StatusCode, apiErr.Type, apiErr.Message)
+	}
+	if out == nil {
+		return nil
+	}
+	if err := json.Unmarshal(respBody, out); err != nil {
+		return fmt.Errorf("cannot parse the response of sqs %s: %w", action, err)
+	}
+	return nil
+}
@@ -0,0 +1,98 @@
+package sqs
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"gotest.tools/v3/assert"
+)
+
+func TestNewClient(t *testing.T) {
+	tests := []struct {
+		name       string
+		queueURL   string
+		region     string
+		wantRegion string
+		wantErr    string
+	}{
+		{
+			name:       "sqs host",
+			queueURL:   "https://sqs.eu-west-1.amazonaws.com/123456789012/pac-events",
+			wantRegion: "eu-west-1",
+		},
+		{
+			name:       "legacy queue host",
+			queueURL:   "https://us-east-2.queue.amazonaws.com/123456789012/pac-events",
+			wantRegion: "us-east-2",
+		},
+		{
+			name:       "region setting",
+			queueURL:   "http://localstack:4566/000000000000/pac-events",
+			region:     "us-east-1",
+			wantRegion: "us-east-1",
+		},
+		{
+			name:     "no region",
+			queueURL: "http://localstack:4566/000000000000/pac-events",
+			wantErr:  "cannot get the region of sqs queue http://localstack:4566/000000000000/pac-events, set sqs-region",
+		},
+		{
+			name:     "invalid url",
+			queueURL: "pac-events",
+			wantErr:  `invalid sqs queue url "pac-events"`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, err := NewClient(tt.queueURL, tt.region, Credentials{}, http.DefaultClient)
+			if tt.wantErr != "" {
+				assert.Error(t, err, tt.wantErr)
+				return
+			}
+			assert.NilError(t, err)
+			assert.Equal(t, c.region, tt.wantRegion)
+		})
+	}
+}
+
+func TestClientReceiveDelete(t *testing.T) {
+	var deleted []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Assert(t, strings.HasPrefix(r.Header.Get("Authorization"), "AWS4-HMAC-SHA256 Credential=AKID/"))
+		assert.Equal(t, r.Header.Get("Content-Type"), "application/x-amz-json-1.0")
+		body, _ := io.ReadAll(r.Body)
+		in := map[string]any{}
+		assert.NilError(t, json.Unmarshal(body, &in))
+		assert.Equal(t, in["QueueUrl"], "http://"+r.Host+"/000000000000/pac-events")
+		switch r.Header.Get("X-Amz-Target") {
+		case "AmazonSQS.ReceiveMessage":
+			assert.Equal(t, in["WaitTimeSeconds"], float64(20))
+			fmt.Fprint(w, `{"Messages": [{"MessageId": "1", "ReceiptHandle": "handle-1", "Body": "{}"}]}`)
+		case "AmazonSQS.DeleteMessage":
+			deleted = append(deleted, in["ReceiptHandle"].(string))
+			fmt.Fprint(w, `{}`)
+		default:
+			w.WriteHeader(http.StatusBadRequest)
+			fmt.Fprint(w, `{"__type": "com.amazonaws.sqs#InvalidAction", "message": "unknown action"}`)
+		}
+	}))
+	defer server.Close()
+
+	ctx := context.Background()
+	c, err := NewClient(server.URL+"/000000000000/pac-events", "us-east-1", Credentials{AccessKeyID: "AKID", SecretAccessKey: "secret"}, server.Client())
+	assert.NilError(t, err)
+	messages, err := c.Receive(ctx)
+	assert.NilError(t, err)
+	assert.DeepEqual(t, messages, []Message{{MessageID: "1", ReceiptHandle: "handle-1", Body: "{}"}})
+	assert.NilError(t, c.Delete(ctx, messages[0]))
+	assert.DeepEqual(t, deleted, []string{"handle-1"})
+
+	err = c.call(ctx, "PurgeQueue", map[string]any{"QueueUrl": c.queueURL}, nil)
+	assert.Error(t, err, "sqs PurgeQueue has failed with status 400: com.amazonaws.sqs#InvalidAction unknown action")
+}
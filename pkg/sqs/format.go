@@ -0,0 +1,89 @@
+package sqs
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/params/settings"
+)
+
+// the formats of the messages, validated with the sqs-message-format setting.
+const (
+	FormatWebhook     = settings.SQSMessageFormatWebhook
+	FormatEventBridge = settings.SQSMessageFormatEventBridge
+	FormatSNS         = settings.SQSMessageFormatSNS
+)
+
+// webhook is the webhook of the git provider in a message, the payload is
+// kept as a string for its signature to be verified.
+type webhook struct {
+	Headers map[string]string `json:"headers"`
+	Payload string            `json:"payload"`
+}
+
+// formats decode the webhooks of the messages by format name.
+var formats = map[string]func(body []byte) (webhook, error){
+	FormatWebhook: func(body []byte) (webhook, error) {
+		w := webhook{}
+		err := json.Unmarshal(body, &w)
+		return w, err
+	},
+	FormatEventBridge: func(body []byte) (webhook, error) {
+		event := struct {
+			Detail webhook `json:"detail"`
+		}{}
+		err := json.Unmarshal(body, &event)
+		return event.Detail, err
+	},
+	FormatSNS: func(body []byte) (webhook, error) {
+		notification := struct {
+			Message string `json:"Message"`
+		}{}
+		if err := json.Unmarshal(body, &notification); err != nil {
+			return webhook{}, err
+		}
+		w := webhook{}
+		err := json.Unmarshal([]byte(notification.Message), &w)
+		return w, err
+	},
+}
+
+// Formats returns the names of the formats of the messages.
+func Formats() []string {
+	names := make([]string, 0, len(formats))
+	for name := range formats {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// IsValidFormat returns an error when format is not the name of a format.
+func IsValidFormat(format string) error {
+	if _, ok := formats[format]; !ok {
+		return fmt.Errorf("invalid sqs message format %q, it must be one of %s", format, strings.Join(Formats(), ", "))
+	}
+	return nil
+}
+
+// Decode returns the headers and the payload of the webhook of a message.
+func Decode(format string, message Message) (http.Header, []byte, error) {
+	if err := IsValidFormat(format); err != nil {
+		return nil, nil, err
+	}
+	w, err := formats[format]([]byte(message.Body))
+	if err != nil {
+		return nil, nil, fmt.Errorf("cannot decode the %s message %s: %w", format, message.MessageID, err)
+	}
+	if len(w.Headers) == 0 || w.Payload == "" {
+		return nil, nil, fmt.Errorf("the %s message %s has no webhook headers or payload", format, message.MessageID)
+	}
+	header := http.Header{}
+	for name, value := range w.Headers {
+		header.Set(name, value)
+	}
+	return header, []byte(w.Payload), nil
+}
@@ -0,0 +1,65 @@
+package sqs
+
+import (
+	"testing"
+
+	"gotest.tools/v3/assert"
+)
+
+func TestDecode(t *testing.T) {
+	payload := `{"action": "opened"}`
+	tests := []struct {
+		name    string
+		format  string
+		body    string
+		wantErr string
+	}{
+		{
+			name:   "webhook",
+			format: FormatWebhook,
+			body:   `{"headers": {"X-GitHub-Event": "pull_request"}, "payload": "{\"action\": \"opened\"}"}`,
+		},
+		{
+			name:   "eventbridge",
+			format: FormatEventBridge,
+			body: `{"version": "0", "id": "1", "detail-type": "pull_request", "source": "git.example.com",
+"detail": {"headers": {"X-GitHub-Event": "pull_request"}, "payload": "{\"action\": \"opened\"}"}}`,
+		},
+		{
+			name:   "sns",
+			format: FormatSNS,
+			body: `{"Type": "Notification", "MessageId": "1",
+"Message": "{\"headers\": {\"X-GitHub-Event\": \"pull_request\"}, \"payload\": \"{\\\"action\\\": \\\"opened\\\"}\"}"}`,
+		},
+		{
+			name:    "no webhook",
+			format:  FormatEventBridge,
+			body:    `{"detail": {"action": "opened"}}`,
+			wantErr: "the eventbridge message 1 has no webhook headers or payload",
+		},
+		{
+			name:    "invalid json",
+			format:  FormatWebhook,
+			body:    `headers`,
+			wantErr: "cannot decode the webhook message 1: invalid character 'h' looking for beginning of value",
+		},
+		{
+			name:    "unknown format",
+			format:  "kinesis",
+			body:    `{}`,
+			wantErr: `invalid sqs message format "kinesis", it must be one of eventbridge, sns, webhook`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			header, got, err := Decode(tt.format, Message{MessageID: "1", Body: tt.body})
+			if tt.wantErr != "" {
+				assert.Error(t, err, tt.wantErr)
+				return
+			}
+			assert.NilError(t, err)
+			assert.Equal(t, header.Get("X-GitHub-Event"), "pull_request")
+			assert.Equal(t, string(got), payload)
+		})
+	}
+}
@@ -0,0 +1,115 @@
+package sqs
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"gotest.tools/v3/assert"
+)
+
+func TestSignV4(t *testing.T) {
+	// requests of the AWS Signature Version 4 test suite, signed with its
+	// credentials, region, service and date
+	tests := []struct {
+		name          string
+		method        string
+		path          string
+		header        http.Header
+		body          string
+		signedHeaders string
+		signature     string
+	}{
+		{
+			name:          "get-vanilla",
+			method:        http.MethodGet,
+			path:          "/",
+			signedHeaders: "host;x-amz-date",
+			signature:     "5fa00fa31553b73ebf1942676e86291e8372ff2a2260956d9b8aae1d763fbf31",
+		},
+		{
+			name:          "post-vanilla",
+			method:        http.MethodPost,
+			path:          "/",
+			signedHeaders: "host;x-amz-date",
+			signature:     "5da7c1a2acd57cee7505fc6676e4e544621c30862966e37dddb68e92efbe5d6b",
+		},
+		{
+			name:          "get-unreserved",
+			method:        http.MethodGet,
+			path:          "/-._~0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz",
+			signedHeaders: "host;x-amz-date",
+			signature:     "07ef7494c76fa4850883e2b006601f940f8a34d404d0cfa977f52a65bbf5f24f",
+		},
+		{
+			name:          "get-utf8",
+			method:        http.MethodGet,
+			path:          "/ሴ",
+			signedHeaders: "host;x-amz-date",
+			signature:     "8318018e0b0f223aa2bbf98705b62bb787dc9c0e678f255a891fd03141be5d85",
+		},
+		{
+			name:          "post-header-key-sort",
+			method:        http.MethodPost,
+			path:          "/",
+			header:        http.Header{"My-Header1": {"value1"}},
+			signedHeaders: "host;my-header1;x-amz-date",
+			signature:     "c5410059b04c1ee005303aed430f6e6645f61f4dc9e1461ec8f8916fdf18852c",
+		},
+		{
+			name:          "post-header-value-case",
+			method:        http.MethodPost,
+			path:          "/",
+			header:        http.Header{"My-Header1": {"VALUE1"}},
+			signedHeaders: "host;my-header1;x-amz-date",
+			signature:     "cdbc9802e29d2942e5e10b5bccfdd67c5f22c7c4e8ae67b53629efa58b974b7d",
+		},
+		{
+			name:          "post-x-www-form-urlencoded",
+			method:        http.MethodPost,
+			path:          "/",
+			header:        http.Header{"Content-Type": {"application/x-www-form-urlencoded"}},
+			body:          "Param1=value1",
+			signedHeaders: "content-type;host;x-amz-date",
+			signature:     "ff11897932ad3f4e8b18135d722051e5ac45fc38421b1da7b9d196a0fe09473a",
+		},
+		{
+			name:          "post-x-www-form-urlencoded-parameters",
+			method:        http.MethodPost,
+			path:          "/",
+			header:        http.Header{"Content-Type": {"application/x-www-form-urlencoded; charset=utf8"}},
+			body:          "Param1=value1",
+			signedHeaders: "content-type;host;x-amz-date",
+			signature:     "1a72ec8f64bd914b0e42e42607c7fbce7fb2c7465f63e3092b3b0d39fa77a6fe",
+		},
+	}
+	creds := Credentials{AccessKeyID: "AKIDEXAMPLE", SecretAccessKey: "wJalrXUtnFEMI/K7MDENG+bPxRfiCYEXAMPLEKEY"}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req, err := http.NewRequest(tt.method, "https://example.amazonaws.com"+tt.path, nil)
+			assert.NilError(t, err)
+			for name, values := range tt.header {
+				req.Header[name] = values
+			}
+			signV4(req, []byte(tt.body), creds, "us-east-1", "service", time.Date(2015, 8, 30, 12, 36, 0, 0, time.UTC))
+
+			assert.Equal(t, req.Header.Get("X-Amz-Date"), "20150830T123600Z")
+			assert.Equal(t, req.Header.Get("Authorization"),
+				"AWS4-HMAC-SHA256 Credential=AKIDEXAMPLE/20150830/us-east-1/service/aws4_request, SignedHeaders="+tt.signedHeaders+", Signature="+tt.signature)
+		})
+	}
+}
+
+func TestSignV4SessionToken(t *testing.T) {
+	req, err := http.NewRequest(http.MethodPost, "https://sqs.eu-west-1.amazonaws.com/", nil)
+	assert.NilError(t, err)
+	req.Header.Set("X-Amz-Target", "AmazonSQS.ReceiveMessage")
+	creds := Credentials{AccessKeyID: "AKID", SecretAccessKey: "secret", SessionToken: "token"}
+	signV4(req, []byte("{}"), creds, "eu-west-1", "sqs", time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC))
+
+	assert.Equal(t, req.Header.Get("X-Amz-Security-Token"), "token")
+	assert.Assert(t, strings.HasPrefix(req.Header.Get("Authorization"),
+		"AWS4-HMAC-SHA256 Credential=AKID/20250102/eu-west-1/sqs/aws4_request, SignedHeaders=host;x-amz-date;x-amz-security-token;x-amz-target, Signature="),
+		req.Header.Get("Authorization"))
+}
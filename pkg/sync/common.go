@@ -12,6 +12,7 @@ type Semaphore interface {
 	resize(int) bool
 	addToQueue(string, time.Time) bool
 	addToPendingQueue(string, time.Time) bool
+	addToRunning(string) bool
 	removeFromQueue(string)
 	getName() string
 	getLimit() int
@@ -3,6 +3,7 @@ package sync
 import (
 	"context"
 	"fmt"
+	"sort"
 	"strings"
 	"sync"
 	"time"
@@ -11,16 +12,10 @@ import (
 	"github.com/openshift-pipelines/pipelines-as-code/pkg/apis/pipelinesascode/v1alpha1"
 	"github.com/openshift-pipelines/pipelines-as-code/pkg/generated/clientset/versioned"
 	"github.com/openshift-pipelines/pipelines-as-code/pkg/kubeinteraction"
-	"github.com/openshift-pipelines/pipelines-as-code/pkg/sort"
 	tektonv1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
 	versioned2 "github.com/tektoncd/pipeline/pkg/client/clientset/versioned"
 	"go.uber.org/zap"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
-	"k8s.io/apimachinery/pkg/runtime"
-)
-
-const (
-	creationTimestamp = "{.metadata.creationTimestamp}"
 )
 
 type QueueManager struct {
@@ -191,6 +186,12 @@ func FilterPipelineRunByState(ctx context.Context, tekton versioned2.Interface,
 
 // InitQueues rebuild all the queues for all repository if concurrency is defined before
 // reconciler started reconciling them.
+//
+// The queues are rebuilt deterministically from what has been persisted on
+// the PipelineRuns: the state label, the execution-order annotation and the
+// creation timestamp. PipelineRuns already started are restored directly as
+// running so a restart never starts them a second time, and queued
+// PipelineRuns are restored in the same order they were queued.
 func (qm *QueueManager) InitQueues(ctx context.Context, tekton versioned2.Interface, pac versioned.Interface) error {
 	// fetch all repos
 	repos, err := pac.PipelinesascodeV1alpha1().Repositories("").List(ctx, v1.ListOptions{})
@@ -200,12 +201,12 @@ func (qm *QueueManager) InitQueues(ctx context.Context, tekton versioned2.Interf
 
 	// pipelineRuns from the namespace where repository is present
 	// those are required for creating queues
-	for _, repo := range repos.Items {
+	for i := range repos.Items {
+		repo := &repos.Items[i]
 		if repo.Spec.ConcurrencyLimit == nil || *repo.Spec.ConcurrencyLimit == 0 {
 			continue
 		}
 
-		// add all pipelineRuns in started state to pending queue
 		prs, err := tekton.TektonV1().PipelineRuns(repo.Namespace).
 			List(ctx, v1.ListOptions{
 				LabelSelector: fmt.Sprintf("%s=%s", keys.State, kubeinteraction.StateStarted),
@@ -213,25 +214,8 @@ func (qm *QueueManager) InitQueues(ctx context.Context, tekton versioned2.Interf
 		if err != nil {
 			return err
 		}
+		started := filterPipelineRunsForRepository(repo, prs.Items, "")
 
-		// sort the pipelinerun by creation time before adding to queue
-		sortedPRs := sortPipelineRunsByCreationTimestamp(prs.Items)
-
-		for _, pr := range sortedPRs {
-			order, exist := pr.GetAnnotations()[keys.ExecutionOrder]
-			if !exist {
-				// if the pipelineRun doesn't have order label then wait
-				return nil
-			}
-			orderedList := FilterPipelineRunByState(ctx, tekton, strings.Split(order, ","), "", kubeinteraction.StateStarted)
-
-			_, err = qm.AddListToRunningQueue(&repo, orderedList)
-			if err != nil {
-				qm.logger.Error("failed to init queue for repo: ", repo.GetName())
-			}
-		}
-
-		// now fetch all queued pipelineRun
 		prs, err = tekton.TektonV1().PipelineRuns(repo.Namespace).
 			List(ctx, v1.ListOptions{
 				LabelSelector: fmt.Sprintf("%s=%s", keys.State, kubeinteraction.StateQueued),
@@ -239,26 +223,109 @@ func (qm *QueueManager) InitQueues(ctx context.Context, tekton versioned2.Interf
 		if err != nil {
 			return err
 		}
+		queued := filterPipelineRunsForRepository(repo, prs.Items, tektonv1.PipelineRunSpecStatusPending)
 
-		// sort the pipelinerun by creation time before adding to queue
-		sortedPRs = sortPipelineRunsByCreationTimestamp(prs.Items)
+		if err := qm.restoreQueue(repo, sortPipelineRunsByQueueOrder(started), sortPipelineRunsByQueueOrder(queued)); err != nil {
+			qm.logger.Errorf("failed to init queue for repo %s: %v", repo.GetName(), err)
+		}
+	}
 
-		for _, pr := range sortedPRs {
-			order, exist := pr.GetAnnotations()[keys.ExecutionOrder]
-			if !exist {
-				// if the pipelineRun doesn't have order label then wait
-				return nil
-			}
-			orderedList := FilterPipelineRunByState(ctx, tekton, strings.Split(order, ","), tektonv1.PipelineRunSpecStatusPending, kubeinteraction.StateQueued)
-			if err := qm.AddToPendingQueue(&repo, orderedList); err != nil {
-				qm.logger.Error("failed to init queue for repo: ", repo.GetName())
-			}
+	return nil
+}
+
+// restoreQueue adds the started PipelineRuns as running and the queued ones
+// to the pending queue of the repository semaphore. The pending PipelineRuns
+// get a priority matching their position in the list, which is always lower
+// than the priority of the PipelineRuns queued after the controller started.
+func (qm *QueueManager) restoreQueue(repo *v1alpha1.Repository, started, queued []*tektonv1.PipelineRun) error {
+	qm.lock.Lock()
+	defer qm.lock.Unlock()
+
+	sema, err := qm.getSemaphore(repo)
+	if err != nil {
+		return err
+	}
+
+	for _, pr := range started {
+		if sema.addToRunning(PrKey(pr)) {
+			qm.logger.Infof("restored pipelineRun (%s) as running for repository (%s)", PrKey(pr), RepoKey(repo))
 		}
 	}
 
+	for i, pr := range queued {
+		if sema.addToPendingQueue(PrKey(pr), time.Unix(0, int64(i))) {
+			qm.logger.Infof("restored pipelineRun (%s) to pending queue for repository (%s)", PrKey(pr), RepoKey(repo))
+		}
+	}
 	return nil
 }
 
+// filterPipelineRunsForRepository keeps only the PipelineRuns belonging to
+// the repository which have an execution order, if wantedStatus is set the
+// PipelineRun spec status has to match it too.
+func filterPipelineRunsForRepository(repo *v1alpha1.Repository, prs []tektonv1.PipelineRun, wantedStatus tektonv1.PipelineRunSpecStatus) []*tektonv1.PipelineRun {
+	filtered := []*tektonv1.PipelineRun{}
+	for i := range prs {
+		pr := &prs[i]
+		if pr.GetAnnotations()[keys.Repository] != "" && pr.GetAnnotations()[keys.Repository] != repo.GetName() {
+			continue
+		}
+		// if the pipelineRun doesn't have an execution order the reconciler
+		// will pick it up once it has been patched.
+		if _, exist := pr.GetAnnotations()[keys.ExecutionOrder]; !exist {
+			continue
+		}
+		if wantedStatus != "" && pr.Spec.Status != wantedStatus {
+			continue
+		}
+		filtered = append(filtered, pr)
+	}
+	return filtered
+}
+
+// sortPipelineRunsByQueueOrder sorts the PipelineRuns in the order they have
+// been queued. PipelineRuns created for the same event share the same
+// execution order annotation and are kept in that order, events are sorted by
+// the creation time of their oldest PipelineRun. Ties are broken by the
+// execution order and the name so the result is always the same for the same
+// input.
+func sortPipelineRunsByQueueOrder(prs []*tektonv1.PipelineRun) []*tektonv1.PipelineRun {
+	eventTime := map[string]time.Time{}
+	for _, pr := range prs {
+		order := pr.GetAnnotations()[keys.ExecutionOrder]
+		created := pr.GetCreationTimestamp().Time
+		if t, ok := eventTime[order]; !ok || created.Before(t) {
+			eventTime[order] = created
+		}
+	}
+
+	position := func(pr *tektonv1.PipelineRun) int {
+		for i, key := range strings.Split(pr.GetAnnotations()[keys.ExecutionOrder], ",") {
+			if key == PrKey(pr) {
+				return i
+			}
+		}
+		return -1
+	}
+
+	sorted := append([]*tektonv1.PipelineRun{}, prs...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		orderI := sorted[i].GetAnnotations()[keys.ExecutionOrder]
+		orderJ := sorted[j].GetAnnotations()[keys.ExecutionOrder]
+		if orderI != orderJ {
+			if !eventTime[orderI].Equal(eventTime[orderJ]) {
+				return eventTime[orderI].Before(eventTime[orderJ])
+			}
+			return orderI < orderJ
+		}
+		if posI, posJ := position(sorted[i]), position(sorted[j]); posI != posJ {
+			return posI < posJ
+		}
+		return sorted[i].GetName() < sorted[j].GetName()
+	})
+	return sorted
+}
+
 func (qm *QueueManager) RemoveRepository(repo *v1alpha1.Repository) {
 	qm.lock.Lock()
 	defer qm.lock.Unlock()
@@ -288,17 +355,3 @@ func (qm *QueueManager) RunningPipelineRuns(repo *v1alpha1.Repository) []string
 	}
 	return []string{}
 }
-
-func sortPipelineRunsByCreationTimestamp(prs []tektonv1.PipelineRun) []*tektonv1.PipelineRun {
-	runTimeObj := []runtime.Object{}
-	for i := range prs {
-		runTimeObj = append(runTimeObj, &prs[i])
-	}
-	sort.ByField(creationTimestamp, runTimeObj)
-	sortedPRs := []*tektonv1.PipelineRun{}
-	for _, run := range runTimeObj {
-		pr, _ := run.(*tektonv1.PipelineRun)
-		sortedPRs = append(sortedPRs, pr)
-	}
-	return sortedPRs
-}
@@ -338,3 +338,66 @@ func TestFilterPipelineRunByInProgress(t *testing.T) {
 	expected := []string{"test-ns/pr1"}
 	assert.DeepEqual(t, filtered, expected)
 }
+
+func TestQueueManager_InitQueuesDeterministicOrder(t *testing.T) {
+	ctx, _ := rtesting.SetupFakeContext(t)
+	observer, _ := zapobserver.New(zap.InfoLevel)
+	logger := zap.New(observer).Sugar()
+	cw := clockwork.NewFakeClock()
+
+	startedLabel := map[string]string{keys.State: kubeinteraction.StateStarted}
+	queuedLabel := map[string]string{keys.State: kubeinteraction.StateQueued}
+	annotations := func(order, state string) map[string]string {
+		return map[string]string{
+			keys.ExecutionOrder: order,
+			keys.State:          state,
+			keys.Repository:     "test",
+		}
+	}
+	pending := tektonv1.PipelineRunSpec{Status: tektonv1.PipelineRunSpecStatusPending}
+
+	// limit has been lowered while two runs were started
+	repo := newTestRepo(1)
+
+	firstEvent := "test-ns/a-started,test-ns/b-started,test-ns/z-queued,test-ns/c-queued"
+	secondEvent := "test-ns/d-queued,test-ns/y-queued"
+	prs := []*tektonv1.PipelineRun{
+		newTestPR("a-started", cw.Now(), startedLabel, annotations(firstEvent, kubeinteraction.StateStarted), tektonv1.PipelineRunSpec{}),
+		newTestPR("b-started", cw.Now(), startedLabel, annotations(firstEvent, kubeinteraction.StateStarted), tektonv1.PipelineRunSpec{}),
+		newTestPR("z-queued", cw.Now(), queuedLabel, annotations(firstEvent, kubeinteraction.StateQueued), pending),
+		newTestPR("c-queued", cw.Now(), queuedLabel, annotations(firstEvent, kubeinteraction.StateQueued), pending),
+		// created in the same second than the first event
+		newTestPR("y-queued", cw.Now(), queuedLabel, annotations(secondEvent, kubeinteraction.StateQueued), pending),
+		newTestPR("d-queued", cw.Now().Add(time.Second), queuedLabel, annotations(secondEvent, kubeinteraction.StateQueued), pending),
+		// no execution order yet, the reconciler will take care of it
+		newTestPR("no-order", cw.Now(), queuedLabel, map[string]string{keys.State: kubeinteraction.StateQueued}, pending),
+		// belongs to another repository in the same namespace
+		newTestPR("other-repo", cw.Now(), queuedLabel, map[string]string{
+			keys.ExecutionOrder: "test-ns/other-repo",
+			keys.State:          kubeinteraction.StateQueued,
+			keys.Repository:     "other",
+		}, pending),
+	}
+
+	stdata, _ := testclient.SeedTestData(t, ctx, testclient.Data{
+		Repositories: []*v1alpha1.Repository{repo},
+		PipelineRuns: prs,
+	})
+
+	qm := NewQueueManager(logger)
+	assert.NilError(t, qm.InitQueues(ctx, stdata.Pipeline, stdata.PipelineAsCode))
+
+	sema := qm.queueMap[RepoKey(repo)]
+	assert.Equal(t, len(sema.getCurrentRunning()), 2)
+	assert.Equal(t, len(sema.getCurrentPending()), 4)
+
+	// still over the limit, nothing gets started
+	assert.Equal(t, qm.RemoveAndTakeItemFromQueue(repo, prs[0]), "")
+	expected := []string{"test-ns/z-queued", "test-ns/c-queued", "test-ns/d-queued", "test-ns/y-queued"}
+	for _, next := range expected {
+		current := qm.RunningPipelineRuns(repo)
+		assert.Equal(t, len(current), 1)
+		done := newTestPR(current[0][len("test-ns/"):], cw.Now(), nil, nil, tektonv1.PipelineRunSpec{})
+		assert.Equal(t, qm.RemoveAndTakeItemFromQueue(repo, done), next)
+	}
+}
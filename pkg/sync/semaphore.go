@@ -2,6 +2,7 @@ package sync
 
 import (
 	"fmt"
+	"sort"
 	"sync"
 	"time"
 
@@ -38,9 +39,15 @@ func (s *prioritySemaphore) getLimit() int {
 	return s.limit
 }
 
+// getCurrentPending returns the pending keys in the order they are going to
+// be started.
 func (s *prioritySemaphore) getCurrentPending() []string {
+	items := append([]*item{}, s.pending.items...)
+	sort.SliceStable(items, func(i, j int) bool {
+		return items[i].priority < items[j].priority
+	})
 	keys := []string{}
-	for _, item := range s.pending.items {
+	for _, item := range items {
 		keys = append(keys, item.key)
 	}
 	return keys
@@ -94,6 +101,24 @@ func (s *prioritySemaphore) addToPendingQueue(key string, creationTime time.Time
 	return true
 }
 
+// addToRunning marks the key as running without going through the pending
+// queue, it is used when restoring PipelineRuns which were already started.
+// If the semaphore is already full (i.e: the limit has been lowered) the key
+// is still tracked as running, release takes care of not freeing a slot
+// until we are back under the limit.
+func (s *prioritySemaphore) addToRunning(key string) bool {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if _, ok := s.running[key]; ok {
+		return false
+	}
+	s.pending.remove(key)
+	_ = s.semaphore.TryAcquire(1)
+	s.running[key] = true
+	return true
+}
+
 func (s *prioritySemaphore) acquireLatest() string {
 	s.lock.Lock()
 	defer s.lock.Unlock()
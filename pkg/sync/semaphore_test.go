@@ -285,3 +285,37 @@ func TestTryAcquireConcurrentAccess(t *testing.T) {
 	assert.Equal(t, acquired3, false)
 	assert.Equal(t, len(repo.getCurrentRunning()), 2)
 }
+
+func TestSemaphoreAddToRunning(t *testing.T) {
+	repo := newSemaphore("restore", 1)
+	cw := clockwork.NewFakeClock()
+
+	assert.Equal(t, repo.addToQueue("C", cw.Now()), true)
+
+	// restored running keys are tracked even above the limit
+	assert.Equal(t, repo.addToRunning("A"), true)
+	assert.Equal(t, repo.addToRunning("B"), true)
+	assert.Equal(t, repo.addToRunning("A"), false)
+	assert.Equal(t, len(repo.getCurrentRunning()), 2)
+
+	// still over the limit after releasing one, nothing can start
+	repo.release("A")
+	assert.Equal(t, repo.acquireLatest(), "")
+
+	// back under the limit, next one in the queue can start
+	repo.release("B")
+	assert.Equal(t, repo.acquireLatest(), "C")
+	assert.Equal(t, len(repo.getCurrentPending()), 0)
+}
+
+func TestSemaphoreCurrentPendingOrder(t *testing.T) {
+	repo := newSemaphore("order", 1)
+	cw := clockwork.NewFakeClock()
+
+	assert.Equal(t, repo.addToQueue("D", cw.Now().Add(4*time.Second)), true)
+	assert.Equal(t, repo.addToQueue("A", cw.Now()), true)
+	assert.Equal(t, repo.addToQueue("C", cw.Now().Add(3*time.Second)), true)
+	assert.Equal(t, repo.addToQueue("B", cw.Now().Add(1*time.Second)), true)
+
+	assert.DeepEqual(t, repo.getCurrentPending(), []string{"A", "B", "C", "D"})
+}
@@ -34,6 +34,9 @@ func SetupGH() (client *github.Client, mux *http.ServeMux, serverURL string, tea
 	// when there's a non-empty base URL path. So, use that. See issue #752.
 	apiHandler := http.NewServeMux()
 	apiHandler.Handle(githubBaseURLPath+"/", http.StripPrefix(githubBaseURLPath, mux))
+	// GitHub Enterprise serves the GraphQL API next to the REST one, the
+	// tests handle it on /graphql.
+	apiHandler.Handle("/api/graphql", http.StripPrefix("/api", mux))
 	apiHandler.HandleFunc("/", func(w http.ResponseWriter, req *http.Request) {
 		fmt.Fprintln(os.Stderr, "FAIL: Client.BaseURL path prefix is not preserved in the request URL:")
 		fmt.Fprintln(os.Stderr)
@@ -0,0 +1,119 @@
+package gitssh
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/binary"
+	"encoding/pem"
+	"fmt"
+	"net"
+	"os/exec"
+	"strings"
+	"testing"
+
+	"golang.org/x/crypto/ssh"
+	"gotest.tools/v3/assert"
+)
+
+// Server is a SSH server running git-upload-archive on a local repository,
+// whatever the path of the repository in the command.
+type Server struct {
+	// Remote is the SSH URL of the repository on the server.
+	Remote string
+	// PrivateKey is the private key of the client allowed to connect.
+	PrivateKey []byte
+	// KnownHosts is the known hosts line of the server.
+	KnownHosts []byte
+}
+
+// NewServer starts a SSH server serving the repository of repoDir until the
+// end of the test.
+func NewServer(t *testing.T, repoDir string) *Server {
+	t.Helper()
+	_, hostPriv, err := ed25519.GenerateKey(rand.Reader)
+	assert.NilError(t, err)
+	hostSigner, err := ssh.NewSignerFromKey(hostPriv)
+	assert.NilError(t, err)
+	clientPub, clientPriv, err := ed25519.GenerateKey(rand.Reader)
+	assert.NilError(t, err)
+	clientSSHPub, err := ssh.NewPublicKey(clientPub)
+	assert.NilError(t, err)
+	block, err := ssh.MarshalPrivateKey(clientPriv, "")
+	assert.NilError(t, err)
+
+	config := &ssh.ServerConfig{
+		PublicKeyCallback: func(_ ssh.ConnMetadata, key ssh.PublicKey) (*ssh.Permissions, error) {
+			if !bytes.Equal(key.Marshal(), clientSSHPub.Marshal()) {
+				return nil, fmt.Errorf("unknown key")
+			}
+			return nil, nil
+		},
+	}
+	config.AddHostKey(hostSigner)
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.NilError(t, err)
+	t.Cleanup(func() { listener.Close() })
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			go serve(conn, config, repoDir)
+		}
+	}()
+
+	port := listener.Addr().(*net.TCPAddr).Port
+	return &Server{
+		Remote:     fmt.Sprintf("ssh://git@127.0.0.1:%d/group/repo.git", port),
+		PrivateKey: pem.EncodeToMemory(block),
+		KnownHosts: []byte(fmt.Sprintf("[127.0.0.1]:%d %s", port, ssh.MarshalAuthorizedKey(hostSigner.PublicKey()))),
+	}
+}
+
+func serve(conn net.Conn, config *ssh.ServerConfig, repoDir string) {
+	_, chans, reqs, err := ssh.NewServerConn(conn, config)
+	if err != nil {
+		return
+	}
+	go ssh.DiscardRequests(reqs)
+	for newChannel := range chans {
+		if newChannel.ChannelType() != "session" {
+			_ = newChannel.Reject(ssh.UnknownChannelType, "only sessions")
+			continue
+		}
+		channel, requests, err := newChannel.Accept()
+		if err != nil {
+			continue
+		}
+		go func() {
+			defer channel.Close()
+			for req := range requests {
+				if req.Type != "exec" || len(req.Payload) < 4 {
+					_ = req.Reply(false, nil)
+					continue
+				}
+				command := string(req.Payload[4:])
+				if !strings.HasPrefix(command, "git-upload-archive ") {
+					_ = req.Reply(false, nil)
+					continue
+				}
+				_ = req.Reply(true, nil)
+				cmd := exec.Command("git", "upload-archive", repoDir)
+				cmd.Stdin = channel
+				cmd.Stdout = channel
+				cmd.Stderr = channel.Stderr()
+				status := uint32(0)
+				if err := cmd.Run(); err != nil {
+					status = 1
+				}
+				payload := make([]byte, 4)
+				binary.BigEndian.PutUint32(payload, status)
+				_, _ = channel.SendRequest("exit-status", false, payload)
+				return
+			}
+		}()
+	}
+}
@@ -10,6 +10,7 @@ import (
 	tektonv1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
 	"go.uber.org/zap"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
 )
 
 type KinterfaceTest struct {
@@ -18,6 +19,12 @@ type KinterfaceTest struct {
 	ExpectedNumberofCleanups int
 	GetSecretResult          map[string]string
 	GetPodLogsOutput         map[string]string
+	GetArchivedLogsOutput    map[string]string
+	PodsGone                 bool
+
+	// GetSecretKeyResult are the values of the secrets per name/key, they
+	// win over GetSecretResult.
+	GetSecretKeyResult map[string]string
 }
 
 var _ kubeinteraction.Interface = (*KinterfaceTest)(nil)
@@ -30,17 +37,27 @@ func (k *KinterfaceTest) GetConsoleUI(_ context.Context, _, _ string) (string, e
 }
 
 func (k *KinterfaceTest) GetPodLogs(_ context.Context, _, pod, _ string, _ int64) (string, error) {
+	if k.PodsGone {
+		return "", errors.NewNotFound(corev1.Resource("pods"), pod)
+	}
 	if ok := k.GetPodLogsOutput[pod]; ok != "" {
 		return k.GetPodLogsOutput[pod], nil
 	}
 	return "", nil
 }
 
+func (k *KinterfaceTest) GetArchivedLogs(_ context.Context, _, taskRun string, _ int64) (string, error) {
+	return k.GetArchivedLogsOutput[taskRun], nil
+}
+
 func (k *KinterfaceTest) UpdateSecretWithOwnerRef(_ context.Context, _ *zap.SugaredLogger, _, _ string, _ *tektonv1.PipelineRun) error {
 	return nil
 }
 
 func (k *KinterfaceTest) GetSecret(_ context.Context, secret ktypes.GetSecretOpt) (string, error) {
+	if value, ok := k.GetSecretKeyResult[secret.Name+"/"+secret.Key]; ok {
+		return value, nil
+	}
 	if _, ok := k.GetSecretResult[secret.Name]; !ok {
 		return "", fmt.Errorf("secret %s does not exist", secret.Name)
 	}
@@ -60,6 +77,10 @@ func (k *KinterfaceTest) CreateSecret(_ context.Context, _ string, _ *corev1.Sec
 	return nil
 }
 
+func (k *KinterfaceTest) CreateOrUpdateSecret(_ context.Context, _ string, _ *corev1.Secret) error {
+	return nil
+}
+
 func (k *KinterfaceTest) DeleteSecret(_ context.Context, _ *zap.SugaredLogger, _, _ string) error {
 	return nil
 }
@@ -0,0 +1,235 @@
+// Package payloads holds scrubbed sample webhook payloads of the git
+// providers for the parser tests. They are generated by hack/gen-fixtures
+// from the samples of the provider docs or from live deliveries.
+package payloads
+
+import (
+	"bytes"
+	"context"
+	"embed"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// Redacted replaces the sensitive values of the payloads.
+const Redacted = "[REDACTED]"
+
+//go:embed testdata
+var fixtures embed.FS
+
+// sensitiveKeys are the keys, or part of the keys, of the values redacted
+// from the payloads.
+var sensitiveKeys = []string{"email", "token", "secret", "password", "private_key", "signature", "gravatar_id"}
+
+// angledEmail matches the emails in values like "Name <name@example.com>".
+var angledEmail = regexp.MustCompile(`<[^<>@\s]+@[^<>\s]+>`)
+
+// Source is a sample payload published by a git provider.
+type Source struct {
+	Provider string
+	// Name of the fixture, unique for the provider.
+	Name string
+	// Event is the value of the event type header sent with the payload, like
+	// X-GitHub-Event.
+	Event string
+	URL   string
+}
+
+// octokitExample is the URL of a payload example of the GitHub webhooks
+// documentation.
+func octokitExample(file string) string {
+	return "https://raw.githubusercontent.com/octokit/webhooks/main/payload-examples/api.github.com/" + file
+}
+
+// Sources are the sample payloads downloaded by hack/gen-fixtures, the
+// providers without published samples get their fixtures from live
+// deliveries.
+var Sources = []Source{
+	{Provider: "github", Name: "check_run-rerequested", Event: "check_run", URL: octokitExample("check_run/rerequested.payload.json")},
+	{Provider: "github", Name: "check_suite-rerequested", Event: "check_suite", URL: octokitExample("check_suite/rerequested.payload.json")},
+	{Provider: "github", Name: "commit_comment-created", Event: "commit_comment", URL: octokitExample("commit_comment/created.payload.json")},
+	{Provider: "github", Name: "issue_comment-created", Event: "issue_comment", URL: octokitExample("issue_comment/created.payload.json")},
+	{Provider: "github", Name: "pull_request-closed", Event: "pull_request", URL: octokitExample("pull_request/closed.payload.json")},
+	{Provider: "github", Name: "pull_request-labeled", Event: "pull_request", URL: octokitExample("pull_request/labeled.payload.json")},
+	{Provider: "github", Name: "pull_request-opened", Event: "pull_request", URL: octokitExample("pull_request/opened.payload.json")},
+	{Provider: "github", Name: "pull_request-reopened", Event: "pull_request", URL: octokitExample("pull_request/reopened.payload.json")},
+	{Provider: "github", Name: "pull_request-synchronize", Event: "pull_request", URL: octokitExample("pull_request/synchronize.payload.json")},
+	{Provider: "github", Name: "push", Event: "push", URL: octokitExample("push/payload.json")},
+}
+
+// Fixture is a scrubbed payload.
+type Fixture struct {
+	Provider string `json:"-"`
+	Name     string `json:"-"`
+	Event    string `json:"event"`
+	// Source is where the payload comes from, the URL of a sample or the
+	// description of a live delivery.
+	Source  string          `json:"source"`
+	Payload json.RawMessage `json:"payload"`
+}
+
+// Scrub redacts the emails, tokens and secrets of a payload and formats it
+// with sorted keys so the fixtures only change with the provider schema.
+func Scrub(payload []byte) ([]byte, error) {
+	dec := json.NewDecoder(bytes.NewReader(payload))
+	dec.UseNumber()
+	var v any
+	if err := dec.Decode(&v); err != nil {
+		return nil, fmt.Errorf("invalid payload: %w", err)
+	}
+	var out bytes.Buffer
+	enc := json.NewEncoder(&out)
+	enc.SetEscapeHTML(false)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(scrub("", v)); err != nil {
+		return nil, err
+	}
+	return out.Bytes(), nil
+}
+
+func sensitive(key string) bool {
+	key = strings.ToLower(key)
+	for _, s := range sensitiveKeys {
+		if strings.Contains(key, s) {
+			return true
+		}
+	}
+	return false
+}
+
+func scrub(key string, v any) any {
+	switch value := v.(type) {
+	case map[string]any:
+		for k, item := range value {
+			value[k] = scrub(k, item)
+		}
+	case []any:
+		for i, item := range value {
+			value[i] = scrub(key, item)
+		}
+	case string:
+		if value != "" && sensitive(key) {
+			return Redacted
+		}
+		return angledEmail.ReplaceAllString(value, "<"+Redacted+">")
+	}
+	return v
+}
+
+// Fetch downloads the sample payload of a source and scrubs it.
+func Fetch(ctx context.Context, client *http.Client, source Source) (Fixture, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, source.URL, nil)
+	if err != nil {
+		return Fixture{}, err
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return Fixture{}, fmt.Errorf("cannot download %s: %w", source.URL, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return Fixture{}, fmt.Errorf("cannot download %s: %s", source.URL, resp.Status)
+	}
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return Fixture{}, fmt.Errorf("cannot download %s: %w", source.URL, err)
+	}
+	payload, err := Scrub(body)
+	if err != nil {
+		return Fixture{}, fmt.Errorf("%s: %w", source.URL, err)
+	}
+	return Fixture{Provider: source.Provider, Name: source.Name, Event: source.Event, Source: source.URL, Payload: payload}, nil
+}
+
+// githubDelivery is a delivery of a GitHub webhook as returned by the
+// /repos/{owner}/{repo}/hooks/{hook_id}/deliveries/{delivery_id} API.
+type githubDelivery struct {
+	Event   string `json:"event"`
+	Request struct {
+		Payload json.RawMessage `json:"payload"`
+	} `json:"request"`
+}
+
+// FromDelivery makes a fixture of a live delivery, either the raw payload
+// or a GitHub delivery with the payload in its request. The event is taken
+// from the GitHub delivery when it is empty.
+func FromDelivery(provider, name, event, source string, data []byte) (Fixture, error) {
+	payload := data
+	delivery := githubDelivery{}
+	if err := json.Unmarshal(data, &delivery); err == nil && len(delivery.Request.Payload) > 0 {
+		payload = delivery.Request.Payload
+		if event == "" {
+			event = delivery.Event
+		}
+	}
+	if provider == "" || name == "" || event == "" {
+		return Fixture{}, fmt.Errorf("the provider, the name and the event of the delivery are needed")
+	}
+	scrubbed, err := Scrub(payload)
+	if err != nil {
+		return Fixture{}, err
+	}
+	return Fixture{Provider: provider, Name: name, Event: event, Source: source, Payload: scrubbed}, nil
+}
+
+// Write writes a fixture in the dir/<provider>/<name>.json file and returns
+// its path.
+func Write(dir string, fixture Fixture) (string, error) {
+	var out bytes.Buffer
+	enc := json.NewEncoder(&out)
+	enc.SetEscapeHTML(false)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(fixture); err != nil {
+		return "", err
+	}
+	file := filepath.Join(dir, fixture.Provider, fixture.Name+".json")
+	if err := os.MkdirAll(filepath.Dir(file), 0o755); err != nil {
+		return "", err
+	}
+	return file, os.WriteFile(file, out.Bytes(), 0o600)
+}
+
+// Load returns the fixture of a provider.
+func Load(provider, name string) (Fixture, error) {
+	data, err := fixtures.ReadFile(path.Join("testdata", provider, name+".json"))
+	if err != nil {
+		return Fixture{}, fmt.Errorf("no fixture %s for %s: %w", name, provider, err)
+	}
+	fixture := Fixture{Provider: provider, Name: name}
+	if err := json.Unmarshal(data, &fixture); err != nil {
+		return Fixture{}, fmt.Errorf("invalid fixture %s for %s: %w", name, provider, err)
+	}
+	return fixture, nil
+}
+
+// List returns the fixtures of a provider sorted by name.
+func List(provider string) ([]Fixture, error) {
+	entries, err := fixtures.ReadDir(path.Join("testdata", provider))
+	if err != nil {
+		return nil, fmt.Errorf("no fixtures for %s: %w", provider, err)
+	}
+	names := []string{}
+	for _, entry := range entries {
+		if name, ok := strings.CutSuffix(entry.Name(), ".json"); ok && !entry.IsDir() {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	list := make([]Fixture, 0, len(names))
+	for _, name := range names {
+		fixture, err := Load(provider, name)
+		if err != nil {
+			return nil, err
+		}
+		list = append(list, fixture)
+	}
+	return list, nil
+}
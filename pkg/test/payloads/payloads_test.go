@@ -0,0 +1,177 @@
+package payloads
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"gotest.tools/v3/assert"
+)
+
+func TestScrub(t *testing.T) {
+	tests := []struct {
+		name    string
+		payload string
+		want    string
+		wantErr string
+	}{
+		{
+			name:    "sorted and indented",
+			payload: `{"ref":"refs/heads/main","after":"abc","id":12345678901234567890}`,
+			want:    "{\n  \"after\": \"abc\",\n  \"id\": 12345678901234567890,\n  \"ref\": \"refs/heads/main\"\n}\n",
+		},
+		{
+			name:    "sensitive keys",
+			payload: `{"author":{"email":"me@example.com","name":"me"},"webhook_secret":"shh","Token":"glpat","password":"","gravatar_id":"123"}`,
+			want:    "{\n  \"Token\": \"[REDACTED]\",\n  \"author\": {\n    \"email\": \"[REDACTED]\",\n    \"name\": \"me\"\n  },\n  \"gravatar_id\": \"[REDACTED]\",\n  \"password\": \"\",\n  \"webhook_secret\": \"[REDACTED]\"\n}\n",
+		},
+		{
+			name:    "emails in values",
+			payload: `{"raw":"Me <me@example.com>","ssh_url":"git@gitlab.com:me/project.git"}`,
+			want:    "{\n  \"raw\": \"Me <[REDACTED]>\",\n  \"ssh_url\": \"git@gitlab.com:me/project.git\"\n}\n",
+		},
+		{
+			name:    "arrays and html",
+			payload: `{"emails":["a@b.c"],"body":"<b>&</b>"}`,
+			want:    "{\n  \"body\": \"<b>&</b>\",\n  \"emails\": [\n    \"[REDACTED]\"\n  ]\n}\n",
+		},
+		{
+			name:    "invalid",
+			payload: `{"ref":`,
+			wantErr: "invalid payload",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Scrub([]byte(tt.payload))
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			assert.NilError(t, err)
+			assert.Equal(t, string(got), tt.want)
+		})
+	}
+}
+
+func TestFetch(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/push.json" {
+			http.NotFound(w, r)
+			return
+		}
+		_, _ = w.Write([]byte(`{"ref":"refs/heads/main","pusher":{"email":"me@example.com"}}`))
+	}))
+	defer server.Close()
+
+	source := Source{Provider: "github", Name: "push", Event: "push", URL: server.URL + "/push.json"}
+	fixture, err := Fetch(context.Background(), server.Client(), source)
+	assert.NilError(t, err)
+	assert.Equal(t, fixture.Provider, "github")
+	assert.Equal(t, fixture.Name, "push")
+	assert.Equal(t, fixture.Event, "push")
+	assert.Equal(t, fixture.Source, source.URL)
+	assert.Equal(t, string(fixture.Payload), "{\n  \"pusher\": {\n    \"email\": \"[REDACTED]\"\n  },\n  \"ref\": \"refs/heads/main\"\n}\n")
+
+	source.URL = server.URL + "/missing.json"
+	_, err = Fetch(context.Background(), server.Client(), source)
+	assert.ErrorContains(t, err, "404 Not Found")
+}
+
+func TestFromDelivery(t *testing.T) {
+	tests := []struct {
+		name      string
+		event     string
+		data      string
+		wantEvent string
+		wantErr   string
+	}{
+		{
+			name:      "raw payload",
+			event:     "Push Hook",
+			data:      `{"object_kind":"push"}`,
+			wantEvent: "Push Hook",
+		},
+		{
+			name:      "github delivery",
+			data:      `{"event":"issue_comment","action":"created","request":{"headers":{},"payload":{"object_kind":"push"}}}`,
+			wantEvent: "issue_comment",
+		},
+		{
+			name:      "github delivery with the event",
+			event:     "pull_request",
+			data:      `{"event":"issue_comment","request":{"payload":{"object_kind":"push"}}}`,
+			wantEvent: "pull_request",
+		},
+		{
+			name:    "no event",
+			data:    `{"object_kind":"push"}`,
+			wantErr: "the provider, the name and the event of the delivery are needed",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fixture, err := FromDelivery("gitlab", "push", tt.event, "test", []byte(tt.data))
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			assert.NilError(t, err)
+			assert.Equal(t, fixture.Event, tt.wantEvent)
+			assert.Equal(t, string(fixture.Payload), "{\n  \"object_kind\": \"push\"\n}\n")
+		})
+	}
+}
+
+func TestWrite(t *testing.T) {
+	dir := t.TempDir()
+	payload, err := Scrub([]byte(`{"ref":"refs/heads/main"}`))
+	assert.NilError(t, err)
+	file, err := Write(dir, Fixture{Provider: "gitea", Name: "push", Event: "push", Source: "test", Payload: payload})
+	assert.NilError(t, err)
+	assert.Equal(t, file, filepath.Join(dir, "gitea", "push.json"))
+	data, err := os.ReadFile(file)
+	assert.NilError(t, err)
+	assert.Equal(t, string(data), "{\n  \"event\": \"push\",\n  \"source\": \"test\",\n  \"payload\": {\n    \"ref\": \"refs/heads/main\"\n  }\n}\n")
+}
+
+func TestLoad(t *testing.T) {
+	fixture, err := Load("gitea", "push")
+	assert.NilError(t, err)
+	assert.Equal(t, fixture.Provider, "gitea")
+	assert.Equal(t, fixture.Event, "push")
+	assert.Assert(t, len(fixture.Payload) > 0)
+
+	_, err = Load("gitea", "missing")
+	assert.ErrorContains(t, err, "no fixture missing for gitea")
+
+	fixtures, err := List("gitlab")
+	assert.NilError(t, err)
+	assert.Equal(t, len(fixtures), 1)
+	assert.Equal(t, fixtures[0].Name, "merge_request")
+
+	_, err = List("missing")
+	assert.ErrorContains(t, err, "no fixtures for missing")
+}
+
+// TestFixturesScrubbed checks the fixtures have been generated by
+// hack/gen-fixtures and not edited by hand.
+func TestFixturesScrubbed(t *testing.T) {
+	for _, provider := range []string{"gitea", "gitlab"} {
+		fixtures, err := List(provider)
+		assert.NilError(t, err)
+		for _, fixture := range fixtures {
+			scrubbed, err := Scrub(fixture.Payload)
+			assert.NilError(t, err)
+			var got, want bytes.Buffer
+			assert.NilError(t, json.Compact(&got, fixture.Payload))
+			assert.NilError(t, json.Compact(&want, scrubbed))
+			assert.Equal(t, got.String(), want.String(), "%s/%s is not scrubbed, regenerate it with hack/gen-fixtures", provider, fixture.Name)
+		}
+	}
+}
@@ -30,6 +30,13 @@ type TestProviderImp struct {
 	WantDeletedFiles       []string
 	WantModifiedFiles      []string
 	WantRenamedFiles       []string
+	BranchHeadSHA          string
+	CIVariables            map[string]string
+	UserProfile            *provider.UserProfile
+	UserProfileErroring    bool
+	PollEvents             []*info.Event
+	ReviewDecision         string
+	ReviewDecisionErroring bool
 	pacInfo                *info.PacOpts
 }
 
@@ -52,6 +59,10 @@ func (v *TestProviderImp) CreateComment(_ context.Context, _ *info.Event, _, _ s
 	return nil
 }
 
+func (v *TestProviderImp) CreateLineComment(_ context.Context, _ *info.Event, _ string, _ int, _ string) error {
+	return nil
+}
+
 func (v *TestProviderImp) SetLogger(_ *zap.SugaredLogger) {
 }
 
@@ -71,10 +82,46 @@ func (v *TestProviderImp) GetConfig() *info.ProviderConfig {
 	return &info.ProviderConfig{}
 }
 
-func (v *TestProviderImp) GetCommitInfo(_ context.Context, _ *info.Event) error {
+func (v *TestProviderImp) GetCommitInfo(_ context.Context, event *info.Event) error {
+	if event.SHA == "" && event.HeadBranch != "" {
+		event.SHA = v.BranchHeadSHA
+	}
 	return nil
 }
 
+func (v *TestProviderImp) GetUserProfile(_ context.Context, event *info.Event) (*provider.UserProfile, error) {
+	if v.UserProfileErroring {
+		return nil, fmt.Errorf("cannot get the user %s", event.Sender)
+	}
+	if v.UserProfile == nil {
+		return &provider.UserProfile{}, nil
+	}
+	return v.UserProfile, nil
+}
+
+func (v *TestProviderImp) GetPollEvents(_ context.Context, _ *info.Event, _ []string, _ bool) ([]*info.Event, error) {
+	return v.PollEvents, nil
+}
+
+func (v *TestProviderImp) GetReviewDecision(_ context.Context, _ *info.Event) (string, error) {
+	if v.ReviewDecisionErroring {
+		return "", fmt.Errorf("cannot get the reviews")
+	}
+	return v.ReviewDecision, nil
+}
+
+func (v *TestProviderImp) GetCIVariables(_ context.Context, _ *info.Event, names []string) (map[string]string, error) {
+	ret := map[string]string{}
+	for _, name := range names {
+		value, ok := v.CIVariables[name]
+		if !ok {
+			return nil, fmt.Errorf("CI variable %s does not exist", name)
+		}
+		ret[name] = value
+	}
+	return ret, nil
+}
+
 func (v *TestProviderImp) SetClient(_ context.Context, _ *params.Run, _ *info.Event, _ *v1alpha1.Repository, _ *events.EventEmitter) error {
 	return nil
 }
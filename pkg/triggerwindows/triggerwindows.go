@@ -0,0 +1,164 @@
+package triggerwindows
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/apis/pipelinesascode/v1alpha1"
+)
+
+// dayNames are the names of the days of the week in a cron expression.
+var dayNames = map[string]int{"sun": 0, "mon": 1, "tue": 2, "wed": 3, "thu": 4, "fri": 5, "sat": 6}
+
+// window is a parsed trigger window, its start and end are minutes since
+// midnight.
+type window struct {
+	days       [7]bool
+	start, end int
+}
+
+// Get returns the trigger windows of the settings of a repository, nil when
+// it has none.
+func Get(repo *v1alpha1.Repository) *v1alpha1.TriggerWindows {
+	if repo == nil || repo.Spec.Settings == nil {
+		return nil
+	}
+	return repo.Spec.Settings.TriggerWindows
+}
+
+// Outside returns what happens to the events of a repository received
+// outside of its trigger windows.
+func Outside(repo *v1alpha1.Repository) string {
+	if tw := Get(repo); tw != nil && tw.Outside == v1alpha1.TriggerWindowsOutsideQueue {
+		return v1alpha1.TriggerWindowsOutsideQueue
+	}
+	return v1alpha1.TriggerWindowsOutsideSkip
+}
+
+// Check tells whether now is inside one of the trigger windows of a
+// repository, always true when it has none. When it is not, it returns the
+// time the next window opens.
+func Check(repo *v1alpha1.Repository, now time.Time) (bool, time.Time, error) {
+	tw := Get(repo)
+	if tw == nil {
+		return true, time.Time{}, nil
+	}
+	windows, loc, err := parse(tw)
+	if err != nil {
+		return false, time.Time{}, fmt.Errorf("invalid trigger_windows of repository %s: %w", repo.GetName(), err)
+	}
+
+	local := now.In(loc)
+	next := time.Time{}
+	// start from the day before for the windows ending after midnight
+	for offset := -1; offset <= 7; offset++ {
+		day := time.Date(local.Year(), local.Month(), local.Day()+offset, 0, 0, 0, 0, loc)
+		for _, w := range windows {
+			if !w.days[day.Weekday()] {
+				continue
+			}
+			start := time.Date(day.Year(), day.Month(), day.Day(), 0, w.start, 0, 0, loc)
+			endDay := day.Day()
+			if w.end <= w.start {
+				endDay++
+			}
+			end := time.Date(day.Year(), day.Month(), endDay, 0, w.end, 0, 0, loc)
+			if !now.Before(start) && now.Before(end) {
+				return true, time.Time{}, nil
+			}
+			if start.After(now) && (next.IsZero() || start.Before(next)) {
+				next = start
+			}
+		}
+	}
+	return false, next, nil
+}
+
+func parse(tw *v1alpha1.TriggerWindows) ([]window, *time.Location, error) {
+	loc := time.UTC
+	if tw.Timezone != "" {
+		var err error
+		if loc, err = time.LoadLocation(tw.Timezone); err != nil {
+			return nil, nil, fmt.Errorf("unknown timezone %q", tw.Timezone)
+		}
+	}
+	if len(tw.Windows) == 0 {
+		return nil, nil, fmt.Errorf("no window defined")
+	}
+	windows := make([]window, 0, len(tw.Windows))
+	for _, tws := range tw.Windows {
+		days, err := parseDays(tws.Days)
+		if err != nil {
+			return nil, nil, err
+		}
+		start, err := parseClock(tws.Start, false)
+		if err != nil {
+			return nil, nil, err
+		}
+		end, err := parseClock(tws.End, true)
+		if err != nil {
+			return nil, nil, err
+		}
+		windows = append(windows, window{days: days, start: start, end: end})
+	}
+	return windows, loc, nil
+}
+
+// parseDays parses the day of the week field of a cron expression, like
+// "mon-fri" or "0,6". Sunday is either 0 or 7 and the ranges can wrap around
+// the end of the week, like "fri-mon".
+func parseDays(field string) ([7]bool, error) {
+	days := [7]bool{}
+	if field == "" || field == "*" {
+		for i := range days {
+			days[i] = true
+		}
+		return days, nil
+	}
+	for _, item := range strings.Split(field, ",") {
+		first, last, isRange := strings.Cut(strings.TrimSpace(item), "-")
+		from, err := parseDay(first)
+		if err != nil {
+			return days, err
+		}
+		to := from
+		if isRange {
+			if to, err = parseDay(last); err != nil {
+				return days, err
+			}
+		}
+		if to < from {
+			to += 7
+		}
+		for i := from; i <= to; i++ {
+			days[i%7] = true
+		}
+	}
+	return days, nil
+}
+
+func parseDay(day string) (int, error) {
+	if n, ok := dayNames[strings.ToLower(day)]; ok {
+		return n, nil
+	}
+	n, err := strconv.Atoi(day)
+	if err != nil || n < 0 || n > 7 {
+		return 0, fmt.Errorf("invalid day %q, it needs to be a number from 0 to 7 or a name like mon", day)
+	}
+	return n, nil
+}
+
+// parseClock returns the minutes since midnight of a time like "17:30",
+// "24:00" is only allowed as the end of a window.
+func parseClock(clock string, end bool) (int, error) {
+	if end && clock == "24:00" {
+		return 24 * 60, nil
+	}
+	t, err := time.Parse("15:04", clock)
+	if err != nil {
+		return 0, fmt.Errorf("invalid time %q, it needs to be like 09:00", clock)
+	}
+	return t.Hour()*60 + t.Minute(), nil
+}
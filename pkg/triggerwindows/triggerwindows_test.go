@@ -0,0 +1,130 @@
+package triggerwindows
+
+import (
+	"testing"
+	"time"
+
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/apis/pipelinesascode/v1alpha1"
+	"gotest.tools/v3/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestCheck(t *testing.T) {
+	paris, err := time.LoadLocation("Europe/Paris")
+	assert.NilError(t, err)
+	officeHours := []v1alpha1.TriggerWindow{{Days: "mon-fri", Start: "09:00", End: "17:30"}}
+	tests := []struct {
+		name     string
+		windows  *v1alpha1.TriggerWindows
+		now      time.Time
+		wantOpen bool
+		wantNext time.Time
+		wantErr  string
+	}{
+		{
+			name:     "no trigger windows",
+			now:      time.Date(2026, 10, 17, 3, 0, 0, 0, time.UTC),
+			wantOpen: true,
+		},
+		{
+			name:     "inside a window",
+			windows:  &v1alpha1.TriggerWindows{Windows: officeHours},
+			now:      time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC),
+			wantOpen: true,
+		},
+		{
+			name:     "before a window on the same day",
+			windows:  &v1alpha1.TriggerWindows{Windows: officeHours},
+			now:      time.Date(2026, 10, 16, 8, 0, 0, 0, time.UTC),
+			wantNext: time.Date(2026, 10, 16, 9, 0, 0, 0, time.UTC),
+		},
+		{
+			name:     "weekend",
+			windows:  &v1alpha1.TriggerWindows{Windows: officeHours},
+			now:      time.Date(2026, 10, 16, 17, 30, 0, 0, time.UTC),
+			wantNext: time.Date(2026, 10, 19, 9, 0, 0, 0, time.UTC),
+		},
+		{
+			name:     "timezone",
+			windows:  &v1alpha1.TriggerWindows{Timezone: "Europe/Paris", Windows: officeHours},
+			now:      time.Date(2026, 10, 16, 6, 30, 0, 0, time.UTC),
+			wantNext: time.Date(2026, 10, 16, 9, 0, 0, 0, paris),
+		},
+		{
+			name: "overnight window from the day before",
+			windows: &v1alpha1.TriggerWindows{Windows: []v1alpha1.TriggerWindow{
+				{Days: "fri", Start: "22:00", End: "06:00"},
+			}},
+			now:      time.Date(2026, 10, 17, 5, 0, 0, 0, time.UTC),
+			wantOpen: true,
+		},
+		{
+			name: "range wrapping around the week",
+			windows: &v1alpha1.TriggerWindows{Windows: []v1alpha1.TriggerWindow{
+				{Days: "sat-0", Start: "00:00", End: "24:00"},
+			}},
+			now:      time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC),
+			wantNext: time.Date(2026, 10, 17, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name: "numbered days",
+			windows: &v1alpha1.TriggerWindows{Windows: []v1alpha1.TriggerWindow{
+				{Days: "1,3,7", Start: "10:00", End: "11:00"},
+			}},
+			now:      time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC),
+			wantNext: time.Date(2026, 10, 18, 10, 0, 0, 0, time.UTC),
+		},
+		{
+			name:    "unknown timezone",
+			windows: &v1alpha1.TriggerWindows{Timezone: "Mars/Olympus", Windows: officeHours},
+			now:     time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC),
+			wantErr: `invalid trigger_windows of repository repo: unknown timezone "Mars/Olympus"`,
+		},
+		{
+			name:    "no window",
+			windows: &v1alpha1.TriggerWindows{},
+			now:     time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC),
+			wantErr: "invalid trigger_windows of repository repo: no window defined",
+		},
+		{
+			name: "invalid day",
+			windows: &v1alpha1.TriggerWindows{Windows: []v1alpha1.TriggerWindow{
+				{Days: "mon-funday", Start: "09:00", End: "17:00"},
+			}},
+			now:     time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC),
+			wantErr: `invalid trigger_windows of repository repo: invalid day "funday", it needs to be a number from 0 to 7 or a name like mon`,
+		},
+		{
+			name: "invalid time",
+			windows: &v1alpha1.TriggerWindows{Windows: []v1alpha1.TriggerWindow{
+				{Start: "24:00", End: "17:00"},
+			}},
+			now:     time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC),
+			wantErr: `invalid trigger_windows of repository repo: invalid time "24:00", it needs to be like 09:00`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &v1alpha1.Repository{
+				ObjectMeta: metav1.ObjectMeta{Name: "repo"},
+				Spec:       v1alpha1.RepositorySpec{Settings: &v1alpha1.Settings{TriggerWindows: tt.windows}},
+			}
+			open, next, err := Check(repo, tt.now)
+			if tt.wantErr != "" {
+				assert.Error(t, err, tt.wantErr)
+				return
+			}
+			assert.NilError(t, err)
+			assert.Equal(t, open, tt.wantOpen)
+			assert.Assert(t, next.Equal(tt.wantNext), "next is %s", next)
+		})
+	}
+}
+
+func TestOutside(t *testing.T) {
+	assert.Equal(t, Outside(&v1alpha1.Repository{}), v1alpha1.TriggerWindowsOutsideSkip)
+	repo := &v1alpha1.Repository{Spec: v1alpha1.RepositorySpec{Settings: &v1alpha1.Settings{
+		TriggerWindows: &v1alpha1.TriggerWindows{Outside: v1alpha1.TriggerWindowsOutsideQueue},
+	}}}
+	assert.Equal(t, Outside(repo), v1alpha1.TriggerWindowsOutsideQueue)
+}
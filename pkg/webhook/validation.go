@@ -2,10 +2,12 @@ package webhook
 
 import (
 	"context"
+	"fmt"
 	"net/url"
 	"os"
 
 	"github.com/openshift-pipelines/pipelines-as-code/pkg/apis/pipelinesascode/v1alpha1"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/formatting"
 	pac "github.com/openshift-pipelines/pipelines-as-code/pkg/generated/listers/pipelinesascode/v1alpha1"
 	v1 "k8s.io/api/admission/v1"
 	"k8s.io/apimachinery/pkg/labels"
@@ -19,6 +21,8 @@ var universalDeserializer = serializer.NewCodecFactory(runtime.NewScheme()).Univ
 
 var allowedGitlabDisableCommentStrategyOnMr = sets.NewString("", "disable_all")
 
+var allowedGiteaCommentStrategy = sets.NewString("", "disable_all", "checks_summary")
+
 // Path implements AdmissionController.
 func (ac *reconciler) Path() string {
 	return ac.path
@@ -32,20 +36,8 @@ func (ac *reconciler) Admit(_ context.Context, request *v1.AdmissionRequest) *v1
 		return webhook.MakeErrorStatus("validation failed: %v", err)
 	}
 
-	// Check that if we have a URL set only for non global repository which can be set as empty.
-	if repo.GetNamespace() != os.Getenv("SYSTEM_NAMESPACE") {
-		if repo.Spec.URL == "" {
-			return webhook.MakeErrorStatus("URL must be set")
-		}
-
-		parsed, err := url.Parse(repo.Spec.URL)
-		if err != nil {
-			return webhook.MakeErrorStatus("invalid URL format: %v", err)
-		}
-
-		if parsed.Scheme != "http" && parsed.Scheme != "https" {
-			return webhook.MakeErrorStatus("URL scheme must be http or https")
-		}
+	if err := ValidateRepository(&repo, os.Getenv("SYSTEM_NAMESPACE")); err != nil {
+		return webhook.MakeErrorStatus("%s", err.Error())
 	}
 
 	exist, err := checkIfRepoExist(ac.pacLister, &repo, "")
@@ -57,17 +49,45 @@ func (ac *reconciler) Admit(_ context.Context, request *v1.AdmissionRequest) *v1
 		return webhook.MakeErrorStatus("repository already exists with URL: %s", repo.Spec.URL)
 	}
 
+	return &v1.AdmissionResponse{Allowed: true}
+}
+
+// ValidateRepository checks the spec of a Repository like the admission
+// webhook does, without the check of the other Repositories with the same URL.
+// The URL may be empty for the Repository of the systemNamespace which holds
+// the global settings.
+func ValidateRepository(repo *v1alpha1.Repository, systemNamespace string) error {
+	if repo.GetNamespace() != systemNamespace {
+		if repo.Spec.URL == "" {
+			return fmt.Errorf("URL must be set")
+		}
+
+		parsed, err := url.Parse(repo.Spec.URL)
+		if err != nil {
+			return fmt.Errorf("invalid URL format: %w", err)
+		}
+
+		if parsed.Scheme != "http" && parsed.Scheme != "https" {
+			return fmt.Errorf("URL scheme must be http or https")
+		}
+	}
+
 	if repo.Spec.ConcurrencyLimit != nil && *repo.Spec.ConcurrencyLimit == 0 {
-		return webhook.MakeErrorStatus("concurrency limit must be greater than 0")
+		return fmt.Errorf("concurrency limit must be greater than 0")
 	}
 
 	if repo.Spec.Settings != nil && repo.Spec.Settings.Gitlab != nil {
 		if !allowedGitlabDisableCommentStrategyOnMr.Has(repo.Spec.Settings.Gitlab.CommentStrategy) {
-			return webhook.MakeErrorStatus("comment strategy '%s' is not supported for Gitlab MRs", repo.Spec.Settings.Gitlab.CommentStrategy)
+			return fmt.Errorf("comment strategy '%s' is not supported for Gitlab MRs", repo.Spec.Settings.Gitlab.CommentStrategy)
 		}
 	}
 
-	return &v1.AdmissionResponse{Allowed: true}
+	if repo.Spec.Settings != nil && repo.Spec.Settings.Gitea != nil {
+		if !allowedGiteaCommentStrategy.Has(repo.Spec.Settings.Gitea.CommentStrategy) {
+			return fmt.Errorf("comment strategy '%s' is not supported for Gitea pull requests", repo.Spec.Settings.Gitea.CommentStrategy)
+		}
+	}
+	return nil
 }
 
 func checkIfRepoExist(pac pac.RepositoryLister, repo *v1alpha1.Repository, ns string) (bool, error) {
@@ -77,7 +97,7 @@ func checkIfRepoExist(pac pac.RepositoryLister, repo *v1alpha1.Repository, ns st
 	}
 	for i := len(repositories) - 1; i >= 0; i-- {
 		repoFromCluster := repositories[i]
-		if repoFromCluster.Spec.URL == repo.Spec.URL &&
+		if formatting.NormalizeRepoURL(repoFromCluster.Spec.URL) == formatting.NormalizeRepoURL(repo.Spec.URL) &&
 			(repoFromCluster.Name != repo.Name || repoFromCluster.Namespace != repo.Namespace) {
 			return true, nil
 		}
@@ -84,6 +84,16 @@ func TestReconciler_Admit(t *testing.T) {
 			allowed: false,
 			result:  "repository already exists with URL: https://pac.test/already/installed",
 		},
+		{
+			name: "reject same url once normalized",
+			repo: testnewrepo.NewRepo(testnewrepo.RepoTestcreationOpts{
+				Name:             "test-run",
+				InstallNamespace: "namespace",
+				URL:              "https://pac.test/Already/Installed.git/",
+			}),
+			allowed: false,
+			result:  "repository already exists with URL: https://pac.test/Already/Installed.git/",
+		},
 		{
 			name: "allow as it is be update to existing repo",
 			repo: testnewrepo.NewRepo(testnewrepo.RepoTestcreationOpts{
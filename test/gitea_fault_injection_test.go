@@ -0,0 +1,78 @@
+//go:build e2e
+// +build e2e
+
+package test
+
+import (
+	"net/http"
+	"os"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/params/triggertype"
+	"github.com/openshift-pipelines/pipelines-as-code/test/pkg/faultinject"
+	tgitea "github.com/openshift-pipelines/pipelines-as-code/test/pkg/gitea"
+	twait "github.com/openshift-pipelines/pipelines-as-code/test/pkg/wait"
+	"gotest.tools/v3/assert"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// TestGiteaStatusReportedAfterProviderErrors points the Repository at a
+// faultinject proxy in front of Gitea, makes the commit status API fail and
+// checks that the controller retries until the statuses are reported.
+func TestGiteaStatusReportedAfterProviderErrors(t *testing.T) {
+	proxyURL := os.Getenv("TEST_FAULT_PROXY_URL")
+	if proxyURL == "" {
+		t.Skip("TEST_FAULT_PROXY_URL is not set, skipping the fault injection tests")
+	}
+	listenAddr := os.Getenv("TEST_FAULT_PROXY_ADDR")
+	if listenAddr == "" {
+		listenAddr = ":8089"
+	}
+	proxy, err := faultinject.New(os.Getenv("TEST_GITEA_API_URL"))
+	assert.NilError(t, err)
+	stop, err := proxy.Start(listenAddr)
+	assert.NilError(t, err)
+	defer stop()
+
+	statuses := regexp.MustCompile(`^/api/v1/repos/[^/]+/[^/]+/statuses/`)
+	tests := []struct {
+		name string
+		rule faultinject.Rule
+	}{
+		{
+			name: "server errors",
+			rule: faultinject.Rule{Method: http.MethodPost, Path: statuses, StatusCode: http.StatusInternalServerError, Times: 2},
+		},
+		{
+			name: "rate limited",
+			rule: faultinject.Rule{Method: http.MethodPost, Path: statuses, StatusCode: http.StatusTooManyRequests, RetryAfter: 2 * time.Second, Times: 2},
+		},
+		{
+			name: "slow provider",
+			rule: faultinject.Rule{Method: http.MethodPost, Path: statuses, Delay: 20 * time.Second, Times: 1},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			proxy.Reset()
+			fault := proxy.Inject(tt.rule)
+			topts := &tgitea.TestOpts{
+				TargetEvent:    triggertype.PullRequest.String(),
+				YAMLFiles:      map[string]string{".tekton/pr.yaml": "testdata/pipelinerun.yaml"},
+				CheckForStatus: "success",
+				GitProviderURL: proxyURL,
+			}
+			_, f := tgitea.TestPR(t, topts)
+			defer f()
+
+			assert.Equal(t, fault.Hits(), tt.rule.Times, "the controller did not hit the injected fault")
+			tgitea.WaitForRepositoryStatus(t, topts, twait.RepositoryStatusOpts{
+				EventType: triggertype.PullRequest.String(),
+				SHA:       topts.PullRequest.Head.Sha,
+				Status:    corev1.ConditionTrue,
+			})
+		})
+	}
+}
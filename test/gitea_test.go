@@ -615,7 +615,10 @@ func TestGiteaPush(t *testing.T) {
 	assert.Assert(t, resp.StatusCode < 400, resp)
 	assert.Assert(t, merged)
 	tgitea.WaitForStatus(t, topts, topts.PullRequest.Head.Sha, "", false)
-	time.Sleep(5 * time.Second)
+	tgitea.WaitForRepositoryStatus(t, topts, twait.RepositoryStatusOpts{
+		EventType:    triggertype.Push.String(),
+		TargetBranch: topts.DefaultBranch,
+	})
 	prs, err := topts.ParamsRun.Clients.Tekton.TektonV1().PipelineRuns(topts.TargetNS).List(context.Background(), metav1.ListOptions{
 		LabelSelector: pacapi.EventType + "=push",
 	})
@@ -878,6 +881,47 @@ func TestGiteaOnPathChangeAndOnPathChangeIgnore(t *testing.T) {
 	defer f()
 }
 
+// TestGiteaMonorepoOnPathChange seeds the default branch with a monorepo
+// fixture and checks that a pull request changing only the frontend only runs
+// the frontend PipelineRun.
+func TestGiteaMonorepoOnPathChange(t *testing.T) {
+	topts := &tgitea.TestOpts{
+		TargetEvent:           triggertype.PullRequest.String(),
+		NoPullRequestCreation: true,
+		CheckForStatus:        "success",
+		CheckForNumberStatus:  1,
+	}
+	_, f := tgitea.TestPR(t, topts)
+	defer f()
+
+	entries, err := payload.GetFixtureEntries("testdata/fixtures/monorepo", topts.TargetNS,
+		topts.DefaultBranch, topts.TargetEvent, topts.ExtraArgs)
+	assert.NilError(t, err)
+	tgitea.PushFixtureToRefAPI(t, topts, entries, tgitea.PushFixtureOpts{Branch: topts.DefaultBranch})
+
+	_, err = tgitea.PushFilesToRefAPI(t, topts, map[string]string{
+		"frontend/src/app.js": "console.log(\"a frontend change\");\n",
+	})
+	assert.NilError(t, err)
+	topts.PullRequest, _, err = topts.GiteaCNX.Client().CreatePullRequest(topts.Opts.Organization, topts.Opts.Repo, gitea.CreatePullRequestOption{
+		Title: "Test Pull Request - " + topts.TargetRefName,
+		Head:  topts.TargetRefName,
+		Base:  topts.DefaultBranch,
+	})
+	assert.NilError(t, err)
+	tgitea.WaitForStatus(t, topts, topts.TargetRefName, "", false)
+
+	run := tgitea.WaitForRepositoryStatus(t, topts, twait.RepositoryStatusOpts{
+		EventType:    triggertype.PullRequest.String(),
+		SHA:          topts.PullRequest.Head.Sha,
+		TargetBranch: topts.DefaultBranch,
+	})
+	assert.Assert(t, strings.HasPrefix(run.PipelineRunName, "frontend"))
+	repo, err := topts.ParamsRun.Clients.PipelineAsCode.PipelinesascodeV1alpha1().Repositories(topts.TargetNS).Get(context.Background(), topts.TargetNS, metav1.GetOptions{})
+	assert.NilError(t, err)
+	assert.Equal(t, len(repo.Status), 1)
+}
+
 func TestGiteaErrorSnippet(t *testing.T) {
 	topts := &tgitea.TestOpts{
 		TargetEvent: triggertype.PullRequest.String(),
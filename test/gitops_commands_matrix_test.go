@@ -0,0 +1,160 @@
+//go:build e2e
+// +build e2e
+
+package test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/params/triggertype"
+	tbbc "github.com/openshift-pipelines/pipelines-as-code/test/pkg/bitbucketcloud"
+	"github.com/openshift-pipelines/pipelines-as-code/test/pkg/cctx"
+	tgitea "github.com/openshift-pipelines/pipelines-as-code/test/pkg/gitea"
+	tgithub "github.com/openshift-pipelines/pipelines-as-code/test/pkg/github"
+	tgitlab "github.com/openshift-pipelines/pipelines-as-code/test/pkg/gitlab"
+	"github.com/openshift-pipelines/pipelines-as-code/test/pkg/gitops"
+	"github.com/openshift-pipelines/pipelines-as-code/test/pkg/options"
+	"github.com/openshift-pipelines/pipelines-as-code/test/pkg/payload"
+	"github.com/openshift-pipelines/pipelines-as-code/test/pkg/scm"
+	twait "github.com/openshift-pipelines/pipelines-as-code/test/pkg/wait"
+	"github.com/tektoncd/pipeline/pkg/names"
+	"gotest.tools/v3/assert"
+)
+
+// gitopsCommandsPipelineRun is the PipelineRun of
+// testdata/pipelinerun-gitops.yaml, long enough to be cancelled.
+const gitopsCommandsPipelineRun = "pr-gitops-comment"
+
+var gitopsCommandsYAMLFiles = map[string]string{
+	".tekton/pipelinerun.yaml":        "testdata/pipelinerun.yaml",
+	".tekton/pipelinerun-gitops.yaml": "testdata/pipelinerun-gitops.yaml",
+}
+
+func TestGiteaGitOpsCommandsMatrix(t *testing.T) {
+	topts := &tgitea.TestOpts{
+		TargetEvent:          triggertype.PullRequest.String(),
+		YAMLFiles:            gitopsCommandsYAMLFiles,
+		CheckForStatus:       "success",
+		CheckForNumberStatus: 2,
+	}
+	ctx, f := tgitea.TestPR(t, topts)
+	defer f()
+
+	gitops.RunCommandsMatrix(ctx, t, gitops.Env{
+		Driver:          tgitea.NewCommentDriver(topts),
+		Run:             topts.ParamsRun,
+		TargetNS:        topts.TargetNS,
+		SHA:             topts.PullRequest.Head.Sha,
+		PipelineRunName: gitopsCommandsPipelineRun,
+	})
+}
+
+func TestGithubGitOpsCommandsMatrix(t *testing.T) {
+	ctx := context.Background()
+	g := &tgithub.PRTest{
+		Label:     "Github gitops commands matrix",
+		YamlFiles: []string{"testdata/pipelinerun.yaml", "testdata/pipelinerun-gitops.yaml"},
+	}
+	g.RunPullRequest(ctx, t)
+	defer g.TearDown(ctx, t)
+
+	gitops.RunCommandsMatrix(ctx, t, gitops.Env{
+		Driver:          g.CommentDriver(),
+		Run:             g.Cnx,
+		TargetNS:        g.TargetNamespace,
+		SHA:             g.SHA,
+		PipelineRunName: gitopsCommandsPipelineRun,
+	})
+}
+
+func TestGitlabGitOpsCommandsMatrix(t *testing.T) {
+	targetNS := names.SimpleNameGenerator.RestrictLengthWithRandomSuffix("pac-e2e-ns")
+	ctx := context.Background()
+	runcnx, opts, glprovider, err := tgitlab.Setup(ctx)
+	assert.NilError(t, err)
+	ctx, err = cctx.GetControllerCtxInfo(ctx, runcnx)
+	assert.NilError(t, err)
+
+	projectinfo, resp, err := glprovider.Client().Projects.GetProject(opts.ProjectID, nil)
+	assert.NilError(t, err)
+	if resp != nil && resp.StatusCode == http.StatusNotFound {
+		t.Fatalf("Repository %s not found in %s", opts.Organization, opts.Repo)
+	}
+	assert.NilError(t, tgitlab.CreateCRD(ctx, projectinfo, runcnx, opts, targetNS, nil))
+
+	entries, err := payload.GetEntries(gitopsCommandsYAMLFiles, targetNS, projectinfo.DefaultBranch,
+		triggertype.PullRequest.String(), map[string]string{})
+	assert.NilError(t, err)
+	targetRefName := names.SimpleNameGenerator.RestrictLengthWithRandomSuffix("pac-e2e-test")
+	gitCloneURL, err := scm.MakeGitCloneURL(projectinfo.WebURL, opts.UserName, opts.Password)
+	assert.NilError(t, err)
+	commitTitle := "Committing files from test on " + targetRefName
+	sha := scm.PushFilesToRefGit(t, &scm.Opts{
+		GitURL:        gitCloneURL,
+		CommitTitle:   commitTitle,
+		Log:           runcnx.Clients.Log,
+		WebURL:        projectinfo.WebURL,
+		TargetRefName: targetRefName,
+		BaseRefName:   projectinfo.DefaultBranch,
+	}, entries)
+
+	mrID, err := tgitlab.CreateMR(glprovider.Client(), opts.ProjectID, targetRefName, projectinfo.DefaultBranch,
+		"TestGitOpsCommandsMatrix - "+targetRefName)
+	assert.NilError(t, err)
+	defer tgitlab.TearDown(ctx, t, runcnx, glprovider, mrID, targetRefName, targetNS, opts.ProjectID)
+
+	twait.Succeeded(ctx, t, runcnx, opts, twait.SuccessOpt{
+		Title:           commitTitle,
+		OnEvent:         "Merge Request",
+		TargetNS:        targetNS,
+		NumberofPRMatch: 2,
+		SHA:             sha,
+	})
+
+	gitops.RunCommandsMatrix(ctx, t, gitops.Env{
+		Driver:          tgitlab.NewCommentDriver(glprovider.Client(), opts.ProjectID, mrID),
+		Run:             runcnx,
+		TargetNS:        targetNS,
+		SHA:             sha,
+		PipelineRunName: gitopsCommandsPipelineRun,
+	})
+}
+
+func TestBitbucketCloudGitOpsCommandsMatrix(t *testing.T) {
+	targetNS := names.SimpleNameGenerator.RestrictLengthWithRandomSuffix("pac-e2e-ns")
+	ctx := context.Background()
+	runcnx, opts, bprovider, err := tbbc.Setup(ctx)
+	if err != nil {
+		t.Skip(err.Error())
+		return
+	}
+	bcrepo := tbbc.CreateCRD(ctx, t, bprovider, runcnx, opts, targetNS)
+	targetRefName := names.SimpleNameGenerator.RestrictLengthWithRandomSuffix("pac-e2e-test")
+	title := "TestGitOpsCommandsMatrix - " + targetRefName
+
+	entries, err := payload.GetEntries(gitopsCommandsYAMLFiles, targetNS, options.MainBranch,
+		triggertype.PullRequest.String(), map[string]string{})
+	assert.NilError(t, err)
+	pr, repobranch := tbbc.MakePR(t, bprovider, runcnx, bcrepo, opts, title, targetRefName, entries)
+	defer tbbc.TearDown(ctx, t, runcnx, bprovider, opts, pr.ID, targetRefName, targetNS, false)
+
+	sha, ok := repobranch.Target["hash"].(string)
+	assert.Assert(t, ok)
+	twait.Succeeded(ctx, t, runcnx, opts, twait.SuccessOpt{
+		TargetNS:        targetNS,
+		OnEvent:         triggertype.PullRequest.String(),
+		NumberofPRMatch: 2,
+		SHA:             sha,
+		Title:           title,
+	})
+
+	gitops.RunCommandsMatrix(ctx, t, gitops.Env{
+		Driver:          tbbc.NewCommentDriver(bprovider, opts, pr.ID),
+		Run:             runcnx,
+		TargetNS:        targetNS,
+		SHA:             sha,
+		PipelineRunName: gitopsCommandsPipelineRun,
+	})
+}
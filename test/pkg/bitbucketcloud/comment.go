@@ -0,0 +1,61 @@
+package bitbucketcloud
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/ktrysmt/go-bitbucket"
+	"github.com/mitchellh/mapstructure"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/provider/bitbucketcloud"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/provider/bitbucketcloud/types"
+	"github.com/openshift-pipelines/pipelines-as-code/test/pkg/gitops"
+	"github.com/openshift-pipelines/pipelines-as-code/test/pkg/options"
+)
+
+var _ gitops.CommentDriver = (*CommentDriver)(nil)
+
+// CommentDriver posts and reads the comments of a pull request with the
+// Bitbucket Cloud API.
+type CommentDriver struct {
+	bprovider bitbucketcloud.Provider
+	opts      options.E2E
+	prID      int
+}
+
+func NewCommentDriver(bprovider bitbucketcloud.Provider, opts options.E2E, prID int) *CommentDriver {
+	return &CommentDriver{bprovider: bprovider, opts: opts, prID: prID}
+}
+
+func (d *CommentDriver) Provider() string {
+	return "bitbucket-cloud"
+}
+
+func (d *CommentDriver) PostComment(_ context.Context, body string) error {
+	_, err := d.bprovider.Client().Repositories.PullRequests.AddComment(&bitbucket.PullRequestCommentOptions{
+		Owner:         d.opts.Organization,
+		RepoSlug:      d.opts.Repo,
+		PullRequestID: strconv.Itoa(d.prID),
+		Content:       body,
+	})
+	return err
+}
+
+func (d *CommentDriver) ListComments(_ context.Context) ([]string, error) {
+	intf, err := d.bprovider.Client().Repositories.PullRequests.GetComments(&bitbucket.PullRequestsOptions{
+		Owner:    d.opts.Organization,
+		RepoSlug: d.opts.Repo,
+		ID:       strconv.Itoa(d.prID),
+	})
+	if err != nil {
+		return nil, err
+	}
+	comments := &types.Comments{}
+	if err := mapstructure.Decode(intf, comments); err != nil {
+		return nil, err
+	}
+	bodies := make([]string, 0, len(comments.Values))
+	for _, comment := range comments.Values {
+		bodies = append(bodies, comment.Content.Raw)
+	}
+	return bodies, nil
+}
@@ -0,0 +1,43 @@
+package bitbucketdatacenter
+
+import (
+	"context"
+
+	goscm "github.com/jenkins-x/go-scm/scm"
+	"github.com/openshift-pipelines/pipelines-as-code/test/pkg/gitops"
+)
+
+var _ gitops.CommentDriver = (*CommentDriver)(nil)
+
+// CommentDriver posts and reads the comments of a pull request with the
+// Bitbucket Data Center API.
+type CommentDriver struct {
+	client     *goscm.Client
+	orgAndRepo string
+	prID       int
+}
+
+func NewCommentDriver(client *goscm.Client, orgAndRepo string, prID int) *CommentDriver {
+	return &CommentDriver{client: client, orgAndRepo: orgAndRepo, prID: prID}
+}
+
+func (d *CommentDriver) Provider() string {
+	return "bitbucket-datacenter"
+}
+
+func (d *CommentDriver) PostComment(ctx context.Context, body string) error {
+	_, _, err := d.client.PullRequests.CreateComment(ctx, d.orgAndRepo, d.prID, &goscm.CommentInput{Body: body})
+	return err
+}
+
+func (d *CommentDriver) ListComments(ctx context.Context) ([]string, error) {
+	comments, _, err := d.client.PullRequests.ListComments(ctx, d.orgAndRepo, d.prID, &goscm.ListOptions{Size: 100})
+	if err != nil {
+		return nil, err
+	}
+	bodies := make([]string, 0, len(comments))
+	for _, comment := range comments {
+		bodies = append(bodies, comment.Body)
+	}
+	return bodies, nil
+}
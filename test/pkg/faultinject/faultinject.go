@@ -0,0 +1,187 @@
+// Package faultinject is a reverse proxy between the controller and a git
+// provider API, it lets the e2e tests make the provider answer with errors or
+// hang to check how the controller copes with them.
+package faultinject
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httputil"
+	"net/url"
+	"regexp"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// Rule describes the requests to fault and how.
+type Rule struct {
+	// Method of the requests to fault, all the methods when empty.
+	Method string
+	// Path of the requests to fault, all the paths when nil.
+	Path *regexp.Regexp
+	// StatusCode answered instead of forwarding the request, like
+	// http.StatusTooManyRequests or http.StatusInternalServerError. When 0
+	// the request is forwarded after the Delay.
+	StatusCode int
+	// RetryAfter is sent in the Retry-After header of the answer when set.
+	RetryAfter time.Duration
+	// Delay before answering or forwarding the request, longer than the
+	// client timeout to simulate a provider not answering.
+	Delay time.Duration
+	// Times is the number of requests to fault, all of them when 0.
+	Times int
+}
+
+func (r Rule) matches(req *http.Request) bool {
+	if r.Method != "" && r.Method != req.Method {
+		return false
+	}
+	return r.Path == nil || r.Path.MatchString(req.URL.Path)
+}
+
+// Fault is a rule injected in the proxy.
+type Fault struct {
+	rule  Rule
+	proxy *Proxy
+	hits  int
+}
+
+// Hits returns the number of requests faulted by the rule.
+func (f *Fault) Hits() int {
+	f.proxy.mu.Lock()
+	defer f.proxy.mu.Unlock()
+	return f.hits
+}
+
+// Remove stops faulting the requests matching the rule.
+func (f *Fault) Remove() {
+	f.proxy.mu.Lock()
+	defer f.proxy.mu.Unlock()
+	for i, fault := range f.proxy.faults {
+		if fault == f {
+			f.proxy.faults = append(f.proxy.faults[:i], f.proxy.faults[i+1:]...)
+			return
+		}
+	}
+}
+
+// Proxy forwards the requests to the provider API unless a fault matches
+// them.
+type Proxy struct {
+	mu       sync.Mutex
+	faults   []*Fault
+	forward  *httputil.ReverseProxy
+	requests int
+}
+
+// New returns a proxy forwarding the requests to the provider API at target.
+func New(target string) (*Proxy, error) {
+	u, err := url.Parse(target)
+	if err != nil {
+		return nil, fmt.Errorf("invalid target url %s: %w", target, err)
+	}
+	if u.Scheme == "" || u.Host == "" {
+		return nil, fmt.Errorf("invalid target url %s: it needs a scheme and a host", target)
+	}
+	return &Proxy{
+		forward: &httputil.ReverseProxy{
+			Rewrite: func(r *httputil.ProxyRequest) {
+				r.SetURL(u)
+				r.SetXForwarded()
+			},
+		},
+	}, nil
+}
+
+// Inject starts faulting the requests matching the rule, the rules are
+// checked in the order they have been injected.
+func (p *Proxy) Inject(rule Rule) *Fault {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	fault := &Fault{rule: rule, proxy: p}
+	p.faults = append(p.faults, fault)
+	return fault
+}
+
+// Reset removes all the faults, the requests are forwarded untouched again.
+func (p *Proxy) Reset() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.faults = nil
+}
+
+// Requests returns the number of requests received by the proxy.
+func (p *Proxy) Requests() int {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.requests
+}
+
+// match returns the rule of the first fault matching the request and counts
+// the hit, false when the request is to be forwarded untouched.
+func (p *Proxy) match(req *http.Request) (Rule, bool) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.requests++
+	for _, fault := range p.faults {
+		if !fault.rule.matches(req) {
+			continue
+		}
+		if fault.rule.Times > 0 && fault.hits >= fault.rule.Times {
+			continue
+		}
+		fault.hits++
+		return fault.rule, true
+	}
+	return Rule{}, false
+}
+
+func (p *Proxy) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	rule, ok := p.match(req)
+	if !ok {
+		p.forward.ServeHTTP(w, req)
+		return
+	}
+	if rule.Delay > 0 {
+		select {
+		case <-time.After(rule.Delay):
+		case <-req.Context().Done():
+			return
+		}
+	}
+	if rule.StatusCode == 0 {
+		p.forward.ServeHTTP(w, req)
+		return
+	}
+	if rule.RetryAfter > 0 {
+		w.Header().Set("Retry-After", strconv.Itoa(int(rule.RetryAfter.Round(time.Second).Seconds())))
+	}
+	http.Error(w, fmt.Sprintf("fault injected: %s", http.StatusText(rule.StatusCode)), rule.StatusCode)
+}
+
+// Start serves the proxy on addr, like ":8089", until the returned function
+// is called.
+func (p *Proxy) Start(addr string) (func(), error) {
+	listener, err := net.Listen("tcp", addr)
+	if err != nil {
+		return nil, fmt.Errorf("cannot listen on %s: %w", addr, err)
+	}
+	server := &http.Server{Handler: p, ReadHeaderTimeout: 10 * time.Second}
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		if err := server.Serve(listener); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			fmt.Printf("fault injection proxy on %s stopped: %v\n", addr, err)
+		}
+	}()
+	return func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		_ = server.Shutdown(ctx)
+		<-done
+	}, nil
+}
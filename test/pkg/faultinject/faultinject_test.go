@@ -0,0 +1,196 @@
+package faultinject
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"gotest.tools/v3/assert"
+)
+
+type answer struct {
+	status     int
+	retryAfter string
+}
+
+func TestProxy(t *testing.T) {
+	statuses := regexp.MustCompile(`^/api/v1/repos/[^/]+/[^/]+/statuses/`)
+	tests := []struct {
+		name     string
+		rules    []Rule
+		method   string
+		path     string
+		requests int
+		want     []answer
+		wantHits []int
+	}{
+		{
+			name:     "no fault",
+			method:   http.MethodGet,
+			path:     "/api/v1/version",
+			requests: 2,
+			want:     []answer{{status: http.StatusOK}, {status: http.StatusOK}},
+		},
+		{
+			name:     "server errors a number of times",
+			rules:    []Rule{{Method: http.MethodPost, Path: statuses, StatusCode: http.StatusInternalServerError, Times: 2}},
+			method:   http.MethodPost,
+			path:     "/api/v1/repos/owner/repo/statuses/abc",
+			requests: 3,
+			want: []answer{
+				{status: http.StatusInternalServerError},
+				{status: http.StatusInternalServerError},
+				{status: http.StatusOK},
+			},
+			wantHits: []int{2},
+		},
+		{
+			name:     "rate limited with a retry after",
+			rules:    []Rule{{StatusCode: http.StatusTooManyRequests, RetryAfter: 3 * time.Second, Times: 1}},
+			method:   http.MethodGet,
+			path:     "/api/v1/version",
+			requests: 2,
+			want:     []answer{{status: http.StatusTooManyRequests, retryAfter: "3"}, {status: http.StatusOK}},
+			wantHits: []int{1},
+		},
+		{
+			name:     "other method not faulted",
+			rules:    []Rule{{Method: http.MethodPost, Path: statuses, StatusCode: http.StatusInternalServerError}},
+			method:   http.MethodGet,
+			path:     "/api/v1/repos/owner/repo/statuses/abc",
+			requests: 1,
+			want:     []answer{{status: http.StatusOK}},
+			wantHits: []int{0},
+		},
+		{
+			name:     "other path not faulted",
+			rules:    []Rule{{Path: statuses, StatusCode: http.StatusInternalServerError}},
+			method:   http.MethodPost,
+			path:     "/api/v1/repos/owner/repo/pulls",
+			requests: 1,
+			want:     []answer{{status: http.StatusOK}},
+			wantHits: []int{0},
+		},
+		{
+			name: "first matching rule wins",
+			rules: []Rule{
+				{StatusCode: http.StatusTooManyRequests, Times: 1},
+				{StatusCode: http.StatusInternalServerError, Times: 1},
+			},
+			method:   http.MethodGet,
+			path:     "/api/v1/version",
+			requests: 3,
+			want: []answer{
+				{status: http.StatusTooManyRequests},
+				{status: http.StatusInternalServerError},
+				{status: http.StatusOK},
+			},
+			wantHits: []int{1, 1},
+		},
+		{
+			name:     "delayed then forwarded",
+			rules:    []Rule{{Delay: 10 * time.Millisecond, Times: 1}},
+			method:   http.MethodGet,
+			path:     "/api/v1/version",
+			requests: 1,
+			want:     []answer{{status: http.StatusOK}},
+			wantHits: []int{1},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			upstream := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_, _ = io.WriteString(w, r.Method+" "+r.URL.Path)
+			}))
+			defer upstream.Close()
+			proxy, err := New(upstream.URL)
+			assert.NilError(t, err)
+			faults := []*Fault{}
+			for _, rule := range tt.rules {
+				faults = append(faults, proxy.Inject(rule))
+			}
+			server := httptest.NewServer(proxy)
+			defer server.Close()
+
+			for i := 0; i < tt.requests; i++ {
+				req, err := http.NewRequest(tt.method, server.URL+tt.path, nil)
+				assert.NilError(t, err)
+				resp, err := http.DefaultClient.Do(req)
+				assert.NilError(t, err)
+				body, err := io.ReadAll(resp.Body)
+				resp.Body.Close()
+				assert.NilError(t, err)
+				assert.Equal(t, resp.StatusCode, tt.want[i].status, "request %d", i)
+				assert.Equal(t, resp.Header.Get("Retry-After"), tt.want[i].retryAfter, "request %d", i)
+				if resp.StatusCode == http.StatusOK {
+					assert.Equal(t, string(body), tt.method+" "+tt.path)
+				} else {
+					assert.Assert(t, strings.HasPrefix(string(body), "fault injected"))
+				}
+			}
+			for i, fault := range faults {
+				assert.Equal(t, fault.Hits(), tt.wantHits[i], "fault %d", i)
+			}
+			assert.Equal(t, proxy.Requests(), tt.requests)
+		})
+	}
+}
+
+func TestProxyTimeout(t *testing.T) {
+	upstream := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer upstream.Close()
+	proxy, err := New(upstream.URL)
+	assert.NilError(t, err)
+	fault := proxy.Inject(Rule{Delay: time.Minute, Times: 1})
+	server := httptest.NewServer(proxy)
+	defer server.Close()
+
+	client := &http.Client{Timeout: 50 * time.Millisecond}
+	_, err = client.Get(server.URL)
+	assert.ErrorContains(t, err, "Client.Timeout")
+	assert.Equal(t, fault.Hits(), 1)
+
+	resp, err := client.Get(server.URL)
+	assert.NilError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, resp.StatusCode, http.StatusOK)
+}
+
+func TestProxyToggle(t *testing.T) {
+	upstream := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer upstream.Close()
+	proxy, err := New(upstream.URL)
+	assert.NilError(t, err)
+	server := httptest.NewServer(proxy)
+	defer server.Close()
+
+	get := func() int {
+		resp, err := http.Get(server.URL)
+		assert.NilError(t, err)
+		resp.Body.Close()
+		return resp.StatusCode
+	}
+	fault := proxy.Inject(Rule{StatusCode: http.StatusBadGateway})
+	assert.Equal(t, get(), http.StatusBadGateway)
+	fault.Remove()
+	assert.Equal(t, get(), http.StatusOK)
+	proxy.Inject(Rule{StatusCode: http.StatusServiceUnavailable})
+	assert.Equal(t, get(), http.StatusServiceUnavailable)
+	proxy.Reset()
+	assert.Equal(t, get(), http.StatusOK)
+}
+
+func TestNew(t *testing.T) {
+	_, err := New("localhost")
+	assert.ErrorContains(t, err, "it needs a scheme and a host")
+	_, err = New("http://localhost:3000")
+	assert.NilError(t, err)
+}
@@ -0,0 +1,44 @@
+package gitea
+
+import (
+	"context"
+
+	"code.gitea.io/sdk/gitea"
+	"github.com/openshift-pipelines/pipelines-as-code/test/pkg/gitops"
+)
+
+var _ gitops.CommentDriver = (*CommentDriver)(nil)
+
+// CommentDriver posts and reads the comments of the pull request of a test
+// with the Gitea API.
+type CommentDriver struct {
+	topts *TestOpts
+}
+
+func NewCommentDriver(topts *TestOpts) *CommentDriver {
+	return &CommentDriver{topts: topts}
+}
+
+func (d *CommentDriver) Provider() string {
+	return "gitea"
+}
+
+func (d *CommentDriver) PostComment(_ context.Context, body string) error {
+	_, _, err := d.topts.GiteaCNX.Client().CreateIssueComment(d.topts.Opts.Organization,
+		d.topts.Opts.Repo, d.topts.PullRequest.Index,
+		gitea.CreateIssueCommentOption{Body: body})
+	return err
+}
+
+func (d *CommentDriver) ListComments(_ context.Context) ([]string, error) {
+	comments, _, err := d.topts.GiteaCNX.Client().ListIssueComments(d.topts.PullRequest.Base.Repository.Owner.UserName,
+		d.topts.PullRequest.Base.Repository.Name, d.topts.PullRequest.Index, gitea.ListIssueCommentOptions{})
+	if err != nil {
+		return nil, err
+	}
+	bodies := make([]string, 0, len(comments))
+	for _, comment := range comments {
+		bodies = append(bodies, comment.Body)
+	}
+	return bodies, nil
+}
@@ -0,0 +1,135 @@
+package gitea
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"testing"
+
+	"code.gitea.io/sdk/gitea"
+	"gotest.tools/v3/assert"
+)
+
+// PushFixtureOpts tells where PushFixtureToRefAPI commits the files of a
+// fixture.
+type PushFixtureOpts struct {
+	// BaseBranch is the branch the commit is based on, the default branch
+	// when empty.
+	BaseBranch string
+	// Branch receives the commit, it is created from BaseBranch when it
+	// differs, topts.TargetRefName when empty.
+	Branch string
+	// Branches and Tags are created on the commit.
+	Branches []string
+	Tags     []string
+	Message  string
+}
+
+type changeFileOperation struct {
+	Operation string `json:"operation"`
+	Path      string `json:"path"`
+	Content   string `json:"content"`
+}
+
+type changeFilesOptions struct {
+	gitea.FileOptions
+	Files []changeFileOperation `json:"files"`
+}
+
+// PushFixtureToRefAPI pushes all the entries, ie: the ones of
+// payload.GetFixtureEntries, in a single commit with the Gitea API and creates
+// the branches and tags of opts on it, so a test gets a single push event for
+// a whole monorepo. The files must not exist on the base branch. It returns
+// the SHA of the commit.
+func PushFixtureToRefAPI(t *testing.T, topts *TestOpts, entries map[string]string, opts PushFixtureOpts) string {
+	t.Helper()
+	if opts.BaseBranch == "" {
+		opts.BaseBranch = topts.DefaultBranch
+	}
+	if opts.Branch == "" {
+		opts.Branch = topts.TargetRefName
+	}
+	if opts.Message == "" {
+		opts.Message = fmt.Sprintf("Committing %d fixture files", len(entries))
+	}
+
+	fOpts := changeFilesOptions{
+		FileOptions: gitea.FileOptions{
+			Message:    opts.Message,
+			BranchName: opts.BaseBranch,
+			Author:     gitea.Identity{Name: "OpenShift Pipelines E2E test", Email: "e2e-pipelines@redhat.com"},
+		},
+	}
+	if opts.Branch != opts.BaseBranch {
+		fOpts.NewBranchName = opts.Branch
+	}
+	paths := make([]string, 0, len(entries))
+	for path := range entries {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+	for _, path := range paths {
+		fOpts.Files = append(fOpts.Files, changeFileOperation{
+			Operation: "create",
+			Path:      path,
+			Content:   base64.StdEncoding.EncodeToString([]byte(entries[path])),
+		})
+	}
+	sha, err := changeFiles(context.Background(), topts, fOpts)
+	assert.NilError(t, err)
+	topts.ParamsRun.Clients.Log.Infof("Pushed %d fixture files to branch %s on commit %s", len(entries), opts.Branch, sha)
+
+	client := topts.GiteaCNX.Client()
+	for _, branch := range opts.Branches {
+		_, _, err := client.CreateBranch(topts.Opts.Organization, topts.Opts.Repo, gitea.CreateBranchOption{
+			BranchName:    branch,
+			OldBranchName: opts.Branch,
+		})
+		assert.NilError(t, err)
+	}
+	for _, tag := range opts.Tags {
+		_, _, err := client.CreateTag(topts.Opts.Organization, topts.Opts.Repo, gitea.CreateTagOption{
+			TagName: tag,
+			Message: tag,
+			Target:  sha,
+		})
+		assert.NilError(t, err)
+	}
+	return sha
+}
+
+// changeFiles commits several files at once, the Gitea SDK has no support for
+// this endpoint.
+func changeFiles(ctx context.Context, topts *TestOpts, fOpts changeFilesOptions) (string, error) {
+	body, err := json.Marshal(fOpts)
+	if err != nil {
+		return "", err
+	}
+	url := fmt.Sprintf("%s/api/v1/repos/%s/%s/contents", topts.GiteaAPIURL, topts.Opts.Organization, topts.Opts.Repo)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.SetBasicAuth(topts.Opts.Organization, topts.GiteaPassword)
+	resp, err := topts.ParamsRun.Clients.HTTP.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("error on URL %s: %w", url, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusCreated {
+		return "", fmt.Errorf("failed to push the fixture files: %s", resp.Status)
+	}
+	var fr gitea.FileResponse
+	if err := json.NewDecoder(resp.Body).Decode(&fr); err != nil {
+		return "", err
+	}
+	if fr.Commit == nil {
+		return "", fmt.Errorf("no commit in the response of %s", url)
+	}
+	return fr.Commit.SHA, nil
+}
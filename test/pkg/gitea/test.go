@@ -19,11 +19,13 @@ import (
 	"github.com/openshift-pipelines/pipelines-as-code/pkg/params/info"
 	pgitea "github.com/openshift-pipelines/pipelines-as-code/pkg/provider/gitea"
 	"github.com/openshift-pipelines/pipelines-as-code/test/pkg/cctx"
+	"github.com/openshift-pipelines/pipelines-as-code/test/pkg/gitops"
 	tlogs "github.com/openshift-pipelines/pipelines-as-code/test/pkg/logs"
 	"github.com/openshift-pipelines/pipelines-as-code/test/pkg/options"
 	"github.com/openshift-pipelines/pipelines-as-code/test/pkg/payload"
 	pacrepo "github.com/openshift-pipelines/pipelines-as-code/test/pkg/repository"
 	"github.com/openshift-pipelines/pipelines-as-code/test/pkg/scm"
+	twait "github.com/openshift-pipelines/pipelines-as-code/test/pkg/wait"
 	v1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
 	"github.com/tektoncd/pipeline/pkg/names"
 	"gotest.tools/v3/assert"
@@ -60,17 +62,16 @@ type TestOpts struct {
 	GiteaPassword         string
 	ExpectEvents          bool
 	InternalGiteaURL      string
-	Token                 string
-	SHA                   string
-	FileChanges           []scm.FileChange
+	// GitProviderURL is the API URL set on the Repository instead of
+	// InternalGiteaURL, like the one of a faultinject proxy.
+	GitProviderURL string
+	Token          string
+	SHA            string
+	FileChanges    []scm.FileChange
 }
 
 func PostCommentOnPullRequest(t *testing.T, topt *TestOpts, body string) {
-	_, _, err := topt.GiteaCNX.Client().CreateIssueComment(topt.Opts.Organization,
-		topt.Opts.Repo, topt.PullRequest.Index,
-		gitea.CreateIssueCommentOption{Body: body})
-	topt.ParamsRun.Clients.Log.Infof("Posted comment \"%s\" in %s", body, topt.PullRequest.HTMLURL)
-	assert.NilError(t, err)
+	gitops.PostCommentOnPullRequest(context.Background(), t, topt.ParamsRun, NewCommentDriver(topt), body)
 }
 
 func checkEvents(t *testing.T, events *corev1.EventList, topts *TestOpts) {
@@ -170,6 +171,9 @@ func TestPR(t *testing.T, topts *TestOpts) (context.Context, func()) {
 		URL:    topts.InternalGiteaURL,
 		Secret: &v1alpha1.Secret{Name: topts.TargetNS, Key: "token"},
 	}
+	if topts.GitProviderURL != "" {
+		gp.URL = topts.GitProviderURL
+	}
 	spec := v1alpha1.RepositorySpec{
 		URL:              topts.GitHTMLURL,
 		ConcurrencyLimit: topts.ConcurrencyLimit,
@@ -460,6 +464,20 @@ func WaitForStatus(t *testing.T, topts *TestOpts, ref, forcontext string, onlyla
 	}
 }
 
+// WaitForRepositoryStatus waits for the status of the Repository of the test
+// to have a completed run matching opts, so the tests check the status of the
+// Repository and not only the commit statuses on Gitea.
+func WaitForRepositoryStatus(t *testing.T, topts *TestOpts, opts twait.RepositoryStatusOpts) v1alpha1.RepositoryRunStatus {
+	t.Helper()
+	if opts.Namespace == "" {
+		opts.Namespace = topts.TargetNS
+	}
+	if opts.RepoName == "" {
+		opts.RepoName = topts.TargetNS
+	}
+	return twait.ForRepositoryStatus(context.Background(), t, topts.ParamsRun, opts)
+}
+
 func WaitForSecretDeletion(t *testing.T, topts *TestOpts, _ string) {
 	i := 0
 	for {
@@ -509,23 +527,7 @@ func WaitForSecretDeletion(t *testing.T, topts *TestOpts, _ string) {
 }
 
 func WaitForPullRequestCommentMatch(t *testing.T, topts *TestOpts) {
-	i := 0
-	topts.ParamsRun.Clients.Log.Infof("Looking for regexp \"%s\" in PR comments", topts.Regexp.String())
-	for {
-		comments, _, err := topts.GiteaCNX.Client().ListRepoIssueComments(topts.PullRequest.Base.Repository.Owner.UserName, topts.PullRequest.Base.Repository.Name, gitea.ListIssueCommentOptions{})
-		assert.NilError(t, err)
-		for _, v := range comments {
-			if topts.Regexp.MatchString(v.Body) {
-				topts.ParamsRun.Clients.Log.Infof("Found regexp in comment: %s", v.Body)
-				return
-			}
-		}
-		if i > 60 {
-			t.Fatalf("gitea driver has not been posted any comment")
-		}
-		time.Sleep(2 * time.Second)
-		i++
-	}
+	gitops.WaitForPullRequestCommentMatch(context.Background(), t, topts.ParamsRun, NewCommentDriver(topts), topts.Regexp)
 }
 
 func CheckIfPipelineRunsCancelled(t *testing.T, topts *TestOpts) {
@@ -0,0 +1,45 @@
+package github
+
+import (
+	"context"
+
+	ghlib "github.com/google/go-github/v74/github"
+	"github.com/openshift-pipelines/pipelines-as-code/test/pkg/gitops"
+)
+
+var _ gitops.CommentDriver = (*CommentDriver)(nil)
+
+// CommentDriver posts and reads the comments of the pull request of a test
+// with the GitHub API.
+type CommentDriver struct {
+	g *PRTest
+}
+
+// CommentDriver returns the driver of the comments of the pull request of the
+// test.
+func (g *PRTest) CommentDriver() *CommentDriver {
+	return &CommentDriver{g: g}
+}
+
+func (d *CommentDriver) Provider() string {
+	return "github"
+}
+
+func (d *CommentDriver) PostComment(ctx context.Context, body string) error {
+	_, _, err := d.g.Provider.Client().Issues.CreateComment(ctx, d.g.Options.Organization, d.g.Options.Repo, d.g.PRNumber,
+		&ghlib.IssueComment{Body: ghlib.Ptr(body)})
+	return err
+}
+
+func (d *CommentDriver) ListComments(ctx context.Context) ([]string, error) {
+	comments, _, err := d.g.Provider.Client().Issues.ListComments(ctx, d.g.Options.Organization, d.g.Options.Repo, d.g.PRNumber,
+		&ghlib.IssueListCommentsOptions{ListOptions: ghlib.ListOptions{PerPage: 100}})
+	if err != nil {
+		return nil, err
+	}
+	bodies := make([]string, 0, len(comments))
+	for _, comment := range comments {
+		bodies = append(bodies, comment.GetBody())
+	}
+	return bodies, nil
+}
@@ -0,0 +1,47 @@
+package gitlab
+
+import (
+	"context"
+
+	"github.com/openshift-pipelines/pipelines-as-code/test/pkg/gitops"
+	ghlib "gitlab.com/gitlab-org/api/client-go"
+)
+
+var _ gitops.CommentDriver = (*CommentDriver)(nil)
+
+// CommentDriver posts and reads the notes of a merge request with the GitLab
+// API.
+type CommentDriver struct {
+	client    *ghlib.Client
+	projectID int
+	mrID      int
+}
+
+func NewCommentDriver(client *ghlib.Client, projectID, mrID int) *CommentDriver {
+	return &CommentDriver{client: client, projectID: projectID, mrID: mrID}
+}
+
+func (d *CommentDriver) Provider() string {
+	return "gitlab"
+}
+
+func (d *CommentDriver) PostComment(ctx context.Context, body string) error {
+	_, _, err := d.client.Notes.CreateMergeRequestNote(d.projectID, d.mrID, &ghlib.CreateMergeRequestNoteOptions{
+		Body: ghlib.Ptr(body),
+	}, ghlib.WithContext(ctx))
+	return err
+}
+
+func (d *CommentDriver) ListComments(ctx context.Context) ([]string, error) {
+	notes, _, err := d.client.Notes.ListMergeRequestNotes(d.projectID, d.mrID, &ghlib.ListMergeRequestNotesOptions{
+		ListOptions: ghlib.ListOptions{PerPage: 100},
+	}, ghlib.WithContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+	bodies := make([]string, 0, len(notes))
+	for _, note := range notes {
+		bodies = append(bodies, note.Body)
+	}
+	return bodies, nil
+}
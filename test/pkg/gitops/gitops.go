@@ -0,0 +1,163 @@
+package gitops
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/apis/pipelinesascode/keys"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/formatting"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/kubeinteraction"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/params"
+	"github.com/openshift-pipelines/pipelines-as-code/test/pkg/wait"
+	tektonv1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+	"gotest.tools/v3/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"knative.dev/pkg/apis"
+)
+
+// CommentDriver posts and reads the comments of a pull request on a git
+// provider, so the gitops commands are tested the same way on all of them.
+type CommentDriver interface {
+	// Provider is the name of the git provider, for the logs.
+	Provider() string
+	PostComment(ctx context.Context, body string) error
+	ListComments(ctx context.Context) ([]string, error)
+}
+
+// PostCommentOnPullRequest posts a comment on the pull request of the driver.
+func PostCommentOnPullRequest(ctx context.Context, t *testing.T, runcnx *params.Run, d CommentDriver, body string) {
+	t.Helper()
+	assert.NilError(t, d.PostComment(ctx, body))
+	runcnx.Clients.Log.Infof("Posted comment %q on the %s pull request", body, d.Provider())
+}
+
+// WaitForPullRequestCommentMatch waits for a comment of the pull request of
+// the driver to match the regexp and returns it.
+func WaitForPullRequestCommentMatch(ctx context.Context, t *testing.T, runcnx *params.Run, d CommentDriver, reg *regexp.Regexp) string {
+	t.Helper()
+	runcnx.Clients.Log.Infof("Looking for regexp %q in the %s pull request comments", reg.String(), d.Provider())
+	for i := 0; i <= 60; i++ {
+		comments, err := d.ListComments(ctx)
+		assert.NilError(t, err)
+		for _, comment := range comments {
+			if reg.MatchString(comment) {
+				runcnx.Clients.Log.Infof("Found regexp in comment: %s", comment)
+				return comment
+			}
+		}
+		time.Sleep(2 * time.Second)
+	}
+	t.Fatalf("no comment matching %q has been posted on the %s pull request", reg.String(), d.Provider())
+	return ""
+}
+
+// Env is the pull request the gitops commands are tested on, all its
+// PipelineRuns need to have succeeded.
+type Env struct {
+	Driver   CommentDriver
+	Run      *params.Run
+	TargetNS string
+	SHA      string
+	// PipelineRunName is the name of a PipelineRun of the pull request taking
+	// long enough to be cancelled, ie: pr-gitops-comment of
+	// testdata/pipelinerun-gitops.yaml.
+	PipelineRunName string
+}
+
+// pipelineRuns returns the PipelineRuns of the commit of the pull request,
+// only the ones of a PipelineRun definition when name is not empty, the
+// latest last.
+func (e Env) pipelineRuns(ctx context.Context, name string) ([]tektonv1.PipelineRun, error) {
+	prs, err := e.Run.Clients.Tekton.TektonV1().PipelineRuns(e.TargetNS).List(ctx, metav1.ListOptions{
+		LabelSelector: fmt.Sprintf("%s=%s", keys.SHA, formatting.CleanValueKubernetes(e.SHA)),
+	})
+	if err != nil {
+		return nil, err
+	}
+	runs := []tektonv1.PipelineRun{}
+	for _, pr := range prs.Items {
+		if name != "" && pr.GetAnnotations()[keys.OriginalPRName] != name {
+			continue
+		}
+		runs = append(runs, pr)
+	}
+	sort.SliceStable(runs, func(i, j int) bool {
+		return runs[i].CreationTimestamp.Before(&runs[j].CreationTimestamp)
+	})
+	return runs, nil
+}
+
+// waitForNewRun waits for a PipelineRun of the definition to be created after
+// the count existing ones, until done returns true for it.
+func (e Env) waitForNewRun(ctx context.Context, name string, count int, done func(*tektonv1.PipelineRun) bool) (*tektonv1.PipelineRun, error) {
+	ctx, cancel := context.WithTimeout(ctx, wait.DefaultTimeout)
+	defer cancel()
+	var latest *tektonv1.PipelineRun
+	err := kubeinteraction.PollImmediateWithContext(ctx, wait.DefaultTimeout, func() (bool, error) {
+		runs, err := e.pipelineRuns(ctx, name)
+		if err != nil {
+			return true, err
+		}
+		if len(runs) <= count {
+			e.Run.Clients.Log.Infof("Still waiting for a new PipelineRun of %s: %d/%d", name, len(runs), count+1)
+			time.Sleep(2 * time.Second)
+			return false, nil
+		}
+		latest = &runs[len(runs)-1]
+		if !done(latest) {
+			time.Sleep(2 * time.Second)
+			return false, nil
+		}
+		return true, nil
+	})
+	return latest, err
+}
+
+// RunCommandsMatrix tests the /ok-to-test, /retest and /cancel gitops
+// commands on the pull request of env.
+func RunCommandsMatrix(ctx context.Context, t *testing.T, env Env) {
+	t.Run("ok-to-test skips the succeeded PipelineRuns", func(t *testing.T) {
+		before, err := env.pipelineRuns(ctx, "")
+		assert.NilError(t, err)
+		PostCommentOnPullRequest(ctx, t, env.Run, env.Driver, "/ok-to-test")
+		// give the controller the time to process the comment
+		time.Sleep(20 * time.Second)
+		after, err := env.pipelineRuns(ctx, "")
+		assert.NilError(t, err)
+		assert.Equal(t, len(after), len(before), "no PipelineRun should have been started by /ok-to-test")
+	})
+
+	t.Run("retest a PipelineRun", func(t *testing.T) {
+		before, err := env.pipelineRuns(ctx, env.PipelineRunName)
+		assert.NilError(t, err)
+		PostCommentOnPullRequest(ctx, t, env.Run, env.Driver, "/retest "+env.PipelineRunName)
+		pr, err := env.waitForNewRun(ctx, env.PipelineRunName, len(before), func(pr *tektonv1.PipelineRun) bool {
+			return pr.IsDone()
+		})
+		assert.NilError(t, err)
+		assert.Assert(t, pr.Status.GetCondition(apis.ConditionSucceeded).IsTrue(), "retested PipelineRun %s has not succeeded", pr.GetName())
+	})
+
+	t.Run("cancel a PipelineRun", func(t *testing.T) {
+		before, err := env.pipelineRuns(ctx, env.PipelineRunName)
+		assert.NilError(t, err)
+		PostCommentOnPullRequest(ctx, t, env.Run, env.Driver, "/test "+env.PipelineRunName)
+		pr, err := env.waitForNewRun(ctx, env.PipelineRunName, len(before), func(pr *tektonv1.PipelineRun) bool {
+			return pr.Status.StartTime != nil
+		})
+		assert.NilError(t, err)
+		assert.Assert(t, !pr.IsDone(), "PipelineRun %s has completed before being cancelled", pr.GetName())
+
+		PostCommentOnPullRequest(ctx, t, env.Run, env.Driver, "/cancel "+env.PipelineRunName)
+		pr, err = env.waitForNewRun(ctx, env.PipelineRunName, len(before), func(pr *tektonv1.PipelineRun) bool {
+			return pr.IsDone()
+		})
+		assert.NilError(t, err)
+		// the gitops command cancels the PipelineRuns gracefully, letting their finally tasks run
+		assert.Assert(t, pr.IsGracefullyCancelled(), "PipelineRun %s has not been cancelled: %s", pr.GetName(), pr.Status.GetCondition(apis.ConditionSucceeded).GetReason())
+	})
+}
@@ -0,0 +1,58 @@
+package payload
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// tektonDir is the directory of the fixtures with the PipelineRun templates.
+const tektonDir = ".tekton"
+
+// GetFixtureEntries returns the content of all the files of a fixture
+// directory tree, keyed by their path relative to the directory, to push a
+// realistic repository in a single call. The files of the .tekton directory
+// are templated like the ones of GetEntries, the other ones are pushed as is.
+func GetFixtureEntries(dir, targetNS, targetBranch, targetEvent string, extraParams map[string]string) (map[string]string, error) {
+	templates := map[string]string{}
+	entries := map[string]string{}
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			return nil
+		}
+		rel, err := filepath.Rel(dir, path)
+		if err != nil {
+			return err
+		}
+		target := filepath.ToSlash(rel)
+		if strings.HasPrefix(target, tektonDir+"/") {
+			templates[target] = path
+			return nil
+		}
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		entries[target] = string(content)
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to read fixture directory %s: %w", dir, err)
+	}
+	if extraParams == nil {
+		extraParams = map[string]string{}
+	}
+	tektonEntries, err := GetEntries(templates, targetNS, targetBranch, targetEvent, extraParams)
+	if err != nil {
+		return nil, err
+	}
+	for target, content := range tektonEntries {
+		entries[target] = content
+	}
+	return entries, nil
+}
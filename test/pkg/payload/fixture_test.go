@@ -0,0 +1,29 @@
+package payload
+
+import (
+	"strings"
+	"testing"
+
+	"gotest.tools/v3/assert"
+	"gotest.tools/v3/fs"
+)
+
+func TestGetFixtureEntries(t *testing.T) {
+	dir := fs.NewDir(t, "fixture",
+		fs.WithDir(".tekton",
+			fs.WithFile("pr.yaml", "namespace: \\\\ .TargetNamespace //\nbranch: \\\\ .TargetBranch //\nname: \\\\ .PipelineName //\n")),
+		fs.WithDir("frontend",
+			fs.WithDir("src", fs.WithFile("index.js", "console.log(\"\\\\ not templated //\")\n"))),
+		fs.WithFile("README.md", "# monorepo\n"),
+	)
+
+	entries, err := GetFixtureEntries(dir.Path(), "ns", "main", "pull_request", nil)
+	assert.NilError(t, err)
+	assert.Equal(t, len(entries), 3)
+	assert.Equal(t, entries["README.md"], "# monorepo\n")
+	assert.Equal(t, entries["frontend/src/index.js"], "console.log(\"\\\\ not templated //\")\n")
+	assert.Assert(t, strings.HasPrefix(entries[".tekton/pr.yaml"], "namespace: ns\nbranch: main\nname: pr-"))
+
+	_, err = GetFixtureEntries(dir.Join("missing"), "ns", "main", "pull_request", nil)
+	assert.ErrorContains(t, err, "failed to read fixture directory")
+}
@@ -0,0 +1,131 @@
+package wait
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	pacv1alpha1 "github.com/openshift-pipelines/pipelines-as-code/pkg/apis/pipelinesascode/v1alpha1"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/kubeinteraction"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/params"
+	"github.com/openshift-pipelines/pipelines-as-code/pkg/params/clients"
+	"gotest.tools/v3/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"knative.dev/pkg/apis"
+)
+
+// RepositoryStatusOpts describes the run expected in the status of a
+// Repository, the empty fields match any run.
+type RepositoryStatusOpts struct {
+	Namespace string
+	// RepoName is the name of the Repository, Namespace when empty.
+	RepoName                string
+	EventType               string
+	SHA                     string
+	TargetBranch            string
+	OriginalPipelineRunName string
+	// Status is the expected status of the Succeeded condition of the run,
+	// True when empty.
+	Status corev1.ConditionStatus
+	// Reason is the expected reason of the Succeeded condition, ie: Failed
+	// or Cancelled.
+	Reason      string
+	PollTimeout time.Duration
+}
+
+func strPtrValue(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
+
+// matchRunStatus tells whether a run of the status of a Repository is the
+// expected one, and returns an error when it is but it has not completed the
+// expected way.
+func matchRunStatus(run pacv1alpha1.RepositoryRunStatus, opts RepositoryStatusOpts) (bool, error) {
+	if opts.EventType != "" && strPtrValue(run.EventType) != opts.EventType {
+		return false, nil
+	}
+	if opts.SHA != "" && strPtrValue(run.SHA) != opts.SHA {
+		return false, nil
+	}
+	if opts.OriginalPipelineRunName != "" && run.OriginalPipelineRunName != opts.OriginalPipelineRunName {
+		return false, nil
+	}
+	name := run.PipelineRunName
+	if opts.TargetBranch != "" && strPtrValue(run.TargetBranch) != opts.TargetBranch {
+		return false, fmt.Errorf("run %s has the target branch %q instead of %q", name, strPtrValue(run.TargetBranch), opts.TargetBranch)
+	}
+	if run.CompletionTime == nil {
+		return false, fmt.Errorf("run %s has no completion time", name)
+	}
+	if run.LogURL == nil || *run.LogURL == "" {
+		return false, fmt.Errorf("run %s has no log URL", name)
+	}
+	cond := run.GetCondition(apis.ConditionSucceeded)
+	if cond == nil {
+		return false, fmt.Errorf("run %s has no Succeeded condition", name)
+	}
+	status := opts.Status
+	if status == "" {
+		status = corev1.ConditionTrue
+	}
+	if cond.Status != status {
+		return false, fmt.Errorf("run %s has the status %s instead of %s: %s", name, cond.Status, status, cond.Message)
+	}
+	if opts.Reason != "" && cond.Reason != opts.Reason {
+		return false, fmt.Errorf("run %s has the reason %s instead of %s", name, cond.Reason, opts.Reason)
+	}
+	return true, nil
+}
+
+// UntilRepositoryStatus waits for the status of a Repository to have a run
+// matching opts and returns it. It fails as soon as the matching run has not
+// completed the expected way.
+func UntilRepositoryStatus(ctx context.Context, clients clients.Clients, opts RepositoryStatusOpts) (*pacv1alpha1.RepositoryRunStatus, error) {
+	if opts.RepoName == "" {
+		opts.RepoName = opts.Namespace
+	}
+	if opts.PollTimeout == 0 {
+		opts.PollTimeout = DefaultTimeout
+	}
+	ctx, cancel := context.WithTimeout(ctx, opts.PollTimeout)
+	defer cancel()
+	var found *pacv1alpha1.RepositoryRunStatus
+	err := kubeinteraction.PollImmediateWithContext(ctx, opts.PollTimeout, func() (bool, error) {
+		repo, err := clients.PipelineAsCode.PipelinesascodeV1alpha1().Repositories(opts.Namespace).Get(ctx, opts.RepoName, metav1.GetOptions{})
+		if err != nil {
+			return true, err
+		}
+		// the latest runs are at the end of the status
+		for i := len(repo.Status) - 1; i >= 0; i-- {
+			matched, err := matchRunStatus(repo.Status[i], opts)
+			if err != nil {
+				return true, err
+			}
+			if matched {
+				found = &repo.Status[i]
+				return true, nil
+			}
+		}
+		clients.Log.Infof("Still waiting for a run of event %q and SHA %q in the status of repository %s/%s",
+			opts.EventType, opts.SHA, opts.Namespace, opts.RepoName)
+		time.Sleep(2 * time.Second)
+		return false, nil
+	})
+	return found, err
+}
+
+// ForRepositoryStatus waits for the status of a Repository to have a run
+// matching opts, failing the test otherwise.
+func ForRepositoryStatus(ctx context.Context, t *testing.T, runcnx *params.Run, opts RepositoryStatusOpts) pacv1alpha1.RepositoryRunStatus {
+	t.Helper()
+	run, err := UntilRepositoryStatus(ctx, runcnx.Clients, opts)
+	assert.NilError(t, err)
+	runcnx.Clients.Log.Infof("Run %s of event %s has been found in the status of the repository",
+		run.PipelineRunName, strPtrValue(run.EventType))
+	return *run
+}
@@ -0,0 +1,122 @@
+package wait
+
+import (
+	"testing"
+
+	pacv1alpha1 "github.com/openshift-pipelines/pipelines-as-code/pkg/apis/pipelinesascode/v1alpha1"
+	"gotest.tools/v3/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"knative.dev/pkg/apis"
+	duckv1 "knative.dev/pkg/apis/duck/v1"
+)
+
+func TestMatchRunStatus(t *testing.T) {
+	strp := func(s string) *string { return &s }
+	makeRun := func(status corev1.ConditionStatus, reason string) pacv1alpha1.RepositoryRunStatus {
+		return pacv1alpha1.RepositoryRunStatus{
+			Status: duckv1.Status{Conditions: duckv1.Conditions{
+				{Type: apis.ConditionSucceeded, Status: status, Reason: reason},
+			}},
+			PipelineRunName:         "pr-abcde",
+			OriginalPipelineRunName: "pr",
+			CompletionTime:          &metav1.Time{},
+			SHA:                     strp("sha"),
+			LogURL:                  strp("https://console/pr-abcde"),
+			TargetBranch:            strp("main"),
+			EventType:               strp("pull_request"),
+		}
+	}
+
+	tests := []struct {
+		name        string
+		run         pacv1alpha1.RepositoryRunStatus
+		opts        RepositoryStatusOpts
+		wantMatched bool
+		wantErr     string
+	}{
+		{
+			name:        "match any",
+			run:         makeRun(corev1.ConditionTrue, "Succeeded"),
+			wantMatched: true,
+		},
+		{
+			name:        "match event, sha and branch",
+			run:         makeRun(corev1.ConditionTrue, "Succeeded"),
+			opts:        RepositoryStatusOpts{EventType: "pull_request", SHA: "sha", TargetBranch: "main", OriginalPipelineRunName: "pr"},
+			wantMatched: true,
+		},
+		{
+			name: "other event",
+			run:  makeRun(corev1.ConditionTrue, "Succeeded"),
+			opts: RepositoryStatusOpts{EventType: "push"},
+		},
+		{
+			name: "other sha",
+			run:  makeRun(corev1.ConditionTrue, "Succeeded"),
+			opts: RepositoryStatusOpts{SHA: "other"},
+		},
+		{
+			name: "other pipelinerun",
+			run:  makeRun(corev1.ConditionTrue, "Succeeded"),
+			opts: RepositoryStatusOpts{OriginalPipelineRunName: "other"},
+		},
+		{
+			name:        "expected failure",
+			run:         makeRun(corev1.ConditionFalse, "Failed"),
+			opts:        RepositoryStatusOpts{Status: corev1.ConditionFalse, Reason: "Failed"},
+			wantMatched: true,
+		},
+		{
+			name:    "unexpected failure",
+			run:     makeRun(corev1.ConditionFalse, "Failed"),
+			wantErr: "run pr-abcde has the status False instead of True",
+		},
+		{
+			name:    "unexpected reason",
+			run:     makeRun(corev1.ConditionFalse, "Failed"),
+			opts:    RepositoryStatusOpts{Status: corev1.ConditionFalse, Reason: "Cancelled"},
+			wantErr: "run pr-abcde has the reason Failed instead of Cancelled",
+		},
+		{
+			name:    "unexpected target branch",
+			run:     makeRun(corev1.ConditionTrue, "Succeeded"),
+			opts:    RepositoryStatusOpts{TargetBranch: "release"},
+			wantErr: `run pr-abcde has the target branch "main" instead of "release"`,
+		},
+		{
+			name: "no completion time",
+			run: func() pacv1alpha1.RepositoryRunStatus {
+				run := makeRun(corev1.ConditionTrue, "Succeeded")
+				run.CompletionTime = nil
+				return run
+			}(),
+			wantErr: "run pr-abcde has no completion time",
+		},
+		{
+			name: "no log url",
+			run: func() pacv1alpha1.RepositoryRunStatus {
+				run := makeRun(corev1.ConditionTrue, "Succeeded")
+				run.LogURL = nil
+				return run
+			}(),
+			wantErr: "run pr-abcde has no log URL",
+		},
+		{
+			name:    "no condition",
+			run:     pacv1alpha1.RepositoryRunStatus{PipelineRunName: "pr-abcde", CompletionTime: &metav1.Time{}, LogURL: strp("https://console")},
+			wantErr: "run pr-abcde has no Succeeded condition",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			matched, err := matchRunStatus(tt.run, tt.opts)
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			assert.NilError(t, err)
+			assert.Equal(t, matched, tt.wantMatched)
+		})
+	}
+}